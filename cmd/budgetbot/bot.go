@@ -0,0 +1,639 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// refreshBefore: токен доступа обновляется заранее, чтобы не получить отказ посреди команды
+const refreshBefore = 30 * time.Second
+
+var errSessionExpired = errors.New("session expired")
+
+// Messenger отправляет ответы пользователю
+type Messenger interface {
+	Send(chatID int64, text string) error
+	// SendLink добавляет к сообщению кнопку со ссылкой
+	SendLink(chatID int64, text, label, url string) error
+}
+
+// Clients gRPC-клиенты budgetd
+type Clients struct {
+	OAuth        budgetv1.OAuthServiceClient
+	Auth         budgetv1.AuthServiceClient
+	Categories   budgetv1.CategoryServiceClient
+	Transactions budgetv1.TransactionServiceClient
+	Reports      budgetv1.ReportServiceClient
+}
+
+// Message входящее сообщение, уже без деталей Telegram API
+type Message struct {
+	UserID    int64
+	ChatID    int64
+	FirstName string
+	Text      string
+}
+
+// Bot обрабатывает сообщения: вход через OAuthService, быстрый ввод транзакций и отчеты
+type Bot struct {
+	api     Clients
+	store   *Store
+	out     Messenger
+	log     *zap.SugaredLogger
+	loc     *time.Location
+	locale  string
+	timeout time.Duration
+	now     func() time.Time
+	locks   sync.Map // userID -> *sync.Mutex: сообщения одного пользователя обрабатываются по очереди
+}
+
+func NewBot(api Clients, store *Store, out Messenger, log *zap.SugaredLogger, loc *time.Location, locale string, timeout time.Duration) *Bot {
+	return &Bot{api: api, store: store, out: out, log: log, loc: loc, locale: locale, timeout: timeout, now: time.Now}
+}
+
+// Handle обрабатывает одно сообщение; безопасно вызывать из нескольких горутин
+func (b *Bot) Handle(ctx context.Context, msg Message) {
+	mu, _ := b.locks.LoadOrStore(msg.UserID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+	s := b.store.Get(msg.UserID)
+	text := strings.TrimSpace(msg.Text)
+
+	if strings.HasPrefix(text, "/") {
+		b.handleCommand(ctx, msg, &s, text)
+		return
+	}
+	switch s.State {
+	case stateWaitingEmail:
+		b.handleEmail(ctx, msg, &s, text)
+	case stateWaitingCode:
+		b.handleCode(ctx, msg, &s, text)
+	default:
+		b.handleEntry(ctx, msg, &s, text)
+	}
+}
+
+func (b *Bot) handleCommand(ctx context.Context, msg Message, s *Session, text string) {
+	cmd, args, _ := strings.Cut(text, " ")
+	// в группах команды приходят как /today@budget_bot
+	cmd, _, _ = strings.Cut(cmd, "@")
+	args = strings.TrimSpace(args)
+	switch cmd {
+	case "/start":
+		if s.LoggedIn() {
+			b.reply(msg.ChatID, "С возвращением! Отправьте трату, например «350 кофе», или /help.")
+			return
+		}
+		b.reply(msg.ChatID, "Добро пожаловать в Budget Bot! Используйте /login для авторизации.")
+	case "/help":
+		b.reply(msg.ChatID, helpText)
+	case "/login":
+		b.startLogin(msg, s)
+	case "/cancel":
+		b.cancelLogin(ctx, msg, s)
+	case "/logout":
+		b.logout(ctx, msg, s)
+	case "/today", "/month", "/undo", "/map":
+		if !s.LoggedIn() {
+			b.reply(msg.ChatID, "Сначала авторизуйтесь: /login")
+			return
+		}
+		var err error
+		switch cmd {
+		case "/today":
+			err = b.today(ctx, msg, s)
+		case "/month":
+			err = b.month(ctx, msg, s)
+		case "/undo":
+			err = b.undo(ctx, msg, s)
+		case "/map":
+			err = b.mapAlias(ctx, msg, s, args)
+		}
+		if err != nil {
+			b.fail(msg, s, cmd, err)
+		}
+	default:
+		b.reply(msg.ChatID, "Неизвестная команда. Используйте /help для справки.")
+	}
+}
+
+const helpText = `📋 Быстрый ввод:
+350 кофе – расход 350 в валюте бюджета, категория по слову «кофе»
++50000 зарплата USD – доход 50000 USD
+Слово сопоставляется с кодом или названием категории; свое слово можно привязать через /map.
+
+Команды:
+/today – операции за сегодня
+/month – итоги текущего месяца по категориям
+/undo – удалить последнюю добавленную операцию
+/map слово категория – привязать слово к категории (/map слово – отвязать, /map – список)
+/login – авторизация
+/logout – выход
+/cancel – отмена входа`
+
+// startLogin начинает вход: email -> ссылка в браузер -> код подтверждения
+func (b *Bot) startLogin(msg Message, s *Session) {
+	if s.LoggedIn() {
+		b.reply(msg.ChatID, "Вы уже авторизованы. Используйте /logout, чтобы выйти.")
+		return
+	}
+	if s.State != stateIdle {
+		b.reply(msg.ChatID, "Завершите текущий процесс авторизации или используйте /cancel для отмены.")
+		return
+	}
+	s.State, s.Email, s.AuthToken = stateWaitingEmail, "", ""
+	b.save(msg, s)
+	b.reply(msg.ChatID, "Для авторизации введите ваш email адрес.\n\nЭтот email должен совпадать с email в вашем аккаунте Budget.")
+}
+
+func (b *Bot) handleEmail(ctx context.Context, msg Message, s *Session, email string) {
+	if !strings.Contains(email, "@") {
+		b.reply(msg.ChatID, "Пожалуйста, введите корректный email адрес.")
+		return
+	}
+	resp, err := b.api.OAuth.GenerateAuthLink(ctx, &budgetv1.GenerateAuthLinkRequest{
+		Email:          email,
+		TelegramUserId: strconv.FormatInt(msg.UserID, 10),
+		UserAgent:      "budgetbot",
+	})
+	if err != nil {
+		b.log.Warnw("generate auth link failed", "telegram_user_id", msg.UserID, "error", err)
+		if status.Code(err) == codes.ResourceExhausted {
+			b.reply(msg.ChatID, "Слишком много попыток. Попробуйте позже.")
+			return
+		}
+		b.reply(msg.ChatID, "Ошибка при создании ссылки авторизации. Попробуйте позже.")
+		return
+	}
+	s.State, s.Email, s.AuthToken = stateWaitingCode, email, resp.GetAuthToken()
+	b.save(msg, s)
+	minutes := int(resp.GetExpiresAt().AsTime().Sub(b.now()).Minutes())
+	text := fmt.Sprintf("Ссылка для авторизации создана!\n\n"+
+		"1. Нажмите кнопку «Открыть в браузере»\n"+
+		"2. Войдите в аккаунт Budget\n"+
+		"3. Введите здесь 6-значный код подтверждения\n\n"+
+		"⏰ Ссылка действительна %d мин. Отмена – /cancel", minutes)
+	if err := b.out.SendLink(msg.ChatID, text, "Открыть в браузере", resp.GetAuthUrl()); err != nil {
+		b.log.Warnw("send message failed", "chat_id", msg.ChatID, "error", err)
+	}
+}
+
+func (b *Bot) handleCode(ctx context.Context, msg Message, s *Session, code string) {
+	if len(code) != 6 {
+		b.reply(msg.ChatID, "Код подтверждения должен состоять из 6 цифр. Попробуйте еще раз.")
+		return
+	}
+	resp, err := b.api.OAuth.VerifyAuthCode(ctx, &budgetv1.VerifyAuthCodeRequest{
+		AuthToken:        s.AuthToken,
+		VerificationCode: code,
+		TelegramUserId:   strconv.FormatInt(msg.UserID, 10),
+	})
+	if err != nil {
+		b.log.Infow("verify auth code failed", "telegram_user_id", msg.UserID, "error", err)
+		b.reply(msg.ChatID, "Неверный код подтверждения. Попробуйте еще раз.")
+		return
+	}
+	var tenant *budgetv1.Tenant
+	for _, m := range resp.GetMemberships() {
+		if tenant == nil || m.GetIsDefault() {
+			tenant = m.GetTenant()
+		}
+	}
+	if tenant == nil {
+		s.State, s.Email, s.AuthToken = stateIdle, "", ""
+		b.save(msg, s)
+		b.reply(msg.ChatID, "У аккаунта нет ни одного бюджета. Создайте его в веб-интерфейсе и повторите /login.")
+		return
+	}
+	s.State, s.Email, s.AuthToken = stateIdle, "", ""
+	s.SessionID, s.TenantID, s.Currency = resp.GetSessionId(), tenant.GetId(), tenant.GetDefaultCurrencyCode()
+	setTokens(s, resp.GetTokens())
+	b.save(msg, s)
+	b.reply(msg.ChatID, fmt.Sprintf("✅ Авторизация успешна!\n\nДобро пожаловать, %s! Бюджет: %s (%s).\n"+
+		"Отправьте трату, например «350 кофе», или /help.", msg.FirstName, tenant.GetName(), s.Currency))
+}
+
+func setTokens(s *Session, t *budgetv1.TokenPair) {
+	s.AccessToken, s.RefreshToken = t.GetAccessToken(), t.GetRefreshToken()
+	s.AccessExpiresAt = t.GetAccessTokenExpiresAt().AsTime()
+}
+
+func (b *Bot) cancelLogin(ctx context.Context, msg Message, s *Session) {
+	if s.State == stateIdle {
+		b.reply(msg.ChatID, "Нет активного процесса для отмены.")
+		return
+	}
+	if s.AuthToken != "" {
+		if _, err := b.api.OAuth.CancelAuth(ctx, &budgetv1.CancelAuthRequest{
+			AuthToken:      s.AuthToken,
+			TelegramUserId: strconv.FormatInt(msg.UserID, 10),
+		}); err != nil {
+			b.log.Warnw("cancel auth failed", "telegram_user_id", msg.UserID, "error", err)
+		}
+	}
+	s.State, s.Email, s.AuthToken = stateIdle, "", ""
+	b.save(msg, s)
+	b.reply(msg.ChatID, "Процесс отменен. Используйте /login для новой попытки авторизации.")
+}
+
+// logout отзывает сессию на сервере; локально токены забываются, даже если отзыв не удался
+func (b *Bot) logout(ctx context.Context, msg Message, s *Session) {
+	if s.LoggedIn() && s.SessionID != "" {
+		err := b.authed(ctx, msg, s, func(ctx context.Context) error {
+			_, err := b.api.OAuth.RevokeTelegramSession(ctx, &budgetv1.RevokeTelegramSessionRequest{
+				SessionId:      s.SessionID,
+				TelegramUserId: strconv.FormatInt(msg.UserID, 10),
+			})
+			return err
+		})
+		if err != nil && !errors.Is(err, errSessionExpired) {
+			b.log.Warnw("revoke telegram session failed", "telegram_user_id", msg.UserID, "error", err)
+		}
+	}
+	s.logout()
+	b.save(msg, s)
+	b.reply(msg.ChatID, "Вы вышли из системы. Используйте /login для повторной авторизации.")
+}
+
+// handleEntry создает транзакцию из быстрого ввода
+func (b *Bot) handleEntry(ctx context.Context, msg Message, s *Session, text string) {
+	entry, ok, err := ParseEntry(text)
+	if !ok {
+		if s.LoggedIn() {
+			b.reply(msg.ChatID, "Не понял. Отправьте сумму и категорию, например «350 кофе», или /help.")
+		} else {
+			b.reply(msg.ChatID, "Используйте /login для авторизации или /help для справки.")
+		}
+		return
+	}
+	if !s.LoggedIn() {
+		b.reply(msg.ChatID, "Сначала авторизуйтесь: /login")
+		return
+	}
+	switch {
+	case errors.Is(err, errNoCategoryWord):
+		b.reply(msg.ChatID, "Укажите категорию после суммы, например «350 кофе».")
+		return
+	case err != nil:
+		b.reply(msg.ChatID, "Сумма должна быть больше нуля.")
+		return
+	}
+	if err := b.createFromEntry(ctx, msg, s, entry); err != nil {
+		b.fail(msg, s, "entry", err)
+	}
+}
+
+func (b *Bot) createFromEntry(ctx context.Context, msg Message, s *Session, entry Entry) error {
+	currency := entry.Currency
+	if currency == "" {
+		currency = s.Currency
+	}
+	minor, err := domain.DecimalToMinor(entry.Amount, currency)
+	if err != nil || minor <= 0 {
+		b.reply(msg.ChatID, "Не удалось разобрать сумму.")
+		return nil
+	}
+	var cats []*budgetv1.Category
+	if err := b.authed(ctx, msg, s, func(ctx context.Context) error {
+		resp, err := b.api.Categories.ListCategories(ctx, &budgetv1.ListCategoriesRequest{Kind: entry.Kind(), Locale: b.locale})
+		cats = resp.GetCategories()
+		return err
+	}); err != nil {
+		return err
+	}
+	cat, candidates := ResolveCategory(cats, s.Aliases, entry.Words[0])
+	if cat == nil {
+		b.reply(msg.ChatID, unresolvedText(entry.Words[0], candidates, cats))
+		return nil
+	}
+	var tx *budgetv1.Transaction
+	if err := b.authed(ctx, msg, s, func(ctx context.Context) error {
+		resp, err := b.api.Transactions.CreateTransaction(ctx, &budgetv1.CreateTransactionRequest{
+			Type:       entry.Type(),
+			CategoryId: cat.GetId(),
+			Amount:     &budgetv1.Money{CurrencyCode: currency, MinorUnits: minor},
+			OccurredAt: timestamppb.New(b.now()),
+			Comment:    entry.Comment(),
+		})
+		tx = resp.GetTransaction()
+		return err
+	}); err != nil {
+		return err
+	}
+	s.pushRecent(tx.GetId())
+	b.save(msg, s)
+	kind := "Расход"
+	if entry.Income {
+		kind = "Доход"
+	}
+	b.reply(msg.ChatID, fmt.Sprintf("✅ %s %s · %s\n%s\nОтменить: /undo", kind, formatMoney(tx.GetAmount()), categoryName(cat), entry.Comment()))
+	return nil
+}
+
+func unresolvedText(word string, candidates, all []*budgetv1.Category) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Не знаю категорию «%s».", word)
+	list := candidates
+	if len(list) > 0 {
+		sb.WriteString(" Подходят несколько:")
+	} else {
+		list = all
+		sb.WriteString(" Категории:")
+	}
+	for _, c := range list {
+		fmt.Fprintf(&sb, "\n• %s", categoryName(c))
+	}
+	fmt.Fprintf(&sb, "\n\nПривяжите слово: /map %s <категория>", strings.ToLower(word))
+	return sb.String()
+}
+
+// mapAlias: "/map" – список, "/map слово" – отвязать, "/map слово категория" – привязать
+func (b *Bot) mapAlias(ctx context.Context, msg Message, s *Session, args string) error {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		if len(s.Aliases) == 0 {
+			b.reply(msg.ChatID, "Привязанных слов нет. Пример: /map кофе кафе")
+			return nil
+		}
+		var cats []*budgetv1.Category
+		if err := b.authed(ctx, msg, s, func(ctx context.Context) error {
+			resp, err := b.api.Categories.ListCategories(ctx, &budgetv1.ListCategoriesRequest{Locale: b.locale})
+			cats = resp.GetCategories()
+			return err
+		}); err != nil {
+			return err
+		}
+		names := categoryNames(cats)
+		words := make([]string, 0, len(s.Aliases))
+		for w := range s.Aliases {
+			words = append(words, w)
+		}
+		sort.Strings(words)
+		var sb strings.Builder
+		sb.WriteString("Привязанные слова:")
+		for _, w := range words {
+			name, ok := names[s.Aliases[w]]
+			if !ok {
+				name = "категория удалена"
+			}
+			fmt.Fprintf(&sb, "\n• %s → %s", w, name)
+		}
+		b.reply(msg.ChatID, sb.String())
+		return nil
+	}
+	word := strings.ToLower(fields[0])
+	if len(fields) == 1 {
+		delete(s.Aliases, word)
+		b.save(msg, s)
+		b.reply(msg.ChatID, fmt.Sprintf("Слово «%s» отвязано.", word))
+		return nil
+	}
+	var cats []*budgetv1.Category
+	if err := b.authed(ctx, msg, s, func(ctx context.Context) error {
+		resp, err := b.api.Categories.ListCategories(ctx, &budgetv1.ListCategoriesRequest{Locale: b.locale})
+		cats = resp.GetCategories()
+		return err
+	}); err != nil {
+		return err
+	}
+	target := strings.Join(fields[1:], " ")
+	cat, candidates := ResolveCategory(cats, nil, target)
+	if cat == nil {
+		b.reply(msg.ChatID, unresolvedText(target, candidates, cats))
+		return nil
+	}
+	if s.Aliases == nil {
+		s.Aliases = map[string]string{}
+	}
+	s.Aliases[word] = cat.GetId()
+	b.save(msg, s)
+	b.reply(msg.ChatID, fmt.Sprintf("Готово: «%s» → %s", word, categoryName(cat)))
+	return nil
+}
+
+func categoryNames(cats []*budgetv1.Category) map[string]string {
+	out := make(map[string]string, len(cats))
+	for _, c := range cats {
+		out[c.GetId()] = categoryName(c)
+	}
+	return out
+}
+
+// today показывает операции за сегодня (в часовом поясе бота) и итоги дня
+func (b *Bot) today(ctx context.Context, msg Message, s *Session) error {
+	now := b.now().In(b.loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, b.loc)
+	day := &budgetv1.DateRange{From: timestamppb.New(from), To: timestamppb.New(from.AddDate(0, 0, 1))}
+	var (
+		txs    []*budgetv1.Transaction
+		total  int64
+		totals *budgetv1.GetTransactionsTotalsResponse
+		cats   []*budgetv1.Category
+	)
+	if err := b.authed(ctx, msg, s, func(ctx context.Context) error {
+		list, err := b.api.Transactions.ListTransactions(ctx, &budgetv1.ListTransactionsRequest{
+			DateRange: day,
+			Page:      &budgetv1.PageRequest{Page: 1, PageSize: 30, Sort: "occurred_at asc"},
+		})
+		if err != nil {
+			return err
+		}
+		txs, total = list.GetTransactions(), list.GetPage().GetTotalItems()
+		if totals, err = b.api.Transactions.GetTransactionsTotals(ctx, &budgetv1.GetTransactionsTotalsRequest{DateRange: day}); err != nil {
+			return err
+		}
+		resp, err := b.api.Categories.ListCategories(ctx, &budgetv1.ListCategoriesRequest{Locale: b.locale, IncludeInactive: true})
+		cats = resp.GetCategories()
+		return err
+	}); err != nil {
+		return err
+	}
+	if len(txs) == 0 {
+		b.reply(msg.ChatID, "Сегодня операций нет.")
+		return nil
+	}
+	names := categoryNames(cats)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📅 %s\n", from.Format("02.01.2006"))
+	for _, tx := range txs {
+		sign := "−"
+		if tx.GetType() == budgetv1.TransactionType_TRANSACTION_TYPE_INCOME {
+			sign = "+"
+		}
+		fmt.Fprintf(&sb, "\n%s%s · %s", sign, formatMoney(tx.GetAmount()), names[tx.GetCategoryId()])
+		if c := tx.GetComment(); c != "" {
+			fmt.Fprintf(&sb, " – %s", c)
+		}
+	}
+	if rest := total - int64(len(txs)); rest > 0 {
+		fmt.Fprintf(&sb, "\n…и еще %d", rest)
+	}
+	fmt.Fprintf(&sb, "\n\nДоходы: %s\nРасходы: %s", formatMoney(totals.GetTotalIncome()), formatMoney(totals.GetTotalExpense()))
+	b.reply(msg.ChatID, sb.String())
+	return nil
+}
+
+// monthTopCategories: сколько категорий расходов показывать в /month
+const monthTopCategories = 10
+
+// month показывает итоги текущего месяца из ReportService
+func (b *Bot) month(ctx context.Context, msg Message, s *Session) error {
+	now := b.now().In(b.loc)
+	_, offset := now.Zone()
+	var sum *budgetv1.GetMonthlySummaryResponse
+	if err := b.authed(ctx, msg, s, func(ctx context.Context) error {
+		var err error
+		sum, err = b.api.Reports.GetMonthlySummary(ctx, &budgetv1.GetMonthlySummaryRequest{
+			Year:   int32(now.Year()),
+			Month:  int32(now.Month()),
+			Locale: b.locale,
+			// как JS getTimezoneOffset: минуты, которые нужно прибавить к местному времени, чтобы получить UTC
+			TimezoneOffsetMinutes: int32(-offset / 60),
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+	var expenses []*budgetv1.MonthlyCategorySummaryItem
+	for _, it := range sum.GetItems() {
+		if it.GetType() == budgetv1.TransactionType_TRANSACTION_TYPE_EXPENSE && it.GetTotal().GetMinorUnits() > 0 {
+			expenses = append(expenses, it)
+		}
+	}
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].GetTotal().GetMinorUnits() > expenses[j].GetTotal().GetMinorUnits()
+	})
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📊 %s\n\nДоходы: %s\nРасходы: %s", monthNames[now.Month()-1]+" "+strconv.Itoa(now.Year()),
+		formatMoney(sum.GetTotalIncome()), formatMoney(sum.GetTotalExpense()))
+	if len(expenses) > 0 {
+		sb.WriteString("\n\nКрупнейшие расходы:")
+		for i, it := range expenses {
+			if i == monthTopCategories {
+				fmt.Fprintf(&sb, "\n…и еще %d", len(expenses)-i)
+				break
+			}
+			fmt.Fprintf(&sb, "\n• %s – %s", it.GetCategoryName(), formatMoney(it.GetTotal()))
+		}
+	}
+	b.reply(msg.ChatID, sb.String())
+	return nil
+}
+
+var monthNames = [...]string{"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь", "Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"}
+
+// undo удаляет последнюю транзакцию, созданную ботом (она попадает в корзину budgetd)
+func (b *Bot) undo(ctx context.Context, msg Message, s *Session) error {
+	if len(s.Recent) == 0 {
+		b.reply(msg.ChatID, "Нечего отменять.")
+		return nil
+	}
+	last := s.Recent[len(s.Recent)-1]
+	tenantID := last.TenantID
+	if tenantID == "" { // запись из старого файла состояния
+		tenantID = s.TenantID
+	}
+	err := b.authedIn(ctx, msg, s, tenantID, func(ctx context.Context) error {
+		_, err := b.api.Transactions.DeleteTransaction(ctx, &budgetv1.DeleteTransactionRequest{Id: last.ID})
+		return err
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+	s.Recent = s.Recent[:len(s.Recent)-1]
+	b.save(msg, s)
+	if err != nil {
+		b.reply(msg.ChatID, "Операция уже удалена.")
+		return nil
+	}
+	b.reply(msg.ChatID, "↩️ Последняя операция удалена. Ее можно восстановить из корзины в веб-интерфейсе.")
+	return nil
+}
+
+// authed вызывает call с токеном сессии и бюджетом в метаданных. Истекающий токен обновляется
+// заранее, отказ Unauthenticated – одна попытка обновить токен и повторить вызов.
+func (b *Bot) authed(ctx context.Context, msg Message, s *Session, call func(ctx context.Context) error) error {
+	return b.authedIn(ctx, msg, s, s.TenantID, call)
+}
+
+// authedIn как authed, но обращается к указанному бюджету вместо бюджета сессии
+func (b *Bot) authedIn(ctx context.Context, msg Message, s *Session, tenantID string, call func(ctx context.Context) error) error {
+	refreshed := false
+	if b.now().Add(refreshBefore).After(s.AccessExpiresAt) {
+		if err := b.refresh(ctx, msg, s); err != nil {
+			return err
+		}
+		refreshed = true
+	}
+	for {
+		md := metadata.Pairs("authorization", "Bearer "+s.AccessToken, "x-tenant-id", tenantID)
+		err := call(metadata.NewOutgoingContext(ctx, md))
+		if status.Code(err) != codes.Unauthenticated || refreshed {
+			return err
+		}
+		if err := b.refresh(ctx, msg, s); err != nil {
+			return err
+		}
+		refreshed = true
+	}
+}
+
+// refresh обновляет пару токенов; отказ сервера завершает сессию бота
+func (b *Bot) refresh(ctx context.Context, msg Message, s *Session) error {
+	resp, err := b.api.Auth.RefreshToken(ctx, &budgetv1.RefreshTokenRequest{RefreshToken: s.RefreshToken})
+	if err != nil {
+		if code := status.Code(err); code == codes.Unauthenticated || code == codes.InvalidArgument || code == codes.NotFound {
+			s.logout()
+			b.save(msg, s)
+			return errSessionExpired
+		}
+		return err
+	}
+	setTokens(s, resp.GetTokens())
+	b.save(msg, s)
+	return nil
+}
+
+// fail сообщает пользователю об ошибке вызова budgetd
+func (b *Bot) fail(msg Message, s *Session, op string, err error) {
+	switch {
+	case errors.Is(err, errSessionExpired), status.Code(err) == codes.Unauthenticated:
+		b.reply(msg.ChatID, "Сессия истекла. Авторизуйтесь заново: /login")
+	case status.Code(err) == codes.InvalidArgument || status.Code(err) == codes.FailedPrecondition:
+		b.reply(msg.ChatID, "Не получилось: "+status.Convert(err).Message())
+	case status.Code(err) == codes.PermissionDenied:
+		b.reply(msg.ChatID, "Недостаточно прав в этом бюджете.")
+	default:
+		b.log.Warnw("budget request failed", "op", op, "telegram_user_id", msg.UserID, "tenant_id", s.TenantID, "error", err)
+		b.reply(msg.ChatID, "Сервис недоступен, попробуйте позже.")
+	}
+}
+
+func (b *Bot) save(msg Message, s *Session) {
+	if err := b.store.Put(msg.UserID, *s); err != nil {
+		b.log.Errorw("save bot state failed", "telegram_user_id", msg.UserID, "error", err)
+	}
+}
+
+func (b *Bot) reply(chatID int64, text string) {
+	if err := b.out.Send(chatID, text); err != nil {
+		b.log.Warnw("send message failed", "chat_id", chatID, "error", err)
+	}
+}
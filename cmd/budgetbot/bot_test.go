@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testNow = time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+
+type sentMessage struct {
+	chatID int64
+	text   string
+	url    string
+}
+
+type memMessenger struct{ sent []sentMessage }
+
+func (m *memMessenger) Send(chatID int64, text string) error {
+	m.sent = append(m.sent, sentMessage{chatID: chatID, text: text})
+	return nil
+}
+
+func (m *memMessenger) SendLink(chatID int64, text, _, url string) error {
+	m.sent = append(m.sent, sentMessage{chatID: chatID, text: text, url: url})
+	return nil
+}
+
+func (m *memMessenger) last() string {
+	if len(m.sent) == 0 {
+		return ""
+	}
+	return m.sent[len(m.sent)-1].text
+}
+
+// budgetd is a stand-in for the gRPC services the bot calls; the embedded interfaces panic on
+// anything else
+type budgetd struct {
+	budgetv1.OAuthServiceClient
+	budgetv1.AuthServiceClient
+	budgetv1.CategoryServiceClient
+	budgetv1.TransactionServiceClient
+	budgetv1.ReportServiceClient
+
+	validToken string // the access token the services accept
+	refreshes  int
+	created    []*budgetv1.CreateTransactionRequest
+	deleted    []string
+	monthReq   *budgetv1.GetMonthlySummaryRequest
+	listReq    *budgetv1.ListTransactionsRequest
+}
+
+func (d *budgetd) checkAuth(ctx context.Context) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if auth := md.Get("authorization"); len(auth) == 0 || auth[0] != "Bearer "+d.validToken {
+		return status.Error(codes.Unauthenticated, "missing or invalid access token")
+	}
+	if tenant := md.Get("x-tenant-id"); len(tenant) == 0 || tenant[0] != "t1" {
+		return status.Error(codes.PermissionDenied, "no tenant")
+	}
+	return nil
+}
+
+func (d *budgetd) GenerateAuthLink(_ context.Context, req *budgetv1.GenerateAuthLinkRequest, _ ...grpc.CallOption) (*budgetv1.GenerateAuthLinkResponse, error) {
+	if req.GetTelegramUserId() != "7" {
+		return nil, status.Error(codes.InvalidArgument, "telegram_user_id")
+	}
+	return &budgetv1.GenerateAuthLinkResponse{AuthUrl: "https://budget.example.com/oauth/authorize?token=link", AuthToken: "link", ExpiresAt: timestamppb.New(testNow.Add(15 * time.Minute))}, nil
+}
+
+func (d *budgetd) VerifyAuthCode(_ context.Context, req *budgetv1.VerifyAuthCodeRequest, _ ...grpc.CallOption) (*budgetv1.VerifyAuthCodeResponse, error) {
+	if req.GetAuthToken() != "link" || req.GetVerificationCode() != "123456" {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+	return &budgetv1.VerifyAuthCodeResponse{
+		Tokens:    &budgetv1.TokenPair{AccessToken: "at1", RefreshToken: "rt1", AccessTokenExpiresAt: timestamppb.New(testNow.Add(15 * time.Minute))},
+		SessionId: "s1",
+		Memberships: []*budgetv1.TenantMembership{
+			{Tenant: &budgetv1.Tenant{Id: "t0", Name: "Work", DefaultCurrencyCode: "USD"}},
+			{Tenant: &budgetv1.Tenant{Id: "t1", Name: "Home", DefaultCurrencyCode: "RUB"}, IsDefault: true},
+		},
+	}, nil
+}
+
+func (d *budgetd) RevokeTelegramSession(ctx context.Context, _ *budgetv1.RevokeTelegramSessionRequest, _ ...grpc.CallOption) (*budgetv1.RevokeTelegramSessionResponse, error) {
+	return &budgetv1.RevokeTelegramSessionResponse{}, d.checkAuth(ctx)
+}
+
+func (d *budgetd) RefreshToken(_ context.Context, req *budgetv1.RefreshTokenRequest, _ ...grpc.CallOption) (*budgetv1.RefreshTokenResponse, error) {
+	if req.GetRefreshToken() != "rt1" {
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	d.refreshes++
+	return &budgetv1.RefreshTokenResponse{Tokens: &budgetv1.TokenPair{AccessToken: d.validToken, RefreshToken: "rt1", AccessTokenExpiresAt: timestamppb.New(testNow.Add(time.Hour))}}, nil
+}
+
+func (d *budgetd) ListCategories(ctx context.Context, req *budgetv1.ListCategoriesRequest, _ ...grpc.CallOption) (*budgetv1.ListCategoriesResponse, error) {
+	if err := d.checkAuth(ctx); err != nil {
+		return nil, err
+	}
+	expense := []*budgetv1.Category{category("cafe", "cafe", "Кафе"), category("transport", "transport", "Транспорт")}
+	income := []*budgetv1.Category{category("salary", "salary", "Зарплата")}
+	switch req.GetKind() {
+	case budgetv1.CategoryKind_CATEGORY_KIND_EXPENSE:
+		return &budgetv1.ListCategoriesResponse{Categories: expense}, nil
+	case budgetv1.CategoryKind_CATEGORY_KIND_INCOME:
+		return &budgetv1.ListCategoriesResponse{Categories: income}, nil
+	}
+	return &budgetv1.ListCategoriesResponse{Categories: append(expense, income...)}, nil
+}
+
+func (d *budgetd) CreateTransaction(ctx context.Context, req *budgetv1.CreateTransactionRequest, _ ...grpc.CallOption) (*budgetv1.CreateTransactionResponse, error) {
+	if err := d.checkAuth(ctx); err != nil {
+		return nil, err
+	}
+	d.created = append(d.created, req)
+	return &budgetv1.CreateTransactionResponse{Transaction: &budgetv1.Transaction{Id: "tx" + string(rune('0'+len(d.created))), Amount: req.GetAmount(), CategoryId: req.GetCategoryId()}}, nil
+}
+
+func (d *budgetd) DeleteTransaction(ctx context.Context, req *budgetv1.DeleteTransactionRequest, _ ...grpc.CallOption) (*budgetv1.DeleteTransactionResponse, error) {
+	if err := d.checkAuth(ctx); err != nil {
+		return nil, err
+	}
+	d.deleted = append(d.deleted, req.GetId())
+	return &budgetv1.DeleteTransactionResponse{}, nil
+}
+
+func (d *budgetd) ListTransactions(ctx context.Context, req *budgetv1.ListTransactionsRequest, _ ...grpc.CallOption) (*budgetv1.ListTransactionsResponse, error) {
+	if err := d.checkAuth(ctx); err != nil {
+		return nil, err
+	}
+	d.listReq = req
+	return &budgetv1.ListTransactionsResponse{
+		Transactions: []*budgetv1.Transaction{
+			{Id: "a", Type: budgetv1.TransactionType_TRANSACTION_TYPE_EXPENSE, CategoryId: "cafe", Amount: &budgetv1.Money{CurrencyCode: "RUB", MinorUnits: 35000}, Comment: "coffee"},
+			{Id: "b", Type: budgetv1.TransactionType_TRANSACTION_TYPE_INCOME, CategoryId: "salary", Amount: &budgetv1.Money{CurrencyCode: "USD", MinorUnits: 5000000}},
+		},
+		Page: &budgetv1.PageResponse{TotalItems: 2},
+	}, nil
+}
+
+func (d *budgetd) GetTransactionsTotals(ctx context.Context, _ *budgetv1.GetTransactionsTotalsRequest, _ ...grpc.CallOption) (*budgetv1.GetTransactionsTotalsResponse, error) {
+	return &budgetv1.GetTransactionsTotalsResponse{
+		TotalIncome:  &budgetv1.Money{CurrencyCode: "RUB", MinorUnits: 450000000},
+		TotalExpense: &budgetv1.Money{CurrencyCode: "RUB", MinorUnits: 35000},
+	}, d.checkAuth(ctx)
+}
+
+func (d *budgetd) GetMonthlySummary(ctx context.Context, req *budgetv1.GetMonthlySummaryRequest, _ ...grpc.CallOption) (*budgetv1.GetMonthlySummaryResponse, error) {
+	if err := d.checkAuth(ctx); err != nil {
+		return nil, err
+	}
+	d.monthReq = req
+	rub := func(minor int64) *budgetv1.Money { return &budgetv1.Money{CurrencyCode: "RUB", MinorUnits: minor} }
+	return &budgetv1.GetMonthlySummaryResponse{
+		Items: []*budgetv1.MonthlyCategorySummaryItem{
+			{CategoryName: "Транспорт", Type: budgetv1.TransactionType_TRANSACTION_TYPE_EXPENSE, Total: rub(120000)},
+			{CategoryName: "Зарплата", Type: budgetv1.TransactionType_TRANSACTION_TYPE_INCOME, Total: rub(9000000)},
+			{CategoryName: "Кафе", Type: budgetv1.TransactionType_TRANSACTION_TYPE_EXPENSE, Total: rub(350000)},
+		},
+		TotalIncome:  rub(9000000),
+		TotalExpense: rub(470000),
+	}, nil
+}
+
+func newTestBot(t *testing.T) (*Bot, *budgetd, *memMessenger, *Store) {
+	t.Helper()
+	store, err := OpenStore(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	api := &budgetd{validToken: "at1"}
+	out := &memMessenger{}
+	loc := time.FixedZone("MSK", 3*60*60)
+	b := NewBot(Clients{OAuth: api, Auth: api, Categories: api, Transactions: api, Reports: api}, store, out, zap.NewNop().Sugar(), loc, "ru", time.Second)
+	b.now = func() time.Time { return testNow }
+	return b, api, out, store
+}
+
+func send(b *Bot, text string) {
+	b.Handle(context.Background(), Message{UserID: 7, ChatID: 70, FirstName: "Ann", Text: text})
+}
+
+func login(t *testing.T, b *Bot, out *memMessenger) {
+	t.Helper()
+	send(b, "/login")
+	send(b, "ann@example.com")
+	if got := out.sent[len(out.sent)-1]; got.url != "https://budget.example.com/oauth/authorize?token=link" || !strings.Contains(got.text, "15 мин") {
+		t.Fatalf("unexpected link message %#v", got)
+	}
+	send(b, "123456")
+	if !strings.Contains(out.last(), "Авторизация успешна") || !strings.Contains(out.last(), "Home (RUB)") {
+		t.Fatalf("unexpected login reply %q", out.last())
+	}
+}
+
+func TestBot_LoginPersistsSession(t *testing.T) {
+	b, _, out, store := newTestBot(t)
+	send(b, "350 coffee")
+	if !strings.Contains(out.last(), "/login") {
+		t.Fatalf("entry before login: %q", out.last())
+	}
+	send(b, "/login")
+	send(b, "not an email")
+	if s := store.Get(7); s.State != stateWaitingEmail {
+		t.Fatalf("state after a bad email: %q", s.State)
+	}
+	send(b, "ann@example.com")
+	send(b, "000000")
+	if !strings.Contains(out.last(), "Неверный код") {
+		t.Fatalf("wrong code: %q", out.last())
+	}
+	send(b, "123456")
+
+	reopened, err := OpenStore(store.path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	s := reopened.Get(7)
+	if !s.LoggedIn() || s.TenantID != "t1" || s.Currency != "RUB" || s.SessionID != "s1" || s.State != stateIdle || s.AuthToken != "" {
+		t.Fatalf("unexpected persisted session %#v", s)
+	}
+}
+
+func TestBot_QuickEntryAndUndo(t *testing.T) {
+	b, api, out, store := newTestBot(t)
+	login(t, b, out)
+
+	send(b, "350 кафе")
+	if len(api.created) != 1 {
+		t.Fatalf("expected a transaction, got %d", len(api.created))
+	}
+	req := api.created[0]
+	if req.GetType() != budgetv1.TransactionType_TRANSACTION_TYPE_EXPENSE || req.GetCategoryId() != "cafe" ||
+		req.GetAmount().GetCurrencyCode() != "RUB" || req.GetAmount().GetMinorUnits() != 35000 || req.GetComment() != "кафе" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	if !strings.Contains(out.last(), "350.00 RUB") || !strings.Contains(out.last(), "Кафе") {
+		t.Fatalf("unexpected reply %q", out.last())
+	}
+
+	send(b, "+50000 зарплата USD")
+	if req := api.created[1]; req.GetType() != budgetv1.TransactionType_TRANSACTION_TYPE_INCOME || req.GetCategoryId() != "salary" || req.GetAmount().GetMinorUnits() != 5000000 || req.GetAmount().GetCurrencyCode() != "USD" {
+		t.Fatalf("unexpected income request %+v", req)
+	}
+
+	// an unknown word is mapped once and remembered
+	send(b, "120 coffee")
+	if len(api.created) != 2 || !strings.Contains(out.last(), "/map coffee") {
+		t.Fatalf("unknown word: %d created, reply %q", len(api.created), out.last())
+	}
+	send(b, "/map coffee кафе")
+	send(b, "120 coffee beans")
+	if len(api.created) != 3 || api.created[2].GetCategoryId() != "cafe" || api.created[2].GetComment() != "coffee beans" {
+		t.Fatalf("alias not applied: %+v", api.created)
+	}
+	s := store.Get(7)
+	if s.Aliases["coffee"] != "cafe" || len(s.Recent) != 3 {
+		t.Fatalf("unexpected session %#v", s)
+	}
+	// undo deletes in the budget the transaction was created in, not the current one
+	s.TenantID = "t2"
+	if err := store.Put(7, s); err != nil {
+		t.Fatal(err)
+	}
+
+	send(b, "/undo")
+	send(b, "/undo")
+	if len(api.deleted) != 2 || api.deleted[0] != "tx3" || api.deleted[1] != "tx2" {
+		t.Fatalf("unexpected deletions %v", api.deleted)
+	}
+	if s := store.Get(7); len(s.Recent) != 1 {
+		t.Fatalf("undo didn't pop: %v", s.Recent)
+	}
+}
+
+func TestBot_TodayAndMonth(t *testing.T) {
+	b, api, out, _ := newTestBot(t)
+	login(t, b, out)
+
+	send(b, "/today")
+	// testNow is 15:00 in MSK: the day starts at 21:00 UTC the day before
+	if from := api.listReq.GetDateRange().GetFrom().AsTime(); !from.Equal(time.Date(2025, 3, 19, 21, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected day start %v", from)
+	}
+	for _, want := range []string{"20.03.2025", "−350.00 RUB · Кафе – coffee", "+50000.00 USD · Зарплата", "Расходы: 350.00 RUB"} {
+		if !strings.Contains(out.last(), want) {
+			t.Fatalf("missing %q in:\n%s", want, out.last())
+		}
+	}
+
+	send(b, "/month")
+	if api.monthReq.GetYear() != 2025 || api.monthReq.GetMonth() != 3 || api.monthReq.GetTimezoneOffsetMinutes() != -180 || api.monthReq.GetLocale() != "ru" {
+		t.Fatalf("unexpected summary request %+v", api.monthReq)
+	}
+	got := out.last()
+	if !strings.Contains(got, "Март 2025") || !strings.Contains(got, "Расходы: 4700.00 RUB") || strings.Index(got, "Кафе") > strings.Index(got, "Транспорт") || strings.Contains(got, "• Зарплата") {
+		t.Fatalf("unexpected month reply:\n%s", got)
+	}
+}
+
+func TestBot_RefreshesExpiredToken(t *testing.T) {
+	b, api, out, store := newTestBot(t)
+	login(t, b, out)
+	api.validToken = "at2" // the server no longer accepts at1
+
+	send(b, "/today")
+	if api.refreshes != 1 || store.Get(7).AccessToken != "at2" {
+		t.Fatalf("token not refreshed: %d refreshes", api.refreshes)
+	}
+	if !strings.Contains(out.last(), "Кафе") {
+		t.Fatalf("call not retried after refresh: %q", out.last())
+	}
+
+	// a rejected refresh token ends the session
+	s := store.Get(7)
+	s.RefreshToken, s.AccessExpiresAt = "revoked", testNow
+	if err := store.Put(7, s); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	send(b, "/month")
+	if !strings.Contains(out.last(), "Сессия истекла") || store.Get(7).LoggedIn() {
+		t.Fatalf("expired session kept: %q", out.last())
+	}
+}
+
+func TestBot_Logout(t *testing.T) {
+	b, _, out, store := newTestBot(t)
+	login(t, b, out)
+	send(b, "/map кофе кафе")
+	send(b, "/logout")
+	s := store.Get(7)
+	if s.LoggedIn() || s.Aliases["кофе"] != "cafe" {
+		t.Fatalf("unexpected session after logout %#v", s)
+	}
+	send(b, "/today")
+	if !strings.Contains(out.last(), "/login") {
+		t.Fatalf("command after logout: %q", out.last())
+	}
+}
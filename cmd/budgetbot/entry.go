@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+)
+
+// Entry быстрый ввод транзакции: "350 кофе", "+50000 зарплата USD"
+type Entry struct {
+	Income   bool
+	Amount   string   // десятичная запись суммы с точкой, больше нуля
+	Currency string   // пусто – валюта тенанта по умолчанию
+	Words    []string // первое слово определяет категорию, все вместе – комментарий
+}
+
+var (
+	amountRe   = regexp.MustCompile(`^([+-]?)(\d+(?:[.,]\d+)?)$`)
+	currencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+	errNoCategoryWord = errors.New("no category word")
+)
+
+// ParseEntry разбирает быстрый ввод; ok=false – текст не начинается с суммы и не является вводом.
+// "+" перед суммой – доход, иначе расход. Код валюты (заглавными) стоит сразу после суммы или в конце.
+func ParseEntry(text string) (Entry, bool, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return Entry{}, false, nil
+	}
+	// знак можно отделить пробелом: "+ 50000 зарплата"
+	if (fields[0] == "+" || fields[0] == "-") && len(fields) > 1 {
+		fields = append([]string{fields[0] + fields[1]}, fields[2:]...)
+	}
+	m := amountRe.FindStringSubmatch(fields[0])
+	if m == nil {
+		return Entry{}, false, nil
+	}
+	e := Entry{Income: m[1] == "+", Amount: strings.Replace(m[2], ",", ".", 1)}
+	if strings.Trim(e.Amount, "0.") == "" {
+		return Entry{}, true, domain.ErrInvalidAmount
+	}
+	rest := fields[1:]
+	if len(rest) > 0 && isCurrency(rest[0]) {
+		e.Currency, rest = rest[0], rest[1:]
+	} else if len(rest) > 0 && isCurrency(rest[len(rest)-1]) {
+		e.Currency, rest = rest[len(rest)-1], rest[:len(rest)-1]
+	}
+	if len(rest) == 0 {
+		return Entry{}, true, errNoCategoryWord
+	}
+	e.Words = rest
+	return e, true, nil
+}
+
+func isCurrency(s string) bool {
+	return currencyRe.MatchString(s) && domain.IsCurrencyCode(s)
+}
+
+func (e Entry) Type() budgetv1.TransactionType {
+	if e.Income {
+		return budgetv1.TransactionType_TRANSACTION_TYPE_INCOME
+	}
+	return budgetv1.TransactionType_TRANSACTION_TYPE_EXPENSE
+}
+
+func (e Entry) Kind() budgetv1.CategoryKind {
+	if e.Income {
+		return budgetv1.CategoryKind_CATEGORY_KIND_INCOME
+	}
+	return budgetv1.CategoryKind_CATEGORY_KIND_EXPENSE
+}
+
+func (e Entry) Comment() string { return strings.Join(e.Words, " ") }
+
+// ResolveCategory находит категорию по слову: сначала словарь пользователя (/map), затем точное
+// совпадение с кодом или названием категории, затем единственная категория, начинающаяся с
+// этого слова. Без результата возвращает подходящих кандидатов (возможно, ни одного).
+func ResolveCategory(cats []*budgetv1.Category, aliases map[string]string, word string) (*budgetv1.Category, []*budgetv1.Category) {
+	key := strings.ToLower(strings.TrimSpace(word))
+	if key == "" {
+		return nil, nil
+	}
+	if id, ok := aliases[key]; ok {
+		for _, c := range cats {
+			if c.GetId() == id {
+				return c, nil
+			}
+		}
+	}
+	var exact, prefix []*budgetv1.Category
+	for _, c := range cats {
+		names := []string{c.GetCode()}
+		for _, tr := range c.GetTranslations() {
+			names = append(names, tr.GetName())
+		}
+		isExact, isPrefix := false, false
+		for _, n := range names {
+			n = strings.ToLower(n)
+			if n == "" {
+				continue
+			}
+			if n == key {
+				isExact = true
+			} else if strings.HasPrefix(n, key) {
+				isPrefix = true
+			}
+		}
+		switch {
+		case isExact:
+			exact = append(exact, c)
+		case isPrefix:
+			prefix = append(prefix, c)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0], nil
+	}
+	if len(exact) > 1 {
+		return nil, exact
+	}
+	if len(prefix) == 1 && len([]rune(key)) >= 3 {
+		return prefix[0], nil
+	}
+	return nil, prefix
+}
+
+// categoryName название категории в локали запроса (ListCategories ставит ее первой), иначе код
+func categoryName(c *budgetv1.Category) string {
+	if tr := c.GetTranslations(); len(tr) > 0 && tr[0].GetName() != "" {
+		return tr[0].GetName()
+	}
+	return c.GetCode()
+}
+
+func formatMoney(m *budgetv1.Money) string {
+	return domain.MinorToDecimal(m.GetMinorUnits(), m.GetCurrencyCode()) + " " + m.GetCurrencyCode()
+}
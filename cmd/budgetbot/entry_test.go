@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+)
+
+func TestParseEntry(t *testing.T) {
+	cases := []struct {
+		text string
+		want Entry
+	}{
+		{"350 coffee", Entry{Amount: "350", Words: []string{"coffee"}}},
+		{"+50000 salary USD", Entry{Income: true, Amount: "50000", Currency: "USD", Words: []string{"salary"}}},
+		{"  12,5 EUR taxi to airport ", Entry{Amount: "12.5", Currency: "EUR", Words: []string{"taxi", "to", "airport"}}},
+		{"+ 100 gift", Entry{Income: true, Amount: "100", Words: []string{"gift"}}},
+		{"-99.90 кафе", Entry{Amount: "99.90", Words: []string{"кафе"}}},
+		// lower case three-letter words are words, not currencies
+		{"40 coffee cup", Entry{Amount: "40", Words: []string{"coffee", "cup"}}},
+	}
+	for _, c := range cases {
+		got, ok, err := ParseEntry(c.text)
+		if !ok || err != nil {
+			t.Errorf("%q: ok=%v err=%v", c.text, ok, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%q: got %#v, want %#v", c.text, got, c.want)
+		}
+	}
+
+	for _, text := range []string{"", "hello", "coffee 350", "1.2.3 x", "/today"} {
+		if _, ok, _ := ParseEntry(text); ok {
+			t.Errorf("%q parsed as an entry", text)
+		}
+	}
+	if _, ok, err := ParseEntry("350 USD"); !ok || !errors.Is(err, errNoCategoryWord) {
+		t.Errorf("missing category: ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := ParseEntry("0.00 coffee"); !ok || !errors.Is(err, domain.ErrInvalidAmount) {
+		t.Errorf("zero amount: ok=%v err=%v", ok, err)
+	}
+}
+
+func category(id, code string, names ...string) *budgetv1.Category {
+	c := &budgetv1.Category{Id: id, Code: code}
+	for _, n := range names {
+		c.Translations = append(c.Translations, &budgetv1.CategoryTranslation{Locale: "ru", Name: n})
+	}
+	return c
+}
+
+func TestResolveCategory(t *testing.T) {
+	cats := []*budgetv1.Category{
+		category("c1", "cafe", "Кафе"),
+		category("c2", "transport", "Транспорт"),
+		category("c3", "travel", "Путешествия"),
+		category("c4", "groceries", "Продукты"),
+	}
+	aliases := map[string]string{"coffee": "c1", "stale": "gone"}
+	cases := map[string]string{
+		"coffee":  "c1", // alias
+		"CAFE":    "c1", // code, any case
+		"кафе":    "c1", // translation
+		"прод":    "c4", // unique prefix
+		"transp":  "c2",
+		"stale":   "", // alias to a missing category
+		"tr":      "", // ambiguous prefix
+		"unknown": "",
+	}
+	for word, want := range cases {
+		got, _ := ResolveCategory(cats, aliases, word)
+		if got.GetId() != want {
+			t.Errorf("%q: got %q, want %q", word, got.GetId(), want)
+		}
+	}
+	if _, candidates := ResolveCategory(cats, nil, "tra"); len(candidates) != 2 {
+		t.Errorf("expected two candidates for an ambiguous prefix, got %d", len(candidates))
+	}
+}
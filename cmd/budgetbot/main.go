@@ -0,0 +1,90 @@
+// Command budgetbot is the Telegram bot of Budget: OAuth login through budgetd, quick entry of
+// transactions and short reports. State lives in a JSON file (BOT_STATE_FILE).
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/pkg/config"
+	"github.com/positron48/budget/internal/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	cfg, err := config.LoadBotConfig()
+	if err != nil {
+		panic(err)
+	}
+	lg, err := logger.New(cfg.AppEnv)
+	if err != nil {
+		panic(err)
+	}
+	defer lg.Sync() //nolint:errcheck
+	sug := lg.Sugar()
+
+	store, err := OpenStore(cfg.StateFile)
+	if err != nil {
+		sug.Fatalw("failed to open bot state", "file", cfg.StateFile, "error", err)
+	}
+	conn, err := grpc.NewClient(cfg.BudgetGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		sug.Fatalw("failed to connect to budgetd", "addr", cfg.BudgetGRPCAddr, "error", err)
+	}
+	defer conn.Close()
+
+	api, err := tgbotapi.NewBotAPIWithAPIEndpoint(cfg.TelegramBotToken, strings.TrimRight(cfg.TelegramAPIURL, "/")+"/bot%s/%s")
+	if err != nil {
+		sug.Fatalw("failed to create bot", "error", err)
+	}
+	bot := NewBot(Clients{
+		OAuth:        budgetv1.NewOAuthServiceClient(conn),
+		Auth:         budgetv1.NewAuthServiceClient(conn),
+		Categories:   budgetv1.NewCategoryServiceClient(conn),
+		Transactions: budgetv1.NewTransactionServiceClient(conn),
+		Reports:      budgetv1.NewReportServiceClient(conn),
+	}, store, telegramMessenger{api}, sug, cfg.Location, cfg.Locale, cfg.RequestTimeout)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	u := tgbotapi.NewUpdate(0)
+	u.Timeout = 60
+	updates := api.GetUpdatesChan(u)
+	sug.Infow("bot started", "username", api.Self.UserName, "budgetd", cfg.BudgetGRPCAddr)
+	for {
+		select {
+		case <-ctx.Done():
+			api.StopReceivingUpdates()
+			sug.Infow("bot stopped")
+			return
+		case update := <-updates:
+			m := update.Message
+			if m == nil || m.From == nil {
+				continue
+			}
+			go bot.Handle(ctx, Message{UserID: m.From.ID, ChatID: m.Chat.ID, FirstName: m.From.FirstName, Text: m.Text})
+		}
+	}
+}
+
+// telegramMessenger отправляет ответы через Bot API
+type telegramMessenger struct{ api *tgbotapi.BotAPI }
+
+func (t telegramMessenger) Send(chatID int64, text string) error {
+	_, err := t.api.Send(tgbotapi.NewMessage(chatID, text))
+	return err
+}
+
+func (t telegramMessenger) SendLink(chatID int64, text, label, url string) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonURL(label, url)))
+	_, err := t.api.Send(msg)
+	return err
+}
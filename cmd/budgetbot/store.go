@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Состояния диалога входа
+const (
+	stateIdle         = "idle"
+	stateWaitingEmail = "waiting_email"
+	stateWaitingCode  = "waiting_code"
+)
+
+// maxRecent: сколько последних транзакций бота можно отменить через /undo
+const maxRecent = 20
+
+// Session состояние пользователя Telegram: диалог входа, токены budgetd и словарь категорий
+type Session struct {
+	State     string `json:"state"`
+	Email     string `json:"email,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"` // токен ссылки входа, пока ждем код
+
+	SessionID       string    `json:"session_id,omitempty"`
+	TenantID        string    `json:"tenant_id,omitempty"`
+	Currency        string    `json:"currency,omitempty"` // валюта тенанта по умолчанию
+	AccessToken     string    `json:"access_token,omitempty"`
+	RefreshToken    string    `json:"refresh_token,omitempty"`
+	AccessExpiresAt time.Time `json:"access_expires_at,omitempty"`
+
+	// Recent: транзакции, созданные ботом, последняя в конце
+	Recent []RecentTx `json:"recent,omitempty"`
+	// Aliases: слово быстрого ввода (в нижнем регистре) -> id категории
+	Aliases map[string]string `json:"aliases,omitempty"`
+}
+
+// RecentTx транзакция бота вместе с бюджетом, в котором она создана: /undo удаляет ее
+// там же, даже если бюджет сессии с тех пор сменился
+type RecentTx struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenant_id"`
+}
+
+// UnmarshalJSON принимает и старый формат файла состояния, где хранился только id
+func (r *RecentTx) UnmarshalJSON(b []byte) error {
+	var id string
+	if err := json.Unmarshal(b, &id); err == nil {
+		*r = RecentTx{ID: id}
+		return nil
+	}
+	type plain RecentTx
+	return json.Unmarshal(b, (*plain)(r))
+}
+
+func (s Session) LoggedIn() bool { return s.AccessToken != "" }
+
+// logout забывает токены и диалог входа, словарь категорий остается
+func (s *Session) logout() {
+	aliases := s.Aliases
+	*s = Session{State: stateIdle, Aliases: aliases}
+}
+
+func (s *Session) pushRecent(id string) {
+	s.Recent = append(s.Recent, RecentTx{ID: id, TenantID: s.TenantID})
+	if len(s.Recent) > maxRecent {
+		s.Recent = s.Recent[len(s.Recent)-maxRecent:]
+	}
+}
+
+func (s Session) clone() Session {
+	s.Recent = append([]RecentTx(nil), s.Recent...)
+	aliases := make(map[string]string, len(s.Aliases))
+	for k, v := range s.Aliases {
+		aliases[k] = v
+	}
+	s.Aliases = aliases
+	return s
+}
+
+// Store хранит сессии в JSON-файле; каждое изменение сразу записывается на диск
+type Store struct {
+	mu       sync.Mutex
+	path     string
+	sessions map[int64]Session
+}
+
+// OpenStore читает файл состояния; отсутствующий файл означает пустое состояние
+func OpenStore(path string) (*Store, error) {
+	st := &Store{path: path, sessions: map[int64]Session{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]Session
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for k, s := range raw {
+		id, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: bad user id %q", path, k)
+		}
+		st.sessions[id] = s
+	}
+	return st, nil
+}
+
+// Get возвращает копию сессии пользователя, для нового пользователя – пустую
+func (st *Store) Get(userID int64) Session {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	s, ok := st.sessions[userID]
+	if !ok {
+		return Session{State: stateIdle}
+	}
+	return s.clone()
+}
+
+// Put сохраняет сессию и переписывает файл
+func (st *Store) Put(userID int64, s Session) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.sessions[userID] = s.clone()
+	return st.save()
+}
+
+// save пишет во временный файл и переименовывает, чтобы сбой не оставил файл обрезанным
+func (st *Store) save() error {
+	raw := make(map[string]Session, len(st.sessions))
+	for id, s := range st.sessions {
+		raw[strconv.FormatInt(id, 10)] = s
+	}
+	b, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(st.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+	// в файле токены доступа: только владельцу процесса
+	tmp := st.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_PersistsSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "bot.json")
+	st, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if s := st.Get(42); s.State != stateIdle || s.LoggedIn() {
+		t.Fatalf("unexpected new session %#v", s)
+	}
+
+	s := Session{State: stateIdle, TenantID: "t1", AccessToken: "at", RefreshToken: "rt", AccessExpiresAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Recent: []RecentTx{{ID: "tx1", TenantID: "t1"}}, Aliases: map[string]string{"coffee": "c1"}}
+	if err := st.Put(42, s); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	// the stored session is a copy
+	s.Aliases["tea"] = "c2"
+	if got := st.Get(42); len(got.Aliases) != 1 {
+		t.Fatalf("store shares the aliases map")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("state file mode %v, want 0600", info.Mode().Perm())
+	}
+
+	reopened, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	got := reopened.Get(42)
+	if !got.LoggedIn() || got.TenantID != "t1" || got.Aliases["coffee"] != "c1" || len(got.Recent) != 1 || got.Recent[0].TenantID != "t1" || !got.AccessExpiresAt.Equal(s.AccessExpiresAt) {
+		t.Fatalf("unexpected reloaded session %#v", got)
+	}
+}
+
+func TestStore_OldRecentFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"42":{"state":"idle","tenant_id":"t1","recent":["tx1"]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	st, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if got := st.Get(42).Recent; len(got) != 1 || got[0].ID != "tx1" || got[0].TenantID != "" {
+		t.Fatalf("unexpected recent %#v", got)
+	}
+}
+
+func TestSession_LogoutKeepsAliases(t *testing.T) {
+	s := Session{State: stateWaitingCode, AccessToken: "at", Recent: []RecentTx{{ID: "tx1", TenantID: "t1"}}, Aliases: map[string]string{"coffee": "c1"}}
+	s.logout()
+	if s.LoggedIn() || s.State != stateIdle || len(s.Recent) != 0 || s.Aliases["coffee"] != "c1" {
+		t.Fatalf("unexpected session after logout %#v", s)
+	}
+
+	for i := 0; i < maxRecent+5; i++ {
+		s.pushRecent(string(rune('a' + i)))
+	}
+	if len(s.Recent) != maxRecent || s.Recent[maxRecent-1].ID != string(rune('a'+maxRecent+4)) {
+		t.Fatalf("recent not capped: %v", s.Recent)
+	}
+}
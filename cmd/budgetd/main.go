@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -18,14 +19,21 @@ import (
 	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
 	aauth "github.com/positron48/budget/internal/adapter/auth"
 	grpcadapter "github.com/positron48/budget/internal/adapter/grpc"
+	"github.com/positron48/budget/internal/adapter/mail"
+	"github.com/positron48/budget/internal/adapter/telegram"
 	"github.com/positron48/budget/internal/domain"
+	alertuse "github.com/positron48/budget/internal/usecase/alert"
+	useaudit "github.com/positron48/budget/internal/usecase/audit"
 	useauth "github.com/positron48/budget/internal/usecase/auth"
 	"github.com/positron48/budget/internal/usecase/category"
+	"github.com/positron48/budget/internal/usecase/events"
 	useoauth "github.com/positron48/budget/internal/usecase/oauth"
 	reportuse "github.com/positron48/budget/internal/usecase/report"
 	"github.com/positron48/budget/internal/usecase/tenant"
 	"github.com/positron48/budget/internal/usecase/transaction"
 	useuser "github.com/positron48/budget/internal/usecase/user"
+	viewuse "github.com/positron48/budget/internal/usecase/view"
+	webhookuse "github.com/positron48/budget/internal/usecase/webhook"
 
 	// usecase imports will be wired when generated stubs are available
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -97,16 +105,63 @@ func main() {
 		sug.Fatalw("listen failed", "error", err)
 	}
 
+	// Redis connection (OAuth cache, access token denylist)
+	var redisClient *redis.Client
+	var tokenDenylist *redis.TokenDenylist
+	if cfg.RedisURL != "" {
+		redisClient, err = redis.NewClient(cfg.RedisURL)
+		if err != nil {
+			sug.Warnw("failed to connect to Redis", "error", err)
+		} else {
+			defer func() {
+				if err := redisClient.Close(); err != nil {
+					sug.Warnw("failed to close Redis client", "error", err)
+				}
+			}()
+			tokenDenylist = redis.NewTokenDenylist(redisClient, cfg.JWTAccessTTL)
+		}
+	}
+	// JWT keys: HS256 shared secret or RS256/EdDSA with kid-based rotation
+	jwtKeys, err := aauth.LoadKeySet(aauth.KeySetConfig{
+		Algorithm:            cfg.JWTKeys.Algorithm,
+		HMACSecret:           cfg.JWTSignKey,
+		SigningKeyPEM:        cfg.JWTKeys.SigningKeyPEM,
+		SigningKeyFile:       cfg.JWTKeys.SigningKeyFile,
+		SigningKeyID:         cfg.JWTKeys.SigningKeyID,
+		VerificationKeyFiles: cfg.JWTKeys.VerificationKeyFiles,
+		VerificationKeyPEMs:  cfg.JWTKeys.VerificationKeyPEMs,
+	})
+	if err != nil {
+		sug.Fatalw("jwt keys", "error", err)
+	}
+	var denylist grpcadapter.AccessTokenDenylist
+	if tokenDenylist != nil {
+		denylist = tokenDenylist
+	}
+	// personal access tokens are resolved by the user service, built below with the other services
+	var userSvc *useuser.Service
+	apiTokenAuth := grpcadapter.APITokenAuthenticatorFunc(func(ctx context.Context, token string) (domain.APIToken, error) {
+		if userSvc == nil {
+			return domain.APIToken{}, useuser.ErrInvalidAPIToken
+		}
+		return userSvc.AuthenticateAPIToken(ctx, token)
+	})
+	authInterceptor := grpcadapter.NewAuthUnaryInterceptorWithAPITokens(jwtKeys.Keyfunc, denylist, apiTokenAuth)
+
 	// Build gRPC server with interceptors
 	// Tenant guard needs tenantRepo; build a validate function lazily below.
 	var tenantGuard grpc.UnaryServerInterceptor = func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		return handler(ctx, req)
 	}
+	var tenantStreamGuard grpc.StreamServerInterceptor = func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	}
 
 	server := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
-			grpcadapter.NewAuthUnaryInterceptor(cfg.JWTSignKey),
+			grpcadapter.NewClientInfoUnaryInterceptor(cfg.TrustedProxies),
+			authInterceptor,
 			grpcadapter.MetricsUnaryInterceptor(),
 			grpcadapter.LoggingUnaryInterceptor(sug),
 			grpcadapter.RecoveryUnaryInterceptor(sug),
@@ -114,12 +169,26 @@ func main() {
 				return tenantGuard(ctx, req, info, handler)
 			},
 		),
+		grpc.ChainStreamInterceptor(
+			grpcadapter.NewClientInfoStreamInterceptor(cfg.TrustedProxies),
+			grpcadapter.NewAuthStreamInterceptorWithAPITokens(jwtKeys.Keyfunc, denylist, apiTokenAuth),
+			grpcadapter.MetricsStreamInterceptor(),
+			grpcadapter.LoggingStreamInterceptor(sug),
+			grpcadapter.RecoveryStreamInterceptor(sug),
+			func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return tenantStreamGuard(srv, ss, info, handler)
+			},
+		),
 	)
 
 	// optional metrics server with Prometheus handler
 	if cfg.MetricsAddr != "" {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
+		if jwtKeys.Algorithm() != aauth.AlgHS256 {
+			// public keys for the Telegram bot and sidecars verifying access tokens themselves
+			mux.Handle("/.well-known/jwks.json", jwtKeys.JWKSHandler())
+		}
 		stopMetrics := logger.StartMetricsServer(sug, cfg.MetricsAddr, mux)
 		defer stopMetrics(context.Background())
 	}
@@ -130,33 +199,73 @@ func main() {
 	// enable server reflection for grpcurl tooling in dev
 	reflection.Register(server)
 
+	// cancelled on shutdown, before the server stops: long-lived workers and streams end with it
+	shutdownCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	// periodic workers finish their current run before the process exits
+	var workers sync.WaitGroup
+
 	// register services
 	if db != nil {
 
-		// Redis connection
-		var redisClient *redis.Client
-		if cfg.RedisURL != "" {
-			redisClient, err = redis.NewClient(cfg.RedisURL)
-			if err != nil {
-				sug.Warnw("failed to connect to Redis", "error", err)
-			} else {
-				defer func() {
-					if err := redisClient.Close(); err != nil {
-						sug.Warnw("failed to close Redis client", "error", err)
-					}
-				}()
-			}
+		// Mail: SMTP relay, or .eml files in the outbox directory
+		var mailSender mail.Sender
+		switch {
+		case cfg.Mail.SMTPHost != "":
+			mailSender = mail.NewSMTPSender(cfg.Mail.SMTPHost, cfg.Mail.SMTPPort, cfg.Mail.SMTPUsername, cfg.Mail.SMTPPassword, cfg.Mail.From)
+		case cfg.Mail.OutboxDir != "":
+			mailSender = mail.NewFileSender(cfg.Mail.OutboxDir, cfg.Mail.From)
+		default:
+			sug.Warn("neither MAIL_SMTP_HOST nor MAIL_OUTBOX_DIR is set; emails are not delivered")
 		}
 
 		// Auth
 		userRepo := postgres.NewUserRepo(db)
 		rtRepo := postgres.NewRefreshTokenRepo(db)
 		hasher := aauth.NewArgon2Hasher()
-		issuer := aauth.NewJWTIssuer(cfg.JWTSignKey)
+		issuer := aauth.NewJWTIssuerWithKeys(jwtKeys)
 		authSvc := useauth.NewService(userRepo, rtRepo, hasher, issuer, cfg.JWTAccessTTL, cfg.JWTRefreshTTL)
 		if cfg.GoogleClientID != "" {
 			authSvc.SetGoogleVerifier(aauth.NewGoogleVerifier(cfg.GoogleClientID))
 		}
+		for _, p := range cfg.OIDCProviders {
+			authSvc.SetIdentityProvider(p.Name, aauth.NewOIDCVerifier(aauth.OIDCConfig{
+				IssuerURL: p.IssuerURL,
+				ClientID:  p.ClientID,
+				JWKSURL:   p.JWKSURL,
+			}), p.TrustEmail)
+		}
+		authSvc.SetIdentityRepo(postgres.NewIdentityRepo(db))
+		if mailSender != nil {
+			authSvc.SetPasswordReset(postgres.NewPasswordResetRepo(db), mail.NewPasswordResetMailer(mailSender, cfg.OAuth.WebBaseURL), useauth.PasswordResetConfig{
+				TokenTTL:           cfg.PasswordReset.TokenTTL,
+				MaxPerEmailPerHour: cfg.PasswordReset.MaxPerEmailPerHour,
+				MaxPerIPPerHour:    cfg.PasswordReset.MaxPerIPPerHour,
+			})
+		}
+		var verificationMailer useauth.EmailVerificationMailer
+		if mailSender != nil {
+			verificationMailer = mail.NewVerificationMailer(mailSender, cfg.OAuth.WebBaseURL)
+		}
+		authSvc.SetEmailVerification(postgres.NewEmailVerificationRepo(db), verificationMailer, cfg.EmailVerificationTTL)
+		if tokenDenylist != nil {
+			authSvc.SetAccessTokenRevoker(tokenDenylist)
+		}
+		twoFactorRepo := postgres.NewTwoFactorRepo(db)
+		authSvc.SetTwoFactor(twoFactorRepo, cfg.TwoFactorChallengeTTL)
+		loginAttemptRepo := postgres.NewLoginAttemptRepo(db)
+		authSvc.SetLoginAttemptLog(loginAttemptRepo)
+		if redisClient != nil {
+			authSvc.SetLoginGuard(redis.NewLoginThrottle(redisClient), useauth.LoginGuardConfig{
+				Window:       cfg.LoginGuard.FailureWindow,
+				FreeAttempts: cfg.LoginGuard.FreeAttempts,
+				BaseDelay:    cfg.LoginGuard.BaseDelay,
+				MaxDelay:     cfg.LoginGuard.MaxDelay,
+				EmailLockAt:  cfg.LoginGuard.EmailLockAt,
+				IPLockAt:     cfg.LoginGuard.IPLockAt,
+				LockDuration: cfg.LoginGuard.LockDuration,
+			})
+		}
 		budgetv1.RegisterAuthServiceServer(server, grpcadapter.NewAuthServerWithPasswordAuth(authSvc, cfg.AuthPasswordEnabled))
 
 		// OAuth (if Redis is available)
@@ -165,46 +274,227 @@ func main() {
 			oauthCache := redis.NewOAuthCache(redisClient)
 			configOAuth := cfg.GetOAuthConfig()
 			oauthConfig := domain.OAuthConfig{
-				AuthTokenTTL:        configOAuth.AuthTokenTTL,
-				SessionTTL:          configOAuth.SessionTTL,
-				VerificationCodeTTL: configOAuth.VerificationCodeTTL,
-				MaxAttemptsPerHour:  configOAuth.MaxAttemptsPerHour,
-				MaxAttemptsPer10Min: configOAuth.MaxAttemptsPer10Min,
-				WebBaseURL:          configOAuth.WebBaseURL,
+				AuthTokenTTL:         configOAuth.AuthTokenTTL,
+				SessionTTL:           configOAuth.SessionTTL,
+				VerificationCodeTTL:  configOAuth.VerificationCodeTTL,
+				MaxAttemptsPerHour:   configOAuth.MaxAttemptsPerHour,
+				MaxAttemptsPer10Min:  configOAuth.MaxAttemptsPer10Min,
+				WebBaseURL:           configOAuth.WebBaseURL,
+				RequireVerifiedEmail: cfg.RequireVerifiedEmail,
 			}
 			oauthSvc := useoauth.NewService(oauthRepo, oauthCache, authSvc, issuer, oauthConfig, cfg.JWTAccessTTL, cfg.JWTRefreshTTL)
+			oauthSvc.SetTokenRevoker(tokenDenylist)
 			budgetv1.RegisterOAuthServiceServer(server, grpcadapter.NewOAuthServer(oauthSvc))
 		}
 
 		// Tenant
 		tenantRepo := postgres.NewTenantRepo(db)
+		auditLog := useaudit.NewLog(postgres.NewAuditRepo(db), db)
 		tenantSvc := tenant.NewService(tenantRepo)
+		tenantSvc.SetAuditLog(auditLog)
+		var invitationSender tenant.InvitationSender
+		if mailSender != nil {
+			invitationSender = mail.NewInvitationMailer(mailSender, cfg.OAuth.WebBaseURL)
+		}
+		tenantSvc.SetInvitations(postgres.NewInvitationRepo(db), invitationSender, cfg.TenantInvitationTTL)
+		tenantSvc.SetRequireVerifiedEmail(cfg.RequireVerifiedEmail)
+		if tokenDenylist != nil {
+			tenantSvc.SetTokenRevoker(tokenDenylist)
+		}
+		authSvc.SetInvitationAttacher(useauth.InvitationAttacherFunc(func(ctx context.Context, userID, email string) ([]useauth.TenantMembership, error) {
+			ms, err := tenantSvc.AttachPendingInvitations(ctx, userID, email)
+			if err != nil {
+				return nil, err
+			}
+			out := make([]useauth.TenantMembership, 0, len(ms))
+			for _, m := range ms {
+				out = append(out, useauth.TenantMembership{TenantID: m.Tenant.ID, Role: string(m.Role), IsDefault: m.IsDefault})
+			}
+			return out, nil
+		}))
+		authSvc.SetMembershipChecker(tenantRepo)
+		tenantSvc.SetDeletionGracePeriod(cfg.TenantDeletionGrace)
+		go func() {
+			// purge tenants whose deletion grace period has ended
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+			for range ticker.C {
+				if n, err := tenantSvc.PurgeScheduledTenants(context.Background()); err != nil {
+					sug.Warnw("tenant purge failed", "error", err)
+				} else if n > 0 {
+					sug.Infow("purged deleted tenants", "count", n)
+				}
+			}
+		}()
 		// now that tenantRepo is ready, attach tenant guard
-		tenantGuard = grpcadapter.NewTenantGuardUnaryInterceptor(func(ctx context.Context, userID, tenantID string) (bool, error) {
+		isMember := func(ctx context.Context, userID, tenantID string) (bool, error) {
 			return tenantRepo.HasMembership(ctx, userID, tenantID)
-		})
+		}
+		tenantGuard = grpcadapter.NewTenantGuardUnaryInterceptor(isMember)
+		tenantStreamGuard = grpcadapter.NewTenantGuardStreamInterceptor(isMember)
 		budgetv1.RegisterTenantServiceServer(server, grpcadapter.NewTenantServer(tenantSvc))
 
 		// Category
 		categoryRepo := postgres.NewCategoryRepo(db)
 		categorySvc := category.NewService(categoryRepo)
+		categorySvc.SetAuditLog(auditLog)
 		budgetv1.RegisterCategoryServiceServer(server, grpcadapter.NewCategoryServer(categorySvc))
 
 		// Fx
 		fxRepo := postgres.NewFxRepo(db)
 		budgetv1.RegisterFxServiceServer(server, grpcadapter.NewFxServer(fxRepo))
+		budgetv1.RegisterCurrencyServiceServer(server, grpcadapter.NewCurrencyServer(tenantRepo))
 
 		// Transaction (wire repos into usecase)
 		txRepo := postgres.NewTransactionRepo(db)
 		txSvc := transaction.NewService(txRepo, fxRepo, tenantRepo, categoryRepo)
-		budgetv1.RegisterTransactionServiceServer(server, grpcadapter.NewTransactionServer(txSvc))
+		txSvc.SetAuditLog(auditLog)
+		txSvc.SetTxRunner(db)
+		// Webhooks: changes queue deliveries in the outbox, the dispatcher sends them
+		webhookRepo := postgres.NewWebhookRepo(db)
+		webhookOutbox := webhookuse.NewOutbox(webhookRepo)
+		txSvc.SetWebhooks(webhookOutbox)
+		categorySvc.SetWebhooks(webhookOutbox)
+		webhookPolicy := webhookuse.AddressPolicy{Allowed: cfg.Webhooks.AllowedNetworks}
+		webhookSvc := webhookuse.NewService(webhookRepo, tenantRepo)
+		webhookSvc.SetAddressPolicy(webhookPolicy)
+		budgetv1.RegisterWebhookServiceServer(server, grpcadapter.NewWebhookServer(webhookSvc))
+		dispatcher := webhookuse.NewDispatcher(webhookRepo, webhookuse.NewClient(webhookPolicy, cfg.Webhooks.Timeout), webhookuse.DispatcherConfig{
+			BatchSize:   cfg.Webhooks.BatchSize,
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			BaseDelay:   cfg.Webhooks.RetryBaseDelay,
+			MaxDelay:    cfg.Webhooks.RetryMaxDelay,
+			Timeout:     cfg.Webhooks.Timeout,
+		})
+		workers.Go(func() {
+			ticker := time.NewTicker(cfg.Webhooks.DispatchInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-shutdownCtx.Done():
+					return
+				case <-ticker.C:
+				}
+				// drain the backlog before waiting for the next tick
+				for {
+					delivered, failed, err := dispatcher.RunOnce(shutdownCtx)
+					if shutdownCtx.Err() != nil {
+						return
+					}
+					if err != nil {
+						sug.Warnw("webhook dispatch failed", "error", err)
+						break
+					}
+					if failed > 0 {
+						sug.Infow("webhook deliveries failed", "delivered", delivered, "failed", failed)
+					}
+					if n := delivered + failed; n == 0 || n < cfg.Webhooks.BatchSize {
+						break
+					}
+				}
+			}
+		})
+		// Alerts: rules are evaluated periodically, each firing is recorded once and sent through its channels
+		alertRepo := postgres.NewAlertRepo(db)
+		budgetv1.RegisterAlertServiceServer(server, grpcadapter.NewAlertServer(alertuse.NewService(alertRepo, tenantRepo, categoryRepo)))
+		alertEvaluator := alertuse.NewEvaluator(alertRepo, alertRepo, txSvc)
+		alertEvaluator.SetChannel(domain.AlertChannelWebhook, alertuse.NewWebhookChannel(webhookuse.NewOutbox(webhookRepo)))
+		if mailSender != nil {
+			alertEvaluator.SetChannel(domain.AlertChannelEmail, mail.NewAlertMailer(mailSender, tenantRepo, cfg.OAuth.WebBaseURL))
+		}
+		if cfg.Alerts.TelegramBotToken != "" {
+			tg := telegram.NewClient(cfg.Alerts.TelegramAPIURL, cfg.Alerts.TelegramBotToken, &http.Client{Timeout: 10 * time.Second})
+			alertEvaluator.SetChannel(domain.AlertChannelTelegram, telegram.NewAlertNotifier(tg))
+		}
+		workers.Go(func() {
+			ticker := time.NewTicker(cfg.Alerts.EvalInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-shutdownCtx.Done():
+					return
+				case <-ticker.C:
+				}
+				fired, err := alertEvaluator.RunOnce(shutdownCtx)
+				if err != nil && shutdownCtx.Err() == nil {
+					sug.Warnw("alert evaluation failed", "error", err)
+				}
+				if fired > 0 {
+					sug.Infow("alerts fired", "count", fired)
+				}
+			}
+		})
+		// Change feed: repositories NOTIFY, every instance LISTENs and fans out to its watchers
+		eventBroker := events.NewBroker()
+		budgetv1.RegisterEventServiceServer(server, grpcadapter.NewEventServer(eventBroker))
+		go func() {
+			// watchers end with ABORTED and reload: on shutdown, and whenever LISTEN starts again,
+			// since events sent while the listener was down are lost
+			defer eventBroker.DropAll()
+			listener := postgres.NewEventListener(db)
+			for {
+				err := listener.Listen(shutdownCtx, eventBroker.Publish, eventBroker.DropAll)
+				if shutdownCtx.Err() != nil {
+					return
+				}
+				sug.Warnw("tenant event listener stopped; restarting", "error", err)
+				select {
+				case <-shutdownCtx.Done():
+					return
+				case <-time.After(5 * time.Second):
+				}
+			}
+		}()
+
+		// Saved views, shared by listings, totals and reports
+		viewSvc := viewuse.NewService(postgres.NewViewRepo(db), tenantRepo)
+		budgetv1.RegisterViewServiceServer(server, grpcadapter.NewViewServer(viewSvc))
+		txServer := grpcadapter.NewTransactionServer(txSvc)
+		txServer.SetViews(viewSvc)
+		budgetv1.RegisterTransactionServiceServer(server, txServer)
+		workers.Go(func() {
+			// empty the trash: transactions first, then categories they no longer reference
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-shutdownCtx.Done():
+					return
+				case <-ticker.C:
+				}
+				cutoff := time.Now().Add(-cfg.TrashRetention)
+				txs, err := txSvc.PurgeDeleted(shutdownCtx, cutoff)
+				if err != nil {
+					if shutdownCtx.Err() == nil {
+						sug.Warnw("transaction trash purge failed", "error", err)
+					}
+					continue
+				}
+				cats, err := categorySvc.PurgeDeleted(shutdownCtx, cutoff)
+				if err != nil {
+					if shutdownCtx.Err() == nil {
+						sug.Warnw("category trash purge failed", "error", err)
+					}
+					continue
+				}
+				if txs > 0 || cats > 0 {
+					sug.Infow("purged trash", "transactions", txs, "categories", cats)
+				}
+			}
+		})
 
 		// Report
 		reportSvc := reportuse.NewService(txSvc, fxRepo, tenantRepo, categoryRepo)
-		budgetv1.RegisterReportServiceServer(server, grpcadapter.NewReportServer(reportSvc))
+		reportServer := grpcadapter.NewReportServer(reportSvc)
+		reportServer.SetViews(viewSvc)
+		budgetv1.RegisterReportServiceServer(server, reportServer)
 
 		// User
-		userSvc := useuser.NewService(userRepo, hasher)
+		userSvc = useuser.NewService(userRepo, hasher)
+		userSvc.SetSessions(rtRepo)
+		userSvc.SetTwoFactor(twoFactorRepo, cfg.TOTPIssuer)
+		userSvc.SetAPITokens(postgres.NewAPITokenRepo(db), tenantRepo)
+		userSvc.SetLoginHistory(loginAttemptRepo)
 		getHash := func(ctx context.Context, userID string) (string, error) {
 			u, err := userRepo.GetByID(ctx, userID)
 			if err != nil {
@@ -230,6 +520,7 @@ func main() {
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 	sug.Info("shutting down gRPC server...")
+	stopWorkers()
 	done := make(chan struct{})
 	go func() { server.GracefulStop(); close(done) }()
 	select {
@@ -239,4 +530,11 @@ func main() {
 		sug.Warn("force stopping gRPC server")
 		server.Stop()
 	}
+	workersDone := make(chan struct{})
+	go func() { workers.Wait(); close(workersDone) }()
+	select {
+	case <-workersDone:
+	case <-time.After(5 * time.Second):
+		sug.Warn("background workers did not stop in time")
+	}
 }
@@ -287,11 +287,15 @@ func (x *LoginRequest) GetPassword() string {
 }
 
 type LoginResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tokens        *TokenPair             `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"`
-	Memberships   []*TenantMembership    `protobuf:"bytes,2,rep,name=memberships,proto3" json:"memberships,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Tokens      *TokenPair             `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"` // empty when two_factor_required
+	Memberships []*TenantMembership    `protobuf:"bytes,2,rep,name=memberships,proto3" json:"memberships,omitempty"`
+	// 2FA is enabled: call CompleteTwoFactorLogin with the challenge and a code
+	TwoFactorRequired  bool                   `protobuf:"varint,3,opt,name=two_factor_required,json=twoFactorRequired,proto3" json:"two_factor_required,omitempty"`
+	ChallengeToken     string                 `protobuf:"bytes,4,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	ChallengeExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=challenge_expires_at,json=challengeExpiresAt,proto3" json:"challenge_expires_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
@@ -338,6 +342,132 @@ func (x *LoginResponse) GetMemberships() []*TenantMembership {
 	return nil
 }
 
+func (x *LoginResponse) GetTwoFactorRequired() bool {
+	if x != nil {
+		return x.TwoFactorRequired
+	}
+	return false
+}
+
+func (x *LoginResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetChallengeExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChallengeExpiresAt
+	}
+	return nil
+}
+
+// CompleteTwoFactorLogin finishes Login with a TOTP code or a recovery code
+type CompleteTwoFactorLoginRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CompleteTwoFactorLoginRequest) Reset() {
+	*x = CompleteTwoFactorLoginRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTwoFactorLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTwoFactorLoginRequest) ProtoMessage() {}
+
+func (x *CompleteTwoFactorLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTwoFactorLoginRequest.ProtoReflect.Descriptor instead.
+func (*CompleteTwoFactorLoginRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CompleteTwoFactorLoginRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *CompleteTwoFactorLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type CompleteTwoFactorLoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        *TokenPair             `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	Memberships   []*TenantMembership    `protobuf:"bytes,2,rep,name=memberships,proto3" json:"memberships,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTwoFactorLoginResponse) Reset() {
+	*x = CompleteTwoFactorLoginResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTwoFactorLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTwoFactorLoginResponse) ProtoMessage() {}
+
+func (x *CompleteTwoFactorLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTwoFactorLoginResponse.ProtoReflect.Descriptor instead.
+func (*CompleteTwoFactorLoginResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CompleteTwoFactorLoginResponse) GetTokens() *TokenPair {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *CompleteTwoFactorLoginResponse) GetMemberships() []*TenantMembership {
+	if x != nil {
+		return x.Memberships
+	}
+	return nil
+}
+
 type RefreshTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
@@ -347,7 +477,7 @@ type RefreshTokenRequest struct {
 
 func (x *RefreshTokenRequest) Reset() {
 	*x = RefreshTokenRequest{}
-	mi := &file_budget_v1_auth_proto_msgTypes[5]
+	mi := &file_budget_v1_auth_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -359,7 +489,7 @@ func (x *RefreshTokenRequest) String() string {
 func (*RefreshTokenRequest) ProtoMessage() {}
 
 func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_auth_proto_msgTypes[5]
+	mi := &file_budget_v1_auth_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,7 +502,7 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
 func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_auth_proto_rawDescGZIP(), []int{5}
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RefreshTokenRequest) GetRefreshToken() string {
@@ -391,7 +521,7 @@ type RefreshTokenResponse struct {
 
 func (x *RefreshTokenResponse) Reset() {
 	*x = RefreshTokenResponse{}
-	mi := &file_budget_v1_auth_proto_msgTypes[6]
+	mi := &file_budget_v1_auth_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -403,7 +533,7 @@ func (x *RefreshTokenResponse) String() string {
 func (*RefreshTokenResponse) ProtoMessage() {}
 
 func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_auth_proto_msgTypes[6]
+	mi := &file_budget_v1_auth_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,7 +546,7 @@ func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
 func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_auth_proto_rawDescGZIP(), []int{6}
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RefreshTokenResponse) GetTokens() *TokenPair {
@@ -437,7 +567,7 @@ type GoogleAuthRequest struct {
 
 func (x *GoogleAuthRequest) Reset() {
 	*x = GoogleAuthRequest{}
-	mi := &file_budget_v1_auth_proto_msgTypes[7]
+	mi := &file_budget_v1_auth_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -449,7 +579,7 @@ func (x *GoogleAuthRequest) String() string {
 func (*GoogleAuthRequest) ProtoMessage() {}
 
 func (x *GoogleAuthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_auth_proto_msgTypes[7]
+	mi := &file_budget_v1_auth_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +592,7 @@ func (x *GoogleAuthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoogleAuthRequest.ProtoReflect.Descriptor instead.
 func (*GoogleAuthRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_auth_proto_rawDescGZIP(), []int{7}
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GoogleAuthRequest) GetIdToken() string {
@@ -487,18 +617,22 @@ func (x *GoogleAuthRequest) GetTenantName() string {
 }
 
 type GoogleAuthResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tokens        *TokenPair             `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"`
-	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	Memberships   []*TenantMembership    `protobuf:"bytes,3,rep,name=memberships,proto3" json:"memberships,omitempty"`
-	Tenant        *Tenant                `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"` // set only when a new user is created
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Tokens      *TokenPair             `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"` // empty when two_factor_required
+	User        *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Memberships []*TenantMembership    `protobuf:"bytes,3,rep,name=memberships,proto3" json:"memberships,omitempty"`
+	Tenant      *Tenant                `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"` // set only when a new user is created
+	// 2FA is enabled: call CompleteTwoFactorLogin with the challenge and a code
+	TwoFactorRequired  bool                   `protobuf:"varint,5,opt,name=two_factor_required,json=twoFactorRequired,proto3" json:"two_factor_required,omitempty"`
+	ChallengeToken     string                 `protobuf:"bytes,6,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	ChallengeExpiresAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=challenge_expires_at,json=challengeExpiresAt,proto3" json:"challenge_expires_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *GoogleAuthResponse) Reset() {
 	*x = GoogleAuthResponse{}
-	mi := &file_budget_v1_auth_proto_msgTypes[8]
+	mi := &file_budget_v1_auth_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -510,7 +644,7 @@ func (x *GoogleAuthResponse) String() string {
 func (*GoogleAuthResponse) ProtoMessage() {}
 
 func (x *GoogleAuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_auth_proto_msgTypes[8]
+	mi := &file_budget_v1_auth_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +657,7 @@ func (x *GoogleAuthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoogleAuthResponse.ProtoReflect.Descriptor instead.
 func (*GoogleAuthResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_auth_proto_rawDescGZIP(), []int{8}
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GoogleAuthResponse) GetTokens() *TokenPair {
@@ -554,28 +688,53 @@ func (x *GoogleAuthResponse) GetTenant() *Tenant {
 	return nil
 }
 
-type RequestPasswordResetRequest struct {
+func (x *GoogleAuthResponse) GetTwoFactorRequired() bool {
+	if x != nil {
+		return x.TwoFactorRequired
+	}
+	return false
+}
+
+func (x *GoogleAuthResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *GoogleAuthResponse) GetChallengeExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChallengeExpiresAt
+	}
+	return nil
+}
+
+// ExternalAuth signs in with an ID token of a configured OpenID Connect provider
+type ExternalAuthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // e.g. "google", "keycloak"; see ListIdentityProviders
+	IdToken       string                 `protobuf:"bytes,2,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`                           // optional locale for new users
+	TenantName    string                 `protobuf:"bytes,4,opt,name=tenant_name,json=tenantName,proto3" json:"tenant_name,omitempty"` // optional initial tenant name for new users
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RequestPasswordResetRequest) Reset() {
-	*x = RequestPasswordResetRequest{}
-	mi := &file_budget_v1_auth_proto_msgTypes[9]
+func (x *ExternalAuthRequest) Reset() {
+	*x = ExternalAuthRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestPasswordResetRequest) String() string {
+func (x *ExternalAuthRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestPasswordResetRequest) ProtoMessage() {}
+func (*ExternalAuthRequest) ProtoMessage() {}
 
-func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_auth_proto_msgTypes[9]
+func (x *ExternalAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -586,39 +745,68 @@ func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
-func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_auth_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ExternalAuthRequest.ProtoReflect.Descriptor instead.
+func (*ExternalAuthRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *RequestPasswordResetRequest) GetEmail() string {
+func (x *ExternalAuthRequest) GetProvider() string {
 	if x != nil {
-		return x.Email
+		return x.Provider
 	}
 	return ""
 }
 
-type RequestPasswordResetResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ExternalAuthRequest) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
+	}
+	return ""
 }
 
-func (x *RequestPasswordResetResponse) Reset() {
-	*x = RequestPasswordResetResponse{}
-	mi := &file_budget_v1_auth_proto_msgTypes[10]
+func (x *ExternalAuthRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ExternalAuthRequest) GetTenantName() string {
+	if x != nil {
+		return x.TenantName
+	}
+	return ""
+}
+
+type ExternalAuthResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Tokens      *TokenPair             `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"` // empty when two_factor_required
+	User        *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Memberships []*TenantMembership    `protobuf:"bytes,3,rep,name=memberships,proto3" json:"memberships,omitempty"`
+	Tenant      *Tenant                `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"` // set only when a new user is created
+	// 2FA is enabled: call CompleteTwoFactorLogin with the challenge and a code
+	TwoFactorRequired  bool                   `protobuf:"varint,5,opt,name=two_factor_required,json=twoFactorRequired,proto3" json:"two_factor_required,omitempty"`
+	ChallengeToken     string                 `protobuf:"bytes,6,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	ChallengeExpiresAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=challenge_expires_at,json=challengeExpiresAt,proto3" json:"challenge_expires_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExternalAuthResponse) Reset() {
+	*x = ExternalAuthResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestPasswordResetResponse) String() string {
+func (x *ExternalAuthResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestPasswordResetResponse) ProtoMessage() {}
+func (*ExternalAuthResponse) ProtoMessage() {}
 
-func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_auth_proto_msgTypes[10]
+func (x *ExternalAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,84 +817,81 @@ func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
-func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_auth_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ExternalAuthResponse.ProtoReflect.Descriptor instead.
+func (*ExternalAuthResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{12}
 }
 
-type ResetPasswordRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ResetToken    string                 `protobuf:"bytes,1,opt,name=reset_token,json=resetToken,proto3" json:"reset_token,omitempty"`
-	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ExternalAuthResponse) GetTokens() *TokenPair {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
 }
 
-func (x *ResetPasswordRequest) Reset() {
-	*x = ResetPasswordRequest{}
-	mi := &file_budget_v1_auth_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ExternalAuthResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
 }
 
-func (x *ResetPasswordRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ExternalAuthResponse) GetMemberships() []*TenantMembership {
+	if x != nil {
+		return x.Memberships
+	}
+	return nil
 }
 
-func (*ResetPasswordRequest) ProtoMessage() {}
-
-func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_auth_proto_msgTypes[11]
+func (x *ExternalAuthResponse) GetTenant() *Tenant {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Tenant
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
-func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_auth_proto_rawDescGZIP(), []int{11}
+func (x *ExternalAuthResponse) GetTwoFactorRequired() bool {
+	if x != nil {
+		return x.TwoFactorRequired
+	}
+	return false
 }
 
-func (x *ResetPasswordRequest) GetResetToken() string {
+func (x *ExternalAuthResponse) GetChallengeToken() string {
 	if x != nil {
-		return x.ResetToken
+		return x.ChallengeToken
 	}
 	return ""
 }
 
-func (x *ResetPasswordRequest) GetNewPassword() string {
+func (x *ExternalAuthResponse) GetChallengeExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.NewPassword
+		return x.ChallengeExpiresAt
 	}
-	return ""
+	return nil
 }
 
-type ResetPasswordResponse struct {
+type ListIdentityProvidersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResetPasswordResponse) Reset() {
-	*x = ResetPasswordResponse{}
-	mi := &file_budget_v1_auth_proto_msgTypes[12]
+func (x *ListIdentityProvidersRequest) Reset() {
+	*x = ListIdentityProvidersRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResetPasswordResponse) String() string {
+func (x *ListIdentityProvidersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResetPasswordResponse) ProtoMessage() {}
+func (*ListIdentityProvidersRequest) ProtoMessage() {}
 
-func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_auth_proto_msgTypes[12]
+func (x *ListIdentityProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,14 +902,819 @@ func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
-func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_auth_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListIdentityProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListIdentityProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{13}
 }
 
-var File_budget_v1_auth_proto protoreflect.FileDescriptor
+type ListIdentityProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []string               `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_budget_v1_auth_proto_rawDesc = "" +
+func (x *ListIdentityProvidersResponse) Reset() {
+	*x = ListIdentityProvidersResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentityProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentityProvidersResponse) ProtoMessage() {}
+
+func (x *ListIdentityProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentityProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListIdentityProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListIdentityProvidersResponse) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+// Identity is an external account linked to the user
+type Identity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"` // email at the provider when linked
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastLoginAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	mi := &file_budget_v1_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Identity) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Identity) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Identity) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Identity) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Identity) GetLastLoginAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return nil
+}
+
+// LinkIdentity attaches an external account to the authenticated user
+type LinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	IdToken       string                 `protobuf:"bytes,2,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityRequest) Reset() {
+	*x = LinkIdentityRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityRequest) ProtoMessage() {}
+
+func (x *LinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*LinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
+	}
+	return ""
+}
+
+type LinkIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identity      *Identity              `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityResponse) Reset() {
+	*x = LinkIdentityResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityResponse) ProtoMessage() {}
+
+func (x *LinkIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityResponse.ProtoReflect.Descriptor instead.
+func (*LinkIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LinkIdentityResponse) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+type UnlinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityRequest) Reset() {
+	*x = UnlinkIdentityRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityRequest) ProtoMessage() {}
+
+func (x *UnlinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UnlinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type UnlinkIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityResponse) Reset() {
+	*x = UnlinkIdentityResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityResponse) ProtoMessage() {}
+
+func (x *UnlinkIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{19}
+}
+
+type ListIdentitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIdentitiesRequest) Reset() {
+	*x = ListIdentitiesRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentitiesRequest) ProtoMessage() {}
+
+func (x *ListIdentitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListIdentitiesRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{20}
+}
+
+type ListIdentitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identities    []*Identity            `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIdentitiesResponse) Reset() {
+	*x = ListIdentitiesResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentitiesResponse) ProtoMessage() {}
+
+func (x *ListIdentitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListIdentitiesResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListIdentitiesResponse) GetIdentities() []*Identity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{23}
+}
+
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResetToken    string                 `protobuf:"bytes,1,opt,name=reset_token,json=resetToken,proto3" json:"reset_token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ResetPasswordRequest) GetResetToken() string {
+	if x != nil {
+		return x.ResetToken
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ResetPasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordResponse) Reset() {
+	*x = ResetPasswordResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordResponse) ProtoMessage() {}
+
+func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{25}
+}
+
+// SwitchTenant issues tokens scoped to another tenant of the authenticated user
+type SwitchTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // optional: current refresh token, revoked after the switch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwitchTenantRequest) Reset() {
+	*x = SwitchTenantRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwitchTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchTenantRequest) ProtoMessage() {}
+
+func (x *SwitchTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchTenantRequest.ProtoReflect.Descriptor instead.
+func (*SwitchTenantRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SwitchTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SwitchTenantRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type SwitchTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        *TokenPair             `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwitchTenantResponse) Reset() {
+	*x = SwitchTenantResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwitchTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchTenantResponse) ProtoMessage() {}
+
+func (x *SwitchTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchTenantResponse.ProtoReflect.Descriptor instead.
+func (*SwitchTenantResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SwitchTenantResponse) GetTokens() *TokenPair {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+// VerifyEmail confirms the address from the link sent after registration
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type VerifyEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailResponse) Reset() {
+	*x = VerifyEmailResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailResponse) ProtoMessage() {}
+
+func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailResponse.ProtoReflect.Descriptor instead.
+func (*VerifyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *VerifyEmailResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// ResendVerification sends a new confirmation link to the authenticated user
+type ResendVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationRequest) Reset() {
+	*x = ResendVerificationRequest{}
+	mi := &file_budget_v1_auth_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationRequest) ProtoMessage() {}
+
+func (x *ResendVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationRequest.ProtoReflect.Descriptor instead.
+func (*ResendVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{30}
+}
+
+type ResendVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationResponse) Reset() {
+	*x = ResendVerificationResponse{}
+	mi := &file_budget_v1_auth_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationResponse) ProtoMessage() {}
+
+func (x *ResendVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_auth_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationResponse.ProtoReflect.Descriptor instead.
+func (*ResendVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_auth_proto_rawDescGZIP(), []int{31}
+}
+
+var File_budget_v1_auth_proto protoreflect.FileDescriptor
+
+const file_budget_v1_auth_proto_rawDesc = "" +
 	"\n" +
 	"\x14budget/v1/auth.proto\x12\tbudget.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14budget/v1/user.proto\x1a\x16budget/v1/tenant.proto\"\x9a\x02\n" +
 	"\tTokenPair\x12!\n" +
@@ -747,9 +1737,18 @@ const file_budget_v1_auth_proto_rawDesc = "" +
 	"\x06tenant\x18\x03 \x01(\v2\x11.budget.v1.TenantR\x06tenant\"@\n" +
 	"\fLoginRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"|\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xa3\x02\n" +
 	"\rLoginResponse\x12,\n" +
 	"\x06tokens\x18\x01 \x01(\v2\x14.budget.v1.TokenPairR\x06tokens\x12=\n" +
+	"\vmemberships\x18\x02 \x03(\v2\x1b.budget.v1.TenantMembershipR\vmemberships\x12.\n" +
+	"\x13two_factor_required\x18\x03 \x01(\bR\x11twoFactorRequired\x12'\n" +
+	"\x0fchallenge_token\x18\x04 \x01(\tR\x0echallengeToken\x12L\n" +
+	"\x14challenge_expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x12challengeExpiresAt\"\\\n" +
+	"\x1dCompleteTwoFactorLoginRequest\x12'\n" +
+	"\x0fchallenge_token\x18\x01 \x01(\tR\x0echallengeToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x8d\x01\n" +
+	"\x1eCompleteTwoFactorLoginResponse\x12,\n" +
+	"\x06tokens\x18\x01 \x01(\v2\x14.budget.v1.TokenPairR\x06tokens\x12=\n" +
 	"\vmemberships\x18\x02 \x03(\v2\x1b.budget.v1.TenantMembershipR\vmemberships\":\n" +
 	"\x13RefreshTokenRequest\x12#\n" +
 	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"D\n" +
@@ -759,12 +1758,52 @@ const file_budget_v1_auth_proto_rawDesc = "" +
 	"\bid_token\x18\x01 \x01(\tR\aidToken\x12\x16\n" +
 	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x1f\n" +
 	"\vtenant_name\x18\x03 \x01(\tR\n" +
-	"tenantName\"\xd1\x01\n" +
+	"tenantName\"\xf8\x02\n" +
 	"\x12GoogleAuthResponse\x12,\n" +
 	"\x06tokens\x18\x01 \x01(\v2\x14.budget.v1.TokenPairR\x06tokens\x12#\n" +
 	"\x04user\x18\x02 \x01(\v2\x0f.budget.v1.UserR\x04user\x12=\n" +
 	"\vmemberships\x18\x03 \x03(\v2\x1b.budget.v1.TenantMembershipR\vmemberships\x12)\n" +
-	"\x06tenant\x18\x04 \x01(\v2\x11.budget.v1.TenantR\x06tenant\"3\n" +
+	"\x06tenant\x18\x04 \x01(\v2\x11.budget.v1.TenantR\x06tenant\x12.\n" +
+	"\x13two_factor_required\x18\x05 \x01(\bR\x11twoFactorRequired\x12'\n" +
+	"\x0fchallenge_token\x18\x06 \x01(\tR\x0echallengeToken\x12L\n" +
+	"\x14challenge_expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x12challengeExpiresAt\"\x85\x01\n" +
+	"\x13ExternalAuthRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x19\n" +
+	"\bid_token\x18\x02 \x01(\tR\aidToken\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x1f\n" +
+	"\vtenant_name\x18\x04 \x01(\tR\n" +
+	"tenantName\"\xfa\x02\n" +
+	"\x14ExternalAuthResponse\x12,\n" +
+	"\x06tokens\x18\x01 \x01(\v2\x14.budget.v1.TokenPairR\x06tokens\x12#\n" +
+	"\x04user\x18\x02 \x01(\v2\x0f.budget.v1.UserR\x04user\x12=\n" +
+	"\vmemberships\x18\x03 \x03(\v2\x1b.budget.v1.TenantMembershipR\vmemberships\x12)\n" +
+	"\x06tenant\x18\x04 \x01(\v2\x11.budget.v1.TenantR\x06tenant\x12.\n" +
+	"\x13two_factor_required\x18\x05 \x01(\bR\x11twoFactorRequired\x12'\n" +
+	"\x0fchallenge_token\x18\x06 \x01(\tR\x0echallengeToken\x12L\n" +
+	"\x14challenge_expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x12challengeExpiresAt\"\x1e\n" +
+	"\x1cListIdentityProvidersRequest\"=\n" +
+	"\x1dListIdentityProvidersResponse\x12\x1c\n" +
+	"\tproviders\x18\x01 \x03(\tR\tproviders\"\xd1\x01\n" +
+	"\bIdentity\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\rlast_login_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\"L\n" +
+	"\x13LinkIdentityRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x19\n" +
+	"\bid_token\x18\x02 \x01(\tR\aidToken\"G\n" +
+	"\x14LinkIdentityResponse\x12/\n" +
+	"\bidentity\x18\x01 \x01(\v2\x13.budget.v1.IdentityR\bidentity\"3\n" +
+	"\x15UnlinkIdentityRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\"\x18\n" +
+	"\x16UnlinkIdentityResponse\"\x17\n" +
+	"\x15ListIdentitiesRequest\"M\n" +
+	"\x16ListIdentitiesResponse\x123\n" +
+	"\n" +
+	"identities\x18\x01 \x03(\v2\x13.budget.v1.IdentityR\n" +
+	"identities\"3\n" +
 	"\x1bRequestPasswordResetRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\"\x1e\n" +
 	"\x1cRequestPasswordResetResponse\"Z\n" +
@@ -772,15 +1811,36 @@ const file_budget_v1_auth_proto_rawDesc = "" +
 	"\vreset_token\x18\x01 \x01(\tR\n" +
 	"resetToken\x12!\n" +
 	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"\x17\n" +
-	"\x15ResetPasswordResponse2\xe7\x03\n" +
+	"\x15ResetPasswordResponse\"W\n" +
+	"\x13SwitchTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"D\n" +
+	"\x14SwitchTenantResponse\x12,\n" +
+	"\x06tokens\x18\x01 \x01(\v2\x14.budget.v1.TokenPairR\x06tokens\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\":\n" +
+	"\x13VerifyEmailResponse\x12#\n" +
+	"\x04user\x18\x01 \x01(\v2\x0f.budget.v1.UserR\x04user\"\x1b\n" +
+	"\x19ResendVerificationRequest\"\x1c\n" +
+	"\x1aResendVerificationResponse2\x94\n" +
+	"\n" +
 	"\vAuthService\x12C\n" +
 	"\bRegister\x12\x1a.budget.v1.RegisterRequest\x1a\x1b.budget.v1.RegisterResponse\x12:\n" +
-	"\x05Login\x12\x17.budget.v1.LoginRequest\x1a\x18.budget.v1.LoginResponse\x12I\n" +
+	"\x05Login\x12\x17.budget.v1.LoginRequest\x1a\x18.budget.v1.LoginResponse\x12m\n" +
+	"\x16CompleteTwoFactorLogin\x12(.budget.v1.CompleteTwoFactorLoginRequest\x1a).budget.v1.CompleteTwoFactorLoginResponse\x12I\n" +
 	"\n" +
 	"GoogleAuth\x12\x1c.budget.v1.GoogleAuthRequest\x1a\x1d.budget.v1.GoogleAuthResponse\x12O\n" +
+	"\fExternalAuth\x12\x1e.budget.v1.ExternalAuthRequest\x1a\x1f.budget.v1.ExternalAuthResponse\x12j\n" +
+	"\x15ListIdentityProviders\x12'.budget.v1.ListIdentityProvidersRequest\x1a(.budget.v1.ListIdentityProvidersResponse\x12O\n" +
+	"\fLinkIdentity\x12\x1e.budget.v1.LinkIdentityRequest\x1a\x1f.budget.v1.LinkIdentityResponse\x12U\n" +
+	"\x0eUnlinkIdentity\x12 .budget.v1.UnlinkIdentityRequest\x1a!.budget.v1.UnlinkIdentityResponse\x12U\n" +
+	"\x0eListIdentities\x12 .budget.v1.ListIdentitiesRequest\x1a!.budget.v1.ListIdentitiesResponse\x12O\n" +
 	"\fRefreshToken\x12\x1e.budget.v1.RefreshTokenRequest\x1a\x1f.budget.v1.RefreshTokenResponse\x12g\n" +
 	"\x14RequestPasswordReset\x12&.budget.v1.RequestPasswordResetRequest\x1a'.budget.v1.RequestPasswordResetResponse\x12R\n" +
-	"\rResetPassword\x12\x1f.budget.v1.ResetPasswordRequest\x1a .budget.v1.ResetPasswordResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
+	"\rResetPassword\x12\x1f.budget.v1.ResetPasswordRequest\x1a .budget.v1.ResetPasswordResponse\x12O\n" +
+	"\fSwitchTenant\x12\x1e.budget.v1.SwitchTenantRequest\x1a\x1f.budget.v1.SwitchTenantResponse\x12L\n" +
+	"\vVerifyEmail\x12\x1d.budget.v1.VerifyEmailRequest\x1a\x1e.budget.v1.VerifyEmailResponse\x12a\n" +
+	"\x12ResendVerification\x12$.budget.v1.ResendVerificationRequest\x1a%.budget.v1.ResendVerificationResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
 
 var (
 	file_budget_v1_auth_proto_rawDescOnce sync.Once
@@ -794,56 +1854,108 @@ func file_budget_v1_auth_proto_rawDescGZIP() []byte {
 	return file_budget_v1_auth_proto_rawDescData
 }
 
-var file_budget_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_budget_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_budget_v1_auth_proto_goTypes = []any{
-	(*TokenPair)(nil),                    // 0: budget.v1.TokenPair
-	(*RegisterRequest)(nil),              // 1: budget.v1.RegisterRequest
-	(*RegisterResponse)(nil),             // 2: budget.v1.RegisterResponse
-	(*LoginRequest)(nil),                 // 3: budget.v1.LoginRequest
-	(*LoginResponse)(nil),                // 4: budget.v1.LoginResponse
-	(*RefreshTokenRequest)(nil),          // 5: budget.v1.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),         // 6: budget.v1.RefreshTokenResponse
-	(*GoogleAuthRequest)(nil),            // 7: budget.v1.GoogleAuthRequest
-	(*GoogleAuthResponse)(nil),           // 8: budget.v1.GoogleAuthResponse
-	(*RequestPasswordResetRequest)(nil),  // 9: budget.v1.RequestPasswordResetRequest
-	(*RequestPasswordResetResponse)(nil), // 10: budget.v1.RequestPasswordResetResponse
-	(*ResetPasswordRequest)(nil),         // 11: budget.v1.ResetPasswordRequest
-	(*ResetPasswordResponse)(nil),        // 12: budget.v1.ResetPasswordResponse
-	(*timestamppb.Timestamp)(nil),        // 13: google.protobuf.Timestamp
-	(*User)(nil),                         // 14: budget.v1.User
-	(*Tenant)(nil),                       // 15: budget.v1.Tenant
-	(*TenantMembership)(nil),             // 16: budget.v1.TenantMembership
+	(*TokenPair)(nil),                      // 0: budget.v1.TokenPair
+	(*RegisterRequest)(nil),                // 1: budget.v1.RegisterRequest
+	(*RegisterResponse)(nil),               // 2: budget.v1.RegisterResponse
+	(*LoginRequest)(nil),                   // 3: budget.v1.LoginRequest
+	(*LoginResponse)(nil),                  // 4: budget.v1.LoginResponse
+	(*CompleteTwoFactorLoginRequest)(nil),  // 5: budget.v1.CompleteTwoFactorLoginRequest
+	(*CompleteTwoFactorLoginResponse)(nil), // 6: budget.v1.CompleteTwoFactorLoginResponse
+	(*RefreshTokenRequest)(nil),            // 7: budget.v1.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),           // 8: budget.v1.RefreshTokenResponse
+	(*GoogleAuthRequest)(nil),              // 9: budget.v1.GoogleAuthRequest
+	(*GoogleAuthResponse)(nil),             // 10: budget.v1.GoogleAuthResponse
+	(*ExternalAuthRequest)(nil),            // 11: budget.v1.ExternalAuthRequest
+	(*ExternalAuthResponse)(nil),           // 12: budget.v1.ExternalAuthResponse
+	(*ListIdentityProvidersRequest)(nil),   // 13: budget.v1.ListIdentityProvidersRequest
+	(*ListIdentityProvidersResponse)(nil),  // 14: budget.v1.ListIdentityProvidersResponse
+	(*Identity)(nil),                       // 15: budget.v1.Identity
+	(*LinkIdentityRequest)(nil),            // 16: budget.v1.LinkIdentityRequest
+	(*LinkIdentityResponse)(nil),           // 17: budget.v1.LinkIdentityResponse
+	(*UnlinkIdentityRequest)(nil),          // 18: budget.v1.UnlinkIdentityRequest
+	(*UnlinkIdentityResponse)(nil),         // 19: budget.v1.UnlinkIdentityResponse
+	(*ListIdentitiesRequest)(nil),          // 20: budget.v1.ListIdentitiesRequest
+	(*ListIdentitiesResponse)(nil),         // 21: budget.v1.ListIdentitiesResponse
+	(*RequestPasswordResetRequest)(nil),    // 22: budget.v1.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil),   // 23: budget.v1.RequestPasswordResetResponse
+	(*ResetPasswordRequest)(nil),           // 24: budget.v1.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),          // 25: budget.v1.ResetPasswordResponse
+	(*SwitchTenantRequest)(nil),            // 26: budget.v1.SwitchTenantRequest
+	(*SwitchTenantResponse)(nil),           // 27: budget.v1.SwitchTenantResponse
+	(*VerifyEmailRequest)(nil),             // 28: budget.v1.VerifyEmailRequest
+	(*VerifyEmailResponse)(nil),            // 29: budget.v1.VerifyEmailResponse
+	(*ResendVerificationRequest)(nil),      // 30: budget.v1.ResendVerificationRequest
+	(*ResendVerificationResponse)(nil),     // 31: budget.v1.ResendVerificationResponse
+	(*timestamppb.Timestamp)(nil),          // 32: google.protobuf.Timestamp
+	(*User)(nil),                           // 33: budget.v1.User
+	(*Tenant)(nil),                         // 34: budget.v1.Tenant
+	(*TenantMembership)(nil),               // 35: budget.v1.TenantMembership
 }
 var file_budget_v1_auth_proto_depIdxs = []int32{
-	13, // 0: budget.v1.TokenPair.access_token_expires_at:type_name -> google.protobuf.Timestamp
-	13, // 1: budget.v1.TokenPair.refresh_token_expires_at:type_name -> google.protobuf.Timestamp
+	32, // 0: budget.v1.TokenPair.access_token_expires_at:type_name -> google.protobuf.Timestamp
+	32, // 1: budget.v1.TokenPair.refresh_token_expires_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: budget.v1.RegisterResponse.tokens:type_name -> budget.v1.TokenPair
-	14, // 3: budget.v1.RegisterResponse.user:type_name -> budget.v1.User
-	15, // 4: budget.v1.RegisterResponse.tenant:type_name -> budget.v1.Tenant
+	33, // 3: budget.v1.RegisterResponse.user:type_name -> budget.v1.User
+	34, // 4: budget.v1.RegisterResponse.tenant:type_name -> budget.v1.Tenant
 	0,  // 5: budget.v1.LoginResponse.tokens:type_name -> budget.v1.TokenPair
-	16, // 6: budget.v1.LoginResponse.memberships:type_name -> budget.v1.TenantMembership
-	0,  // 7: budget.v1.RefreshTokenResponse.tokens:type_name -> budget.v1.TokenPair
-	0,  // 8: budget.v1.GoogleAuthResponse.tokens:type_name -> budget.v1.TokenPair
-	14, // 9: budget.v1.GoogleAuthResponse.user:type_name -> budget.v1.User
-	16, // 10: budget.v1.GoogleAuthResponse.memberships:type_name -> budget.v1.TenantMembership
-	15, // 11: budget.v1.GoogleAuthResponse.tenant:type_name -> budget.v1.Tenant
-	1,  // 12: budget.v1.AuthService.Register:input_type -> budget.v1.RegisterRequest
-	3,  // 13: budget.v1.AuthService.Login:input_type -> budget.v1.LoginRequest
-	7,  // 14: budget.v1.AuthService.GoogleAuth:input_type -> budget.v1.GoogleAuthRequest
-	5,  // 15: budget.v1.AuthService.RefreshToken:input_type -> budget.v1.RefreshTokenRequest
-	9,  // 16: budget.v1.AuthService.RequestPasswordReset:input_type -> budget.v1.RequestPasswordResetRequest
-	11, // 17: budget.v1.AuthService.ResetPassword:input_type -> budget.v1.ResetPasswordRequest
-	2,  // 18: budget.v1.AuthService.Register:output_type -> budget.v1.RegisterResponse
-	4,  // 19: budget.v1.AuthService.Login:output_type -> budget.v1.LoginResponse
-	8,  // 20: budget.v1.AuthService.GoogleAuth:output_type -> budget.v1.GoogleAuthResponse
-	6,  // 21: budget.v1.AuthService.RefreshToken:output_type -> budget.v1.RefreshTokenResponse
-	10, // 22: budget.v1.AuthService.RequestPasswordReset:output_type -> budget.v1.RequestPasswordResetResponse
-	12, // 23: budget.v1.AuthService.ResetPassword:output_type -> budget.v1.ResetPasswordResponse
-	18, // [18:24] is the sub-list for method output_type
-	12, // [12:18] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	35, // 6: budget.v1.LoginResponse.memberships:type_name -> budget.v1.TenantMembership
+	32, // 7: budget.v1.LoginResponse.challenge_expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: budget.v1.CompleteTwoFactorLoginResponse.tokens:type_name -> budget.v1.TokenPair
+	35, // 9: budget.v1.CompleteTwoFactorLoginResponse.memberships:type_name -> budget.v1.TenantMembership
+	0,  // 10: budget.v1.RefreshTokenResponse.tokens:type_name -> budget.v1.TokenPair
+	0,  // 11: budget.v1.GoogleAuthResponse.tokens:type_name -> budget.v1.TokenPair
+	33, // 12: budget.v1.GoogleAuthResponse.user:type_name -> budget.v1.User
+	35, // 13: budget.v1.GoogleAuthResponse.memberships:type_name -> budget.v1.TenantMembership
+	34, // 14: budget.v1.GoogleAuthResponse.tenant:type_name -> budget.v1.Tenant
+	32, // 15: budget.v1.GoogleAuthResponse.challenge_expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 16: budget.v1.ExternalAuthResponse.tokens:type_name -> budget.v1.TokenPair
+	33, // 17: budget.v1.ExternalAuthResponse.user:type_name -> budget.v1.User
+	35, // 18: budget.v1.ExternalAuthResponse.memberships:type_name -> budget.v1.TenantMembership
+	34, // 19: budget.v1.ExternalAuthResponse.tenant:type_name -> budget.v1.Tenant
+	32, // 20: budget.v1.ExternalAuthResponse.challenge_expires_at:type_name -> google.protobuf.Timestamp
+	32, // 21: budget.v1.Identity.created_at:type_name -> google.protobuf.Timestamp
+	32, // 22: budget.v1.Identity.last_login_at:type_name -> google.protobuf.Timestamp
+	15, // 23: budget.v1.LinkIdentityResponse.identity:type_name -> budget.v1.Identity
+	15, // 24: budget.v1.ListIdentitiesResponse.identities:type_name -> budget.v1.Identity
+	0,  // 25: budget.v1.SwitchTenantResponse.tokens:type_name -> budget.v1.TokenPair
+	33, // 26: budget.v1.VerifyEmailResponse.user:type_name -> budget.v1.User
+	1,  // 27: budget.v1.AuthService.Register:input_type -> budget.v1.RegisterRequest
+	3,  // 28: budget.v1.AuthService.Login:input_type -> budget.v1.LoginRequest
+	5,  // 29: budget.v1.AuthService.CompleteTwoFactorLogin:input_type -> budget.v1.CompleteTwoFactorLoginRequest
+	9,  // 30: budget.v1.AuthService.GoogleAuth:input_type -> budget.v1.GoogleAuthRequest
+	11, // 31: budget.v1.AuthService.ExternalAuth:input_type -> budget.v1.ExternalAuthRequest
+	13, // 32: budget.v1.AuthService.ListIdentityProviders:input_type -> budget.v1.ListIdentityProvidersRequest
+	16, // 33: budget.v1.AuthService.LinkIdentity:input_type -> budget.v1.LinkIdentityRequest
+	18, // 34: budget.v1.AuthService.UnlinkIdentity:input_type -> budget.v1.UnlinkIdentityRequest
+	20, // 35: budget.v1.AuthService.ListIdentities:input_type -> budget.v1.ListIdentitiesRequest
+	7,  // 36: budget.v1.AuthService.RefreshToken:input_type -> budget.v1.RefreshTokenRequest
+	22, // 37: budget.v1.AuthService.RequestPasswordReset:input_type -> budget.v1.RequestPasswordResetRequest
+	24, // 38: budget.v1.AuthService.ResetPassword:input_type -> budget.v1.ResetPasswordRequest
+	26, // 39: budget.v1.AuthService.SwitchTenant:input_type -> budget.v1.SwitchTenantRequest
+	28, // 40: budget.v1.AuthService.VerifyEmail:input_type -> budget.v1.VerifyEmailRequest
+	30, // 41: budget.v1.AuthService.ResendVerification:input_type -> budget.v1.ResendVerificationRequest
+	2,  // 42: budget.v1.AuthService.Register:output_type -> budget.v1.RegisterResponse
+	4,  // 43: budget.v1.AuthService.Login:output_type -> budget.v1.LoginResponse
+	6,  // 44: budget.v1.AuthService.CompleteTwoFactorLogin:output_type -> budget.v1.CompleteTwoFactorLoginResponse
+	10, // 45: budget.v1.AuthService.GoogleAuth:output_type -> budget.v1.GoogleAuthResponse
+	12, // 46: budget.v1.AuthService.ExternalAuth:output_type -> budget.v1.ExternalAuthResponse
+	14, // 47: budget.v1.AuthService.ListIdentityProviders:output_type -> budget.v1.ListIdentityProvidersResponse
+	17, // 48: budget.v1.AuthService.LinkIdentity:output_type -> budget.v1.LinkIdentityResponse
+	19, // 49: budget.v1.AuthService.UnlinkIdentity:output_type -> budget.v1.UnlinkIdentityResponse
+	21, // 50: budget.v1.AuthService.ListIdentities:output_type -> budget.v1.ListIdentitiesResponse
+	8,  // 51: budget.v1.AuthService.RefreshToken:output_type -> budget.v1.RefreshTokenResponse
+	23, // 52: budget.v1.AuthService.RequestPasswordReset:output_type -> budget.v1.RequestPasswordResetResponse
+	25, // 53: budget.v1.AuthService.ResetPassword:output_type -> budget.v1.ResetPasswordResponse
+	27, // 54: budget.v1.AuthService.SwitchTenant:output_type -> budget.v1.SwitchTenantResponse
+	29, // 55: budget.v1.AuthService.VerifyEmail:output_type -> budget.v1.VerifyEmailResponse
+	31, // 56: budget.v1.AuthService.ResendVerification:output_type -> budget.v1.ResendVerificationResponse
+	42, // [42:57] is the sub-list for method output_type
+	27, // [27:42] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_budget_v1_auth_proto_init() }
@@ -859,7 +1971,7 @@ func file_budget_v1_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_budget_v1_auth_proto_rawDesc), len(file_budget_v1_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.9
+// 	protoc-gen-go v1.36.11
 // 	protoc        (unknown)
 // source: budget/v1/category.proto
 
@@ -92,6 +92,7 @@ type Category struct {
 	IsActive      bool                   `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	Translations  []*CategoryTranslation `protobuf:"bytes,8,rep,name=translations,proto3" json:"translations,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // set only for categories in the trash
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -182,6 +183,13 @@ func (x *Category) GetTranslations() []*CategoryTranslation {
 	return nil
 }
 
+func (x *Category) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 type CreateCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Kind          CategoryKind           `protobuf:"varint,1,opt,name=kind,proto3,enum=budget.v1.CategoryKind" json:"kind,omitempty"`
@@ -423,10 +431,13 @@ func (x *UpdateCategoryResponse) GetCategory() *Category {
 }
 
 type DeleteCategoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Moves the category's transactions to this category (same kind) before deleting.
+	// Without it a category that still has transactions can't be deleted.
+	ReassignToCategoryId string `protobuf:"bytes,2,opt,name=reassign_to_category_id,json=reassignToCategoryId,proto3" json:"reassign_to_category_id,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *DeleteCategoryRequest) Reset() {
@@ -466,6 +477,13 @@ func (x *DeleteCategoryRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteCategoryRequest) GetReassignToCategoryId() string {
+	if x != nil {
+		return x.ReassignToCategoryId
+	}
+	return ""
+}
+
 type DeleteCategoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -702,6 +720,183 @@ func (x *ListCategoriesResponse) GetCategories() []*Category {
 	return nil
 }
 
+// Trash: deleted categories are kept until the retention purge
+type ListDeletedCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedCategoriesRequest) Reset() {
+	*x = ListDeletedCategoriesRequest{}
+	mi := &file_budget_v1_category_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedCategoriesRequest) ProtoMessage() {}
+
+func (x *ListDeletedCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_category_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_category_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListDeletedCategoriesRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ListDeletedCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedCategoriesResponse) Reset() {
+	*x = ListDeletedCategoriesResponse{}
+	mi := &file_budget_v1_category_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedCategoriesResponse) ProtoMessage() {}
+
+func (x *ListDeletedCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_category_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_category_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListDeletedCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type RestoreCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCategoryRequest) Reset() {
+	*x = RestoreCategoryRequest{}
+	mi := &file_budget_v1_category_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCategoryRequest) ProtoMessage() {}
+
+func (x *RestoreCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_category_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCategoryRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_category_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RestoreCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCategoryResponse) Reset() {
+	*x = RestoreCategoryResponse{}
+	mi := &file_budget_v1_category_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCategoryResponse) ProtoMessage() {}
+
+func (x *RestoreCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_category_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCategoryResponse.ProtoReflect.Descriptor instead.
+func (*RestoreCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_category_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestoreCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
 var File_budget_v1_category_proto protoreflect.FileDescriptor
 
 const file_budget_v1_category_proto_rawDesc = "" +
@@ -710,7 +905,7 @@ const file_budget_v1_category_proto_rawDesc = "" +
 	"\x13CategoryTranslation\x12\x16\n" +
 	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\"\xb1\x02\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\xec\x02\n" +
 	"\bCategory\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12+\n" +
@@ -720,7 +915,9 @@ const file_budget_v1_category_proto_rawDesc = "" +
 	"\tis_active\x18\x06 \x01(\bR\bisActive\x129\n" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12B\n" +
-	"\ftranslations\x18\b \x03(\v2\x1e.budget.v1.CategoryTranslationR\ftranslations\"\xd6\x01\n" +
+	"\ftranslations\x18\b \x03(\v2\x1e.budget.v1.CategoryTranslationR\ftranslations\x129\n" +
+	"\n" +
+	"deleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xd6\x01\n" +
 	"\x15CreateCategoryRequest\x12+\n" +
 	"\x04kind\x18\x01 \x01(\x0e2\x17.budget.v1.CategoryKindR\x04kind\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1b\n" +
@@ -736,9 +933,10 @@ const file_budget_v1_category_proto_rawDesc = "" +
 	"\tis_active\x18\x04 \x01(\bR\bisActive\x12B\n" +
 	"\ftranslations\x18\x05 \x03(\v2\x1e.budget.v1.CategoryTranslationR\ftranslations\"I\n" +
 	"\x16UpdateCategoryResponse\x12/\n" +
-	"\bcategory\x18\x01 \x01(\v2\x13.budget.v1.CategoryR\bcategory\"'\n" +
+	"\bcategory\x18\x01 \x01(\v2\x13.budget.v1.CategoryR\bcategory\"^\n" +
 	"\x15DeleteCategoryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x125\n" +
+	"\x17reassign_to_category_id\x18\x02 \x01(\tR\x14reassignToCategoryId\"\x18\n" +
 	"\x16DeleteCategoryResponse\"<\n" +
 	"\x12GetCategoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
@@ -752,13 +950,25 @@ const file_budget_v1_category_proto_rawDesc = "" +
 	"\x16ListCategoriesResponse\x123\n" +
 	"\n" +
 	"categories\x18\x01 \x03(\v2\x13.budget.v1.CategoryR\n" +
-	"categories2\xbb\x03\n" +
+	"categories\"6\n" +
+	"\x1cListDeletedCategoriesRequest\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\"T\n" +
+	"\x1dListDeletedCategoriesResponse\x123\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x13.budget.v1.CategoryR\n" +
+	"categories\"(\n" +
+	"\x16RestoreCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
+	"\x17RestoreCategoryResponse\x12/\n" +
+	"\bcategory\x18\x01 \x01(\v2\x13.budget.v1.CategoryR\bcategory2\x81\x05\n" +
 	"\x0fCategoryService\x12U\n" +
 	"\x0eCreateCategory\x12 .budget.v1.CreateCategoryRequest\x1a!.budget.v1.CreateCategoryResponse\x12U\n" +
 	"\x0eUpdateCategory\x12 .budget.v1.UpdateCategoryRequest\x1a!.budget.v1.UpdateCategoryResponse\x12U\n" +
 	"\x0eDeleteCategory\x12 .budget.v1.DeleteCategoryRequest\x1a!.budget.v1.DeleteCategoryResponse\x12L\n" +
 	"\vGetCategory\x12\x1d.budget.v1.GetCategoryRequest\x1a\x1e.budget.v1.GetCategoryResponse\x12U\n" +
-	"\x0eListCategories\x12 .budget.v1.ListCategoriesRequest\x1a!.budget.v1.ListCategoriesResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
+	"\x0eListCategories\x12 .budget.v1.ListCategoriesRequest\x1a!.budget.v1.ListCategoriesResponse\x12j\n" +
+	"\x15ListDeletedCategories\x12'.budget.v1.ListDeletedCategoriesRequest\x1a(.budget.v1.ListDeletedCategoriesResponse\x12X\n" +
+	"\x0fRestoreCategory\x12!.budget.v1.RestoreCategoryRequest\x1a\".budget.v1.RestoreCategoryResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
 
 var (
 	file_budget_v1_category_proto_rawDescOnce sync.Once
@@ -772,50 +982,61 @@ func file_budget_v1_category_proto_rawDescGZIP() []byte {
 	return file_budget_v1_category_proto_rawDescData
 }
 
-var file_budget_v1_category_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_budget_v1_category_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_budget_v1_category_proto_goTypes = []any{
-	(*CategoryTranslation)(nil),    // 0: budget.v1.CategoryTranslation
-	(*Category)(nil),               // 1: budget.v1.Category
-	(*CreateCategoryRequest)(nil),  // 2: budget.v1.CreateCategoryRequest
-	(*CreateCategoryResponse)(nil), // 3: budget.v1.CreateCategoryResponse
-	(*UpdateCategoryRequest)(nil),  // 4: budget.v1.UpdateCategoryRequest
-	(*UpdateCategoryResponse)(nil), // 5: budget.v1.UpdateCategoryResponse
-	(*DeleteCategoryRequest)(nil),  // 6: budget.v1.DeleteCategoryRequest
-	(*DeleteCategoryResponse)(nil), // 7: budget.v1.DeleteCategoryResponse
-	(*GetCategoryRequest)(nil),     // 8: budget.v1.GetCategoryRequest
-	(*GetCategoryResponse)(nil),    // 9: budget.v1.GetCategoryResponse
-	(*ListCategoriesRequest)(nil),  // 10: budget.v1.ListCategoriesRequest
-	(*ListCategoriesResponse)(nil), // 11: budget.v1.ListCategoriesResponse
-	(CategoryKind)(0),              // 12: budget.v1.CategoryKind
-	(*timestamppb.Timestamp)(nil),  // 13: google.protobuf.Timestamp
+	(*CategoryTranslation)(nil),           // 0: budget.v1.CategoryTranslation
+	(*Category)(nil),                      // 1: budget.v1.Category
+	(*CreateCategoryRequest)(nil),         // 2: budget.v1.CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),        // 3: budget.v1.CreateCategoryResponse
+	(*UpdateCategoryRequest)(nil),         // 4: budget.v1.UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),        // 5: budget.v1.UpdateCategoryResponse
+	(*DeleteCategoryRequest)(nil),         // 6: budget.v1.DeleteCategoryRequest
+	(*DeleteCategoryResponse)(nil),        // 7: budget.v1.DeleteCategoryResponse
+	(*GetCategoryRequest)(nil),            // 8: budget.v1.GetCategoryRequest
+	(*GetCategoryResponse)(nil),           // 9: budget.v1.GetCategoryResponse
+	(*ListCategoriesRequest)(nil),         // 10: budget.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),        // 11: budget.v1.ListCategoriesResponse
+	(*ListDeletedCategoriesRequest)(nil),  // 12: budget.v1.ListDeletedCategoriesRequest
+	(*ListDeletedCategoriesResponse)(nil), // 13: budget.v1.ListDeletedCategoriesResponse
+	(*RestoreCategoryRequest)(nil),        // 14: budget.v1.RestoreCategoryRequest
+	(*RestoreCategoryResponse)(nil),       // 15: budget.v1.RestoreCategoryResponse
+	(CategoryKind)(0),                     // 16: budget.v1.CategoryKind
+	(*timestamppb.Timestamp)(nil),         // 17: google.protobuf.Timestamp
 }
 var file_budget_v1_category_proto_depIdxs = []int32{
-	12, // 0: budget.v1.Category.kind:type_name -> budget.v1.CategoryKind
-	13, // 1: budget.v1.Category.created_at:type_name -> google.protobuf.Timestamp
+	16, // 0: budget.v1.Category.kind:type_name -> budget.v1.CategoryKind
+	17, // 1: budget.v1.Category.created_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: budget.v1.Category.translations:type_name -> budget.v1.CategoryTranslation
-	12, // 3: budget.v1.CreateCategoryRequest.kind:type_name -> budget.v1.CategoryKind
-	0,  // 4: budget.v1.CreateCategoryRequest.translations:type_name -> budget.v1.CategoryTranslation
-	1,  // 5: budget.v1.CreateCategoryResponse.category:type_name -> budget.v1.Category
-	0,  // 6: budget.v1.UpdateCategoryRequest.translations:type_name -> budget.v1.CategoryTranslation
-	1,  // 7: budget.v1.UpdateCategoryResponse.category:type_name -> budget.v1.Category
-	1,  // 8: budget.v1.GetCategoryResponse.category:type_name -> budget.v1.Category
-	12, // 9: budget.v1.ListCategoriesRequest.kind:type_name -> budget.v1.CategoryKind
-	1,  // 10: budget.v1.ListCategoriesResponse.categories:type_name -> budget.v1.Category
-	2,  // 11: budget.v1.CategoryService.CreateCategory:input_type -> budget.v1.CreateCategoryRequest
-	4,  // 12: budget.v1.CategoryService.UpdateCategory:input_type -> budget.v1.UpdateCategoryRequest
-	6,  // 13: budget.v1.CategoryService.DeleteCategory:input_type -> budget.v1.DeleteCategoryRequest
-	8,  // 14: budget.v1.CategoryService.GetCategory:input_type -> budget.v1.GetCategoryRequest
-	10, // 15: budget.v1.CategoryService.ListCategories:input_type -> budget.v1.ListCategoriesRequest
-	3,  // 16: budget.v1.CategoryService.CreateCategory:output_type -> budget.v1.CreateCategoryResponse
-	5,  // 17: budget.v1.CategoryService.UpdateCategory:output_type -> budget.v1.UpdateCategoryResponse
-	7,  // 18: budget.v1.CategoryService.DeleteCategory:output_type -> budget.v1.DeleteCategoryResponse
-	9,  // 19: budget.v1.CategoryService.GetCategory:output_type -> budget.v1.GetCategoryResponse
-	11, // 20: budget.v1.CategoryService.ListCategories:output_type -> budget.v1.ListCategoriesResponse
-	16, // [16:21] is the sub-list for method output_type
-	11, // [11:16] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	17, // 3: budget.v1.Category.deleted_at:type_name -> google.protobuf.Timestamp
+	16, // 4: budget.v1.CreateCategoryRequest.kind:type_name -> budget.v1.CategoryKind
+	0,  // 5: budget.v1.CreateCategoryRequest.translations:type_name -> budget.v1.CategoryTranslation
+	1,  // 6: budget.v1.CreateCategoryResponse.category:type_name -> budget.v1.Category
+	0,  // 7: budget.v1.UpdateCategoryRequest.translations:type_name -> budget.v1.CategoryTranslation
+	1,  // 8: budget.v1.UpdateCategoryResponse.category:type_name -> budget.v1.Category
+	1,  // 9: budget.v1.GetCategoryResponse.category:type_name -> budget.v1.Category
+	16, // 10: budget.v1.ListCategoriesRequest.kind:type_name -> budget.v1.CategoryKind
+	1,  // 11: budget.v1.ListCategoriesResponse.categories:type_name -> budget.v1.Category
+	1,  // 12: budget.v1.ListDeletedCategoriesResponse.categories:type_name -> budget.v1.Category
+	1,  // 13: budget.v1.RestoreCategoryResponse.category:type_name -> budget.v1.Category
+	2,  // 14: budget.v1.CategoryService.CreateCategory:input_type -> budget.v1.CreateCategoryRequest
+	4,  // 15: budget.v1.CategoryService.UpdateCategory:input_type -> budget.v1.UpdateCategoryRequest
+	6,  // 16: budget.v1.CategoryService.DeleteCategory:input_type -> budget.v1.DeleteCategoryRequest
+	8,  // 17: budget.v1.CategoryService.GetCategory:input_type -> budget.v1.GetCategoryRequest
+	10, // 18: budget.v1.CategoryService.ListCategories:input_type -> budget.v1.ListCategoriesRequest
+	12, // 19: budget.v1.CategoryService.ListDeletedCategories:input_type -> budget.v1.ListDeletedCategoriesRequest
+	14, // 20: budget.v1.CategoryService.RestoreCategory:input_type -> budget.v1.RestoreCategoryRequest
+	3,  // 21: budget.v1.CategoryService.CreateCategory:output_type -> budget.v1.CreateCategoryResponse
+	5,  // 22: budget.v1.CategoryService.UpdateCategory:output_type -> budget.v1.UpdateCategoryResponse
+	7,  // 23: budget.v1.CategoryService.DeleteCategory:output_type -> budget.v1.DeleteCategoryResponse
+	9,  // 24: budget.v1.CategoryService.GetCategory:output_type -> budget.v1.GetCategoryResponse
+	11, // 25: budget.v1.CategoryService.ListCategories:output_type -> budget.v1.ListCategoriesResponse
+	13, // 26: budget.v1.CategoryService.ListDeletedCategories:output_type -> budget.v1.ListDeletedCategoriesResponse
+	15, // 27: budget.v1.CategoryService.RestoreCategory:output_type -> budget.v1.RestoreCategoryResponse
+	21, // [21:28] is the sub-list for method output_type
+	14, // [14:21] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_budget_v1_category_proto_init() }
@@ -830,7 +1051,7 @@ func file_budget_v1_category_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_budget_v1_category_proto_rawDesc), len(file_budget_v1_category_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.5.1
+// - protoc-gen-go-grpc v1.6.1
 // - protoc             (unknown)
 // source: budget/v1/category.proto
 
@@ -19,11 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CategoryService_CreateCategory_FullMethodName = "/budget.v1.CategoryService/CreateCategory"
-	CategoryService_UpdateCategory_FullMethodName = "/budget.v1.CategoryService/UpdateCategory"
-	CategoryService_DeleteCategory_FullMethodName = "/budget.v1.CategoryService/DeleteCategory"
-	CategoryService_GetCategory_FullMethodName    = "/budget.v1.CategoryService/GetCategory"
-	CategoryService_ListCategories_FullMethodName = "/budget.v1.CategoryService/ListCategories"
+	CategoryService_CreateCategory_FullMethodName        = "/budget.v1.CategoryService/CreateCategory"
+	CategoryService_UpdateCategory_FullMethodName        = "/budget.v1.CategoryService/UpdateCategory"
+	CategoryService_DeleteCategory_FullMethodName        = "/budget.v1.CategoryService/DeleteCategory"
+	CategoryService_GetCategory_FullMethodName           = "/budget.v1.CategoryService/GetCategory"
+	CategoryService_ListCategories_FullMethodName        = "/budget.v1.CategoryService/ListCategories"
+	CategoryService_ListDeletedCategories_FullMethodName = "/budget.v1.CategoryService/ListDeletedCategories"
+	CategoryService_RestoreCategory_FullMethodName       = "/budget.v1.CategoryService/RestoreCategory"
 )
 
 // CategoryServiceClient is the client API for CategoryService service.
@@ -35,6 +37,8 @@ type CategoryServiceClient interface {
 	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
 	GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error)
 	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	ListDeletedCategories(ctx context.Context, in *ListDeletedCategoriesRequest, opts ...grpc.CallOption) (*ListDeletedCategoriesResponse, error)
+	RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*RestoreCategoryResponse, error)
 }
 
 type categoryServiceClient struct {
@@ -95,6 +99,26 @@ func (c *categoryServiceClient) ListCategories(ctx context.Context, in *ListCate
 	return out, nil
 }
 
+func (c *categoryServiceClient) ListDeletedCategories(ctx context.Context, in *ListDeletedCategoriesRequest, opts ...grpc.CallOption) (*ListDeletedCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedCategoriesResponse)
+	err := c.cc.Invoke(ctx, CategoryService_ListDeletedCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*RestoreCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreCategoryResponse)
+	err := c.cc.Invoke(ctx, CategoryService_RestoreCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CategoryServiceServer is the server API for CategoryService service.
 // All implementations must embed UnimplementedCategoryServiceServer
 // for forward compatibility.
@@ -104,6 +128,8 @@ type CategoryServiceServer interface {
 	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
 	GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error)
 	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	ListDeletedCategories(context.Context, *ListDeletedCategoriesRequest) (*ListDeletedCategoriesResponse, error)
+	RestoreCategory(context.Context, *RestoreCategoryRequest) (*RestoreCategoryResponse, error)
 	mustEmbedUnimplementedCategoryServiceServer()
 }
 
@@ -115,19 +141,25 @@ type CategoryServiceServer interface {
 type UnimplementedCategoryServiceServer struct{}
 
 func (UnimplementedCategoryServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+	return nil, status.Error(codes.Unimplemented, "method CreateCategory not implemented")
 }
 func (UnimplementedCategoryServiceServer) UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
+	return nil, status.Error(codes.Unimplemented, "method UpdateCategory not implemented")
 }
 func (UnimplementedCategoryServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+	return nil, status.Error(codes.Unimplemented, "method DeleteCategory not implemented")
 }
 func (UnimplementedCategoryServiceServer) GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCategory not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GetCategory not implemented")
 }
 func (UnimplementedCategoryServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+	return nil, status.Error(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedCategoryServiceServer) ListDeletedCategories(context.Context, *ListDeletedCategoriesRequest) (*ListDeletedCategoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeletedCategories not implemented")
+}
+func (UnimplementedCategoryServiceServer) RestoreCategory(context.Context, *RestoreCategoryRequest) (*RestoreCategoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreCategory not implemented")
 }
 func (UnimplementedCategoryServiceServer) mustEmbedUnimplementedCategoryServiceServer() {}
 func (UnimplementedCategoryServiceServer) testEmbeddedByValue()                         {}
@@ -140,7 +172,7 @@ type UnsafeCategoryServiceServer interface {
 }
 
 func RegisterCategoryServiceServer(s grpc.ServiceRegistrar, srv CategoryServiceServer) {
-	// If the following call pancis, it indicates UnimplementedCategoryServiceServer was
+	// If the following call panics, it indicates UnimplementedCategoryServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
@@ -240,6 +272,42 @@ func _CategoryService_ListCategories_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CategoryService_ListDeletedCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).ListDeletedCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_ListDeletedCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).ListDeletedCategories(ctx, req.(*ListDeletedCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_RestoreCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).RestoreCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_RestoreCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).RestoreCategory(ctx, req.(*RestoreCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CategoryService_ServiceDesc is the grpc.ServiceDesc for CategoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +335,14 @@ var CategoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCategories",
 			Handler:    _CategoryService_ListCategories_Handler,
 		},
+		{
+			MethodName: "ListDeletedCategories",
+			Handler:    _CategoryService_ListDeletedCategories_Handler,
+		},
+		{
+			MethodName: "RestoreCategory",
+			Handler:    _CategoryService_RestoreCategory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "budget/v1/category.proto",
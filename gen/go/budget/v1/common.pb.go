@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.9
+// 	protoc-gen-go v1.36.11
 // 	protoc        (unknown)
 // source: budget/v1/common.proto
 
@@ -122,9 +122,11 @@ func (CategoryKind) EnumDescriptor() ([]byte, []int) {
 
 // Generic money representation using minor currency units (e.g., cents)
 type Money struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CurrencyCode  string                 `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"` // ISO 4217, e.g. "USD", "EUR", "RUB"
-	MinorUnits    int64                  `protobuf:"varint,2,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`      // integer number of minor units (cents/kopecks)
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CurrencyCode string                 `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"` // ISO 4217, e.g. "USD", "EUR", "RUB"
+	// Integer amount times 10^exponent of the currency: 123.45 USD = 12345, 500 JPY = 500,
+	// 1.250 KWD = 1250. Unknown codes use 2 decimals.
+	MinorUnits    int64 `protobuf:"varint,2,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.11
-// 	protoc        v7.34.1
+// 	protoc        (unknown)
 // source: budget/v1/report.proto
 
 package budgetv1
@@ -97,6 +97,7 @@ type GetMonthlySummaryRequest struct {
 	TargetCurrencyCode    string                 `protobuf:"bytes,4,opt,name=target_currency_code,json=targetCurrencyCode,proto3" json:"target_currency_code,omitempty"`           // if empty, use tenant default currency
 	TimezoneOffsetMinutes int32                  `protobuf:"varint,5,opt,name=timezone_offset_minutes,json=timezoneOffsetMinutes,proto3" json:"timezone_offset_minutes,omitempty"` // minutes to add to local time to get UTC (JS getTimezoneOffset)
 	ExcludeExtraordinary  bool                   `protobuf:"varint,6,opt,name=exclude_extraordinary,json=excludeExtraordinary,proto3" json:"exclude_extraordinary,omitempty"`      // exclude one-off operations from the report
+	ViewId                string                 `protobuf:"bytes,7,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`                                                 // saved view narrowing the transactions; the report's own period wins over the view's
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -173,6 +174,13 @@ func (x *GetMonthlySummaryRequest) GetExcludeExtraordinary() bool {
 	return false
 }
 
+func (x *GetMonthlySummaryRequest) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
 type GetMonthlySummaryResponse struct {
 	state         protoimpl.MessageState        `protogen:"open.v1"`
 	Items         []*MonthlyCategorySummaryItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -317,6 +325,7 @@ type GetSummaryReportRequest struct {
 	TargetCurrencyCode    string                 `protobuf:"bytes,4,opt,name=target_currency_code,json=targetCurrencyCode,proto3" json:"target_currency_code,omitempty"`           // if empty, use tenant default currency
 	TimezoneOffsetMinutes int32                  `protobuf:"varint,5,opt,name=timezone_offset_minutes,json=timezoneOffsetMinutes,proto3" json:"timezone_offset_minutes,omitempty"` // minutes to add to local time to get UTC (JS getTimezoneOffset)
 	ExcludeExtraordinary  bool                   `protobuf:"varint,6,opt,name=exclude_extraordinary,json=excludeExtraordinary,proto3" json:"exclude_extraordinary,omitempty"`      // exclude one-off operations from the report
+	ViewId                string                 `protobuf:"bytes,7,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`                                                 // saved view narrowing the transactions; the report's own period wins over the view's
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -393,6 +402,13 @@ func (x *GetSummaryReportRequest) GetExcludeExtraordinary() bool {
 	return false
 }
 
+func (x *GetSummaryReportRequest) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
 type GetSummaryReportResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Categories    []*MonthlyCategoryData `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
@@ -575,14 +591,15 @@ const file_budget_v1_report_proto_rawDesc = "" +
 	"categoryId\x12#\n" +
 	"\rcategory_name\x18\x02 \x01(\tR\fcategoryName\x12.\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x1a.budget.v1.TransactionTypeR\x04type\x12&\n" +
-	"\x05total\x18\x04 \x01(\v2\x10.budget.v1.MoneyR\x05total\"\xfb\x01\n" +
+	"\x05total\x18\x04 \x01(\v2\x10.budget.v1.MoneyR\x05total\"\x94\x02\n" +
 	"\x18GetMonthlySummaryRequest\x12\x12\n" +
 	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
 	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x16\n" +
 	"\x06locale\x18\x03 \x01(\tR\x06locale\x120\n" +
 	"\x14target_currency_code\x18\x04 \x01(\tR\x12targetCurrencyCode\x126\n" +
 	"\x17timezone_offset_minutes\x18\x05 \x01(\x05R\x15timezoneOffsetMinutes\x123\n" +
-	"\x15exclude_extraordinary\x18\x06 \x01(\bR\x14excludeExtraordinary\"\xc4\x01\n" +
+	"\x15exclude_extraordinary\x18\x06 \x01(\bR\x14excludeExtraordinary\x12\x17\n" +
+	"\aview_id\x18\a \x01(\tR\x06viewId\"\xc4\x01\n" +
 	"\x19GetMonthlySummaryResponse\x12;\n" +
 	"\x05items\x18\x01 \x03(\v2%.budget.v1.MonthlyCategorySummaryItemR\x05items\x123\n" +
 	"\ftotal_income\x18\x02 \x01(\v2\x10.budget.v1.MoneyR\vtotalIncome\x125\n" +
@@ -593,14 +610,15 @@ const file_budget_v1_report_proto_rawDesc = "" +
 	"\rcategory_name\x18\x02 \x01(\tR\fcategoryName\x12.\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x1a.budget.v1.TransactionTypeR\x04type\x127\n" +
 	"\x0emonthly_totals\x18\x04 \x03(\v2\x10.budget.v1.MoneyR\rmonthlyTotals\x12&\n" +
-	"\x05total\x18\x05 \x01(\v2\x10.budget.v1.MoneyR\x05total\"\x86\x02\n" +
+	"\x05total\x18\x05 \x01(\v2\x10.budget.v1.MoneyR\x05total\"\x9f\x02\n" +
 	"\x17GetSummaryReportRequest\x12\x1b\n" +
 	"\tfrom_date\x18\x01 \x01(\tR\bfromDate\x12\x17\n" +
 	"\ato_date\x18\x02 \x01(\tR\x06toDate\x12\x16\n" +
 	"\x06locale\x18\x03 \x01(\tR\x06locale\x120\n" +
 	"\x14target_currency_code\x18\x04 \x01(\tR\x12targetCurrencyCode\x126\n" +
 	"\x17timezone_offset_minutes\x18\x05 \x01(\x05R\x15timezoneOffsetMinutes\x123\n" +
-	"\x15exclude_extraordinary\x18\x06 \x01(\bR\x14excludeExtraordinary\"\xde\x01\n" +
+	"\x15exclude_extraordinary\x18\x06 \x01(\bR\x14excludeExtraordinary\x12\x17\n" +
+	"\aview_id\x18\a \x01(\tR\x06viewId\"\xde\x01\n" +
 	"\x18GetSummaryReportResponse\x12>\n" +
 	"\n" +
 	"categories\x18\x01 \x03(\v2\x1e.budget.v1.MonthlyCategoryDataR\n" +
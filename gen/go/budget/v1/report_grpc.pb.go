@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.5.1
+// - protoc-gen-go-grpc v1.6.1
 // - protoc             (unknown)
 // source: budget/v1/report.proto
 
@@ -89,13 +89,13 @@ type ReportServiceServer interface {
 type UnimplementedReportServiceServer struct{}
 
 func (UnimplementedReportServiceServer) GetMonthlySummary(context.Context, *GetMonthlySummaryRequest) (*GetMonthlySummaryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMonthlySummary not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GetMonthlySummary not implemented")
 }
 func (UnimplementedReportServiceServer) GetSummaryReport(context.Context, *GetSummaryReportRequest) (*GetSummaryReportResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSummaryReport not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GetSummaryReport not implemented")
 }
 func (UnimplementedReportServiceServer) GetDateRange(context.Context, *GetDateRangeRequest) (*GetDateRangeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDateRange not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GetDateRange not implemented")
 }
 func (UnimplementedReportServiceServer) mustEmbedUnimplementedReportServiceServer() {}
 func (UnimplementedReportServiceServer) testEmbeddedByValue()                       {}
@@ -108,7 +108,7 @@ type UnsafeReportServiceServer interface {
 }
 
 func RegisterReportServiceServer(s grpc.ServiceRegistrar, srv ReportServiceServer) {
-	// If the following call pancis, it indicates UnimplementedReportServiceServer was
+	// If the following call panics, it indicates UnimplementedReportServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
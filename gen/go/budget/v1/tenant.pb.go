@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.9
+// 	protoc-gen-go v1.36.11
 // 	protoc        (unknown)
 // source: budget/v1/tenant.proto
 
@@ -74,6 +74,120 @@ func (TenantRole) EnumDescriptor() ([]byte, []int) {
 	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{0}
 }
 
+// Invitations
+type InvitationStatus int32
+
+const (
+	InvitationStatus_INVITATION_STATUS_UNSPECIFIED InvitationStatus = 0
+	InvitationStatus_INVITATION_STATUS_PENDING     InvitationStatus = 1
+	InvitationStatus_INVITATION_STATUS_ACCEPTED    InvitationStatus = 2
+	InvitationStatus_INVITATION_STATUS_DECLINED    InvitationStatus = 3
+	InvitationStatus_INVITATION_STATUS_REVOKED     InvitationStatus = 4
+	InvitationStatus_INVITATION_STATUS_EXPIRED     InvitationStatus = 5
+)
+
+// Enum value maps for InvitationStatus.
+var (
+	InvitationStatus_name = map[int32]string{
+		0: "INVITATION_STATUS_UNSPECIFIED",
+		1: "INVITATION_STATUS_PENDING",
+		2: "INVITATION_STATUS_ACCEPTED",
+		3: "INVITATION_STATUS_DECLINED",
+		4: "INVITATION_STATUS_REVOKED",
+		5: "INVITATION_STATUS_EXPIRED",
+	}
+	InvitationStatus_value = map[string]int32{
+		"INVITATION_STATUS_UNSPECIFIED": 0,
+		"INVITATION_STATUS_PENDING":     1,
+		"INVITATION_STATUS_ACCEPTED":    2,
+		"INVITATION_STATUS_DECLINED":    3,
+		"INVITATION_STATUS_REVOKED":     4,
+		"INVITATION_STATUS_EXPIRED":     5,
+	}
+)
+
+func (x InvitationStatus) Enum() *InvitationStatus {
+	p := new(InvitationStatus)
+	*p = x
+	return p
+}
+
+func (x InvitationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InvitationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_budget_v1_tenant_proto_enumTypes[1].Descriptor()
+}
+
+func (InvitationStatus) Type() protoreflect.EnumType {
+	return &file_budget_v1_tenant_proto_enumTypes[1]
+}
+
+func (x InvitationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InvitationStatus.Descriptor instead.
+func (InvitationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{1}
+}
+
+type AuditAction int32
+
+const (
+	AuditAction_AUDIT_ACTION_UNSPECIFIED AuditAction = 0
+	AuditAction_AUDIT_ACTION_CREATED     AuditAction = 1
+	AuditAction_AUDIT_ACTION_UPDATED     AuditAction = 2
+	AuditAction_AUDIT_ACTION_DELETED     AuditAction = 3
+	AuditAction_AUDIT_ACTION_RESTORED    AuditAction = 4 // brought back from the trash
+)
+
+// Enum value maps for AuditAction.
+var (
+	AuditAction_name = map[int32]string{
+		0: "AUDIT_ACTION_UNSPECIFIED",
+		1: "AUDIT_ACTION_CREATED",
+		2: "AUDIT_ACTION_UPDATED",
+		3: "AUDIT_ACTION_DELETED",
+		4: "AUDIT_ACTION_RESTORED",
+	}
+	AuditAction_value = map[string]int32{
+		"AUDIT_ACTION_UNSPECIFIED": 0,
+		"AUDIT_ACTION_CREATED":     1,
+		"AUDIT_ACTION_UPDATED":     2,
+		"AUDIT_ACTION_DELETED":     3,
+		"AUDIT_ACTION_RESTORED":    4,
+	}
+)
+
+func (x AuditAction) Enum() *AuditAction {
+	p := new(AuditAction)
+	*p = x
+	return p
+}
+
+func (x AuditAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_budget_v1_tenant_proto_enumTypes[2].Descriptor()
+}
+
+func (AuditAction) Type() protoreflect.EnumType {
+	return &file_budget_v1_tenant_proto_enumTypes[2]
+}
+
+func (x AuditAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditAction.Descriptor instead.
+func (AuditAction) EnumDescriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{2}
+}
+
 type Tenant struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                // UUID
@@ -81,6 +195,8 @@ type Tenant struct {
 	Slug                string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`                                                            // URL-friendly identifier
 	DefaultCurrencyCode string                 `protobuf:"bytes,4,opt,name=default_currency_code,json=defaultCurrencyCode,proto3" json:"default_currency_code,omitempty"` // e.g. "USD", "RUB"
 	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeletionScheduledAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3" json:"deletion_scheduled_at,omitempty"` // set when the tenant is scheduled for deletion
+	CurrencyCodes       []string               `protobuf:"bytes,7,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"`                     // pinned currencies; empty allows every ISO 4217 code
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -150,6 +266,20 @@ func (x *Tenant) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Tenant) GetDeletionScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletionScheduledAt
+	}
+	return nil
+}
+
+func (x *Tenant) GetCurrencyCodes() []string {
+	if x != nil {
+		return x.CurrencyCodes
+	}
+	return nil
+}
+
 type TenantMembership struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tenant        *Tenant                `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
@@ -507,6 +637,104 @@ func (x *UpdateTenantResponse) GetTenant() *Tenant {
 	return nil
 }
 
+// Pin the currencies the tenant actually uses (owner/admin). New transactions are
+// limited to these codes and the default currency; an empty list lifts the restriction.
+type SetTenantCurrenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	CurrencyCodes []string               `protobuf:"bytes,2,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"` // ISO 4217 codes, e.g. ["RUB", "USD", "EUR"]
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTenantCurrenciesRequest) Reset() {
+	*x = SetTenantCurrenciesRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTenantCurrenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTenantCurrenciesRequest) ProtoMessage() {}
+
+func (x *SetTenantCurrenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTenantCurrenciesRequest.ProtoReflect.Descriptor instead.
+func (*SetTenantCurrenciesRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetTenantCurrenciesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SetTenantCurrenciesRequest) GetCurrencyCodes() []string {
+	if x != nil {
+		return x.CurrencyCodes
+	}
+	return nil
+}
+
+type SetTenantCurrenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        *Tenant                `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTenantCurrenciesResponse) Reset() {
+	*x = SetTenantCurrenciesResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTenantCurrenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTenantCurrenciesResponse) ProtoMessage() {}
+
+func (x *SetTenantCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTenantCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*SetTenantCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetTenantCurrenciesResponse) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
 // Members management
 type TenantMember struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -519,7 +747,7 @@ type TenantMember struct {
 
 func (x *TenantMember) Reset() {
 	*x = TenantMember{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[8]
+	mi := &file_budget_v1_tenant_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -531,7 +759,7 @@ func (x *TenantMember) String() string {
 func (*TenantMember) ProtoMessage() {}
 
 func (x *TenantMember) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[8]
+	mi := &file_budget_v1_tenant_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -544,7 +772,7 @@ func (x *TenantMember) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TenantMember.ProtoReflect.Descriptor instead.
 func (*TenantMember) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{8}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *TenantMember) GetUser() *User {
@@ -577,7 +805,7 @@ type ListMembersRequest struct {
 
 func (x *ListMembersRequest) Reset() {
 	*x = ListMembersRequest{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[9]
+	mi := &file_budget_v1_tenant_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -589,7 +817,7 @@ func (x *ListMembersRequest) String() string {
 func (*ListMembersRequest) ProtoMessage() {}
 
 func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[9]
+	mi := &file_budget_v1_tenant_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,7 +830,7 @@ func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
 func (*ListMembersRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{9}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListMembersRequest) GetTenantId() string {
@@ -621,7 +849,7 @@ type ListMembersResponse struct {
 
 func (x *ListMembersResponse) Reset() {
 	*x = ListMembersResponse{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[10]
+	mi := &file_budget_v1_tenant_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -633,7 +861,7 @@ func (x *ListMembersResponse) String() string {
 func (*ListMembersResponse) ProtoMessage() {}
 
 func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[10]
+	mi := &file_budget_v1_tenant_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -646,7 +874,7 @@ func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
 func (*ListMembersResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{10}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListMembersResponse) GetMembers() []*TenantMember {
@@ -667,7 +895,7 @@ type AddMemberRequest struct {
 
 func (x *AddMemberRequest) Reset() {
 	*x = AddMemberRequest{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[11]
+	mi := &file_budget_v1_tenant_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -679,7 +907,7 @@ func (x *AddMemberRequest) String() string {
 func (*AddMemberRequest) ProtoMessage() {}
 
 func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[11]
+	mi := &file_budget_v1_tenant_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +920,7 @@ func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddMemberRequest.ProtoReflect.Descriptor instead.
 func (*AddMemberRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{11}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AddMemberRequest) GetTenantId() string {
@@ -725,7 +953,7 @@ type AddMemberResponse struct {
 
 func (x *AddMemberResponse) Reset() {
 	*x = AddMemberResponse{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[12]
+	mi := &file_budget_v1_tenant_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -737,7 +965,7 @@ func (x *AddMemberResponse) String() string {
 func (*AddMemberResponse) ProtoMessage() {}
 
 func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[12]
+	mi := &file_budget_v1_tenant_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +978,7 @@ func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddMemberResponse.ProtoReflect.Descriptor instead.
 func (*AddMemberResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{12}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AddMemberResponse) GetMember() *TenantMember {
@@ -771,7 +999,7 @@ type UpdateMemberRoleRequest struct {
 
 func (x *UpdateMemberRoleRequest) Reset() {
 	*x = UpdateMemberRoleRequest{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[13]
+	mi := &file_budget_v1_tenant_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -783,7 +1011,7 @@ func (x *UpdateMemberRoleRequest) String() string {
 func (*UpdateMemberRoleRequest) ProtoMessage() {}
 
 func (x *UpdateMemberRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[13]
+	mi := &file_budget_v1_tenant_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -796,7 +1024,7 @@ func (x *UpdateMemberRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMemberRoleRequest.ProtoReflect.Descriptor instead.
 func (*UpdateMemberRoleRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{13}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UpdateMemberRoleRequest) GetTenantId() string {
@@ -829,7 +1057,7 @@ type UpdateMemberRoleResponse struct {
 
 func (x *UpdateMemberRoleResponse) Reset() {
 	*x = UpdateMemberRoleResponse{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[14]
+	mi := &file_budget_v1_tenant_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -841,7 +1069,7 @@ func (x *UpdateMemberRoleResponse) String() string {
 func (*UpdateMemberRoleResponse) ProtoMessage() {}
 
 func (x *UpdateMemberRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[14]
+	mi := &file_budget_v1_tenant_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -854,7 +1082,7 @@ func (x *UpdateMemberRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMemberRoleResponse.ProtoReflect.Descriptor instead.
 func (*UpdateMemberRoleResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{14}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UpdateMemberRoleResponse) GetMember() *TenantMember {
@@ -874,7 +1102,7 @@ type RemoveMemberRequest struct {
 
 func (x *RemoveMemberRequest) Reset() {
 	*x = RemoveMemberRequest{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[15]
+	mi := &file_budget_v1_tenant_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -886,7 +1114,7 @@ func (x *RemoveMemberRequest) String() string {
 func (*RemoveMemberRequest) ProtoMessage() {}
 
 func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[15]
+	mi := &file_budget_v1_tenant_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -899,7 +1127,7 @@ func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
 func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{15}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RemoveMemberRequest) GetTenantId() string {
@@ -924,7 +1152,7 @@ type RemoveMemberResponse struct {
 
 func (x *RemoveMemberResponse) Reset() {
 	*x = RemoveMemberResponse{}
-	mi := &file_budget_v1_tenant_proto_msgTypes[16]
+	mi := &file_budget_v1_tenant_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -936,7 +1164,7 @@ func (x *RemoveMemberResponse) String() string {
 func (*RemoveMemberResponse) ProtoMessage() {}
 
 func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_tenant_proto_msgTypes[16]
+	mi := &file_budget_v1_tenant_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,81 +1177,1496 @@ func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
 func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{16}
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{18}
 }
 
-var File_budget_v1_tenant_proto protoreflect.FileDescriptor
+type TenantInvitation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tenant        *Tenant                `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role          TenantRole             `protobuf:"varint,4,opt,name=role,proto3,enum=budget.v1.TenantRole" json:"role,omitempty"`
+	InvitedBy     *User                  `protobuf:"bytes,5,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	Status        InvitationStatus       `protobuf:"varint,6,opt,name=status,proto3,enum=budget.v1.InvitationStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RespondedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=responded_at,json=respondedAt,proto3" json:"responded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_budget_v1_tenant_proto_rawDesc = "" +
-	"\n" +
-	"\x16budget/v1/tenant.proto\x12\tbudget.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14budget/v1/user.proto\"\xaf\x01\n" +
-	"\x06Tenant\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04slug\x18\x03 \x01(\tR\x04slug\x122\n" +
-	"\x15default_currency_code\x18\x04 \x01(\tR\x13defaultCurrencyCode\x129\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x87\x01\n" +
-	"\x10TenantMembership\x12)\n" +
-	"\x06tenant\x18\x01 \x01(\v2\x11.budget.v1.TenantR\x06tenant\x12)\n" +
-	"\x04role\x18\x02 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\x12\x1d\n" +
-	"\n" +
-	"is_default\x18\x03 \x01(\bR\tisDefault\"q\n" +
-	"\x13CreateTenantRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04slug\x18\x02 \x01(\tR\x04slug\x122\n" +
-	"\x15default_currency_code\x18\x03 \x01(\tR\x13defaultCurrencyCode\"A\n" +
-	"\x14CreateTenantResponse\x12)\n" +
-	"\x06tenant\x18\x01 \x01(\v2\x11.budget.v1.TenantR\x06tenant\"\x16\n" +
-	"\x14ListMyTenantsRequest\"V\n" +
-	"\x15ListMyTenantsResponse\x12=\n" +
-	"\vmemberships\x18\x01 \x03(\v2\x1b.budget.v1.TenantMembershipR\vmemberships\"\x81\x01\n" +
-	"\x13UpdateTenantRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04slug\x18\x03 \x01(\tR\x04slug\x122\n" +
-	"\x15default_currency_code\x18\x04 \x01(\tR\x13defaultCurrencyCode\"A\n" +
-	"\x14UpdateTenantResponse\x12)\n" +
-	"\x06tenant\x18\x01 \x01(\v2\x11.budget.v1.TenantR\x06tenant\"}\n" +
-	"\fTenantMember\x12#\n" +
-	"\x04user\x18\x01 \x01(\v2\x0f.budget.v1.UserR\x04user\x12)\n" +
-	"\x04role\x18\x02 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\x12\x1d\n" +
-	"\n" +
-	"is_default\x18\x03 \x01(\bR\tisDefault\"1\n" +
-	"\x12ListMembersRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"H\n" +
-	"\x13ListMembersResponse\x121\n" +
-	"\amembers\x18\x01 \x03(\v2\x17.budget.v1.TenantMemberR\amembers\"p\n" +
-	"\x10AddMemberRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12)\n" +
-	"\x04role\x18\x03 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\"D\n" +
-	"\x11AddMemberResponse\x12/\n" +
-	"\x06member\x18\x01 \x01(\v2\x17.budget.v1.TenantMemberR\x06member\"z\n" +
-	"\x17UpdateMemberRoleRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12)\n" +
-	"\x04role\x18\x03 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\"K\n" +
-	"\x18UpdateMemberRoleResponse\x12/\n" +
-	"\x06member\x18\x01 \x01(\v2\x17.budget.v1.TenantMemberR\x06member\"K\n" +
-	"\x13RemoveMemberRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x16\n" +
-	"\x14RemoveMemberResponse*o\n" +
+func (x *TenantInvitation) Reset() {
+	*x = TenantInvitation{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantInvitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantInvitation) ProtoMessage() {}
+
+func (x *TenantInvitation) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantInvitation.ProtoReflect.Descriptor instead.
+func (*TenantInvitation) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TenantInvitation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TenantInvitation) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+func (x *TenantInvitation) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *TenantInvitation) GetRole() TenantRole {
+	if x != nil {
+		return x.Role
+	}
+	return TenantRole_TENANT_ROLE_UNSPECIFIED
+}
+
+func (x *TenantInvitation) GetInvitedBy() *User {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return nil
+}
+
+func (x *TenantInvitation) GetStatus() InvitationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return InvitationStatus_INVITATION_STATUS_UNSPECIFIED
+}
+
+func (x *TenantInvitation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TenantInvitation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *TenantInvitation) GetRespondedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RespondedAt
+	}
+	return nil
+}
+
+type CreateInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          TenantRole             `protobuf:"varint,3,opt,name=role,proto3,enum=budget.v1.TenantRole" json:"role,omitempty"` // defaults to member
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvitationRequest) Reset() {
+	*x = CreateInvitationRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvitationRequest) ProtoMessage() {}
+
+func (x *CreateInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvitationRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateInvitationRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreateInvitationRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateInvitationRequest) GetRole() TenantRole {
+	if x != nil {
+		return x.Role
+	}
+	return TenantRole_TENANT_ROLE_UNSPECIFIED
+}
+
+type CreateInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitation    *TenantInvitation      `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvitationResponse) Reset() {
+	*x = CreateInvitationResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvitationResponse) ProtoMessage() {}
+
+func (x *CreateInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvitationResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateInvitationResponse) GetInvitation() *TenantInvitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+type ListInvitationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // empty: pending invitations addressed to the current user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsRequest) Reset() {
+	*x = ListInvitationsRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsRequest) ProtoMessage() {}
+
+func (x *ListInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListInvitationsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ListInvitationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitations   []*TenantInvitation    `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsResponse) Reset() {
+	*x = ListInvitationsResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsResponse) ProtoMessage() {}
+
+func (x *ListInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListInvitationsResponse) GetInvitations() []*TenantInvitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+type AcceptInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                   // token from the invitation email
+	InvitationId  string                 `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"` // alternative to token for the signed-in invitee
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInvitationRequest) Reset() {
+	*x = AcceptInvitationRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInvitationRequest) ProtoMessage() {}
+
+func (x *AcceptInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInvitationRequest.ProtoReflect.Descriptor instead.
+func (*AcceptInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AcceptInvitationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AcceptInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+type AcceptInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Membership    *TenantMembership      `protobuf:"bytes,1,opt,name=membership,proto3" json:"membership,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInvitationResponse) Reset() {
+	*x = AcceptInvitationResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInvitationResponse) ProtoMessage() {}
+
+func (x *AcceptInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInvitationResponse.ProtoReflect.Descriptor instead.
+func (*AcceptInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AcceptInvitationResponse) GetMembership() *TenantMembership {
+	if x != nil {
+		return x.Membership
+	}
+	return nil
+}
+
+type DeclineInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	InvitationId  string                 `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineInvitationRequest) Reset() {
+	*x = DeclineInvitationRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineInvitationRequest) ProtoMessage() {}
+
+func (x *DeclineInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineInvitationRequest.ProtoReflect.Descriptor instead.
+func (*DeclineInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeclineInvitationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeclineInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+type DeclineInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineInvitationResponse) Reset() {
+	*x = DeclineInvitationResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineInvitationResponse) ProtoMessage() {}
+
+func (x *DeclineInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineInvitationResponse.ProtoReflect.Descriptor instead.
+func (*DeclineInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{27}
+}
+
+type RevokeInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	InvitationId  string                 `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInvitationRequest) Reset() {
+	*x = RevokeInvitationRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInvitationRequest) ProtoMessage() {}
+
+func (x *RevokeInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInvitationRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RevokeInvitationRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *RevokeInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+type RevokeInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInvitationResponse) Reset() {
+	*x = RevokeInvitationResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInvitationResponse) ProtoMessage() {}
+
+func (x *RevokeInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInvitationResponse.ProtoReflect.Descriptor instead.
+func (*RevokeInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{29}
+}
+
+// Ownership and tenant lifecycle
+type TransferOwnershipRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TenantId       string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	NewOwnerUserId string                 `protobuf:"bytes,2,opt,name=new_owner_user_id,json=newOwnerUserId,proto3" json:"new_owner_user_id,omitempty"` // must already be a member
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipRequest) Reset() {
+	*x = TransferOwnershipRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TransferOwnershipRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetNewOwnerUserId() string {
+	if x != nil {
+		return x.NewOwnerUserId
+	}
+	return ""
+}
+
+type TransferOwnershipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PreviousOwner *TenantMember          `protobuf:"bytes,1,opt,name=previous_owner,json=previousOwner,proto3" json:"previous_owner,omitempty"` // demoted to admin
+	NewOwner      *TenantMember          `protobuf:"bytes,2,opt,name=new_owner,json=newOwner,proto3" json:"new_owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipResponse) Reset() {
+	*x = TransferOwnershipResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipResponse) ProtoMessage() {}
+
+func (x *TransferOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TransferOwnershipResponse) GetPreviousOwner() *TenantMember {
+	if x != nil {
+		return x.PreviousOwner
+	}
+	return nil
+}
+
+func (x *TransferOwnershipResponse) GetNewOwner() *TenantMember {
+	if x != nil {
+		return x.NewOwner
+	}
+	return nil
+}
+
+type LeaveTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveTenantRequest) Reset() {
+	*x = LeaveTenantRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveTenantRequest) ProtoMessage() {}
+
+func (x *LeaveTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveTenantRequest.ProtoReflect.Descriptor instead.
+func (*LeaveTenantRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LeaveTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type LeaveTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveTenantResponse) Reset() {
+	*x = LeaveTenantResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveTenantResponse) ProtoMessage() {}
+
+func (x *LeaveTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveTenantResponse.ProtoReflect.Descriptor instead.
+func (*LeaveTenantResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{33}
+}
+
+// DeleteTenant is two-step: call without confirmation_token to receive one,
+// then repeat the call with it to schedule deletion after the grace period.
+type DeleteTenantRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TenantId          string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ConfirmationToken string                 `protobuf:"bytes,2,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeleteTenantRequest) Reset() {
+	*x = DeleteTenantRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantRequest) ProtoMessage() {}
+
+func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *DeleteTenantRequest) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+type DeleteTenantResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ConfirmationToken     string                 `protobuf:"bytes,1,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"` // set on the first step only
+	ConfirmationExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=confirmation_expires_at,json=confirmationExpiresAt,proto3" json:"confirmation_expires_at,omitempty"`
+	Tenant                *Tenant                `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"` // deletion_scheduled_at is set after confirmation
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *DeleteTenantResponse) Reset() {
+	*x = DeleteTenantResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantResponse) ProtoMessage() {}
+
+func (x *DeleteTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTenantResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteTenantResponse) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+func (x *DeleteTenantResponse) GetConfirmationExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConfirmationExpiresAt
+	}
+	return nil
+}
+
+func (x *DeleteTenantResponse) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+type CancelTenantDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTenantDeletionRequest) Reset() {
+	*x = CancelTenantDeletionRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTenantDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTenantDeletionRequest) ProtoMessage() {}
+
+func (x *CancelTenantDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTenantDeletionRequest.ProtoReflect.Descriptor instead.
+func (*CancelTenantDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CancelTenantDeletionRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type CancelTenantDeletionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        *Tenant                `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTenantDeletionResponse) Reset() {
+	*x = CancelTenantDeletionResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTenantDeletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTenantDeletionResponse) ProtoMessage() {}
+
+func (x *CancelTenantDeletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTenantDeletionResponse.ProtoReflect.Descriptor instead.
+func (*CancelTenantDeletionResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CancelTenantDeletionResponse) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+type SetDefaultTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultTenantRequest) Reset() {
+	*x = SetDefaultTenantRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultTenantRequest) ProtoMessage() {}
+
+func (x *SetDefaultTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultTenantRequest.ProtoReflect.Descriptor instead.
+func (*SetDefaultTenantRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetDefaultTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type SetDefaultTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultTenantResponse) Reset() {
+	*x = SetDefaultTenantResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultTenantResponse) ProtoMessage() {}
+
+func (x *SetDefaultTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultTenantResponse.ProtoReflect.Descriptor instead.
+func (*SetDefaultTenantResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{39}
+}
+
+// AuditEvent is an append-only record of a change of tenant data
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorUserId   string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"` // empty for system changes
+	EntityType    string                 `protobuf:"bytes,3,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`      // "transaction", "category", "membership", "tenant"
+	EntityId      string                 `protobuf:"bytes,4,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`            // for memberships: the member's user id
+	Action        AuditAction            `protobuf:"varint,5,opt,name=action,proto3,enum=budget.v1.AuditAction" json:"action,omitempty"`
+	BeforeJson    string                 `protobuf:"bytes,6,opt,name=before_json,json=beforeJson,proto3" json:"before_json,omitempty"` // entity before the change, empty for created
+	AfterJson     string                 `protobuf:"bytes,7,opt,name=after_json,json=afterJson,proto3" json:"after_json,omitempty"`    // entity after the change, empty for deleted
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() AuditAction {
+	if x != nil {
+		return x.Action
+	}
+	return AuditAction_AUDIT_ACTION_UNSPECIFIED
+}
+
+func (x *AuditEvent) GetBeforeJson() string {
+	if x != nil {
+		return x.BeforeJson
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAfterJson() string {
+	if x != nil {
+		return x.AfterJson
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ListAuditEvents is available to owners and admins; events are returned newest first
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	EntityType    string                 `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"` // optional filters
+	EntityId      string                 `protobuf:"bytes,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	ActorUserId   string                 `protobuf:"bytes,4,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	Action        AuditAction            `protobuf:"varint,5,opt,name=action,proto3,enum=budget.v1.AuditAction" json:"action,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=to,proto3" json:"to,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,8,opt,name=page,proto3" json:"page,omitempty"` // sort is ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListAuditEventsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAction() AuditAction {
+	if x != nil {
+		return x.Action
+	}
+	return AuditAction_AUDIT_ACTION_UNSPECIFIED
+}
+
+func (x *ListAuditEventsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Page          *PageResponse          `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_budget_v1_tenant_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_tenant_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_tenant_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetPage() *PageResponse {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+var File_budget_v1_tenant_proto protoreflect.FileDescriptor
+
+const file_budget_v1_tenant_proto_rawDesc = "" +
+	"\n" +
+	"\x16budget/v1/tenant.proto\x12\tbudget.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14budget/v1/user.proto\x1a\x16budget/v1/common.proto\"\xa6\x02\n" +
+	"\x06Tenant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x122\n" +
+	"\x15default_currency_code\x18\x04 \x01(\tR\x13defaultCurrencyCode\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12N\n" +
+	"\x15deletion_scheduled_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x13deletionScheduledAt\x12%\n" +
+	"\x0ecurrency_codes\x18\a \x03(\tR\rcurrencyCodes\"\x87\x01\n" +
+	"\x10TenantMembership\x12)\n" +
+	"\x06tenant\x18\x01 \x01(\v2\x11.budget.v1.TenantR\x06tenant\x12)\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x03 \x01(\bR\tisDefault\"q\n" +
+	"\x13CreateTenantRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x122\n" +
+	"\x15default_currency_code\x18\x03 \x01(\tR\x13defaultCurrencyCode\"A\n" +
+	"\x14CreateTenantResponse\x12)\n" +
+	"\x06tenant\x18\x01 \x01(\v2\x11.budget.v1.TenantR\x06tenant\"\x16\n" +
+	"\x14ListMyTenantsRequest\"V\n" +
+	"\x15ListMyTenantsResponse\x12=\n" +
+	"\vmemberships\x18\x01 \x03(\v2\x1b.budget.v1.TenantMembershipR\vmemberships\"\x81\x01\n" +
+	"\x13UpdateTenantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x122\n" +
+	"\x15default_currency_code\x18\x04 \x01(\tR\x13defaultCurrencyCode\"A\n" +
+	"\x14UpdateTenantResponse\x12)\n" +
+	"\x06tenant\x18\x01 \x01(\v2\x11.budget.v1.TenantR\x06tenant\"`\n" +
+	"\x1aSetTenantCurrenciesRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12%\n" +
+	"\x0ecurrency_codes\x18\x02 \x03(\tR\rcurrencyCodes\"H\n" +
+	"\x1bSetTenantCurrenciesResponse\x12)\n" +
+	"\x06tenant\x18\x01 \x01(\v2\x11.budget.v1.TenantR\x06tenant\"}\n" +
+	"\fTenantMember\x12#\n" +
+	"\x04user\x18\x01 \x01(\v2\x0f.budget.v1.UserR\x04user\x12)\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x03 \x01(\bR\tisDefault\"1\n" +
+	"\x12ListMembersRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"H\n" +
+	"\x13ListMembersResponse\x121\n" +
+	"\amembers\x18\x01 \x03(\v2\x17.budget.v1.TenantMemberR\amembers\"p\n" +
+	"\x10AddMemberRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12)\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\"D\n" +
+	"\x11AddMemberResponse\x12/\n" +
+	"\x06member\x18\x01 \x01(\v2\x17.budget.v1.TenantMemberR\x06member\"z\n" +
+	"\x17UpdateMemberRoleRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12)\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\"K\n" +
+	"\x18UpdateMemberRoleResponse\x12/\n" +
+	"\x06member\x18\x01 \x01(\v2\x17.budget.v1.TenantMemberR\x06member\"K\n" +
+	"\x13RemoveMemberRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x16\n" +
+	"\x14RemoveMemberResponse\"\xa8\x03\n" +
+	"\x10TenantInvitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x06tenant\x18\x02 \x01(\v2\x11.budget.v1.TenantR\x06tenant\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12)\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\x12.\n" +
+	"\n" +
+	"invited_by\x18\x05 \x01(\v2\x0f.budget.v1.UserR\tinvitedBy\x123\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1b.budget.v1.InvitationStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12=\n" +
+	"\fresponded_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vrespondedAt\"w\n" +
+	"\x17CreateInvitationRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12)\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x15.budget.v1.TenantRoleR\x04role\"W\n" +
+	"\x18CreateInvitationResponse\x12;\n" +
+	"\n" +
+	"invitation\x18\x01 \x01(\v2\x1b.budget.v1.TenantInvitationR\n" +
+	"invitation\"5\n" +
+	"\x16ListInvitationsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"X\n" +
+	"\x17ListInvitationsResponse\x12=\n" +
+	"\vinvitations\x18\x01 \x03(\v2\x1b.budget.v1.TenantInvitationR\vinvitations\"T\n" +
+	"\x17AcceptInvitationRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rinvitation_id\x18\x02 \x01(\tR\finvitationId\"W\n" +
+	"\x18AcceptInvitationResponse\x12;\n" +
+	"\n" +
+	"membership\x18\x01 \x01(\v2\x1b.budget.v1.TenantMembershipR\n" +
+	"membership\"U\n" +
+	"\x18DeclineInvitationRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rinvitation_id\x18\x02 \x01(\tR\finvitationId\"\x1b\n" +
+	"\x19DeclineInvitationResponse\"[\n" +
+	"\x17RevokeInvitationRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12#\n" +
+	"\rinvitation_id\x18\x02 \x01(\tR\finvitationId\"\x1a\n" +
+	"\x18RevokeInvitationResponse\"b\n" +
+	"\x18TransferOwnershipRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12)\n" +
+	"\x11new_owner_user_id\x18\x02 \x01(\tR\x0enewOwnerUserId\"\x91\x01\n" +
+	"\x19TransferOwnershipResponse\x12>\n" +
+	"\x0eprevious_owner\x18\x01 \x01(\v2\x17.budget.v1.TenantMemberR\rpreviousOwner\x124\n" +
+	"\tnew_owner\x18\x02 \x01(\v2\x17.budget.v1.TenantMemberR\bnewOwner\"1\n" +
+	"\x12LeaveTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"\x15\n" +
+	"\x13LeaveTenantResponse\"a\n" +
+	"\x13DeleteTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12-\n" +
+	"\x12confirmation_token\x18\x02 \x01(\tR\x11confirmationToken\"\xc4\x01\n" +
+	"\x14DeleteTenantResponse\x12-\n" +
+	"\x12confirmation_token\x18\x01 \x01(\tR\x11confirmationToken\x12R\n" +
+	"\x17confirmation_expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x15confirmationExpiresAt\x12)\n" +
+	"\x06tenant\x18\x03 \x01(\v2\x11.budget.v1.TenantR\x06tenant\":\n" +
+	"\x1bCancelTenantDeletionRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"I\n" +
+	"\x1cCancelTenantDeletionResponse\x12)\n" +
+	"\x06tenant\x18\x01 \x01(\v2\x11.budget.v1.TenantR\x06tenant\"6\n" +
+	"\x17SetDefaultTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"\x1a\n" +
+	"\x18SetDefaultTenantResponse\"\xa9\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\x12\x1f\n" +
+	"\ventity_type\x18\x03 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x04 \x01(\tR\bentityId\x12.\n" +
+	"\x06action\x18\x05 \x01(\x0e2\x16.budget.v1.AuditActionR\x06action\x12\x1f\n" +
+	"\vbefore_json\x18\x06 \x01(\tR\n" +
+	"beforeJson\x12\x1d\n" +
+	"\n" +
+	"after_json\x18\a \x01(\tR\tafterJson\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xcf\x02\n" +
+	"\x16ListAuditEventsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1f\n" +
+	"\ventity_type\x18\x02 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x03 \x01(\tR\bentityId\x12\"\n" +
+	"\ractor_user_id\x18\x04 \x01(\tR\vactorUserId\x12.\n" +
+	"\x06action\x18\x05 \x01(\x0e2\x16.budget.v1.AuditActionR\x06action\x12.\n" +
+	"\x04from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12*\n" +
+	"\x04page\x18\b \x01(\v2\x16.budget.v1.PageRequestR\x04page\"u\n" +
+	"\x17ListAuditEventsResponse\x12-\n" +
+	"\x06events\x18\x01 \x03(\v2\x15.budget.v1.AuditEventR\x06events\x12+\n" +
+	"\x04page\x18\x02 \x01(\v2\x17.budget.v1.PageResponseR\x04page*o\n" +
 	"\n" +
 	"TenantRole\x12\x1b\n" +
 	"\x17TENANT_ROLE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11TENANT_ROLE_OWNER\x10\x01\x12\x15\n" +
 	"\x11TENANT_ROLE_ADMIN\x10\x02\x12\x16\n" +
-	"\x12TENANT_ROLE_MEMBER\x10\x032\xc9\x04\n" +
+	"\x12TENANT_ROLE_MEMBER\x10\x03*\xd2\x01\n" +
+	"\x10InvitationStatus\x12!\n" +
+	"\x1dINVITATION_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19INVITATION_STATUS_PENDING\x10\x01\x12\x1e\n" +
+	"\x1aINVITATION_STATUS_ACCEPTED\x10\x02\x12\x1e\n" +
+	"\x1aINVITATION_STATUS_DECLINED\x10\x03\x12\x1d\n" +
+	"\x19INVITATION_STATUS_REVOKED\x10\x04\x12\x1d\n" +
+	"\x19INVITATION_STATUS_EXPIRED\x10\x05*\x94\x01\n" +
+	"\vAuditAction\x12\x1c\n" +
+	"\x18AUDIT_ACTION_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14AUDIT_ACTION_CREATED\x10\x01\x12\x18\n" +
+	"\x14AUDIT_ACTION_UPDATED\x10\x02\x12\x18\n" +
+	"\x14AUDIT_ACTION_DELETED\x10\x03\x12\x19\n" +
+	"\x15AUDIT_ACTION_RESTORED\x10\x042\x9f\r\n" +
 	"\rTenantService\x12O\n" +
 	"\fCreateTenant\x12\x1e.budget.v1.CreateTenantRequest\x1a\x1f.budget.v1.CreateTenantResponse\x12R\n" +
 	"\rListMyTenants\x12\x1f.budget.v1.ListMyTenantsRequest\x1a .budget.v1.ListMyTenantsResponse\x12O\n" +
-	"\fUpdateTenant\x12\x1e.budget.v1.UpdateTenantRequest\x1a\x1f.budget.v1.UpdateTenantResponse\x12L\n" +
+	"\fUpdateTenant\x12\x1e.budget.v1.UpdateTenantRequest\x1a\x1f.budget.v1.UpdateTenantResponse\x12d\n" +
+	"\x13SetTenantCurrencies\x12%.budget.v1.SetTenantCurrenciesRequest\x1a&.budget.v1.SetTenantCurrenciesResponse\x12L\n" +
 	"\vListMembers\x12\x1d.budget.v1.ListMembersRequest\x1a\x1e.budget.v1.ListMembersResponse\x12F\n" +
 	"\tAddMember\x12\x1b.budget.v1.AddMemberRequest\x1a\x1c.budget.v1.AddMemberResponse\x12[\n" +
 	"\x10UpdateMemberRole\x12\".budget.v1.UpdateMemberRoleRequest\x1a#.budget.v1.UpdateMemberRoleResponse\x12O\n" +
-	"\fRemoveMember\x12\x1e.budget.v1.RemoveMemberRequest\x1a\x1f.budget.v1.RemoveMemberResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
+	"\fRemoveMember\x12\x1e.budget.v1.RemoveMemberRequest\x1a\x1f.budget.v1.RemoveMemberResponse\x12[\n" +
+	"\x10CreateInvitation\x12\".budget.v1.CreateInvitationRequest\x1a#.budget.v1.CreateInvitationResponse\x12X\n" +
+	"\x0fListInvitations\x12!.budget.v1.ListInvitationsRequest\x1a\".budget.v1.ListInvitationsResponse\x12[\n" +
+	"\x10AcceptInvitation\x12\".budget.v1.AcceptInvitationRequest\x1a#.budget.v1.AcceptInvitationResponse\x12^\n" +
+	"\x11DeclineInvitation\x12#.budget.v1.DeclineInvitationRequest\x1a$.budget.v1.DeclineInvitationResponse\x12[\n" +
+	"\x10RevokeInvitation\x12\".budget.v1.RevokeInvitationRequest\x1a#.budget.v1.RevokeInvitationResponse\x12^\n" +
+	"\x11TransferOwnership\x12#.budget.v1.TransferOwnershipRequest\x1a$.budget.v1.TransferOwnershipResponse\x12L\n" +
+	"\vLeaveTenant\x12\x1d.budget.v1.LeaveTenantRequest\x1a\x1e.budget.v1.LeaveTenantResponse\x12O\n" +
+	"\fDeleteTenant\x12\x1e.budget.v1.DeleteTenantRequest\x1a\x1f.budget.v1.DeleteTenantResponse\x12g\n" +
+	"\x14CancelTenantDeletion\x12&.budget.v1.CancelTenantDeletionRequest\x1a'.budget.v1.CancelTenantDeletionResponse\x12[\n" +
+	"\x10SetDefaultTenant\x12\".budget.v1.SetDefaultTenantRequest\x1a#.budget.v1.SetDefaultTenantResponse\x12X\n" +
+	"\x0fListAuditEvents\x12!.budget.v1.ListAuditEventsRequest\x1a\".budget.v1.ListAuditEventsResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
 
 var (
 	file_budget_v1_tenant_proto_rawDescOnce sync.Once
@@ -1037,63 +2680,143 @@ func file_budget_v1_tenant_proto_rawDescGZIP() []byte {
 	return file_budget_v1_tenant_proto_rawDescData
 }
 
-var file_budget_v1_tenant_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_budget_v1_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_budget_v1_tenant_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_budget_v1_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_budget_v1_tenant_proto_goTypes = []any{
-	(TenantRole)(0),                  // 0: budget.v1.TenantRole
-	(*Tenant)(nil),                   // 1: budget.v1.Tenant
-	(*TenantMembership)(nil),         // 2: budget.v1.TenantMembership
-	(*CreateTenantRequest)(nil),      // 3: budget.v1.CreateTenantRequest
-	(*CreateTenantResponse)(nil),     // 4: budget.v1.CreateTenantResponse
-	(*ListMyTenantsRequest)(nil),     // 5: budget.v1.ListMyTenantsRequest
-	(*ListMyTenantsResponse)(nil),    // 6: budget.v1.ListMyTenantsResponse
-	(*UpdateTenantRequest)(nil),      // 7: budget.v1.UpdateTenantRequest
-	(*UpdateTenantResponse)(nil),     // 8: budget.v1.UpdateTenantResponse
-	(*TenantMember)(nil),             // 9: budget.v1.TenantMember
-	(*ListMembersRequest)(nil),       // 10: budget.v1.ListMembersRequest
-	(*ListMembersResponse)(nil),      // 11: budget.v1.ListMembersResponse
-	(*AddMemberRequest)(nil),         // 12: budget.v1.AddMemberRequest
-	(*AddMemberResponse)(nil),        // 13: budget.v1.AddMemberResponse
-	(*UpdateMemberRoleRequest)(nil),  // 14: budget.v1.UpdateMemberRoleRequest
-	(*UpdateMemberRoleResponse)(nil), // 15: budget.v1.UpdateMemberRoleResponse
-	(*RemoveMemberRequest)(nil),      // 16: budget.v1.RemoveMemberRequest
-	(*RemoveMemberResponse)(nil),     // 17: budget.v1.RemoveMemberResponse
-	(*timestamppb.Timestamp)(nil),    // 18: google.protobuf.Timestamp
-	(*User)(nil),                     // 19: budget.v1.User
+	(TenantRole)(0),                      // 0: budget.v1.TenantRole
+	(InvitationStatus)(0),                // 1: budget.v1.InvitationStatus
+	(AuditAction)(0),                     // 2: budget.v1.AuditAction
+	(*Tenant)(nil),                       // 3: budget.v1.Tenant
+	(*TenantMembership)(nil),             // 4: budget.v1.TenantMembership
+	(*CreateTenantRequest)(nil),          // 5: budget.v1.CreateTenantRequest
+	(*CreateTenantResponse)(nil),         // 6: budget.v1.CreateTenantResponse
+	(*ListMyTenantsRequest)(nil),         // 7: budget.v1.ListMyTenantsRequest
+	(*ListMyTenantsResponse)(nil),        // 8: budget.v1.ListMyTenantsResponse
+	(*UpdateTenantRequest)(nil),          // 9: budget.v1.UpdateTenantRequest
+	(*UpdateTenantResponse)(nil),         // 10: budget.v1.UpdateTenantResponse
+	(*SetTenantCurrenciesRequest)(nil),   // 11: budget.v1.SetTenantCurrenciesRequest
+	(*SetTenantCurrenciesResponse)(nil),  // 12: budget.v1.SetTenantCurrenciesResponse
+	(*TenantMember)(nil),                 // 13: budget.v1.TenantMember
+	(*ListMembersRequest)(nil),           // 14: budget.v1.ListMembersRequest
+	(*ListMembersResponse)(nil),          // 15: budget.v1.ListMembersResponse
+	(*AddMemberRequest)(nil),             // 16: budget.v1.AddMemberRequest
+	(*AddMemberResponse)(nil),            // 17: budget.v1.AddMemberResponse
+	(*UpdateMemberRoleRequest)(nil),      // 18: budget.v1.UpdateMemberRoleRequest
+	(*UpdateMemberRoleResponse)(nil),     // 19: budget.v1.UpdateMemberRoleResponse
+	(*RemoveMemberRequest)(nil),          // 20: budget.v1.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),         // 21: budget.v1.RemoveMemberResponse
+	(*TenantInvitation)(nil),             // 22: budget.v1.TenantInvitation
+	(*CreateInvitationRequest)(nil),      // 23: budget.v1.CreateInvitationRequest
+	(*CreateInvitationResponse)(nil),     // 24: budget.v1.CreateInvitationResponse
+	(*ListInvitationsRequest)(nil),       // 25: budget.v1.ListInvitationsRequest
+	(*ListInvitationsResponse)(nil),      // 26: budget.v1.ListInvitationsResponse
+	(*AcceptInvitationRequest)(nil),      // 27: budget.v1.AcceptInvitationRequest
+	(*AcceptInvitationResponse)(nil),     // 28: budget.v1.AcceptInvitationResponse
+	(*DeclineInvitationRequest)(nil),     // 29: budget.v1.DeclineInvitationRequest
+	(*DeclineInvitationResponse)(nil),    // 30: budget.v1.DeclineInvitationResponse
+	(*RevokeInvitationRequest)(nil),      // 31: budget.v1.RevokeInvitationRequest
+	(*RevokeInvitationResponse)(nil),     // 32: budget.v1.RevokeInvitationResponse
+	(*TransferOwnershipRequest)(nil),     // 33: budget.v1.TransferOwnershipRequest
+	(*TransferOwnershipResponse)(nil),    // 34: budget.v1.TransferOwnershipResponse
+	(*LeaveTenantRequest)(nil),           // 35: budget.v1.LeaveTenantRequest
+	(*LeaveTenantResponse)(nil),          // 36: budget.v1.LeaveTenantResponse
+	(*DeleteTenantRequest)(nil),          // 37: budget.v1.DeleteTenantRequest
+	(*DeleteTenantResponse)(nil),         // 38: budget.v1.DeleteTenantResponse
+	(*CancelTenantDeletionRequest)(nil),  // 39: budget.v1.CancelTenantDeletionRequest
+	(*CancelTenantDeletionResponse)(nil), // 40: budget.v1.CancelTenantDeletionResponse
+	(*SetDefaultTenantRequest)(nil),      // 41: budget.v1.SetDefaultTenantRequest
+	(*SetDefaultTenantResponse)(nil),     // 42: budget.v1.SetDefaultTenantResponse
+	(*AuditEvent)(nil),                   // 43: budget.v1.AuditEvent
+	(*ListAuditEventsRequest)(nil),       // 44: budget.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),      // 45: budget.v1.ListAuditEventsResponse
+	(*timestamppb.Timestamp)(nil),        // 46: google.protobuf.Timestamp
+	(*User)(nil),                         // 47: budget.v1.User
+	(*PageRequest)(nil),                  // 48: budget.v1.PageRequest
+	(*PageResponse)(nil),                 // 49: budget.v1.PageResponse
 }
 var file_budget_v1_tenant_proto_depIdxs = []int32{
-	18, // 0: budget.v1.Tenant.created_at:type_name -> google.protobuf.Timestamp
-	1,  // 1: budget.v1.TenantMembership.tenant:type_name -> budget.v1.Tenant
-	0,  // 2: budget.v1.TenantMembership.role:type_name -> budget.v1.TenantRole
-	1,  // 3: budget.v1.CreateTenantResponse.tenant:type_name -> budget.v1.Tenant
-	2,  // 4: budget.v1.ListMyTenantsResponse.memberships:type_name -> budget.v1.TenantMembership
-	1,  // 5: budget.v1.UpdateTenantResponse.tenant:type_name -> budget.v1.Tenant
-	19, // 6: budget.v1.TenantMember.user:type_name -> budget.v1.User
-	0,  // 7: budget.v1.TenantMember.role:type_name -> budget.v1.TenantRole
-	9,  // 8: budget.v1.ListMembersResponse.members:type_name -> budget.v1.TenantMember
-	0,  // 9: budget.v1.AddMemberRequest.role:type_name -> budget.v1.TenantRole
-	9,  // 10: budget.v1.AddMemberResponse.member:type_name -> budget.v1.TenantMember
-	0,  // 11: budget.v1.UpdateMemberRoleRequest.role:type_name -> budget.v1.TenantRole
-	9,  // 12: budget.v1.UpdateMemberRoleResponse.member:type_name -> budget.v1.TenantMember
-	3,  // 13: budget.v1.TenantService.CreateTenant:input_type -> budget.v1.CreateTenantRequest
-	5,  // 14: budget.v1.TenantService.ListMyTenants:input_type -> budget.v1.ListMyTenantsRequest
-	7,  // 15: budget.v1.TenantService.UpdateTenant:input_type -> budget.v1.UpdateTenantRequest
-	10, // 16: budget.v1.TenantService.ListMembers:input_type -> budget.v1.ListMembersRequest
-	12, // 17: budget.v1.TenantService.AddMember:input_type -> budget.v1.AddMemberRequest
-	14, // 18: budget.v1.TenantService.UpdateMemberRole:input_type -> budget.v1.UpdateMemberRoleRequest
-	16, // 19: budget.v1.TenantService.RemoveMember:input_type -> budget.v1.RemoveMemberRequest
-	4,  // 20: budget.v1.TenantService.CreateTenant:output_type -> budget.v1.CreateTenantResponse
-	6,  // 21: budget.v1.TenantService.ListMyTenants:output_type -> budget.v1.ListMyTenantsResponse
-	8,  // 22: budget.v1.TenantService.UpdateTenant:output_type -> budget.v1.UpdateTenantResponse
-	11, // 23: budget.v1.TenantService.ListMembers:output_type -> budget.v1.ListMembersResponse
-	13, // 24: budget.v1.TenantService.AddMember:output_type -> budget.v1.AddMemberResponse
-	15, // 25: budget.v1.TenantService.UpdateMemberRole:output_type -> budget.v1.UpdateMemberRoleResponse
-	17, // 26: budget.v1.TenantService.RemoveMember:output_type -> budget.v1.RemoveMemberResponse
-	20, // [20:27] is the sub-list for method output_type
-	13, // [13:20] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	46, // 0: budget.v1.Tenant.created_at:type_name -> google.protobuf.Timestamp
+	46, // 1: budget.v1.Tenant.deletion_scheduled_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: budget.v1.TenantMembership.tenant:type_name -> budget.v1.Tenant
+	0,  // 3: budget.v1.TenantMembership.role:type_name -> budget.v1.TenantRole
+	3,  // 4: budget.v1.CreateTenantResponse.tenant:type_name -> budget.v1.Tenant
+	4,  // 5: budget.v1.ListMyTenantsResponse.memberships:type_name -> budget.v1.TenantMembership
+	3,  // 6: budget.v1.UpdateTenantResponse.tenant:type_name -> budget.v1.Tenant
+	3,  // 7: budget.v1.SetTenantCurrenciesResponse.tenant:type_name -> budget.v1.Tenant
+	47, // 8: budget.v1.TenantMember.user:type_name -> budget.v1.User
+	0,  // 9: budget.v1.TenantMember.role:type_name -> budget.v1.TenantRole
+	13, // 10: budget.v1.ListMembersResponse.members:type_name -> budget.v1.TenantMember
+	0,  // 11: budget.v1.AddMemberRequest.role:type_name -> budget.v1.TenantRole
+	13, // 12: budget.v1.AddMemberResponse.member:type_name -> budget.v1.TenantMember
+	0,  // 13: budget.v1.UpdateMemberRoleRequest.role:type_name -> budget.v1.TenantRole
+	13, // 14: budget.v1.UpdateMemberRoleResponse.member:type_name -> budget.v1.TenantMember
+	3,  // 15: budget.v1.TenantInvitation.tenant:type_name -> budget.v1.Tenant
+	0,  // 16: budget.v1.TenantInvitation.role:type_name -> budget.v1.TenantRole
+	47, // 17: budget.v1.TenantInvitation.invited_by:type_name -> budget.v1.User
+	1,  // 18: budget.v1.TenantInvitation.status:type_name -> budget.v1.InvitationStatus
+	46, // 19: budget.v1.TenantInvitation.created_at:type_name -> google.protobuf.Timestamp
+	46, // 20: budget.v1.TenantInvitation.expires_at:type_name -> google.protobuf.Timestamp
+	46, // 21: budget.v1.TenantInvitation.responded_at:type_name -> google.protobuf.Timestamp
+	0,  // 22: budget.v1.CreateInvitationRequest.role:type_name -> budget.v1.TenantRole
+	22, // 23: budget.v1.CreateInvitationResponse.invitation:type_name -> budget.v1.TenantInvitation
+	22, // 24: budget.v1.ListInvitationsResponse.invitations:type_name -> budget.v1.TenantInvitation
+	4,  // 25: budget.v1.AcceptInvitationResponse.membership:type_name -> budget.v1.TenantMembership
+	13, // 26: budget.v1.TransferOwnershipResponse.previous_owner:type_name -> budget.v1.TenantMember
+	13, // 27: budget.v1.TransferOwnershipResponse.new_owner:type_name -> budget.v1.TenantMember
+	46, // 28: budget.v1.DeleteTenantResponse.confirmation_expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 29: budget.v1.DeleteTenantResponse.tenant:type_name -> budget.v1.Tenant
+	3,  // 30: budget.v1.CancelTenantDeletionResponse.tenant:type_name -> budget.v1.Tenant
+	2,  // 31: budget.v1.AuditEvent.action:type_name -> budget.v1.AuditAction
+	46, // 32: budget.v1.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 33: budget.v1.ListAuditEventsRequest.action:type_name -> budget.v1.AuditAction
+	46, // 34: budget.v1.ListAuditEventsRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 35: budget.v1.ListAuditEventsRequest.to:type_name -> google.protobuf.Timestamp
+	48, // 36: budget.v1.ListAuditEventsRequest.page:type_name -> budget.v1.PageRequest
+	43, // 37: budget.v1.ListAuditEventsResponse.events:type_name -> budget.v1.AuditEvent
+	49, // 38: budget.v1.ListAuditEventsResponse.page:type_name -> budget.v1.PageResponse
+	5,  // 39: budget.v1.TenantService.CreateTenant:input_type -> budget.v1.CreateTenantRequest
+	7,  // 40: budget.v1.TenantService.ListMyTenants:input_type -> budget.v1.ListMyTenantsRequest
+	9,  // 41: budget.v1.TenantService.UpdateTenant:input_type -> budget.v1.UpdateTenantRequest
+	11, // 42: budget.v1.TenantService.SetTenantCurrencies:input_type -> budget.v1.SetTenantCurrenciesRequest
+	14, // 43: budget.v1.TenantService.ListMembers:input_type -> budget.v1.ListMembersRequest
+	16, // 44: budget.v1.TenantService.AddMember:input_type -> budget.v1.AddMemberRequest
+	18, // 45: budget.v1.TenantService.UpdateMemberRole:input_type -> budget.v1.UpdateMemberRoleRequest
+	20, // 46: budget.v1.TenantService.RemoveMember:input_type -> budget.v1.RemoveMemberRequest
+	23, // 47: budget.v1.TenantService.CreateInvitation:input_type -> budget.v1.CreateInvitationRequest
+	25, // 48: budget.v1.TenantService.ListInvitations:input_type -> budget.v1.ListInvitationsRequest
+	27, // 49: budget.v1.TenantService.AcceptInvitation:input_type -> budget.v1.AcceptInvitationRequest
+	29, // 50: budget.v1.TenantService.DeclineInvitation:input_type -> budget.v1.DeclineInvitationRequest
+	31, // 51: budget.v1.TenantService.RevokeInvitation:input_type -> budget.v1.RevokeInvitationRequest
+	33, // 52: budget.v1.TenantService.TransferOwnership:input_type -> budget.v1.TransferOwnershipRequest
+	35, // 53: budget.v1.TenantService.LeaveTenant:input_type -> budget.v1.LeaveTenantRequest
+	37, // 54: budget.v1.TenantService.DeleteTenant:input_type -> budget.v1.DeleteTenantRequest
+	39, // 55: budget.v1.TenantService.CancelTenantDeletion:input_type -> budget.v1.CancelTenantDeletionRequest
+	41, // 56: budget.v1.TenantService.SetDefaultTenant:input_type -> budget.v1.SetDefaultTenantRequest
+	44, // 57: budget.v1.TenantService.ListAuditEvents:input_type -> budget.v1.ListAuditEventsRequest
+	6,  // 58: budget.v1.TenantService.CreateTenant:output_type -> budget.v1.CreateTenantResponse
+	8,  // 59: budget.v1.TenantService.ListMyTenants:output_type -> budget.v1.ListMyTenantsResponse
+	10, // 60: budget.v1.TenantService.UpdateTenant:output_type -> budget.v1.UpdateTenantResponse
+	12, // 61: budget.v1.TenantService.SetTenantCurrencies:output_type -> budget.v1.SetTenantCurrenciesResponse
+	15, // 62: budget.v1.TenantService.ListMembers:output_type -> budget.v1.ListMembersResponse
+	17, // 63: budget.v1.TenantService.AddMember:output_type -> budget.v1.AddMemberResponse
+	19, // 64: budget.v1.TenantService.UpdateMemberRole:output_type -> budget.v1.UpdateMemberRoleResponse
+	21, // 65: budget.v1.TenantService.RemoveMember:output_type -> budget.v1.RemoveMemberResponse
+	24, // 66: budget.v1.TenantService.CreateInvitation:output_type -> budget.v1.CreateInvitationResponse
+	26, // 67: budget.v1.TenantService.ListInvitations:output_type -> budget.v1.ListInvitationsResponse
+	28, // 68: budget.v1.TenantService.AcceptInvitation:output_type -> budget.v1.AcceptInvitationResponse
+	30, // 69: budget.v1.TenantService.DeclineInvitation:output_type -> budget.v1.DeclineInvitationResponse
+	32, // 70: budget.v1.TenantService.RevokeInvitation:output_type -> budget.v1.RevokeInvitationResponse
+	34, // 71: budget.v1.TenantService.TransferOwnership:output_type -> budget.v1.TransferOwnershipResponse
+	36, // 72: budget.v1.TenantService.LeaveTenant:output_type -> budget.v1.LeaveTenantResponse
+	38, // 73: budget.v1.TenantService.DeleteTenant:output_type -> budget.v1.DeleteTenantResponse
+	40, // 74: budget.v1.TenantService.CancelTenantDeletion:output_type -> budget.v1.CancelTenantDeletionResponse
+	42, // 75: budget.v1.TenantService.SetDefaultTenant:output_type -> budget.v1.SetDefaultTenantResponse
+	45, // 76: budget.v1.TenantService.ListAuditEvents:output_type -> budget.v1.ListAuditEventsResponse
+	58, // [58:77] is the sub-list for method output_type
+	39, // [39:58] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_budget_v1_tenant_proto_init() }
@@ -1102,13 +2825,14 @@ func file_budget_v1_tenant_proto_init() {
 		return
 	}
 	file_budget_v1_user_proto_init()
+	file_budget_v1_common_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_budget_v1_tenant_proto_rawDesc), len(file_budget_v1_tenant_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   17,
+			NumEnums:      3,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
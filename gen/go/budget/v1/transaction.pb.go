@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.11
-// 	protoc        v7.34.1
+// 	protoc        (unknown)
 // source: budget/v1/transaction.proto
 
 package budgetv1
@@ -37,6 +37,7 @@ type Transaction struct {
 	Comment         string                 `protobuf:"bytes,10,opt,name=comment,proto3" json:"comment,omitempty"`
 	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	IsExtraordinary bool                   `protobuf:"varint,12,opt,name=is_extraordinary,json=isExtraordinary,proto3" json:"is_extraordinary,omitempty"` // one-off operation excluded from reports on demand
+	DeletedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`                    // set only for transactions in the trash
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -155,6 +156,13 @@ func (x *Transaction) GetIsExtraordinary() bool {
 	return false
 }
 
+func (x *Transaction) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 type CreateTransactionRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Type            TransactionType        `protobuf:"varint,1,opt,name=type,proto3,enum=budget.v1.TransactionType" json:"type,omitempty"`
@@ -561,10 +569,20 @@ type ListTransactionsRequest struct {
 	DateRange     *DateRange             `protobuf:"bytes,2,opt,name=date_range,json=dateRange,proto3" json:"date_range,omitempty"`
 	CategoryIds   []string               `protobuf:"bytes,3,rep,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
 	Type          TransactionType        `protobuf:"varint,4,opt,name=type,proto3,enum=budget.v1.TransactionType" json:"type,omitempty"`           // optional filter
-	MinMinorUnits int64                  `protobuf:"varint,5,opt,name=min_minor_units,json=minMinorUnits,proto3" json:"min_minor_units,omitempty"` // optional amount filters
+	MinMinorUnits int64                  `protobuf:"varint,5,opt,name=min_minor_units,json=minMinorUnits,proto3" json:"min_minor_units,omitempty"` // optional amount filters, minor units of currency_code (2 decimals without it)
 	MaxMinorUnits int64                  `protobuf:"varint,6,opt,name=max_minor_units,json=maxMinorUnits,proto3" json:"max_minor_units,omitempty"`
 	CurrencyCode  string                 `protobuf:"bytes,7,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"` // optional filter by transaction currency
-	Search        string                 `protobuf:"bytes,8,opt,name=search,proto3" json:"search,omitempty"`                                 // comment search
+	Search        string                 `protobuf:"bytes,8,opt,name=search,proto3" json:"search,omitempty"`                                 // free text over comment and category names (ru/en stemming)
+	// Cursor pagination (AIP-158): pass next_page_token of the previous response to get the
+	// following page; page.page is ignored then. Filters and page.sort must stay the same,
+	// page.page_size may change.
+	PageToken      string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	SkipTotalCount bool   `protobuf:"varint,10,opt,name=skip_total_count,json=skipTotalCount,proto3" json:"skip_total_count,omitempty"` // don't count matches: page.total_items/total_pages are left 0
+	// Search query, e.g. `category:food amount>1000 before:2026-01-01 "coffee"`. Keys: category,
+	// type, currency, amount (:, >, >=, <, <=; major units), before, after, on (YYYY-MM-DD);
+	// the rest is free text. Keys override the same filters above.
+	Query         string `protobuf:"bytes,11,opt,name=query,proto3" json:"query,omitempty"`
+	ViewId        string `protobuf:"bytes,12,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"` // saved view (ViewService) to start from; fields set here override it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -655,10 +673,39 @@ func (x *ListTransactionsRequest) GetSearch() string {
 	return ""
 }
 
+func (x *ListTransactionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetSkipTotalCount() bool {
+	if x != nil {
+		return x.SkipTotalCount
+	}
+	return false
+}
+
+func (x *ListTransactionsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
 type ListTransactionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	Page          *PageResponse          `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	Page          *PageResponse          `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`                                          // page is 0 for cursor requests
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -707,6 +754,612 @@ func (x *ListTransactionsResponse) GetPage() *PageResponse {
 	return nil
 }
 
+func (x *ListTransactionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Trash: deleted transactions are kept until the retention purge, newest deletions first
+type ListDeletedTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *PageRequest           `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedTransactionsRequest) Reset() {
+	*x = ListDeletedTransactionsRequest{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedTransactionsRequest) ProtoMessage() {}
+
+func (x *ListDeletedTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListDeletedTransactionsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListDeletedTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Page          *PageResponse          `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedTransactionsResponse) Reset() {
+	*x = ListDeletedTransactionsResponse{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedTransactionsResponse) ProtoMessage() {}
+
+func (x *ListDeletedTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListDeletedTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListDeletedTransactionsResponse) GetPage() *PageResponse {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type RestoreTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTransactionRequest) Reset() {
+	*x = RestoreTransactionRequest{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTransactionRequest) ProtoMessage() {}
+
+func (x *RestoreTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTransactionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RestoreTransactionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTransactionResponse) Reset() {
+	*x = RestoreTransactionResponse{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTransactionResponse) ProtoMessage() {}
+
+func (x *RestoreTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTransactionResponse.ProtoReflect.Descriptor instead.
+func (*RestoreTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RestoreTransactionResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// Batch operations run in one database transaction. Items failing validation are reported in
+// their result and skipped; any other failure rolls the whole batch back.
+type BatchTransactionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                   // empty for a rejected create
+	Transaction   *Transaction           `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"` // set for successful creates and updates
+	Code          int32                  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`              // google.rpc.Code of the item, 0 (OK) on success
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTransactionResult) Reset() {
+	*x = BatchTransactionResult{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTransactionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTransactionResult) ProtoMessage() {}
+
+func (x *BatchTransactionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTransactionResult.ProtoReflect.Descriptor instead.
+func (*BatchTransactionResult) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchTransactionResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchTransactionResult) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *BatchTransactionResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchTransactionResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchUpdateTransactionsRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Ids           []string                 `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`                                 // either explicit ids (up to 1000)...
+	Filter        *ListTransactionsRequest `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`                           // ...or every transaction matching the filter (page is ignored)
+	Transaction   *Transaction             `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`                 // new values
+	UpdateMask    *fieldmaskpb.FieldMask   `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // "category_id", "comment", "is_extraordinary"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTransactionsRequest) Reset() {
+	*x = BatchUpdateTransactionsRequest{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTransactionsRequest) ProtoMessage() {}
+
+func (x *BatchUpdateTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchUpdateTransactionsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchUpdateTransactionsRequest) GetFilter() *ListTransactionsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *BatchUpdateTransactionsRequest) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *BatchUpdateTransactionsRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type BatchUpdateTransactionsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Results       []*BatchTransactionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // in the order of ids (or newest first for a filter)
+	Succeeded     int32                     `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                     `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTransactionsResponse) Reset() {
+	*x = BatchUpdateTransactionsResponse{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTransactionsResponse) ProtoMessage() {}
+
+func (x *BatchUpdateTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchUpdateTransactionsResponse) GetResults() []*BatchTransactionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchUpdateTransactionsResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BatchUpdateTransactionsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type BatchDeleteTransactionsRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Ids           []string                 `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Filter        *ListTransactionsRequest `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteTransactionsRequest) Reset() {
+	*x = BatchDeleteTransactionsRequest{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteTransactionsRequest) ProtoMessage() {}
+
+func (x *BatchDeleteTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchDeleteTransactionsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchDeleteTransactionsRequest) GetFilter() *ListTransactionsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type BatchDeleteTransactionsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Results       []*BatchTransactionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Succeeded     int32                     `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                     `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteTransactionsResponse) Reset() {
+	*x = BatchDeleteTransactionsResponse{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteTransactionsResponse) ProtoMessage() {}
+
+func (x *BatchDeleteTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*BatchDeleteTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BatchDeleteTransactionsResponse) GetResults() []*BatchTransactionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchDeleteTransactionsResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BatchDeleteTransactionsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type BatchCreateTransactionsRequest struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Items         []*CreateTransactionRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTransactionsRequest) Reset() {
+	*x = BatchCreateTransactionsRequest{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTransactionsRequest) ProtoMessage() {}
+
+func (x *BatchCreateTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BatchCreateTransactionsRequest) GetItems() []*CreateTransactionRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type BatchCreateTransactionsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Results       []*BatchTransactionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // in the order of items
+	Succeeded     int32                     `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                     `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTransactionsResponse) Reset() {
+	*x = BatchCreateTransactionsResponse{}
+	mi := &file_budget_v1_transaction_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTransactionsResponse) ProtoMessage() {}
+
+func (x *BatchCreateTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_transaction_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BatchCreateTransactionsResponse) GetResults() []*BatchTransactionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchCreateTransactionsResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BatchCreateTransactionsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
 // Filtered totals for transactions (ignores pagination). Totals are returned in tenant base currency.
 type GetTransactionsTotalsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -716,14 +1369,16 @@ type GetTransactionsTotalsRequest struct {
 	MinMinorUnits int64                  `protobuf:"varint,4,opt,name=min_minor_units,json=minMinorUnits,proto3" json:"min_minor_units,omitempty"` // optional amount filters (by original amount)
 	MaxMinorUnits int64                  `protobuf:"varint,5,opt,name=max_minor_units,json=maxMinorUnits,proto3" json:"max_minor_units,omitempty"`
 	CurrencyCode  string                 `protobuf:"bytes,6,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"` // optional filter by transaction currency
-	Search        string                 `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"`                                 // comment search
+	Search        string                 `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"`                                 // free text over comment and category names
+	Query         string                 `protobuf:"bytes,8,opt,name=query,proto3" json:"query,omitempty"`                                   // search query, see ListTransactionsRequest.query
+	ViewId        string                 `protobuf:"bytes,9,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`                   // saved view, see ListTransactionsRequest.view_id
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTransactionsTotalsRequest) Reset() {
 	*x = GetTransactionsTotalsRequest{}
-	mi := &file_budget_v1_transaction_proto_msgTypes[11]
+	mi := &file_budget_v1_transaction_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -735,7 +1390,7 @@ func (x *GetTransactionsTotalsRequest) String() string {
 func (*GetTransactionsTotalsRequest) ProtoMessage() {}
 
 func (x *GetTransactionsTotalsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_transaction_proto_msgTypes[11]
+	mi := &file_budget_v1_transaction_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +1403,7 @@ func (x *GetTransactionsTotalsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionsTotalsRequest.ProtoReflect.Descriptor instead.
 func (*GetTransactionsTotalsRequest) Descriptor() ([]byte, []int) {
-	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{11}
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetTransactionsTotalsRequest) GetDateRange() *DateRange {
@@ -800,6 +1455,20 @@ func (x *GetTransactionsTotalsRequest) GetSearch() string {
 	return ""
 }
 
+func (x *GetTransactionsTotalsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetTransactionsTotalsRequest) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
 type GetTransactionsTotalsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TotalIncome   *Money                 `protobuf:"bytes,1,opt,name=total_income,json=totalIncome,proto3" json:"total_income,omitempty"`    // in tenant base currency
@@ -810,7 +1479,7 @@ type GetTransactionsTotalsResponse struct {
 
 func (x *GetTransactionsTotalsResponse) Reset() {
 	*x = GetTransactionsTotalsResponse{}
-	mi := &file_budget_v1_transaction_proto_msgTypes[12]
+	mi := &file_budget_v1_transaction_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -822,7 +1491,7 @@ func (x *GetTransactionsTotalsResponse) String() string {
 func (*GetTransactionsTotalsResponse) ProtoMessage() {}
 
 func (x *GetTransactionsTotalsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_budget_v1_transaction_proto_msgTypes[12]
+	mi := &file_budget_v1_transaction_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,7 +1504,7 @@ func (x *GetTransactionsTotalsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionsTotalsResponse.ProtoReflect.Descriptor instead.
 func (*GetTransactionsTotalsResponse) Descriptor() ([]byte, []int) {
-	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{12}
+	return file_budget_v1_transaction_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetTransactionsTotalsResponse) GetTotalIncome() *Money {
@@ -856,7 +1525,7 @@ var File_budget_v1_transaction_proto protoreflect.FileDescriptor
 
 const file_budget_v1_transaction_proto_rawDesc = "" +
 	"\n" +
-	"\x1bbudget/v1/transaction.proto\x12\tbudget.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x16budget/v1/common.proto\"\xe1\x03\n" +
+	"\x1bbudget/v1/transaction.proto\x12\tbudget.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x16budget/v1/common.proto\"\x9c\x04\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x17\n" +
@@ -874,7 +1543,9 @@ const file_budget_v1_transaction_proto_rawDesc = "" +
 	" \x01(\tR\acomment\x129\n" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12)\n" +
-	"\x10is_extraordinary\x18\f \x01(\bR\x0fisExtraordinary\"\x97\x02\n" +
+	"\x10is_extraordinary\x18\f \x01(\bR\x0fisExtraordinary\x129\n" +
+	"\n" +
+	"deleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\x97\x02\n" +
 	"\x18CreateTransactionRequest\x12.\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x1a.budget.v1.TransactionTypeR\x04type\x12\x1f\n" +
 	"\vcategory_id\x18\x02 \x01(\tR\n" +
@@ -899,7 +1570,7 @@ const file_budget_v1_transaction_proto_rawDesc = "" +
 	"\x15GetTransactionRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"R\n" +
 	"\x16GetTransactionResponse\x128\n" +
-	"\vtransaction\x18\x01 \x01(\v2\x16.budget.v1.TransactionR\vtransaction\"\xda\x02\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x16.budget.v1.TransactionR\vtransaction\"\xd2\x03\n" +
 	"\x17ListTransactionsRequest\x12*\n" +
 	"\x04page\x18\x01 \x01(\v2\x16.budget.v1.PageRequestR\x04page\x123\n" +
 	"\n" +
@@ -909,10 +1580,54 @@ const file_budget_v1_transaction_proto_rawDesc = "" +
 	"\x0fmin_minor_units\x18\x05 \x01(\x03R\rminMinorUnits\x12&\n" +
 	"\x0fmax_minor_units\x18\x06 \x01(\x03R\rmaxMinorUnits\x12#\n" +
 	"\rcurrency_code\x18\a \x01(\tR\fcurrencyCode\x12\x16\n" +
-	"\x06search\x18\b \x01(\tR\x06search\"\x83\x01\n" +
+	"\x06search\x18\b \x01(\tR\x06search\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\x12(\n" +
+	"\x10skip_total_count\x18\n" +
+	" \x01(\bR\x0eskipTotalCount\x12\x14\n" +
+	"\x05query\x18\v \x01(\tR\x05query\x12\x17\n" +
+	"\aview_id\x18\f \x01(\tR\x06viewId\"\xab\x01\n" +
 	"\x18ListTransactionsResponse\x12:\n" +
 	"\ftransactions\x18\x01 \x03(\v2\x16.budget.v1.TransactionR\ftransactions\x12+\n" +
-	"\x04page\x18\x02 \x01(\v2\x17.budget.v1.PageResponseR\x04page\"\xb3\x02\n" +
+	"\x04page\x18\x02 \x01(\v2\x17.budget.v1.PageResponseR\x04page\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"L\n" +
+	"\x1eListDeletedTransactionsRequest\x12*\n" +
+	"\x04page\x18\x01 \x01(\v2\x16.budget.v1.PageRequestR\x04page\"\x8a\x01\n" +
+	"\x1fListDeletedTransactionsResponse\x12:\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x16.budget.v1.TransactionR\ftransactions\x12+\n" +
+	"\x04page\x18\x02 \x01(\v2\x17.budget.v1.PageResponseR\x04page\"+\n" +
+	"\x19RestoreTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"V\n" +
+	"\x1aRestoreTransactionResponse\x128\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x16.budget.v1.TransactionR\vtransaction\"\x8c\x01\n" +
+	"\x16BatchTransactionResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x128\n" +
+	"\vtransaction\x18\x02 \x01(\v2\x16.budget.v1.TransactionR\vtransaction\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xe5\x01\n" +
+	"\x1eBatchUpdateTransactionsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12:\n" +
+	"\x06filter\x18\x02 \x01(\v2\".budget.v1.ListTransactionsRequestR\x06filter\x128\n" +
+	"\vtransaction\x18\x03 \x01(\v2\x16.budget.v1.TransactionR\vtransaction\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"\x94\x01\n" +
+	"\x1fBatchUpdateTransactionsResponse\x12;\n" +
+	"\aresults\x18\x01 \x03(\v2!.budget.v1.BatchTransactionResultR\aresults\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"n\n" +
+	"\x1eBatchDeleteTransactionsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12:\n" +
+	"\x06filter\x18\x02 \x01(\v2\".budget.v1.ListTransactionsRequestR\x06filter\"\x94\x01\n" +
+	"\x1fBatchDeleteTransactionsResponse\x12;\n" +
+	"\aresults\x18\x01 \x03(\v2!.budget.v1.BatchTransactionResultR\aresults\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"[\n" +
+	"\x1eBatchCreateTransactionsRequest\x129\n" +
+	"\x05items\x18\x01 \x03(\v2#.budget.v1.CreateTransactionRequestR\x05items\"\x94\x01\n" +
+	"\x1fBatchCreateTransactionsResponse\x12;\n" +
+	"\aresults\x18\x01 \x03(\v2!.budget.v1.BatchTransactionResultR\aresults\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"\xe2\x02\n" +
 	"\x1cGetTransactionsTotalsRequest\x123\n" +
 	"\n" +
 	"date_range\x18\x01 \x01(\v2\x14.budget.v1.DateRangeR\tdateRange\x12!\n" +
@@ -921,17 +1636,24 @@ const file_budget_v1_transaction_proto_rawDesc = "" +
 	"\x0fmin_minor_units\x18\x04 \x01(\x03R\rminMinorUnits\x12&\n" +
 	"\x0fmax_minor_units\x18\x05 \x01(\x03R\rmaxMinorUnits\x12#\n" +
 	"\rcurrency_code\x18\x06 \x01(\tR\fcurrencyCode\x12\x16\n" +
-	"\x06search\x18\a \x01(\tR\x06search\"\x8b\x01\n" +
+	"\x06search\x18\a \x01(\tR\x06search\x12\x14\n" +
+	"\x05query\x18\b \x01(\tR\x05query\x12\x17\n" +
+	"\aview_id\x18\t \x01(\tR\x06viewId\"\x8b\x01\n" +
 	"\x1dGetTransactionsTotalsResponse\x123\n" +
 	"\ftotal_income\x18\x01 \x01(\v2\x10.budget.v1.MoneyR\vtotalIncome\x125\n" +
-	"\rtotal_expense\x18\x02 \x01(\v2\x10.budget.v1.MoneyR\ftotalExpense2\xd4\x04\n" +
+	"\rtotal_expense\x18\x02 \x01(\v2\x10.budget.v1.MoneyR\ftotalExpense2\xff\b\n" +
 	"\x12TransactionService\x12^\n" +
 	"\x11CreateTransaction\x12#.budget.v1.CreateTransactionRequest\x1a$.budget.v1.CreateTransactionResponse\x12^\n" +
 	"\x11UpdateTransaction\x12#.budget.v1.UpdateTransactionRequest\x1a$.budget.v1.UpdateTransactionResponse\x12^\n" +
 	"\x11DeleteTransaction\x12#.budget.v1.DeleteTransactionRequest\x1a$.budget.v1.DeleteTransactionResponse\x12U\n" +
 	"\x0eGetTransaction\x12 .budget.v1.GetTransactionRequest\x1a!.budget.v1.GetTransactionResponse\x12[\n" +
 	"\x10ListTransactions\x12\".budget.v1.ListTransactionsRequest\x1a#.budget.v1.ListTransactionsResponse\x12j\n" +
-	"\x15GetTransactionsTotals\x12'.budget.v1.GetTransactionsTotalsRequest\x1a(.budget.v1.GetTransactionsTotalsResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
+	"\x15GetTransactionsTotals\x12'.budget.v1.GetTransactionsTotalsRequest\x1a(.budget.v1.GetTransactionsTotalsResponse\x12p\n" +
+	"\x17BatchCreateTransactions\x12).budget.v1.BatchCreateTransactionsRequest\x1a*.budget.v1.BatchCreateTransactionsResponse\x12p\n" +
+	"\x17BatchUpdateTransactions\x12).budget.v1.BatchUpdateTransactionsRequest\x1a*.budget.v1.BatchUpdateTransactionsResponse\x12p\n" +
+	"\x17BatchDeleteTransactions\x12).budget.v1.BatchDeleteTransactionsRequest\x1a*.budget.v1.BatchDeleteTransactionsResponse\x12p\n" +
+	"\x17ListDeletedTransactions\x12).budget.v1.ListDeletedTransactionsRequest\x1a*.budget.v1.ListDeletedTransactionsResponse\x12a\n" +
+	"\x12RestoreTransaction\x12$.budget.v1.RestoreTransactionRequest\x1a%.budget.v1.RestoreTransactionResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
 
 var (
 	file_budget_v1_transaction_proto_rawDescOnce sync.Once
@@ -945,71 +1667,106 @@ func file_budget_v1_transaction_proto_rawDescGZIP() []byte {
 	return file_budget_v1_transaction_proto_rawDescData
 }
 
-var file_budget_v1_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_budget_v1_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_budget_v1_transaction_proto_goTypes = []any{
-	(*Transaction)(nil),                   // 0: budget.v1.Transaction
-	(*CreateTransactionRequest)(nil),      // 1: budget.v1.CreateTransactionRequest
-	(*CreateTransactionResponse)(nil),     // 2: budget.v1.CreateTransactionResponse
-	(*UpdateTransactionRequest)(nil),      // 3: budget.v1.UpdateTransactionRequest
-	(*UpdateTransactionResponse)(nil),     // 4: budget.v1.UpdateTransactionResponse
-	(*DeleteTransactionRequest)(nil),      // 5: budget.v1.DeleteTransactionRequest
-	(*DeleteTransactionResponse)(nil),     // 6: budget.v1.DeleteTransactionResponse
-	(*GetTransactionRequest)(nil),         // 7: budget.v1.GetTransactionRequest
-	(*GetTransactionResponse)(nil),        // 8: budget.v1.GetTransactionResponse
-	(*ListTransactionsRequest)(nil),       // 9: budget.v1.ListTransactionsRequest
-	(*ListTransactionsResponse)(nil),      // 10: budget.v1.ListTransactionsResponse
-	(*GetTransactionsTotalsRequest)(nil),  // 11: budget.v1.GetTransactionsTotalsRequest
-	(*GetTransactionsTotalsResponse)(nil), // 12: budget.v1.GetTransactionsTotalsResponse
-	(TransactionType)(0),                  // 13: budget.v1.TransactionType
-	(*Money)(nil),                         // 14: budget.v1.Money
-	(*FxInfo)(nil),                        // 15: budget.v1.FxInfo
-	(*timestamppb.Timestamp)(nil),         // 16: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),         // 17: google.protobuf.FieldMask
-	(*PageRequest)(nil),                   // 18: budget.v1.PageRequest
-	(*DateRange)(nil),                     // 19: budget.v1.DateRange
-	(*PageResponse)(nil),                  // 20: budget.v1.PageResponse
+	(*Transaction)(nil),                     // 0: budget.v1.Transaction
+	(*CreateTransactionRequest)(nil),        // 1: budget.v1.CreateTransactionRequest
+	(*CreateTransactionResponse)(nil),       // 2: budget.v1.CreateTransactionResponse
+	(*UpdateTransactionRequest)(nil),        // 3: budget.v1.UpdateTransactionRequest
+	(*UpdateTransactionResponse)(nil),       // 4: budget.v1.UpdateTransactionResponse
+	(*DeleteTransactionRequest)(nil),        // 5: budget.v1.DeleteTransactionRequest
+	(*DeleteTransactionResponse)(nil),       // 6: budget.v1.DeleteTransactionResponse
+	(*GetTransactionRequest)(nil),           // 7: budget.v1.GetTransactionRequest
+	(*GetTransactionResponse)(nil),          // 8: budget.v1.GetTransactionResponse
+	(*ListTransactionsRequest)(nil),         // 9: budget.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),        // 10: budget.v1.ListTransactionsResponse
+	(*ListDeletedTransactionsRequest)(nil),  // 11: budget.v1.ListDeletedTransactionsRequest
+	(*ListDeletedTransactionsResponse)(nil), // 12: budget.v1.ListDeletedTransactionsResponse
+	(*RestoreTransactionRequest)(nil),       // 13: budget.v1.RestoreTransactionRequest
+	(*RestoreTransactionResponse)(nil),      // 14: budget.v1.RestoreTransactionResponse
+	(*BatchTransactionResult)(nil),          // 15: budget.v1.BatchTransactionResult
+	(*BatchUpdateTransactionsRequest)(nil),  // 16: budget.v1.BatchUpdateTransactionsRequest
+	(*BatchUpdateTransactionsResponse)(nil), // 17: budget.v1.BatchUpdateTransactionsResponse
+	(*BatchDeleteTransactionsRequest)(nil),  // 18: budget.v1.BatchDeleteTransactionsRequest
+	(*BatchDeleteTransactionsResponse)(nil), // 19: budget.v1.BatchDeleteTransactionsResponse
+	(*BatchCreateTransactionsRequest)(nil),  // 20: budget.v1.BatchCreateTransactionsRequest
+	(*BatchCreateTransactionsResponse)(nil), // 21: budget.v1.BatchCreateTransactionsResponse
+	(*GetTransactionsTotalsRequest)(nil),    // 22: budget.v1.GetTransactionsTotalsRequest
+	(*GetTransactionsTotalsResponse)(nil),   // 23: budget.v1.GetTransactionsTotalsResponse
+	(TransactionType)(0),                    // 24: budget.v1.TransactionType
+	(*Money)(nil),                           // 25: budget.v1.Money
+	(*FxInfo)(nil),                          // 26: budget.v1.FxInfo
+	(*timestamppb.Timestamp)(nil),           // 27: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),           // 28: google.protobuf.FieldMask
+	(*PageRequest)(nil),                     // 29: budget.v1.PageRequest
+	(*DateRange)(nil),                       // 30: budget.v1.DateRange
+	(*PageResponse)(nil),                    // 31: budget.v1.PageResponse
 }
 var file_budget_v1_transaction_proto_depIdxs = []int32{
-	13, // 0: budget.v1.Transaction.type:type_name -> budget.v1.TransactionType
-	14, // 1: budget.v1.Transaction.amount:type_name -> budget.v1.Money
-	14, // 2: budget.v1.Transaction.base_amount:type_name -> budget.v1.Money
-	15, // 3: budget.v1.Transaction.fx:type_name -> budget.v1.FxInfo
-	16, // 4: budget.v1.Transaction.occurred_at:type_name -> google.protobuf.Timestamp
-	16, // 5: budget.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
-	13, // 6: budget.v1.CreateTransactionRequest.type:type_name -> budget.v1.TransactionType
-	14, // 7: budget.v1.CreateTransactionRequest.amount:type_name -> budget.v1.Money
-	16, // 8: budget.v1.CreateTransactionRequest.occurred_at:type_name -> google.protobuf.Timestamp
-	0,  // 9: budget.v1.CreateTransactionResponse.transaction:type_name -> budget.v1.Transaction
-	0,  // 10: budget.v1.UpdateTransactionRequest.transaction:type_name -> budget.v1.Transaction
-	17, // 11: budget.v1.UpdateTransactionRequest.update_mask:type_name -> google.protobuf.FieldMask
-	0,  // 12: budget.v1.UpdateTransactionResponse.transaction:type_name -> budget.v1.Transaction
-	0,  // 13: budget.v1.GetTransactionResponse.transaction:type_name -> budget.v1.Transaction
-	18, // 14: budget.v1.ListTransactionsRequest.page:type_name -> budget.v1.PageRequest
-	19, // 15: budget.v1.ListTransactionsRequest.date_range:type_name -> budget.v1.DateRange
-	13, // 16: budget.v1.ListTransactionsRequest.type:type_name -> budget.v1.TransactionType
-	0,  // 17: budget.v1.ListTransactionsResponse.transactions:type_name -> budget.v1.Transaction
-	20, // 18: budget.v1.ListTransactionsResponse.page:type_name -> budget.v1.PageResponse
-	19, // 19: budget.v1.GetTransactionsTotalsRequest.date_range:type_name -> budget.v1.DateRange
-	13, // 20: budget.v1.GetTransactionsTotalsRequest.type:type_name -> budget.v1.TransactionType
-	14, // 21: budget.v1.GetTransactionsTotalsResponse.total_income:type_name -> budget.v1.Money
-	14, // 22: budget.v1.GetTransactionsTotalsResponse.total_expense:type_name -> budget.v1.Money
-	1,  // 23: budget.v1.TransactionService.CreateTransaction:input_type -> budget.v1.CreateTransactionRequest
-	3,  // 24: budget.v1.TransactionService.UpdateTransaction:input_type -> budget.v1.UpdateTransactionRequest
-	5,  // 25: budget.v1.TransactionService.DeleteTransaction:input_type -> budget.v1.DeleteTransactionRequest
-	7,  // 26: budget.v1.TransactionService.GetTransaction:input_type -> budget.v1.GetTransactionRequest
-	9,  // 27: budget.v1.TransactionService.ListTransactions:input_type -> budget.v1.ListTransactionsRequest
-	11, // 28: budget.v1.TransactionService.GetTransactionsTotals:input_type -> budget.v1.GetTransactionsTotalsRequest
-	2,  // 29: budget.v1.TransactionService.CreateTransaction:output_type -> budget.v1.CreateTransactionResponse
-	4,  // 30: budget.v1.TransactionService.UpdateTransaction:output_type -> budget.v1.UpdateTransactionResponse
-	6,  // 31: budget.v1.TransactionService.DeleteTransaction:output_type -> budget.v1.DeleteTransactionResponse
-	8,  // 32: budget.v1.TransactionService.GetTransaction:output_type -> budget.v1.GetTransactionResponse
-	10, // 33: budget.v1.TransactionService.ListTransactions:output_type -> budget.v1.ListTransactionsResponse
-	12, // 34: budget.v1.TransactionService.GetTransactionsTotals:output_type -> budget.v1.GetTransactionsTotalsResponse
-	29, // [29:35] is the sub-list for method output_type
-	23, // [23:29] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	24, // 0: budget.v1.Transaction.type:type_name -> budget.v1.TransactionType
+	25, // 1: budget.v1.Transaction.amount:type_name -> budget.v1.Money
+	25, // 2: budget.v1.Transaction.base_amount:type_name -> budget.v1.Money
+	26, // 3: budget.v1.Transaction.fx:type_name -> budget.v1.FxInfo
+	27, // 4: budget.v1.Transaction.occurred_at:type_name -> google.protobuf.Timestamp
+	27, // 5: budget.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	27, // 6: budget.v1.Transaction.deleted_at:type_name -> google.protobuf.Timestamp
+	24, // 7: budget.v1.CreateTransactionRequest.type:type_name -> budget.v1.TransactionType
+	25, // 8: budget.v1.CreateTransactionRequest.amount:type_name -> budget.v1.Money
+	27, // 9: budget.v1.CreateTransactionRequest.occurred_at:type_name -> google.protobuf.Timestamp
+	0,  // 10: budget.v1.CreateTransactionResponse.transaction:type_name -> budget.v1.Transaction
+	0,  // 11: budget.v1.UpdateTransactionRequest.transaction:type_name -> budget.v1.Transaction
+	28, // 12: budget.v1.UpdateTransactionRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 13: budget.v1.UpdateTransactionResponse.transaction:type_name -> budget.v1.Transaction
+	0,  // 14: budget.v1.GetTransactionResponse.transaction:type_name -> budget.v1.Transaction
+	29, // 15: budget.v1.ListTransactionsRequest.page:type_name -> budget.v1.PageRequest
+	30, // 16: budget.v1.ListTransactionsRequest.date_range:type_name -> budget.v1.DateRange
+	24, // 17: budget.v1.ListTransactionsRequest.type:type_name -> budget.v1.TransactionType
+	0,  // 18: budget.v1.ListTransactionsResponse.transactions:type_name -> budget.v1.Transaction
+	31, // 19: budget.v1.ListTransactionsResponse.page:type_name -> budget.v1.PageResponse
+	29, // 20: budget.v1.ListDeletedTransactionsRequest.page:type_name -> budget.v1.PageRequest
+	0,  // 21: budget.v1.ListDeletedTransactionsResponse.transactions:type_name -> budget.v1.Transaction
+	31, // 22: budget.v1.ListDeletedTransactionsResponse.page:type_name -> budget.v1.PageResponse
+	0,  // 23: budget.v1.RestoreTransactionResponse.transaction:type_name -> budget.v1.Transaction
+	0,  // 24: budget.v1.BatchTransactionResult.transaction:type_name -> budget.v1.Transaction
+	9,  // 25: budget.v1.BatchUpdateTransactionsRequest.filter:type_name -> budget.v1.ListTransactionsRequest
+	0,  // 26: budget.v1.BatchUpdateTransactionsRequest.transaction:type_name -> budget.v1.Transaction
+	28, // 27: budget.v1.BatchUpdateTransactionsRequest.update_mask:type_name -> google.protobuf.FieldMask
+	15, // 28: budget.v1.BatchUpdateTransactionsResponse.results:type_name -> budget.v1.BatchTransactionResult
+	9,  // 29: budget.v1.BatchDeleteTransactionsRequest.filter:type_name -> budget.v1.ListTransactionsRequest
+	15, // 30: budget.v1.BatchDeleteTransactionsResponse.results:type_name -> budget.v1.BatchTransactionResult
+	1,  // 31: budget.v1.BatchCreateTransactionsRequest.items:type_name -> budget.v1.CreateTransactionRequest
+	15, // 32: budget.v1.BatchCreateTransactionsResponse.results:type_name -> budget.v1.BatchTransactionResult
+	30, // 33: budget.v1.GetTransactionsTotalsRequest.date_range:type_name -> budget.v1.DateRange
+	24, // 34: budget.v1.GetTransactionsTotalsRequest.type:type_name -> budget.v1.TransactionType
+	25, // 35: budget.v1.GetTransactionsTotalsResponse.total_income:type_name -> budget.v1.Money
+	25, // 36: budget.v1.GetTransactionsTotalsResponse.total_expense:type_name -> budget.v1.Money
+	1,  // 37: budget.v1.TransactionService.CreateTransaction:input_type -> budget.v1.CreateTransactionRequest
+	3,  // 38: budget.v1.TransactionService.UpdateTransaction:input_type -> budget.v1.UpdateTransactionRequest
+	5,  // 39: budget.v1.TransactionService.DeleteTransaction:input_type -> budget.v1.DeleteTransactionRequest
+	7,  // 40: budget.v1.TransactionService.GetTransaction:input_type -> budget.v1.GetTransactionRequest
+	9,  // 41: budget.v1.TransactionService.ListTransactions:input_type -> budget.v1.ListTransactionsRequest
+	22, // 42: budget.v1.TransactionService.GetTransactionsTotals:input_type -> budget.v1.GetTransactionsTotalsRequest
+	20, // 43: budget.v1.TransactionService.BatchCreateTransactions:input_type -> budget.v1.BatchCreateTransactionsRequest
+	16, // 44: budget.v1.TransactionService.BatchUpdateTransactions:input_type -> budget.v1.BatchUpdateTransactionsRequest
+	18, // 45: budget.v1.TransactionService.BatchDeleteTransactions:input_type -> budget.v1.BatchDeleteTransactionsRequest
+	11, // 46: budget.v1.TransactionService.ListDeletedTransactions:input_type -> budget.v1.ListDeletedTransactionsRequest
+	13, // 47: budget.v1.TransactionService.RestoreTransaction:input_type -> budget.v1.RestoreTransactionRequest
+	2,  // 48: budget.v1.TransactionService.CreateTransaction:output_type -> budget.v1.CreateTransactionResponse
+	4,  // 49: budget.v1.TransactionService.UpdateTransaction:output_type -> budget.v1.UpdateTransactionResponse
+	6,  // 50: budget.v1.TransactionService.DeleteTransaction:output_type -> budget.v1.DeleteTransactionResponse
+	8,  // 51: budget.v1.TransactionService.GetTransaction:output_type -> budget.v1.GetTransactionResponse
+	10, // 52: budget.v1.TransactionService.ListTransactions:output_type -> budget.v1.ListTransactionsResponse
+	23, // 53: budget.v1.TransactionService.GetTransactionsTotals:output_type -> budget.v1.GetTransactionsTotalsResponse
+	21, // 54: budget.v1.TransactionService.BatchCreateTransactions:output_type -> budget.v1.BatchCreateTransactionsResponse
+	17, // 55: budget.v1.TransactionService.BatchUpdateTransactions:output_type -> budget.v1.BatchUpdateTransactionsResponse
+	19, // 56: budget.v1.TransactionService.BatchDeleteTransactions:output_type -> budget.v1.BatchDeleteTransactionsResponse
+	12, // 57: budget.v1.TransactionService.ListDeletedTransactions:output_type -> budget.v1.ListDeletedTransactionsResponse
+	14, // 58: budget.v1.TransactionService.RestoreTransaction:output_type -> budget.v1.RestoreTransactionResponse
+	48, // [48:59] is the sub-list for method output_type
+	37, // [37:48] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_budget_v1_transaction_proto_init() }
@@ -1024,7 +1781,7 @@ func file_budget_v1_transaction_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_budget_v1_transaction_proto_rawDesc), len(file_budget_v1_transaction_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.5.1
+// - protoc-gen-go-grpc v1.6.1
 // - protoc             (unknown)
 // source: budget/v1/transaction.proto
 
@@ -19,12 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TransactionService_CreateTransaction_FullMethodName     = "/budget.v1.TransactionService/CreateTransaction"
-	TransactionService_UpdateTransaction_FullMethodName     = "/budget.v1.TransactionService/UpdateTransaction"
-	TransactionService_DeleteTransaction_FullMethodName     = "/budget.v1.TransactionService/DeleteTransaction"
-	TransactionService_GetTransaction_FullMethodName        = "/budget.v1.TransactionService/GetTransaction"
-	TransactionService_ListTransactions_FullMethodName      = "/budget.v1.TransactionService/ListTransactions"
-	TransactionService_GetTransactionsTotals_FullMethodName = "/budget.v1.TransactionService/GetTransactionsTotals"
+	TransactionService_CreateTransaction_FullMethodName       = "/budget.v1.TransactionService/CreateTransaction"
+	TransactionService_UpdateTransaction_FullMethodName       = "/budget.v1.TransactionService/UpdateTransaction"
+	TransactionService_DeleteTransaction_FullMethodName       = "/budget.v1.TransactionService/DeleteTransaction"
+	TransactionService_GetTransaction_FullMethodName          = "/budget.v1.TransactionService/GetTransaction"
+	TransactionService_ListTransactions_FullMethodName        = "/budget.v1.TransactionService/ListTransactions"
+	TransactionService_GetTransactionsTotals_FullMethodName   = "/budget.v1.TransactionService/GetTransactionsTotals"
+	TransactionService_BatchCreateTransactions_FullMethodName = "/budget.v1.TransactionService/BatchCreateTransactions"
+	TransactionService_BatchUpdateTransactions_FullMethodName = "/budget.v1.TransactionService/BatchUpdateTransactions"
+	TransactionService_BatchDeleteTransactions_FullMethodName = "/budget.v1.TransactionService/BatchDeleteTransactions"
+	TransactionService_ListDeletedTransactions_FullMethodName = "/budget.v1.TransactionService/ListDeletedTransactions"
+	TransactionService_RestoreTransaction_FullMethodName      = "/budget.v1.TransactionService/RestoreTransaction"
 )
 
 // TransactionServiceClient is the client API for TransactionService service.
@@ -38,6 +43,12 @@ type TransactionServiceClient interface {
 	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
 	// Returns totals (income/expense) for the provided filters, ignoring pagination
 	GetTransactionsTotals(ctx context.Context, in *GetTransactionsTotalsRequest, opts ...grpc.CallOption) (*GetTransactionsTotalsResponse, error)
+	BatchCreateTransactions(ctx context.Context, in *BatchCreateTransactionsRequest, opts ...grpc.CallOption) (*BatchCreateTransactionsResponse, error)
+	BatchUpdateTransactions(ctx context.Context, in *BatchUpdateTransactionsRequest, opts ...grpc.CallOption) (*BatchUpdateTransactionsResponse, error)
+	BatchDeleteTransactions(ctx context.Context, in *BatchDeleteTransactionsRequest, opts ...grpc.CallOption) (*BatchDeleteTransactionsResponse, error)
+	ListDeletedTransactions(ctx context.Context, in *ListDeletedTransactionsRequest, opts ...grpc.CallOption) (*ListDeletedTransactionsResponse, error)
+	// Fails with FAILED_PRECONDITION while the transaction's category is deleted
+	RestoreTransaction(ctx context.Context, in *RestoreTransactionRequest, opts ...grpc.CallOption) (*RestoreTransactionResponse, error)
 }
 
 type transactionServiceClient struct {
@@ -108,6 +119,56 @@ func (c *transactionServiceClient) GetTransactionsTotals(ctx context.Context, in
 	return out, nil
 }
 
+func (c *transactionServiceClient) BatchCreateTransactions(ctx context.Context, in *BatchCreateTransactionsRequest, opts ...grpc.CallOption) (*BatchCreateTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCreateTransactionsResponse)
+	err := c.cc.Invoke(ctx, TransactionService_BatchCreateTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) BatchUpdateTransactions(ctx context.Context, in *BatchUpdateTransactionsRequest, opts ...grpc.CallOption) (*BatchUpdateTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateTransactionsResponse)
+	err := c.cc.Invoke(ctx, TransactionService_BatchUpdateTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) BatchDeleteTransactions(ctx context.Context, in *BatchDeleteTransactionsRequest, opts ...grpc.CallOption) (*BatchDeleteTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchDeleteTransactionsResponse)
+	err := c.cc.Invoke(ctx, TransactionService_BatchDeleteTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) ListDeletedTransactions(ctx context.Context, in *ListDeletedTransactionsRequest, opts ...grpc.CallOption) (*ListDeletedTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedTransactionsResponse)
+	err := c.cc.Invoke(ctx, TransactionService_ListDeletedTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) RestoreTransaction(ctx context.Context, in *RestoreTransactionRequest, opts ...grpc.CallOption) (*RestoreTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreTransactionResponse)
+	err := c.cc.Invoke(ctx, TransactionService_RestoreTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionServiceServer is the server API for TransactionService service.
 // All implementations must embed UnimplementedTransactionServiceServer
 // for forward compatibility.
@@ -119,6 +180,12 @@ type TransactionServiceServer interface {
 	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
 	// Returns totals (income/expense) for the provided filters, ignoring pagination
 	GetTransactionsTotals(context.Context, *GetTransactionsTotalsRequest) (*GetTransactionsTotalsResponse, error)
+	BatchCreateTransactions(context.Context, *BatchCreateTransactionsRequest) (*BatchCreateTransactionsResponse, error)
+	BatchUpdateTransactions(context.Context, *BatchUpdateTransactionsRequest) (*BatchUpdateTransactionsResponse, error)
+	BatchDeleteTransactions(context.Context, *BatchDeleteTransactionsRequest) (*BatchDeleteTransactionsResponse, error)
+	ListDeletedTransactions(context.Context, *ListDeletedTransactionsRequest) (*ListDeletedTransactionsResponse, error)
+	// Fails with FAILED_PRECONDITION while the transaction's category is deleted
+	RestoreTransaction(context.Context, *RestoreTransactionRequest) (*RestoreTransactionResponse, error)
 	mustEmbedUnimplementedTransactionServiceServer()
 }
 
@@ -130,22 +197,37 @@ type TransactionServiceServer interface {
 type UnimplementedTransactionServiceServer struct{}
 
 func (UnimplementedTransactionServiceServer) CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
+	return nil, status.Error(codes.Unimplemented, "method CreateTransaction not implemented")
 }
 func (UnimplementedTransactionServiceServer) UpdateTransaction(context.Context, *UpdateTransactionRequest) (*UpdateTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateTransaction not implemented")
+	return nil, status.Error(codes.Unimplemented, "method UpdateTransaction not implemented")
 }
 func (UnimplementedTransactionServiceServer) DeleteTransaction(context.Context, *DeleteTransactionRequest) (*DeleteTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTransaction not implemented")
+	return nil, status.Error(codes.Unimplemented, "method DeleteTransaction not implemented")
 }
 func (UnimplementedTransactionServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GetTransaction not implemented")
 }
 func (UnimplementedTransactionServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+	return nil, status.Error(codes.Unimplemented, "method ListTransactions not implemented")
 }
 func (UnimplementedTransactionServiceServer) GetTransactionsTotals(context.Context, *GetTransactionsTotalsRequest) (*GetTransactionsTotalsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionsTotals not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GetTransactionsTotals not implemented")
+}
+func (UnimplementedTransactionServiceServer) BatchCreateTransactions(context.Context, *BatchCreateTransactionsRequest) (*BatchCreateTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchCreateTransactions not implemented")
+}
+func (UnimplementedTransactionServiceServer) BatchUpdateTransactions(context.Context, *BatchUpdateTransactionsRequest) (*BatchUpdateTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchUpdateTransactions not implemented")
+}
+func (UnimplementedTransactionServiceServer) BatchDeleteTransactions(context.Context, *BatchDeleteTransactionsRequest) (*BatchDeleteTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchDeleteTransactions not implemented")
+}
+func (UnimplementedTransactionServiceServer) ListDeletedTransactions(context.Context, *ListDeletedTransactionsRequest) (*ListDeletedTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeletedTransactions not implemented")
+}
+func (UnimplementedTransactionServiceServer) RestoreTransaction(context.Context, *RestoreTransactionRequest) (*RestoreTransactionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreTransaction not implemented")
 }
 func (UnimplementedTransactionServiceServer) mustEmbedUnimplementedTransactionServiceServer() {}
 func (UnimplementedTransactionServiceServer) testEmbeddedByValue()                            {}
@@ -158,7 +240,7 @@ type UnsafeTransactionServiceServer interface {
 }
 
 func RegisterTransactionServiceServer(s grpc.ServiceRegistrar, srv TransactionServiceServer) {
-	// If the following call pancis, it indicates UnimplementedTransactionServiceServer was
+	// If the following call panics, it indicates UnimplementedTransactionServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
@@ -276,6 +358,96 @@ func _TransactionService_GetTransactionsTotals_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_BatchCreateTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).BatchCreateTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_BatchCreateTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).BatchCreateTransactions(ctx, req.(*BatchCreateTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_BatchUpdateTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).BatchUpdateTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_BatchUpdateTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).BatchUpdateTransactions(ctx, req.(*BatchUpdateTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_BatchDeleteTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).BatchDeleteTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_BatchDeleteTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).BatchDeleteTransactions(ctx, req.(*BatchDeleteTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_ListDeletedTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListDeletedTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_ListDeletedTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListDeletedTransactions(ctx, req.(*ListDeletedTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_RestoreTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).RestoreTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_RestoreTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).RestoreTransaction(ctx, req.(*RestoreTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionService_ServiceDesc is the grpc.ServiceDesc for TransactionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -307,6 +479,26 @@ var TransactionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactionsTotals",
 			Handler:    _TransactionService_GetTransactionsTotals_Handler,
 		},
+		{
+			MethodName: "BatchCreateTransactions",
+			Handler:    _TransactionService_BatchCreateTransactions_Handler,
+		},
+		{
+			MethodName: "BatchUpdateTransactions",
+			Handler:    _TransactionService_BatchUpdateTransactions_Handler,
+		},
+		{
+			MethodName: "BatchDeleteTransactions",
+			Handler:    _TransactionService_BatchDeleteTransactions_Handler,
+		},
+		{
+			MethodName: "ListDeletedTransactions",
+			Handler:    _TransactionService_ListDeletedTransactions_Handler,
+		},
+		{
+			MethodName: "RestoreTransaction",
+			Handler:    _TransactionService_RestoreTransaction_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "budget/v1/transaction.proto",
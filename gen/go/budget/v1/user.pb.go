@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.9
+// 	protoc-gen-go v1.36.11
 // 	protoc        (unknown)
 // source: budget/v1/user.proto
 
@@ -22,6 +22,110 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ApiTokenScope int32
+
+const (
+	ApiTokenScope_API_TOKEN_SCOPE_UNSPECIFIED ApiTokenScope = 0
+	ApiTokenScope_API_TOKEN_SCOPE_READ        ApiTokenScope = 1 // Get*/List* methods only
+	ApiTokenScope_API_TOKEN_SCOPE_READ_WRITE  ApiTokenScope = 2
+)
+
+// Enum value maps for ApiTokenScope.
+var (
+	ApiTokenScope_name = map[int32]string{
+		0: "API_TOKEN_SCOPE_UNSPECIFIED",
+		1: "API_TOKEN_SCOPE_READ",
+		2: "API_TOKEN_SCOPE_READ_WRITE",
+	}
+	ApiTokenScope_value = map[string]int32{
+		"API_TOKEN_SCOPE_UNSPECIFIED": 0,
+		"API_TOKEN_SCOPE_READ":        1,
+		"API_TOKEN_SCOPE_READ_WRITE":  2,
+	}
+)
+
+func (x ApiTokenScope) Enum() *ApiTokenScope {
+	p := new(ApiTokenScope)
+	*p = x
+	return p
+}
+
+func (x ApiTokenScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApiTokenScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_budget_v1_user_proto_enumTypes[0].Descriptor()
+}
+
+func (ApiTokenScope) Type() protoreflect.EnumType {
+	return &file_budget_v1_user_proto_enumTypes[0]
+}
+
+func (x ApiTokenScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ApiTokenScope.Descriptor instead.
+func (ApiTokenScope) EnumDescriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{0}
+}
+
+type LoginAttemptStatus int32
+
+const (
+	LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_UNSPECIFIED         LoginAttemptStatus = 0
+	LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_SUCCESS             LoginAttemptStatus = 1
+	LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_FAILED              LoginAttemptStatus = 2
+	LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_BLOCKED             LoginAttemptStatus = 3 // rejected by lockout or delay, password not checked
+	LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_TWO_FACTOR_REQUIRED LoginAttemptStatus = 4 // correct password, second factor requested
+)
+
+// Enum value maps for LoginAttemptStatus.
+var (
+	LoginAttemptStatus_name = map[int32]string{
+		0: "LOGIN_ATTEMPT_STATUS_UNSPECIFIED",
+		1: "LOGIN_ATTEMPT_STATUS_SUCCESS",
+		2: "LOGIN_ATTEMPT_STATUS_FAILED",
+		3: "LOGIN_ATTEMPT_STATUS_BLOCKED",
+		4: "LOGIN_ATTEMPT_STATUS_TWO_FACTOR_REQUIRED",
+	}
+	LoginAttemptStatus_value = map[string]int32{
+		"LOGIN_ATTEMPT_STATUS_UNSPECIFIED":         0,
+		"LOGIN_ATTEMPT_STATUS_SUCCESS":             1,
+		"LOGIN_ATTEMPT_STATUS_FAILED":              2,
+		"LOGIN_ATTEMPT_STATUS_BLOCKED":             3,
+		"LOGIN_ATTEMPT_STATUS_TWO_FACTOR_REQUIRED": 4,
+	}
+)
+
+func (x LoginAttemptStatus) Enum() *LoginAttemptStatus {
+	p := new(LoginAttemptStatus)
+	*p = x
+	return p
+}
+
+func (x LoginAttemptStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LoginAttemptStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_budget_v1_user_proto_enumTypes[1].Descriptor()
+}
+
+func (LoginAttemptStatus) Type() protoreflect.EnumType {
+	return &file_budget_v1_user_proto_enumTypes[1]
+}
+
+func (x LoginAttemptStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LoginAttemptStatus.Descriptor instead.
+func (LoginAttemptStatus) EnumDescriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
@@ -294,6 +398,7 @@ type ChangePasswordRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
 	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	RefreshToken    string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // optional: this session stays signed in, others are revoked
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -342,6 +447,13 @@ func (x *ChangePasswordRequest) GetNewPassword() string {
 	return ""
 }
 
+func (x *ChangePasswordRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
 type ChangePasswordResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -378,37 +490,1305 @@ func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
 	return file_budget_v1_user_proto_rawDescGZIP(), []int{6}
 }
 
-var File_budget_v1_user_proto protoreflect.FileDescriptor
+// Session is a signed-in device (refresh token family)
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"` // last login or token refresh
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Current       bool                   `protobuf:"varint,8,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_budget_v1_user_proto_rawDesc = "" +
-	"\n" +
-	"\x14budget/v1/user.proto\x12\tbudget.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf5\x01\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
-	"\x06locale\x18\x04 \x01(\tR\x06locale\x12%\n" +
-	"\x0eemail_verified\x18\x05 \x01(\bR\remailVerified\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x0e\n" +
-	"\fGetMeRequest\"4\n" +
-	"\rGetMeResponse\x12#\n" +
-	"\x04user\x18\x01 \x01(\v2\x0f.budget.v1.UserR\x04user\"B\n" +
-	"\x14UpdateProfileRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
-	"\x06locale\x18\x02 \x01(\tR\x06locale\"<\n" +
-	"\x15UpdateProfileResponse\x12#\n" +
-	"\x04user\x18\x01 \x01(\v2\x0f.budget.v1.UserR\x04user\"e\n" +
-	"\x15ChangePasswordRequest\x12)\n" +
-	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
-	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"\x18\n" +
-	"\x16ChangePasswordResponse2\xf4\x01\n" +
-	"\vUserService\x12:\n" +
-	"\x05GetMe\x12\x17.budget.v1.GetMeRequest\x1a\x18.budget.v1.GetMeResponse\x12R\n" +
-	"\rUpdateProfile\x12\x1f.budget.v1.UpdateProfileRequest\x1a .budget.v1.UpdateProfileResponse\x12U\n" +
-	"\x0eChangePassword\x12 .budget.v1.ChangePasswordRequest\x1a!.budget.v1.ChangePasswordResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_budget_v1_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // optional: marks the caller's session as current
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSessionsRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RevokeSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{11}
+}
+
+type RevokeAllOtherSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // refresh token of the session to keep
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllOtherSessionsRequest) Reset() {
+	*x = RevokeAllOtherSessionsRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllOtherSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllOtherSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeAllOtherSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllOtherSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllOtherSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RevokeAllOtherSessionsRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RevokeAllOtherSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RevokedCount  int64                  `protobuf:"varint,1,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllOtherSessionsResponse) Reset() {
+	*x = RevokeAllOtherSessionsResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllOtherSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllOtherSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeAllOtherSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllOtherSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAllOtherSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RevokeAllOtherSessionsResponse) GetRevokedCount() int64 {
+	if x != nil {
+		return x.RevokedCount
+	}
+	return 0
+}
+
+// EnrollTOTP starts 2FA setup: add the secret to an authenticator app, then ConfirmTOTP
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{14}
+}
+
+type EnrollTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                           // base32
+	OtpauthUri    string                 `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"` // for QR codes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPResponse) ProtoMessage() {}
+
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EnrollTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetOtpauthUri() string {
+	if x != nil {
+		return x.OtpauthUri
+	}
+	return ""
+}
+
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // one-time codes, shown only once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPResponse) ProtoMessage() {}
+
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConfirmTOTPResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // current TOTP code or a recovery code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPResponse) Reset() {
+	*x = DisableTOTPResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPResponse) ProtoMessage() {}
+
+func (x *DisableTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{19}
+}
+
+// ApiToken is a personal access token for scripts; sent as "authorization: Bearer bpat_..."
+type ApiToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Scope         ApiTokenScope          `protobuf:"varint,4,opt,name=scope,proto3,enum=budget.v1.ApiTokenScope" json:"scope,omitempty"`
+	TokenPrefix   string                 `protobuf:"bytes,5,opt,name=token_prefix,json=tokenPrefix,proto3" json:"token_prefix,omitempty"` // first characters of the token
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unset: no expiry
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiToken) Reset() {
+	*x = ApiToken{}
+	mi := &file_budget_v1_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiToken) ProtoMessage() {}
+
+func (x *ApiToken) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiToken.ProtoReflect.Descriptor instead.
+func (*ApiToken) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApiToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApiToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiToken) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ApiToken) GetScope() ApiTokenScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ApiTokenScope_API_TOKEN_SCOPE_UNSPECIFIED
+}
+
+func (x *ApiToken) GetTokenPrefix() string {
+	if x != nil {
+		return x.TokenPrefix
+	}
+	return ""
+}
+
+func (x *ApiToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateApiTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // optional: defaults to the active tenant
+	Scope         ApiTokenScope          `protobuf:"varint,3,opt,name=scope,proto3,enum=budget.v1.ApiTokenScope" json:"scope,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiTokenRequest) Reset() {
+	*x = CreateApiTokenRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiTokenRequest) ProtoMessage() {}
+
+func (x *CreateApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateApiTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiTokenRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreateApiTokenRequest) GetScope() ApiTokenScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ApiTokenScope_API_TOKEN_SCOPE_UNSPECIFIED
+}
+
+func (x *CreateApiTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateApiTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiToken      *ApiToken              `protobuf:"bytes,1,opt,name=api_token,json=apiToken,proto3" json:"api_token,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // shown only once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiTokenResponse) Reset() {
+	*x = CreateApiTokenResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiTokenResponse) ProtoMessage() {}
+
+func (x *CreateApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateApiTokenResponse) GetApiToken() *ApiToken {
+	if x != nil {
+		return x.ApiToken
+	}
+	return nil
+}
+
+func (x *CreateApiTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListApiTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiTokensRequest) Reset() {
+	*x = ListApiTokensRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiTokensRequest) ProtoMessage() {}
+
+func (x *ListApiTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListApiTokensRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{23}
+}
+
+type ListApiTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiTokens     []*ApiToken            `protobuf:"bytes,1,rep,name=api_tokens,json=apiTokens,proto3" json:"api_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiTokensResponse) Reset() {
+	*x = ListApiTokensResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiTokensResponse) ProtoMessage() {}
+
+func (x *ListApiTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListApiTokensResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListApiTokensResponse) GetApiTokens() []*ApiToken {
+	if x != nil {
+		return x.ApiTokens
+	}
+	return nil
+}
+
+type RevokeApiTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiTokenRequest) Reset() {
+	*x = RevokeApiTokenRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiTokenRequest) ProtoMessage() {}
+
+func (x *RevokeApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RevokeApiTokenRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevokeApiTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiTokenResponse) Reset() {
+	*x = RevokeApiTokenResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiTokenResponse) ProtoMessage() {}
+
+func (x *RevokeApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{26}
+}
+
+// LoginAttempt is an entry of the account security log
+type LoginAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"` // "password", "two_factor" or identity provider name
+	Status        LoginAttemptStatus     `protobuf:"varint,3,opt,name=status,proto3,enum=budget.v1.LoginAttemptStatus" json:"status,omitempty"`
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginAttempt) Reset() {
+	*x = LoginAttempt{}
+	mi := &file_budget_v1_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginAttempt) ProtoMessage() {}
+
+func (x *LoginAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginAttempt.ProtoReflect.Descriptor instead.
+func (*LoginAttempt) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LoginAttempt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetStatus() LoginAttemptStatus {
+	if x != nil {
+		return x.Status
+	}
+	return LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_UNSPECIFIED
+}
+
+func (x *LoginAttempt) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListLoginAttemptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // default 20, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoginAttemptsRequest) Reset() {
+	*x = ListLoginAttemptsRequest{}
+	mi := &file_budget_v1_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoginAttemptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoginAttemptsRequest) ProtoMessage() {}
+
+func (x *ListLoginAttemptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoginAttemptsRequest.ProtoReflect.Descriptor instead.
+func (*ListLoginAttemptsRequest) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListLoginAttemptsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLoginAttemptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempts      []*LoginAttempt        `protobuf:"bytes,1,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoginAttemptsResponse) Reset() {
+	*x = ListLoginAttemptsResponse{}
+	mi := &file_budget_v1_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoginAttemptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoginAttemptsResponse) ProtoMessage() {}
+
+func (x *ListLoginAttemptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_budget_v1_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoginAttemptsResponse.ProtoReflect.Descriptor instead.
+func (*ListLoginAttemptsResponse) Descriptor() ([]byte, []int) {
+	return file_budget_v1_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListLoginAttemptsResponse) GetAttempts() []*LoginAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+var File_budget_v1_user_proto protoreflect.FileDescriptor
+
+const file_budget_v1_user_proto_rawDesc = "" +
+	"\n" +
+	"\x14budget/v1/user.proto\x12\tbudget.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf5\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12%\n" +
+	"\x0eemail_verified\x18\x05 \x01(\bR\remailVerified\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x0e\n" +
+	"\fGetMeRequest\"4\n" +
+	"\rGetMeResponse\x12#\n" +
+	"\x04user\x18\x01 \x01(\v2\x0f.budget.v1.UserR\x04user\"B\n" +
+	"\x14UpdateProfileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"<\n" +
+	"\x15UpdateProfileResponse\x12#\n" +
+	"\x04user\x18\x01 \x01(\v2\x0f.budget.v1.UserR\x04user\"\x8a\x01\n" +
+	"\x15ChangePasswordRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"\x18\n" +
+	"\x16ChangePasswordResponse\"\xb3\x02\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\acurrent\x18\b \x01(\bR\acurrent\":\n" +
+	"\x13ListSessionsRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"F\n" +
+	"\x14ListSessionsResponse\x12.\n" +
+	"\bsessions\x18\x01 \x03(\v2\x12.budget.v1.SessionR\bsessions\"5\n" +
+	"\x14RevokeSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\x17\n" +
+	"\x15RevokeSessionResponse\"D\n" +
+	"\x1dRevokeAllOtherSessionsRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"E\n" +
+	"\x1eRevokeAllOtherSessionsResponse\x12#\n" +
+	"\rrevoked_count\x18\x01 \x01(\x03R\frevokedCount\"\x13\n" +
+	"\x11EnrollTOTPRequest\"M\n" +
+	"\x12EnrollTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_uri\x18\x02 \x01(\tR\n" +
+	"otpauthUri\"(\n" +
+	"\x12ConfirmTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"<\n" +
+	"\x13ConfirmTOTPResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"(\n" +
+	"\x12DisableTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x15\n" +
+	"\x13DisableTOTPResponse\"\xd2\x02\n" +
+	"\bApiToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12.\n" +
+	"\x05scope\x18\x04 \x01(\x0e2\x18.budget.v1.ApiTokenScopeR\x05scope\x12!\n" +
+	"\ftoken_prefix\x18\x05 \x01(\tR\vtokenPrefix\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xb3\x01\n" +
+	"\x15CreateApiTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12.\n" +
+	"\x05scope\x18\x03 \x01(\x0e2\x18.budget.v1.ApiTokenScopeR\x05scope\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"`\n" +
+	"\x16CreateApiTokenResponse\x120\n" +
+	"\tapi_token\x18\x01 \x01(\v2\x13.budget.v1.ApiTokenR\bapiToken\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x16\n" +
+	"\x14ListApiTokensRequest\"K\n" +
+	"\x15ListApiTokensResponse\x122\n" +
+	"\n" +
+	"api_tokens\x18\x01 \x03(\v2\x13.budget.v1.ApiTokenR\tapiTokens\"'\n" +
+	"\x15RevokeApiTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16RevokeApiTokenResponse\"\xd7\x01\n" +
+	"\fLoginAttempt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x125\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1d.budget.v1.LoginAttemptStatusR\x06status\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"0\n" +
+	"\x18ListLoginAttemptsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"P\n" +
+	"\x19ListLoginAttemptsResponse\x123\n" +
+	"\battempts\x18\x01 \x03(\v2\x17.budget.v1.LoginAttemptR\battempts*j\n" +
+	"\rApiTokenScope\x12\x1f\n" +
+	"\x1bAPI_TOKEN_SCOPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14API_TOKEN_SCOPE_READ\x10\x01\x12\x1e\n" +
+	"\x1aAPI_TOKEN_SCOPE_READ_WRITE\x10\x02*\xcd\x01\n" +
+	"\x12LoginAttemptStatus\x12$\n" +
+	" LOGIN_ATTEMPT_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cLOGIN_ATTEMPT_STATUS_SUCCESS\x10\x01\x12\x1f\n" +
+	"\x1bLOGIN_ATTEMPT_STATUS_FAILED\x10\x02\x12 \n" +
+	"\x1cLOGIN_ATTEMPT_STATUS_BLOCKED\x10\x03\x12,\n" +
+	"(LOGIN_ATTEMPT_STATUS_TWO_FACTOR_REQUIRED\x10\x042\xd1\b\n" +
+	"\vUserService\x12:\n" +
+	"\x05GetMe\x12\x17.budget.v1.GetMeRequest\x1a\x18.budget.v1.GetMeResponse\x12R\n" +
+	"\rUpdateProfile\x12\x1f.budget.v1.UpdateProfileRequest\x1a .budget.v1.UpdateProfileResponse\x12U\n" +
+	"\x0eChangePassword\x12 .budget.v1.ChangePasswordRequest\x1a!.budget.v1.ChangePasswordResponse\x12O\n" +
+	"\fListSessions\x12\x1e.budget.v1.ListSessionsRequest\x1a\x1f.budget.v1.ListSessionsResponse\x12R\n" +
+	"\rRevokeSession\x12\x1f.budget.v1.RevokeSessionRequest\x1a .budget.v1.RevokeSessionResponse\x12m\n" +
+	"\x16RevokeAllOtherSessions\x12(.budget.v1.RevokeAllOtherSessionsRequest\x1a).budget.v1.RevokeAllOtherSessionsResponse\x12I\n" +
+	"\n" +
+	"EnrollTOTP\x12\x1c.budget.v1.EnrollTOTPRequest\x1a\x1d.budget.v1.EnrollTOTPResponse\x12L\n" +
+	"\vConfirmTOTP\x12\x1d.budget.v1.ConfirmTOTPRequest\x1a\x1e.budget.v1.ConfirmTOTPResponse\x12L\n" +
+	"\vDisableTOTP\x12\x1d.budget.v1.DisableTOTPRequest\x1a\x1e.budget.v1.DisableTOTPResponse\x12U\n" +
+	"\x0eCreateApiToken\x12 .budget.v1.CreateApiTokenRequest\x1a!.budget.v1.CreateApiTokenResponse\x12R\n" +
+	"\rListApiTokens\x12\x1f.budget.v1.ListApiTokensRequest\x1a .budget.v1.ListApiTokensResponse\x12U\n" +
+	"\x0eRevokeApiToken\x12 .budget.v1.RevokeApiTokenRequest\x1a!.budget.v1.RevokeApiTokenResponse\x12^\n" +
+	"\x11ListLoginAttempts\x12#.budget.v1.ListLoginAttemptsRequest\x1a$.budget.v1.ListLoginAttemptsResponseB8Z6github.com/positron48/budget/gen/go/budget/v1;budgetv1b\x06proto3"
 
 var (
 	file_budget_v1_user_proto_rawDescOnce sync.Once
@@ -422,33 +1802,94 @@ func file_budget_v1_user_proto_rawDescGZIP() []byte {
 	return file_budget_v1_user_proto_rawDescData
 }
 
-var file_budget_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_budget_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_budget_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_budget_v1_user_proto_goTypes = []any{
-	(*User)(nil),                   // 0: budget.v1.User
-	(*GetMeRequest)(nil),           // 1: budget.v1.GetMeRequest
-	(*GetMeResponse)(nil),          // 2: budget.v1.GetMeResponse
-	(*UpdateProfileRequest)(nil),   // 3: budget.v1.UpdateProfileRequest
-	(*UpdateProfileResponse)(nil),  // 4: budget.v1.UpdateProfileResponse
-	(*ChangePasswordRequest)(nil),  // 5: budget.v1.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil), // 6: budget.v1.ChangePasswordResponse
-	(*timestamppb.Timestamp)(nil),  // 7: google.protobuf.Timestamp
+	(ApiTokenScope)(0),                     // 0: budget.v1.ApiTokenScope
+	(LoginAttemptStatus)(0),                // 1: budget.v1.LoginAttemptStatus
+	(*User)(nil),                           // 2: budget.v1.User
+	(*GetMeRequest)(nil),                   // 3: budget.v1.GetMeRequest
+	(*GetMeResponse)(nil),                  // 4: budget.v1.GetMeResponse
+	(*UpdateProfileRequest)(nil),           // 5: budget.v1.UpdateProfileRequest
+	(*UpdateProfileResponse)(nil),          // 6: budget.v1.UpdateProfileResponse
+	(*ChangePasswordRequest)(nil),          // 7: budget.v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),         // 8: budget.v1.ChangePasswordResponse
+	(*Session)(nil),                        // 9: budget.v1.Session
+	(*ListSessionsRequest)(nil),            // 10: budget.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),           // 11: budget.v1.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),           // 12: budget.v1.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),          // 13: budget.v1.RevokeSessionResponse
+	(*RevokeAllOtherSessionsRequest)(nil),  // 14: budget.v1.RevokeAllOtherSessionsRequest
+	(*RevokeAllOtherSessionsResponse)(nil), // 15: budget.v1.RevokeAllOtherSessionsResponse
+	(*EnrollTOTPRequest)(nil),              // 16: budget.v1.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),             // 17: budget.v1.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),             // 18: budget.v1.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),            // 19: budget.v1.ConfirmTOTPResponse
+	(*DisableTOTPRequest)(nil),             // 20: budget.v1.DisableTOTPRequest
+	(*DisableTOTPResponse)(nil),            // 21: budget.v1.DisableTOTPResponse
+	(*ApiToken)(nil),                       // 22: budget.v1.ApiToken
+	(*CreateApiTokenRequest)(nil),          // 23: budget.v1.CreateApiTokenRequest
+	(*CreateApiTokenResponse)(nil),         // 24: budget.v1.CreateApiTokenResponse
+	(*ListApiTokensRequest)(nil),           // 25: budget.v1.ListApiTokensRequest
+	(*ListApiTokensResponse)(nil),          // 26: budget.v1.ListApiTokensResponse
+	(*RevokeApiTokenRequest)(nil),          // 27: budget.v1.RevokeApiTokenRequest
+	(*RevokeApiTokenResponse)(nil),         // 28: budget.v1.RevokeApiTokenResponse
+	(*LoginAttempt)(nil),                   // 29: budget.v1.LoginAttempt
+	(*ListLoginAttemptsRequest)(nil),       // 30: budget.v1.ListLoginAttemptsRequest
+	(*ListLoginAttemptsResponse)(nil),      // 31: budget.v1.ListLoginAttemptsResponse
+	(*timestamppb.Timestamp)(nil),          // 32: google.protobuf.Timestamp
 }
 var file_budget_v1_user_proto_depIdxs = []int32{
-	7, // 0: budget.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	7, // 1: budget.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 2: budget.v1.GetMeResponse.user:type_name -> budget.v1.User
-	0, // 3: budget.v1.UpdateProfileResponse.user:type_name -> budget.v1.User
-	1, // 4: budget.v1.UserService.GetMe:input_type -> budget.v1.GetMeRequest
-	3, // 5: budget.v1.UserService.UpdateProfile:input_type -> budget.v1.UpdateProfileRequest
-	5, // 6: budget.v1.UserService.ChangePassword:input_type -> budget.v1.ChangePasswordRequest
-	2, // 7: budget.v1.UserService.GetMe:output_type -> budget.v1.GetMeResponse
-	4, // 8: budget.v1.UserService.UpdateProfile:output_type -> budget.v1.UpdateProfileResponse
-	6, // 9: budget.v1.UserService.ChangePassword:output_type -> budget.v1.ChangePasswordResponse
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	32, // 0: budget.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	32, // 1: budget.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: budget.v1.GetMeResponse.user:type_name -> budget.v1.User
+	2,  // 3: budget.v1.UpdateProfileResponse.user:type_name -> budget.v1.User
+	32, // 4: budget.v1.Session.created_at:type_name -> google.protobuf.Timestamp
+	32, // 5: budget.v1.Session.last_used_at:type_name -> google.protobuf.Timestamp
+	32, // 6: budget.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
+	9,  // 7: budget.v1.ListSessionsResponse.sessions:type_name -> budget.v1.Session
+	0,  // 8: budget.v1.ApiToken.scope:type_name -> budget.v1.ApiTokenScope
+	32, // 9: budget.v1.ApiToken.created_at:type_name -> google.protobuf.Timestamp
+	32, // 10: budget.v1.ApiToken.last_used_at:type_name -> google.protobuf.Timestamp
+	32, // 11: budget.v1.ApiToken.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: budget.v1.CreateApiTokenRequest.scope:type_name -> budget.v1.ApiTokenScope
+	32, // 13: budget.v1.CreateApiTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	22, // 14: budget.v1.CreateApiTokenResponse.api_token:type_name -> budget.v1.ApiToken
+	22, // 15: budget.v1.ListApiTokensResponse.api_tokens:type_name -> budget.v1.ApiToken
+	1,  // 16: budget.v1.LoginAttempt.status:type_name -> budget.v1.LoginAttemptStatus
+	32, // 17: budget.v1.LoginAttempt.created_at:type_name -> google.protobuf.Timestamp
+	29, // 18: budget.v1.ListLoginAttemptsResponse.attempts:type_name -> budget.v1.LoginAttempt
+	3,  // 19: budget.v1.UserService.GetMe:input_type -> budget.v1.GetMeRequest
+	5,  // 20: budget.v1.UserService.UpdateProfile:input_type -> budget.v1.UpdateProfileRequest
+	7,  // 21: budget.v1.UserService.ChangePassword:input_type -> budget.v1.ChangePasswordRequest
+	10, // 22: budget.v1.UserService.ListSessions:input_type -> budget.v1.ListSessionsRequest
+	12, // 23: budget.v1.UserService.RevokeSession:input_type -> budget.v1.RevokeSessionRequest
+	14, // 24: budget.v1.UserService.RevokeAllOtherSessions:input_type -> budget.v1.RevokeAllOtherSessionsRequest
+	16, // 25: budget.v1.UserService.EnrollTOTP:input_type -> budget.v1.EnrollTOTPRequest
+	18, // 26: budget.v1.UserService.ConfirmTOTP:input_type -> budget.v1.ConfirmTOTPRequest
+	20, // 27: budget.v1.UserService.DisableTOTP:input_type -> budget.v1.DisableTOTPRequest
+	23, // 28: budget.v1.UserService.CreateApiToken:input_type -> budget.v1.CreateApiTokenRequest
+	25, // 29: budget.v1.UserService.ListApiTokens:input_type -> budget.v1.ListApiTokensRequest
+	27, // 30: budget.v1.UserService.RevokeApiToken:input_type -> budget.v1.RevokeApiTokenRequest
+	30, // 31: budget.v1.UserService.ListLoginAttempts:input_type -> budget.v1.ListLoginAttemptsRequest
+	4,  // 32: budget.v1.UserService.GetMe:output_type -> budget.v1.GetMeResponse
+	6,  // 33: budget.v1.UserService.UpdateProfile:output_type -> budget.v1.UpdateProfileResponse
+	8,  // 34: budget.v1.UserService.ChangePassword:output_type -> budget.v1.ChangePasswordResponse
+	11, // 35: budget.v1.UserService.ListSessions:output_type -> budget.v1.ListSessionsResponse
+	13, // 36: budget.v1.UserService.RevokeSession:output_type -> budget.v1.RevokeSessionResponse
+	15, // 37: budget.v1.UserService.RevokeAllOtherSessions:output_type -> budget.v1.RevokeAllOtherSessionsResponse
+	17, // 38: budget.v1.UserService.EnrollTOTP:output_type -> budget.v1.EnrollTOTPResponse
+	19, // 39: budget.v1.UserService.ConfirmTOTP:output_type -> budget.v1.ConfirmTOTPResponse
+	21, // 40: budget.v1.UserService.DisableTOTP:output_type -> budget.v1.DisableTOTPResponse
+	24, // 41: budget.v1.UserService.CreateApiToken:output_type -> budget.v1.CreateApiTokenResponse
+	26, // 42: budget.v1.UserService.ListApiTokens:output_type -> budget.v1.ListApiTokensResponse
+	28, // 43: budget.v1.UserService.RevokeApiToken:output_type -> budget.v1.RevokeApiTokenResponse
+	31, // 44: budget.v1.UserService.ListLoginAttempts:output_type -> budget.v1.ListLoginAttemptsResponse
+	32, // [32:45] is the sub-list for method output_type
+	19, // [19:32] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_budget_v1_user_proto_init() }
@@ -461,13 +1902,14 @@ func file_budget_v1_user_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_budget_v1_user_proto_rawDesc), len(file_budget_v1_user_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_budget_v1_user_proto_goTypes,
 		DependencyIndexes: file_budget_v1_user_proto_depIdxs,
+		EnumInfos:         file_budget_v1_user_proto_enumTypes,
 		MessageInfos:      file_budget_v1_user_proto_msgTypes,
 	}.Build()
 	File_budget_v1_user_proto = out.File
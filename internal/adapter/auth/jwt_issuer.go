@@ -7,15 +7,21 @@ import (
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	uauth "github.com/positron48/budget/internal/usecase/auth"
 )
 
 type JWTIssuer struct {
-	signKey []byte
+	keys *KeySet
 }
 
 func NewJWTIssuer(signKey string) *JWTIssuer {
-	return &JWTIssuer{signKey: []byte(signKey)}
+	return &JWTIssuer{keys: NewHMACKeySet(signKey)}
+}
+
+// NewJWTIssuerWithKeys signs access tokens with the active key of the set.
+func NewJWTIssuerWithKeys(keys *KeySet) *JWTIssuer {
+	return &JWTIssuer{keys: keys}
 }
 
 func (i *JWTIssuer) Issue(ctx context.Context, userID, tenantID string, accessTTL, refreshTTL time.Duration) (uauth.TokenPair, error) {
@@ -29,9 +35,9 @@ func (i *JWTIssuer) Issue(ctx context.Context, userID, tenantID string, accessTT
 		"iat":       now.Unix(),
 		"exp":       accessExp.Unix(),
 		"typ":       "access",
+		"jti":       uuid.NewString(), // lets a single token be put on the denylist
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	access, err := token.SignedString(i.signKey)
+	access, err := i.keys.Sign(claims)
 	if err != nil {
 		return uauth.TokenPair{}, err
 	}
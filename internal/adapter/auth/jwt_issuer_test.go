@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
 )
 
 func TestJWTIssuer_Issue(t *testing.T) {
@@ -22,3 +24,23 @@ func TestJWTIssuer_Issue(t *testing.T) {
 		t.Fatal("expiration not set correctly")
 	}
 }
+
+func TestJWTIssuer_UniqueJTI(t *testing.T) {
+	iss := NewJWTIssuer("k")
+	ids := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		tp, err := iss.Issue(context.Background(), "u1", "t1", time.Minute, time.Hour)
+		if err != nil {
+			t.Fatalf("issue: %v", err)
+		}
+		parsed, err := jwt.Parse(tp.AccessToken, func(*jwt.Token) (interface{}, error) { return []byte("k"), nil })
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		jti, _ := parsed.Claims.(jwt.MapClaims)["jti"].(string)
+		if jti == "" || ids[jti] {
+			t.Fatalf("expected unique jti, got %q", jti)
+		}
+		ids[jti] = true
+	}
+}
@@ -0,0 +1,289 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sort"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+var ErrUnknownKeyID = errors.New("unknown jwt key id")
+
+// KeySetConfig describes signing and verification keys. PEM values take precedence over files.
+type KeySetConfig struct {
+	Algorithm string // HS256 (default), RS256 or EdDSA
+	// HMACSecret signs tokens with HS256, and with asymmetric algorithms still verifies
+	// tokens without kid issued before the switch.
+	HMACSecret     string
+	SigningKeyPEM  string
+	SigningKeyFile string
+	SigningKeyID   string // defaults to a thumbprint of the public key
+	// VerificationKeyFiles: kid -> PEM file (public or private key) of previous keys that
+	// are still accepted, so that rotation does not sign everyone out.
+	VerificationKeyFiles map[string]string
+	// VerificationKeyPEMs: the same keys given inline, kid -> PEM
+	VerificationKeyPEMs map[string]string
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+// KeySet signs access tokens with the active key and verifies tokens signed by any known key.
+type KeySet struct {
+	method     jwt.SigningMethod
+	kid        string
+	signingKey interface{}
+	hmacSecret []byte
+	verify     map[string]verificationKey
+}
+
+// NewHMACKeySet is the legacy single shared secret setup.
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{method: jwt.SigningMethodHS256, signingKey: []byte(secret), hmacSecret: []byte(secret)}
+}
+
+func LoadKeySet(cfg KeySetConfig) (*KeySet, error) {
+	if cfg.Algorithm == "" || cfg.Algorithm == AlgHS256 {
+		if cfg.HMACSecret == "" {
+			return nil, errors.New("HS256 requires a shared secret")
+		}
+		return NewHMACKeySet(cfg.HMACSecret), nil
+	}
+	pemData := []byte(cfg.SigningKeyPEM)
+	if len(pemData) == 0 {
+		if cfg.SigningKeyFile == "" {
+			return nil, fmt.Errorf("%s requires a signing key", cfg.Algorithm)
+		}
+		var err error
+		if pemData, err = os.ReadFile(cfg.SigningKeyFile); err != nil {
+			return nil, fmt.Errorf("read signing key: %w", err)
+		}
+	}
+	priv, err := parsePrivateKey(pemData)
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+	method, pub, err := methodFor(priv)
+	if err != nil {
+		return nil, err
+	}
+	if method.Alg() != cfg.Algorithm {
+		return nil, fmt.Errorf("signing key is not a %s key", cfg.Algorithm)
+	}
+	ks := &KeySet{method: method, signingKey: priv, verify: map[string]verificationKey{}}
+	if cfg.HMACSecret != "" {
+		ks.hmacSecret = []byte(cfg.HMACSecret)
+	}
+	ks.kid = cfg.SigningKeyID
+	if ks.kid == "" {
+		if ks.kid, err = thumbprint(pub); err != nil {
+			return nil, err
+		}
+	}
+	ks.verify[ks.kid] = verificationKey{method: method, key: pub}
+	previous := map[string][]byte{}
+	for kid, file := range cfg.VerificationKeyFiles {
+		if _, inline := cfg.VerificationKeyPEMs[kid]; inline {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read verification key %q: %w", kid, err)
+		}
+		previous[kid] = data
+	}
+	for kid, data := range cfg.VerificationKeyPEMs {
+		previous[kid] = []byte(data)
+	}
+	for kid, data := range previous {
+		if kid == ks.kid {
+			continue
+		}
+		pub, err := parsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %q: %w", kid, err)
+		}
+		m, _, err := methodFor(pub)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %q: %w", kid, err)
+		}
+		ks.verify[kid] = verificationKey{method: m, key: pub}
+	}
+	return ks, nil
+}
+
+// Algorithm returns the alg of newly signed tokens.
+func (k *KeySet) Algorithm() string { return k.method.Alg() }
+
+// Sign signs claims with the active key; asymmetric tokens carry its kid.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.kid != "" {
+		token.Header["kid"] = k.kid
+	}
+	return token.SignedString(k.signingKey)
+}
+
+// Keyfunc selects the verification key by kid. The token alg must match the key type,
+// so a public key can never be used as an HMAC secret.
+func (k *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok && len(k.hmacSecret) > 0 {
+			return k.hmacSecret, nil
+		}
+		return nil, ErrUnknownKeyID
+	}
+	vk, ok := k.verify[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	if t.Method.Alg() != vk.method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	return vk.key, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS returns the public verification keys (RFC 7517). HMAC secrets are never published.
+func (k *KeySet) JWKS() ([]byte, error) {
+	kids := make([]string, 0, len(k.verify))
+	for kid := range k.verify {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	keys := make([]jwk, 0, len(kids))
+	for _, kid := range kids {
+		vk := k.verify[kid]
+		key := jwk{Kid: kid, Use: "sig", Alg: vk.method.Alg()}
+		switch pub := vk.key.(type) {
+		case *rsa.PublicKey:
+			key.Kty = "RSA"
+			key.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			key.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			key.Kty = "OKP"
+			key.Crv = "Ed25519"
+			key.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return json.Marshal(struct {
+		Keys []jwk `json:"keys"`
+	}{Keys: keys})
+}
+
+// JWKSHandler serves JWKS at e.g. /.well-known/jwks.json.
+func (k *KeySet) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := k.JWKS()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_, _ = w.Write(body)
+	})
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key type")
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+// parsePublicKey accepts a public key or a private key (its public part is used).
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		priv, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, err
+		}
+		return priv.Public(), nil
+	}
+}
+
+// methodFor maps a private or public key to its signing method and public key.
+func methodFor(key interface{}) (jwt.SigningMethod, crypto.PublicKey, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, &k.PublicKey, nil
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, k, nil
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, k.Public(), nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, k, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+func thumbprint(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
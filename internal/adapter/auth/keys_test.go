@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+func writePEM(t *testing.T, dir, name, typ string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestKeySet_RotationKeepsOldTokensValid(t *testing.T) {
+	dir := t.TempDir()
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edPriv)
+	oldFile := writePEM(t, dir, "old.pem", "PRIVATE KEY", edDER)
+
+	oldKeys, err := LoadKeySet(KeySetConfig{Algorithm: AlgEdDSA, SigningKeyFile: oldFile, SigningKeyID: "old"})
+	if err != nil {
+		t.Fatalf("load old: %v", err)
+	}
+	oldToken, err := NewJWTIssuerWithKeys(oldKeys).Issue(context.Background(), "u1", "t1", time.Minute, time.Hour)
+	if err != nil {
+		t.Fatalf("issue old: %v", err)
+	}
+	legacy, _ := NewJWTIssuer("secret").Issue(context.Background(), "u1", "t1", time.Minute, time.Hour)
+
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// previous key published as a public key only
+	edPubDER, _ := x509.MarshalPKIXPublicKey(edPriv.Public())
+	oldPubFile := writePEM(t, dir, "old.pub", "PUBLIC KEY", edPubDER)
+	newKeys, err := LoadKeySet(KeySetConfig{
+		Algorithm:            AlgRS256,
+		HMACSecret:           "secret",
+		SigningKeyPEM:        string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaPriv)})),
+		VerificationKeyFiles: map[string]string{"old": oldPubFile},
+	})
+	if err != nil {
+		t.Fatalf("load new: %v", err)
+	}
+	newToken, err := NewJWTIssuerWithKeys(newKeys).Issue(context.Background(), "u1", "t1", time.Minute, time.Hour)
+	if err != nil {
+		t.Fatalf("issue new: %v", err)
+	}
+
+	for name, raw := range map[string]string{"old": oldToken.AccessToken, "new": newToken.AccessToken, "legacy hs256": legacy.AccessToken} {
+		if _, err := jwt.Parse(raw, newKeys.Keyfunc); err != nil {
+			t.Fatalf("%s token must verify after rotation: %v", name, err)
+		}
+	}
+	parsed, _ := jwt.Parse(newToken.AccessToken, newKeys.Keyfunc)
+	if parsed.Header["kid"] == "" || parsed.Method.Alg() != AlgRS256 {
+		t.Fatalf("unexpected header: %v", parsed.Header)
+	}
+	// without the legacy secret, tokens without kid are rejected
+	if _, err := jwt.Parse(legacy.AccessToken, oldKeys.Keyfunc); err == nil {
+		t.Fatal("expected legacy token to be rejected")
+	}
+
+	// the previous key can be given inline instead of a file
+	inlineKeys, err := LoadKeySet(KeySetConfig{
+		Algorithm:           AlgRS256,
+		SigningKeyPEM:       string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaPriv)})),
+		VerificationKeyPEMs: map[string]string{"old": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: edPubDER}))},
+	})
+	if err != nil {
+		t.Fatalf("load inline: %v", err)
+	}
+	if _, err := jwt.Parse(oldToken.AccessToken, inlineKeys.Keyfunc); err != nil {
+		t.Fatalf("old token must verify with an inline key: %v", err)
+	}
+}
+
+func TestKeySet_RejectsAlgorithmConfusion(t *testing.T) {
+	rsaPriv, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keys, err := LoadKeySet(KeySetConfig{
+		Algorithm:     AlgRS256,
+		SigningKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaPriv)})),
+		SigningKeyID:  "k1",
+	})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	pubDER, _ := x509.MarshalPKIXPublicKey(&rsaPriv.PublicKey)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1"})
+	forged.Header["kid"] = "k1"
+	raw, _ := forged.SignedString(pubDER)
+	if _, err := jwt.Parse(raw, keys.Keyfunc); err == nil {
+		t.Fatal("HS256 token signed with the public key must be rejected")
+	}
+	unknown := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "u1"})
+	unknown.Header["kid"] = "nope"
+	raw, _ = unknown.SignedString(rsaPriv)
+	if _, err := jwt.Parse(raw, keys.Keyfunc); err == nil {
+		t.Fatal("unknown kid must be rejected")
+	}
+}
+
+func TestKeySet_JWKSHandler(t *testing.T) {
+	dir := t.TempDir()
+	_, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edPriv)
+	rsaPriv, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keys, err := LoadKeySet(KeySetConfig{
+		Algorithm:            AlgEdDSA,
+		HMACSecret:           "secret",
+		SigningKeyFile:       writePEM(t, dir, "cur.pem", "PRIVATE KEY", edDER),
+		SigningKeyID:         "cur",
+		VerificationKeyFiles: map[string]string{"prev": writePEM(t, dir, "prev.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPriv))},
+	})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	keys.JWKSHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+	if rec.Code != 200 || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected response: %d %v", rec.Code, rec.Header())
+	}
+	var body struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Keys) != 2 {
+		t.Fatalf("expected 2 keys, got %v", body.Keys)
+	}
+	got := map[string]map[string]string{}
+	for _, k := range body.Keys {
+		got[k["kid"]] = k
+	}
+	if got["cur"]["kty"] != "OKP" || got["cur"]["crv"] != "Ed25519" || got["cur"]["x"] == "" {
+		t.Fatalf("unexpected Ed25519 jwk: %v", got["cur"])
+	}
+	if got["prev"]["kty"] != "RSA" || got["prev"]["alg"] != AlgRS256 || got["prev"]["n"] == "" || got["prev"]["e"] != "AQAB" {
+		t.Fatalf("unexpected RSA jwk: %v", got["prev"])
+	}
+}
@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+)
+
+// OIDCConfig describes an OpenID Connect provider (Google, Keycloak, Authentik, Authelia...).
+type OIDCConfig struct {
+	IssuerURL string
+	ClientID  string
+	// JWKSURL overrides jwks_uri from the discovery document
+	JWKSURL string
+	// ExtraIssuers are accepted in the iss claim besides IssuerURL
+	ExtraIssuers []string
+}
+
+// jwksRefreshInterval limits JWKS refetches triggered by unknown kids
+const jwksRefreshInterval = time.Minute
+
+// OIDCVerifier verifies ID tokens locally against the provider's JWKS.
+type OIDCVerifier struct {
+	cfg        OIDCConfig
+	httpClient *http.Client
+	minRefresh time.Duration
+
+	mu        sync.Mutex
+	jwksURL   string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func NewOIDCVerifier(cfg OIDCConfig) *OIDCVerifier {
+	cfg.IssuerURL = strings.TrimRight(cfg.IssuerURL, "/")
+	return &OIDCVerifier{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		minRefresh: jwksRefreshInterval,
+		jwksURL:    cfg.JWKSURL,
+	}
+}
+
+// NewGoogleVerifier verifies Google ID tokens.
+func NewGoogleVerifier(clientID string) *OIDCVerifier {
+	return NewOIDCVerifier(OIDCConfig{
+		IssuerURL:    "https://accounts.google.com",
+		ClientID:     clientID,
+		ExtraIssuers: []string{"accounts.google.com"},
+	})
+}
+
+type oidcClaims struct {
+	jwt.RegisteredClaims
+	Email             string      `json:"email"`
+	EmailVerified     interface{} `json:"email_verified"` // bool, or "true" from some providers
+	Name              string      `json:"name"`
+	PreferredUsername string      `json:"preferred_username"`
+	Locale            string      `json:"locale"`
+}
+
+func (v *OIDCVerifier) VerifyIDToken(ctx context.Context, idToken string) (useauth.IdentityClaims, error) {
+	var claims oidcClaims
+	_, err := jwt.ParseWithClaims(strings.TrimSpace(idToken), &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "EdDSA"}),
+		jwt.WithAudience(v.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return useauth.IdentityClaims{}, fmt.Errorf("invalid id token: %w", err)
+	}
+	if !v.validIssuer(claims.Issuer) {
+		return useauth.IdentityClaims{}, fmt.Errorf("id token issuer mismatch: %q", claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return useauth.IdentityClaims{}, errors.New("subject is missing in id token")
+	}
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name = strings.TrimSpace(claims.PreferredUsername)
+	}
+	verified := false
+	switch ev := claims.EmailVerified.(type) {
+	case bool:
+		verified = ev
+	case string:
+		verified = strings.EqualFold(ev, "true")
+	}
+	return useauth.IdentityClaims{
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(claims.Email)),
+		Name:          name,
+		Locale:        strings.TrimSpace(claims.Locale),
+		EmailVerified: verified,
+	}, nil
+}
+
+func (v *OIDCVerifier) validIssuer(iss string) bool {
+	if strings.TrimRight(iss, "/") == v.cfg.IssuerURL {
+		return true
+	}
+	for _, alt := range v.cfg.ExtraIssuers {
+		if iss == alt {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the verification key for kid, refetching JWKS when the provider rotated keys.
+func (v *OIDCVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if k, ok := v.lookup(kid); ok {
+		return k, nil
+	}
+	if !v.fetchedAt.IsZero() && time.Since(v.fetchedAt) < v.minRefresh {
+		return nil, ErrUnknownKeyID
+	}
+	if err := v.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if k, ok := v.lookup(kid); ok {
+		return k, nil
+	}
+	return nil, ErrUnknownKeyID
+}
+
+// lookup: a token without kid is accepted only if the provider publishes a single key
+func (v *OIDCVerifier) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			return k, true
+		}
+	}
+	k, ok := v.keys[kid]
+	return k, ok
+}
+
+func (v *OIDCVerifier) refresh(ctx context.Context) error {
+	v.fetchedAt = time.Now()
+	if v.jwksURL == "" {
+		var doc struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := v.getJSON(ctx, v.cfg.IssuerURL+"/.well-known/openid-configuration", &doc); err != nil {
+			return fmt.Errorf("oidc discovery: %w", err)
+		}
+		if doc.JWKSURI == "" {
+			return errors.New("oidc discovery: jwks_uri is missing")
+		}
+		v.jwksURL = doc.JWKSURI
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := v.getJSON(ctx, v.jwksURL, &set); err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			continue // unsupported key types are skipped
+		}
+		keys[k.Kid] = pub
+	}
+	v.keys = keys
+	return nil
+}
+
+func (v *OIDCVerifier) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	dec := base64.RawURLEncoding.DecodeString
+	switch k.Kty {
+	case "RSA":
+		n, err := dec(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := dec(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := dec(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := dec(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := dec(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// testIssuer is a local stand-in OIDC provider: discovery document and JWKS.
+type testIssuer struct {
+	srv  *httptest.Server
+	mu   sync.Mutex
+	keys []jwk
+	hits int
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	iss := &testIssuer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": iss.srv.URL, "jwks_uri": iss.srv.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		iss.mu.Lock()
+		defer iss.mu.Unlock()
+		iss.hits++
+		_ = json.NewEncoder(w).Encode(map[string][]jwk{"keys": iss.keys})
+	})
+	iss.srv = httptest.NewServer(mux)
+	t.Cleanup(iss.srv.Close)
+	return iss
+}
+
+func (i *testIssuer) addRSA(kid string, k *rsa.PrivateKey) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys = append(i.keys, jwk{Kty: "RSA", Kid: kid, Use: "sig", Alg: "RS256",
+		N: base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+		E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())})
+}
+
+func signIDToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, claims)
+	tok.Header["kid"] = kid
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestOIDCVerifier_StandInIssuer(t *testing.T) {
+	iss := newTestIssuer(t)
+	k1, _ := rsa.GenerateKey(rand.Reader, 2048)
+	iss.addRSA("k1", k1)
+	v := NewOIDCVerifier(OIDCConfig{IssuerURL: iss.srv.URL + "/", ClientID: "budget"})
+	ctx := context.Background()
+	claims := func(mod func(jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss": iss.srv.URL, "aud": "budget", "sub": "kc-42",
+			"email": "Alice@Example.com", "email_verified": true, "preferred_username": "alice",
+			"iat": time.Now().Unix(), "exp": time.Now().Add(time.Minute).Unix(),
+		}
+		if mod != nil {
+			mod(c)
+		}
+		return c
+	}
+
+	got, err := v.VerifyIDToken(ctx, signIDToken(t, jwt.SigningMethodRS256, k1, "k1", claims(nil)))
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if got.Subject != "kc-42" || got.Email != "alice@example.com" || !got.EmailVerified || got.Name != "alice" {
+		t.Fatalf("unexpected claims: %+v", got)
+	}
+
+	for name, mod := range map[string]func(jwt.MapClaims){
+		"audience": func(c jwt.MapClaims) { c["aud"] = "other" },
+		"issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"expired":  func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"subject":  func(c jwt.MapClaims) { delete(c, "sub") },
+	} {
+		if _, err := v.VerifyIDToken(ctx, signIDToken(t, jwt.SigningMethodRS256, k1, "k1", claims(mod))); err == nil {
+			t.Fatalf("%s: expected rejection", name)
+		}
+	}
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := v.VerifyIDToken(ctx, signIDToken(t, jwt.SigningMethodRS256, other, "k1", claims(nil))); err == nil {
+		t.Fatal("token signed with a foreign key must be rejected")
+	}
+	hmac := signIDToken(t, jwt.SigningMethodHS256, []byte("secret"), "k1", claims(nil))
+	if _, err := v.VerifyIDToken(ctx, hmac); err == nil {
+		t.Fatal("HS256 id tokens must be rejected")
+	}
+
+	// key rotation at the provider: an unknown kid triggers a JWKS refetch
+	v.minRefresh = 0
+	k2, _ := rsa.GenerateKey(rand.Reader, 2048)
+	iss.addRSA("k2", k2)
+	if _, err := v.VerifyIDToken(ctx, signIDToken(t, jwt.SigningMethodRS256, k2, "k2", claims(nil))); err != nil {
+		t.Fatalf("rotated key: %v", err)
+	}
+}
+
+func TestOIDCVerifier_JWKSRefetchIsThrottled(t *testing.T) {
+	iss := newTestIssuer(t)
+	_, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+	iss.mu.Lock()
+	iss.keys = []jwk{{Kty: "OKP", Crv: "Ed25519", Kid: "ed", X: base64.RawURLEncoding.EncodeToString(edPriv.Public().(ed25519.PublicKey))}}
+	iss.mu.Unlock()
+	v := NewOIDCVerifier(OIDCConfig{IssuerURL: iss.srv.URL, ClientID: "budget", JWKSURL: iss.srv.URL + "/keys"})
+	c := jwt.MapClaims{"iss": iss.srv.URL, "aud": "budget", "sub": "s", "exp": time.Now().Add(time.Minute).Unix()}
+	if _, err := v.VerifyIDToken(context.Background(), signIDToken(t, jwt.SigningMethodEdDSA, edPriv, "ed", c)); err != nil {
+		t.Fatalf("verify EdDSA: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		_, _ = v.VerifyIDToken(context.Background(), signIDToken(t, jwt.SigningMethodEdDSA, edPriv, "unknown", c))
+	}
+	if iss.hits != 1 {
+		t.Fatalf("unknown kids must not hammer the provider, got %d fetches", iss.hits)
+	}
+}
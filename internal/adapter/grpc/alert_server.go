@@ -0,0 +1,200 @@
+//go:build !ignore
+// +build !ignore
+
+package grpcadapter
+
+import (
+	"context"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type alertService interface {
+	Create(ctx context.Context, tenantID, userID string, r domain.AlertRule) (domain.AlertRule, error)
+	List(ctx context.Context, tenantID, userID string) ([]domain.AlertRule, error)
+	Update(ctx context.Context, tenantID, userID string, r domain.AlertRule) (domain.AlertRule, error)
+	Delete(ctx context.Context, tenantID, userID, id string) error
+	ListFired(ctx context.Context, tenantID, userID, ruleID string, page, pageSize int) ([]domain.FiredAlert, int64, error)
+}
+
+type AlertServer struct {
+	budgetv1.UnimplementedAlertServiceServer
+	svc alertService
+}
+
+func NewAlertServer(svc alertService) *AlertServer {
+	return &AlertServer{svc: svc}
+}
+
+func (s *AlertServer) CreateAlertRule(ctx context.Context, req *budgetv1.CreateAlertRuleRequest) (*budgetv1.CreateAlertRuleResponse, error) {
+	kind, ok := alertRuleKinds[req.GetKind()]
+	if !ok {
+		return nil, invalidArg("kind is required")
+	}
+	channels, ok := fromProtoAlertChannels(req.GetChannels())
+	if !ok {
+		return nil, invalidArg("unknown channel")
+	}
+	rule, err := s.svc.Create(ctx, ctxTenantID(ctx), ctxUserID(ctx), domain.AlertRule{
+		Name:           req.GetName(),
+		Kind:           kind,
+		CategoryID:     req.GetCategoryId(),
+		ThresholdMinor: req.GetThresholdMinorUnits(),
+		Days:           int(req.GetDays()),
+		Channels:       channels,
+		TelegramChatID: req.GetTelegramChatId(),
+		Timezone:       req.GetTimezone(),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.CreateAlertRuleResponse{Rule: toProtoAlertRule(rule)}, nil
+}
+
+func (s *AlertServer) ListAlertRules(ctx context.Context, _ *budgetv1.ListAlertRulesRequest) (*budgetv1.ListAlertRulesResponse, error) {
+	rules, err := s.svc.List(ctx, ctxTenantID(ctx), ctxUserID(ctx))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.AlertRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, toProtoAlertRule(r))
+	}
+	return &budgetv1.ListAlertRulesResponse{Rules: out}, nil
+}
+
+func (s *AlertServer) UpdateAlertRule(ctx context.Context, req *budgetv1.UpdateAlertRuleRequest) (*budgetv1.UpdateAlertRuleResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	channels, ok := fromProtoAlertChannels(req.GetChannels())
+	if !ok {
+		return nil, invalidArg("unknown channel")
+	}
+	rule, err := s.svc.Update(ctx, ctxTenantID(ctx), ctxUserID(ctx), domain.AlertRule{
+		ID:             req.GetId(),
+		Name:           req.GetName(),
+		CategoryID:     req.GetCategoryId(),
+		ThresholdMinor: req.GetThresholdMinorUnits(),
+		Days:           int(req.GetDays()),
+		Channels:       channels,
+		TelegramChatID: req.GetTelegramChatId(),
+		Timezone:       req.GetTimezone(),
+		Active:         req.GetActive(),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.UpdateAlertRuleResponse{Rule: toProtoAlertRule(rule)}, nil
+}
+
+func (s *AlertServer) DeleteAlertRule(ctx context.Context, req *budgetv1.DeleteAlertRuleRequest) (*budgetv1.DeleteAlertRuleResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	if err := s.svc.Delete(ctx, ctxTenantID(ctx), ctxUserID(ctx), req.GetId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.DeleteAlertRuleResponse{}, nil
+}
+
+func (s *AlertServer) ListFiredAlerts(ctx context.Context, req *budgetv1.ListFiredAlertsRequest) (*budgetv1.ListFiredAlertsResponse, error) {
+	var page, pageSize int
+	if req.GetPage() != nil {
+		page = int(req.GetPage().GetPage())
+		pageSize = int(req.GetPage().GetPageSize())
+	}
+	alerts, total, err := s.svc.ListFired(ctx, ctxTenantID(ctx), ctxUserID(ctx), req.GetRuleId(), page, pageSize)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.FiredAlert, 0, len(alerts))
+	for _, a := range alerts {
+		out = append(out, toProtoFiredAlert(a))
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 50
+	}
+	totalPages := (int32(total) + int32(pageSize) - 1) / int32(pageSize)
+	return &budgetv1.ListFiredAlertsResponse{Alerts: out, Page: &budgetv1.PageResponse{Page: int32(page), PageSize: int32(pageSize), TotalItems: total, TotalPages: totalPages}}, nil
+}
+
+var alertRuleKinds = map[budgetv1.AlertRuleKind]domain.AlertRuleKind{
+	budgetv1.AlertRuleKind_ALERT_RULE_KIND_CATEGORY_MONTHLY_LIMIT:  domain.AlertCategoryMonthlyLimit,
+	budgetv1.AlertRuleKind_ALERT_RULE_KIND_LARGE_EXPENSE:           domain.AlertLargeExpense,
+	budgetv1.AlertRuleKind_ALERT_RULE_KIND_INCOME_BELOW_LAST_MONTH: domain.AlertIncomeBelowLastMonth,
+	budgetv1.AlertRuleKind_ALERT_RULE_KIND_NO_TRANSACTIONS:         domain.AlertNoTransactions,
+}
+
+var alertChannels = map[budgetv1.AlertChannel]domain.AlertChannel{
+	budgetv1.AlertChannel_ALERT_CHANNEL_EMAIL:    domain.AlertChannelEmail,
+	budgetv1.AlertChannel_ALERT_CHANNEL_TELEGRAM: domain.AlertChannelTelegram,
+	budgetv1.AlertChannel_ALERT_CHANNEL_WEBHOOK:  domain.AlertChannelWebhook,
+}
+
+func fromProtoAlertChannels(in []budgetv1.AlertChannel) ([]domain.AlertChannel, bool) {
+	out := make([]domain.AlertChannel, 0, len(in))
+	for _, ch := range in {
+		c, ok := alertChannels[ch]
+		if !ok {
+			return nil, false
+		}
+		out = append(out, c)
+	}
+	return out, true
+}
+
+func toProtoAlertRuleKind(k domain.AlertRuleKind) budgetv1.AlertRuleKind {
+	for pk, dk := range alertRuleKinds {
+		if dk == k {
+			return pk
+		}
+	}
+	return budgetv1.AlertRuleKind_ALERT_RULE_KIND_UNSPECIFIED
+}
+
+func toProtoAlertRule(r domain.AlertRule) *budgetv1.AlertRule {
+	out := &budgetv1.AlertRule{
+		Id:                  r.ID,
+		Name:                r.Name,
+		Kind:                toProtoAlertRuleKind(r.Kind),
+		CategoryId:          r.CategoryID,
+		ThresholdMinorUnits: r.ThresholdMinor,
+		Days:                int32(r.Days),
+		TelegramChatId:      r.TelegramChatID,
+		Timezone:            r.Timezone,
+		Active:              r.Active,
+		CreatedByUserId:     r.CreatedBy,
+		CreatedAt:           timestamppb.New(r.CreatedAt),
+		UpdatedAt:           timestamppb.New(r.UpdatedAt),
+	}
+	for _, ch := range r.Channels {
+		for pc, dc := range alertChannels {
+			if dc == ch {
+				out.Channels = append(out.Channels, pc)
+			}
+		}
+	}
+	return out
+}
+
+func toProtoFiredAlert(a domain.FiredAlert) *budgetv1.FiredAlert {
+	out := &budgetv1.FiredAlert{
+		Id:          a.ID,
+		RuleId:      a.RuleID,
+		Kind:        toProtoAlertRuleKind(a.Kind),
+		PeriodKey:   a.PeriodKey,
+		Message:     a.Message,
+		NotifyError: a.NotifyError,
+		FiredAt:     timestamppb.New(a.FiredAt),
+	}
+	if a.Value.CurrencyCode != "" {
+		out.Value = &budgetv1.Money{CurrencyCode: a.Value.CurrencyCode, MinorUnits: a.Value.MinorUnits}
+	}
+	return out
+}
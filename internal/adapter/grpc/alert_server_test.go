@@ -0,0 +1,115 @@
+package grpcadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	alertuse "github.com/positron48/budget/internal/usecase/alert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type alertSvcStub struct {
+	rule   domain.AlertRule
+	ruleID string
+	page   int
+}
+
+func (s *alertSvcStub) Create(ctx context.Context, tenantID, userID string, r domain.AlertRule) (domain.AlertRule, error) {
+	r.ID, r.TenantID, r.CreatedBy, r.Active = "r1", tenantID, userID, true
+	s.rule = r
+	return r, nil
+}
+
+func (s *alertSvcStub) List(ctx context.Context, tenantID, userID string) ([]domain.AlertRule, error) {
+	return []domain.AlertRule{s.rule}, nil
+}
+
+func (s *alertSvcStub) Update(ctx context.Context, tenantID, userID string, r domain.AlertRule) (domain.AlertRule, error) {
+	if r.ID != s.rule.ID {
+		return domain.AlertRule{}, alertuse.ErrRuleNotFound
+	}
+	return r, nil
+}
+
+func (s *alertSvcStub) Delete(ctx context.Context, tenantID, userID, id string) error {
+	return alertuse.ErrPermissionDenied
+}
+
+func (s *alertSvcStub) ListFired(ctx context.Context, tenantID, userID, ruleID string, page, pageSize int) ([]domain.FiredAlert, int64, error) {
+	s.ruleID, s.page = ruleID, page
+	return []domain.FiredAlert{
+		{ID: "a1", RuleID: "r1", Kind: domain.AlertLargeExpense, PeriodKey: "tx:1", Message: "Big: expense of 600.00 RUB",
+			Value: domain.Money{CurrencyCode: "RUB", MinorUnits: 60000}, NotifyError: "telegram: channel is not configured", FiredAt: time.Now()},
+		{ID: "a2", RuleID: "r1", Kind: domain.AlertNoTransactions, PeriodKey: "since:x", FiredAt: time.Now()},
+	}, 2, nil
+}
+
+func TestAlertServer(t *testing.T) {
+	svc := &alertSvcStub{}
+	srv := NewAlertServer(svc)
+	ctx := ctxutil.WithUserID(ctxutil.WithTenantID(context.Background(), "t1"), "u1")
+
+	created, err := srv.CreateAlertRule(ctx, &budgetv1.CreateAlertRuleRequest{
+		Name:                "Food",
+		Kind:                budgetv1.AlertRuleKind_ALERT_RULE_KIND_CATEGORY_MONTHLY_LIMIT,
+		CategoryId:          "c1",
+		ThresholdMinorUnits: 30000,
+		Channels:            []budgetv1.AlertChannel{budgetv1.AlertChannel_ALERT_CHANNEL_EMAIL, budgetv1.AlertChannel_ALERT_CHANNEL_TELEGRAM},
+		TelegramChatId:      "42",
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	r := created.GetRule()
+	if r.GetId() != "r1" || r.GetKind() != budgetv1.AlertRuleKind_ALERT_RULE_KIND_CATEGORY_MONTHLY_LIMIT || r.GetCreatedByUserId() != "u1" ||
+		len(r.GetChannels()) != 2 || r.GetChannels()[1] != budgetv1.AlertChannel_ALERT_CHANNEL_TELEGRAM || r.GetTelegramChatId() != "42" {
+		t.Fatalf("unexpected rule %+v", r)
+	}
+	if svc.rule.Kind != domain.AlertCategoryMonthlyLimit || svc.rule.ThresholdMinor != 30000 {
+		t.Fatalf("unexpected domain rule %+v", svc.rule)
+	}
+	if _, err := srv.CreateAlertRule(ctx, &budgetv1.CreateAlertRuleRequest{Name: "x"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("missing kind: %v", err)
+	}
+	if _, err := srv.CreateAlertRule(ctx, &budgetv1.CreateAlertRuleRequest{Name: "x", Kind: budgetv1.AlertRuleKind_ALERT_RULE_KIND_LARGE_EXPENSE,
+		Channels: []budgetv1.AlertChannel{budgetv1.AlertChannel_ALERT_CHANNEL_UNSPECIFIED}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unspecified channel: %v", err)
+	}
+
+	list, err := srv.ListAlertRules(ctx, &budgetv1.ListAlertRulesRequest{})
+	if err != nil || len(list.GetRules()) != 1 {
+		t.Fatalf("list: %v %+v", err, list)
+	}
+	if _, err := srv.UpdateAlertRule(ctx, &budgetv1.UpdateAlertRuleRequest{Id: "nope", Name: "x"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown rule: %v", err)
+	}
+	if _, err := srv.UpdateAlertRule(ctx, &budgetv1.UpdateAlertRuleRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("missing id: %v", err)
+	}
+	if _, err := srv.DeleteAlertRule(ctx, &budgetv1.DeleteAlertRuleRequest{Id: "r1"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("delete by a member: %v", err)
+	}
+
+	fired, err := srv.ListFiredAlerts(ctx, &budgetv1.ListFiredAlertsRequest{RuleId: "r1", Page: &budgetv1.PageRequest{Page: 2, PageSize: 1}})
+	if err != nil {
+		t.Fatalf("list fired: %v", err)
+	}
+	if svc.ruleID != "r1" || svc.page != 2 {
+		t.Fatalf("unexpected filter %q %d", svc.ruleID, svc.page)
+	}
+	a := fired.GetAlerts()[0]
+	if a.GetKind() != budgetv1.AlertRuleKind_ALERT_RULE_KIND_LARGE_EXPENSE || a.GetValue().GetMinorUnits() != 60000 || a.GetNotifyError() == "" {
+		t.Fatalf("unexpected alert %+v", a)
+	}
+	if fired.GetAlerts()[1].GetValue() != nil {
+		t.Fatalf("alert without a value got one")
+	}
+	if fired.GetPage().GetTotalPages() != 2 || fired.GetPage().GetPage() != 2 {
+		t.Fatalf("unexpected page %+v", fired.GetPage())
+	}
+}
@@ -0,0 +1,68 @@
+package grpcadapter
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	useuser "github.com/positron48/budget/internal/usecase/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// APITokenAuthenticator resolves personal access tokens.
+type APITokenAuthenticator interface {
+	AuthenticateAPIToken(ctx context.Context, token string) (domain.APIToken, error)
+}
+
+// APITokenAuthenticatorFunc adapts a function to APITokenAuthenticator.
+type APITokenAuthenticatorFunc func(ctx context.Context, token string) (domain.APIToken, error)
+
+func (f APITokenAuthenticatorFunc) AuthenticateAPIToken(ctx context.Context, token string) (domain.APIToken, error) {
+	return f(ctx, token)
+}
+
+// authenticateAPIToken puts the token owner and its tenant into the context. The tenant guard
+// still checks membership, so a token stops working when its owner leaves the tenant.
+func authenticateAPIToken(ctx context.Context, apiTokens APITokenAuthenticator, raw, fullMethod string) (context.Context, error) {
+	t, err := apiTokens.AuthenticateAPIToken(ctx, raw)
+	if err != nil {
+		if errors.Is(err, useuser.ErrInvalidAPIToken) {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid access token")
+		}
+		return nil, status.Error(codes.Unavailable, "api token check failed")
+	}
+	if !apiTokenAllows(t.Scope, fullMethod) {
+		return nil, status.Error(codes.PermissionDenied, "method is not available for this api token")
+	}
+	if tid, ok := ctxutil.TenantIDFromContext(ctx); ok && tid != t.TenantID {
+		return nil, status.Error(codes.PermissionDenied, "api token is scoped to another tenant")
+	}
+	ctx = ctxutil.WithUserID(ctx, t.UserID)
+	return ctxutil.WithTenantID(ctx, t.TenantID), nil
+}
+
+// apiTokenAllows limits API tokens to tenant data; account management (sessions, 2FA,
+// tokens themselves, membership) needs an interactive login. Read tokens call Get*/List* only.
+func apiTokenAllows(scope domain.APITokenScope, fullMethod string) bool {
+	if fullMethod == "/budget.v1.UserService/GetMe" {
+		return true
+	}
+	i := strings.LastIndex(fullMethod, "/")
+	if i < 0 {
+		return false
+	}
+	switch fullMethod[:i+1] {
+	case "/budget.v1.TransactionService/", "/budget.v1.CategoryService/", "/budget.v1.ReportService/", "/budget.v1.FxService/",
+		"/budget.v1.CurrencyService/", "/budget.v1.ViewService/", "/budget.v1.EventService/":
+	default:
+		return false
+	}
+	if scope == domain.APITokenScopeReadWrite {
+		return true
+	}
+	method := fullMethod[i+1:]
+	return hasPrefix(method, "Get") || hasPrefix(method, "List") || hasPrefix(method, "BatchGet") || hasPrefix(method, "Watch")
+}
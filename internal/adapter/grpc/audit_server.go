@@ -0,0 +1,94 @@
+//go:build !ignore
+// +build !ignore
+
+package grpcadapter
+
+import (
+	"context"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *TenantServer) ListAuditEvents(ctx context.Context, req *budgetv1.ListAuditEventsRequest) (*budgetv1.ListAuditEventsResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, invalidArg("tenant_id is required")
+	}
+	f := audit.Filter{
+		EntityType: req.GetEntityType(),
+		EntityID:   req.GetEntityId(),
+		ActorID:    req.GetActorUserId(),
+		Action:     fromProtoAuditAction(req.GetAction()),
+	}
+	if req.GetFrom() != nil {
+		t := req.GetFrom().AsTime()
+		f.From = &t
+	}
+	if req.GetTo() != nil {
+		t := req.GetTo().AsTime()
+		f.To = &t
+	}
+	if req.GetPage() != nil {
+		f.Page = int(req.GetPage().GetPage())
+		f.PageSize = int(req.GetPage().GetPageSize())
+	}
+	events, total, err := s.svc.ListAuditEvents(ctx, ctxUserID(ctx), req.GetTenantId(), f)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.AuditEvent, 0, len(events))
+	for _, ev := range events {
+		out = append(out, &budgetv1.AuditEvent{
+			Id:          ev.ID,
+			ActorUserId: ev.ActorID,
+			EntityType:  ev.EntityType,
+			EntityId:    ev.EntityID,
+			Action:      toProtoAuditAction(ev.Action),
+			BeforeJson:  string(ev.Before),
+			AfterJson:   string(ev.After),
+			CreatedAt:   timestamppb.New(ev.CreatedAt),
+		})
+	}
+	page := int32(f.Page)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int32(f.PageSize)
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 50
+	}
+	totalPages := (int32(total) + pageSize - 1) / pageSize
+	return &budgetv1.ListAuditEventsResponse{Events: out, Page: &budgetv1.PageResponse{Page: page, PageSize: pageSize, TotalItems: total, TotalPages: totalPages}}, nil
+}
+
+func fromProtoAuditAction(a budgetv1.AuditAction) domain.AuditAction {
+	switch a {
+	case budgetv1.AuditAction_AUDIT_ACTION_CREATED:
+		return domain.AuditActionCreated
+	case budgetv1.AuditAction_AUDIT_ACTION_UPDATED:
+		return domain.AuditActionUpdated
+	case budgetv1.AuditAction_AUDIT_ACTION_DELETED:
+		return domain.AuditActionDeleted
+	case budgetv1.AuditAction_AUDIT_ACTION_RESTORED:
+		return domain.AuditActionRestored
+	default:
+		return ""
+	}
+}
+
+func toProtoAuditAction(a domain.AuditAction) budgetv1.AuditAction {
+	switch a {
+	case domain.AuditActionCreated:
+		return budgetv1.AuditAction_AUDIT_ACTION_CREATED
+	case domain.AuditActionUpdated:
+		return budgetv1.AuditAction_AUDIT_ACTION_UPDATED
+	case domain.AuditActionDeleted:
+		return budgetv1.AuditAction_AUDIT_ACTION_DELETED
+	case domain.AuditActionRestored:
+		return budgetv1.AuditAction_AUDIT_ACTION_RESTORED
+	default:
+		return budgetv1.AuditAction_AUDIT_ACTION_UNSPECIFIED
+	}
+}
@@ -5,6 +5,8 @@ package grpcadapter
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
 	useauth "github.com/positron48/budget/internal/usecase/auth"
@@ -53,6 +55,14 @@ func (s *AuthServer) Login(ctx context.Context, req *budgetv1.LoginRequest) (*bu
 		return nil, status.Error(codes.FailedPrecondition, "password login is disabled; use GoogleAuth")
 	}
 	_, memberships, tp, err := s.svc.Login(ctx, req.GetEmail(), req.GetPassword())
+	var challenge *useauth.TwoFactorRequiredError
+	if errors.As(err, &challenge) {
+		return &budgetv1.LoginResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challenge.ChallengeToken,
+			ChallengeExpiresAt: timestamppb.New(challenge.ExpiresAt),
+		}, nil
+	}
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -72,8 +82,11 @@ func (s *AuthServer) Login(ctx context.Context, req *budgetv1.LoginRequest) (*bu
 	}, nil
 }
 
-func (s *AuthServer) GoogleAuth(ctx context.Context, req *budgetv1.GoogleAuthRequest) (*budgetv1.GoogleAuthResponse, error) {
-	u, memberships, createdTenant, tp, err := s.svc.GoogleAuth(ctx, req.GetIdToken(), req.GetLocale(), req.GetTenantName())
+func (s *AuthServer) CompleteTwoFactorLogin(ctx context.Context, req *budgetv1.CompleteTwoFactorLoginRequest) (*budgetv1.CompleteTwoFactorLoginResponse, error) {
+	if req.GetChallengeToken() == "" || req.GetCode() == "" {
+		return nil, invalidArg("challenge_token and code are required")
+	}
+	memberships, tp, err := s.svc.CompleteTwoFactorLogin(ctx, req.GetChallengeToken(), req.GetCode())
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -81,7 +94,7 @@ func (s *AuthServer) GoogleAuth(ctx context.Context, req *budgetv1.GoogleAuthReq
 	for _, m := range memberships {
 		ms = append(ms, &budgetv1.TenantMembership{Tenant: &budgetv1.Tenant{Id: m.TenantID}, Role: mapRole(m.Role), IsDefault: m.IsDefault})
 	}
-	resp := &budgetv1.GoogleAuthResponse{
+	return &budgetv1.CompleteTwoFactorLoginResponse{
 		Tokens: &budgetv1.TokenPair{
 			AccessToken:           tp.AccessToken,
 			RefreshToken:          tp.RefreshToken,
@@ -89,8 +102,54 @@ func (s *AuthServer) GoogleAuth(ctx context.Context, req *budgetv1.GoogleAuthReq
 			RefreshTokenExpiresAt: timestamppb.New(tp.RefreshTokenExpiresAt),
 			TokenType:             tp.TokenType,
 		},
-		User:        &budgetv1.User{Id: u.ID, Email: u.Email, Name: u.Name, Locale: u.Locale, EmailVerified: true},
 		Memberships: ms,
+	}, nil
+}
+
+func (s *AuthServer) GoogleAuth(ctx context.Context, req *budgetv1.GoogleAuthRequest) (*budgetv1.GoogleAuthResponse, error) {
+	u, memberships, createdTenant, tp, err := s.svc.GoogleAuth(ctx, req.GetIdToken(), req.GetLocale(), req.GetTenantName())
+	var challenge *useauth.TwoFactorRequiredError
+	if errors.As(err, &challenge) {
+		return &budgetv1.GoogleAuthResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challenge.ChallengeToken,
+			ChallengeExpiresAt: timestamppb.New(challenge.ExpiresAt),
+		}, nil
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	resp := &budgetv1.GoogleAuthResponse{
+		Tokens:      toProtoTokenPair(tp),
+		User:        &budgetv1.User{Id: u.ID, Email: u.Email, Name: u.Name, Locale: u.Locale, EmailVerified: true},
+		Memberships: toProtoAuthMemberships(memberships),
+	}
+	if createdTenant != nil {
+		resp.Tenant = &budgetv1.Tenant{Id: createdTenant.ID, Name: createdTenant.Name, DefaultCurrencyCode: createdTenant.DefaultCurrencyCode}
+	}
+	return resp, nil
+}
+
+func (s *AuthServer) ExternalAuth(ctx context.Context, req *budgetv1.ExternalAuthRequest) (*budgetv1.ExternalAuthResponse, error) {
+	if req.GetProvider() == "" || req.GetIdToken() == "" {
+		return nil, invalidArg("provider and id_token are required")
+	}
+	u, memberships, createdTenant, tp, err := s.svc.ExternalAuth(ctx, req.GetProvider(), req.GetIdToken(), req.GetLocale(), req.GetTenantName())
+	var challenge *useauth.TwoFactorRequiredError
+	if errors.As(err, &challenge) {
+		return &budgetv1.ExternalAuthResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challenge.ChallengeToken,
+			ChallengeExpiresAt: timestamppb.New(challenge.ExpiresAt),
+		}, nil
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	resp := &budgetv1.ExternalAuthResponse{
+		Tokens:      toProtoTokenPair(tp),
+		User:        &budgetv1.User{Id: u.ID, Email: u.Email, Name: u.Name, Locale: u.Locale, EmailVerified: u.EmailVerified},
+		Memberships: toProtoAuthMemberships(memberships),
 	}
 	if createdTenant != nil {
 		resp.Tenant = &budgetv1.Tenant{Id: createdTenant.ID, Name: createdTenant.Name, DefaultCurrencyCode: createdTenant.DefaultCurrencyCode}
@@ -98,6 +157,81 @@ func (s *AuthServer) GoogleAuth(ctx context.Context, req *budgetv1.GoogleAuthReq
 	return resp, nil
 }
 
+func (s *AuthServer) ListIdentityProviders(ctx context.Context, _ *budgetv1.ListIdentityProvidersRequest) (*budgetv1.ListIdentityProvidersResponse, error) {
+	return &budgetv1.ListIdentityProvidersResponse{Providers: s.svc.IdentityProviders()}, nil
+}
+
+func (s *AuthServer) LinkIdentity(ctx context.Context, req *budgetv1.LinkIdentityRequest) (*budgetv1.LinkIdentityResponse, error) {
+	if req.GetProvider() == "" || req.GetIdToken() == "" {
+		return nil, invalidArg("provider and id_token are required")
+	}
+	userID := ctxUserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	id, err := s.svc.LinkIdentity(ctx, userID, req.GetProvider(), req.GetIdToken())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.LinkIdentityResponse{Identity: toProtoIdentity(id)}, nil
+}
+
+func (s *AuthServer) UnlinkIdentity(ctx context.Context, req *budgetv1.UnlinkIdentityRequest) (*budgetv1.UnlinkIdentityResponse, error) {
+	if req.GetProvider() == "" {
+		return nil, invalidArg("provider is required")
+	}
+	userID := ctxUserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	if err := s.svc.UnlinkIdentity(ctx, userID, req.GetProvider()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.UnlinkIdentityResponse{}, nil
+}
+
+func (s *AuthServer) ListIdentities(ctx context.Context, _ *budgetv1.ListIdentitiesRequest) (*budgetv1.ListIdentitiesResponse, error) {
+	userID := ctxUserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	list, err := s.svc.ListIdentities(ctx, userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.Identity, 0, len(list))
+	for _, id := range list {
+		out = append(out, toProtoIdentity(id))
+	}
+	return &budgetv1.ListIdentitiesResponse{Identities: out}, nil
+}
+
+func toProtoIdentity(id useauth.Identity) *budgetv1.Identity {
+	out := &budgetv1.Identity{Provider: id.Provider, Subject: id.Subject, Email: id.Email, CreatedAt: timestamppb.New(id.CreatedAt)}
+	if id.LastLoginAt != nil {
+		out.LastLoginAt = timestamppb.New(*id.LastLoginAt)
+	}
+	return out
+}
+
+func toProtoTokenPair(tp useauth.TokenPair) *budgetv1.TokenPair {
+	return &budgetv1.TokenPair{
+		AccessToken:           tp.AccessToken,
+		RefreshToken:          tp.RefreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(tp.AccessTokenExpiresAt),
+		RefreshTokenExpiresAt: timestamppb.New(tp.RefreshTokenExpiresAt),
+		TokenType:             tp.TokenType,
+	}
+}
+
+func toProtoAuthMemberships(memberships []useauth.TenantMembership) []*budgetv1.TenantMembership {
+	ms := make([]*budgetv1.TenantMembership, 0, len(memberships))
+	for _, m := range memberships {
+		ms = append(ms, &budgetv1.TenantMembership{Tenant: &budgetv1.Tenant{Id: m.TenantID}, Role: mapRole(m.Role), IsDefault: m.IsDefault})
+	}
+	return ms
+}
+
 func (s *AuthServer) RefreshToken(ctx context.Context, req *budgetv1.RefreshTokenRequest) (*budgetv1.RefreshTokenResponse, error) {
 	tp, err := s.svc.Refresh(ctx, req.GetRefreshToken())
 	if err != nil {
@@ -112,6 +246,76 @@ func (s *AuthServer) RefreshToken(ctx context.Context, req *budgetv1.RefreshToke
 	}}, nil
 }
 
+func (s *AuthServer) SwitchTenant(ctx context.Context, req *budgetv1.SwitchTenantRequest) (*budgetv1.SwitchTenantResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, invalidArg("tenant_id is required")
+	}
+	userID := ctxUserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	tp, err := s.svc.SwitchTenant(ctx, userID, req.GetTenantId(), req.GetRefreshToken())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.SwitchTenantResponse{Tokens: &budgetv1.TokenPair{
+		AccessToken:           tp.AccessToken,
+		RefreshToken:          tp.RefreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(tp.AccessTokenExpiresAt),
+		RefreshTokenExpiresAt: timestamppb.New(tp.RefreshTokenExpiresAt),
+		TokenType:             tp.TokenType,
+	}}, nil
+}
+
+func (s *AuthServer) RequestPasswordReset(ctx context.Context, req *budgetv1.RequestPasswordResetRequest) (*budgetv1.RequestPasswordResetResponse, error) {
+	if !strings.Contains(req.GetEmail(), "@") {
+		return nil, invalidArg("valid email is required")
+	}
+	ip, _ := requestClientInfo(ctx)
+	if err := s.svc.RequestPasswordReset(ctx, req.GetEmail(), ip); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.RequestPasswordResetResponse{}, nil
+}
+
+func (s *AuthServer) ResetPassword(ctx context.Context, req *budgetv1.ResetPasswordRequest) (*budgetv1.ResetPasswordResponse, error) {
+	if req.GetResetToken() == "" {
+		return nil, invalidArg("reset_token is required")
+	}
+	if req.GetNewPassword() == "" {
+		return nil, invalidArg("new_password is required")
+	}
+	ip, _ := requestClientInfo(ctx)
+	if err := s.svc.ResetPassword(ctx, req.GetResetToken(), req.GetNewPassword(), ip); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.ResetPasswordResponse{}, nil
+}
+
+func (s *AuthServer) VerifyEmail(ctx context.Context, req *budgetv1.VerifyEmailRequest) (*budgetv1.VerifyEmailResponse, error) {
+	if req.GetToken() == "" {
+		return nil, invalidArg("token is required")
+	}
+	u, err := s.svc.VerifyEmail(ctx, req.GetToken())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.VerifyEmailResponse{
+		User: &budgetv1.User{Id: u.ID, Email: u.Email, Name: u.Name, Locale: u.Locale, EmailVerified: u.EmailVerified},
+	}, nil
+}
+
+func (s *AuthServer) ResendVerification(ctx context.Context, _ *budgetv1.ResendVerificationRequest) (*budgetv1.ResendVerificationResponse, error) {
+	userID := ctxUserID(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	if err := s.svc.ResendVerification(ctx, userID); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.ResendVerificationResponse{}, nil
+}
+
 func mapRole(role string) budgetv1.TenantRole {
 	switch role {
 	case "owner":
@@ -47,7 +47,7 @@ func (s *CategoryServer) UpdateCategory(ctx context.Context, req *budgetv1.Updat
 }
 
 func (s *CategoryServer) DeleteCategory(ctx context.Context, req *budgetv1.DeleteCategoryRequest) (*budgetv1.DeleteCategoryResponse, error) {
-	if err := s.svc.Delete(ctx, req.GetId()); err != nil {
+	if err := s.svc.Delete(ctx, req.GetId(), req.GetReassignToCategoryId()); err != nil {
 		return nil, mapError(err)
 	}
 	return &budgetv1.DeleteCategoryResponse{}, nil
@@ -95,6 +95,43 @@ func (s *CategoryServer) ListCategories(ctx context.Context, req *budgetv1.ListC
 	return &budgetv1.ListCategoriesResponse{Categories: out}, nil
 }
 
+func (s *CategoryServer) ListDeletedCategories(ctx context.Context, req *budgetv1.ListDeletedCategoriesRequest) (*budgetv1.ListDeletedCategoriesResponse, error) {
+	cs, err := s.svc.ListDeleted(ctx, ctxTenantID(ctx))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.Category, 0, len(cs))
+	for _, c := range cs {
+		preferLocale(c.Translations, req.GetLocale())
+		out = append(out, toProtoCategory(c))
+	}
+	return &budgetv1.ListDeletedCategoriesResponse{Categories: out}, nil
+}
+
+func (s *CategoryServer) RestoreCategory(ctx context.Context, req *budgetv1.RestoreCategoryRequest) (*budgetv1.RestoreCategoryResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	c, err := s.svc.Restore(ctx, ctxTenantID(ctx), req.GetId())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.RestoreCategoryResponse{Category: toProtoCategory(c)}, nil
+}
+
+// preferLocale moves the translation in the given locale to the front
+func preferLocale(trs []domain.CategoryTranslation, locale string) {
+	if locale == "" {
+		return
+	}
+	for i := range trs {
+		if trs[i].Locale == locale {
+			trs[0], trs[i] = trs[i], trs[0]
+			return
+		}
+	}
+}
+
 func toProtoCategory(c domain.Category) *budgetv1.Category {
 	trs := make([]*budgetv1.CategoryTranslation, 0, len(c.Translations))
 	for _, tr := range c.Translations {
@@ -104,7 +141,7 @@ func toProtoCategory(c domain.Category) *budgetv1.Category {
 	if c.ParentID != nil {
 		parent = *c.ParentID
 	}
-	return &budgetv1.Category{Id: c.ID, TenantId: c.TenantID, Kind: toProtoKind(c.Kind), Code: c.Code, ParentId: parent, IsActive: c.IsActive, Translations: trs}
+	return &budgetv1.Category{Id: c.ID, TenantId: c.TenantID, Kind: toProtoKind(c.Kind), Code: c.Code, ParentId: parent, IsActive: c.IsActive, Translations: trs, DeletedAt: optTimestamp(c.DeletedAt)}
 }
 
 func toKind(k budgetv1.CategoryKind) domain.CategoryKind {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
 	"github.com/positron48/budget/internal/domain"
@@ -40,6 +41,20 @@ func (s *catStubRepo) List(ctx context.Context, tenantID string, kind domain.Cat
 	return s.list, nil
 }
 
+func (s *catStubRepo) CountTransactions(ctx context.Context, id string) (int64, error) { return 0, nil }
+func (s *catStubRepo) ReassignTransactions(ctx context.Context, fromID, toID string) ([]domain.Transaction, error) {
+	return nil, nil
+}
+func (s *catStubRepo) ListDeleted(ctx context.Context, tenantID string) ([]domain.Category, error) {
+	return nil, nil
+}
+func (s *catStubRepo) Restore(ctx context.Context, tenantID, id string) (domain.Category, error) {
+	return domain.Category{ID: id, TenantID: tenantID}, nil
+}
+func (s *catStubRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
 func TestCategoryServer_CRUD_And_Locale(t *testing.T) {
 	repo := &catStubRepo{}
 	svc := usecat.NewService(repo)
@@ -99,6 +114,22 @@ func (errCatRepo) List(ctx context.Context, tenantID string, kind domain.Categor
 	return nil, errors.New("boom")
 }
 
+func (errCatRepo) CountTransactions(ctx context.Context, id string) (int64, error) {
+	return 0, errors.New("boom")
+}
+func (errCatRepo) ReassignTransactions(ctx context.Context, fromID, toID string) ([]domain.Transaction, error) {
+	return nil, errors.New("boom")
+}
+func (errCatRepo) ListDeleted(ctx context.Context, tenantID string) ([]domain.Category, error) {
+	return nil, errors.New("boom")
+}
+func (errCatRepo) Restore(ctx context.Context, tenantID, id string) (domain.Category, error) {
+	return domain.Category{ID: id, TenantID: tenantID}, errors.New("boom")
+}
+func (errCatRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, errors.New("boom")
+}
+
 func TestCategoryServer_MapError(t *testing.T) {
 	svc := usecat.NewService(errCatRepo{})
 	srv := NewCategoryServer(svc)
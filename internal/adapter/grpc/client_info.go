@@ -0,0 +1,96 @@
+package grpcadapter
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// NewClientInfoUnaryInterceptor puts the caller IP and User-Agent into the context. It goes before
+// the auth interceptor; see clientInfo for how trusted proxies are handled.
+func NewClientInfoUnaryInterceptor(trustedProxies []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ip, ua := clientInfo(ctx, trustedProxies)
+		return handler(ctxutil.WithClientInfo(ctx, ip, ua), req)
+	}
+}
+
+func NewClientInfoStreamInterceptor(trustedProxies []netip.Prefix) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ip, ua := clientInfo(ss.Context(), trustedProxies)
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctxutil.WithClientInfo(ss.Context(), ip, ua)})
+	}
+}
+
+// requestClientInfo returns the client info put into the context by the interceptors, or the
+// transport peer address when they did not run.
+func requestClientInfo(ctx context.Context) (ip, userAgent string) {
+	if ip, userAgent = ctxutil.ClientInfoFromContext(ctx); ip != "" {
+		return ip, userAgent
+	}
+	return clientInfo(ctx, nil)
+}
+
+// clientInfo returns the caller IP and User-Agent. The IP is the transport peer address, unless the
+// peer is a trusted proxy: then x-forwarded-for is read from the right, skipping trusted hops, and
+// the first untrusted address is the client (x-real-ip if the proxy sends no x-forwarded-for).
+// Hops left of it are written by the client and are never used.
+func clientInfo(ctx context.Context, trustedProxies []netip.Prefix) (ip, userAgent string) {
+	var peerAddr netip.Addr
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			ip = host
+			peerAddr, _ = netip.ParseAddr(host)
+		}
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ua := md.Get("user-agent"); len(ua) > 0 {
+		userAgent = ua[0]
+	}
+	if !trusted(peerAddr, trustedProxies) {
+		return ip, userAgent
+	}
+	var hops []string
+	for _, v := range md.Get("x-forwarded-for") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	if len(hops) == 0 {
+		if xri := md.Get("x-real-ip"); len(xri) > 0 {
+			if addr, err := netip.ParseAddr(strings.TrimSpace(xri[0])); err == nil {
+				return addr.String(), userAgent
+			}
+		}
+		return ip, userAgent
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// a garbled hop: nothing left of it can be trusted
+			return ip, userAgent
+		}
+		ip = addr.String()
+		if !trusted(addr, trustedProxies) {
+			break
+		}
+	}
+	return ip, userAgent
+}
+
+func trusted(addr netip.Addr, proxies []netip.Prefix) bool {
+	if !addr.IsValid() {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range proxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
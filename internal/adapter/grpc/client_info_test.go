@@ -0,0 +1,57 @@
+package grpcadapter
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func peerContext(addr string, kv ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 40000}})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(kv...))
+}
+
+func TestClientInfo_TrustedProxies(t *testing.T) {
+	proxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"direct client spoofing the header", peerContext("203.0.113.7", "x-forwarded-for", "1.2.3.4", "x-real-ip", "1.2.3.4"), "203.0.113.7"},
+		{"trusted proxy", peerContext("10.0.0.2", "x-forwarded-for", "198.51.100.9"), "198.51.100.9"},
+		{"client prepends a fake hop", peerContext("10.0.0.2", "x-forwarded-for", "1.2.3.4, 198.51.100.9"), "198.51.100.9"},
+		{"chain of trusted proxies", peerContext("10.0.0.2", "x-forwarded-for", "198.51.100.9, 10.0.0.5"), "198.51.100.9"},
+		{"trusted proxy with x-real-ip", peerContext("10.0.0.2", "x-real-ip", "198.51.100.9"), "198.51.100.9"},
+		{"trusted proxy without headers", peerContext("10.0.0.2"), "10.0.0.2"},
+	}
+	for _, c := range cases {
+		if got, _ := clientInfo(c.ctx, proxies); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+	// without trusted proxies the headers are ignored
+	if got, _ := clientInfo(peerContext("10.0.0.2", "x-forwarded-for", "198.51.100.9"), nil); got != "10.0.0.2" {
+		t.Errorf("got %q, want the peer address", got)
+	}
+}
+
+func TestClientInfoInterceptor_StoresInfo(t *testing.T) {
+	it := NewClientInfoUnaryInterceptor(nil)
+	ctx := peerContext("203.0.113.7", "user-agent", "curl/8", "x-forwarded-for", "1.2.3.4")
+	_, err := it(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/x"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		if ip, ua := ctxutil.ClientInfoFromContext(ctx); ip != "203.0.113.7" || ua != "curl/8" {
+			t.Fatalf("unexpected client info %q %q", ip, ua)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
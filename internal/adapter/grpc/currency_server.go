@@ -0,0 +1,53 @@
+//go:build !ignore
+// +build !ignore
+
+package grpcadapter
+
+import (
+	"context"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+)
+
+// TenantGetter loads the active tenant to mark its pinned currencies.
+type TenantGetter interface {
+	GetByID(ctx context.Context, id string) (domain.Tenant, error)
+}
+
+// CurrencyServer serves the built-in ISO 4217 catalogue.
+type CurrencyServer struct {
+	budgetv1.UnimplementedCurrencyServiceServer
+	tenants TenantGetter
+}
+
+func NewCurrencyServer(tenants TenantGetter) *CurrencyServer {
+	return &CurrencyServer{tenants: tenants}
+}
+
+func (s *CurrencyServer) ListCurrencies(ctx context.Context, req *budgetv1.ListCurrenciesRequest) (*budgetv1.ListCurrenciesResponse, error) {
+	t, err := s.tenants.GetByID(ctx, ctxTenantID(ctx))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	pinned := make(map[string]bool, len(t.CurrencyCodes))
+	for _, c := range t.CurrencyCodes {
+		pinned[c] = true
+	}
+	all := domain.Currencies()
+	out := make([]*budgetv1.Currency, 0, len(all))
+	for _, c := range all {
+		if req.GetPinnedOnly() && !t.AllowsCurrency(c.Code) {
+			continue
+		}
+		out = append(out, &budgetv1.Currency{
+			Code:        c.Code,
+			NumericCode: c.Numeric,
+			Exponent:    int32(c.Exponent),
+			Name:        c.Name(req.GetLocale()),
+			Symbol:      c.Symbol,
+			Pinned:      pinned[c.Code],
+		})
+	}
+	return &budgetv1.ListCurrenciesResponse{Currencies: out}, nil
+}
@@ -0,0 +1,42 @@
+package grpcadapter
+
+import (
+	"context"
+	"testing"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+type currencyTenantStub struct{ t domain.Tenant }
+
+func (s currencyTenantStub) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
+	return s.t, nil
+}
+
+func TestCurrencyServer_ListCurrencies(t *testing.T) {
+	srv := NewCurrencyServer(currencyTenantStub{t: domain.Tenant{ID: "t1", DefaultCurrencyCode: "RUB", CurrencyCodes: []string{"USD"}}})
+	ctx := ctxutil.WithTenantID(context.Background(), "t1")
+
+	all, err := srv.ListCurrencies(ctx, &budgetv1.ListCurrenciesRequest{Locale: "ru"})
+	if err != nil || len(all.GetCurrencies()) != len(domain.Currencies()) {
+		t.Fatalf("list: %v %d", err, len(all.GetCurrencies()))
+	}
+	for _, c := range all.GetCurrencies() {
+		if c.GetCode() == "KWD" && (c.GetExponent() != 3 || c.GetNumericCode() != "414" || c.GetName() != "Кувейтский динар") {
+			t.Fatalf("unexpected KWD: %#v", c)
+		}
+		if c.GetPinned() != (c.GetCode() == "USD") {
+			t.Fatalf("pinned flag of %s: %v", c.GetCode(), c.GetPinned())
+		}
+	}
+
+	pinned, err := srv.ListCurrencies(ctx, &budgetv1.ListCurrenciesRequest{PinnedOnly: true})
+	if err != nil || len(pinned.GetCurrencies()) != 2 {
+		t.Fatalf("pinned only: %v %#v", err, pinned)
+	}
+	if got := pinned.GetCurrencies()[1]; got.GetCode() != "USD" || got.GetName() != "US Dollar" {
+		t.Fatalf("unexpected entry: %#v", got)
+	}
+}
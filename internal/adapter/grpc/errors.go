@@ -5,9 +5,15 @@ import (
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v5"
+	"github.com/positron48/budget/internal/domain"
+	alertuse "github.com/positron48/budget/internal/usecase/alert"
 	authuse "github.com/positron48/budget/internal/usecase/auth"
+	catuse "github.com/positron48/budget/internal/usecase/category"
 	tenuse "github.com/positron48/budget/internal/usecase/tenant"
 	txuse "github.com/positron48/budget/internal/usecase/transaction"
+	useuser "github.com/positron48/budget/internal/usecase/user"
+	viewuse "github.com/positron48/budget/internal/usecase/view"
+	webhookuse "github.com/positron48/budget/internal/usecase/webhook"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -26,12 +32,98 @@ func mapError(err error) error {
 		return status.Error(codes.FailedPrecondition, err.Error())
 	case errors.Is(err, authuse.ErrGoogleEmailNotVerified):
 		return status.Error(codes.Unauthenticated, err.Error())
-	case errors.Is(err, txuse.ErrFxRateNotFound):
+	case errors.Is(err, authuse.ErrInvalidResetToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, authuse.ErrRateLimitExceeded), errors.Is(err, authuse.ErrTooManyLoginAttempts):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, authuse.ErrPasswordResetDisabled):
 		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, authuse.ErrInvalidVerificationToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, authuse.ErrEmailAlreadyVerified), errors.Is(err, authuse.ErrEmailVerificationDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, authuse.ErrRefreshTokenReused):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, authuse.ErrUnknownIdentityProvider):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, authuse.ErrInvalidIDToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, authuse.ErrIdentityNotLinked):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, authuse.ErrIdentityAlreadyLinked):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, authuse.ErrIdentityNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, authuse.ErrInvalidTwoFactorCode), errors.Is(err, authuse.ErrInvalidTwoFactorChallenge):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, authuse.ErrTwoFactorNotEnabled), errors.Is(err, useuser.ErrTwoFactorDisabled), errors.Is(err, useuser.ErrTwoFactorAlreadyEnabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, useuser.ErrAPITokenNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, useuser.ErrInvalidAPIToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, useuser.ErrInvalidAPITokenSpec):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, useuser.ErrAPITokensDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, useuser.ErrSessionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, useuser.ErrSessionsDisabled), errors.Is(err, useuser.ErrLoginHistoryDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, authuse.ErrNotTenantMember):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, authuse.ErrTenantSwitchDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrUnknownCurrency):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, txuse.ErrFxRateNotFound), errors.Is(err, txuse.ErrCategoryDeleted), errors.Is(err, txuse.ErrCurrencyNotAllowed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, txuse.ErrInvalidCategory), errors.Is(err, txuse.ErrTypeMismatch), errors.Is(err, txuse.ErrInvalidAmount),
+		errors.Is(err, txuse.ErrInvalidBatch), errors.Is(err, txuse.ErrEmptyBatch), errors.Is(err, txuse.ErrBatchTooLarge), errors.Is(err, txuse.ErrInvalidPageToken),
+		errors.Is(err, txuse.ErrInvalidQuery):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, txuse.ErrTransactionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, viewuse.ErrViewNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, viewuse.ErrInvalidView):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, viewuse.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, webhookuse.ErrSubscriptionNotFound), errors.Is(err, webhookuse.ErrDeliveryNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, webhookuse.ErrInvalidSubscription):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, webhookuse.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, alertuse.ErrRuleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, alertuse.ErrInvalidRule):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, alertuse.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, catuse.ErrCategoryInUse):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, catuse.ErrInvalidReassignTarget):
+		return status.Error(codes.InvalidArgument, err.Error())
 	case errors.Is(err, tenuse.ErrPermissionDenied):
 		return status.Error(codes.PermissionDenied, err.Error())
 	case errors.Is(err, tenuse.ErrAlreadyMember):
 		return status.Error(codes.AlreadyExists, "already_member")
+	case errors.Is(err, tenuse.ErrNotMember):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, tenuse.ErrLastOwner), errors.Is(err, tenuse.ErrDeletionScheduled), errors.Is(err, tenuse.ErrDeletionNotScheduled),
+		errors.Is(err, tenuse.ErrAuditDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, tenuse.ErrInvalidDeletionToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tenuse.ErrInvitationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, tenuse.ErrInvitationEmailMismatch):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, tenuse.ErrInvitationExpired), errors.Is(err, tenuse.ErrInvitationNotPending), errors.Is(err, tenuse.ErrInvitationsDisabled),
+		errors.Is(err, tenuse.ErrEmailNotVerified):
+		return status.Error(codes.FailedPrecondition, err.Error())
 	}
 	// postgres specific
 	var pgErr *pgconn.PgError
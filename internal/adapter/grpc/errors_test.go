@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/jackc/pgconn"
+	catuse "github.com/positron48/budget/internal/usecase/category"
+	txuse "github.com/positron48/budget/internal/usecase/transaction"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -19,3 +21,15 @@ func TestMapError_DomainAndPg(t *testing.T) {
 		t.Fatal("unknown -> Internal")
 	}
 }
+
+func TestMapError_Trash(t *testing.T) {
+	if status.Code(mapError(catuse.ErrCategoryInUse)) != codes.FailedPrecondition {
+		t.Fatal("category in use -> FailedPrecondition")
+	}
+	if status.Code(mapError(catuse.ErrInvalidReassignTarget)) != codes.InvalidArgument {
+		t.Fatal("bad reassign target -> InvalidArgument")
+	}
+	if status.Code(mapError(txuse.ErrCategoryDeleted)) != codes.FailedPrecondition {
+		t.Fatal("restore into deleted category -> FailedPrecondition")
+	}
+}
@@ -0,0 +1,67 @@
+//go:build !ignore
+// +build !ignore
+
+package grpcadapter
+
+import (
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EventSubscriber delivers tenant change events (see events.Broker).
+type EventSubscriber interface {
+	Subscribe(tenantID string) (events <-chan domain.TenantEvent, cancel func())
+}
+
+// EventServer streams changes of the caller's tenant; the tenant guard has already checked membership.
+type EventServer struct {
+	budgetv1.UnimplementedEventServiceServer
+	events EventSubscriber
+}
+
+func NewEventServer(events EventSubscriber) *EventServer {
+	return &EventServer{events: events}
+}
+
+func (s *EventServer) WatchTenantEvents(req *budgetv1.WatchTenantEventsRequest, stream grpc.ServerStreamingServer[budgetv1.TenantEvent]) error {
+	ctx := stream.Context()
+	tenantID := ctxTenantID(ctx)
+	if tenantID == "" {
+		return status.Error(codes.Unauthenticated, "missing tenant context")
+	}
+	wanted := make(map[string]bool, len(req.GetEntityTypes()))
+	for _, t := range req.GetEntityTypes() {
+		if t != domain.AuditEntityTransaction && t != domain.AuditEntityCategory {
+			return invalidArg("unsupported entity type: " + t)
+		}
+		wanted[t] = true
+	}
+	events, cancel := s.events.Subscribe(tenantID)
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-events:
+			if !ok {
+				return status.Error(codes.Aborted, "events may have been missed; reload and watch again")
+			}
+			if len(wanted) > 0 && !wanted[ev.EntityType] {
+				continue
+			}
+			if err := stream.Send(&budgetv1.TenantEvent{
+				EntityType:  ev.EntityType,
+				EntityId:    ev.EntityID,
+				Action:      toProtoAuditAction(ev.Action),
+				ActorUserId: ev.ActorID,
+				OccurredAt:  timestamppb.New(ev.OccurredAt),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
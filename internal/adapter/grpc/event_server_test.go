@@ -0,0 +1,116 @@
+package grpcadapter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	"github.com/positron48/budget/internal/usecase/events"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream records sent messages of a server stream; with block set, SendMsg waits
+// until block is closed
+type fakeServerStream struct {
+	ctx   context.Context
+	block chan struct{}
+	mu    sync.Mutex
+	sent  []interface{}
+}
+
+func (s *fakeServerStream) SetHeader(metadata.MD) error  { return nil }
+func (s *fakeServerStream) SendHeader(metadata.MD) error { return nil }
+func (s *fakeServerStream) SetTrailer(metadata.MD)       {}
+func (s *fakeServerStream) Context() context.Context     { return s.ctx }
+func (s *fakeServerStream) RecvMsg(interface{}) error    { return nil }
+func (s *fakeServerStream) SendMsg(m interface{}) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *fakeServerStream) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sent)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestEventServer_Watch(t *testing.T) {
+	broker := events.NewBroker()
+	srv := NewEventServer(broker)
+	ctx, cancel := context.WithCancel(ctxutil.WithTenantID(context.Background(), "t1"))
+	fake := &fakeServerStream{ctx: ctx}
+	stream := &grpc.GenericServerStream[budgetv1.WatchTenantEventsRequest, budgetv1.TenantEvent]{ServerStream: fake}
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.WatchTenantEvents(&budgetv1.WatchTenantEventsRequest{EntityTypes: []string{"transaction"}}, stream)
+	}()
+	waitFor(t, func() bool { return broker.Subscribers("t1") == 1 })
+
+	broker.Publish(domain.TenantEvent{TenantID: "t1", EntityType: domain.AuditEntityCategory, EntityID: "c1", Action: domain.AuditActionUpdated})
+	broker.Publish(domain.TenantEvent{TenantID: "t2", EntityType: domain.AuditEntityTransaction, EntityID: "x2", Action: domain.AuditActionCreated})
+	broker.Publish(domain.TenantEvent{TenantID: "t1", EntityType: domain.AuditEntityTransaction, EntityID: "x1", Action: domain.AuditActionCreated, ActorID: "u1"})
+	waitFor(t, func() bool { return fake.count() == 1 })
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("watch ended with %v", err)
+	}
+	ev := fake.sent[0].(*budgetv1.TenantEvent)
+	if ev.GetEntityId() != "x1" || ev.GetAction() != budgetv1.AuditAction_AUDIT_ACTION_CREATED || ev.GetActorUserId() != "u1" {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+	if broker.Subscribers("t1") != 0 {
+		t.Fatalf("subscription must be released")
+	}
+}
+
+func TestEventServer_Watch_Errors(t *testing.T) {
+	broker := events.NewBroker()
+	srv := NewEventServer(broker)
+	ctx := ctxutil.WithTenantID(context.Background(), "t1")
+	stream := &grpc.GenericServerStream[budgetv1.WatchTenantEventsRequest, budgetv1.TenantEvent]{ServerStream: &fakeServerStream{ctx: ctx}}
+	if err := srv.WatchTenantEvents(&budgetv1.WatchTenantEventsRequest{EntityTypes: []string{"membership"}}, stream); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unsupported entity type: %v", err)
+	}
+	noTenant := &grpc.GenericServerStream[budgetv1.WatchTenantEventsRequest, budgetv1.TenantEvent]{ServerStream: &fakeServerStream{ctx: context.Background()}}
+	if err := srv.WatchTenantEvents(&budgetv1.WatchTenantEventsRequest{}, noTenant); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("missing tenant: %v", err)
+	}
+
+	// a watcher that fell behind is told to reload
+	slow := &fakeServerStream{ctx: ctx, block: make(chan struct{})}
+	stream = &grpc.GenericServerStream[budgetv1.WatchTenantEventsRequest, budgetv1.TenantEvent]{ServerStream: slow}
+	done := make(chan error, 1)
+	go func() { done <- srv.WatchTenantEvents(&budgetv1.WatchTenantEventsRequest{}, stream) }()
+	waitFor(t, func() bool { return broker.Subscribers("t1") == 1 })
+	for i := 0; i < 1000 && broker.Subscribers("t1") > 0; i++ {
+		broker.Publish(domain.TenantEvent{TenantID: "t1", EntityType: domain.AuditEntityTransaction})
+	}
+	close(slow.block)
+	if err := <-done; status.Code(err) != codes.Aborted {
+		t.Fatalf("expected Aborted, got %v", err)
+	}
+}
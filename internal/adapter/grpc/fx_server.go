@@ -33,6 +33,12 @@ type FxServer struct {
 func NewFxServer(repo FxRepo) *FxServer { return &FxServer{repo: repo} }
 
 func (s *FxServer) GetRate(ctx context.Context, req *budgetv1.GetRateRequest) (*budgetv1.GetRateResponse, error) {
+	if err := checkCurrency("from_currency_code", req.GetFromCurrencyCode()); err != nil {
+		return nil, err
+	}
+	if err := checkCurrency("to_currency_code", req.GetToCurrencyCode()); err != nil {
+		return nil, err
+	}
 	asOf := time.Now()
 	if req.GetAsOf() != nil {
 		asOf = req.GetAsOf().AsTime()
@@ -46,6 +52,12 @@ func (s *FxServer) GetRate(ctx context.Context, req *budgetv1.GetRateRequest) (*
 
 func (s *FxServer) UpsertRate(ctx context.Context, req *budgetv1.UpsertRateRequest) (*budgetv1.UpsertRateResponse, error) {
 	r := req.GetRate()
+	if err := checkCurrency("rate.from_currency_code", r.GetFromCurrencyCode()); err != nil {
+		return nil, err
+	}
+	if err := checkCurrency("rate.to_currency_code", r.GetToCurrencyCode()); err != nil {
+		return nil, err
+	}
 	asOf := time.Now()
 	if r.GetAsOf() != nil {
 		asOf = r.GetAsOf().AsTime()
@@ -58,6 +70,14 @@ func (s *FxServer) UpsertRate(ctx context.Context, req *budgetv1.UpsertRateReque
 }
 
 func (s *FxServer) BatchGetRates(ctx context.Context, req *budgetv1.BatchGetRatesRequest) (*budgetv1.BatchGetRatesResponse, error) {
+	if err := checkCurrency("to_currency_code", req.GetToCurrencyCode()); err != nil {
+		return nil, err
+	}
+	for _, c := range req.GetFromCurrencyCodes() {
+		if err := checkCurrency("from_currency_codes", c); err != nil {
+			return nil, err
+		}
+	}
 	asOf := time.Now()
 	if req.GetAsOf() != nil {
 		asOf = req.GetAsOf().AsTime()
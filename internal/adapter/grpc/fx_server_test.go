@@ -7,6 +7,8 @@ import (
 	"time"
 
 	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -106,3 +108,16 @@ func TestFxServer_Batch_Error(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestFxServer_RejectsUnknownCurrency(t *testing.T) {
+	srv := NewFxServer(fxStub{rate: "1"})
+	if _, err := srv.GetRate(context.Background(), &budgetv1.GetRateRequest{FromCurrencyCode: "usd", ToCurrencyCode: "RUB"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("lower case code: %v", err)
+	}
+	if _, err := srv.UpsertRate(context.Background(), &budgetv1.UpsertRateRequest{Rate: &budgetv1.FxRate{FromCurrencyCode: "USD", ToCurrencyCode: "XYZ", RateDecimal: "1"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unknown code: %v", err)
+	}
+	if _, err := srv.BatchGetRates(context.Background(), &budgetv1.BatchGetRatesRequest{FromCurrencyCodes: []string{"USD", ""}, ToCurrencyCode: "RUB"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("empty code: %v", err)
+	}
+}
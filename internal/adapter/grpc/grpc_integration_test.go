@@ -12,13 +12,16 @@ import (
 	"github.com/positron48/budget/internal/domain"
 	useauth "github.com/positron48/budget/internal/usecase/auth"
 	"github.com/positron48/budget/internal/usecase/category"
+	"github.com/positron48/budget/internal/usecase/events"
 	repuse "github.com/positron48/budget/internal/usecase/report"
 	useTenant "github.com/positron48/budget/internal/usecase/tenant"
 	txuse "github.com/positron48/budget/internal/usecase/transaction"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -69,6 +72,22 @@ func (m *memCategoryRepo) List(ctx context.Context, tenantID string, kind domain
 	return out, nil
 }
 
+func (m *memCategoryRepo) CountTransactions(ctx context.Context, id string) (int64, error) {
+	return 0, nil
+}
+func (m *memCategoryRepo) ReassignTransactions(ctx context.Context, fromID, toID string) ([]domain.Transaction, error) {
+	return nil, nil
+}
+func (m *memCategoryRepo) ListDeleted(ctx context.Context, tenantID string) ([]domain.Category, error) {
+	return nil, nil
+}
+func (m *memCategoryRepo) Restore(ctx context.Context, tenantID, id string) (domain.Category, error) {
+	return domain.Category{ID: id, TenantID: tenantID}, nil
+}
+func (m *memCategoryRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
 func issueToken(signKey, userID, tenantID string) (string, error) {
 	claims := jwt.MapClaims{"sub": userID, "tenant_id": tenantID, "iat": time.Now().Unix(), "exp": time.Now().Add(15 * time.Minute).Unix(), "typ": "access"}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -191,6 +210,7 @@ type memRTRepo struct {
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}
 }
 
@@ -201,6 +221,7 @@ func (m *memRTRepo) Store(ctx context.Context, userID, tenantID, token string, e
 			TenantID  string
 			ExpiresAt time.Time
 			RevokedAt *time.Time
+			RotatedAt *time.Time
 		}{}
 	}
 	m.rows[token] = struct {
@@ -208,6 +229,7 @@ func (m *memRTRepo) Store(ctx context.Context, userID, tenantID, token string, e
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}{UserID: userID, TenantID: tenantID, ExpiresAt: expiresAt}
 	return nil
 }
@@ -219,26 +241,44 @@ func (m *memRTRepo) Rotate(ctx context.Context, oldToken, newToken string, newEx
 			TenantID  string
 			ExpiresAt time.Time
 			RevokedAt *time.Time
+			RotatedAt *time.Time
 		}{}
 	}
 	row := m.rows[oldToken]
 	now := time.Now()
-	row.RevokedAt = &now
+	row.RevokedAt, row.RotatedAt = &now, &now
 	m.rows[oldToken] = row
 	m.rows[newToken] = struct {
 		UserID    string
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}{UserID: row.UserID, TenantID: row.TenantID, ExpiresAt: newExpiresAt}
 	return nil
 }
 
+func (m *memRTRepo) Revoke(ctx context.Context, token string) error {
+	row, ok := m.rows[token]
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	row.RevokedAt = &now
+	m.rows[token] = row
+	return nil
+}
+
+func (m *memRTRepo) RevokeFamily(ctx context.Context, token string) error {
+	return m.Revoke(ctx, token)
+}
+
 func (m *memRTRepo) GetByToken(ctx context.Context, token string) (struct {
 	UserID    string
 	TenantID  string
 	ExpiresAt time.Time
 	RevokedAt *time.Time
+	RotatedAt *time.Time
 }, error,
 ) {
 	if row, ok := m.rows[token]; ok {
@@ -249,6 +289,7 @@ func (m *memRTRepo) GetByToken(ctx context.Context, token string) (struct {
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}{}, nil
 }
 
@@ -485,6 +526,10 @@ func (memTxSvc) List(ctx context.Context, tenantID string, filter txuse.ListFilt
 	return []domain.Transaction{{ID: "tx1"}}, 1, nil
 }
 
+func (memTxSvc) ListPage(ctx context.Context, tenantID string, filter txuse.ListFilter, pageToken string) (txuse.Page, error) {
+	return txuse.Page{Items: []domain.Transaction{{ID: "tx1"}}, Total: 1}, nil
+}
+
 func (memTxSvc) Totals(ctx context.Context, tenantID string, filter txuse.ListFilter) (domain.Money, domain.Money, error) {
 	return domain.Money{CurrencyCode: "USD", MinorUnits: 100}, domain.Money{CurrencyCode: "USD", MinorUnits: 50}, nil
 }
@@ -493,13 +538,33 @@ func (memTxSvc) CreateForUser(ctx context.Context, tenantID, userID string, txTy
 	return domain.Transaction{ID: "tx1", TenantID: tenantID, UserID: userID, CategoryID: categoryID, Type: txType, Amount: amount, BaseAmount: amount, OccurredAt: occurredAt, CreatedAt: time.Now()}, nil
 }
 
+func (memTxSvc) ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error) {
+	return nil, 0, nil
+}
+
+func (memTxSvc) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	return domain.Transaction{ID: id, TenantID: tenantID}, nil
+}
+
+func (memTxSvc) BatchCreate(ctx context.Context, tenantID, userID string, items []domain.Transaction) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
+func (memTxSvc) BatchUpdate(ctx context.Context, tenantID string, sel txuse.BatchSelector, patch txuse.BatchPatch) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
+func (memTxSvc) BatchDelete(ctx context.Context, tenantID string, sel txuse.BatchSelector) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
 func (memTxSvc) GetDateRange(ctx context.Context, tenantID string) (earliest, latest time.Time, err error) {
 	return time.Time{}, time.Time{}, nil
 }
 
 type memReportSvc struct{}
 
-func (memReportSvc) GetMonthlySummary(ctx context.Context, tenantID string, year int, month int, locale string, targetCurrencyCode string, tzOffsetMinutes int, excludeExtraordinary bool) (repuse.MonthlySummary, error) {
+func (memReportSvc) GetMonthlySummary(ctx context.Context, tenantID string, year int, month int, locale string, targetCurrencyCode string, tzOffsetMinutes int, base txuse.ListFilter) (repuse.MonthlySummary, error) {
 	return repuse.MonthlySummary{
 		Items:        []repuse.MonthlyItem{{CategoryID: "c1", CategoryName: "Food", Type: domain.TransactionTypeExpense, Total: domain.Money{CurrencyCode: "USD", MinorUnits: 500}}},
 		TotalIncome:  domain.Money{CurrencyCode: "USD", MinorUnits: 0},
@@ -511,7 +576,7 @@ func (memReportSvc) GetDateRange(ctx context.Context, tenantID string, locale st
 	return repuse.DateRange{}, nil
 }
 
-func (memReportSvc) GetSummaryReport(ctx context.Context, tenantID string, fromDate string, toDate string, locale string, targetCurrencyCode string, tzOffsetMinutes int, excludeExtraordinary bool) (repuse.SummaryReport, error) {
+func (memReportSvc) GetSummaryReport(ctx context.Context, tenantID string, fromDate string, toDate string, locale string, targetCurrencyCode string, tzOffsetMinutes int, base txuse.ListFilter) (repuse.SummaryReport, error) {
 	return repuse.SummaryReport{}, nil
 }
 
@@ -677,6 +742,32 @@ func (memTenantRepo) GetUserRole(ctx context.Context, tenantID, userID string) (
 	return domain.TenantRoleOwner, nil
 }
 
+func (memTenantRepo) TransferOwnership(ctx context.Context, tenantID, fromUserID, toUserID string) (domain.TenantMembership, domain.TenantMembership, error) {
+	return domain.TenantMembership{}, domain.TenantMembership{}, nil
+}
+func (memTenantRepo) CountOwners(ctx context.Context, tenantID string) (int, error) { return 1, nil }
+func (memTenantRepo) SetDefaultTenant(ctx context.Context, userID, tenantID string) error {
+	return nil
+}
+func (memTenantRepo) SetDeletionToken(ctx context.Context, tenantID, userID, token string, expiresAt time.Time) error {
+	return nil
+}
+func (memTenantRepo) ScheduleDeletion(ctx context.Context, tenantID, token string, purgeAt time.Time) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID, DeletionScheduledAt: &purgeAt}, nil
+}
+func (memTenantRepo) CancelDeletion(ctx context.Context, tenantID string) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID}, nil
+}
+func (memTenantRepo) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
+	return domain.Tenant{ID: id}, nil
+}
+func (memTenantRepo) SetCurrencies(ctx context.Context, tenantID string, codes []string) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID, CurrencyCodes: codes}, nil
+}
+func (memTenantRepo) PurgeScheduledTenants(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
 func TestTenant_Create_And_List_WithAuth(t *testing.T) {
 	const signKey = "test-secret"
 	lis := bufconn.Listen(bufSize)
@@ -727,3 +818,64 @@ func TestTenant_Create_And_List_WithAuth(t *testing.T) {
 		t.Fatalf("list: %v %#v", err, lst)
 	}
 }
+
+func TestEvents_StreamInterceptorChain(t *testing.T) {
+	const signKey = "test-secret"
+	lis := bufconn.Listen(bufSize)
+	t.Cleanup(func() { _ = lis.Close() })
+	lg, _ := zap.NewDevelopment()
+	sug := lg.Sugar()
+	defer lg.Sync() //nolint:errcheck
+
+	srv := grpc.NewServer(grpc.ChainStreamInterceptor(
+		NewAuthStreamInterceptor(signKey),
+		MetricsStreamInterceptor(),
+		LoggingStreamInterceptor(sug),
+		RecoveryStreamInterceptor(sug),
+		NewTenantGuardStreamInterceptor(func(ctx context.Context, userID, tenantID string) (bool, error) { return userID == "u1", nil }),
+	))
+	broker := events.NewBroker()
+	budgetv1.RegisterEventServiceServer(srv, NewEventServer(broker))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := dialBufConn(ctx, lis)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	client := budgetv1.NewEventServiceClient(conn)
+	watch := func(ctx context.Context) error {
+		stream, err := client.WatchTenantEvents(ctx, &budgetv1.WatchTenantEventsRequest{})
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+
+	if err := watch(ctx); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("anonymous stream: expected Unauthenticated, got %v", err)
+	}
+	stranger, _ := issueToken(signKey, "u2", "t1")
+	if err := watch(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+stranger)); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("non-member stream: expected PermissionDenied, got %v", err)
+	}
+
+	member, _ := issueToken(signKey, "u1", "t1")
+	stream, err := client.WatchTenantEvents(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+member), &budgetv1.WatchTenantEventsRequest{})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	waitFor(t, func() bool { return broker.Subscribers("t1") == 1 })
+	broker.Publish(domain.TenantEvent{TenantID: "t1", EntityType: domain.AuditEntityTransaction, EntityID: "x1", Action: domain.AuditActionDeleted})
+	ev, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if ev.GetEntityId() != "x1" || ev.GetAction() != budgetv1.AuditAction_AUDIT_ACTION_DELETED {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+}
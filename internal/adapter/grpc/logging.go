@@ -28,3 +28,22 @@ func LoggingUnaryInterceptor(lg *zap.SugaredLogger) grpc.UnaryServerInterceptor
 		return resp, err
 	}
 }
+
+// LoggingStreamInterceptor logs streams like LoggingUnaryInterceptor when they end.
+func LoggingStreamInterceptor(lg *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+		err := handler(srv, ss)
+		ctx := ss.Context()
+		userID, _ := ctxutil.UserIDFromContext(ctx)
+		tenantID, _ := ctxutil.TenantIDFromContext(ctx)
+		lg.Infow("rpc stream",
+			"method", info.FullMethod,
+			"elapsed_ms", time.Since(started).Milliseconds(),
+			"code", status.Code(err).String(),
+			"user_id", userID,
+			"tenant_id", tenantID,
+		)
+		return err
+	}
+}
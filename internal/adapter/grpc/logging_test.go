@@ -33,3 +33,13 @@ func TestLoggingInterceptor_Error(t *testing.T) {
 		t.Fatalf("expected InvalidArgument, got %v", err)
 	}
 }
+
+func TestLoggingStreamInterceptor(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+	it := LoggingStreamInterceptor(lg.Sugar())
+	ss := &fakeServerStream{ctx: context.Background()}
+	errStream := func(srv interface{}, ss grpc.ServerStream) error { return status.Error(codes.Aborted, "behind") }
+	if err := it(nil, ss, &grpc.StreamServerInfo{FullMethod: "/test.Watch"}, errStream); status.Code(err) != codes.Aborted {
+		t.Fatalf("expected Aborted, got %v", err)
+	}
+}
@@ -29,10 +29,30 @@ var (
 		},
 		[]string{"method", "code"},
 	)
+	// streams (watch feeds) live for minutes or hours, far beyond the request buckets
+	grpcStreamDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "budget",
+			Subsystem: "grpc",
+			Name:      "stream_duration_seconds",
+			Help:      "Duration of gRPC streams in seconds.",
+			Buckets:   []float64{1, 10, 60, 300, 900, 3600, 4 * 3600, 24 * 3600},
+		},
+		[]string{"method", "code"},
+	)
+	grpcStreamMessagesSent = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "budget",
+			Subsystem: "grpc",
+			Name:      "stream_messages_sent_total",
+			Help:      "Total number of messages sent on gRPC server streams.",
+		},
+		[]string{"method"},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(grpcRequestsTotal, grpcRequestDuration)
+	prometheus.MustRegister(grpcRequestsTotal, grpcRequestDuration, grpcStreamDuration, grpcStreamMessagesSent)
 }
 
 // MetricsUnaryInterceptor records request count and latency per method and status code.
@@ -47,3 +67,29 @@ func MetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// MetricsStreamInterceptor counts streams in requests_total (by final status code), records
+// their lifetime and the number of messages sent.
+func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+		err := handler(srv, &countingServerStream{ServerStream: ss, sent: grpcStreamMessagesSent.WithLabelValues(info.FullMethod)})
+		labels := prometheus.Labels{"method": info.FullMethod, "code": status.Code(err).String()}
+		grpcRequestsTotal.With(labels).Inc()
+		grpcStreamDuration.With(labels).Observe(time.Since(started).Seconds())
+		return err
+	}
+}
+
+type countingServerStream struct {
+	grpc.ServerStream
+	sent prometheus.Counter
+}
+
+func (s *countingServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Inc()
+	}
+	return err
+}
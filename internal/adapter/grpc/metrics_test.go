@@ -0,0 +1,47 @@
+package grpcadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsUnaryInterceptor(t *testing.T) {
+	it := MetricsUnaryInterceptor()
+	before := testutil.ToFloat64(grpcRequestsTotal.WithLabelValues("/test.Metrics/Unary", "InvalidArgument"))
+	if _, err := it(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Metrics/Unary"}, handlerErr); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if got := testutil.ToFloat64(grpcRequestsTotal.WithLabelValues("/test.Metrics/Unary", "InvalidArgument")); got != before+1 {
+		t.Fatalf("requests_total = %v, want %v", got, before+1)
+	}
+}
+
+func TestMetricsStreamInterceptor(t *testing.T) {
+	it := MetricsStreamInterceptor()
+	const method = "/test.Metrics/Watch"
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		for i := 0; i < 3; i++ {
+			if err := ss.SendMsg(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := it(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}, handler); err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	if got := testutil.ToFloat64(grpcStreamMessagesSent.WithLabelValues(method)); got != 3 {
+		t.Fatalf("messages sent = %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(grpcRequestsTotal.WithLabelValues(method, "OK")); got != 1 {
+		t.Fatalf("requests_total = %v, want 1", got)
+	}
+	if n := testutil.CollectAndCount(grpcStreamDuration, "budget_grpc_stream_duration_seconds"); n == 0 {
+		t.Fatalf("stream duration not observed")
+	}
+}
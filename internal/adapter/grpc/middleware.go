@@ -3,8 +3,10 @@ package grpcadapter
 import (
 	"context"
 	"strings"
+	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/positron48/budget/internal/domain"
 	"github.com/positron48/budget/internal/pkg/ctxutil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -12,60 +14,172 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// AccessTokenDenylist reports access tokens revoked before their expiry.
+type AccessTokenDenylist interface {
+	IsAccessTokenRevoked(ctx context.Context, userID, jti string, issuedAt time.Time) (bool, error)
+}
+
 // NewAuthUnaryInterceptor validates JWT (HS256) and extracts user_id/tenant_id into context.
 func NewAuthUnaryInterceptor(signKey string) grpc.UnaryServerInterceptor {
+	return NewAuthUnaryInterceptorWithDenylist(signKey, nil)
+}
+
+// NewAuthUnaryInterceptorWithDenylist additionally rejects tokens found in the denylist.
+func NewAuthUnaryInterceptorWithDenylist(signKey string, denylist AccessTokenDenylist) grpc.UnaryServerInterceptor {
+	return NewAuthUnaryInterceptorWithKeyfunc(hmacKeyfunc(signKey), denylist)
+}
+
+// hmacKeyfunc accepts HS256 tokens signed with signKey
+func hmacKeyfunc(signKey string) jwt.Keyfunc {
 	keyBytes := []byte(signKey)
+	return func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return keyBytes, nil
+	}
+}
+
+// NewAuthUnaryInterceptorWithKeyfunc verifies tokens with keys selected by keyfunc
+// (e.g. by kid for asymmetric keys); denylist is optional.
+func NewAuthUnaryInterceptorWithKeyfunc(keyfunc jwt.Keyfunc, denylist AccessTokenDenylist) grpc.UnaryServerInterceptor {
+	return NewAuthUnaryInterceptorWithAPITokens(keyfunc, denylist, nil)
+}
+
+// NewAuthUnaryInterceptorWithAPITokens also accepts personal access tokens (bpat_...) in the
+// authorization header; apiTokens is optional.
+func NewAuthUnaryInterceptorWithAPITokens(keyfunc jwt.Keyfunc, denylist AccessTokenDenylist, apiTokens APITokenAuthenticator) grpc.UnaryServerInterceptor {
+	authenticate := newAuthenticator(keyfunc, denylist, apiTokens)
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewAuthStreamInterceptor is NewAuthUnaryInterceptor for streaming RPCs.
+func NewAuthStreamInterceptor(signKey string) grpc.StreamServerInterceptor {
+	return NewAuthStreamInterceptorWithAPITokens(hmacKeyfunc(signKey), nil, nil)
+}
+
+// NewAuthStreamInterceptorWithAPITokens authenticates streaming RPCs like NewAuthUnaryInterceptorWithAPITokens.
+func NewAuthStreamInterceptorWithAPITokens(keyfunc jwt.Keyfunc, denylist AccessTokenDenylist, apiTokens APITokenAuthenticator) grpc.StreamServerInterceptor {
+	authenticate := newAuthenticator(keyfunc, denylist, apiTokens)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextServerStream replaces the context of a stream, as interceptors do for unary handlers.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context { return s.ctx }
+
+// newAuthenticator returns the check shared by the unary and stream interceptors: it puts the
+// caller (and tenant) into the context, or fails with a gRPC status.
+func newAuthenticator(keyfunc jwt.Keyfunc, denylist AccessTokenDenylist, apiTokens APITokenAuthenticator) func(ctx context.Context, fullMethod string) (context.Context, error) {
+	return func(ctx context.Context, fullMethod string) (context.Context, error) {
+		// device info is recorded with issued refresh tokens
+		ip, ua := requestClientInfo(ctx)
+		ctx = ctxutil.WithClientInfo(ctx, ip, ua)
 		// allowlist: health and auth methods don't require token
-		if isPublicMethod(info.FullMethod) {
-			return handler(ctx, req)
+		if isPublicMethod(fullMethod) {
+			return ctx, nil
+		}
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			// no metadata provided for protected method
+			return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+		}
+		if vals := md.Get("x-tenant-id"); len(vals) > 0 && vals[0] != "" {
+			ctx = ctxutil.WithTenantID(ctx, vals[0])
+		}
+		if raw, ok := bearerToken(md); ok && apiTokens != nil && domain.IsAPIToken(raw) {
+			return authenticateAPIToken(ctx, apiTokens, raw, fullMethod)
 		}
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			var hasAuth bool
-			if vals := md.Get("x-tenant-id"); len(vals) > 0 && vals[0] != "" {
-				ctx = ctxutil.WithTenantID(ctx, vals[0])
+		claims, ok := parseBearer(md, keyfunc)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid access token")
+		}
+		sub, _ := claims["sub"].(string)
+		if denylist != nil {
+			jti, _ := claims["jti"].(string)
+			var issuedAt time.Time
+			if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+				issuedAt = iat.Time
 			}
-			if vals := md.Get("authorization"); len(vals) > 0 {
-				token := vals[0]
-				if strings.HasPrefix(strings.ToLower(token), "bearer ") {
-					raw := strings.TrimSpace(token[len("Bearer "):])
-					parsed, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
-						if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-							return nil, jwt.ErrSignatureInvalid
-						}
-						return keyBytes, nil
-					})
-					if err == nil && parsed != nil && parsed.Valid {
-						if claims, ok := parsed.Claims.(jwt.MapClaims); ok {
-							if sub, ok := claims["sub"].(string); ok && sub != "" {
-								ctx = ctxutil.WithUserID(ctx, sub)
-								hasAuth = true
-							}
-							if tid, ok := claims["tenant_id"].(string); ok && tid != "" {
-								if _, exists := ctxutil.TenantIDFromContext(ctx); !exists {
-									ctx = ctxutil.WithTenantID(ctx, tid)
-								}
-							}
-						}
-					}
-				}
+			revoked, err := denylist.IsAccessTokenRevoked(ctx, sub, jti, issuedAt)
+			if err != nil {
+				return nil, status.Error(codes.Unavailable, "token revocation check failed")
 			}
-			if !hasAuth {
-				return nil, status.Error(codes.Unauthenticated, "missing or invalid access token")
+			if revoked {
+				return nil, status.Error(codes.Unauthenticated, "access token revoked")
 			}
-		} else {
-			// no metadata provided for protected method
-			return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
 		}
-		return handler(ctx, req)
+		ctx = ctxutil.WithUserID(ctx, sub)
+		if jti, _ := claims["jti"].(string); jti != "" {
+			if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+				ctx = ctxutil.WithAccessToken(ctx, jti, exp.Time)
+			}
+		}
+		if tid, ok := claims["tenant_id"].(string); ok && tid != "" {
+			if _, exists := ctxutil.TenantIDFromContext(ctx); !exists {
+				ctx = ctxutil.WithTenantID(ctx, tid)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// parseBearer validates the "authorization: Bearer <jwt>" header and returns claims with a subject.
+func parseBearer(md metadata.MD, keyfunc jwt.Keyfunc) (jwt.MapClaims, bool) {
+	raw, ok := bearerToken(md)
+	if !ok {
+		return nil, false
+	}
+	parsed, err := jwt.Parse(raw, keyfunc)
+	if err != nil || parsed == nil || !parsed.Valid {
+		return nil, false
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, false
+	}
+	if sub, ok := claims["sub"].(string); !ok || sub == "" {
+		return nil, false
+	}
+	return claims, true
+}
+
+func bearerToken(md metadata.MD) (string, bool) {
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return "", false
+	}
+	token := vals[0]
+	if !strings.HasPrefix(strings.ToLower(token), "bearer ") {
+		return "", false
 	}
+	return strings.TrimSpace(token[len("Bearer "):]), true
 }
 
 func isPublicMethod(fullMethod string) bool {
 	switch fullMethod {
 	case "/grpc.health.v1.Health/Check", "/grpc.health.v1.Health/Watch":
 		return true
-	case "/budget.v1.AuthService/Register", "/budget.v1.AuthService/Login", "/budget.v1.AuthService/GoogleAuth", "/budget.v1.AuthService/RefreshToken":
+	case "/budget.v1.AuthService/Register", "/budget.v1.AuthService/Login", "/budget.v1.AuthService/GoogleAuth", "/budget.v1.AuthService/RefreshToken",
+		"/budget.v1.AuthService/RequestPasswordReset", "/budget.v1.AuthService/ResetPassword",
+		"/budget.v1.AuthService/VerifyEmail", "/budget.v1.AuthService/CompleteTwoFactorLogin",
+		"/budget.v1.AuthService/ExternalAuth", "/budget.v1.AuthService/ListIdentityProviders":
 		return true
 	case "/budget.v1.OAuthService/GenerateAuthLink", "/budget.v1.OAuthService/GetVerificationCode", "/budget.v1.OAuthService/VerifyAuthCode",
 		"/budget.v1.OAuthService/CancelAuth", "/budget.v1.OAuthService/GetAuthStatus":
@@ -2,11 +2,14 @@ package grpcadapter
 
 import (
 	"context"
+	"crypto/ed25519"
 	"testing"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/positron48/budget/internal/domain"
 	"github.com/positron48/budget/internal/pkg/ctxutil"
+	useuser "github.com/positron48/budget/internal/usecase/user"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -162,3 +165,137 @@ func ctxTenant(ctx context.Context) (string, bool) {
 func metadataIncoming(m map[string]string) context.Context {
 	return metadata.NewIncomingContext(context.Background(), metadata.New(m))
 }
+
+type denylistStub struct {
+	jtis      map[string]bool
+	watermark time.Time
+}
+
+func (d denylistStub) IsAccessTokenRevoked(ctx context.Context, userID, jti string, issuedAt time.Time) (bool, error) {
+	return d.jtis[jti] || !issuedAt.After(d.watermark), nil
+}
+
+func TestAuthInterceptor_Denylist(t *testing.T) {
+	now := time.Now()
+	sign := func(jti string, iat time.Time) context.Context {
+		claims := jwt.MapClaims{"sub": "u1", "jti": jti, "iat": iat.Unix(), "exp": now.Add(time.Minute).Unix()}
+		s, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("k"))
+		return metadataIncoming(map[string]string{"authorization": "Bearer " + s})
+	}
+	it := NewAuthUnaryInterceptorWithDenylist("k", denylistStub{jtis: map[string]bool{"bad": true}, watermark: now.Add(-time.Hour)})
+	info := &grpc.UnaryServerInfo{FullMethod: "/budget.v1.CategoryService/ListCategories"}
+	if _, err := it(sign("bad", now), nil, info, handlerOK); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected denied jti to be rejected, got %v", err)
+	}
+	if _, err := it(sign("old", now.Add(-2*time.Hour)), nil, info, handlerOK); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected token issued before watermark to be rejected, got %v", err)
+	}
+	var gotJTI string
+	_, err := it(sign("good", now), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		gotJTI, _, _ = ctxutil.AccessTokenFromContext(ctx)
+		return "ok", nil
+	})
+	if err != nil || gotJTI != "good" {
+		t.Fatalf("expected valid token to pass with jti in context: %v %q", err, gotJTI)
+	}
+}
+
+func TestAuthInterceptor_Keyfunc_EdDSA(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	keyfunc := func(tok *jwt.Token) (interface{}, error) {
+		if tok.Header["kid"] != "k1" {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		return pub, nil
+	}
+	it := NewAuthUnaryInterceptorWithKeyfunc(keyfunc, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/budget.v1.CategoryService/ListCategories"}
+	tok := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"sub": "u1", "exp": time.Now().Add(time.Minute).Unix()})
+	tok.Header["kid"] = "k1"
+	s, _ := tok.SignedString(priv)
+	if _, err := it(metadataIncoming(map[string]string{"authorization": "Bearer " + s}), nil, info, handlerOK); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hs, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1"}).SignedString([]byte("k"))
+	if _, err := it(metadataIncoming(map[string]string{"authorization": "Bearer " + hs}), nil, info, handlerOK); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestAuthInterceptor_APITokens(t *testing.T) {
+	tokens := APITokenAuthenticatorFunc(func(ctx context.Context, token string) (domain.APIToken, error) {
+		switch token {
+		case "bpat_read":
+			return domain.APIToken{UserID: "u1", TenantID: "t1", Scope: domain.APITokenScopeRead}, nil
+		case "bpat_rw":
+			return domain.APIToken{UserID: "u1", TenantID: "t1", Scope: domain.APITokenScopeReadWrite}, nil
+		}
+		return domain.APIToken{}, useuser.ErrInvalidAPIToken
+	})
+	it := NewAuthUnaryInterceptorWithAPITokens(func(*jwt.Token) (interface{}, error) { return []byte("k"), nil }, nil, tokens)
+	call := func(token, method string, md map[string]string) (context.Context, error) {
+		if md == nil {
+			md = map[string]string{}
+		}
+		md["authorization"] = "Bearer " + token
+		var got context.Context
+		_, err := it(metadataIncoming(md), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			got = ctx
+			return "ok", nil
+		})
+		return got, err
+	}
+
+	ctx, err := call("bpat_read", "/budget.v1.TransactionService/ListTransactions", nil)
+	if err != nil {
+		t.Fatalf("read token must list: %v", err)
+	}
+	if uid, _ := ctxutil.UserIDFromContext(ctx); uid != "u1" {
+		t.Fatalf("unexpected user %q", uid)
+	}
+	if tid, _ := ctxutil.TenantIDFromContext(ctx); tid != "t1" {
+		t.Fatalf("unexpected tenant %q", tid)
+	}
+	if _, err := call("bpat_read", "/budget.v1.TransactionService/CreateTransaction", nil); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("read token must not write, got %v", err)
+	}
+	if _, err := call("bpat_rw", "/budget.v1.TransactionService/CreateTransaction", nil); err != nil {
+		t.Fatalf("read-write token must write: %v", err)
+	}
+	if _, err := call("bpat_rw", "/budget.v1.UserService/CreateApiToken", nil); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("api tokens must not manage the account, got %v", err)
+	}
+	if _, err := call("bpat_rw", "/budget.v1.CategoryService/ListCategories", map[string]string{"x-tenant-id": "t2"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("tenant override must be refused, got %v", err)
+	}
+	if _, err := call("bpat_unknown", "/budget.v1.CategoryService/ListCategories", nil); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("unknown token must be unauthenticated, got %v", err)
+	}
+}
+
+func TestAuthStreamInterceptor(t *testing.T) {
+	it := NewAuthStreamInterceptor("k")
+	info := &grpc.StreamServerInfo{FullMethod: "/budget.v1.EventService/WatchTenantEvents", IsServerStream: true}
+	var gotUser, gotTenant string
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		gotUser, _ = ctxutil.UserIDFromContext(ss.Context())
+		gotTenant, _ = ctxutil.TenantIDFromContext(ss.Context())
+		return nil
+	}
+	if err := it(nil, &fakeServerStream{ctx: context.Background()}, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+	claims := jwt.MapClaims{"sub": "u1", "tenant_id": "t1", "exp": time.Now().Add(time.Minute).Unix()}
+	s, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("k"))
+	ss := &fakeServerStream{ctx: metadataIncoming(map[string]string{"authorization": "Bearer " + s})}
+	if err := it(nil, ss, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotUser != "u1" || gotTenant != "t1" {
+		t.Fatalf("stream context not populated: user=%q tenant=%q", gotUser, gotTenant)
+	}
+	// health watch is public
+	if err := it(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}, handler); err != nil {
+		t.Fatalf("public stream should pass: %v", err)
+	}
+}
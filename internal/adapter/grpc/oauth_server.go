@@ -9,7 +9,6 @@ import (
 	"github.com/positron48/budget/internal/domain"
 	useoauth "github.com/positron48/budget/internal/usecase/oauth"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -324,26 +323,7 @@ func (s *OAuthServer) GetAuthLogs(ctx context.Context, req *budgetv1.GetAuthLogs
 // Вспомогательные методы
 
 func (s *OAuthServer) extractClientInfo(ctx context.Context) (string, string) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return "", ""
-	}
-
-	// Получение IP адреса
-	ipAddress := ""
-	if xff := md.Get("x-forwarded-for"); len(xff) > 0 {
-		ipAddress = xff[0]
-	} else if xri := md.Get("x-real-ip"); len(xri) > 0 {
-		ipAddress = xri[0]
-	}
-
-	// Получение User-Agent
-	userAgent := ""
-	if ua := md.Get("user-agent"); len(ua) > 0 {
-		userAgent = ua[0]
-	}
-
-	return ipAddress, userAgent
+	return requestClientInfo(ctx)
 }
 
 func (s *OAuthServer) mapOAuthError(err error) error {
@@ -366,6 +346,8 @@ func (s *OAuthServer) mapOAuthError(err error) error {
 		return status.Error(codes.InvalidArgument, "invalid email format")
 	case errors.Is(err, useoauth.ErrUserNotFound):
 		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, useoauth.ErrEmailNotVerified):
+		return status.Error(codes.FailedPrecondition, "email is not verified")
 	default:
 		return status.Error(codes.Internal, fmt.Sprintf("internal error: %v", err))
 	}
@@ -28,3 +28,16 @@ func RecoveryUnaryInterceptor(lg *zap.SugaredLogger) grpc.UnaryServerInterceptor
 		return handler(ctx, req)
 	}
 }
+
+// RecoveryStreamInterceptor catches panics of stream handlers like RecoveryUnaryInterceptor.
+func RecoveryStreamInterceptor(lg *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				lg.Errorw("panic recovered", "method", info.FullMethod, "panic", r, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
@@ -20,3 +20,13 @@ func TestRecoveryInterceptor(t *testing.T) {
 		t.Fatalf("expected Internal, got %v", err)
 	}
 }
+
+func TestRecoveryStreamInterceptor(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+	it := RecoveryStreamInterceptor(lg.Sugar())
+	panicStream := func(srv interface{}, ss grpc.ServerStream) error { panic("boom") }
+	err := it(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/test"}, panicStream)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal, got %v", err)
+	}
+}
@@ -9,29 +9,51 @@ import (
 	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
 	"github.com/positron48/budget/internal/pkg/ctxutil"
 	repuse "github.com/positron48/budget/internal/usecase/report"
+	txusecase "github.com/positron48/budget/internal/usecase/transaction"
 )
 
 type ReportServer struct {
 	budgetv1.UnimplementedReportServiceServer
 	svc interface {
-		GetMonthlySummary(ctx context.Context, tenantID string, year int, month int, locale string, targetCurrencyCode string, tzOffsetMinutes int, excludeExtraordinary bool) (repuse.MonthlySummary, error)
-		GetSummaryReport(ctx context.Context, tenantID string, fromDate, toDate, locale, targetCurrencyCode string, tzOffsetMinutes int, excludeExtraordinary bool) (repuse.SummaryReport, error)
+		GetMonthlySummary(ctx context.Context, tenantID string, year int, month int, locale string, targetCurrencyCode string, tzOffsetMinutes int, base txusecase.ListFilter) (repuse.MonthlySummary, error)
+		GetSummaryReport(ctx context.Context, tenantID string, fromDate, toDate, locale, targetCurrencyCode string, tzOffsetMinutes int, base txusecase.ListFilter) (repuse.SummaryReport, error)
 		GetDateRange(ctx context.Context, tenantID string, locale string, tzOffsetMinutes int) (repuse.DateRange, error)
 	}
+	// views resolves view_id of report requests (optional, see SetViews)
+	views ViewResolver
 }
 
 func NewReportServer(svc interface {
-	GetMonthlySummary(ctx context.Context, tenantID string, year int, month int, locale string, targetCurrencyCode string, tzOffsetMinutes int, excludeExtraordinary bool) (repuse.MonthlySummary, error)
-	GetSummaryReport(ctx context.Context, tenantID string, fromDate, toDate, locale, targetCurrencyCode string, tzOffsetMinutes int, excludeExtraordinary bool) (repuse.SummaryReport, error)
+	GetMonthlySummary(ctx context.Context, tenantID string, year int, month int, locale string, targetCurrencyCode string, tzOffsetMinutes int, base txusecase.ListFilter) (repuse.MonthlySummary, error)
+	GetSummaryReport(ctx context.Context, tenantID string, fromDate, toDate, locale, targetCurrencyCode string, tzOffsetMinutes int, base txusecase.ListFilter) (repuse.SummaryReport, error)
 	GetDateRange(ctx context.Context, tenantID string, locale string, tzOffsetMinutes int) (repuse.DateRange, error)
 },
 ) *ReportServer {
 	return &ReportServer{svc: svc}
 }
 
+// SetViews enables view_id in report requests.
+func (s *ReportServer) SetViews(views ViewResolver) { s.views = views }
+
+// reportFilter is the saved view narrowing a report, with one-off operations excluded on request
+func (s *ReportServer) reportFilter(ctx context.Context, viewID string, excludeExtraordinary bool) (txusecase.ListFilter, error) {
+	f, _, err := resolveView(ctx, s.views, viewID)
+	if err != nil {
+		return txusecase.ListFilter{}, err
+	}
+	if excludeExtraordinary {
+		f.ExcludeExtraordinary = true
+	}
+	return f, nil
+}
+
 func (s *ReportServer) GetMonthlySummary(ctx context.Context, req *budgetv1.GetMonthlySummaryRequest) (*budgetv1.GetMonthlySummaryResponse, error) {
 	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
-	sum, err := s.svc.GetMonthlySummary(ctx, tenantID, int(req.GetYear()), int(req.GetMonth()), req.GetLocale(), req.GetTargetCurrencyCode(), int(req.GetTimezoneOffsetMinutes()), req.GetExcludeExtraordinary())
+	base, err := s.reportFilter(ctx, req.GetViewId(), req.GetExcludeExtraordinary())
+	if err != nil {
+		return nil, err
+	}
+	sum, err := s.svc.GetMonthlySummary(ctx, tenantID, int(req.GetYear()), int(req.GetMonth()), req.GetLocale(), req.GetTargetCurrencyCode(), int(req.GetTimezoneOffsetMinutes()), base)
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -53,7 +75,11 @@ func (s *ReportServer) GetMonthlySummary(ctx context.Context, req *budgetv1.GetM
 
 func (s *ReportServer) GetSummaryReport(ctx context.Context, req *budgetv1.GetSummaryReportRequest) (*budgetv1.GetSummaryReportResponse, error) {
 	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
-	report, err := s.svc.GetSummaryReport(ctx, tenantID, req.GetFromDate(), req.GetToDate(), req.GetLocale(), req.GetTargetCurrencyCode(), int(req.GetTimezoneOffsetMinutes()), req.GetExcludeExtraordinary())
+	base, err := s.reportFilter(ctx, req.GetViewId(), req.GetExcludeExtraordinary())
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.svc.GetSummaryReport(ctx, tenantID, req.GetFromDate(), req.GetToDate(), req.GetLocale(), req.GetTargetCurrencyCode(), int(req.GetTimezoneOffsetMinutes()), base)
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -10,28 +10,45 @@ import (
 )
 
 // NewTenantGuardUnaryInterceptor ensures the authenticated user is a member of the active tenant
-// for tenant-scoped RPCs (Category, Transaction, Report). Non-tenant-scoped methods are bypassed.
+// for tenant-scoped RPCs (Category, Transaction, Report, Currency, View). Non-tenant-scoped methods are bypassed.
 func NewTenantGuardUnaryInterceptor(validate func(ctx context.Context, userID, tenantID string) (bool, error)) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if !isTenantScopedMethod(info.FullMethod) {
-			return handler(ctx, req)
-		}
-		userID, okU := ctxutil.UserIDFromContext(ctx)
-		tenantID, okT := ctxutil.TenantIDFromContext(ctx)
-		if !okU || !okT || userID == "" || tenantID == "" {
-			return nil, status.Error(codes.Unauthenticated, "missing user or tenant context")
-		}
-		ok, err := validate(ctx, userID, tenantID)
-		if err != nil {
-			return nil, status.Error(codes.Internal, "membership check failed")
-		}
-		if !ok {
-			return nil, status.Error(codes.PermissionDenied, "user is not a member of the tenant")
+		if err := checkTenantMembership(ctx, info.FullMethod, validate); err != nil {
+			return nil, err
 		}
 		return handler(ctx, req)
 	}
 }
 
+// NewTenantGuardStreamInterceptor applies the tenant guard to streaming RPCs (EventService).
+func NewTenantGuardStreamInterceptor(validate func(ctx context.Context, userID, tenantID string) (bool, error)) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkTenantMembership(ss.Context(), info.FullMethod, validate); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func checkTenantMembership(ctx context.Context, fullMethod string, validate func(ctx context.Context, userID, tenantID string) (bool, error)) error {
+	if !isTenantScopedMethod(fullMethod) {
+		return nil
+	}
+	userID, okU := ctxutil.UserIDFromContext(ctx)
+	tenantID, okT := ctxutil.TenantIDFromContext(ctx)
+	if !okU || !okT || userID == "" || tenantID == "" {
+		return status.Error(codes.Unauthenticated, "missing user or tenant context")
+	}
+	ok, err := validate(ctx, userID, tenantID)
+	if err != nil {
+		return status.Error(codes.Internal, "membership check failed")
+	}
+	if !ok {
+		return status.Error(codes.PermissionDenied, "user is not a member of the tenant")
+	}
+	return nil
+}
+
 func isTenantScopedMethod(fullMethod string) bool {
 	switch {
 	case hasPrefix(fullMethod, "/budget.v1.CategoryService/"):
@@ -40,8 +57,20 @@ func isTenantScopedMethod(fullMethod string) bool {
 		return true
 	case hasPrefix(fullMethod, "/budget.v1.ReportService/"):
 		return true
+	case hasPrefix(fullMethod, "/budget.v1.CurrencyService/"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.ViewService/"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.EventService/"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.WebhookService/"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.AlertService/"):
+		return true
 	case hasPrefix(fullMethod, "/budget.v1.TenantService/UpdateTenant"):
 		return true
+	case hasPrefix(fullMethod, "/budget.v1.TenantService/SetTenantCurrencies"):
+		return true
 	case hasPrefix(fullMethod, "/budget.v1.TenantService/ListMembers"):
 		return true
 	case hasPrefix(fullMethod, "/budget.v1.TenantService/AddMember"):
@@ -50,6 +79,18 @@ func isTenantScopedMethod(fullMethod string) bool {
 		return true
 	case hasPrefix(fullMethod, "/budget.v1.TenantService/RemoveMember"):
 		return true
+	case hasPrefix(fullMethod, "/budget.v1.TenantService/CreateInvitation"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.TenantService/RevokeInvitation"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.TenantService/TransferOwnership"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.TenantService/DeleteTenant"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.TenantService/ListAuditEvents"):
+		return true
+	case hasPrefix(fullMethod, "/budget.v1.TenantService/CancelTenantDeletion"):
+		return true
 	default:
 		return false
 	}
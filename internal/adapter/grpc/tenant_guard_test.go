@@ -48,3 +48,23 @@ func TestTenantGuard_MissingContext(t *testing.T) {
 		t.Fatalf("expected Unauthenticated, got %v", err)
 	}
 }
+
+func TestTenantGuardStream(t *testing.T) {
+	it := NewTenantGuardStreamInterceptor(func(ctx context.Context, userID, tenantID string) (bool, error) { return userID == "u1", nil })
+	info := &grpc.StreamServerInfo{FullMethod: "/budget.v1.EventService/WatchTenantEvents", IsServerStream: true}
+	called := false
+	handler := func(srv interface{}, ss grpc.ServerStream) error { called = true; return nil }
+
+	member := &fakeServerStream{ctx: ctxutil.WithUserID(ctxutil.WithTenantID(context.Background(), "t1"), "u1")}
+	if err := it(nil, member, info, handler); err != nil || !called {
+		t.Fatalf("member should pass: %v", err)
+	}
+	called = false
+	stranger := &fakeServerStream{ctx: ctxutil.WithUserID(ctxutil.WithTenantID(context.Background(), "t1"), "u2")}
+	if err := it(nil, stranger, info, handler); status.Code(err) != codes.PermissionDenied || called {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+	if err := it(nil, &fakeServerStream{ctx: context.Background()}, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
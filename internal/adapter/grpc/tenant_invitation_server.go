@@ -0,0 +1,118 @@
+//go:build !ignore
+// +build !ignore
+
+package grpcadapter
+
+import (
+	"context"
+	"strings"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *TenantServer) CreateInvitation(ctx context.Context, req *budgetv1.CreateInvitationRequest) (*budgetv1.CreateInvitationResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, invalidArg("tenant_id is required")
+	}
+	if !strings.Contains(req.GetEmail(), "@") {
+		return nil, invalidArg("valid email is required")
+	}
+	inv, err := s.svc.CreateInvitation(ctx, ctxUserID(ctx), req.GetTenantId(), req.GetEmail(), tenantRoleFromPb(req.GetRole()))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.CreateInvitationResponse{Invitation: toPbInvitation(inv)}, nil
+}
+
+func (s *TenantServer) ListInvitations(ctx context.Context, req *budgetv1.ListInvitationsRequest) (*budgetv1.ListInvitationsResponse, error) {
+	var (
+		invs []domain.TenantInvitation
+		err  error
+	)
+	if req.GetTenantId() == "" {
+		invs, err = s.svc.ListMyInvitations(ctx, ctxUserID(ctx))
+	} else {
+		invs, err = s.svc.ListInvitations(ctx, ctxUserID(ctx), req.GetTenantId())
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.TenantInvitation, 0, len(invs))
+	for _, inv := range invs {
+		out = append(out, toPbInvitation(inv))
+	}
+	return &budgetv1.ListInvitationsResponse{Invitations: out}, nil
+}
+
+func (s *TenantServer) AcceptInvitation(ctx context.Context, req *budgetv1.AcceptInvitationRequest) (*budgetv1.AcceptInvitationResponse, error) {
+	if req.GetToken() == "" && req.GetInvitationId() == "" {
+		return nil, invalidArg("token or invitation_id is required")
+	}
+	m, err := s.svc.AcceptInvitation(ctx, ctxUserID(ctx), req.GetToken(), req.GetInvitationId())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.AcceptInvitationResponse{Membership: &budgetv1.TenantMembership{
+		Tenant:    &budgetv1.Tenant{Id: m.Tenant.ID, Name: m.Tenant.Name, Slug: m.Tenant.Slug, DefaultCurrencyCode: m.Tenant.DefaultCurrencyCode},
+		Role:      mapRole(string(m.Role)),
+		IsDefault: m.IsDefault,
+	}}, nil
+}
+
+func (s *TenantServer) DeclineInvitation(ctx context.Context, req *budgetv1.DeclineInvitationRequest) (*budgetv1.DeclineInvitationResponse, error) {
+	if req.GetToken() == "" && req.GetInvitationId() == "" {
+		return nil, invalidArg("token or invitation_id is required")
+	}
+	if err := s.svc.DeclineInvitation(ctx, ctxUserID(ctx), req.GetToken(), req.GetInvitationId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.DeclineInvitationResponse{}, nil
+}
+
+func (s *TenantServer) RevokeInvitation(ctx context.Context, req *budgetv1.RevokeInvitationRequest) (*budgetv1.RevokeInvitationResponse, error) {
+	if req.GetTenantId() == "" || req.GetInvitationId() == "" {
+		return nil, invalidArg("tenant_id and invitation_id are required")
+	}
+	if err := s.svc.RevokeInvitation(ctx, ctxUserID(ctx), req.GetTenantId(), req.GetInvitationId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.RevokeInvitationResponse{}, nil
+}
+
+func toPbInvitation(inv domain.TenantInvitation) *budgetv1.TenantInvitation {
+	out := &budgetv1.TenantInvitation{
+		Id:        inv.ID,
+		Tenant:    &budgetv1.Tenant{Id: inv.Tenant.ID, Name: inv.Tenant.Name, Slug: inv.Tenant.Slug, DefaultCurrencyCode: inv.Tenant.DefaultCurrencyCode},
+		Email:     inv.Email,
+		Role:      mapRole(string(inv.Role)),
+		Status:    invitationStatusToPb(inv.Status),
+		CreatedAt: timestamppb.New(inv.CreatedAt),
+		ExpiresAt: timestamppb.New(inv.ExpiresAt),
+	}
+	if inv.InvitedByID != "" {
+		out.InvitedBy = &budgetv1.User{Id: inv.InvitedByID, Name: inv.InvitedByName}
+	}
+	if inv.RespondedAt != nil {
+		out.RespondedAt = timestamppb.New(*inv.RespondedAt)
+	}
+	return out
+}
+
+func invitationStatusToPb(s domain.InvitationStatus) budgetv1.InvitationStatus {
+	switch s {
+	case domain.InvitationStatusPending:
+		return budgetv1.InvitationStatus_INVITATION_STATUS_PENDING
+	case domain.InvitationStatusAccepted:
+		return budgetv1.InvitationStatus_INVITATION_STATUS_ACCEPTED
+	case domain.InvitationStatusDeclined:
+		return budgetv1.InvitationStatus_INVITATION_STATUS_DECLINED
+	case domain.InvitationStatusRevoked:
+		return budgetv1.InvitationStatus_INVITATION_STATUS_REVOKED
+	case domain.InvitationStatusExpired:
+		return budgetv1.InvitationStatus_INVITATION_STATUS_EXPIRED
+	default:
+		return budgetv1.InvitationStatus_INVITATION_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,94 @@
+package grpcadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	useTenant "github.com/positron48/budget/internal/usecase/tenant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type invRepoStub struct {
+	inv      domain.TenantInvitation
+	accepted string
+}
+
+func (r *invRepoStub) CreateInvitation(ctx context.Context, inv domain.TenantInvitation, token string) (domain.TenantInvitation, error) {
+	inv.ID = "i1"
+	r.inv = inv
+	return inv, nil
+}
+func (r *invRepoStub) GetInvitation(ctx context.Context, id string) (domain.TenantInvitation, error) {
+	if id != r.inv.ID {
+		return domain.TenantInvitation{}, useTenant.ErrInvitationNotFound
+	}
+	return r.inv, nil
+}
+func (r *invRepoStub) GetInvitationByToken(ctx context.Context, token string) (domain.TenantInvitation, error) {
+	return r.inv, nil
+}
+func (r *invRepoStub) ListInvitations(ctx context.Context, tenantID string) ([]domain.TenantInvitation, error) {
+	return []domain.TenantInvitation{r.inv}, nil
+}
+func (r *invRepoStub) ListPendingInvitationsForEmail(ctx context.Context, email string) ([]domain.TenantInvitation, error) {
+	return []domain.TenantInvitation{r.inv}, nil
+}
+func (r *invRepoStub) AcceptInvitation(ctx context.Context, invitationID, userID string) (domain.TenantMembership, error) {
+	r.accepted = userID
+	return domain.TenantMembership{Tenant: r.inv.Tenant, Role: r.inv.Role}, nil
+}
+func (r *invRepoStub) SetInvitationStatus(ctx context.Context, invitationID string, st domain.InvitationStatus) error {
+	r.inv.Status = st
+	return nil
+}
+func (r *invRepoStub) IsMemberByEmail(ctx context.Context, tenantID, email string) (bool, error) {
+	return false, nil
+}
+func (r *invRepoStub) GetUserEmail(ctx context.Context, userID string) (string, bool, error) {
+	return "bob@example.com", true, nil
+}
+
+func TestTenantServer_Invitations(t *testing.T) {
+	inv := &invRepoStub{}
+	svc := useTenant.NewService(&tRepoStub{})
+	svc.SetInvitations(inv, nil, time.Hour)
+	srv := NewTenantServer(svc)
+	ctx := ctxutil.WithUserID(context.Background(), "u1")
+
+	if _, err := srv.CreateInvitation(ctx, &budgetv1.CreateInvitationRequest{TenantId: "t1", Email: "bob"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	created, err := srv.CreateInvitation(ctx, &budgetv1.CreateInvitationRequest{TenantId: "t1", Email: "bob@example.com", Role: budgetv1.TenantRole_TENANT_ROLE_ADMIN})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	got := created.GetInvitation()
+	if got.GetId() != "i1" || got.GetRole() != budgetv1.TenantRole_TENANT_ROLE_ADMIN || got.GetStatus() != budgetv1.InvitationStatus_INVITATION_STATUS_PENDING || got.GetInvitedBy().GetId() != "u1" {
+		t.Fatalf("unexpected invitation: %#v", got)
+	}
+	lst, err := srv.ListInvitations(ctx, &budgetv1.ListInvitationsRequest{})
+	if err != nil || len(lst.GetInvitations()) != 1 {
+		t.Fatalf("list mine: %v %#v", err, lst)
+	}
+	if _, err := srv.AcceptInvitation(ctx, &budgetv1.AcceptInvitationRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	acc, err := srv.AcceptInvitation(ctx, &budgetv1.AcceptInvitationRequest{InvitationId: "i1"})
+	if err != nil || acc.GetMembership().GetTenant().GetId() != "t1" || inv.accepted != "u1" {
+		t.Fatalf("accept: %v %#v", err, acc)
+	}
+	if _, err := srv.RevokeInvitation(ctx, &budgetv1.RevokeInvitationRequest{TenantId: "t1", InvitationId: "nope"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if _, err := srv.RevokeInvitation(ctx, &budgetv1.RevokeInvitationRequest{TenantId: "t1", InvitationId: "i1"}); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, err := srv.DeclineInvitation(ctx, &budgetv1.DeclineInvitationRequest{InvitationId: "i1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected failed precondition after revoke, got %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/positron48/budget/internal/domain"
 	"github.com/positron48/budget/internal/pkg/ctxutil"
 	"github.com/positron48/budget/internal/usecase/tenant"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type TenantServer struct {
@@ -20,6 +21,9 @@ type TenantServer struct {
 func NewTenantServer(svc *tenant.Service) *TenantServer { return &TenantServer{svc: svc} }
 
 func (s *TenantServer) CreateTenant(ctx context.Context, req *budgetv1.CreateTenantRequest) (*budgetv1.CreateTenantResponse, error) {
+	if err := checkCurrency("default_currency_code", req.GetDefaultCurrencyCode()); err != nil {
+		return nil, err
+	}
 	t, err := s.svc.CreateTenant(ctx, req.GetName(), req.GetSlug(), req.GetDefaultCurrencyCode(), ctxUserID(ctx))
 	if err != nil {
 		return nil, mapError(err)
@@ -34,12 +38,17 @@ func (s *TenantServer) ListMyTenants(ctx context.Context, _ *budgetv1.ListMyTena
 	}
 	out := make([]*budgetv1.TenantMembership, 0, len(ms))
 	for _, m := range ms {
-		out = append(out, &budgetv1.TenantMembership{Tenant: &budgetv1.Tenant{Id: m.Tenant.ID, Name: m.Tenant.Name, Slug: m.Tenant.Slug, DefaultCurrencyCode: m.Tenant.DefaultCurrencyCode}, Role: mapRole(string(m.Role)), IsDefault: m.IsDefault})
+		out = append(out, &budgetv1.TenantMembership{Tenant: toPbTenant(m.Tenant), Role: mapRole(string(m.Role)), IsDefault: m.IsDefault})
 	}
 	return &budgetv1.ListMyTenantsResponse{Memberships: out}, nil
 }
 
 func (s *TenantServer) UpdateTenant(ctx context.Context, req *budgetv1.UpdateTenantRequest) (*budgetv1.UpdateTenantResponse, error) {
+	if c := req.GetDefaultCurrencyCode(); c != "" {
+		if err := checkCurrency("default_currency_code", c); err != nil {
+			return nil, err
+		}
+	}
 	t, err := s.svc.UpdateTenant(ctx, ctxUserID(ctx), req.GetId(), req.GetName(), req.GetSlug(), req.GetDefaultCurrencyCode())
 	if err != nil {
 		return nil, mapError(err)
@@ -47,6 +56,19 @@ func (s *TenantServer) UpdateTenant(ctx context.Context, req *budgetv1.UpdateTen
 	return &budgetv1.UpdateTenantResponse{Tenant: &budgetv1.Tenant{Id: t.ID, Name: t.Name, Slug: t.Slug, DefaultCurrencyCode: t.DefaultCurrencyCode}}, nil
 }
 
+func (s *TenantServer) SetTenantCurrencies(ctx context.Context, req *budgetv1.SetTenantCurrenciesRequest) (*budgetv1.SetTenantCurrenciesResponse, error) {
+	for _, c := range req.GetCurrencyCodes() {
+		if err := checkCurrency("currency_codes", c); err != nil {
+			return nil, err
+		}
+	}
+	t, err := s.svc.SetCurrencies(ctx, ctxUserID(ctx), req.GetTenantId(), req.GetCurrencyCodes())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.SetTenantCurrenciesResponse{Tenant: toPbTenant(t)}, nil
+}
+
 func (s *TenantServer) ListMembers(ctx context.Context, req *budgetv1.ListMembersRequest) (*budgetv1.ListMembersResponse, error) {
 	ms, err := s.svc.ListMembers(ctx, ctxUserID(ctx), req.GetTenantId())
 	if err != nil {
@@ -86,6 +108,78 @@ func (s *TenantServer) RemoveMember(ctx context.Context, req *budgetv1.RemoveMem
 	return &budgetv1.RemoveMemberResponse{}, nil
 }
 
+func (s *TenantServer) TransferOwnership(ctx context.Context, req *budgetv1.TransferOwnershipRequest) (*budgetv1.TransferOwnershipResponse, error) {
+	if req.GetTenantId() == "" || req.GetNewOwnerUserId() == "" {
+		return nil, invalidArg("tenant_id and new_owner_user_id are required")
+	}
+	from, to, err := s.svc.TransferOwnership(ctx, ctxUserID(ctx), req.GetTenantId(), req.GetNewOwnerUserId())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.TransferOwnershipResponse{
+		PreviousOwner: &budgetv1.TenantMember{User: &budgetv1.User{Id: from.UserID, Email: from.UserEmail, Name: from.UserName}, Role: mapRole(string(from.Role)), IsDefault: from.IsDefault},
+		NewOwner:      &budgetv1.TenantMember{User: &budgetv1.User{Id: to.UserID, Email: to.UserEmail, Name: to.UserName}, Role: mapRole(string(to.Role)), IsDefault: to.IsDefault},
+	}, nil
+}
+
+func (s *TenantServer) LeaveTenant(ctx context.Context, req *budgetv1.LeaveTenantRequest) (*budgetv1.LeaveTenantResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, invalidArg("tenant_id is required")
+	}
+	if err := s.svc.LeaveTenant(ctx, ctxUserID(ctx), req.GetTenantId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.LeaveTenantResponse{}, nil
+}
+
+func (s *TenantServer) DeleteTenant(ctx context.Context, req *budgetv1.DeleteTenantRequest) (*budgetv1.DeleteTenantResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, invalidArg("tenant_id is required")
+	}
+	dr, err := s.svc.DeleteTenant(ctx, ctxUserID(ctx), req.GetTenantId(), req.GetConfirmationToken())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	resp := &budgetv1.DeleteTenantResponse{Tenant: toPbTenant(dr.Tenant)}
+	if dr.ConfirmationToken != "" {
+		resp.ConfirmationToken = dr.ConfirmationToken
+		resp.ConfirmationExpiresAt = timestamppb.New(dr.ConfirmationExpiresAt)
+	}
+	return resp, nil
+}
+
+func (s *TenantServer) CancelTenantDeletion(ctx context.Context, req *budgetv1.CancelTenantDeletionRequest) (*budgetv1.CancelTenantDeletionResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, invalidArg("tenant_id is required")
+	}
+	t, err := s.svc.CancelTenantDeletion(ctx, ctxUserID(ctx), req.GetTenantId())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.CancelTenantDeletionResponse{Tenant: toPbTenant(t)}, nil
+}
+
+func (s *TenantServer) SetDefaultTenant(ctx context.Context, req *budgetv1.SetDefaultTenantRequest) (*budgetv1.SetDefaultTenantResponse, error) {
+	if req.GetTenantId() == "" {
+		return nil, invalidArg("tenant_id is required")
+	}
+	if err := s.svc.SetDefaultTenant(ctx, ctxUserID(ctx), req.GetTenantId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.SetDefaultTenantResponse{}, nil
+}
+
+func toPbTenant(t domain.Tenant) *budgetv1.Tenant {
+	out := &budgetv1.Tenant{Id: t.ID, Name: t.Name, Slug: t.Slug, DefaultCurrencyCode: t.DefaultCurrencyCode, CurrencyCodes: t.CurrencyCodes}
+	if !t.CreatedAt.IsZero() {
+		out.CreatedAt = timestamppb.New(t.CreatedAt)
+	}
+	if t.DeletionScheduledAt != nil {
+		out.DeletionScheduledAt = timestamppb.New(*t.DeletionScheduledAt)
+	}
+	return out
+}
+
 func tenantRoleFromPb(r budgetv1.TenantRole) domain.TenantRole {
 	switch r {
 	case budgetv1.TenantRole_TENANT_ROLE_OWNER:
@@ -3,11 +3,14 @@ package grpcadapter
 import (
 	"context"
 	"testing"
+	"time"
 
 	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
 	"github.com/positron48/budget/internal/domain"
 	"github.com/positron48/budget/internal/pkg/ctxutil"
 	useTenant "github.com/positron48/budget/internal/usecase/tenant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type tRepoStub struct{ lastOwner string }
@@ -42,6 +45,33 @@ func (r *tRepoStub) GetUserRole(ctx context.Context, tenantID, userID string) (d
 	return domain.TenantRoleOwner, nil
 }
 
+func (r *tRepoStub) TransferOwnership(ctx context.Context, tenantID, fromUserID, toUserID string) (domain.TenantMembership, domain.TenantMembership, error) {
+	return domain.TenantMembership{Tenant: domain.Tenant{ID: tenantID}, Role: domain.TenantRoleAdmin, UserID: fromUserID},
+		domain.TenantMembership{Tenant: domain.Tenant{ID: tenantID}, Role: domain.TenantRoleOwner, UserID: toUserID}, nil
+}
+func (r *tRepoStub) CountOwners(ctx context.Context, tenantID string) (int, error) { return 1, nil }
+func (r *tRepoStub) SetDefaultTenant(ctx context.Context, userID, tenantID string) error {
+	return nil
+}
+func (r *tRepoStub) SetDeletionToken(ctx context.Context, tenantID, userID, token string, expiresAt time.Time) error {
+	return nil
+}
+func (r *tRepoStub) ScheduleDeletion(ctx context.Context, tenantID, token string, purgeAt time.Time) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID, DeletionScheduledAt: &purgeAt}, nil
+}
+func (r *tRepoStub) CancelDeletion(ctx context.Context, tenantID string) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID}, nil
+}
+func (r *tRepoStub) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
+	return domain.Tenant{ID: id}, nil
+}
+func (r *tRepoStub) SetCurrencies(ctx context.Context, tenantID string, codes []string) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID, CurrencyCodes: codes}, nil
+}
+func (r *tRepoStub) PurgeScheduledTenants(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
 func TestTenantServer_CreateAndList(t *testing.T) {
 	repo := &tRepoStub{}
 	svc := useTenant.NewService(repo)
@@ -58,3 +88,47 @@ func TestTenantServer_CreateAndList(t *testing.T) {
 		t.Fatalf("list: %v %#v", err, lst)
 	}
 }
+
+func TestTenantServer_DeleteTenantTwoStep(t *testing.T) {
+	srv := NewTenantServer(useTenant.NewService(&tRepoStub{}))
+	ctx := ctxutil.WithUserID(context.Background(), "u1")
+	if _, err := srv.DeleteTenant(ctx, &budgetv1.DeleteTenantRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	first, err := srv.DeleteTenant(ctx, &budgetv1.DeleteTenantRequest{TenantId: "t1"})
+	if err != nil || first.GetConfirmationToken() == "" || first.GetConfirmationExpiresAt() == nil {
+		t.Fatalf("first step: %v %#v", err, first)
+	}
+	second, err := srv.DeleteTenant(ctx, &budgetv1.DeleteTenantRequest{TenantId: "t1", ConfirmationToken: first.GetConfirmationToken()})
+	if err != nil || second.GetConfirmationToken() != "" || second.GetTenant().GetDeletionScheduledAt() == nil {
+		t.Fatalf("second step: %v %#v", err, second)
+	}
+	tr, err := srv.TransferOwnership(ctx, &budgetv1.TransferOwnershipRequest{TenantId: "t1", NewOwnerUserId: "u2"})
+	if err != nil || tr.GetNewOwner().GetRole() != budgetv1.TenantRole_TENANT_ROLE_OWNER || tr.GetPreviousOwner().GetRole() != budgetv1.TenantRole_TENANT_ROLE_ADMIN {
+		t.Fatalf("transfer: %v %#v", err, tr)
+	}
+	if _, err := srv.LeaveTenant(ctx, &budgetv1.LeaveTenantRequest{TenantId: "t1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("last owner leave: expected failed precondition, got %v", err)
+	}
+	if _, err := srv.SetDefaultTenant(ctx, &budgetv1.SetDefaultTenantRequest{TenantId: "t1"}); err != nil {
+		t.Fatalf("set default: %v", err)
+	}
+}
+
+func TestTenantServer_Currencies(t *testing.T) {
+	srv := NewTenantServer(useTenant.NewService(&tRepoStub{}))
+	ctx := ctxutil.WithUserID(context.Background(), "u1")
+	if _, err := srv.CreateTenant(ctx, &budgetv1.CreateTenantRequest{Name: "Home", DefaultCurrencyCode: "RUR"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("create with unknown currency: %v", err)
+	}
+	if _, err := srv.UpdateTenant(ctx, &budgetv1.UpdateTenantRequest{Id: "t1", DefaultCurrencyCode: "eur"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("update with lower case currency: %v", err)
+	}
+	out, err := srv.SetTenantCurrencies(ctx, &budgetv1.SetTenantCurrenciesRequest{TenantId: "t1", CurrencyCodes: []string{"USD", "EUR", "USD"}})
+	if err != nil || len(out.GetTenant().GetCurrencyCodes()) != 2 {
+		t.Fatalf("set currencies: %v %#v", err, out)
+	}
+	if _, err := srv.SetTenantCurrencies(ctx, &budgetv1.SetTenantCurrenciesRequest{TenantId: "t1", CurrencyCodes: []string{"XYZ"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unknown pinned currency: %v", err)
+	}
+}
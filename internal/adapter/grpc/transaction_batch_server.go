@@ -0,0 +1,125 @@
+//go:build !ignore
+// +build !ignore
+
+package grpcadapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	txusecase "github.com/positron48/budget/internal/usecase/transaction"
+	"google.golang.org/grpc/status"
+)
+
+func (s *TransactionServer) BatchCreateTransactions(ctx context.Context, req *budgetv1.BatchCreateTransactionsRequest) (*budgetv1.BatchCreateTransactionsResponse, error) {
+	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	items := make([]domain.Transaction, 0, len(req.GetItems()))
+	for i, it := range req.GetItems() {
+		if err := checkCurrency(fmt.Sprintf("items[%d].amount.currency_code", i), it.GetAmount().GetCurrencyCode()); err != nil {
+			return nil, err
+		}
+		occurredAt := time.Now()
+		if it.GetOccurredAt() != nil {
+			occurredAt = it.GetOccurredAt().AsTime()
+		}
+		items = append(items, domain.Transaction{
+			Type:            mapTxType(it.GetType()),
+			CategoryID:      it.GetCategoryId(),
+			Amount:          domain.Money{CurrencyCode: it.GetAmount().GetCurrencyCode(), MinorUnits: it.GetAmount().GetMinorUnits()},
+			OccurredAt:      occurredAt,
+			Comment:         it.GetComment(),
+			IsExtraordinary: it.GetIsExtraordinary(),
+		})
+	}
+	results, err := s.svc.BatchCreate(ctx, tenantID, userID, items)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out, ok, failed := toProtoBatchResults(results)
+	return &budgetv1.BatchCreateTransactionsResponse{Results: out, Succeeded: ok, Failed: failed}, nil
+}
+
+func (s *TransactionServer) BatchUpdateTransactions(ctx context.Context, req *budgetv1.BatchUpdateTransactionsRequest) (*budgetv1.BatchUpdateTransactionsResponse, error) {
+	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
+	var patch txusecase.BatchPatch
+	values := req.GetTransaction()
+	for _, p := range req.GetUpdateMask().GetPaths() {
+		switch p {
+		case "category_id":
+			v := values.GetCategoryId()
+			patch.CategoryID = &v
+		case "comment":
+			v := values.GetComment()
+			patch.Comment = &v
+		case "is_extraordinary":
+			v := values.GetIsExtraordinary()
+			patch.IsExtraordinary = &v
+		default:
+			return nil, invalidArg("unsupported update_mask path: " + p)
+		}
+	}
+	if patch == (txusecase.BatchPatch{}) {
+		return nil, invalidArg("update_mask is required")
+	}
+	sel, err := s.batchSelector(ctx, req.GetIds(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.svc.BatchUpdate(ctx, tenantID, sel, patch)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out, ok, failed := toProtoBatchResults(results)
+	return &budgetv1.BatchUpdateTransactionsResponse{Results: out, Succeeded: ok, Failed: failed}, nil
+}
+
+func (s *TransactionServer) BatchDeleteTransactions(ctx context.Context, req *budgetv1.BatchDeleteTransactionsRequest) (*budgetv1.BatchDeleteTransactionsResponse, error) {
+	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
+	sel, err := s.batchSelector(ctx, req.GetIds(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.svc.BatchDelete(ctx, tenantID, sel)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out, ok, failed := toProtoBatchResults(results)
+	return &budgetv1.BatchDeleteTransactionsResponse{Results: out, Succeeded: ok, Failed: failed}, nil
+}
+
+func (s *TransactionServer) batchSelector(ctx context.Context, ids []string, filter *budgetv1.ListTransactionsRequest) (txusecase.BatchSelector, error) {
+	sel := txusecase.BatchSelector{IDs: ids}
+	if filter != nil {
+		f, err := s.listFilter(ctx, filter)
+		if err != nil {
+			return txusecase.BatchSelector{}, err
+		}
+		sel.Filter = &f
+	}
+	return sel, nil
+}
+
+func toProtoBatchResults(results []txusecase.BatchResult) (out []*budgetv1.BatchTransactionResult, succeeded, failed int32) {
+	out = make([]*budgetv1.BatchTransactionResult, 0, len(results))
+	for _, r := range results {
+		item := &budgetv1.BatchTransactionResult{Id: r.ID}
+		if r.Err != nil {
+			st := status.Convert(mapError(r.Err))
+			item.Code = int32(st.Code())
+			item.Error = st.Message()
+			failed++
+		} else {
+			if r.Transaction.ID != "" {
+				item.Transaction = toProtoTx(r.Transaction)
+			}
+			succeeded++
+		}
+		out = append(out, item)
+	}
+	return out, succeeded, failed
+}
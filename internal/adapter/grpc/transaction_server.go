@@ -24,9 +24,17 @@ type TransactionServer struct {
 		Delete(ctx context.Context, id string) error
 		Get(ctx context.Context, id string) (domain.Transaction, error)
 		List(ctx context.Context, tenantID string, filter txusecase.ListFilter) ([]domain.Transaction, int64, error)
+		ListPage(ctx context.Context, tenantID string, filter txusecase.ListFilter, pageToken string) (txusecase.Page, error)
 		Totals(ctx context.Context, tenantID string, filter txusecase.ListFilter) (domain.Money, domain.Money, error)
 		CreateForUser(ctx context.Context, tenantID, userID string, txType domain.TransactionType, categoryID string, amount domain.Money, occurredAt time.Time, comment string, isExtraordinary bool) (domain.Transaction, error)
-	}
+		ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error)
+		Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error)
+		BatchCreate(ctx context.Context, tenantID, userID string, items []domain.Transaction) ([]txusecase.BatchResult, error)
+		BatchUpdate(ctx context.Context, tenantID string, sel txusecase.BatchSelector, patch txusecase.BatchPatch) ([]txusecase.BatchResult, error)
+		BatchDelete(ctx context.Context, tenantID string, sel txusecase.BatchSelector) ([]txusecase.BatchResult, error)
+	}
+	// views resolves view_id of list and totals requests (optional, see SetViews)
+	views ViewResolver
 }
 
 func NewTransactionServer(svc interface {
@@ -36,21 +44,30 @@ func NewTransactionServer(svc interface {
 	Delete(context.Context, string) error
 	Get(context.Context, string) (domain.Transaction, error)
 	List(context.Context, string, txusecase.ListFilter) ([]domain.Transaction, int64, error)
+	ListPage(context.Context, string, txusecase.ListFilter, string) (txusecase.Page, error)
 	Totals(context.Context, string, txusecase.ListFilter) (domain.Money, domain.Money, error)
 	CreateForUser(context.Context, string, string, domain.TransactionType, string, domain.Money, time.Time, string, bool) (domain.Transaction, error)
+	ListDeleted(context.Context, string, int, int) ([]domain.Transaction, int64, error)
+	Restore(context.Context, string, string) (domain.Transaction, error)
+	BatchCreate(context.Context, string, string, []domain.Transaction) ([]txusecase.BatchResult, error)
+	BatchUpdate(context.Context, string, txusecase.BatchSelector, txusecase.BatchPatch) ([]txusecase.BatchResult, error)
+	BatchDelete(context.Context, string, txusecase.BatchSelector) ([]txusecase.BatchResult, error)
 },
 ) *TransactionServer {
 	return &TransactionServer{svc: svc}
 }
 
+// SetViews enables view_id in ListTransactions, GetTransactionsTotals and batch filters.
+func (s *TransactionServer) SetViews(views ViewResolver) { s.views = views }
+
 func (s *TransactionServer) CreateTransaction(ctx context.Context, req *budgetv1.CreateTransactionRequest) (*budgetv1.CreateTransactionResponse, error) {
 	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
 	userID, _ := ctxutil.UserIDFromContext(ctx)
 	if req.GetCategoryId() == "" {
 		return nil, invalidArg("category_id is required")
 	}
-	if req.GetAmount() == nil || req.GetAmount().GetCurrencyCode() == "" {
-		return nil, invalidArg("amount.currency_code is required")
+	if err := checkCurrency("amount.currency_code", req.GetAmount().GetCurrencyCode()); err != nil {
+		return nil, err
 	}
 	amount := domain.Money{CurrencyCode: req.GetAmount().GetCurrencyCode(), MinorUnits: req.GetAmount().GetMinorUnits()}
 	occurredAt := time.Now()
@@ -71,6 +88,13 @@ func (s *TransactionServer) UpdateTransaction(ctx context.Context, req *budgetv1
 	}
 	patch := req.GetTransaction()
 	mask := req.GetUpdateMask()
+	for _, p := range mask.GetPaths() {
+		if p == "amount" {
+			if err := checkCurrency("transaction.amount.currency_code", patch.GetAmount().GetCurrencyCode()); err != nil {
+				return nil, err
+			}
+		}
+	}
 	applyFieldMask(&current, patch, mask)
 	if maskAffectsBase(mask) {
 		base, fx, err := s.svc.ComputeBaseAmount(ctx, current.TenantID, current.Amount, current.OccurredAt)
@@ -104,7 +128,64 @@ func (s *TransactionServer) GetTransaction(ctx context.Context, req *budgetv1.Ge
 
 func (s *TransactionServer) ListTransactions(ctx context.Context, req *budgetv1.ListTransactionsRequest) (*budgetv1.ListTransactionsResponse, error) {
 	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
-	var f txusecase.ListFilter
+	if c := req.GetCurrencyCode(); c != "" {
+		if err := checkCurrency("currency_code", c); err != nil {
+			return nil, err
+		}
+	}
+	f, err := s.listFilter(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetPage() != nil {
+		f.Page = int(req.GetPage().GetPage())
+		f.PageSize = int(req.GetPage().GetPageSize())
+		if req.GetPage().GetSort() != "" {
+			f.Sort = req.GetPage().GetSort()
+		}
+	}
+	f.SkipCount = req.GetSkipTotalCount()
+	p, err := s.svc.ListPage(ctx, tenantID, f, req.GetPageToken())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.Transaction, 0, len(p.Items))
+	for _, it := range p.Items {
+		out = append(out, toProtoTx(it))
+	}
+	pageSize := int32(f.PageSize)
+	if pageSize == 0 {
+		pageSize = 50
+	}
+	page := &budgetv1.PageResponse{Page: int32(f.Page), PageSize: pageSize}
+	if req.GetPageToken() != "" {
+		page.Page = 0
+	}
+	if p.Total >= 0 {
+		page.TotalItems = p.Total
+		page.TotalPages = (int32(p.Total) + pageSize - 1) / pageSize
+	}
+	return &budgetv1.ListTransactionsResponse{Transactions: out, Page: page, NextPageToken: p.NextPageToken}, nil
+}
+
+// listFilter converts the filter part of a list request (everything but the page) on top of
+// its saved view; the view's sort applies unless page.sort is set. Errors are gRPC statuses.
+func (s *TransactionServer) listFilter(ctx context.Context, req *budgetv1.ListTransactionsRequest) (txusecase.ListFilter, error) {
+	f, sort, err := resolveView(ctx, s.views, req.GetViewId())
+	if err != nil {
+		return txusecase.ListFilter{}, err
+	}
+	f.Sort = sort
+	f, err = listFilterFromRequest(f, req)
+	if err != nil {
+		return txusecase.ListFilter{}, mapError(err)
+	}
+	return f, nil
+}
+
+// listFilterFromRequest overlays the filter fields set in a list request on base
+func listFilterFromRequest(base txusecase.ListFilter, req *budgetv1.ListTransactionsRequest) (txusecase.ListFilter, error) {
+	f := base
 	if dr := req.GetDateRange(); dr != nil {
 		if dr.GetFrom() != nil {
 			t := dr.GetFrom().AsTime()
@@ -115,7 +196,9 @@ func (s *TransactionServer) ListTransactions(ctx context.Context, req *budgetv1.
 			f.To = &t
 		}
 	}
-	f.CategoryIDs = req.GetCategoryIds()
+	if len(req.GetCategoryIds()) > 0 {
+		f.CategoryIDs = req.GetCategoryIds()
+	}
 	if req.GetType() != budgetv1.TransactionType_TRANSACTION_TYPE_UNSPECIFIED {
 		tt := mapTxType(req.GetType())
 		f.Type = &tt
@@ -136,32 +219,18 @@ func (s *TransactionServer) ListTransactions(ctx context.Context, req *budgetv1.
 		v := req.GetSearch()
 		f.Search = &v
 	}
-	if req.GetPage() != nil {
-		f.Page = int(req.GetPage().GetPage())
-		f.PageSize = int(req.GetPage().GetPageSize())
-		if req.GetPage().GetSort() != "" {
-			f.Sort = req.GetPage().GetSort()
-		}
-	}
-	items, total, err := s.svc.List(ctx, tenantID, f)
-	if err != nil {
-		return nil, mapError(err)
-	}
-	out := make([]*budgetv1.Transaction, 0, len(items))
-	for _, it := range items {
-		out = append(out, toProtoTx(it))
-	}
-	pageSize := int32(f.PageSize)
-	if pageSize == 0 {
-		pageSize = 50
+	if err := txusecase.ApplyQuery(&f, req.GetQuery()); err != nil {
+		return txusecase.ListFilter{}, err
 	}
-	totalPages := (int32(total) + pageSize - 1) / pageSize
-	return &budgetv1.ListTransactionsResponse{Transactions: out, Page: &budgetv1.PageResponse{Page: int32(f.Page), PageSize: pageSize, TotalItems: total, TotalPages: totalPages}}, nil
+	return f, nil
 }
 
 func (s *TransactionServer) GetTransactionsTotals(ctx context.Context, req *budgetv1.GetTransactionsTotalsRequest) (*budgetv1.GetTransactionsTotalsResponse, error) {
 	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
-	var f txusecase.ListFilter
+	f, _, err := resolveView(ctx, s.views, req.GetViewId())
+	if err != nil {
+		return nil, err
+	}
 	if dr := req.GetDateRange(); dr != nil {
 		if dr.GetFrom() != nil {
 			t := dr.GetFrom().AsTime()
@@ -172,7 +241,9 @@ func (s *TransactionServer) GetTransactionsTotals(ctx context.Context, req *budg
 			f.To = &t
 		}
 	}
-	f.CategoryIDs = req.GetCategoryIds()
+	if len(req.GetCategoryIds()) > 0 {
+		f.CategoryIDs = req.GetCategoryIds()
+	}
 	if req.GetType() != budgetv1.TransactionType_TRANSACTION_TYPE_UNSPECIFIED {
 		tt := mapTxType(req.GetType())
 		f.Type = &tt
@@ -186,6 +257,9 @@ func (s *TransactionServer) GetTransactionsTotals(ctx context.Context, req *budg
 		f.MaxMinorUnits = &v
 	}
 	if req.GetCurrencyCode() != "" {
+		if err := checkCurrency("currency_code", req.GetCurrencyCode()); err != nil {
+			return nil, err
+		}
 		v := req.GetCurrencyCode()
 		f.CurrencyCode = &v
 	}
@@ -193,6 +267,9 @@ func (s *TransactionServer) GetTransactionsTotals(ctx context.Context, req *budg
 		v := req.GetSearch()
 		f.Search = &v
 	}
+	if err := txusecase.ApplyQuery(&f, req.GetQuery()); err != nil {
+		return nil, mapError(err)
+	}
 
 	inc, exp, err := s.svc.Totals(ctx, tenantID, f)
 	if err != nil {
@@ -204,6 +281,40 @@ func (s *TransactionServer) GetTransactionsTotals(ctx context.Context, req *budg
 	}, nil
 }
 
+func (s *TransactionServer) ListDeletedTransactions(ctx context.Context, req *budgetv1.ListDeletedTransactionsRequest) (*budgetv1.ListDeletedTransactionsResponse, error) {
+	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
+	page := req.GetPage().GetPage()
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.GetPage().GetPageSize()
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 50
+	}
+	items, total, err := s.svc.ListDeleted(ctx, tenantID, int(page), int(pageSize))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.Transaction, 0, len(items))
+	for _, it := range items {
+		out = append(out, toProtoTx(it))
+	}
+	totalPages := (int32(total) + pageSize - 1) / pageSize
+	return &budgetv1.ListDeletedTransactionsResponse{Transactions: out, Page: &budgetv1.PageResponse{Page: page, PageSize: pageSize, TotalItems: total, TotalPages: totalPages}}, nil
+}
+
+func (s *TransactionServer) RestoreTransaction(ctx context.Context, req *budgetv1.RestoreTransactionRequest) (*budgetv1.RestoreTransactionResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	tenantID, _ := ctxutil.TenantIDFromContext(ctx)
+	t, err := s.svc.Restore(ctx, tenantID, req.GetId())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.RestoreTransactionResponse{Transaction: toProtoTx(t)}, nil
+}
+
 func toProtoTx(t domain.Transaction) *budgetv1.Transaction {
 	var fx *budgetv1.FxInfo
 	if t.Fx != nil {
@@ -228,7 +339,15 @@ func toProtoTx(t domain.Transaction) *budgetv1.Transaction {
 		Comment:         t.Comment,
 		CreatedAt:       timestamppb.New(t.CreatedAt),
 		IsExtraordinary: t.IsExtraordinary,
+		DeletedAt:       optTimestamp(t.DeletedAt),
+	}
+}
+
+func optTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
 	}
+	return timestamppb.New(*t)
 }
 
 func mapTxType(t budgetv1.TransactionType) domain.TransactionType {
@@ -10,6 +10,8 @@ import (
 	"github.com/positron48/budget/internal/domain"
 	"github.com/positron48/budget/internal/pkg/ctxutil"
 	txuse "github.com/positron48/budget/internal/usecase/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -19,6 +21,8 @@ type txSvcStub struct {
 	err   error
 	items []domain.Transaction
 	total int64
+	next  string
+	batch []txuse.BatchResult
 }
 
 func (s txSvcStub) ComputeBaseAmount(ctx context.Context, tenantID string, amount domain.Money, occurredAt time.Time) (domain.Money, *domain.FxInfo, error) {
@@ -41,6 +45,34 @@ func (s txSvcStub) List(ctx context.Context, tenantID string, filter txuse.ListF
 	return s.items, s.total, s.err
 }
 
+func (s txSvcStub) ListPage(ctx context.Context, tenantID string, filter txuse.ListFilter, pageToken string) (txuse.Page, error) {
+	p := txuse.Page{Items: s.items, Total: s.total, NextPageToken: s.next}
+	if filter.SkipCount {
+		p.Total = -1
+	}
+	return p, s.err
+}
+
+func (s txSvcStub) ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error) {
+	return s.items, s.total, s.err
+}
+
+func (s txSvcStub) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	return s.tx, s.err
+}
+
+func (s txSvcStub) BatchCreate(ctx context.Context, tenantID, userID string, items []domain.Transaction) ([]txuse.BatchResult, error) {
+	return s.batch, s.err
+}
+
+func (s txSvcStub) BatchUpdate(ctx context.Context, tenantID string, sel txuse.BatchSelector, patch txuse.BatchPatch) ([]txuse.BatchResult, error) {
+	return s.batch, s.err
+}
+
+func (s txSvcStub) BatchDelete(ctx context.Context, tenantID string, sel txuse.BatchSelector) ([]txuse.BatchResult, error) {
+	return s.batch, s.err
+}
+
 func (s txSvcStub) Totals(ctx context.Context, tenantID string, filter txuse.ListFilter) (domain.Money, domain.Money, error) {
 	return domain.Money{CurrencyCode: "RUB", MinorUnits: 0}, domain.Money{CurrencyCode: "RUB", MinorUnits: 0}, s.err
 }
@@ -71,6 +103,10 @@ func (txSvcBaseErr) List(ctx context.Context, tenantID string, filter txuse.List
 	return nil, 0, nil
 }
 
+func (txSvcBaseErr) ListPage(ctx context.Context, tenantID string, filter txuse.ListFilter, pageToken string) (txuse.Page, error) {
+	return txuse.Page{}, nil
+}
+
 func (txSvcBaseErr) Totals(ctx context.Context, tenantID string, filter txuse.ListFilter) (domain.Money, domain.Money, error) {
 	return domain.Money{}, domain.Money{}, errors.New("boom")
 }
@@ -79,6 +115,26 @@ func (txSvcBaseErr) CreateForUser(ctx context.Context, tenantID, userID string,
 	return domain.Transaction{}, nil
 }
 
+func (txSvcBaseErr) ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error) {
+	return nil, 0, nil
+}
+
+func (txSvcBaseErr) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	return domain.Transaction{ID: id, TenantID: tenantID}, nil
+}
+
+func (txSvcBaseErr) BatchCreate(ctx context.Context, tenantID, userID string, items []domain.Transaction) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
+func (txSvcBaseErr) BatchUpdate(ctx context.Context, tenantID string, sel txuse.BatchSelector, patch txuse.BatchPatch) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
+func (txSvcBaseErr) BatchDelete(ctx context.Context, tenantID string, sel txuse.BatchSelector) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
 func TestTransactionServer_MapError(t *testing.T) {
 	stubErr := txSvcStub{err: errors.New("boom")}
 	srv := NewTransactionServer(stubErr)
@@ -166,6 +222,10 @@ func (txSvcEcho) List(ctx context.Context, tenantID string, filter txuse.ListFil
 	return nil, 0, nil
 }
 
+func (txSvcEcho) ListPage(ctx context.Context, tenantID string, filter txuse.ListFilter, pageToken string) (txuse.Page, error) {
+	return txuse.Page{}, nil
+}
+
 func (txSvcEcho) Totals(ctx context.Context, tenantID string, filter txuse.ListFilter) (domain.Money, domain.Money, error) {
 	return domain.Money{CurrencyCode: "EUR", MinorUnits: 100}, domain.Money{CurrencyCode: "EUR", MinorUnits: 50}, nil
 }
@@ -174,6 +234,26 @@ func (txSvcEcho) CreateForUser(ctx context.Context, tenantID, userID string, txT
 	return domain.Transaction{ID: "tx"}, nil
 }
 
+func (txSvcEcho) ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error) {
+	return nil, 0, nil
+}
+
+func (txSvcEcho) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	return domain.Transaction{ID: id, TenantID: tenantID}, nil
+}
+
+func (txSvcEcho) BatchCreate(ctx context.Context, tenantID, userID string, items []domain.Transaction) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
+func (txSvcEcho) BatchUpdate(ctx context.Context, tenantID string, sel txuse.BatchSelector, patch txuse.BatchPatch) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
+func (txSvcEcho) BatchDelete(ctx context.Context, tenantID string, sel txuse.BatchSelector) ([]txuse.BatchResult, error) {
+	return nil, nil
+}
+
 func TestTransactionServer_Update_FxIncluded(t *testing.T) {
 	cur := domain.Transaction{ID: "tx1", TenantID: "t1", Amount: domain.Money{CurrencyCode: "USD", MinorUnits: 150}, OccurredAt: time.Now()}
 	srv := NewTransactionServer(txSvcEcho{cur: cur})
@@ -218,6 +298,37 @@ func TestTransactionServer_List_DefaultPaging(t *testing.T) {
 	}
 }
 
+func TestTransactionServer_List_Cursor(t *testing.T) {
+	srv := NewTransactionServer(txSvcStub{items: []domain.Transaction{{ID: "a"}}, total: 7, next: "tok"})
+	out, err := srv.ListTransactions(context.Background(), &budgetv1.ListTransactionsRequest{PageToken: "prev", SkipTotalCount: true, Page: &budgetv1.PageRequest{Page: 3, PageSize: 1}})
+	if err != nil || out.GetNextPageToken() != "tok" {
+		t.Fatalf("list: %v %#v", err, out)
+	}
+	if p := out.GetPage(); p.GetPage() != 0 || p.GetTotalItems() != 0 || p.GetTotalPages() != 0 || p.GetPageSize() != 1 {
+		t.Fatalf("unexpected paging: %#v", p)
+	}
+	if _, err := NewTransactionServer(txSvcStub{err: txuse.ErrInvalidPageToken}).ListTransactions(context.Background(), &budgetv1.ListTransactionsRequest{PageToken: "x"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad token: %v", err)
+	}
+}
+
+func TestTransactionServer_List_Query(t *testing.T) {
+	srv := NewTransactionServer(txSvcStub{})
+	ctx := ctxutil.WithTenantID(context.Background(), "t1")
+	if _, err := srv.ListTransactions(ctx, &budgetv1.ListTransactionsRequest{Query: `category:food amount>1000 "coffee"`}); err != nil {
+		t.Fatalf("list with query: %v", err)
+	}
+	if _, err := srv.ListTransactions(ctx, &budgetv1.ListTransactionsRequest{Query: "before:tomorrow"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad query: %v", err)
+	}
+	if _, err := srv.GetTransactionsTotals(ctx, &budgetv1.GetTransactionsTotalsRequest{Query: "amount<x"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad totals query: %v", err)
+	}
+	if _, err := srv.BatchDeleteTransactions(ctx, &budgetv1.BatchDeleteTransactionsRequest{Filter: &budgetv1.ListTransactionsRequest{Query: "type:transfer"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad batch query: %v", err)
+	}
+}
+
 func TestTransactionServer_List_WithFilters(t *testing.T) {
 	srv := NewTransactionServer(txSvcStub{})
 	now := time.Now()
@@ -282,3 +393,72 @@ func TestTransactionServer_Totals(t *testing.T) {
 }
 
 // Further server tests can be added by refactoring server to accept an interface in constructor
+
+func TestTransactionServer_Trash(t *testing.T) {
+	deletedAt := time.Now()
+	srv := NewTransactionServer(txSvcStub{
+		tx:    domain.Transaction{ID: "tx1"},
+		items: []domain.Transaction{{ID: "tx1", DeletedAt: &deletedAt}},
+		total: 1,
+	})
+	ctx := ctxutil.WithTenantID(context.Background(), "t1")
+	lst, err := srv.ListDeletedTransactions(ctx, &budgetv1.ListDeletedTransactionsRequest{})
+	if err != nil || len(lst.GetTransactions()) != 1 || lst.GetTransactions()[0].GetDeletedAt() == nil {
+		t.Fatalf("list deleted: %v %#v", err, lst)
+	}
+	if lst.GetPage().GetPageSize() != 50 || lst.GetPage().GetTotalPages() != 1 {
+		t.Fatalf("unexpected page: %#v", lst.GetPage())
+	}
+	if _, err := srv.RestoreTransaction(ctx, &budgetv1.RestoreTransactionRequest{}); err == nil {
+		t.Fatal("expected invalid argument for empty id")
+	}
+	out, err := srv.RestoreTransaction(ctx, &budgetv1.RestoreTransactionRequest{Id: "tx1"})
+	if err != nil || out.GetTransaction().GetId() != "tx1" || out.GetTransaction().GetDeletedAt() != nil {
+		t.Fatalf("restore: %v %#v", err, out)
+	}
+}
+
+func TestTransactionServer_Batch(t *testing.T) {
+	srv := NewTransactionServer(txSvcStub{batch: []txuse.BatchResult{
+		{ID: "tx1", Transaction: domain.Transaction{ID: "tx1"}},
+		{ID: "tx2", Err: txuse.ErrTypeMismatch},
+		{ID: "tx3", Err: txuse.ErrTransactionNotFound},
+	}})
+	ctx := ctxutil.WithTenantID(context.Background(), "t1")
+
+	if _, err := srv.BatchUpdateTransactions(ctx, &budgetv1.BatchUpdateTransactionsRequest{Ids: []string{"tx1"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("empty mask: %v", err)
+	}
+	if _, err := srv.BatchUpdateTransactions(ctx, &budgetv1.BatchUpdateTransactionsRequest{Ids: []string{"tx1"}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"amount"}}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unsupported path: %v", err)
+	}
+	up, err := srv.BatchUpdateTransactions(ctx, &budgetv1.BatchUpdateTransactionsRequest{
+		Ids:         []string{"tx1", "tx2", "tx3"},
+		Transaction: &budgetv1.Transaction{CategoryId: "c2"},
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"category_id"}},
+	})
+	if err != nil || up.GetSucceeded() != 1 || up.GetFailed() != 2 {
+		t.Fatalf("batch update: %v %#v", err, up)
+	}
+	res := up.GetResults()
+	if res[0].GetTransaction().GetId() != "tx1" || res[1].GetCode() != int32(codes.InvalidArgument) || res[2].GetCode() != int32(codes.NotFound) {
+		t.Fatalf("unexpected results: %#v", res)
+	}
+
+	del, err := srv.BatchDeleteTransactions(ctx, &budgetv1.BatchDeleteTransactionsRequest{Filter: &budgetv1.ListTransactionsRequest{}})
+	if err != nil || del.GetSucceeded() != 1 {
+		t.Fatalf("batch delete: %v %#v", err, del)
+	}
+	cr, err := srv.BatchCreateTransactions(ctx, &budgetv1.BatchCreateTransactionsRequest{Items: []*budgetv1.CreateTransactionRequest{{CategoryId: "c1", Amount: &budgetv1.Money{CurrencyCode: "RUB", MinorUnits: 100}}}})
+	if err != nil || len(cr.GetResults()) != 3 {
+		t.Fatalf("batch create: %v %#v", err, cr)
+	}
+	if _, err := srv.BatchCreateTransactions(ctx, &budgetv1.BatchCreateTransactionsRequest{Items: []*budgetv1.CreateTransactionRequest{{CategoryId: "c1", Amount: &budgetv1.Money{CurrencyCode: "RUR", MinorUnits: 100}}}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unknown currency: %v", err)
+	}
+
+	errSrv := NewTransactionServer(txSvcStub{err: txuse.ErrBatchTooLarge})
+	if _, err := errSrv.BatchDeleteTransactions(ctx, &budgetv1.BatchDeleteTransactionsRequest{Ids: []string{"x"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("too large: %v", err)
+	}
+}
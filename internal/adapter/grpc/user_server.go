@@ -5,6 +5,7 @@ package grpcadapter
 
 import (
 	"context"
+	"time"
 
 	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
 	"github.com/positron48/budget/internal/domain"
@@ -46,12 +47,173 @@ func (s *UserServer) UpdateProfile(ctx context.Context, req *budgetv1.UpdateProf
 
 func (s *UserServer) ChangePassword(ctx context.Context, req *budgetv1.ChangePasswordRequest) (*budgetv1.ChangePasswordResponse, error) {
 	userID, _ := ctxutil.UserIDFromContext(ctx)
-	if err := s.svc.ChangePassword(ctx, userID, req.GetCurrentPassword(), req.GetNewPassword(), s.forGetPwd); err != nil {
+	if err := s.svc.ChangePassword(ctx, userID, req.GetCurrentPassword(), req.GetNewPassword(), req.GetRefreshToken(), s.forGetPwd); err != nil {
 		return nil, mapError(err)
 	}
 	return &budgetv1.ChangePasswordResponse{}, nil
 }
 
+func (s *UserServer) ListSessions(ctx context.Context, req *budgetv1.ListSessionsRequest) (*budgetv1.ListSessionsResponse, error) {
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	list, err := s.svc.ListSessions(ctx, userID, req.GetRefreshToken())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.Session, 0, len(list))
+	for _, ss := range list {
+		out = append(out, &budgetv1.Session{
+			Id:         ss.ID,
+			TenantId:   ss.TenantID,
+			UserAgent:  ss.UserAgent,
+			Ip:         ss.IP,
+			CreatedAt:  timestamppb.New(ss.CreatedAt),
+			LastUsedAt: timestamppb.New(ss.LastUsedAt),
+			ExpiresAt:  timestamppb.New(ss.ExpiresAt),
+			Current:    ss.Current,
+		})
+	}
+	return &budgetv1.ListSessionsResponse{Sessions: out}, nil
+}
+
+func (s *UserServer) RevokeSession(ctx context.Context, req *budgetv1.RevokeSessionRequest) (*budgetv1.RevokeSessionResponse, error) {
+	if req.GetSessionId() == "" {
+		return nil, invalidArg("session_id is required")
+	}
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	if err := s.svc.RevokeSession(ctx, userID, req.GetSessionId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.RevokeSessionResponse{}, nil
+}
+
+func (s *UserServer) RevokeAllOtherSessions(ctx context.Context, req *budgetv1.RevokeAllOtherSessionsRequest) (*budgetv1.RevokeAllOtherSessionsResponse, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, invalidArg("refresh_token is required")
+	}
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	n, err := s.svc.RevokeAllOtherSessions(ctx, userID, req.GetRefreshToken())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.RevokeAllOtherSessionsResponse{RevokedCount: n}, nil
+}
+
+func (s *UserServer) EnrollTOTP(ctx context.Context, _ *budgetv1.EnrollTOTPRequest) (*budgetv1.EnrollTOTPResponse, error) {
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	secret, uri, err := s.svc.EnrollTOTP(ctx, userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.EnrollTOTPResponse{Secret: secret, OtpauthUri: uri}, nil
+}
+
+func (s *UserServer) ConfirmTOTP(ctx context.Context, req *budgetv1.ConfirmTOTPRequest) (*budgetv1.ConfirmTOTPResponse, error) {
+	if req.GetCode() == "" {
+		return nil, invalidArg("code is required")
+	}
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	codes, err := s.svc.ConfirmTOTP(ctx, userID, req.GetCode())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.ConfirmTOTPResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *UserServer) DisableTOTP(ctx context.Context, req *budgetv1.DisableTOTPRequest) (*budgetv1.DisableTOTPResponse, error) {
+	if req.GetCode() == "" {
+		return nil, invalidArg("code is required")
+	}
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	if err := s.svc.DisableTOTP(ctx, userID, req.GetCode()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.DisableTOTPResponse{}, nil
+}
+
+func (s *UserServer) CreateApiToken(ctx context.Context, req *budgetv1.CreateApiTokenRequest) (*budgetv1.CreateApiTokenResponse, error) {
+	if req.GetName() == "" {
+		return nil, invalidArg("name is required")
+	}
+	scope, ok := fromProtoAPITokenScope(req.GetScope())
+	if !ok {
+		return nil, invalidArg("scope is required")
+	}
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	tenantID := req.GetTenantId()
+	if tenantID == "" {
+		tenantID, _ = ctxutil.TenantIDFromContext(ctx)
+	}
+	if tenantID == "" {
+		return nil, invalidArg("tenant_id is required")
+	}
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != nil {
+		t := req.GetExpiresAt().AsTime()
+		expiresAt = &t
+	}
+	t, token, err := s.svc.CreateAPIToken(ctx, userID, tenantID, req.GetName(), scope, expiresAt)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.CreateApiTokenResponse{ApiToken: toProtoAPIToken(t), Token: token}, nil
+}
+
+func (s *UserServer) ListApiTokens(ctx context.Context, _ *budgetv1.ListApiTokensRequest) (*budgetv1.ListApiTokensResponse, error) {
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	list, err := s.svc.ListAPITokens(ctx, userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.ApiToken, 0, len(list))
+	for _, t := range list {
+		out = append(out, toProtoAPIToken(t))
+	}
+	return &budgetv1.ListApiTokensResponse{ApiTokens: out}, nil
+}
+
+func (s *UserServer) RevokeApiToken(ctx context.Context, req *budgetv1.RevokeApiTokenRequest) (*budgetv1.RevokeApiTokenResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	if err := s.svc.RevokeAPIToken(ctx, userID, req.GetId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.RevokeApiTokenResponse{}, nil
+}
+
+func fromProtoAPITokenScope(s budgetv1.ApiTokenScope) (domain.APITokenScope, bool) {
+	switch s {
+	case budgetv1.ApiTokenScope_API_TOKEN_SCOPE_READ:
+		return domain.APITokenScopeRead, true
+	case budgetv1.ApiTokenScope_API_TOKEN_SCOPE_READ_WRITE:
+		return domain.APITokenScopeReadWrite, true
+	default:
+		return "", false
+	}
+}
+
+func toProtoAPIToken(t domain.APIToken) *budgetv1.ApiToken {
+	out := &budgetv1.ApiToken{
+		Id:          t.ID,
+		Name:        t.Name,
+		TenantId:    t.TenantID,
+		Scope:       budgetv1.ApiTokenScope_API_TOKEN_SCOPE_READ,
+		TokenPrefix: t.Prefix,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+	}
+	if t.Scope == domain.APITokenScopeReadWrite {
+		out.Scope = budgetv1.ApiTokenScope_API_TOKEN_SCOPE_READ_WRITE
+	}
+	if t.LastUsedAt != nil {
+		out.LastUsedAt = timestamppb.New(*t.LastUsedAt)
+	}
+	if t.ExpiresAt != nil {
+		out.ExpiresAt = timestamppb.New(*t.ExpiresAt)
+	}
+	return out
+}
+
 func toProtoUser(u domain.User) *budgetv1.User {
 	var updated *timestamppb.Timestamp
 	if u.UpdatedAt != nil {
@@ -68,3 +230,38 @@ func toProtoUser(u domain.User) *budgetv1.User {
 		UpdatedAt:     updated,
 	}
 }
+
+func (s *UserServer) ListLoginAttempts(ctx context.Context, req *budgetv1.ListLoginAttemptsRequest) (*budgetv1.ListLoginAttemptsResponse, error) {
+	userID, _ := ctxutil.UserIDFromContext(ctx)
+	list, err := s.svc.ListLoginAttempts(ctx, userID, int(req.GetLimit()))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.LoginAttempt, 0, len(list))
+	for _, a := range list {
+		out = append(out, &budgetv1.LoginAttempt{
+			Id:        a.ID,
+			Method:    a.Method,
+			Status:    toProtoLoginAttemptStatus(a.Status),
+			Ip:        a.IPAddress,
+			UserAgent: a.UserAgent,
+			CreatedAt: timestamppb.New(a.CreatedAt),
+		})
+	}
+	return &budgetv1.ListLoginAttemptsResponse{Attempts: out}, nil
+}
+
+func toProtoLoginAttemptStatus(s domain.LoginAttemptStatus) budgetv1.LoginAttemptStatus {
+	switch s {
+	case domain.LoginAttemptSuccess:
+		return budgetv1.LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_SUCCESS
+	case domain.LoginAttemptFailed:
+		return budgetv1.LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_FAILED
+	case domain.LoginAttemptBlocked:
+		return budgetv1.LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_BLOCKED
+	case domain.LoginAttemptChallenged:
+		return budgetv1.LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_TWO_FACTOR_REQUIRED
+	default:
+		return budgetv1.LoginAttemptStatus_LOGIN_ATTEMPT_STATUS_UNSPECIFIED
+	}
+}
@@ -1,8 +1,20 @@
 package grpcadapter
 
 import (
+	"github.com/positron48/budget/internal/domain"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func invalidArg(msg string) error { return status.Error(codes.InvalidArgument, msg) }
+
+// checkCurrency requires an upper-case ISO 4217 code known to the currency catalogue.
+func checkCurrency(field, code string) error {
+	if code == "" {
+		return invalidArg(field + " is required")
+	}
+	if !domain.IsCurrencyCode(code) {
+		return invalidArg(field + ": unknown currency code " + code)
+	}
+	return nil
+}
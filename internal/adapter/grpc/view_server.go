@@ -0,0 +1,219 @@
+//go:build !ignore
+// +build !ignore
+
+package grpcadapter
+
+import (
+	"context"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	txusecase "github.com/positron48/budget/internal/usecase/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ViewResolver turns a saved view into the filter and sort it stands for.
+type ViewResolver interface {
+	Filter(ctx context.Context, tenantID, userID, viewID string) (txusecase.ListFilter, string, error)
+}
+
+// resolveView returns the filter and sort of viewID for the current user; an empty id is an empty filter.
+func resolveView(ctx context.Context, views ViewResolver, viewID string) (txusecase.ListFilter, string, error) {
+	if viewID == "" {
+		return txusecase.ListFilter{}, "", nil
+	}
+	if views == nil {
+		return txusecase.ListFilter{}, "", status.Error(codes.FailedPrecondition, "saved views are not configured")
+	}
+	f, sort, err := views.Filter(ctx, ctxTenantID(ctx), ctxUserID(ctx), viewID)
+	if err != nil {
+		return txusecase.ListFilter{}, "", mapError(err)
+	}
+	return f, sort, nil
+}
+
+type ViewServer struct {
+	budgetv1.UnimplementedViewServiceServer
+	svc interface {
+		Create(ctx context.Context, tenantID, userID, name string, shared bool, filter domain.ViewFilter, sort string) (domain.SavedView, error)
+		Get(ctx context.Context, tenantID, userID, id string) (domain.SavedView, error)
+		List(ctx context.Context, tenantID, userID string) ([]domain.SavedView, error)
+		Update(ctx context.Context, tenantID, userID, id, name string, filter domain.ViewFilter, sort string) (domain.SavedView, error)
+		Delete(ctx context.Context, tenantID, userID, id string) error
+	}
+}
+
+func NewViewServer(svc interface {
+	Create(context.Context, string, string, string, bool, domain.ViewFilter, string) (domain.SavedView, error)
+	Get(context.Context, string, string, string) (domain.SavedView, error)
+	List(context.Context, string, string) ([]domain.SavedView, error)
+	Update(context.Context, string, string, string, string, domain.ViewFilter, string) (domain.SavedView, error)
+	Delete(context.Context, string, string, string) error
+},
+) *ViewServer {
+	return &ViewServer{svc: svc}
+}
+
+func (s *ViewServer) CreateView(ctx context.Context, req *budgetv1.CreateViewRequest) (*budgetv1.CreateViewResponse, error) {
+	f, err := viewFilterFromProto(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.svc.Create(ctx, ctxTenantID(ctx), ctxUserID(ctx), req.GetName(), req.GetShared(), f, req.GetSort())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.CreateViewResponse{View: toProtoView(v)}, nil
+}
+
+func (s *ViewServer) GetView(ctx context.Context, req *budgetv1.GetViewRequest) (*budgetv1.GetViewResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	v, err := s.svc.Get(ctx, ctxTenantID(ctx), ctxUserID(ctx), req.GetId())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.GetViewResponse{View: toProtoView(v)}, nil
+}
+
+func (s *ViewServer) ListViews(ctx context.Context, _ *budgetv1.ListViewsRequest) (*budgetv1.ListViewsResponse, error) {
+	views, err := s.svc.List(ctx, ctxTenantID(ctx), ctxUserID(ctx))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.SavedView, 0, len(views))
+	for _, v := range views {
+		out = append(out, toProtoView(v))
+	}
+	return &budgetv1.ListViewsResponse{Views: out}, nil
+}
+
+func (s *ViewServer) UpdateView(ctx context.Context, req *budgetv1.UpdateViewRequest) (*budgetv1.UpdateViewResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	f, err := viewFilterFromProto(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.svc.Update(ctx, ctxTenantID(ctx), ctxUserID(ctx), req.GetId(), req.GetName(), f, req.GetSort())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.UpdateViewResponse{View: toProtoView(v)}, nil
+}
+
+func (s *ViewServer) DeleteView(ctx context.Context, req *budgetv1.DeleteViewRequest) (*budgetv1.DeleteViewResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	if err := s.svc.Delete(ctx, ctxTenantID(ctx), ctxUserID(ctx), req.GetId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.DeleteViewResponse{}, nil
+}
+
+var viewPeriods = map[budgetv1.ViewPeriod]domain.ViewPeriod{
+	budgetv1.ViewPeriod_VIEW_PERIOD_UNSPECIFIED:  domain.ViewPeriodNone,
+	budgetv1.ViewPeriod_VIEW_PERIOD_THIS_MONTH:   domain.ViewPeriodThisMonth,
+	budgetv1.ViewPeriod_VIEW_PERIOD_LAST_MONTH:   domain.ViewPeriodLastMonth,
+	budgetv1.ViewPeriod_VIEW_PERIOD_THIS_YEAR:    domain.ViewPeriodThisYear,
+	budgetv1.ViewPeriod_VIEW_PERIOD_LAST_YEAR:    domain.ViewPeriodLastYear,
+	budgetv1.ViewPeriod_VIEW_PERIOD_LAST_30_DAYS: domain.ViewPeriodLast30Days,
+}
+
+func viewFilterFromProto(pf *budgetv1.ViewFilter) (domain.ViewFilter, error) {
+	var f domain.ViewFilter
+	if pf == nil {
+		return f, nil
+	}
+	p, ok := viewPeriods[pf.GetPeriod()]
+	if !ok {
+		return domain.ViewFilter{}, invalidArg("unknown period")
+	}
+	f.Period = p
+	if dr := pf.GetDateRange(); dr != nil {
+		if dr.GetFrom() != nil {
+			t := dr.GetFrom().AsTime()
+			f.From = &t
+		}
+		if dr.GetTo() != nil {
+			t := dr.GetTo().AsTime()
+			f.To = &t
+		}
+	}
+	f.CategoryIDs = pf.GetCategoryIds()
+	f.Type = mapTxType(pf.GetType())
+	if c := pf.GetCurrencyCode(); c != "" {
+		if err := checkCurrency("filter.currency_code", c); err != nil {
+			return domain.ViewFilter{}, err
+		}
+		f.CurrencyCode = c
+	}
+	if v := pf.GetMinMinorUnits(); v != 0 {
+		f.MinMinorUnits = &v
+	}
+	if v := pf.GetMaxMinorUnits(); v != 0 {
+		f.MaxMinorUnits = &v
+	}
+	f.Search = pf.GetSearch()
+	f.Query = pf.GetQuery()
+	switch pf.GetExtraordinary() {
+	case budgetv1.ExtraordinaryFilter_EXTRAORDINARY_FILTER_ONLY:
+		v := true
+		f.Extraordinary = &v
+	case budgetv1.ExtraordinaryFilter_EXTRAORDINARY_FILTER_EXCLUDE:
+		v := false
+		f.Extraordinary = &v
+	}
+	return f, nil
+}
+
+func toProtoView(v domain.SavedView) *budgetv1.SavedView {
+	pf := &budgetv1.ViewFilter{
+		CategoryIds:  v.Filter.CategoryIDs,
+		Type:         toProtoTxType(v.Filter.Type),
+		CurrencyCode: v.Filter.CurrencyCode,
+		Search:       v.Filter.Search,
+		Query:        v.Filter.Query,
+	}
+	for pp, dp := range viewPeriods {
+		if dp == v.Filter.Period {
+			pf.Period = pp
+		}
+	}
+	if v.Filter.From != nil || v.Filter.To != nil {
+		pf.DateRange = &budgetv1.DateRange{}
+		if v.Filter.From != nil {
+			pf.DateRange.From = timestamppb.New(*v.Filter.From)
+		}
+		if v.Filter.To != nil {
+			pf.DateRange.To = timestamppb.New(*v.Filter.To)
+		}
+	}
+	if v.Filter.MinMinorUnits != nil {
+		pf.MinMinorUnits = *v.Filter.MinMinorUnits
+	}
+	if v.Filter.MaxMinorUnits != nil {
+		pf.MaxMinorUnits = *v.Filter.MaxMinorUnits
+	}
+	if e := v.Filter.Extraordinary; e != nil {
+		pf.Extraordinary = budgetv1.ExtraordinaryFilter_EXTRAORDINARY_FILTER_EXCLUDE
+		if *e {
+			pf.Extraordinary = budgetv1.ExtraordinaryFilter_EXTRAORDINARY_FILTER_ONLY
+		}
+	}
+	return &budgetv1.SavedView{
+		Id:          v.ID,
+		Name:        v.Name,
+		Shared:      v.Shared(),
+		OwnerUserId: v.UserID,
+		Filter:      pf,
+		Sort:        v.Sort,
+		CreatedAt:   timestamppb.New(v.CreatedAt),
+		UpdatedAt:   timestamppb.New(v.UpdatedAt),
+	}
+}
@@ -0,0 +1,159 @@
+package grpcadapter
+
+import (
+	"context"
+	"testing"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	txuse "github.com/positron48/budget/internal/usecase/transaction"
+	viewuse "github.com/positron48/budget/internal/usecase/view"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// viewSvcStub keeps one view and resolves it like the usecase does
+type viewSvcStub struct{ view domain.SavedView }
+
+func (s *viewSvcStub) Create(ctx context.Context, tenantID, userID, name string, shared bool, filter domain.ViewFilter, sort string) (domain.SavedView, error) {
+	s.view = domain.SavedView{ID: "v1", TenantID: tenantID, UserID: userID, Name: name, Filter: filter, Sort: sort}
+	if shared {
+		s.view.UserID = ""
+	}
+	return s.view, nil
+}
+
+func (s *viewSvcStub) Get(ctx context.Context, tenantID, userID, id string) (domain.SavedView, error) {
+	if id != s.view.ID {
+		return domain.SavedView{}, viewuse.ErrViewNotFound
+	}
+	return s.view, nil
+}
+
+func (s *viewSvcStub) List(ctx context.Context, tenantID, userID string) ([]domain.SavedView, error) {
+	return []domain.SavedView{s.view}, nil
+}
+
+func (s *viewSvcStub) Update(ctx context.Context, tenantID, userID, id, name string, filter domain.ViewFilter, sort string) (domain.SavedView, error) {
+	s.view.Name, s.view.Filter, s.view.Sort = name, filter, sort
+	return s.view, nil
+}
+
+func (s *viewSvcStub) Delete(ctx context.Context, tenantID, userID, id string) error { return nil }
+
+func (s *viewSvcStub) Filter(ctx context.Context, tenantID, userID, id string) (txuse.ListFilter, string, error) {
+	v, err := s.Get(ctx, tenantID, userID, id)
+	if err != nil {
+		return txuse.ListFilter{}, "", err
+	}
+	f, err := viewuse.ListFilter(v.Filter, v.CreatedAt)
+	return f, v.Sort, err
+}
+
+// txSvcCapture records the filter of list and totals calls
+type txSvcCapture struct {
+	txSvcStub
+	got *txuse.ListFilter
+}
+
+func (s txSvcCapture) ListPage(ctx context.Context, tenantID string, filter txuse.ListFilter, pageToken string) (txuse.Page, error) {
+	*s.got = filter
+	return txuse.Page{}, nil
+}
+
+func (s txSvcCapture) Totals(ctx context.Context, tenantID string, filter txuse.ListFilter) (domain.Money, domain.Money, error) {
+	*s.got = filter
+	return domain.Money{}, domain.Money{}, nil
+}
+
+func TestViewServer_CRUD(t *testing.T) {
+	srv := NewViewServer(&viewSvcStub{})
+	ctx := ctxutil.WithUserID(ctxutil.WithTenantID(context.Background(), "t1"), "u1")
+	created, err := srv.CreateView(ctx, &budgetv1.CreateViewRequest{
+		Name:   "Big food",
+		Shared: true,
+		Filter: &budgetv1.ViewFilter{
+			Period:        budgetv1.ViewPeriod_VIEW_PERIOD_THIS_MONTH,
+			Type:          budgetv1.TransactionType_TRANSACTION_TYPE_EXPENSE,
+			MinMinorUnits: 100000,
+			Extraordinary: budgetv1.ExtraordinaryFilter_EXTRAORDINARY_FILTER_EXCLUDE,
+		},
+		Sort: "amount_numeric desc",
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	v := created.GetView()
+	if !v.GetShared() || v.GetOwnerUserId() != "" || v.GetFilter().GetPeriod() != budgetv1.ViewPeriod_VIEW_PERIOD_THIS_MONTH ||
+		v.GetFilter().GetMinMinorUnits() != 100000 || v.GetFilter().GetExtraordinary() != budgetv1.ExtraordinaryFilter_EXTRAORDINARY_FILTER_EXCLUDE {
+		t.Fatalf("round trip: %+v", v)
+	}
+	if _, err := srv.CreateView(ctx, &budgetv1.CreateViewRequest{Name: "x", Filter: &budgetv1.ViewFilter{CurrencyCode: "usd"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad currency: %v", err)
+	}
+	if _, err := srv.GetView(ctx, &budgetv1.GetViewRequest{Id: "nope"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("missing view: %v", err)
+	}
+	if list, err := srv.ListViews(ctx, &budgetv1.ListViewsRequest{}); err != nil || len(list.GetViews()) != 1 {
+		t.Fatalf("list: %v %v", list, err)
+	}
+	if _, err := srv.DeleteView(ctx, &budgetv1.DeleteViewRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("delete without id: %v", err)
+	}
+}
+
+func TestTransactionServer_ViewID(t *testing.T) {
+	var got txuse.ListFilter
+	srv := NewTransactionServer(txSvcCapture{got: &got})
+	ctx := ctxutil.WithUserID(ctxutil.WithTenantID(context.Background(), "t1"), "u1")
+	if _, err := srv.ListTransactions(ctx, &budgetv1.ListTransactionsRequest{ViewId: "v1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("views not configured: %v", err)
+	}
+
+	views := &viewSvcStub{view: domain.SavedView{ID: "v1", Sort: "amount_numeric desc", Filter: domain.ViewFilter{
+		CategoryIDs: []string{"c1"}, Type: domain.TransactionTypeExpense, Search: "coffee",
+	}}}
+	srv.SetViews(views)
+	if _, err := srv.ListTransactions(ctx, &budgetv1.ListTransactionsRequest{ViewId: "v1", Search: "tea"}); err != nil {
+		t.Fatalf("list with view: %v", err)
+	}
+	if got.Sort != "amount_numeric desc" || len(got.CategoryIDs) != 1 || got.Type == nil || *got.Search != "tea" {
+		t.Fatalf("view not applied or not overridden: %+v", got)
+	}
+	if _, err := srv.ListTransactions(ctx, &budgetv1.ListTransactionsRequest{ViewId: "v1", Page: &budgetv1.PageRequest{Sort: "occurred_at asc"}}); err != nil {
+		t.Fatalf("list with view and sort: %v", err)
+	}
+	if got.Sort != "occurred_at asc" {
+		t.Fatalf("request sort must win: %q", got.Sort)
+	}
+	if _, err := srv.GetTransactionsTotals(ctx, &budgetv1.GetTransactionsTotalsRequest{ViewId: "v1"}); err != nil {
+		t.Fatalf("totals with view: %v", err)
+	}
+	if *got.Search != "coffee" || len(got.CategoryIDs) != 1 {
+		t.Fatalf("totals view not applied: %+v", got)
+	}
+	if _, err := srv.ListTransactions(ctx, &budgetv1.ListTransactionsRequest{ViewId: "other"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown view: %v", err)
+	}
+}
+
+func TestReportServer_ViewID(t *testing.T) {
+	svc := &capReportSvc{}
+	srv := NewReportServer(svc)
+	extra := true
+	srv.SetViews(&viewSvcStub{view: domain.SavedView{ID: "v1", Filter: domain.ViewFilter{Extraordinary: &extra, CategoryIDs: []string{"c1"}}}})
+	ctx := ctxutil.WithUserID(ctxutil.WithTenantID(context.Background(), "t1"), "u1")
+	if _, err := srv.GetSummaryReport(ctx, &budgetv1.GetSummaryReportRequest{FromDate: "2026-01-01", ToDate: "2026-01-31", ViewId: "v1"}); err != nil {
+		t.Fatalf("report with view: %v", err)
+	}
+	if !svc.base.OnlyExtraordinary || len(svc.base.CategoryIDs) != 1 {
+		t.Fatalf("view not passed to the report: %+v", svc.base)
+	}
+	if _, err := srv.GetMonthlySummary(ctx, &budgetv1.GetMonthlySummaryRequest{Year: 2026, Month: 1, ExcludeExtraordinary: true}); err != nil {
+		t.Fatalf("report without view: %v", err)
+	}
+	if !svc.base.ExcludeExtraordinary || len(svc.base.CategoryIDs) != 0 {
+		t.Fatalf("base filter: %+v", svc.base)
+	}
+}
@@ -0,0 +1,176 @@
+//go:build !ignore
+// +build !ignore
+
+package grpcadapter
+
+import (
+	"context"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	webhookuse "github.com/positron48/budget/internal/usecase/webhook"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type webhookService interface {
+	Create(ctx context.Context, tenantID, userID string, sub domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	List(ctx context.Context, tenantID, userID string) ([]domain.WebhookSubscription, error)
+	Update(ctx context.Context, tenantID, userID string, sub domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	Delete(ctx context.Context, tenantID, userID, id string) error
+	ListDeliveries(ctx context.Context, tenantID, userID string, filter webhookuse.DeliveryFilter) ([]domain.WebhookDelivery, int64, error)
+	Redeliver(ctx context.Context, tenantID, userID, deliveryID string) (domain.WebhookDelivery, error)
+}
+
+type WebhookServer struct {
+	budgetv1.UnimplementedWebhookServiceServer
+	svc webhookService
+}
+
+func NewWebhookServer(svc webhookService) *WebhookServer {
+	return &WebhookServer{svc: svc}
+}
+
+func (s *WebhookServer) CreateWebhook(ctx context.Context, req *budgetv1.CreateWebhookRequest) (*budgetv1.CreateWebhookResponse, error) {
+	if req.GetUrl() == "" {
+		return nil, invalidArg("url is required")
+	}
+	sub, err := s.svc.Create(ctx, ctxTenantID(ctx), ctxUserID(ctx), domain.WebhookSubscription{
+		URL:         req.GetUrl(),
+		Description: req.GetDescription(),
+		EventTypes:  req.GetEventTypes(),
+		Secret:      req.GetSecret(),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.CreateWebhookResponse{Webhook: toProtoWebhook(sub), Secret: sub.Secret}, nil
+}
+
+func (s *WebhookServer) ListWebhooks(ctx context.Context, _ *budgetv1.ListWebhooksRequest) (*budgetv1.ListWebhooksResponse, error) {
+	subs, err := s.svc.List(ctx, ctxTenantID(ctx), ctxUserID(ctx))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.Webhook, 0, len(subs))
+	for _, sub := range subs {
+		out = append(out, toProtoWebhook(sub))
+	}
+	return &budgetv1.ListWebhooksResponse{Webhooks: out}, nil
+}
+
+func (s *WebhookServer) UpdateWebhook(ctx context.Context, req *budgetv1.UpdateWebhookRequest) (*budgetv1.UpdateWebhookResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	sub, err := s.svc.Update(ctx, ctxTenantID(ctx), ctxUserID(ctx), domain.WebhookSubscription{
+		ID:          req.GetId(),
+		URL:         req.GetUrl(),
+		Description: req.GetDescription(),
+		EventTypes:  req.GetEventTypes(),
+		Active:      req.GetActive(),
+		Secret:      req.GetSecret(),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.UpdateWebhookResponse{Webhook: toProtoWebhook(sub)}, nil
+}
+
+func (s *WebhookServer) DeleteWebhook(ctx context.Context, req *budgetv1.DeleteWebhookRequest) (*budgetv1.DeleteWebhookResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArg("id is required")
+	}
+	if err := s.svc.Delete(ctx, ctxTenantID(ctx), ctxUserID(ctx), req.GetId()); err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.DeleteWebhookResponse{}, nil
+}
+
+func (s *WebhookServer) ListWebhookDeliveries(ctx context.Context, req *budgetv1.ListWebhookDeliveriesRequest) (*budgetv1.ListWebhookDeliveriesResponse, error) {
+	f := webhookuse.DeliveryFilter{SubscriptionID: req.GetWebhookId(), Status: fromProtoDeliveryStatus(req.GetStatus())}
+	if req.GetPage() != nil {
+		f.Page = int(req.GetPage().GetPage())
+		f.PageSize = int(req.GetPage().GetPageSize())
+	}
+	deliveries, total, err := s.svc.ListDeliveries(ctx, ctxTenantID(ctx), ctxUserID(ctx), f)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*budgetv1.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		out = append(out, toProtoWebhookDelivery(d))
+	}
+	page := int32(f.Page)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int32(f.PageSize)
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 50
+	}
+	totalPages := (int32(total) + pageSize - 1) / pageSize
+	return &budgetv1.ListWebhookDeliveriesResponse{Deliveries: out, Page: &budgetv1.PageResponse{Page: page, PageSize: pageSize, TotalItems: total, TotalPages: totalPages}}, nil
+}
+
+func (s *WebhookServer) RedeliverWebhook(ctx context.Context, req *budgetv1.RedeliverWebhookRequest) (*budgetv1.RedeliverWebhookResponse, error) {
+	if req.GetDeliveryId() == "" {
+		return nil, invalidArg("delivery_id is required")
+	}
+	d, err := s.svc.Redeliver(ctx, ctxTenantID(ctx), ctxUserID(ctx), req.GetDeliveryId())
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &budgetv1.RedeliverWebhookResponse{Delivery: toProtoWebhookDelivery(d)}, nil
+}
+
+func toProtoWebhook(sub domain.WebhookSubscription) *budgetv1.Webhook {
+	return &budgetv1.Webhook{
+		Id:          sub.ID,
+		Url:         sub.URL,
+		Description: sub.Description,
+		EventTypes:  sub.EventTypes,
+		Active:      sub.Active,
+		CreatedAt:   timestamppb.New(sub.CreatedAt),
+		UpdatedAt:   timestamppb.New(sub.UpdatedAt),
+	}
+}
+
+var webhookDeliveryStatuses = map[budgetv1.WebhookDeliveryStatus]domain.WebhookDeliveryStatus{
+	budgetv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING:   domain.WebhookDeliveryPending,
+	budgetv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED: domain.WebhookDeliveryDelivered,
+	budgetv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED:    domain.WebhookDeliveryFailed,
+}
+
+func fromProtoDeliveryStatus(st budgetv1.WebhookDeliveryStatus) domain.WebhookDeliveryStatus {
+	return webhookDeliveryStatuses[st]
+}
+
+func toProtoWebhookDelivery(d domain.WebhookDelivery) *budgetv1.WebhookDelivery {
+	out := &budgetv1.WebhookDelivery{
+		Id:             d.ID,
+		WebhookId:      d.SubscriptionID,
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		PayloadJson:    string(d.Payload),
+		Attempts:       int32(d.Attempts),
+		LastStatusCode: int32(d.LastStatusCode),
+		LastError:      d.LastError,
+		RedeliveryOf:   d.RedeliveryOf,
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+	}
+	for ps, ds := range webhookDeliveryStatuses {
+		if ds == d.Status {
+			out.Status = ps
+		}
+	}
+	if d.Status == domain.WebhookDeliveryPending {
+		out.NextAttemptAt = timestamppb.New(d.NextAttemptAt)
+	}
+	if d.LastAttemptAt != nil {
+		out.LastAttemptAt = timestamppb.New(*d.LastAttemptAt)
+	}
+	if d.DeliveredAt != nil {
+		out.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+	}
+	return out
+}
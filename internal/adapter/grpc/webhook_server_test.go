@@ -0,0 +1,101 @@
+package grpcadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	budgetv1 "github.com/positron48/budget/gen/go/budget/v1"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	webhookuse "github.com/positron48/budget/internal/usecase/webhook"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type webhookSvcStub struct {
+	sub    domain.WebhookSubscription
+	filter webhookuse.DeliveryFilter
+}
+
+func (s *webhookSvcStub) Create(ctx context.Context, tenantID, userID string, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	sub.ID, sub.TenantID, sub.Active, sub.Secret = "w1", tenantID, true, "whsec_generated"
+	s.sub = sub
+	return sub, nil
+}
+
+func (s *webhookSvcStub) List(ctx context.Context, tenantID, userID string) ([]domain.WebhookSubscription, error) {
+	return []domain.WebhookSubscription{s.sub}, nil
+}
+
+func (s *webhookSvcStub) Update(ctx context.Context, tenantID, userID string, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	if sub.ID != s.sub.ID {
+		return domain.WebhookSubscription{}, webhookuse.ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+func (s *webhookSvcStub) Delete(ctx context.Context, tenantID, userID, id string) error {
+	return webhookuse.ErrPermissionDenied
+}
+
+func (s *webhookSvcStub) ListDeliveries(ctx context.Context, tenantID, userID string, filter webhookuse.DeliveryFilter) ([]domain.WebhookDelivery, int64, error) {
+	s.filter = filter
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []domain.WebhookDelivery{
+		{ID: "d1", SubscriptionID: "w1", EventType: domain.WebhookEventTransactionCreated, Payload: []byte(`{"id":"e1"}`), Status: domain.WebhookDeliveryFailed, Attempts: 10, LastStatusCode: 500, LastError: "receiver answered 500", LastAttemptAt: &at},
+	}, 1, nil
+}
+
+func (s *webhookSvcStub) Redeliver(ctx context.Context, tenantID, userID, deliveryID string) (domain.WebhookDelivery, error) {
+	return domain.WebhookDelivery{ID: "d2", RedeliveryOf: deliveryID, Status: domain.WebhookDeliveryPending, NextAttemptAt: time.Now()}, nil
+}
+
+func TestWebhookServer(t *testing.T) {
+	svc := &webhookSvcStub{}
+	srv := NewWebhookServer(svc)
+	ctx := ctxutil.WithUserID(ctxutil.WithTenantID(context.Background(), "t1"), "u1")
+
+	created, err := srv.CreateWebhook(ctx, &budgetv1.CreateWebhookRequest{Url: "https://x.example.com", EventTypes: []string{domain.WebhookEventTransactionCreated}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.GetSecret() != "whsec_generated" || created.GetWebhook().GetId() != "w1" || !created.GetWebhook().GetActive() {
+		t.Fatalf("unexpected response %+v", created)
+	}
+	if _, err := srv.CreateWebhook(ctx, &budgetv1.CreateWebhookRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("missing url: %v", err)
+	}
+	if _, err := srv.UpdateWebhook(ctx, &budgetv1.UpdateWebhookRequest{Id: "nope", Url: "https://x.example.com"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown webhook: %v", err)
+	}
+	if _, err := srv.DeleteWebhook(ctx, &budgetv1.DeleteWebhookRequest{Id: "w1"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("delete by a member: %v", err)
+	}
+
+	log, err := srv.ListWebhookDeliveries(ctx, &budgetv1.ListWebhookDeliveriesRequest{WebhookId: "w1", Status: budgetv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED})
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if svc.filter.SubscriptionID != "w1" || svc.filter.Status != domain.WebhookDeliveryFailed {
+		t.Fatalf("unexpected filter %+v", svc.filter)
+	}
+	d := log.GetDeliveries()[0]
+	if d.GetStatus() != budgetv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED || d.GetLastStatusCode() != 500 || d.GetPayloadJson() != `{"id":"e1"}` || d.GetNextAttemptAt() != nil || d.GetLastAttemptAt() == nil {
+		t.Fatalf("unexpected delivery %+v", d)
+	}
+	if log.GetPage().GetTotalItems() != 1 || log.GetPage().GetTotalPages() != 1 {
+		t.Fatalf("unexpected page %+v", log.GetPage())
+	}
+
+	re, err := srv.RedeliverWebhook(ctx, &budgetv1.RedeliverWebhookRequest{DeliveryId: "d1"})
+	if err != nil {
+		t.Fatalf("redeliver: %v", err)
+	}
+	if re.GetDelivery().GetRedeliveryOf() != "d1" || re.GetDelivery().GetStatus() != budgetv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING || re.GetDelivery().GetNextAttemptAt() == nil {
+		t.Fatalf("unexpected redelivery %+v", re.GetDelivery())
+	}
+	if _, err := srv.RedeliverWebhook(ctx, &budgetv1.RedeliverWebhookRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("missing delivery id: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// MemberLister lists the members of a tenant with their emails.
+type MemberLister interface {
+	ListMembers(ctx context.Context, tenantID string) ([]domain.TenantMembership, error)
+}
+
+// AlertMailer emails fired alerts to the owners and admins of the tenant.
+type AlertMailer struct {
+	sender  Sender
+	members MemberLister
+	baseURL string
+}
+
+func NewAlertMailer(sender Sender, members MemberLister, webBaseURL string) *AlertMailer {
+	return &AlertMailer{sender: sender, members: members, baseURL: strings.TrimRight(webBaseURL, "/")}
+}
+
+func (m *AlertMailer) Notify(ctx context.Context, rule domain.AlertRule, a domain.FiredAlert) error {
+	members, err := m.members.ListMembers(ctx, rule.TenantID)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("%s\n\nThe alert rule %q fired on %s.\nManage alert rules: %s/settings/alerts\n",
+		a.Message, rule.Name, a.FiredAt.UTC().Format("2006-01-02 15:04 MST"), m.baseURL)
+	var errs []error
+	for _, mb := range members {
+		if mb.UserEmail == "" || (mb.Role != domain.TenantRoleOwner && mb.Role != domain.TenantRoleAdmin) {
+			continue
+		}
+		if err := m.sender.Send(ctx, Message{To: mb.UserEmail, Subject: "Budget alert: " + rule.Name, Body: body}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// InvitationMailer sends tenant invitations with an accept link to the web app.
+type InvitationMailer struct {
+	sender  Sender
+	baseURL string
+}
+
+func NewInvitationMailer(sender Sender, webBaseURL string) *InvitationMailer {
+	return &InvitationMailer{sender: sender, baseURL: strings.TrimRight(webBaseURL, "/")}
+}
+
+func (m *InvitationMailer) SendInvitation(ctx context.Context, inv domain.TenantInvitation, token string) error {
+	link := fmt.Sprintf("%s/invitations/accept?token=%s", m.baseURL, url.QueryEscape(token))
+	inviter := inv.InvitedByName
+	if inviter == "" {
+		inviter = "A member"
+	}
+	body := fmt.Sprintf("%s invited you to join the budget %q as %s.\n\n"+
+		"Accept or decline the invitation: %s\n\n"+
+		"The invitation expires on %s. If you don't have an account yet, sign up with this email address "+
+		"and the invitation will be attached automatically.\n",
+		inviter, inv.Tenant.Name, inv.Role, link, inv.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"))
+	return m.sender.Send(ctx, Message{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("Invitation to %s", inv.Tenant.Name),
+		Body:    body,
+	})
+}
@@ -0,0 +1,28 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// MemorySender keeps sent messages in memory. Intended for tests and local runs.
+type MemorySender struct {
+	mu   sync.Mutex
+	msgs []Message
+}
+
+func NewMemorySender() *MemorySender { return &MemorySender{} }
+
+func (s *MemorySender) Send(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
+
+// Messages returns a copy of the messages sent so far.
+func (s *MemorySender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.msgs...)
+}
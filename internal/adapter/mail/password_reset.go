@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+)
+
+// PasswordResetMailer sends password reset links to the web app.
+type PasswordResetMailer struct {
+	sender  Sender
+	baseURL string
+}
+
+func NewPasswordResetMailer(sender Sender, webBaseURL string) *PasswordResetMailer {
+	return &PasswordResetMailer{sender: sender, baseURL: strings.TrimRight(webBaseURL, "/")}
+}
+
+func (m *PasswordResetMailer) SendPasswordReset(ctx context.Context, u useauth.User, token string, expiresAt time.Time) error {
+	link := fmt.Sprintf("%s/reset-password?token=%s", m.baseURL, url.QueryEscape(token))
+	name := u.Name
+	if name == "" {
+		name = u.Email
+	}
+	body := fmt.Sprintf("Hello, %s!\n\n"+
+		"Someone requested a password reset for your account. To choose a new password open: %s\n\n"+
+		"The link can be used once and expires on %s. After the reset you will be signed out on all devices.\n"+
+		"If you didn't request this, just ignore this email.\n",
+		name, link, expiresAt.UTC().Format("2006-01-02 15:04 MST"))
+	return m.sender.Send(ctx, Message{To: u.Email, Subject: "Password reset", Body: body})
+}
@@ -0,0 +1,132 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+)
+
+func TestFileSender_WritesEml(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	s := NewFileSender(dir, "Budget <no-reply@example.com>")
+	if err := s.Send(context.Background(), Message{To: "bob@example.com", Subject: "Hi", Body: "line1\nline2"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one eml, got %v %v", files, err)
+	}
+	b, _ := os.ReadFile(files[0])
+	got := string(b)
+	for _, want := range []string{"From: Budget <no-reply@example.com>\r\n", "To: bob@example.com\r\n", "Subject: Hi\r\n", "\r\n\r\nline1\r\nline2"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+type captureSender struct{ msgs []Message }
+
+func (c *captureSender) Send(_ context.Context, msg Message) error {
+	c.msgs = append(c.msgs, msg)
+	return nil
+}
+
+func TestInvitationMailer_Link(t *testing.T) {
+	c := &captureSender{}
+	m := NewInvitationMailer(c, "https://budget.example.com/")
+	inv := domain.TenantInvitation{Tenant: domain.Tenant{Name: "Home"}, Email: "bob@example.com", Role: domain.TenantRoleMember, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := m.SendInvitation(context.Background(), inv, "abc"); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if len(c.msgs) != 1 || c.msgs[0].To != "bob@example.com" {
+		t.Fatalf("unexpected messages: %#v", c.msgs)
+	}
+	if !strings.Contains(c.msgs[0].Body, "https://budget.example.com/invitations/accept?token=abc") {
+		t.Fatalf("link missing: %s", c.msgs[0].Body)
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	s := NewSMTPSender("smtp.example.com", 587, "user", "secret", "Budget <no-reply@example.com>")
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	s.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		if a == nil {
+			t.Fatal("auth expected when username is set")
+		}
+		return nil
+	}
+	if err := s.Send(context.Background(), Message{To: "bob@example.com", Subject: "Hi", Body: "text"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if gotAddr != "smtp.example.com:587" || gotFrom != "no-reply@example.com" || len(gotTo) != 1 || gotTo[0] != "bob@example.com" {
+		t.Fatalf("unexpected envelope: %s %s %v", gotAddr, gotFrom, gotTo)
+	}
+	if !strings.Contains(string(gotMsg), "Subject: Hi\r\n") {
+		t.Fatalf("unexpected message: %s", gotMsg)
+	}
+	s.send = func(string, smtp.Auth, string, []string, []byte) error { return errors.New("boom") }
+	if err := s.Send(context.Background(), Message{To: "bob@example.com"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestPasswordResetMailer_UsesMemorySender(t *testing.T) {
+	mem := NewMemorySender()
+	m := NewPasswordResetMailer(mem, "http://localhost:3030")
+	if err := m.SendPasswordReset(context.Background(), useauth.User{Email: "bob@example.com"}, "tok", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	msgs := mem.Messages()
+	if len(msgs) != 1 || !strings.Contains(msgs[0].Body, "http://localhost:3030/reset-password?token=tok") {
+		t.Fatalf("unexpected messages: %#v", msgs)
+	}
+}
+
+func TestVerificationMailer_Link(t *testing.T) {
+	mem := NewMemorySender()
+	m := NewVerificationMailer(mem, "http://localhost:3030/")
+	if err := m.SendEmailVerification(context.Background(), useauth.User{Email: "bob@example.com"}, "a+b", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	msgs := mem.Messages()
+	if len(msgs) != 1 || msgs[0].To != "bob@example.com" || !strings.Contains(msgs[0].Body, "http://localhost:3030/verify-email?token=a%2Bb") {
+		t.Fatalf("unexpected messages: %#v", msgs)
+	}
+}
+
+type memberList []domain.TenantMembership
+
+func (l memberList) ListMembers(context.Context, string) ([]domain.TenantMembership, error) {
+	return l, nil
+}
+
+func TestAlertMailer_NotifiesManagers(t *testing.T) {
+	c := &captureSender{}
+	m := NewAlertMailer(c, memberList{
+		{Role: domain.TenantRoleOwner, UserEmail: "owner@example.com"},
+		{Role: domain.TenantRoleAdmin, UserEmail: "admin@example.com"},
+		{Role: domain.TenantRoleMember, UserEmail: "member@example.com"},
+	}, "https://budget.example.com/")
+	rule := domain.AlertRule{TenantID: "t1", Name: "Food"}
+	if err := m.Notify(context.Background(), rule, domain.FiredAlert{Message: "Food: 110.00 RUB spent", FiredAt: time.Now()}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if len(c.msgs) != 2 || c.msgs[0].To != "owner@example.com" || c.msgs[1].To != "admin@example.com" {
+		t.Fatalf("unexpected recipients: %#v", c.msgs)
+	}
+	if c.msgs[0].Subject != "Budget alert: Food" || !strings.Contains(c.msgs[0].Body, "110.00 RUB") || !strings.Contains(c.msgs[0].Body, "https://budget.example.com/settings/alerts") {
+		t.Fatalf("unexpected message: %#v", c.msgs[0])
+	}
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPSender delivers messages through an SMTP relay (STARTTLS is used when offered).
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+	// send is smtp.SendMail; replaced in tests
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{addr: net.JoinHostPort(host, fmt.Sprint(port)), auth: auth, from: from, send: smtp.SendMail}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	envelopeFrom := s.from
+	if a, err := mail.ParseAddress(s.from); err == nil {
+		envelopeFrom = a.Address
+	}
+	data := formatMessage(s.from, msg, time.Now())
+	if err := s.send(s.addr, s.auth, envelopeFrom, []string{msg.To}, []byte(data)); err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+	return nil
+}
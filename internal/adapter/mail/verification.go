@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+)
+
+// VerificationMailer sends email confirmation links to the web app.
+type VerificationMailer struct {
+	sender  Sender
+	baseURL string
+}
+
+func NewVerificationMailer(sender Sender, webBaseURL string) *VerificationMailer {
+	return &VerificationMailer{sender: sender, baseURL: strings.TrimRight(webBaseURL, "/")}
+}
+
+func (m *VerificationMailer) SendEmailVerification(ctx context.Context, u useauth.User, token string, expiresAt time.Time) error {
+	link := fmt.Sprintf("%s/verify-email?token=%s", m.baseURL, url.QueryEscape(token))
+	name := u.Name
+	if name == "" {
+		name = u.Email
+	}
+	body := fmt.Sprintf("Hello, %s!\n\n"+
+		"Please confirm your email address by opening: %s\n\n"+
+		"The link expires on %s.\n"+
+		"If you didn't create an account, just ignore this email.\n",
+		name, link, expiresAt.UTC().Format("2006-01-02 15:04 MST"))
+	return m.sender.Send(ctx, Message{To: u.Email, Subject: "Confirm your email", Body: body})
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/alert"
+)
+
+type AlertRepo struct{ pool *Pool }
+
+func NewAlertRepo(pool *Pool) *AlertRepo { return &AlertRepo{pool: pool} }
+
+const alertRuleColumns = `id, tenant_id, name, kind, COALESCE(category_id::text, ''), threshold_minor, days, channels,
+    telegram_chat_id, timezone, active, COALESCE(created_by::text, ''), created_at, updated_at`
+
+func scanAlertRule(row pgx.Row) (domain.AlertRule, error) {
+	var r domain.AlertRule
+	var kind string
+	var channels []string
+	if err := row.Scan(&r.ID, &r.TenantID, &r.Name, &kind, &r.CategoryID, &r.ThresholdMinor, &r.Days, &channels,
+		&r.TelegramChatID, &r.Timezone, &r.Active, &r.CreatedBy, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		return domain.AlertRule{}, err
+	}
+	r.Kind = domain.AlertRuleKind(kind)
+	for _, ch := range channels {
+		r.Channels = append(r.Channels, domain.AlertChannel(ch))
+	}
+	return r, nil
+}
+
+func alertChannels(r domain.AlertRule) []string {
+	out := make([]string, 0, len(r.Channels))
+	for _, ch := range r.Channels {
+		out = append(out, string(ch))
+	}
+	return out
+}
+
+// nullableID maps an empty id to NULL
+func nullableID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+const firedAlertColumns = `id, tenant_id, rule_id, kind, period_key, message, value_minor, value_currency, notify_error, fired_at`
+
+func scanFiredAlert(row pgx.Row) (domain.FiredAlert, error) {
+	var a domain.FiredAlert
+	var kind string
+	if err := row.Scan(&a.ID, &a.TenantID, &a.RuleID, &kind, &a.PeriodKey, &a.Message, &a.Value.MinorUnits, &a.Value.CurrencyCode,
+		&a.NotifyError, &a.FiredAt); err != nil {
+		return domain.FiredAlert{}, err
+	}
+	a.Kind = domain.AlertRuleKind(kind)
+	return a, nil
+}
+
+func (r *AlertRepo) Create(ctx context.Context, rule domain.AlertRule) (domain.AlertRule, error) {
+	return scanAlertRule(r.pool.DB.QueryRow(ctx,
+		`INSERT INTO alert_rules (tenant_id, name, kind, category_id, threshold_minor, days, channels, telegram_chat_id, active, created_by, timezone)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING `+alertRuleColumns,
+		rule.TenantID, rule.Name, string(rule.Kind), nullableID(rule.CategoryID), rule.ThresholdMinor, rule.Days, alertChannels(rule),
+		rule.TelegramChatID, rule.Active, nullableID(rule.CreatedBy), rule.Timezone,
+	))
+}
+
+func (r *AlertRepo) Get(ctx context.Context, tenantID, id string) (domain.AlertRule, error) {
+	if !validUUID(id) {
+		return domain.AlertRule{}, alert.ErrRuleNotFound
+	}
+	rule, err := scanAlertRule(r.pool.DB.QueryRow(ctx,
+		`SELECT `+alertRuleColumns+` FROM alert_rules WHERE tenant_id=$1 AND id=$2`, tenantID, id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.AlertRule{}, alert.ErrRuleNotFound
+	}
+	return rule, err
+}
+
+func (r *AlertRepo) List(ctx context.Context, tenantID string) ([]domain.AlertRule, error) {
+	return r.listRules(ctx, `SELECT `+alertRuleColumns+` FROM alert_rules WHERE tenant_id=$1 ORDER BY lower(name), id`, tenantID)
+}
+
+// ListActive returns the active rules of tenants that are not scheduled for deletion
+func (r *AlertRepo) ListActive(ctx context.Context) ([]domain.AlertRule, error) {
+	return r.listRules(ctx, `SELECT `+alertRuleColumns+` FROM alert_rules
+         WHERE active AND tenant_id IN (SELECT id FROM tenants WHERE deletion_scheduled_at IS NULL)
+         ORDER BY tenant_id, id`)
+}
+
+func (r *AlertRepo) listRules(ctx context.Context, query string, args ...any) ([]domain.AlertRule, error) {
+	rows, err := r.pool.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.AlertRule
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rule)
+	}
+	return out, rows.Err()
+}
+
+func (r *AlertRepo) Update(ctx context.Context, rule domain.AlertRule) (domain.AlertRule, error) {
+	if !validUUID(rule.ID) {
+		return domain.AlertRule{}, alert.ErrRuleNotFound
+	}
+	out, err := scanAlertRule(r.pool.DB.QueryRow(ctx,
+		`UPDATE alert_rules SET name=$3, category_id=$4, threshold_minor=$5, days=$6, channels=$7, telegram_chat_id=$8,
+             active=$9, timezone=$10, updated_at=now()
+         WHERE tenant_id=$1 AND id=$2 RETURNING `+alertRuleColumns,
+		rule.TenantID, rule.ID, rule.Name, nullableID(rule.CategoryID), rule.ThresholdMinor, rule.Days, alertChannels(rule),
+		rule.TelegramChatID, rule.Active, rule.Timezone,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.AlertRule{}, alert.ErrRuleNotFound
+	}
+	return out, err
+}
+
+func (r *AlertRepo) Delete(ctx context.Context, tenantID, id string) error {
+	if !validUUID(id) {
+		return alert.ErrRuleNotFound
+	}
+	tag, err := r.pool.DB.Exec(ctx, `DELETE FROM alert_rules WHERE tenant_id=$1 AND id=$2`, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return alert.ErrRuleNotFound
+	}
+	return nil
+}
+
+func (r *AlertRepo) ListFired(ctx context.Context, tenantID, ruleID string, page, pageSize int) ([]domain.FiredAlert, int64, error) {
+	clause, args := "tenant_id=$1", []any{tenantID}
+	if ruleID != "" {
+		if !validUUID(ruleID) {
+			return nil, 0, nil
+		}
+		clause += " AND rule_id=$2"
+		args = append(args, ruleID)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 50
+	}
+	var total int64
+	if err := r.pool.DB.QueryRow(ctx, "SELECT COUNT(*) FROM fired_alerts WHERE "+clause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	query := fmt.Sprintf(`SELECT `+firedAlertColumns+` FROM fired_alerts WHERE %s
+         ORDER BY fired_at DESC, id DESC OFFSET $%d LIMIT $%d`, clause, len(args)+1, len(args)+2)
+	rows, err := r.pool.DB.Query(ctx, query, append(args, (page-1)*pageSize, pageSize)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	var out []domain.FiredAlert
+	for rows.Next() {
+		a, err := scanFiredAlert(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		out = append(out, a)
+	}
+	return out, total, rows.Err()
+}
+
+// Record inserts the alert; the unique (rule_id, period_key) turns a repeated firing into a no-op
+func (r *AlertRepo) Record(ctx context.Context, a domain.FiredAlert) (domain.FiredAlert, bool, error) {
+	out, err := scanFiredAlert(r.pool.DB.QueryRow(ctx,
+		`INSERT INTO fired_alerts (tenant_id, rule_id, kind, period_key, message, value_minor, value_currency, fired_at)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+         ON CONFLICT (rule_id, period_key) DO NOTHING
+         RETURNING `+firedAlertColumns,
+		a.TenantID, a.RuleID, string(a.Kind), a.PeriodKey, a.Message, a.Value.MinorUnits, a.Value.CurrencyCode, a.FiredAt,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.FiredAlert{}, false, nil
+	}
+	if err != nil {
+		return domain.FiredAlert{}, false, err
+	}
+	return out, true, nil
+}
+
+func (r *AlertRepo) SetNotifyError(ctx context.Context, id, msg string) error {
+	_, err := r.pool.DB.Exec(ctx, `UPDATE fired_alerts SET notify_error=$2 WHERE id=$1`, id, msg)
+	return err
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/positron48/budget/internal/domain"
+	useuser "github.com/positron48/budget/internal/usecase/user"
+)
+
+type APITokenRepo struct{ pool *Pool }
+
+func NewAPITokenRepo(pool *Pool) *APITokenRepo { return &APITokenRepo{pool: pool} }
+
+const apiTokenColumns = `id, user_id, tenant_id, name, scope, token_prefix, created_at, last_used_at, expires_at`
+
+func scanAPIToken(row pgx.Row) (domain.APIToken, error) {
+	var t domain.APIToken
+	var scope string
+	err := row.Scan(&t.ID, &t.UserID, &t.TenantID, &t.Name, &scope, &t.Prefix, &t.CreatedAt, &t.LastUsedAt, &t.ExpiresAt)
+	t.Scope = domain.APITokenScope(scope)
+	return t, err
+}
+
+func (r *APITokenRepo) CreateAPIToken(ctx context.Context, t domain.APIToken, token string) (domain.APIToken, error) {
+	return scanAPIToken(r.pool.DB.QueryRow(ctx,
+		`INSERT INTO api_tokens (user_id, tenant_id, name, scope, token_prefix, token_hash, expires_at)
+         VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING `+apiTokenColumns,
+		t.UserID, t.TenantID, t.Name, string(t.Scope), t.Prefix, hashToken(token), t.ExpiresAt,
+	))
+}
+
+// ListAPITokens returns tokens that are neither revoked nor expired, newest first
+func (r *APITokenRepo) ListAPITokens(ctx context.Context, userID string) ([]domain.APIToken, error) {
+	rows, err := r.pool.DB.Query(ctx,
+		`SELECT `+apiTokenColumns+` FROM api_tokens
+         WHERE user_id=$1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > now())
+         ORDER BY created_at DESC`, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.APIToken
+	for rows.Next() {
+		t, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func (r *APITokenRepo) RevokeAPIToken(ctx context.Context, userID, id string) (bool, error) {
+	tag, err := r.pool.DB.Exec(ctx,
+		`UPDATE api_tokens SET revoked_at=now() WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL`, id, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *APITokenRepo) GetAPITokenByToken(ctx context.Context, token string) (domain.APIToken, error) {
+	t, err := scanAPIToken(r.pool.DB.QueryRow(ctx,
+		`SELECT `+apiTokenColumns+` FROM api_tokens
+         WHERE token_hash=$1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > now())`,
+		hashToken(token),
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.APIToken{}, useuser.ErrInvalidAPIToken
+	}
+	return t, err
+}
+
+func (r *APITokenRepo) TouchAPIToken(ctx context.Context, id string, at time.Time) error {
+	_, err := r.pool.DB.Exec(ctx, `UPDATE api_tokens SET last_used_at=$2 WHERE id=$1`, id, at)
+	return err
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
+)
+
+type AuditRepo struct{ pool *Pool }
+
+func NewAuditRepo(pool *Pool) *AuditRepo { return &AuditRepo{pool: pool} }
+
+// Record writes with the transaction of ctx, so the event commits together with the change.
+func (r *AuditRepo) Record(ctx context.Context, ev domain.AuditEvent) error {
+	_, err := r.pool.Conn(ctx).Exec(ctx,
+		`INSERT INTO audit_events (tenant_id, actor_id, entity_type, entity_id, action, before, after, created_at)
+         VALUES ($1, NULLIF($2,'')::uuid, $3, $4, $5, $6, $7, $8)`,
+		ev.TenantID, ev.ActorID, ev.EntityType, ev.EntityID, string(ev.Action), nullJSON(ev.Before), nullJSON(ev.After), ev.CreatedAt,
+	)
+	return err
+}
+
+func (r *AuditRepo) List(ctx context.Context, tenantID string, f audit.Filter) ([]domain.AuditEvent, int64, error) {
+	var where []string
+	var args []any
+	add := func(cond string, val any) {
+		where = append(where, fmt.Sprintf(cond, len(args)+1))
+		args = append(args, val)
+	}
+	add("tenant_id=$%d", tenantID)
+	if f.EntityType != "" {
+		add("entity_type=$%d", f.EntityType)
+	}
+	if f.EntityID != "" {
+		add("entity_id=$%d", f.EntityID)
+	}
+	if f.ActorID != "" {
+		add("actor_id=$%d::uuid", f.ActorID)
+	}
+	if f.Action != "" {
+		add("action=$%d", string(f.Action))
+	}
+	if f.From != nil {
+		add("created_at >= $%d", *f.From)
+	}
+	if f.To != nil {
+		add("created_at < $%d", *f.To)
+	}
+	clause := strings.Join(where, " AND ")
+
+	page := f.Page
+	if page < 1 {
+		page = 1
+	}
+	size := f.PageSize
+	if size <= 0 || size > 500 {
+		size = 50
+	}
+
+	var total int64
+	if err := r.pool.Conn(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM audit_events WHERE "+clause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	query := fmt.Sprintf(`SELECT id::text, COALESCE(actor_id::text,''), entity_type, entity_id, action, before, after, created_at
+         FROM audit_events WHERE %s ORDER BY created_at DESC, id DESC OFFSET $%d LIMIT $%d`, clause, len(args)+1, len(args)+2)
+	rows, err := r.pool.Conn(ctx).Query(ctx, query, append(args, (page-1)*size, size)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	var out []domain.AuditEvent
+	for rows.Next() {
+		ev := domain.AuditEvent{TenantID: tenantID}
+		var action string
+		var before, after []byte
+		if err := rows.Scan(&ev.ID, &ev.ActorID, &ev.EntityType, &ev.EntityID, &action, &before, &after, &ev.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		ev.Action = domain.AuditAction(action)
+		ev.Before, ev.After = before, after
+		out = append(out, ev)
+	}
+	return out, total, rows.Err()
+}
+
+// nullJSON stores an absent snapshot as SQL NULL rather than JSON null.
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// authRateLimits implements hourly counters on auth_rate_limits; embedded by auth repos.
+type authRateLimits struct{ pool *Pool }
+
+// CheckRateLimit возвращает число попыток в окне
+func (r authRateLimits) CheckRateLimit(ctx context.Context, subject, action string, windowStart time.Time) (int, error) {
+	var attemptsCount int
+	err := r.pool.DB.QueryRow(ctx,
+		`SELECT attempts_count FROM auth_rate_limits WHERE subject = $1 AND action = $2 AND window_start = $3`,
+		subject, action, windowStart,
+	).Scan(&attemptsCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return attemptsCount, nil
+}
+
+// IncrementRateLimit увеличивает счетчик попыток
+func (r authRateLimits) IncrementRateLimit(ctx context.Context, subject, action string, windowStart time.Time) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`INSERT INTO auth_rate_limits (subject, action, window_start, attempts_count)
+         VALUES ($1, $2, $3, 1)
+         ON CONFLICT (subject, action, window_start)
+         DO UPDATE SET attempts_count = auth_rate_limits.attempts_count + 1, updated_at = now()`,
+		subject, action, windowStart,
+	)
+	return err
+}
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/positron48/budget/internal/domain"
 )
 
@@ -12,7 +14,7 @@ func NewCategoryRepo(pool *Pool) *CategoryRepo { return &CategoryRepo{pool: pool
 
 func (r *CategoryRepo) Create(ctx context.Context, tenantID string, kind domain.CategoryKind, code string, parentID *string, isActive bool, translations []domain.CategoryTranslation) (domain.Category, error) {
 	var c domain.Category
-	err := r.pool.DB.QueryRow(ctx,
+	err := r.pool.Conn(ctx).QueryRow(ctx,
 		`INSERT INTO categories (tenant_id, kind, code, parent_id, is_active) VALUES ($1,$2,$3,$4,$5)
          RETURNING id, tenant_id, kind, code, parent_id, is_active, created_at`,
 		tenantID, string(kind), code, parentID, isActive,
@@ -22,7 +24,7 @@ func (r *CategoryRepo) Create(ctx context.Context, tenantID string, kind domain.
 	}
 	// translations
 	for _, tr := range translations {
-		if _, err := r.pool.DB.Exec(ctx,
+		if _, err := r.pool.Conn(ctx).Exec(ctx,
 			`INSERT INTO category_i18n (category_id, locale, name, description) VALUES ($1,$2,$3,$4)
              ON CONFLICT (category_id, locale) DO UPDATE SET name=EXCLUDED.name, description=EXCLUDED.description`,
 			c.ID, tr.Locale, tr.Name, tr.Description,
@@ -31,18 +33,21 @@ func (r *CategoryRepo) Create(ctx context.Context, tenantID string, kind domain.
 		}
 	}
 	c.Translations = translations
+	if err := r.pool.notifyTenantEvent(ctx, tenantID, domain.AuditEntityCategory, c.ID, domain.AuditActionCreated); err != nil {
+		return domain.Category{}, err
+	}
 	return c, nil
 }
 
 func (r *CategoryRepo) Update(ctx context.Context, id string, code string, parentID *string, isActive bool, translations []domain.CategoryTranslation) (domain.Category, error) {
-	_, err := r.pool.DB.Exec(ctx,
-		`UPDATE categories SET code=$2, parent_id=$3, is_active=$4 WHERE id=$1`, id, code, parentID, isActive,
+	_, err := r.pool.Conn(ctx).Exec(ctx,
+		`UPDATE categories SET code=$2, parent_id=$3, is_active=$4 WHERE id=$1 AND deleted_at IS NULL`, id, code, parentID, isActive,
 	)
 	if err != nil {
 		return domain.Category{}, err
 	}
 	for _, tr := range translations {
-		if _, err := r.pool.DB.Exec(ctx,
+		if _, err := r.pool.Conn(ctx).Exec(ctx,
 			`INSERT INTO category_i18n (category_id, locale, name, description) VALUES ($1,$2,$3,$4)
              ON CONFLICT (category_id, locale) DO UPDATE SET name=EXCLUDED.name, description=EXCLUDED.description`,
 			id, tr.Locale, tr.Name, tr.Description,
@@ -50,23 +55,36 @@ func (r *CategoryRepo) Update(ctx context.Context, id string, code string, paren
 			return domain.Category{}, err
 		}
 	}
-	return r.Get(ctx, id)
+	c, err := r.Get(ctx, id)
+	if err != nil {
+		return domain.Category{}, err
+	}
+	if err := r.pool.notifyTenantEvent(ctx, c.TenantID, domain.AuditEntityCategory, id, domain.AuditActionUpdated); err != nil {
+		return domain.Category{}, err
+	}
+	return c, nil
 }
 
+// Delete moves the category to the trash; it is removed for good by PurgeDeleted
 func (r *CategoryRepo) Delete(ctx context.Context, id string) error {
-	_, err := r.pool.DB.Exec(ctx, `DELETE FROM categories WHERE id=$1`, id)
-	return err
+	var tenantID string
+	if err := r.pool.Conn(ctx).QueryRow(ctx,
+		`UPDATE categories SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL RETURNING tenant_id`, id,
+	).Scan(&tenantID); err != nil {
+		return err
+	}
+	return r.pool.notifyTenantEvent(ctx, tenantID, domain.AuditEntityCategory, id, domain.AuditActionDeleted)
 }
 
 func (r *CategoryRepo) Get(ctx context.Context, id string) (domain.Category, error) {
 	var c domain.Category
-	err := r.pool.DB.QueryRow(ctx,
-		`SELECT id, tenant_id, kind, code, parent_id, is_active, created_at FROM categories WHERE id=$1`, id,
+	err := r.pool.Conn(ctx).QueryRow(ctx,
+		`SELECT id, tenant_id, kind, code, parent_id, is_active, created_at FROM categories WHERE id=$1 AND deleted_at IS NULL`, id,
 	).Scan(&c.ID, &c.TenantID, &c.Kind, &c.Code, &c.ParentID, &c.IsActive, &c.CreatedAt)
 	if err != nil {
 		return domain.Category{}, err
 	}
-	rows, err := r.pool.DB.Query(ctx, `SELECT locale, name, description FROM category_i18n WHERE category_id=$1`, id)
+	rows, err := r.pool.Conn(ctx).Query(ctx, `SELECT locale, name, description FROM category_i18n WHERE category_id=$1`, id)
 	if err != nil {
 		return domain.Category{}, err
 	}
@@ -82,13 +100,13 @@ func (r *CategoryRepo) Get(ctx context.Context, id string) (domain.Category, err
 }
 
 func (r *CategoryRepo) List(ctx context.Context, tenantID string, kind domain.CategoryKind, includeInactive bool) ([]domain.Category, error) {
-	query := `SELECT id, tenant_id, kind, code, parent_id, is_active, created_at FROM categories WHERE tenant_id=$1 AND kind=$2`
+	query := `SELECT id, tenant_id, kind, code, parent_id, is_active, created_at FROM categories WHERE tenant_id=$1 AND kind=$2 AND deleted_at IS NULL`
 	args := []any{tenantID, string(kind)}
 	if !includeInactive {
 		query += ` AND is_active=true`
 	}
 	query += ` ORDER BY code`
-	rows, err := r.pool.DB.Query(ctx, query, args...)
+	rows, err := r.pool.Conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -104,12 +122,13 @@ func (r *CategoryRepo) List(ctx context.Context, tenantID string, kind domain.Ca
 	return out, rows.Err()
 }
 
-// GetMany returns categories with translations for the provided ids
+// GetMany returns categories with translations for the provided ids, including deleted ones
+// (transactions in the trash may still point to them)
 func (r *CategoryRepo) GetMany(ctx context.Context, ids []string) (map[string]domain.Category, error) {
 	if len(ids) == 0 {
 		return map[string]domain.Category{}, nil
 	}
-	rows, err := r.pool.DB.Query(ctx,
+	rows, err := r.pool.Conn(ctx).Query(ctx,
 		`SELECT id, tenant_id, kind, code, parent_id, is_active, created_at FROM categories WHERE id = ANY($1)`, ids,
 	)
 	if err != nil {
@@ -128,7 +147,7 @@ func (r *CategoryRepo) GetMany(ctx context.Context, ids []string) (map[string]do
 		return nil, err
 	}
 	// translations for all
-	trRows, err := r.pool.DB.Query(ctx,
+	trRows, err := r.pool.Conn(ctx).Query(ctx,
 		`SELECT category_id, locale, name, description FROM category_i18n WHERE category_id = ANY($1)`, ids,
 	)
 	if err != nil {
@@ -168,3 +187,121 @@ func (r *CategoryRepo) GetWithLocale(ctx context.Context, id, locale string) (do
 	}
 	return c, nil
 }
+
+// CountTransactions returns the number of live transactions in the category
+func (r *CategoryRepo) CountTransactions(ctx context.Context, id string) (int64, error) {
+	var n int64
+	err := r.pool.Conn(ctx).QueryRow(ctx,
+		`SELECT COUNT(*) FROM transactions WHERE category_id=$1 AND deleted_at IS NULL`, id,
+	).Scan(&n)
+	return n, err
+}
+
+// ReassignTransactions moves all transactions of a category, trashed ones included, to another
+// category and returns the live ones as they are now; a change event is sent for each of them.
+func (r *CategoryRepo) ReassignTransactions(ctx context.Context, fromID, toID string) ([]domain.Transaction, error) {
+	rows, err := r.pool.Conn(ctx).Query(ctx,
+		`UPDATE transactions SET category_id=$2 WHERE category_id=$1 RETURNING id, tenant_id, deleted_at IS NULL`, fromID, toID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var tenantID string
+	var ids []string
+	for rows.Next() {
+		var id string
+		var live bool
+		if err := rows.Scan(&id, &tenantID, &live); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if live {
+			ids = append(ids, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	byID, err := NewTransactionRepo(r.pool).GetMany(ctx, tenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.Transaction, 0, len(ids))
+	for _, id := range ids {
+		if err := r.pool.notifyTenantEvent(ctx, tenantID, domain.AuditEntityTransaction, id, domain.AuditActionUpdated); err != nil {
+			return nil, err
+		}
+		out = append(out, byID[id])
+	}
+	return out, nil
+}
+
+// ListDeleted returns the tenant's categories in the trash, most recently deleted first
+func (r *CategoryRepo) ListDeleted(ctx context.Context, tenantID string) ([]domain.Category, error) {
+	rows, err := r.pool.Conn(ctx).Query(ctx,
+		`SELECT id, tenant_id, kind, code, parent_id, is_active, created_at, deleted_at FROM categories
+          WHERE tenant_id=$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC`, tenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.Category
+	var ids []string
+	for rows.Next() {
+		var c domain.Category
+		if err := rows.Scan(&c.ID, &c.TenantID, &c.Kind, &c.Code, &c.ParentID, &c.IsActive, &c.CreatedAt, &c.DeletedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+		ids = append(ids, c.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	withTr, err := r.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range out {
+		out[i].Translations = withTr[out[i].ID].Translations
+	}
+	return out, nil
+}
+
+// Restore takes a category of the tenant out of the trash
+func (r *CategoryRepo) Restore(ctx context.Context, tenantID, id string) (domain.Category, error) {
+	tag, err := r.pool.Conn(ctx).Exec(ctx,
+		`UPDATE categories SET deleted_at=NULL WHERE id=$1 AND tenant_id=$2 AND deleted_at IS NOT NULL`, id, tenantID,
+	)
+	if err != nil {
+		return domain.Category{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.Category{}, pgx.ErrNoRows
+	}
+	if err := r.pool.notifyTenantEvent(ctx, tenantID, domain.AuditEntityCategory, id, domain.AuditActionRestored); err != nil {
+		return domain.Category{}, err
+	}
+	return r.Get(ctx, id)
+}
+
+// PurgeDeleted removes categories deleted before the cutoff. Categories still referenced by
+// transactions (e.g. ones waiting in the trash) are kept until those are purged.
+func (r *CategoryRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Conn(ctx).Exec(ctx,
+		`DELETE FROM categories c WHERE c.deleted_at < $1
+            AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.category_id = c.id)`, before,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -35,3 +37,39 @@ func (p *Pool) Ping(ctx context.Context) error {
 func (p *Pool) Close() {
 	p.DB.Close()
 }
+
+type txKey struct{}
+
+// querier is the part of pgxpool.Pool and pgx.Tx used by repositories.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Conn returns the transaction started by WithinTx for ctx, or the pool. Begin on a
+// transaction creates a savepoint, so repositories with their own transactions nest.
+func (p *Pool) Conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return p.DB
+}
+
+// WithinTx runs fn in a transaction: repositories called with the context passed to fn
+// use it. A nested call joins the outer transaction.
+func (p *Pool) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
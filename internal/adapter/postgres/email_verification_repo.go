@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+)
+
+type EmailVerificationRepo struct {
+	authRateLimits
+	pool *Pool
+}
+
+func NewEmailVerificationRepo(pool *Pool) *EmailVerificationRepo {
+	return &EmailVerificationRepo{authRateLimits: authRateLimits{pool: pool}, pool: pool}
+}
+
+func (r *EmailVerificationRepo) CreateEmailVerification(ctx context.Context, userID, email, token string, expiresAt time.Time) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`INSERT INTO email_verification_tokens (user_id, email, token_hash, expires_at) VALUES ($1,lower($2),$3,$4)`,
+		userID, email, hashToken(token), expiresAt,
+	)
+	return err
+}
+
+// VerifyEmail consumes the token and sets users.email_verified if the address is unchanged
+func (r *EmailVerificationRepo) VerifyEmail(ctx context.Context, token string) (useauth.User, error) {
+	tx, err := r.pool.DB.Begin(ctx)
+	if err != nil {
+		return useauth.User{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var userID string
+	err = tx.QueryRow(ctx,
+		`UPDATE email_verification_tokens v SET used_at=now()
+         FROM users u
+         WHERE v.token_hash=$1 AND v.used_at IS NULL AND v.expires_at > now()
+           AND u.id = v.user_id AND lower(u.email) = v.email
+         RETURNING v.user_id`, hashToken(token),
+	).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return useauth.User{}, useauth.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return useauth.User{}, err
+	}
+	var u useauth.User
+	if err := tx.QueryRow(ctx,
+		`UPDATE users SET email_verified=true, updated_at=now() WHERE id=$1
+         RETURNING id, email, COALESCE(name,''), COALESCE(locale,''), email_verified`, userID,
+	).Scan(&u.ID, &u.Email, &u.Name, &u.Locale, &u.EmailVerified); err != nil {
+		return useauth.User{}, err
+	}
+	return u, tx.Commit(ctx)
+}
+
+func (r *EmailVerificationRepo) MarkEmailVerified(ctx context.Context, userID string) error {
+	_, err := r.pool.DB.Exec(ctx, `UPDATE users SET email_verified=true, updated_at=now() WHERE id=$1 AND NOT email_verified`, userID)
+	return err
+}
+
+func (r *EmailVerificationRepo) GetUser(ctx context.Context, userID string) (useauth.User, error) {
+	var u useauth.User
+	err := r.pool.DB.QueryRow(ctx,
+		`SELECT id, email, COALESCE(name,''), COALESCE(locale,''), email_verified FROM users WHERE id=$1`, userID,
+	).Scan(&u.ID, &u.Email, &u.Name, &u.Locale, &u.EmailVerified)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return useauth.User{}, useauth.ErrUserNotFound
+	}
+	return u, err
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+// tenantEventsChannel is the LISTEN/NOTIFY channel of tenant change events
+const tenantEventsChannel = "tenant_events"
+
+// notifyTenantEvent queues a change event. Inside a transaction (see Pool.WithinTx) NOTIFY
+// is delivered on commit and dropped on rollback, so watchers never see uncommitted changes.
+func (p *Pool) notifyTenantEvent(ctx context.Context, tenantID, entityType, entityID string, action domain.AuditAction) error {
+	ev := domain.TenantEvent{TenantID: tenantID, EntityType: entityType, EntityID: entityID, Action: action, OccurredAt: time.Now().UTC()}
+	ev.ActorID, _ = ctxutil.UserIDFromContext(ctx)
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = p.Conn(ctx).Exec(ctx, `SELECT pg_notify($1, $2)`, tenantEventsChannel, string(payload))
+	return err
+}
+
+// EventListener receives tenant change events sent by the repositories of any server instance.
+type EventListener struct{ pool *Pool }
+
+func NewEventListener(pool *Pool) *EventListener { return &EventListener{pool: pool} }
+
+// Listen holds a pool connection in LISTEN mode and passes every event to publish. It returns
+// when ctx is done or the connection fails; events sent while nobody listens are lost, so
+// listening is called once LISTEN is active to let subscribers know they may have missed some.
+func (l *EventListener) Listen(ctx context.Context, publish func(domain.TenantEvent), listening func()) error {
+	pooled, err := l.pool.DB.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// a connection left in LISTEN mode must not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, `LISTEN `+tenantEventsChannel); err != nil {
+		return err
+	}
+	if listening != nil {
+		listening()
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var ev domain.TenantEvent
+		if err := json.Unmarshal([]byte(n.Payload), &ev); err != nil || ev.TenantID == "" {
+			continue
+		}
+		publish(ev)
+	}
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+)
+
+type IdentityRepo struct{ pool *Pool }
+
+func NewIdentityRepo(pool *Pool) *IdentityRepo { return &IdentityRepo{pool: pool} }
+
+func (r *IdentityRepo) GetUserByIdentity(ctx context.Context, provider, subject string) (useauth.User, []useauth.TenantMembership, error) {
+	var email string
+	err := r.pool.DB.QueryRow(ctx,
+		`UPDATE user_identities i SET last_login_at=now()
+         FROM users u WHERE i.provider=$1 AND i.subject=$2 AND u.id=i.user_id
+         RETURNING u.email`, provider, subject,
+	).Scan(&email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return useauth.User{}, nil, useauth.ErrUserNotFound
+	}
+	if err != nil {
+		return useauth.User{}, nil, err
+	}
+	return NewUserRepo(r.pool).GetByEmail(ctx, email)
+}
+
+func (r *IdentityRepo) LinkIdentity(ctx context.Context, userID, provider, subject, email string) error {
+	tag, err := r.pool.DB.Exec(ctx,
+		`INSERT INTO user_identities (provider, subject, user_id, email, last_login_at) VALUES ($1,$2,$3,$4,now())
+         ON CONFLICT (provider, subject) DO UPDATE SET email=EXCLUDED.email
+         WHERE user_identities.user_id = EXCLUDED.user_id`,
+		provider, subject, userID, email,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		// the user already has another identity of this provider
+		return useauth.ErrIdentityAlreadyLinked
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return useauth.ErrIdentityAlreadyLinked
+	}
+	return nil
+}
+
+func (r *IdentityRepo) UnlinkIdentity(ctx context.Context, userID, provider string) (bool, error) {
+	tag, err := r.pool.DB.Exec(ctx, `DELETE FROM user_identities WHERE user_id=$1 AND provider=$2`, userID, provider)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *IdentityRepo) ListIdentities(ctx context.Context, userID string) ([]useauth.Identity, error) {
+	rows, err := r.pool.DB.Query(ctx,
+		`SELECT provider, subject, email, created_at, last_login_at FROM user_identities WHERE user_id=$1 ORDER BY provider`, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []useauth.Identity
+	for rows.Next() {
+		var id useauth.Identity
+		if err := rows.Scan(&id.Provider, &id.Subject, &id.Email, &id.CreatedAt, &id.LastLoginAt); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
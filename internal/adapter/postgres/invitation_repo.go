@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/positron48/budget/internal/domain"
+	tenuse "github.com/positron48/budget/internal/usecase/tenant"
+)
+
+type InvitationRepo struct{ pool *Pool }
+
+func NewInvitationRepo(pool *Pool) *InvitationRepo { return &InvitationRepo{pool: pool} }
+
+// status is reported as expired for pending invitations past expires_at even before
+// the row itself is updated.
+const invitationSelect = `SELECT i.id, t.id, t.name, COALESCE(t.slug, ''), t.default_currency_code, t.created_at,
+                i.email, i.role, COALESCE(i.invited_by::text, ''), COALESCE(u.name, ''),
+                CASE WHEN i.status='pending' AND i.expires_at <= now() THEN 'expired' ELSE i.status::text END,
+                i.created_at, i.expires_at, i.responded_at
+         FROM tenant_invitations i
+         JOIN tenants t ON t.id = i.tenant_id
+         LEFT JOIN users u ON u.id = i.invited_by`
+
+func scanInvitation(row pgx.Row) (domain.TenantInvitation, error) {
+	var inv domain.TenantInvitation
+	err := row.Scan(&inv.ID, &inv.Tenant.ID, &inv.Tenant.Name, &inv.Tenant.Slug, &inv.Tenant.DefaultCurrencyCode, &inv.Tenant.CreatedAt,
+		&inv.Email, &inv.Role, &inv.InvitedByID, &inv.InvitedByName, &inv.Status, &inv.CreatedAt, &inv.ExpiresAt, &inv.RespondedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.TenantInvitation{}, tenuse.ErrInvitationNotFound
+	}
+	return inv, err
+}
+
+func (r *InvitationRepo) CreateInvitation(ctx context.Context, inv domain.TenantInvitation, token string) (domain.TenantInvitation, error) {
+	tx, err := r.pool.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// supersede previous pending invitation for the same address (re-invite)
+	if _, err := tx.Exec(ctx,
+		`UPDATE tenant_invitations
+         SET status = CASE WHEN expires_at <= now() THEN 'expired'::tenant_invitation_status ELSE 'revoked'::tenant_invitation_status END,
+             responded_at = now()
+         WHERE tenant_id=$1 AND email=$2 AND status='pending'`, inv.Tenant.ID, inv.Email,
+	); err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	var id string
+	if err := tx.QueryRow(ctx,
+		`INSERT INTO tenant_invitations (tenant_id, email, role, token_hash, invited_by, status, expires_at)
+         VALUES ($1,$2,$3,$4,NULLIF($5,'')::uuid,'pending',$6) RETURNING id`,
+		inv.Tenant.ID, inv.Email, string(inv.Role), hashToken(token), inv.InvitedByID, inv.ExpiresAt,
+	).Scan(&id); err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	return r.GetInvitation(ctx, id)
+}
+
+func (r *InvitationRepo) GetInvitation(ctx context.Context, id string) (domain.TenantInvitation, error) {
+	return scanInvitation(r.pool.Conn(ctx).QueryRow(ctx, invitationSelect+` WHERE i.id=$1`, id))
+}
+
+func (r *InvitationRepo) GetInvitationByToken(ctx context.Context, token string) (domain.TenantInvitation, error) {
+	return scanInvitation(r.pool.Conn(ctx).QueryRow(ctx, invitationSelect+` WHERE i.token_hash=$1`, hashToken(token)))
+}
+
+func (r *InvitationRepo) ListInvitations(ctx context.Context, tenantID string) ([]domain.TenantInvitation, error) {
+	return r.list(ctx, invitationSelect+` WHERE i.tenant_id=$1 ORDER BY i.created_at DESC`, tenantID)
+}
+
+func (r *InvitationRepo) ListPendingInvitationsForEmail(ctx context.Context, email string) ([]domain.TenantInvitation, error) {
+	return r.list(ctx, invitationSelect+` WHERE i.email=lower($1) AND i.status='pending' AND i.expires_at > now() ORDER BY i.created_at`, email)
+}
+
+func (r *InvitationRepo) list(ctx context.Context, query string, args ...any) ([]domain.TenantInvitation, error) {
+	rows, err := r.pool.Conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []domain.TenantInvitation
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, inv)
+	}
+	return res, rows.Err()
+}
+
+// AcceptInvitation grants the invited role (existing memberships are kept as is)
+// and marks the invitation accepted.
+func (r *InvitationRepo) AcceptInvitation(ctx context.Context, invitationID, userID string) (domain.TenantMembership, error) {
+	tx, err := r.pool.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return domain.TenantMembership{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var tenantID, role string
+	err = tx.QueryRow(ctx,
+		`UPDATE tenant_invitations SET status='accepted', responded_at=now()
+         WHERE id=$1 AND status='pending' AND expires_at > now()
+         RETURNING tenant_id, role`, invitationID,
+	).Scan(&tenantID, &role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.TenantMembership{}, tenuse.ErrInvitationNotPending
+	}
+	if err != nil {
+		return domain.TenantMembership{}, err
+	}
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO user_tenants (user_id, tenant_id, role, is_default) VALUES ($1,$2,$3,false) ON CONFLICT (user_id, tenant_id) DO NOTHING`,
+		userID, tenantID, role,
+	); err != nil {
+		return domain.TenantMembership{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.TenantMembership{}, err
+	}
+	return NewTenantRepo(r.pool).getMembership(ctx, tenantID, userID)
+}
+
+// SetInvitationStatus moves a pending invitation to the given status; invitations
+// that were already answered, revoked or expired are left untouched.
+func (r *InvitationRepo) SetInvitationStatus(ctx context.Context, invitationID string, status domain.InvitationStatus) error {
+	tag, err := r.pool.Conn(ctx).Exec(ctx,
+		`UPDATE tenant_invitations SET status=$2, responded_at=now() WHERE id=$1 AND status='pending'`, invitationID, string(status),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return tenuse.ErrInvitationNotPending
+	}
+	return nil
+}
+
+func (r *InvitationRepo) IsMemberByEmail(ctx context.Context, tenantID, email string) (bool, error) {
+	var exists bool
+	err := r.pool.Conn(ctx).QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM user_tenants ut JOIN users u ON u.id = ut.user_id WHERE ut.tenant_id=$1 AND lower(u.email)=lower($2))`,
+		tenantID, email,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *InvitationRepo) GetUserEmail(ctx context.Context, userID string) (string, bool, error) {
+	var email string
+	var verified bool
+	err := r.pool.Conn(ctx).QueryRow(ctx, `SELECT email, email_verified FROM users WHERE id=$1`, userID).Scan(&email, &verified)
+	return email, verified, err
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+type LoginAttemptRepo struct{ pool *Pool }
+
+func NewLoginAttemptRepo(pool *Pool) *LoginAttemptRepo { return &LoginAttemptRepo{pool: pool} }
+
+func (r *LoginAttemptRepo) RecordLoginAttempt(ctx context.Context, a domain.LoginAttempt) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`INSERT INTO login_attempts (user_id, email, method, status, ip_address, user_agent, created_at)
+         VALUES (NULLIF($1,'')::uuid, $2, $3, $4, NULLIF($5,''), NULLIF($6,''), $7)`,
+		a.UserID, a.Email, a.Method, string(a.Status), a.IPAddress, a.UserAgent, a.CreatedAt,
+	)
+	return err
+}
+
+func (r *LoginAttemptRepo) ListLoginAttempts(ctx context.Context, userID string, limit int) ([]domain.LoginAttempt, error) {
+	rows, err := r.pool.DB.Query(ctx,
+		`SELECT id::text, email, method, status, COALESCE(ip_address,''), COALESCE(user_agent,''), created_at
+         FROM login_attempts WHERE user_id=$1
+         ORDER BY created_at DESC LIMIT $2`, userID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.LoginAttempt
+	for rows.Next() {
+		a := domain.LoginAttempt{UserID: userID}
+		var st string
+		if err := rows.Scan(&a.ID, &a.Email, &a.Method, &st, &a.IPAddress, &a.UserAgent, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.Status = domain.LoginAttemptStatus(st)
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
@@ -148,20 +148,29 @@ func (r *OAuthRepo) UpdateTelegramSessionLastUsed(ctx context.Context, sessionID
 	return nil
 }
 
-// RevokeTelegramSession отзывает сессию
+// RevokeTelegramSession отзывает сессию вместе с цепочкой ее refresh токенов
 func (r *OAuthRepo) RevokeTelegramSession(ctx context.Context, sessionID string) error {
 	query := `
-		UPDATE telegram_sessions
-		SET revoked_at = now(), is_active = false
-		WHERE session_id = $1
+		WITH s AS (
+			UPDATE telegram_sessions
+			SET revoked_at = now(), is_active = false
+			WHERE session_id = $1
+			RETURNING refresh_token_hash
+		), rt AS (
+			UPDATE refresh_tokens SET revoked_at = now()
+			WHERE revoked_at IS NULL AND family_id IN (
+				SELECT f.family_id FROM refresh_tokens f JOIN s ON f.token_hash = s.refresh_token_hash
+			)
+		)
+		SELECT count(*) FROM s
 	`
 
-	result, err := r.db.DB.Exec(ctx, query, sessionID)
-	if err != nil {
+	var revoked int
+	if err := r.db.DB.QueryRow(ctx, query, sessionID).Scan(&revoked); err != nil {
 		return err
 	}
 
-	if result.RowsAffected() == 0 {
+	if revoked == 0 {
 		return fmt.Errorf("session not found")
 	}
 
@@ -390,8 +399,8 @@ func (r *OAuthRepo) GetUserByEmail(ctx context.Context, email string) (useauth.U
 	email = strings.ToLower(strings.TrimSpace(email))
 	var u useauth.User
 	err := r.db.DB.QueryRow(ctx,
-		`SELECT id, email, name, locale, password_hash FROM users WHERE email=$1`, email,
-	).Scan(&u.ID, &u.Email, &u.Name, &u.Locale, &u.PasswordHash)
+		`SELECT id, email, name, locale, password_hash, email_verified FROM users WHERE email=$1`, email,
+	).Scan(&u.ID, &u.Email, &u.Name, &u.Locale, &u.PasswordHash, &u.EmailVerified)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return useauth.User{}, nil, err
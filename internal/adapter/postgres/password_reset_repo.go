@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+)
+
+type PasswordResetRepo struct {
+	authRateLimits
+	pool *Pool
+}
+
+func NewPasswordResetRepo(pool *Pool) *PasswordResetRepo {
+	return &PasswordResetRepo{authRateLimits: authRateLimits{pool: pool}, pool: pool}
+}
+
+func (r *PasswordResetRepo) CreatePasswordReset(ctx context.Context, userID, token, ip string, expiresAt time.Time) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`INSERT INTO password_reset_tokens (user_id, token_hash, ip, expires_at) VALUES ($1,$2,NULLIF($3,''),$4)`,
+		userID, hashToken(token), ip, expiresAt,
+	)
+	return err
+}
+
+// ResetPassword consumes the token, updates the password and revokes every refresh token of the user
+func (r *PasswordResetRepo) ResetPassword(ctx context.Context, token, newPasswordHash string) (string, error) {
+	tx, err := r.pool.DB.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var userID string
+	err = tx.QueryRow(ctx,
+		`UPDATE password_reset_tokens SET used_at=now()
+         WHERE token_hash=$1 AND used_at IS NULL AND expires_at > now()
+         RETURNING user_id`, hashToken(token),
+	).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", useauth.ErrInvalidResetToken
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE users SET password_hash=$2, updated_at=now() WHERE id=$1`, userID, newPasswordHash); err != nil {
+		return "", err
+	}
+	// other outstanding reset links become useless after a successful reset
+	if _, err := tx.Exec(ctx, `UPDATE password_reset_tokens SET used_at=now() WHERE user_id=$1 AND used_at IS NULL`, userID); err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL`, userID); err != nil {
+		return "", err
+	}
+	return userID, tx.Commit(ctx)
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	pgx "github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/positron48/budget/internal/domain"
 	txusecase "github.com/positron48/budget/internal/usecase/transaction"
@@ -202,3 +205,289 @@ func TestTransactionRepo_CRUD_PG(t *testing.T) {
 		t.Fatalf("delete: %v", err)
 	}
 }
+
+// seedTenant inserts a tenant with a user and an expense category
+func seedTenant(t *testing.T, pool *Pool) (tenantID, userID, catID string) {
+	t.Helper()
+	ctx := context.Background()
+	if err := pool.DB.QueryRow(ctx, `INSERT INTO tenants(name, default_currency_code) VALUES ($1,$2) RETURNING id`, "Home", "USD").Scan(&tenantID); err != nil {
+		t.Fatalf("seed tenant: %v", err)
+	}
+	if err := pool.DB.QueryRow(ctx, `INSERT INTO users(email, password_hash) VALUES ($1,$2) RETURNING id`, fmt.Sprintf("u_%d@example.com", time.Now().UnixNano()), "h").Scan(&userID); err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	if _, err := pool.DB.Exec(ctx, `INSERT INTO user_tenants(user_id, tenant_id, role, is_default) VALUES ($1,$2,'owner',true)`, userID, tenantID); err != nil {
+		t.Fatalf("seed membership: %v", err)
+	}
+	if err := pool.DB.QueryRow(ctx, `INSERT INTO categories(tenant_id, kind, code, is_active) VALUES ($1,$2,$3,$4) RETURNING id`, tenantID, "expense", "food", true).Scan(&catID); err != nil {
+		t.Fatalf("seed cat: %v", err)
+	}
+	return tenantID, userID, catID
+}
+
+func seedTransaction(t *testing.T, pool *Pool, tenantID, userID, catID string, minor int64, occurredAt time.Time) domain.Transaction {
+	t.Helper()
+	tx, err := NewTransactionRepo(pool).Create(context.Background(), domain.Transaction{
+		TenantID: tenantID, UserID: userID, CategoryID: catID, Type: domain.TransactionTypeExpense,
+		Amount:     domain.Money{CurrencyCode: "USD", MinorUnits: minor},
+		BaseAmount: domain.Money{CurrencyCode: "USD", MinorUnits: minor},
+		OccurredAt: occurredAt,
+	})
+	if err != nil {
+		t.Fatalf("seed transaction: %v", err)
+	}
+	return tx
+}
+
+func TestTenantRepo_PurgeScheduledTenants_PG(t *testing.T) {
+	pool, _ := withPg(t)
+	ctx := context.Background()
+	doomed, user, cat := seedTenant(t, pool)
+	seedTransaction(t, pool, doomed, user, cat, 1000, time.Now())
+	kept, keptUser, keptCat := seedTenant(t, pool)
+	seedTransaction(t, pool, kept, keptUser, keptCat, 2000, time.Now())
+
+	now := time.Now()
+	if _, err := pool.DB.Exec(ctx, `UPDATE tenants SET deletion_scheduled_at=$2 WHERE id=$1`, doomed, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	// scheduled for later, not due yet
+	if _, err := pool.DB.Exec(ctx, `UPDATE tenants SET deletion_scheduled_at=$2 WHERE id=$1`, kept, now.Add(time.Hour)); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	n, err := NewTenantRepo(pool).PurgeScheduledTenants(ctx, now)
+	if err != nil || n != 1 {
+		t.Fatalf("purge: n=%d err=%v", n, err)
+	}
+	var left int
+	if err := pool.DB.QueryRow(ctx, `SELECT (SELECT count(*) FROM tenants WHERE id=$1) + (SELECT count(*) FROM transactions WHERE tenant_id=$1) + (SELECT count(*) FROM categories WHERE tenant_id=$1)`, doomed).Scan(&left); err != nil || left != 0 {
+		t.Fatalf("purged tenant left %d rows (err %v)", left, err)
+	}
+	if err := pool.DB.QueryRow(ctx, `SELECT count(*) FROM transactions WHERE tenant_id=$1`, kept).Scan(&left); err != nil || left != 1 {
+		t.Fatalf("other tenant lost its transactions: %d (err %v)", left, err)
+	}
+}
+
+func TestTransactionRepo_TrashAndRestore_PG(t *testing.T) {
+	pool, _ := withPg(t)
+	ctx := context.Background()
+	tenantID, userID, catID := seedTenant(t, pool)
+	tx := seedTransaction(t, pool, tenantID, userID, catID, 1000, time.Now())
+	repo := NewTransactionRepo(pool)
+
+	if err := repo.Delete(ctx, tx.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repo.Delete(ctx, tx.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("second delete: %v", err)
+	}
+	if _, err := repo.Get(ctx, tx.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("deleted transaction still visible: %v", err)
+	}
+	if _, total, err := repo.List(ctx, tenantID, txusecase.ListFilter{}); err != nil || total != 0 {
+		t.Fatalf("list after delete: total=%d err=%v", total, err)
+	}
+	trash, total, err := repo.ListDeleted(ctx, tenantID, 1, 10)
+	if err != nil || total != 1 || len(trash) != 1 || trash[0].ID != tx.ID || trash[0].DeletedAt == nil {
+		t.Fatalf("trash: %v total=%d %#v", err, total, trash)
+	}
+
+	// the category is in the trash too: the transaction can't come back on its own
+	if _, err := pool.DB.Exec(ctx, `UPDATE categories SET deleted_at=now() WHERE id=$1`, catID); err != nil {
+		t.Fatalf("trash category: %v", err)
+	}
+	if _, err := repo.Restore(ctx, tenantID, tx.ID); !errors.Is(err, txusecase.ErrCategoryDeleted) {
+		t.Fatalf("restore with trashed category: %v", err)
+	}
+	if _, err := pool.DB.Exec(ctx, `UPDATE categories SET deleted_at=NULL WHERE id=$1`, catID); err != nil {
+		t.Fatalf("restore category: %v", err)
+	}
+	other, _, _ := seedTenant(t, pool)
+	if _, err := repo.Restore(ctx, other, tx.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("restore from another tenant: %v", err)
+	}
+	restored, err := repo.Restore(ctx, tenantID, tx.ID)
+	if err != nil || restored.ID != tx.ID || restored.Amount.MinorUnits != 1000 {
+		t.Fatalf("restore: %v %#v", err, restored)
+	}
+	if _, err := repo.Restore(ctx, tenantID, tx.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("second restore: %v", err)
+	}
+
+	// only transactions deleted before the cutoff are purged
+	if err := repo.Delete(ctx, tx.ID); err != nil {
+		t.Fatalf("delete again: %v", err)
+	}
+	if n, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Fatalf("early purge: n=%d err=%v", n, err)
+	}
+	if n, err := repo.PurgeDeleted(ctx, time.Now().Add(time.Minute)); err != nil || n != 1 {
+		t.Fatalf("purge: n=%d err=%v", n, err)
+	}
+	if _, total, err := repo.ListDeleted(ctx, tenantID, 1, 10); err != nil || total != 0 {
+		t.Fatalf("trash after purge: total=%d err=%v", total, err)
+	}
+}
+
+func TestTransactionRepo_ListPageCursor_PG(t *testing.T) {
+	pool, _ := withPg(t)
+	ctx := context.Background()
+	tenantID, userID, catID := seedTenant(t, pool)
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	// two rows share occurred_at: the id tie-breaker must still page through both
+	for i, at := range []time.Time{base, base, base.Add(-time.Minute), base.Add(-2 * time.Minute), base.Add(-3 * time.Minute)} {
+		seedTransaction(t, pool, tenantID, userID, catID, int64(100*(i+1)), at)
+	}
+	repo := NewTransactionRepo(pool)
+
+	// walk pages of two rows; onFirstPage runs between the first and the second page
+	walk := func(sort string, onFirstPage func()) []domain.Transaction {
+		t.Helper()
+		var all []domain.Transaction
+		filter := txusecase.ListFilter{PageSize: 2, Sort: sort}
+		for i := 0; ; i++ {
+			items, next, _, err := repo.ListPage(ctx, tenantID, filter)
+			if err != nil {
+				t.Fatalf("%s page %d: %v", sort, i, err)
+			}
+			all = append(all, items...)
+			if next == nil {
+				return all
+			}
+			if i == 0 && onFirstPage != nil {
+				onFirstPage()
+			}
+			filter.After = next
+		}
+	}
+
+	// a newer row added after the first page sorts before the cursor and doesn't shift later pages
+	byDate := walk("occurred_at desc", func() { seedTransaction(t, pool, tenantID, userID, catID, 700, time.Now()) })
+	if len(byDate) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(byDate))
+	}
+	seen := map[string]bool{}
+	for i, tx := range byDate {
+		if seen[tx.ID] {
+			t.Fatalf("row %s returned twice", tx.ID)
+		}
+		seen[tx.ID] = true
+		if i > 0 && tx.OccurredAt.After(byDate[i-1].OccurredAt) {
+			t.Fatalf("rows out of order at %d", i)
+		}
+	}
+
+	// sorting by category joins categories: the filter columns must not be ambiguous
+	createdFrom := time.Now().Add(-time.Hour)
+	items, _, total, err := repo.ListPage(ctx, tenantID, txusecase.ListFilter{Sort: "category_code asc", CreatedFrom: &createdFrom, PageSize: 10})
+	if err != nil || total != 6 || len(items) != 6 {
+		t.Fatalf("category sort with created_from: %v total=%d len=%d", err, total, len(items))
+	}
+	if _, total, err := repo.List(ctx, tenantID, txusecase.ListFilter{Sort: "category_code desc", CreatedFrom: &createdFrom}); err != nil || total != 6 {
+		t.Fatalf("list by category with created_from: %v total=%d", err, total)
+	}
+
+	// expenses sort by their signed amount: the biggest expense comes first
+	byAmount := walk("amount_numeric asc", nil)
+	if len(byAmount) != 6 || byAmount[0].Amount.MinorUnits != 700 || byAmount[5].Amount.MinorUnits != 100 {
+		t.Fatalf("unexpected amount walk %#v", byAmount)
+	}
+	for i := 1; i < len(byAmount); i++ {
+		if byAmount[i].Amount.MinorUnits >= byAmount[i-1].Amount.MinorUnits {
+			t.Fatalf("amounts out of order at %d", i)
+		}
+	}
+}
+
+func TestWebhookRepo_ClaimDue_PG(t *testing.T) {
+	pool, _ := withPg(t)
+	ctx := context.Background()
+	tenantID, _, _ := seedTenant(t, pool)
+	repo := NewWebhookRepo(pool)
+	sub, err := repo.CreateSubscription(ctx, domain.WebhookSubscription{TenantID: tenantID, URL: "https://example.com/hook", EventTypes: []string{domain.WebhookEventTransactionCreated}, Secret: "s3cret", Active: true})
+	if err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	// neither an inactive subscription nor one to other events gets a delivery
+	for _, s := range []domain.WebhookSubscription{
+		{TenantID: tenantID, URL: "https://example.com/off", EventTypes: []string{domain.WebhookEventTransactionCreated}, Secret: "x"},
+		{TenantID: tenantID, URL: "https://example.com/other", EventTypes: []string{domain.WebhookEventAlertFired}, Secret: "x", Active: true},
+	} {
+		if _, err := repo.CreateSubscription(ctx, s); err != nil {
+			t.Fatalf("create subscription: %v", err)
+		}
+	}
+	n, err := repo.Enqueue(ctx, tenantID, uuid.NewString(), domain.WebhookEventTransactionCreated, []byte(`{"id":"1"}`))
+	if err != nil || n != 1 {
+		t.Fatalf("enqueue: n=%d err=%v", n, err)
+	}
+
+	now := time.Now().Add(time.Second)
+	due, err := repo.ClaimDue(ctx, now, 10, time.Minute)
+	if err != nil || len(due) != 1 {
+		t.Fatalf("claim: %v %#v", err, due)
+	}
+	d := due[0]
+	if d.Delivery.SubscriptionID != sub.ID || d.URL != sub.URL || d.Secret != "s3cret" || d.Delivery.Attempts != 1 || string(d.Delivery.Payload) != `{"id":"1"}` {
+		t.Fatalf("unexpected claim %#v", d)
+	}
+	// leased: another dispatcher doesn't see it until the lease runs out
+	if due, err := repo.ClaimDue(ctx, now, 10, time.Minute); err != nil || len(due) != 0 {
+		t.Fatalf("claim during lease: %v %#v", err, due)
+	}
+	// released on shutdown: due right away, and the cut-off attempt isn't counted
+	if err := repo.Release(ctx, []string{d.Delivery.ID}, now); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if due, err := repo.ClaimDue(ctx, now, 10, time.Minute); err != nil || len(due) != 1 || due[0].Delivery.Attempts != 1 {
+		t.Fatalf("claim after release: %v %#v", err, due)
+	}
+	later := now.Add(2 * time.Minute)
+	due, err = repo.ClaimDue(ctx, later, 10, time.Minute)
+	if err != nil || len(due) != 1 || due[0].Delivery.Attempts != 2 {
+		t.Fatalf("claim after lease: %v %#v", err, due)
+	}
+
+	// a failed attempt with a retry time comes back then; a delivered one never does
+	retry := later.Add(5 * time.Minute)
+	if err := repo.MarkFailed(ctx, d.Delivery.ID, 500, "boom", &retry); err != nil {
+		t.Fatalf("mark failed: %v", err)
+	}
+	if due, err := repo.ClaimDue(ctx, retry.Add(-time.Second), 10, time.Minute); err != nil || len(due) != 0 {
+		t.Fatalf("claim before retry: %v %#v", err, due)
+	}
+	if due, err := repo.ClaimDue(ctx, retry, 10, time.Minute); err != nil || len(due) != 1 || due[0].Delivery.LastError != "boom" {
+		t.Fatalf("claim at retry: %v %#v", err, due)
+	}
+	if err := repo.MarkDelivered(ctx, d.Delivery.ID, 200, retry); err != nil {
+		t.Fatalf("mark delivered: %v", err)
+	}
+	if due, err := repo.ClaimDue(ctx, retry.Add(time.Hour), 10, time.Minute); err != nil || len(due) != 0 {
+		t.Fatalf("claim after delivery: %v %#v", err, due)
+	}
+}
+
+func TestTransactionRepo_LastCreatedAt_PG(t *testing.T) {
+	pool, _ := withPg(t)
+	ctx := context.Background()
+	tenantID, userID, catID := seedTenant(t, pool)
+	repo := NewTransactionRepo(pool)
+
+	if last, err := repo.LastCreatedAt(ctx, tenantID); err != nil || !last.IsZero() {
+		t.Fatalf("empty tenant: %v %v", last, err)
+	}
+	// a backdated entry is still recorded now
+	tx := seedTransaction(t, pool, tenantID, userID, catID, 1000, time.Now().AddDate(-1, 0, 0))
+	last, err := repo.LastCreatedAt(ctx, tenantID)
+	if err != nil || time.Since(last) > time.Minute {
+		t.Fatalf("last created: %v %v", last, err)
+	}
+	// transactions in the trash don't count
+	if err := repo.Delete(ctx, tx.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if last, err := repo.LastCreatedAt(ctx, tenantID); err != nil || !last.IsZero() {
+		t.Fatalf("only trashed transactions: %v %v", last, err)
+	}
+}
@@ -4,7 +4,15 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"time"
+
+	"github.com/google/uuid"
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+	useuser "github.com/positron48/budget/internal/usecase/user"
 )
 
 type RefreshTokenRepo struct{ pool *Pool }
@@ -16,10 +24,12 @@ func hashToken(token string) string {
 	return hex.EncodeToString(sum[:])
 }
 
+// Store starts a new session (token family); device info is taken from the request context.
 func (r *RefreshTokenRepo) Store(ctx context.Context, userID, tenantID, token string, expiresAt time.Time) error {
+	ip, ua := ctxutil.ClientInfoFromContext(ctx)
 	_, err := r.pool.DB.Exec(ctx,
-		`INSERT INTO refresh_tokens (user_id, tenant_id, token_hash, expires_at) VALUES ($1,$2,$3,$4)`,
-		userID, tenantID, hashToken(token), expiresAt,
+		`INSERT INTO refresh_tokens (user_id, tenant_id, token_hash, expires_at, user_agent, ip) VALUES ($1,$2,$3,$4,NULLIF($5::text,''),NULLIF($6::text,''))`,
+		userID, tenantID, hashToken(token), expiresAt, ua, ip,
 	)
 	return err
 }
@@ -29,6 +39,7 @@ func (r *RefreshTokenRepo) GetByToken(ctx context.Context, token string) (struct
 	TenantID  string
 	ExpiresAt time.Time
 	RevokedAt *time.Time
+	RotatedAt *time.Time
 }, error,
 ) {
 	var row struct {
@@ -36,25 +47,111 @@ func (r *RefreshTokenRepo) GetByToken(ctx context.Context, token string) (struct
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}
 	err := r.pool.DB.QueryRow(ctx,
-		`SELECT user_id, tenant_id, expires_at, revoked_at FROM refresh_tokens WHERE token_hash=$1 ORDER BY created_at DESC LIMIT 1`,
+		`SELECT user_id, tenant_id, expires_at, revoked_at, rotated_at FROM refresh_tokens WHERE token_hash=$1 ORDER BY created_at DESC LIMIT 1`,
 		hashToken(token),
-	).Scan(&row.UserID, &row.TenantID, &row.ExpiresAt, &row.RevokedAt)
+	).Scan(&row.UserID, &row.TenantID, &row.ExpiresAt, &row.RevokedAt, &row.RotatedAt)
 	return row, err
 }
 
+// Rotate revokes the old token, marks it rotated and issues the new one in the same family.
+// Only an active token can be rotated, so concurrent use of one token yields ErrRefreshTokenReused.
 func (r *RefreshTokenRepo) Rotate(ctx context.Context, oldToken, newToken string, newExpiresAt time.Time) error {
-	// mark old as revoked and insert new
-	_, err := r.pool.DB.Exec(ctx,
-		`UPDATE refresh_tokens SET revoked_at=now() WHERE token_hash=$1`, hashToken(oldToken),
+	ip, ua := ctxutil.ClientInfoFromContext(ctx)
+	tag, err := r.pool.DB.Exec(ctx,
+		`WITH old AS (
+            UPDATE refresh_tokens SET revoked_at=now(), rotated_at=now()
+            WHERE token_hash=$1 AND revoked_at IS NULL
+            RETURNING user_id, tenant_id, family_id, user_agent, ip
+        )
+        INSERT INTO refresh_tokens (user_id, tenant_id, token_hash, expires_at, family_id, user_agent, ip)
+        SELECT user_id, tenant_id, $2, $3, family_id, COALESCE(NULLIF($4::text,''), user_agent), COALESCE(NULLIF($5::text,''), ip) FROM old`,
+		hashToken(oldToken), hashToken(newToken), newExpiresAt, ua, ip,
 	)
 	if err != nil {
 		return err
 	}
-	_, err = r.pool.DB.Exec(ctx,
-		`INSERT INTO refresh_tokens (user_id, tenant_id, token_hash, expires_at) SELECT user_id, tenant_id, $1, $2 FROM refresh_tokens WHERE token_hash=$3 LIMIT 1`,
-		hashToken(newToken), newExpiresAt, hashToken(oldToken),
+	if tag.RowsAffected() == 0 {
+		return useauth.ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RevokeFamily revokes every token of the session the given token belongs to.
+func (r *RefreshTokenRepo) RevokeFamily(ctx context.Context, token string) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`UPDATE refresh_tokens SET revoked_at=now()
+         WHERE revoked_at IS NULL AND family_id IN (SELECT family_id FROM refresh_tokens WHERE token_hash=$1)`,
+		hashToken(token),
 	)
 	return err
 }
+
+func (r *RefreshTokenRepo) Revoke(ctx context.Context, token string) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`UPDATE refresh_tokens SET revoked_at=now() WHERE token_hash=$1 AND revoked_at IS NULL`, hashToken(token),
+	)
+	return err
+}
+
+// ListSessions returns active sessions: the live token of each family, with the family start time.
+func (r *RefreshTokenRepo) ListSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	rows, err := r.pool.DB.Query(ctx,
+		`SELECT t.family_id::text, COALESCE(t.tenant_id::text,''), COALESCE(t.user_agent,''), COALESCE(t.ip,''),
+                (SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = t.family_id), t.created_at, t.expires_at
+         FROM refresh_tokens t
+         WHERE t.user_id=$1 AND t.revoked_at IS NULL AND t.expires_at > now()
+         ORDER BY t.created_at DESC`, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.Session
+	for rows.Next() {
+		s := domain.Session{UserID: userID}
+		if err := rows.Scan(&s.ID, &s.TenantID, &s.UserAgent, &s.IP, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *RefreshTokenRepo) SessionIDByToken(ctx context.Context, userID, token string) (string, error) {
+	var id string
+	err := r.pool.DB.QueryRow(ctx,
+		`SELECT family_id::text FROM refresh_tokens WHERE token_hash=$1 AND user_id=$2`, hashToken(token), userID,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", useuser.ErrSessionNotFound
+	}
+	return id, err
+}
+
+func (r *RefreshTokenRepo) RevokeSession(ctx context.Context, userID, sessionID string) (bool, error) {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return false, nil
+	}
+	tag, err := r.pool.DB.Exec(ctx,
+		`UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1 AND family_id=$2 AND revoked_at IS NULL`, userID, sessionID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// RevokeSessionsExcept revokes all sessions of the user but keepSessionID (empty: all of them).
+func (r *RefreshTokenRepo) RevokeSessionsExcept(ctx context.Context, userID, keepSessionID string) (int64, error) {
+	tag, err := r.pool.DB.Exec(ctx,
+		`UPDATE refresh_tokens SET revoked_at=now()
+         WHERE user_id=$1 AND revoked_at IS NULL AND ($2::text = '' OR family_id::text <> $2::text)`, userID, keepSessionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
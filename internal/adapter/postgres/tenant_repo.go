@@ -2,6 +2,10 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
 
 	"github.com/positron48/budget/internal/domain"
 	tenuse "github.com/positron48/budget/internal/usecase/tenant"
@@ -9,11 +13,13 @@ import (
 
 type TenantRepo struct{ pool *Pool }
 
+const tenantColumns = `id, name, COALESCE(slug, ''), default_currency_code, created_at, deletion_scheduled_at, currency_codes`
+
 func NewTenantRepo(pool *Pool) *TenantRepo { return &TenantRepo{pool: pool} }
 
 func (r *TenantRepo) Create(ctx context.Context, name, slug, defaultCurrency, ownerUserID string) (domain.Tenant, error) {
 	var t domain.Tenant
-	err := r.pool.DB.QueryRow(ctx,
+	err := r.pool.Conn(ctx).QueryRow(ctx,
 		`INSERT INTO tenants (name, slug, default_currency_code) VALUES ($1,$2,$3)
          RETURNING id, name, COALESCE(slug, ''), default_currency_code, created_at`,
 		name, slug, defaultCurrency,
@@ -22,7 +28,7 @@ func (r *TenantRepo) Create(ctx context.Context, name, slug, defaultCurrency, ow
 		return domain.Tenant{}, err
 	}
 	// grant owner role to creator
-	if _, err := r.pool.DB.Exec(ctx,
+	if _, err := r.pool.Conn(ctx).Exec(ctx,
 		`INSERT INTO user_tenants (user_id, tenant_id, role, is_default) VALUES ($1,$2,'owner',false) ON CONFLICT DO NOTHING`,
 		ownerUserID, t.ID,
 	); err != nil {
@@ -32,8 +38,8 @@ func (r *TenantRepo) Create(ctx context.Context, name, slug, defaultCurrency, ow
 }
 
 func (r *TenantRepo) ListForUser(ctx context.Context, userID string) ([]domain.TenantMembership, error) {
-	rows, err := r.pool.DB.Query(ctx,
-		`SELECT t.id, t.name, COALESCE(t.slug, ''), t.default_currency_code, t.created_at, ut.role, ut.is_default
+	rows, err := r.pool.Conn(ctx).Query(ctx,
+		`SELECT t.id, t.name, COALESCE(t.slug, ''), t.default_currency_code, t.created_at, t.deletion_scheduled_at, t.currency_codes, ut.role, ut.is_default
          FROM user_tenants ut
          JOIN tenants t ON t.id = ut.tenant_id
          WHERE ut.user_id=$1
@@ -45,7 +51,7 @@ func (r *TenantRepo) ListForUser(ctx context.Context, userID string) ([]domain.T
 	var res []domain.TenantMembership
 	for rows.Next() {
 		var tm domain.TenantMembership
-		if err := rows.Scan(&tm.Tenant.ID, &tm.Tenant.Name, &tm.Tenant.Slug, &tm.Tenant.DefaultCurrencyCode, &tm.Tenant.CreatedAt, &tm.Role, &tm.IsDefault); err != nil {
+		if err := rows.Scan(&tm.Tenant.ID, &tm.Tenant.Name, &tm.Tenant.Slug, &tm.Tenant.DefaultCurrencyCode, &tm.Tenant.CreatedAt, &tm.Tenant.DeletionScheduledAt, &tm.Tenant.CurrencyCodes, &tm.Role, &tm.IsDefault); err != nil {
 			return nil, err
 		}
 		res = append(res, tm)
@@ -55,20 +61,20 @@ func (r *TenantRepo) ListForUser(ctx context.Context, userID string) ([]domain.T
 
 func (r *TenantRepo) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
 	var t domain.Tenant
-	err := r.pool.DB.QueryRow(ctx, `SELECT id, name, COALESCE(slug, ''), default_currency_code, created_at FROM tenants WHERE id=$1`, id).Scan(&t.ID, &t.Name, &t.Slug, &t.DefaultCurrencyCode, &t.CreatedAt)
+	err := r.pool.Conn(ctx).QueryRow(ctx, `SELECT `+tenantColumns+` FROM tenants WHERE id=$1`, id).Scan(&t.ID, &t.Name, &t.Slug, &t.DefaultCurrencyCode, &t.CreatedAt, &t.DeletionScheduledAt, &t.CurrencyCodes)
 	return t, err
 }
 
 // HasMembership returns true if user is a member of tenant
 func (r *TenantRepo) HasMembership(ctx context.Context, userID, tenantID string) (bool, error) {
 	var exists bool
-	err := r.pool.DB.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM user_tenants WHERE user_id=$1 AND tenant_id=$2)`, userID, tenantID).Scan(&exists)
+	err := r.pool.Conn(ctx).QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM user_tenants WHERE user_id=$1 AND tenant_id=$2)`, userID, tenantID).Scan(&exists)
 	return exists, err
 }
 
 // UpdateTenant updates basic tenant fields
 func (r *TenantRepo) UpdateTenant(ctx context.Context, tenantID, name, slug, defaultCurrency string) (domain.Tenant, error) {
-	_, err := r.pool.DB.Exec(ctx,
+	_, err := r.pool.Conn(ctx).Exec(ctx,
 		`UPDATE tenants SET name=COALESCE(NULLIF($2,''), name), slug=CASE WHEN $3='' THEN NULL ELSE $3 END, default_currency_code=COALESCE(NULLIF($4,''), default_currency_code)
          WHERE id=$1`, tenantID, name, slug, defaultCurrency,
 	)
@@ -78,9 +84,21 @@ func (r *TenantRepo) UpdateTenant(ctx context.Context, tenantID, name, slug, def
 	return r.GetByID(ctx, tenantID)
 }
 
+// SetCurrencies replaces the list of currencies pinned by the tenant
+func (r *TenantRepo) SetCurrencies(ctx context.Context, tenantID string, codes []string) (domain.Tenant, error) {
+	if codes == nil {
+		codes = []string{}
+	}
+	var t domain.Tenant
+	err := r.pool.Conn(ctx).QueryRow(ctx,
+		`UPDATE tenants SET currency_codes=$2 WHERE id=$1 RETURNING `+tenantColumns, tenantID, codes,
+	).Scan(&t.ID, &t.Name, &t.Slug, &t.DefaultCurrencyCode, &t.CreatedAt, &t.DeletionScheduledAt, &t.CurrencyCodes)
+	return t, err
+}
+
 // ListMembers returns all memberships with roles
 func (r *TenantRepo) ListMembers(ctx context.Context, tenantID string) ([]domain.TenantMembership, error) {
-	rows, err := r.pool.DB.Query(ctx,
+	rows, err := r.pool.Conn(ctx).Query(ctx,
 		`SELECT t.id, t.name, COALESCE(t.slug, ''), t.default_currency_code, t.created_at,
                 ut.role, ut.is_default, u.id, u.email, COALESCE(u.name,'')
          FROM user_tenants ut
@@ -106,18 +124,18 @@ func (r *TenantRepo) ListMembers(ctx context.Context, tenantID string) ([]domain
 // AddMember adds an existing user by email to a tenant with a role
 func (r *TenantRepo) AddMember(ctx context.Context, tenantID, userEmail string, role domain.TenantRole) (domain.TenantMembership, error) {
 	var userID string
-	if err := r.pool.DB.QueryRow(ctx, `SELECT id FROM users WHERE lower(email)=lower($1)`, userEmail).Scan(&userID); err != nil {
+	if err := r.pool.Conn(ctx).QueryRow(ctx, `SELECT id FROM users WHERE lower(email)=lower($1)`, userEmail).Scan(&userID); err != nil {
 		return domain.TenantMembership{}, err
 	}
 	// Reject if already a member
 	var exists bool
-	if err := r.pool.DB.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM user_tenants WHERE tenant_id=$1 AND user_id=$2)`, tenantID, userID).Scan(&exists); err != nil {
+	if err := r.pool.Conn(ctx).QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM user_tenants WHERE tenant_id=$1 AND user_id=$2)`, tenantID, userID).Scan(&exists); err != nil {
 		return domain.TenantMembership{}, err
 	}
 	if exists {
 		return domain.TenantMembership{}, tenuse.ErrAlreadyMember
 	}
-	if _, err := r.pool.DB.Exec(ctx, `INSERT INTO user_tenants (user_id, tenant_id, role) VALUES ($1,$2,$3) ON CONFLICT (user_id, tenant_id) DO UPDATE SET role=EXCLUDED.role`, userID, tenantID, string(role)); err != nil {
+	if _, err := r.pool.Conn(ctx).Exec(ctx, `INSERT INTO user_tenants (user_id, tenant_id, role) VALUES ($1,$2,$3) ON CONFLICT (user_id, tenant_id) DO UPDATE SET role=EXCLUDED.role`, userID, tenantID, string(role)); err != nil {
 		return domain.TenantMembership{}, err
 	}
 	return r.getMembership(ctx, tenantID, userID)
@@ -125,7 +143,7 @@ func (r *TenantRepo) AddMember(ctx context.Context, tenantID, userEmail string,
 
 // UpdateMemberRole changes an existing member's role
 func (r *TenantRepo) UpdateMemberRole(ctx context.Context, tenantID, userID string, role domain.TenantRole) (domain.TenantMembership, error) {
-	if _, err := r.pool.DB.Exec(ctx, `UPDATE user_tenants SET role=$3 WHERE tenant_id=$1 AND user_id=$2`, tenantID, userID, string(role)); err != nil {
+	if _, err := r.pool.Conn(ctx).Exec(ctx, `UPDATE user_tenants SET role=$3 WHERE tenant_id=$1 AND user_id=$2`, tenantID, userID, string(role)); err != nil {
 		return domain.TenantMembership{}, err
 	}
 	return r.getMembership(ctx, tenantID, userID)
@@ -133,14 +151,14 @@ func (r *TenantRepo) UpdateMemberRole(ctx context.Context, tenantID, userID stri
 
 // RemoveMember removes a user from a tenant
 func (r *TenantRepo) RemoveMember(ctx context.Context, tenantID, userID string) error {
-	_, err := r.pool.DB.Exec(ctx, `DELETE FROM user_tenants WHERE tenant_id=$1 AND user_id=$2`, tenantID, userID)
+	_, err := r.pool.Conn(ctx).Exec(ctx, `DELETE FROM user_tenants WHERE tenant_id=$1 AND user_id=$2`, tenantID, userID)
 	return err
 }
 
 // GetUserRole returns role of a user in a tenant (empty if none)
 func (r *TenantRepo) GetUserRole(ctx context.Context, tenantID, userID string) (domain.TenantRole, error) {
 	var role string
-	err := r.pool.DB.QueryRow(ctx, `SELECT role FROM user_tenants WHERE tenant_id=$1 AND user_id=$2`, tenantID, userID).Scan(&role)
+	err := r.pool.Conn(ctx).QueryRow(ctx, `SELECT role FROM user_tenants WHERE tenant_id=$1 AND user_id=$2`, tenantID, userID).Scan(&role)
 	if err != nil {
 		if err.Error() == "no rows in result set" {
 			return "", nil
@@ -152,7 +170,7 @@ func (r *TenantRepo) GetUserRole(ctx context.Context, tenantID, userID string) (
 
 func (r *TenantRepo) getMembership(ctx context.Context, tenantID, userID string) (domain.TenantMembership, error) {
 	var tm domain.TenantMembership
-	err := r.pool.DB.QueryRow(ctx,
+	err := r.pool.Conn(ctx).QueryRow(ctx,
 		`SELECT t.id, t.name, COALESCE(t.slug, ''), t.default_currency_code, t.created_at,
                 ut.role, ut.is_default, u.id, u.email, COALESCE(u.name,'')
          FROM user_tenants ut
@@ -162,3 +180,119 @@ func (r *TenantRepo) getMembership(ctx context.Context, tenantID, userID string)
 	).Scan(&tm.Tenant.ID, &tm.Tenant.Name, &tm.Tenant.Slug, &tm.Tenant.DefaultCurrencyCode, &tm.Tenant.CreatedAt, &tm.Role, &tm.IsDefault, &tm.UserID, &tm.UserEmail, &tm.UserName)
 	return tm, err
 }
+
+func (r *TenantRepo) CountOwners(ctx context.Context, tenantID string) (int, error) {
+	var n int
+	err := r.pool.Conn(ctx).QueryRow(ctx, `SELECT count(*) FROM user_tenants WHERE tenant_id=$1 AND role='owner'`, tenantID).Scan(&n)
+	return n, err
+}
+
+// TransferOwnership promotes toUserID to owner and demotes fromUserID to admin in one transaction
+func (r *TenantRepo) TransferOwnership(ctx context.Context, tenantID, fromUserID, toUserID string) (from, to domain.TenantMembership, err error) {
+	tx, err := r.pool.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	if _, err := tx.Exec(ctx, `UPDATE user_tenants SET role='owner' WHERE tenant_id=$1 AND user_id=$2`, tenantID, toUserID); err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE user_tenants SET role='admin' WHERE tenant_id=$1 AND user_id=$2`, tenantID, fromUserID); err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	if from, err = r.getMembership(ctx, tenantID, fromUserID); err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	if to, err = r.getMembership(ctx, tenantID, toUserID); err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	return from, to, nil
+}
+
+// SetDefaultTenant makes tenantID the only default membership of the user
+func (r *TenantRepo) SetDefaultTenant(ctx context.Context, userID, tenantID string) error {
+	_, err := r.pool.Conn(ctx).Exec(ctx,
+		`UPDATE user_tenants SET is_default = (tenant_id=$2) WHERE user_id=$1`, userID, tenantID,
+	)
+	return err
+}
+
+func (r *TenantRepo) SetDeletionToken(ctx context.Context, tenantID, userID, token string, expiresAt time.Time) error {
+	_, err := r.pool.Conn(ctx).Exec(ctx,
+		`UPDATE tenants SET deletion_token_hash=$2, deletion_token_expires_at=$3, deletion_requested_by=$4 WHERE id=$1`,
+		tenantID, hashToken(token), expiresAt, userID,
+	)
+	return err
+}
+
+func (r *TenantRepo) ScheduleDeletion(ctx context.Context, tenantID, token string, purgeAt time.Time) (domain.Tenant, error) {
+	var t domain.Tenant
+	err := r.pool.Conn(ctx).QueryRow(ctx,
+		`UPDATE tenants SET deletion_scheduled_at=$3, deletion_token_hash=NULL, deletion_token_expires_at=NULL
+         WHERE id=$1 AND deletion_token_hash=$2 AND deletion_token_expires_at > now() AND deletion_scheduled_at IS NULL
+         RETURNING `+tenantColumns, tenantID, hashToken(token), purgeAt,
+	).Scan(&t.ID, &t.Name, &t.Slug, &t.DefaultCurrencyCode, &t.CreatedAt, &t.DeletionScheduledAt, &t.CurrencyCodes)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Tenant{}, tenuse.ErrInvalidDeletionToken
+	}
+	return t, err
+}
+
+func (r *TenantRepo) CancelDeletion(ctx context.Context, tenantID string) (domain.Tenant, error) {
+	var t domain.Tenant
+	err := r.pool.Conn(ctx).QueryRow(ctx,
+		`UPDATE tenants SET deletion_scheduled_at=NULL, deletion_requested_by=NULL WHERE id=$1 RETURNING `+tenantColumns, tenantID,
+	).Scan(&t.ID, &t.Name, &t.Slug, &t.DefaultCurrencyCode, &t.CreatedAt, &t.DeletionScheduledAt, &t.CurrencyCodes)
+	return t, err
+}
+
+// PurgeScheduledTenants deletes tenants past their grace period. Transactions and categories are
+// deleted first, because transactions.category_id is ON DELETE RESTRICT and the cascade from tenants
+// would hit it; the rest of the dependent rows go with ON DELETE CASCADE.
+func (r *TenantRepo) PurgeScheduledTenants(ctx context.Context, now time.Time) (int64, error) {
+	tx, err := r.pool.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	var ids []string
+	rows, err := tx.Query(ctx,
+		`SELECT id FROM tenants WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1 FOR UPDATE`, now)
+	if err != nil {
+		return 0, err
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	for _, q := range []string{
+		`DELETE FROM transactions WHERE tenant_id = ANY($1::uuid[])`,
+		`DELETE FROM categories WHERE tenant_id = ANY($1::uuid[])`,
+	} {
+		if _, err := tx.Exec(ctx, q, ids); err != nil {
+			return 0, err
+		}
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM tenants WHERE id = ANY($1::uuid[])`, ids)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
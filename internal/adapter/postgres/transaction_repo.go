@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/positron48/budget/internal/domain"
 	txusecase "github.com/positron48/budget/internal/usecase/transaction"
 )
@@ -25,20 +26,23 @@ func (r *TransactionRepo) Create(ctx context.Context, tx domain.Transaction) (do
 		asOf := tx.Fx.AsOf.Truncate(24 * time.Hour)
 		fxAsOf = &asOf
 	}
-	if err := r.pool.DB.QueryRow(ctx,
+	if err := r.pool.Conn(ctx).QueryRow(ctx,
 		`INSERT INTO transactions (tenant_id, user_id, category_id, type, amount_numeric, currency_code,
                                    base_amount_numeric, base_currency_code, fx_rate, fx_provider, fx_as_of, occurred_at, comment, is_extraordinary)
           VALUES ($1,$2,$3,$4,$5::numeric,$6,
-                  CASE WHEN $8::numeric IS NULL THEN $5::numeric ELSE ($5::numeric * $8::numeric) END,
+                  CASE WHEN $8::numeric IS NULL THEN $5::numeric ELSE round($5::numeric * $8::numeric, $14::int) END,
                   $7, $8::numeric, $9, $10, $11, $12, $13)
           RETURNING id`,
 		tx.TenantID, tx.UserID, tx.CategoryID, string(tx.Type),
-		toDecimal(tx.Amount.MinorUnits), tx.Amount.CurrencyCode,
+		toDecimal(tx.Amount.MinorUnits, tx.Amount.CurrencyCode), tx.Amount.CurrencyCode,
 		tx.BaseAmount.CurrencyCode,
-		fxRate, fxProvider, fxAsOf, tx.OccurredAt, tx.Comment, tx.IsExtraordinary,
+		fxRate, fxProvider, fxAsOf, tx.OccurredAt, tx.Comment, tx.IsExtraordinary, domain.CurrencyExponent(tx.BaseAmount.CurrencyCode),
 	).Scan(&id); err != nil {
 		return domain.Transaction{}, err
 	}
+	if err := r.pool.notifyTenantEvent(ctx, tx.TenantID, domain.AuditEntityTransaction, id, domain.AuditActionCreated); err != nil {
+		return domain.Transaction{}, err
+	}
 	return r.Get(ctx, id)
 }
 
@@ -52,24 +56,37 @@ func (r *TransactionRepo) Update(ctx context.Context, tx domain.Transaction) (do
 		asOf := tx.Fx.AsOf.Truncate(24 * time.Hour)
 		fxAsOf = &asOf
 	}
-	_, err := r.pool.DB.Exec(ctx,
+	_, err := r.pool.Conn(ctx).Exec(ctx,
 		`UPDATE transactions
            SET category_id=$2, type=$3, amount_numeric=$4::numeric, currency_code=$5,
-               base_amount_numeric=CASE WHEN $7::numeric IS NULL THEN $4::numeric ELSE ($4::numeric * $7::numeric) END,
+               base_amount_numeric=CASE WHEN $7::numeric IS NULL THEN $4::numeric ELSE round($4::numeric * $7::numeric, $13::int) END,
                base_currency_code=$6, fx_rate=$7::numeric, fx_provider=$8, fx_as_of=$9, occurred_at=$10, comment=$11, is_extraordinary=$12
-         WHERE id=$1`,
-		tx.ID, tx.CategoryID, string(tx.Type), toDecimal(tx.Amount.MinorUnits), tx.Amount.CurrencyCode,
-		tx.BaseAmount.CurrencyCode, fxRate, fxProvider, fxAsOf, tx.OccurredAt, tx.Comment, tx.IsExtraordinary,
+         WHERE id=$1 AND deleted_at IS NULL`,
+		tx.ID, tx.CategoryID, string(tx.Type), toDecimal(tx.Amount.MinorUnits, tx.Amount.CurrencyCode), tx.Amount.CurrencyCode,
+		tx.BaseAmount.CurrencyCode, fxRate, fxProvider, fxAsOf, tx.OccurredAt, tx.Comment, tx.IsExtraordinary, domain.CurrencyExponent(tx.BaseAmount.CurrencyCode),
 	)
 	if err != nil {
 		return domain.Transaction{}, err
 	}
-	return r.Get(ctx, tx.ID)
+	updated, err := r.Get(ctx, tx.ID)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	if err := r.pool.notifyTenantEvent(ctx, updated.TenantID, domain.AuditEntityTransaction, updated.ID, domain.AuditActionUpdated); err != nil {
+		return domain.Transaction{}, err
+	}
+	return updated, nil
 }
 
+// Delete moves the transaction to the trash; it is removed for good by PurgeDeleted
 func (r *TransactionRepo) Delete(ctx context.Context, id string) error {
-	_, err := r.pool.DB.Exec(ctx, `DELETE FROM transactions WHERE id=$1`, id)
-	return err
+	var tenantID string
+	if err := r.pool.Conn(ctx).QueryRow(ctx,
+		`UPDATE transactions SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL RETURNING tenant_id`, id,
+	).Scan(&tenantID); err != nil {
+		return err
+	}
+	return r.pool.notifyTenantEvent(ctx, tenantID, domain.AuditEntityTransaction, id, domain.AuditActionDeleted)
 }
 
 func (r *TransactionRepo) Get(ctx context.Context, id string) (domain.Transaction, error) {
@@ -78,17 +95,17 @@ func (r *TransactionRepo) Get(ctx context.Context, id string) (domain.Transactio
 	var typ string
 	var fxRate, fxProvider *string
 	var fxAsOf *time.Time
-	err := r.pool.DB.QueryRow(ctx,
+	err := r.pool.Conn(ctx).QueryRow(ctx,
 		`SELECT id, tenant_id, user_id, category_id, type::text, amount_numeric::text, currency_code,
                 base_amount_numeric::text, base_currency_code, fx_rate::text, fx_provider, fx_as_of, occurred_at, comment, created_at, is_extraordinary
-           FROM transactions WHERE id=$1`, id,
+           FROM transactions WHERE id=$1 AND deleted_at IS NULL`, id,
 	).Scan(&t.ID, &t.TenantID, &t.UserID, &t.CategoryID, &typ, &amountDec, &t.Amount.CurrencyCode, &baseDec, &t.BaseAmount.CurrencyCode, &fxRate, &fxProvider, &fxAsOf, &t.OccurredAt, &t.Comment, &t.CreatedAt, &t.IsExtraordinary)
 	if err != nil {
 		return domain.Transaction{}, err
 	}
 	t.Type = domain.TransactionType(typ)
-	t.Amount.MinorUnits = fromDecimal(amountDec)
-	t.BaseAmount.MinorUnits = fromDecimal(baseDec)
+	t.Amount.MinorUnits = fromDecimal(amountDec, t.Amount.CurrencyCode)
+	t.BaseAmount.MinorUnits = fromDecimal(baseDec, t.BaseAmount.CurrencyCode)
 	if fxRate != nil && *fxRate != "" {
 		var asOf time.Time
 		if fxAsOf != nil {
@@ -100,41 +117,13 @@ func (r *TransactionRepo) Get(ctx context.Context, id string) (domain.Transactio
 }
 
 func (r *TransactionRepo) List(ctx context.Context, tenantID string, filter txusecase.ListFilter) ([]domain.Transaction, int64, error) {
-	var where []string
-	var args []any
-	add := func(cond string, val any) {
-		where = append(where, fmt.Sprintf(cond, len(args)+1))
-		args = append(args, val)
-	}
-	add("tenant_id=$%d", tenantID)
-	if filter.From != nil {
-		add("occurred_at >= $%d", *filter.From)
-	}
-	if filter.To != nil {
-		add("occurred_at < $%d", *filter.To)
-	}
-	if len(filter.CategoryIDs) > 0 {
-		add("category_id = ANY($%d)", filter.CategoryIDs)
-	}
-	if filter.Type != nil {
-		add("type = $%d", string(*filter.Type))
-	}
-	if filter.MinMinorUnits != nil {
-		add("amount_numeric >= $%d::numeric", toDecimal(*filter.MinMinorUnits))
-	}
-	if filter.MaxMinorUnits != nil {
-		add("amount_numeric <= $%d::numeric", toDecimal(*filter.MaxMinorUnits))
-	}
-	if filter.CurrencyCode != nil && *filter.CurrencyCode != "" {
-		add("currency_code = $%d", *filter.CurrencyCode)
-	}
-	if filter.Search != nil && *filter.Search != "" {
-		add("comment ILIKE $%d", "%"+*filter.Search+"%")
-	}
-	if filter.ExcludeExtraordinary {
-		where = append(where, "is_extraordinary = FALSE")
+	// sorting by category code joins categories, whose columns would clash with unqualified ones
+	needsCategoryJoin := strings.Contains(strings.ToLower(filter.Sort), "category_code")
+	prefix := ""
+	if needsCategoryJoin {
+		prefix = "t."
 	}
-	clause := strings.Join(where, " AND ")
+	clause, args := listWhere(tenantID, filter, prefix)
 
 	// pagination
 	page := filter.Page
@@ -148,7 +137,7 @@ func (r *TransactionRepo) List(ctx context.Context, tenantID string, filter txus
 	offset := (page - 1) * size
 
 	var total int64
-	if err := r.pool.DB.QueryRow(ctx, "SELECT COUNT(*) FROM transactions WHERE "+clause, args...).Scan(&total); err != nil {
+	if err := r.pool.Conn(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM transactions t WHERE "+clause, args...).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
@@ -188,16 +177,13 @@ func (r *TransactionRepo) List(ctx context.Context, tenantID string, filter txus
 		}
 	}
 
-	// Check if we need to join with categories for sorting
-	needsCategoryJoin := strings.Contains(strings.ToLower(filter.Sort), "category_code")
-
 	var query string
 	if needsCategoryJoin {
 		// Replace category_code with c.code in ORDER BY clause
 		orderByWithJoin := strings.ReplaceAll(orderBy, "category_code", "c.code")
 		query = fmt.Sprintf(
 			"SELECT t.id, t.tenant_id, t.user_id, t.category_id, t.type::text, t.amount_numeric::text, t.currency_code, t.base_amount_numeric::text, t.base_currency_code, t.fx_rate::text, t.fx_provider, t.fx_as_of, t.occurred_at, t.comment, t.created_at, t.is_extraordinary FROM transactions t LEFT JOIN categories c ON t.category_id = c.id WHERE %s ORDER BY %s OFFSET $%d LIMIT $%d",
-			strings.ReplaceAll(strings.ReplaceAll(clause, "tenant_id=$1", "t.tenant_id=$1"), "is_extraordinary", "t.is_extraordinary"), orderByWithJoin, offIdx, limIdx,
+			clause, orderByWithJoin, offIdx, limIdx,
 		)
 	} else {
 		query = fmt.Sprintf(
@@ -205,7 +191,7 @@ func (r *TransactionRepo) List(ctx context.Context, tenantID string, filter txus
 			clause, orderBy, offIdx, limIdx,
 		)
 	}
-	rows, err := r.pool.DB.Query(ctx, query, append(args, offset, size)...)
+	rows, err := r.pool.Conn(ctx).Query(ctx, query, append(args, offset, size)...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -220,8 +206,8 @@ func (r *TransactionRepo) List(ctx context.Context, tenantID string, filter txus
 			return nil, 0, err
 		}
 		t.Type = domain.TransactionType(typ)
-		t.Amount.MinorUnits = fromDecimal(amountDec)
-		t.BaseAmount.MinorUnits = fromDecimal(baseDec)
+		t.Amount.MinorUnits = fromDecimal(amountDec, t.Amount.CurrencyCode)
+		t.BaseAmount.MinorUnits = fromDecimal(baseDec, t.BaseAmount.CurrencyCode)
 		if fxRate != nil && *fxRate != "" {
 			var asOf time.Time
 			if fxAsOf != nil {
@@ -236,111 +222,122 @@ func (r *TransactionRepo) List(ctx context.Context, tenantID string, filter txus
 
 // Totals computes income/expense totals in base currency according to filter (ignoring pagination)
 func (r *TransactionRepo) Totals(ctx context.Context, tenantID string, filter txusecase.ListFilter) (int64, int64, string, error) {
+	clause, args := listWhere(tenantID, filter, "")
+
+	// Sum by base amount to avoid FX conversion per-request
+	// base_currency_code is same for tenant (default), but keep it just in case
+	var incomeDec, expenseDec, baseCurrency string
+	err := r.pool.Conn(ctx).QueryRow(ctx,
+		"SELECT "+
+			"COALESCE(SUM(CASE WHEN type='income' THEN base_amount_numeric END), 0)::text AS income, "+
+			"COALESCE(SUM(CASE WHEN type='expense' THEN base_amount_numeric END), 0)::text AS expense, "+
+			"COALESCE(MAX(base_currency_code), '') AS base_ccy "+
+			"FROM transactions WHERE "+clause,
+		args...,
+	).Scan(&incomeDec, &expenseDec, &baseCurrency)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	return fromDecimal(incomeDec, baseCurrency), fromDecimal(expenseDec, baseCurrency), baseCurrency, nil
+}
+
+// listWhere builds the WHERE clause (without the keyword) and its args for a list filter;
+// tenant_id is always $1. Columns are qualified with prefix (e.g. "t.") for queries that join
+// other tables.
+func listWhere(tenantID string, filter txusecase.ListFilter, prefix string) (string, []any) {
 	var where []string
 	var args []any
 	add := func(cond string, val any) {
 		where = append(where, fmt.Sprintf(cond, len(args)+1))
 		args = append(args, val)
 	}
-	add("tenant_id=$%d", tenantID)
+	add(prefix+"tenant_id=$%d", tenantID)
+	where = append(where, prefix+"deleted_at IS NULL")
 	if filter.From != nil {
-		add("occurred_at >= $%d", *filter.From)
+		add(prefix+"occurred_at >= $%d", *filter.From)
 	}
 	if filter.To != nil {
-		add("occurred_at < $%d", *filter.To)
+		add(prefix+"occurred_at < $%d", *filter.To)
+	}
+	if filter.CreatedFrom != nil {
+		add(prefix+"created_at >= $%d", *filter.CreatedFrom)
 	}
 	if len(filter.CategoryIDs) > 0 {
-		add("category_id = ANY($%d)", filter.CategoryIDs)
+		add(prefix+"category_id = ANY($%d)", filter.CategoryIDs)
 	}
 	if filter.Type != nil {
-		add("type = $%d", string(*filter.Type))
+		add(prefix+"type = $%d", string(*filter.Type))
+	}
+	// amount bounds are minor units of the filtered currency (2 decimals when no currency is given)
+	var amountCurrency string
+	if filter.CurrencyCode != nil {
+		amountCurrency = *filter.CurrencyCode
 	}
 	if filter.MinMinorUnits != nil {
-		add("amount_numeric >= $%d::numeric", toDecimal(*filter.MinMinorUnits))
+		add(prefix+"amount_numeric >= $%d::numeric", toDecimal(*filter.MinMinorUnits, amountCurrency))
 	}
 	if filter.MaxMinorUnits != nil {
-		add("amount_numeric <= $%d::numeric", toDecimal(*filter.MaxMinorUnits))
+		add(prefix+"amount_numeric <= $%d::numeric", toDecimal(*filter.MaxMinorUnits, amountCurrency))
 	}
 	if filter.CurrencyCode != nil && *filter.CurrencyCode != "" {
-		add("currency_code = $%d", *filter.CurrencyCode)
+		add(prefix+"currency_code = $%d", *filter.CurrencyCode)
+	}
+	if len(filter.CategoryNames) > 0 {
+		names := make([]string, len(filter.CategoryNames))
+		for i, n := range filter.CategoryNames {
+			names[i] = strings.ToLower(n)
+		}
+		add(prefix+`category_id IN (SELECT cn.id FROM categories cn WHERE cn.tenant_id = $1 AND (lower(cn.code) = ANY($%[1]d)
+              OR EXISTS (SELECT 1 FROM category_i18n ci WHERE ci.category_id = cn.id AND lower(ci.name) = ANY($%[1]d))))`, names)
 	}
+	terms := filter.SearchTerms
 	if filter.Search != nil && *filter.Search != "" {
-		add("comment ILIKE $%d", "%"+*filter.Search+"%")
+		terms = append([]string{*filter.Search}, terms...)
+	}
+	for _, term := range terms {
+		// stemmed match in both languages, with a trigram-indexed substring match for partial words
+		where = append(where, fmt.Sprintf(`(%[3]ssearch_tsv @@ phraseto_tsquery('russian', $%[1]d) OR %[3]ssearch_tsv @@ phraseto_tsquery('english', $%[1]d) OR %[3]scomment ILIKE $%[2]d
+              OR %[3]scategory_id IN (SELECT ci.category_id FROM category_i18n ci
+                                  WHERE ci.search_tsv @@ phraseto_tsquery('russian', $%[1]d) OR ci.search_tsv @@ phraseto_tsquery('english', $%[1]d) OR ci.name ILIKE $%[2]d))`,
+			len(args)+1, len(args)+2, prefix))
+		args = append(args, term, likePattern(term))
 	}
 	if filter.ExcludeExtraordinary {
-		where = append(where, "is_extraordinary = FALSE")
+		where = append(where, prefix+"is_extraordinary = FALSE")
 	}
-	clause := strings.Join(where, " AND ")
-
-	// Sum by base amount to avoid FX conversion per-request
-	// base_currency_code is same for tenant (default), but keep it just in case
-	var incomeDec, expenseDec, baseCurrency string
-	err := r.pool.DB.QueryRow(ctx,
-		"SELECT "+
-			"COALESCE(SUM(CASE WHEN type='income' THEN base_amount_numeric END), 0)::text AS income, "+
-			"COALESCE(SUM(CASE WHEN type='expense' THEN base_amount_numeric END), 0)::text AS expense, "+
-			"COALESCE(MAX(base_currency_code), '') AS base_ccy "+
-			"FROM transactions WHERE "+clause,
-		args...,
-	).Scan(&incomeDec, &expenseDec, &baseCurrency)
-	if err != nil {
-		return 0, 0, "", err
+	if filter.OnlyExtraordinary {
+		where = append(where, prefix+"is_extraordinary = TRUE")
 	}
-	return fromDecimal(incomeDec), fromDecimal(expenseDec), baseCurrency, nil
+	clause := strings.Join(where, " AND ")
+	return clause, args
 }
 
 // Helpers
-func toDecimal(minor int64) string { // 2 decimals
-	neg := minor < 0
-	if neg {
-		minor = -minor
-	}
-	s := fmt.Sprintf("%d", minor)
-	if len(s) <= 2 {
-		s = "0." + strings.Repeat("0", 2-len(s)) + s
-	} else {
-		s = s[:len(s)-2] + "." + s[len(s)-2:]
-	}
-	if neg {
-		s = "-" + s
-	}
-	return s
+// likePattern matches s anywhere in a value, with LIKE wildcards in s escaped
+func likePattern(s string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
 }
 
-func fromDecimal(dec string) int64 { // parse "123.45" → 12345
+func toDecimal(minor int64, currency string) string { // 12345 USD → "123.45", 12345 JPY → "12345"
+	return domain.MinorToDecimal(minor, currency)
+}
+
+func fromDecimal(dec string, currency string) int64 { // "123.45" USD → 12345, rounded to the currency exponent
 	if dec == "" {
 		return 0
 	}
-	neg := strings.HasPrefix(dec, "-")
-	dec = strings.TrimPrefix(dec, "-")
-	parts := strings.SplitN(dec, ".", 2)
-	whole := parts[0]
-	frac := "00"
-	if len(parts) == 2 {
-		frac = parts[1]
-	}
-	if len(frac) < 2 {
-		frac = frac + strings.Repeat("0", 2-len(frac))
-	}
-	if len(frac) > 2 {
-		frac = frac[:2]
-	}
-	numStr := whole + frac
-	var v int64
-	if _, err := fmt.Sscanf(numStr, "%d", &v); err != nil {
+	v, err := domain.DecimalToMinor(dec, currency)
+	if err != nil {
 		return 0
 	}
-	if neg {
-		v = -v
-	}
 	return v
 }
 
 func (r *TransactionRepo) GetDateRange(ctx context.Context, tenantID string) (earliest, latest time.Time, err error) {
 	var earliestTime, latestTime time.Time
 
-	err = r.pool.DB.QueryRow(ctx,
-		`SELECT MIN(occurred_at), MAX(occurred_at) FROM transactions WHERE tenant_id = $1`,
+	err = r.pool.Conn(ctx).QueryRow(ctx,
+		`SELECT MIN(occurred_at), MAX(occurred_at) FROM transactions WHERE tenant_id = $1 AND deleted_at IS NULL`,
 		tenantID,
 	).Scan(&earliestTime, &latestTime)
 	if err != nil {
@@ -355,6 +352,272 @@ func (r *TransactionRepo) GetDateRange(ctx context.Context, tenantID string) (ea
 	return earliestTime, latestTime, nil
 }
 
+// LastCreatedAt returns the created_at of the tenant's latest live transaction, zero if there is none
+func (r *TransactionRepo) LastCreatedAt(ctx context.Context, tenantID string) (time.Time, error) {
+	var last *time.Time
+	if err := r.pool.Conn(ctx).QueryRow(ctx,
+		`SELECT MAX(created_at) FROM transactions WHERE tenant_id = $1 AND deleted_at IS NULL`, tenantID,
+	).Scan(&last); err != nil {
+		return time.Time{}, err
+	}
+	if last == nil {
+		return time.Time{}, nil
+	}
+	return *last, nil
+}
+
+// GetMany returns the tenant's live transactions with the given ids; missing ids are absent from the map
+func (r *TransactionRepo) GetMany(ctx context.Context, tenantID string, ids []string) (map[string]domain.Transaction, error) {
+	res := make(map[string]domain.Transaction, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+	rows, err := r.pool.Conn(ctx).Query(ctx,
+		`SELECT id, tenant_id, user_id, category_id, type::text, amount_numeric::text, currency_code,
+                base_amount_numeric::text, base_currency_code, fx_rate::text, fx_provider, fx_as_of, occurred_at, comment, created_at, is_extraordinary
+           FROM transactions WHERE tenant_id=$1 AND id = ANY($2) AND deleted_at IS NULL`,
+		tenantID, ids,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t domain.Transaction
+		var typ, amountDec, baseDec string
+		var fxRate, fxProvider *string
+		var fxAsOf *time.Time
+		if err := rows.Scan(&t.ID, &t.TenantID, &t.UserID, &t.CategoryID, &typ, &amountDec, &t.Amount.CurrencyCode, &baseDec, &t.BaseAmount.CurrencyCode, &fxRate, &fxProvider, &fxAsOf, &t.OccurredAt, &t.Comment, &t.CreatedAt, &t.IsExtraordinary); err != nil {
+			return nil, err
+		}
+		t.Type = domain.TransactionType(typ)
+		t.Amount.MinorUnits = fromDecimal(amountDec, t.Amount.CurrencyCode)
+		t.BaseAmount.MinorUnits = fromDecimal(baseDec, t.BaseAmount.CurrencyCode)
+		if fxRate != nil && *fxRate != "" {
+			var asOf time.Time
+			if fxAsOf != nil {
+				asOf = fxAsOf.Truncate(24 * time.Hour)
+			}
+			t.Fx = &domain.FxInfo{FromCurrency: t.Amount.CurrencyCode, ToCurrency: t.BaseAmount.CurrencyCode, RateDecimal: *fxRate, Provider: deref(fxProvider), AsOf: asOf}
+		}
+		res[t.ID] = t
+	}
+	return res, rows.Err()
+}
+
+// ListIDs returns ids of transactions matching the filter (pagination and sort are ignored),
+// newest first, at most limit of them
+func (r *TransactionRepo) ListIDs(ctx context.Context, tenantID string, filter txusecase.ListFilter, limit int) ([]string, error) {
+	clause, args := listWhere(tenantID, filter, "")
+	rows, err := r.pool.Conn(ctx).Query(ctx,
+		fmt.Sprintf("SELECT id FROM transactions WHERE %s ORDER BY occurred_at DESC, id LIMIT $%d", clause, len(args)+1),
+		append(args, limit)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// keysetSorts maps a sort field to the column expression and the type its text key is cast back to.
+// Expressions must not be NULL: row comparison against NULL would end the listing.
+var keysetSorts = map[string]struct {
+	expr, cast string
+	join       bool
+}{
+	"occurred_at":      {"t.occurred_at", "timestamptz", false},
+	"created_at":       {"t.created_at", "timestamptz", false},
+	"amount_numeric":   {"CASE WHEN t.type = 'expense' THEN -t.amount_numeric ELSE t.amount_numeric END", "numeric", false},
+	"comment":          {"COALESCE(t.comment, '')", "text", false},
+	"type":             {"t.type", "transaction_type", false},
+	"is_extraordinary": {"t.is_extraordinary", "boolean", false},
+	"category_code":    {"COALESCE(c.code, '')", "text", true},
+}
+
+// ListPage lists transactions ordered by the sort column with id as a tie-breaker, so a
+// cursor (sort key, id) identifies a position exactly. One extra row is fetched to learn
+// whether another page follows.
+func (r *TransactionRepo) ListPage(ctx context.Context, tenantID string, filter txusecase.ListFilter) ([]domain.Transaction, *txusecase.Cursor, int64, error) {
+	clause, args := listWhere(tenantID, filter, "t.")
+	size := filter.PageSize
+	if size <= 0 || size > 500 {
+		size = 50
+	}
+
+	// "field [asc|desc]"; an unknown field or direction falls back to the newest first
+	sortCol, desc := keysetSorts["occurred_at"], true
+	if parts := strings.Fields(strings.ToLower(filter.Sort)); len(parts) > 0 && len(parts) <= 2 {
+		col, ok := keysetSorts[parts[0]]
+		dir := "asc"
+		if len(parts) == 2 {
+			dir = parts[1]
+		}
+		if ok && (dir == "asc" || dir == "desc") {
+			sortCol, desc = col, dir == "desc"
+		}
+	}
+	var total int64 = -1
+	if !filter.SkipCount {
+		if err := r.pool.Conn(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM transactions t WHERE "+clause, args...).Scan(&total); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	from := "transactions t"
+	if sortCol.join {
+		from += " LEFT JOIN categories c ON t.category_id = c.id"
+	}
+
+	dir, cmp := "ASC", ">"
+	if desc {
+		dir, cmp = "DESC", "<"
+	}
+	offset := 0
+	if filter.After != nil {
+		clause += fmt.Sprintf(" AND (%s, t.id) %s ($%d::%s, $%d::uuid)", sortCol.expr, cmp, len(args)+1, sortCol.cast, len(args)+2)
+		args = append(args, filter.After.Key, filter.After.ID)
+	} else if filter.Page > 1 {
+		offset = (filter.Page - 1) * size
+	}
+	query := fmt.Sprintf(
+		"SELECT t.id, t.tenant_id, t.user_id, t.category_id, t.type::text, t.amount_numeric::text, t.currency_code, t.base_amount_numeric::text, t.base_currency_code, t.fx_rate::text, t.fx_provider, t.fx_as_of, t.occurred_at, COALESCE(t.comment, ''), t.created_at, t.is_extraordinary, (%s)::text FROM %s WHERE %s ORDER BY %s %s, t.id %s OFFSET $%d LIMIT $%d",
+		sortCol.expr, from, clause, sortCol.expr, dir, dir, len(args)+1, len(args)+2,
+	)
+	rows, err := r.pool.Conn(ctx).Query(ctx, query, append(args, offset, size+1)...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer rows.Close()
+	var list []domain.Transaction
+	var keys []string
+	for rows.Next() {
+		var t domain.Transaction
+		var typ, amountDec, baseDec, key string
+		var fxRate, fxProvider *string
+		var fxAsOf *time.Time
+		if err := rows.Scan(&t.ID, &t.TenantID, &t.UserID, &t.CategoryID, &typ, &amountDec, &t.Amount.CurrencyCode, &baseDec, &t.BaseAmount.CurrencyCode, &fxRate, &fxProvider, &fxAsOf, &t.OccurredAt, &t.Comment, &t.CreatedAt, &t.IsExtraordinary, &key); err != nil {
+			return nil, nil, 0, err
+		}
+		t.Type = domain.TransactionType(typ)
+		t.Amount.MinorUnits = fromDecimal(amountDec, t.Amount.CurrencyCode)
+		t.BaseAmount.MinorUnits = fromDecimal(baseDec, t.BaseAmount.CurrencyCode)
+		if fxRate != nil && *fxRate != "" {
+			var asOf time.Time
+			if fxAsOf != nil {
+				asOf = fxAsOf.Truncate(24 * time.Hour)
+			}
+			t.Fx = &domain.FxInfo{FromCurrency: t.Amount.CurrencyCode, ToCurrency: t.BaseAmount.CurrencyCode, RateDecimal: *fxRate, Provider: deref(fxProvider), AsOf: asOf}
+		}
+		list = append(list, t)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+	if len(list) <= size {
+		return list, nil, total, nil
+	}
+	list = list[:size]
+	return list, &txusecase.Cursor{Key: keys[size-1], ID: list[size-1].ID}, total, nil
+}
+
+// ListDeleted returns a page of the tenant's transactions in the trash, most recently deleted first
+func (r *TransactionRepo) ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 50
+	}
+	var total int64
+	if err := r.pool.Conn(ctx).QueryRow(ctx,
+		`SELECT COUNT(*) FROM transactions WHERE tenant_id=$1 AND deleted_at IS NOT NULL`, tenantID,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	rows, err := r.pool.Conn(ctx).Query(ctx,
+		`SELECT id, tenant_id, user_id, category_id, type::text, amount_numeric::text, currency_code,
+                base_amount_numeric::text, base_currency_code, fx_rate::text, fx_provider, fx_as_of, occurred_at, comment, created_at, is_extraordinary, deleted_at
+           FROM transactions WHERE tenant_id=$1 AND deleted_at IS NOT NULL
+          ORDER BY deleted_at DESC, id OFFSET $2 LIMIT $3`,
+		tenantID, (page-1)*pageSize, pageSize,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	var list []domain.Transaction
+	for rows.Next() {
+		var t domain.Transaction
+		var typ, amountDec, baseDec string
+		var fxRate, fxProvider *string
+		var fxAsOf *time.Time
+		if err := rows.Scan(&t.ID, &t.TenantID, &t.UserID, &t.CategoryID, &typ, &amountDec, &t.Amount.CurrencyCode, &baseDec, &t.BaseAmount.CurrencyCode, &fxRate, &fxProvider, &fxAsOf, &t.OccurredAt, &t.Comment, &t.CreatedAt, &t.IsExtraordinary, &t.DeletedAt); err != nil {
+			return nil, 0, err
+		}
+		t.Type = domain.TransactionType(typ)
+		t.Amount.MinorUnits = fromDecimal(amountDec, t.Amount.CurrencyCode)
+		t.BaseAmount.MinorUnits = fromDecimal(baseDec, t.BaseAmount.CurrencyCode)
+		if fxRate != nil && *fxRate != "" {
+			var asOf time.Time
+			if fxAsOf != nil {
+				asOf = fxAsOf.Truncate(24 * time.Hour)
+			}
+			t.Fx = &domain.FxInfo{FromCurrency: t.Amount.CurrencyCode, ToCurrency: t.BaseAmount.CurrencyCode, RateDecimal: *fxRate, Provider: deref(fxProvider), AsOf: asOf}
+		}
+		list = append(list, t)
+	}
+	return list, total, rows.Err()
+}
+
+// Restore takes a transaction of the tenant out of the trash. It fails with
+// txusecase.ErrCategoryDeleted while the transaction's category is in the trash too.
+func (r *TransactionRepo) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	tag, err := r.pool.Conn(ctx).Exec(ctx,
+		`UPDATE transactions t SET deleted_at=NULL
+          WHERE t.id=$1 AND t.tenant_id=$2 AND t.deleted_at IS NOT NULL
+            AND EXISTS (SELECT 1 FROM categories c WHERE c.id = t.category_id AND c.deleted_at IS NULL)`,
+		id, tenantID,
+	)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		var trashed bool
+		if err := r.pool.Conn(ctx).QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM transactions WHERE id=$1 AND tenant_id=$2 AND deleted_at IS NOT NULL)`, id, tenantID,
+		).Scan(&trashed); err != nil {
+			return domain.Transaction{}, err
+		}
+		if trashed {
+			return domain.Transaction{}, txusecase.ErrCategoryDeleted
+		}
+		return domain.Transaction{}, pgx.ErrNoRows
+	}
+	if err := r.pool.notifyTenantEvent(ctx, tenantID, domain.AuditEntityTransaction, id, domain.AuditActionRestored); err != nil {
+		return domain.Transaction{}, err
+	}
+	return r.Get(ctx, id)
+}
+
+// PurgeDeleted removes transactions deleted before the cutoff
+func (r *TransactionRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Conn(ctx).Exec(ctx, `DELETE FROM transactions WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
 func strPtr(v string) *string { return &v }
 func deref(p *string) string {
 	if p == nil {
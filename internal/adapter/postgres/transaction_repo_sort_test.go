@@ -1,8 +1,11 @@
 package postgres
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/positron48/budget/internal/domain"
 	txusecase "github.com/positron48/budget/internal/usecase/transaction"
 )
 
@@ -177,3 +180,20 @@ func TestTransactionRepo_AmountSortingLogic(t *testing.T) {
 			expenseAmount, incomeAmount)
 	}
 }
+
+func TestListWhere_QualifiesColumns(t *testing.T) {
+	from, typ, minor, ccy, search := time.Now(), domain.TransactionTypeExpense, int64(100), "USD", "coffee"
+	filter := txusecase.ListFilter{From: &from, To: &from, CreatedFrom: &from, CategoryIDs: []string{"c1"}, Type: &typ,
+		MinMinorUnits: &minor, MaxMinorUnits: &minor, CurrencyCode: &ccy, Search: &search, CategoryNames: []string{"food"},
+		ExcludeExtraordinary: true}
+	clause, args := listWhere("t1", filter, "t.")
+	if len(args) == 0 || args[0] != "t1" {
+		t.Fatalf("tenant must be $1: %v", args)
+	}
+	// every transactions column is qualified, so joins with categories (which has created_at,
+	// tenant_id, deleted_at...) are never ambiguous
+	cols := regexp.MustCompile(`(^|[^.\w])(tenant_id|deleted_at|occurred_at|created_at|category_id|type|amount_numeric|currency_code|search_tsv|comment|is_extraordinary)\b`)
+	if m := cols.FindString(clause); m != "" {
+		t.Fatalf("unqualified column %q in %s", m, clause)
+	}
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	useauth "github.com/positron48/budget/internal/usecase/auth"
+	useuser "github.com/positron48/budget/internal/usecase/user"
+)
+
+// TwoFactorRepo implements TOTP enrollment (usecase/user) and login challenges (usecase/auth).
+type TwoFactorRepo struct {
+	authRateLimits
+	pool *Pool
+}
+
+func NewTwoFactorRepo(pool *Pool) *TwoFactorRepo {
+	return &TwoFactorRepo{authRateLimits: authRateLimits{pool: pool}, pool: pool}
+}
+
+func (r *TwoFactorRepo) GetTOTP(ctx context.Context, userID string) (useauth.TOTPState, error) {
+	var st useauth.TOTPState
+	err := r.pool.DB.QueryRow(ctx,
+		`SELECT secret, enabled_at IS NOT NULL FROM user_totp WHERE user_id=$1`, userID,
+	).Scan(&st.Secret, &st.Enabled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return useauth.TOTPState{}, useauth.ErrTwoFactorNotEnabled
+	}
+	return st, err
+}
+
+func (r *TwoFactorRepo) MarkTOTPStepUsed(ctx context.Context, userID string, step int64) (bool, error) {
+	tag, err := r.pool.DB.Exec(ctx,
+		`UPDATE user_totp SET last_used_step=$2 WHERE user_id=$1 AND last_used_step < $2`, userID, step,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *TwoFactorRepo) ConsumeRecoveryCode(ctx context.Context, userID, code string) (bool, error) {
+	tag, err := r.pool.DB.Exec(ctx,
+		`UPDATE user_recovery_codes SET used_at=now() WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL`,
+		userID, hashToken(code),
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// SaveTOTPSecret replaces a pending secret; an enabled one is kept
+func (r *TwoFactorRepo) SaveTOTPSecret(ctx context.Context, userID, secret string) error {
+	tag, err := r.pool.DB.Exec(ctx,
+		`INSERT INTO user_totp (user_id, secret) VALUES ($1,$2)
+         ON CONFLICT (user_id) DO UPDATE SET secret=EXCLUDED.secret, last_used_step=0, created_at=now()
+         WHERE user_totp.enabled_at IS NULL`,
+		userID, secret,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return useuser.ErrTwoFactorAlreadyEnabled
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) EnableTOTP(ctx context.Context, userID string, step int64, recoveryCodes []string) error {
+	tx, err := r.pool.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	tag, err := tx.Exec(ctx,
+		`UPDATE user_totp SET enabled_at=now(), last_used_step=$2 WHERE user_id=$1 AND enabled_at IS NULL`, userID, step,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return useuser.ErrTwoFactorAlreadyEnabled
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	for _, code := range recoveryCodes {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1,$2)`,
+			userID, hashToken(useauth.NormalizeRecoveryCode(code)),
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TwoFactorRepo) DisableTOTP(ctx context.Context, userID string) error {
+	tx, err := r.pool.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE login_challenges SET used_at=now() WHERE user_id=$1 AND used_at IS NULL`, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TwoFactorRepo) CreateLoginChallenge(ctx context.Context, userID, tenantID, token string, expiresAt time.Time) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`INSERT INTO login_challenges (user_id, tenant_id, token_hash, expires_at) VALUES ($1,NULLIF($2::text,'')::uuid,$3,$4)`,
+		userID, tenantID, hashToken(token), expiresAt,
+	)
+	return err
+}
+
+func (r *TwoFactorRepo) GetLoginChallenge(ctx context.Context, token string) (useauth.LoginChallenge, error) {
+	var ch useauth.LoginChallenge
+	err := r.pool.DB.QueryRow(ctx,
+		`SELECT user_id, COALESCE(tenant_id::text,''), attempts FROM login_challenges
+         WHERE token_hash=$1 AND used_at IS NULL AND expires_at > now()`, hashToken(token),
+	).Scan(&ch.UserID, &ch.TenantID, &ch.Attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return useauth.LoginChallenge{}, useauth.ErrInvalidTwoFactorChallenge
+	}
+	return ch, err
+}
+
+func (r *TwoFactorRepo) IncrementChallengeAttempts(ctx context.Context, token string) error {
+	_, err := r.pool.DB.Exec(ctx, `UPDATE login_challenges SET attempts = attempts + 1 WHERE token_hash=$1`, hashToken(token))
+	return err
+}
+
+func (r *TwoFactorRepo) ConsumeLoginChallenge(ctx context.Context, token string) error {
+	tag, err := r.pool.DB.Exec(ctx,
+		`UPDATE login_challenges SET used_at=now() WHERE token_hash=$1 AND used_at IS NULL`, hashToken(token),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return useauth.ErrInvalidTwoFactorChallenge
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) ListMemberships(ctx context.Context, userID string) ([]useauth.TenantMembership, error) {
+	rows, err := r.pool.DB.Query(ctx,
+		`SELECT tenant_id, role, is_default FROM user_tenants WHERE user_id=$1 ORDER BY is_default DESC`, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ms []useauth.TenantMembership
+	for rows.Next() {
+		var m useauth.TenantMembership
+		if err := rows.Scan(&m.TenantID, &m.Role, &m.IsDefault); err != nil {
+			return nil, err
+		}
+		ms = append(ms, m)
+	}
+	return ms, rows.Err()
+}
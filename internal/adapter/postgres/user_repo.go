@@ -80,8 +80,8 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (useauth.User,
 	email = strings.ToLower(strings.TrimSpace(email))
 	var u useauth.User
 	err := r.pool.DB.QueryRow(ctx,
-		`SELECT id, email, name, locale, password_hash FROM users WHERE email=$1`, email,
-	).Scan(&u.ID, &u.Email, &u.Name, &u.Locale, &u.PasswordHash)
+		`SELECT id, email, name, locale, password_hash, email_verified FROM users WHERE email=$1`, email,
+	).Scan(&u.ID, &u.Email, &u.Name, &u.Locale, &u.PasswordHash, &u.EmailVerified)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return useauth.User{}, nil, useauth.ErrUserNotFound
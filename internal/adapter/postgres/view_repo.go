@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/positron48/budget/internal/domain"
+	viewuse "github.com/positron48/budget/internal/usecase/view"
+)
+
+type ViewRepo struct{ pool *Pool }
+
+func NewViewRepo(pool *Pool) *ViewRepo { return &ViewRepo{pool: pool} }
+
+const savedViewColumns = `id, tenant_id, user_id, name, filter, sort, created_at, updated_at`
+
+func scanSavedView(row pgx.Row) (domain.SavedView, error) {
+	var v domain.SavedView
+	var userID *string
+	var filter []byte
+	if err := row.Scan(&v.ID, &v.TenantID, &userID, &v.Name, &filter, &v.Sort, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return domain.SavedView{}, err
+	}
+	if userID != nil {
+		v.UserID = *userID
+	}
+	if err := json.Unmarshal(filter, &v.Filter); err != nil {
+		return domain.SavedView{}, err
+	}
+	return v, nil
+}
+
+// nullableUser maps the empty owner of a shared view to NULL
+func nullableUser(userID string) *string {
+	if userID == "" {
+		return nil
+	}
+	return &userID
+}
+
+func (r *ViewRepo) Create(ctx context.Context, v domain.SavedView) (domain.SavedView, error) {
+	filter, err := json.Marshal(v.Filter)
+	if err != nil {
+		return domain.SavedView{}, err
+	}
+	return scanSavedView(r.pool.DB.QueryRow(ctx,
+		`INSERT INTO saved_views (tenant_id, user_id, name, filter, sort)
+         VALUES ($1,$2,$3,$4,$5) RETURNING `+savedViewColumns,
+		v.TenantID, nullableUser(v.UserID), v.Name, filter, v.Sort,
+	))
+}
+
+func (r *ViewRepo) Get(ctx context.Context, tenantID, id string) (domain.SavedView, error) {
+	v, err := scanSavedView(r.pool.DB.QueryRow(ctx,
+		`SELECT `+savedViewColumns+` FROM saved_views WHERE tenant_id=$1 AND id=$2`, tenantID, id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.SavedView{}, viewuse.ErrViewNotFound
+	}
+	return v, err
+}
+
+// List returns the personal views of the user and the views shared with the tenant, by name
+func (r *ViewRepo) List(ctx context.Context, tenantID, userID string) ([]domain.SavedView, error) {
+	rows, err := r.pool.DB.Query(ctx,
+		`SELECT `+savedViewColumns+` FROM saved_views
+         WHERE tenant_id=$1 AND (user_id=$2 OR user_id IS NULL)
+         ORDER BY lower(name), user_id NULLS LAST`, tenantID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.SavedView
+	for rows.Next() {
+		v, err := scanSavedView(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// Update replaces the name, filter and sort of a view; the owner can't be changed
+func (r *ViewRepo) Update(ctx context.Context, v domain.SavedView) (domain.SavedView, error) {
+	filter, err := json.Marshal(v.Filter)
+	if err != nil {
+		return domain.SavedView{}, err
+	}
+	out, err := scanSavedView(r.pool.DB.QueryRow(ctx,
+		`UPDATE saved_views SET name=$3, filter=$4, sort=$5, updated_at=now()
+         WHERE tenant_id=$1 AND id=$2 RETURNING `+savedViewColumns,
+		v.TenantID, v.ID, v.Name, filter, v.Sort,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.SavedView{}, viewuse.ErrViewNotFound
+	}
+	return out, err
+}
+
+func (r *ViewRepo) Delete(ctx context.Context, tenantID, id string) error {
+	tag, err := r.pool.DB.Exec(ctx, `DELETE FROM saved_views WHERE tenant_id=$1 AND id=$2`, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return viewuse.ErrViewNotFound
+	}
+	return nil
+}
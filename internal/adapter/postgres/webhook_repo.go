@@ -0,0 +1,262 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/webhook"
+)
+
+type WebhookRepo struct{ pool *Pool }
+
+func NewWebhookRepo(pool *Pool) *WebhookRepo { return &WebhookRepo{pool: pool} }
+
+const webhookSubscriptionColumns = `id, tenant_id, url, description, event_types, secret, active, created_at, updated_at`
+
+func scanWebhookSubscription(row pgx.Row) (domain.WebhookSubscription, error) {
+	var s domain.WebhookSubscription
+	err := row.Scan(&s.ID, &s.TenantID, &s.URL, &s.Description, &s.EventTypes, &s.Secret, &s.Active, &s.CreatedAt, &s.UpdatedAt)
+	return s, err
+}
+
+const webhookDeliveryColumns = `d.id, d.tenant_id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+    d.next_attempt_at, d.last_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.redelivery_of, d.created_at`
+
+func scanWebhookDelivery(row pgx.Row, extra ...any) (domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var status string
+	var redeliveryOf *string
+	dest := []any{&d.ID, &d.TenantID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastAttemptAt, &d.LastStatusCode, &d.LastError, &d.DeliveredAt, &redeliveryOf, &d.CreatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	d.Status = domain.WebhookDeliveryStatus(status)
+	if redeliveryOf != nil {
+		d.RedeliveryOf = *redeliveryOf
+	}
+	return d, nil
+}
+
+// validUUID keeps malformed ids from reaching UUID columns, where they are a query error
+func validUUID(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	return scanWebhookSubscription(r.pool.DB.QueryRow(ctx,
+		`INSERT INTO webhook_subscriptions (tenant_id, url, description, event_types, secret, active)
+         VALUES ($1,$2,$3,$4,$5,$6) RETURNING `+webhookSubscriptionColumns,
+		s.TenantID, s.URL, s.Description, s.EventTypes, s.Secret, s.Active,
+	))
+}
+
+func (r *WebhookRepo) GetSubscription(ctx context.Context, tenantID, id string) (domain.WebhookSubscription, error) {
+	if !validUUID(id) {
+		return domain.WebhookSubscription{}, webhook.ErrSubscriptionNotFound
+	}
+	s, err := scanWebhookSubscription(r.pool.DB.QueryRow(ctx,
+		`SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE tenant_id=$1 AND id=$2`, tenantID, id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WebhookSubscription{}, webhook.ErrSubscriptionNotFound
+	}
+	return s, err
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error) {
+	rows, err := r.pool.DB.Query(ctx,
+		`SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE tenant_id=$1 ORDER BY created_at, id`, tenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.WebhookSubscription
+	for rows.Next() {
+		s, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *WebhookRepo) UpdateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	if !validUUID(s.ID) {
+		return domain.WebhookSubscription{}, webhook.ErrSubscriptionNotFound
+	}
+	out, err := scanWebhookSubscription(r.pool.DB.QueryRow(ctx,
+		`UPDATE webhook_subscriptions SET url=$3, description=$4, event_types=$5, secret=$6, active=$7, updated_at=now()
+         WHERE tenant_id=$1 AND id=$2 RETURNING `+webhookSubscriptionColumns,
+		s.TenantID, s.ID, s.URL, s.Description, s.EventTypes, s.Secret, s.Active,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WebhookSubscription{}, webhook.ErrSubscriptionNotFound
+	}
+	return out, err
+}
+
+func (r *WebhookRepo) DeleteSubscription(ctx context.Context, tenantID, id string) error {
+	if !validUUID(id) {
+		return webhook.ErrSubscriptionNotFound
+	}
+	tag, err := r.pool.DB.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE tenant_id=$1 AND id=$2`, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return webhook.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// Enqueue writes one delivery per active subscription of the tenant to eventType, with the transaction of ctx
+func (r *WebhookRepo) Enqueue(ctx context.Context, tenantID, eventID, eventType string, payload []byte) (int, error) {
+	tag, err := r.pool.Conn(ctx).Exec(ctx,
+		`INSERT INTO webhook_deliveries (tenant_id, subscription_id, event_id, event_type, payload)
+         SELECT tenant_id, id, $2, $3, $4 FROM webhook_subscriptions
+         WHERE tenant_id=$1 AND active AND $3 = ANY(event_types)`,
+		tenantID, eventID, eventType, payload,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ListDeliveries returns a page of the delivery log, newest first
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, tenantID string, f webhook.DeliveryFilter) ([]domain.WebhookDelivery, int64, error) {
+	where := []string{"d.tenant_id=$1"}
+	args := []any{tenantID}
+	if f.SubscriptionID != "" {
+		if !validUUID(f.SubscriptionID) {
+			return nil, 0, nil
+		}
+		args = append(args, f.SubscriptionID)
+		where = append(where, fmt.Sprintf("d.subscription_id=$%d", len(args)))
+	}
+	if f.Status != "" {
+		args = append(args, string(f.Status))
+		where = append(where, fmt.Sprintf("d.status=$%d", len(args)))
+	}
+	clause := strings.Join(where, " AND ")
+
+	page := f.Page
+	if page < 1 {
+		page = 1
+	}
+	size := f.PageSize
+	if size <= 0 || size > 500 {
+		size = 50
+	}
+
+	var total int64
+	if err := r.pool.DB.QueryRow(ctx, "SELECT COUNT(*) FROM webhook_deliveries d WHERE "+clause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	query := fmt.Sprintf(`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries d
+         WHERE %s ORDER BY d.created_at DESC, d.id DESC OFFSET $%d LIMIT $%d`, clause, len(args)+1, len(args)+2)
+	rows, err := r.pool.DB.Query(ctx, query, append(args, (page-1)*size, size)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	var out []domain.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		out = append(out, d)
+	}
+	return out, total, rows.Err()
+}
+
+// Redeliver copies the event of a delivery into a new pending delivery for the same subscription
+func (r *WebhookRepo) Redeliver(ctx context.Context, tenantID, id string) (domain.WebhookDelivery, error) {
+	if !validUUID(id) {
+		return domain.WebhookDelivery{}, webhook.ErrDeliveryNotFound
+	}
+	d, err := scanWebhookDelivery(r.pool.DB.QueryRow(ctx,
+		`INSERT INTO webhook_deliveries AS d (tenant_id, subscription_id, event_id, event_type, payload, redelivery_of)
+         SELECT tenant_id, subscription_id, event_id, event_type, payload, id FROM webhook_deliveries
+         WHERE tenant_id=$1 AND id=$2
+         RETURNING `+webhookDeliveryColumns, tenantID, id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WebhookDelivery{}, webhook.ErrDeliveryNotFound
+	}
+	return d, err
+}
+
+// ClaimDue counts an attempt for the due deliveries and pushes them back by lease; SKIP LOCKED
+// lets several dispatchers share the outbox
+func (r *WebhookRepo) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]webhook.Due, error) {
+	rows, err := r.pool.DB.Query(ctx,
+		`WITH due AS (
+             SELECT id FROM webhook_deliveries
+             WHERE status='pending' AND next_attempt_at <= $1
+             ORDER BY next_attempt_at LIMIT $2
+             FOR UPDATE SKIP LOCKED
+         )
+         UPDATE webhook_deliveries d
+         SET attempts = d.attempts + 1, last_attempt_at = $1, next_attempt_at = $1 + make_interval(secs => $3)
+         FROM due, webhook_subscriptions s
+         WHERE d.id = due.id AND s.id = d.subscription_id
+         RETURNING `+webhookDeliveryColumns+`, s.url, s.secret`,
+		now, limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []webhook.Due
+	for rows.Next() {
+		var item webhook.Due
+		if item.Delivery, err = scanWebhookDelivery(rows, &item.URL, &item.Secret); err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, rows.Err()
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id string, statusCode int, at time.Time) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`UPDATE webhook_deliveries SET status='delivered', delivered_at=$2, last_status_code=$3, last_error=''
+         WHERE id=$1`, id, at, statusCode,
+	)
+	return err
+}
+
+func (r *WebhookRepo) MarkFailed(ctx context.Context, id string, statusCode int, errMsg string, next *time.Time) error {
+	if next == nil {
+		_, err := r.pool.DB.Exec(ctx,
+			`UPDATE webhook_deliveries SET status='failed', last_status_code=$2, last_error=$3 WHERE id=$1`,
+			id, statusCode, errMsg,
+		)
+		return err
+	}
+	_, err := r.pool.DB.Exec(ctx,
+		`UPDATE webhook_deliveries SET last_status_code=$2, last_error=$3, next_attempt_at=$4 WHERE id=$1`,
+		id, statusCode, errMsg, *next,
+	)
+	return err
+}
+
+func (r *WebhookRepo) Release(ctx context.Context, ids []string, at time.Time) error {
+	_, err := r.pool.DB.Exec(ctx,
+		`UPDATE webhook_deliveries SET attempts = attempts - 1, next_attempt_at=$2
+         WHERE id = ANY($1) AND status='pending'`, ids, at,
+	)
+	return err
+}
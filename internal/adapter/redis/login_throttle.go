@@ -0,0 +1,93 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LoginThrottle хранит счётчики неудачных входов (по email и по IP) и временные блокировки.
+// Счётчик живёт window после последней неудачи, блокировка — до своего истечения.
+type LoginThrottle struct {
+	client *Client
+}
+
+func NewLoginThrottle(client *Client) *LoginThrottle {
+	return &LoginThrottle{client: client}
+}
+
+func loginFailKey(key string) string { return fmt.Sprintf("auth:login:fail:%s", key) }
+func loginLockKey(key string) string { return fmt.Sprintf("auth:login:lock:%s", key) }
+
+// Failures возвращает число неудач в текущем окне и время последней
+func (t *LoginThrottle) Failures(ctx context.Context, key string) (int, time.Time, error) {
+	vals, err := t.client.Client.HMGet(ctx, loginFailKey(key), "count", "last").Result()
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to read login failures: %w", err)
+	}
+	count, _ := vals[0].(string)
+	last, _ := vals[1].(string)
+	if count == "" {
+		return 0, time.Time{}, nil
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid login failure counter: %w", err)
+	}
+	ms, _ := strconv.ParseInt(last, 10, 64)
+	return n, time.UnixMilli(ms), nil
+}
+
+// RecordFailure увеличивает счётчик и продлевает окно
+func (t *LoginThrottle) RecordFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	k := loginFailKey(key)
+	var incr *redis.IntCmd
+	_, err := t.client.Client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		incr = p.HIncrBy(ctx, k, "count", 1)
+		p.HSet(ctx, k, "last", time.Now().UnixMilli())
+		p.Expire(ctx, k, window)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return int(incr.Val()), nil
+}
+
+func (t *LoginThrottle) ResetFailures(ctx context.Context, key string) error {
+	if err := t.client.Client.Del(ctx, loginFailKey(key)).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return nil
+}
+
+// Lock блокирует вход по ключу до until
+func (t *LoginThrottle) Lock(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := t.client.Client.Set(ctx, loginLockKey(key), strconv.FormatInt(until.Unix(), 10), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+	return nil
+}
+
+// LockedUntil возвращает нулевое время, если блокировки нет
+func (t *LoginThrottle) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	s, err := t.client.Client.Get(ctx, loginLockKey(key)).Result()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to check login lock: %w", err)
+	}
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid login lock: %w", err)
+	}
+	return time.Unix(sec, 0), nil
+}
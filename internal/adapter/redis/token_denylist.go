@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TokenDenylist хранит отозванные access токены (по jti) и per-user отметку
+// "токены, выпущенные раньше T, недействительны". Записи живут не дольше access TTL:
+// после этого отозванные токены истекают сами.
+type TokenDenylist struct {
+	client    *Client
+	accessTTL time.Duration
+}
+
+func NewTokenDenylist(client *Client, accessTTL time.Duration) *TokenDenylist {
+	return &TokenDenylist{client: client, accessTTL: accessTTL}
+}
+
+func denyJTIKey(jti string) string     { return fmt.Sprintf("auth:deny:jti:%s", jti) }
+func denyUserKey(userID string) string { return fmt.Sprintf("auth:deny:user:%s", userID) }
+
+// RevokeAccessToken добавляет токен в denylist до его истечения
+func (d *TokenDenylist) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := d.client.Client.Set(ctx, denyJTIKey(jti), "1", ttl).Err(); err != nil {
+		return fmt.Errorf("failed to deny access token: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserTokens делает недействительными все access токены пользователя, выпущенные раньше секунды before
+func (d *TokenDenylist) RevokeUserTokens(ctx context.Context, userID string, before time.Time) error {
+	err := d.client.Client.Set(ctx, denyUserKey(userID), strconv.FormatInt(before.Unix(), 10), d.accessTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to store token watermark: %w", err)
+	}
+	return nil
+}
+
+// IsAccessTokenRevoked проверяет jti и отметку пользователя за один запрос
+func (d *TokenDenylist) IsAccessTokenRevoked(ctx context.Context, userID, jti string, issuedAt time.Time) (bool, error) {
+	vals, err := d.client.Client.MGet(ctx, denyJTIKey(jti), denyUserKey(userID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token denylist: %w", err)
+	}
+	if jti != "" && vals[0] != nil {
+		return true, nil
+	}
+	if s, ok := vals[1].(string); ok {
+		watermark, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid token watermark: %w", err)
+		}
+		// iat has second precision: tokens issued in the revocation second (e.g. right
+		// after a password change) stay valid, so only strictly earlier seconds are denied
+		if issuedAt.Unix() < watermark {
+			return true, nil
+		}
+	}
+	return false, nil
+}
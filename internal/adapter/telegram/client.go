@@ -0,0 +1,82 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// DefaultAPIURL is the Telegram Bot API; a compatible stand-in can be used instead.
+const DefaultAPIURL = "https://api.telegram.org"
+
+// Client sends messages through the Bot API (only the sendMessage method).
+type Client struct {
+	apiURL string
+	token  string
+	http   *http.Client
+}
+
+func NewClient(apiURL, token string, client *http.Client) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &Client{apiURL: strings.TrimRight(apiURL, "/"), token: token, http: client}
+}
+
+// SendMessage posts a plain-text message to a chat.
+func (c *Client) SendMessage(ctx context.Context, chatID, text string) error {
+	body, err := json.Marshal(map[string]string{"chat_id": chatID, "text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+"/bot"+c.token+"/sendMessage", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// the URL carries the bot token; don't let it end up in logs
+		return fmt.Errorf("telegram sendMessage: %w", stripURL(err))
+	}
+	defer resp.Body.Close()
+	var out struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil || !out.OK {
+		if out.Description == "" {
+			out.Description = resp.Status
+		}
+		return fmt.Errorf("telegram sendMessage: %s", out.Description)
+	}
+	return nil
+}
+
+// stripURL drops the request URL from a transport error
+func stripURL(err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return ue.Err
+	}
+	return err
+}
+
+// AlertNotifier sends fired alerts to the Telegram chat of their rule.
+type AlertNotifier struct{ client *Client }
+
+func NewAlertNotifier(client *Client) *AlertNotifier { return &AlertNotifier{client: client} }
+
+func (n *AlertNotifier) Notify(ctx context.Context, rule domain.AlertRule, a domain.FiredAlert) error {
+	return n.client.SendMessage(ctx, rule.TelegramChatID, "🔔 "+a.Message)
+}
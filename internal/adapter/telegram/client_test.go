@@ -0,0 +1,56 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// botAPI is a stand-in for the Bot API that records sendMessage calls
+func botAPI(t *testing.T, reply string) (*httptest.Server, *[]map[string]string) {
+	t.Helper()
+	var calls []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/botTOKEN/sendMessage" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		calls = append(calls, body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestAlertNotifier_SendsToRuleChat(t *testing.T) {
+	srv, calls := botAPI(t, `{"ok":true,"result":{}}`)
+	n := NewAlertNotifier(NewClient(srv.URL+"/", "TOKEN", srv.Client()))
+	err := n.Notify(context.Background(), domain.AlertRule{TelegramChatID: "-100500"}, domain.FiredAlert{Message: "Food: over the limit"})
+	if err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if len(*calls) != 1 || (*calls)[0]["chat_id"] != "-100500" || !strings.Contains((*calls)[0]["text"], "Food: over the limit") {
+		t.Fatalf("unexpected calls: %v", *calls)
+	}
+}
+
+func TestClient_SendMessageErrors(t *testing.T) {
+	srv, _ := botAPI(t, `{"ok":false,"description":"Bad Request: chat not found"}`)
+	err := NewClient(srv.URL, "TOKEN", srv.Client()).SendMessage(context.Background(), "1", "hi")
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Fatalf("expected the API description, got %v", err)
+	}
+
+	// transport errors don't leak the token through the request URL
+	err = NewClient("http://127.0.0.1:1", "TOKEN", nil).SendMessage(context.Background(), "1", "hi")
+	if err == nil || strings.Contains(err.Error(), "TOKEN") {
+		t.Fatalf("expected an error without the token, got %v", err)
+	}
+}
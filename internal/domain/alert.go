@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// AlertRuleKind is the condition an alert rule watches.
+type AlertRuleKind string
+
+const (
+	// AlertCategoryMonthlyLimit: expenses of a category in a calendar month exceed the threshold
+	AlertCategoryMonthlyLimit AlertRuleKind = "category_monthly_limit"
+	// AlertLargeExpense: a single expense exceeds the threshold
+	AlertLargeExpense AlertRuleKind = "large_expense"
+	// AlertIncomeBelowLastMonth: the income of a finished month is below the month before
+	AlertIncomeBelowLastMonth AlertRuleKind = "income_below_last_month"
+	// AlertNoTransactions: nothing was recorded for AlertRule.Days days
+	AlertNoTransactions AlertRuleKind = "no_transactions"
+)
+
+// AlertChannel is a way to deliver fired alerts.
+type AlertChannel string
+
+const (
+	AlertChannelEmail    AlertChannel = "email"    // owners and admins of the tenant
+	AlertChannelTelegram AlertChannel = "telegram" // AlertRule.TelegramChatID
+	AlertChannelWebhook  AlertChannel = "webhook"  // webhook subscriptions to alert.fired
+)
+
+// DefaultAlertQuietDays is the AlertNoTransactions period when a rule doesn't set one
+const DefaultAlertQuietDays = 7
+
+// AlertRule watches the transactions of a tenant. Thresholds are in minor units of the
+// tenant's default currency and compared with base amounts.
+type AlertRule struct {
+	ID             string
+	TenantID       string
+	Name           string
+	Kind           AlertRuleKind
+	CategoryID     string // AlertCategoryMonthlyLimit only
+	ThresholdMinor int64  // AlertCategoryMonthlyLimit and AlertLargeExpense
+	Days           int    // AlertNoTransactions only
+	Channels       []AlertChannel
+	TelegramChatID string
+	Timezone       string // IANA name; months are counted in it
+	Active         bool
+	CreatedBy      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FiredAlert is the history entry of a rule that fired. PeriodKey identifies what the alert
+// is about (a month, a transaction, a quiet period), so each fires at most once.
+type FiredAlert struct {
+	ID          string
+	TenantID    string
+	RuleID      string
+	Kind        AlertRuleKind
+	PeriodKey   string
+	Message     string
+	Value       Money // the amount that crossed the threshold, if any
+	FiredAt     time.Time
+	NotifyError string // channels that failed, empty if every notification went out
+}
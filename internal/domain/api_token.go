@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// APITokenPrefix marks personal access tokens, so that they are told apart from JWTs.
+const APITokenPrefix = "bpat_"
+
+type APITokenScope string
+
+const (
+	APITokenScopeRead      APITokenScope = "read"
+	APITokenScopeReadWrite APITokenScope = "read_write"
+)
+
+// APIToken is a long-lived personal access token of a user, scoped to one tenant.
+// Only its hash is stored; Prefix is kept to tell tokens apart in listings.
+type APIToken struct {
+	ID         string
+	UserID     string
+	TenantID   string
+	Name       string
+	Scope      APITokenScope
+	Prefix     string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+}
+
+func IsAPIToken(token string) bool { return strings.HasPrefix(token, APITokenPrefix) }
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreated  AuditAction = "created"
+	AuditActionUpdated  AuditAction = "updated"
+	AuditActionDeleted  AuditAction = "deleted"
+	AuditActionRestored AuditAction = "restored" // brought back from the trash
+)
+
+// Audited entity types
+const (
+	AuditEntityTransaction = "transaction"
+	AuditEntityCategory    = "category"
+	AuditEntityMembership  = "membership" // entity ID is the member's user ID
+	AuditEntityTenant      = "tenant"
+)
+
+// AuditEvent is an append-only record of a change of tenant data. Before and After are
+// JSON snapshots of the entity (empty for created/deleted respectively).
+type AuditEvent struct {
+	ID         string
+	TenantID   string
+	ActorID    string // empty for changes made by the system
+	EntityType string
+	EntityID   string
+	Action     AuditAction
+	Before     json.RawMessage
+	After      json.RawMessage
+	CreatedAt  time.Time
+}
@@ -24,4 +24,5 @@ type Category struct {
 	IsActive     bool
 	CreatedAt    time.Time
 	Translations []CategoryTranslation
+	DeletedAt    *time.Time // set while the category is in the trash
 }
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency (or a crypto asset) with its minor unit exponent:
+// the number of digits after the decimal point (USD 2, JPY 0, KWD 3).
+type Currency struct {
+	Code string
+	// Numeric is the ISO 4217 numeric code ("840"), empty for crypto assets
+	Numeric  string
+	Exponent int
+	Symbol   string
+	// Names maps a language ("en", "ru") to the localized currency name
+	Names map[string]string
+}
+
+// Name returns the currency name in the given locale ("ru", "ru-RU", "en"),
+// falling back to English and then to the code.
+func (c Currency) Name(locale string) string {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if n := c.Names[lang]; n != "" {
+		return n
+	}
+	if n := c.Names["en"]; n != "" {
+		return n
+	}
+	return c.Code
+}
+
+const (
+	// DefaultCurrencyExponent is used for codes missing from the registry.
+	DefaultCurrencyExponent = 2
+	// MaxCurrencyExponent is the largest exponent in the registry; amounts are stored with this scale.
+	MaxCurrencyExponent = 8
+)
+
+var (
+	ErrInvalidAmount   = errors.New("invalid decimal amount")
+	ErrUnknownCurrency = errors.New("unknown currency code")
+)
+
+// currencies.csv lists active ISO 4217 codes plus a few crypto assets. Crypto assets are
+// capped at MaxCurrencyExponent so that an int64 of minor units still holds useful amounts.
+//
+//go:embed currencies.csv
+var currenciesCSV string
+
+var currencyRegistry = mustParseCurrencies(currenciesCSV)
+
+func mustParseCurrencies(data string) map[string]Currency {
+	rows, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		panic("currencies.csv: " + err.Error())
+	}
+	out := make(map[string]Currency, len(rows))
+	for i, row := range rows {
+		if i == 0 {
+			continue // header
+		}
+		if len(row) != 6 {
+			panic(fmt.Sprintf("currencies.csv: line %d: want 6 columns, got %d", i+1, len(row)))
+		}
+		exp, err := strconv.Atoi(row[2])
+		if err != nil || exp < 0 || exp > MaxCurrencyExponent {
+			panic(fmt.Sprintf("currencies.csv: line %d: bad exponent %q", i+1, row[2]))
+		}
+		out[row[0]] = Currency{
+			Code:     row[0],
+			Numeric:  row[1],
+			Exponent: exp,
+			Symbol:   row[3],
+			Names:    map[string]string{"en": row[4], "ru": row[5]},
+		}
+	}
+	return out
+}
+
+// LookupCurrency returns the registry entry for a code (case-insensitive).
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencyRegistry[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// IsCurrencyCode reports whether code is a registry code in its canonical (upper case) form.
+func IsCurrencyCode(code string) bool {
+	_, ok := currencyRegistry[code]
+	return ok
+}
+
+// CurrencyExponent returns the minor unit exponent of a code, DefaultCurrencyExponent if unknown.
+func CurrencyExponent(code string) int {
+	if c, ok := LookupCurrency(code); ok {
+		return c.Exponent
+	}
+	return DefaultCurrencyExponent
+}
+
+// Currencies returns the whole registry sorted by code.
+func Currencies() []Currency {
+	out := make([]Currency, 0, len(currencyRegistry))
+	for _, c := range currencyRegistry {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// MinorToDecimal formats minor units of a currency as a decimal string: 12345 USD → "123.45",
+// 12345 JPY → "12345", 12345 KWD → "12.345".
+func MinorToDecimal(minor int64, currency string) string {
+	exp := CurrencyExponent(currency)
+	neg := minor < 0
+	s := new(big.Int).Abs(big.NewInt(minor)).String()
+	if exp > 0 {
+		if len(s) <= exp {
+			s = strings.Repeat("0", exp-len(s)+1) + s
+		}
+		s = s[:len(s)-exp] + "." + s[len(s)-exp:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// DecimalToMinor parses a decimal string into minor units of a currency, rounding extra
+// digits half away from zero: "1.005" USD → 101, "12.5" JPY → 13.
+func DecimalToMinor(dec string, currency string) (int64, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(dec))
+	if !ok {
+		return 0, ErrInvalidAmount
+	}
+	return RatToMinor(r, currency)
+}
+
+// RatToMinor converts an exact major-unit amount to minor units of a currency, rounding half away from zero.
+func RatToMinor(r *big.Rat, currency string) (int64, error) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(CurrencyExponent(currency))), nil)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))
+	num := new(big.Int).Abs(scaled.Num())
+	den := scaled.Denom()
+	// round half away from zero: (|num| + den/2) / den
+	q := new(big.Int).Quo(num.Add(num, new(big.Int).Rsh(den, 1)), den)
+	if scaled.Sign() < 0 {
+		q.Neg(q)
+	}
+	if !q.IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return q.Int64(), nil
+}
+
+// MinorToRat returns minor units of a currency as an exact major-unit amount.
+func MinorToRat(minor int64, currency string) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(CurrencyExponent(currency))), nil)
+	return new(big.Rat).SetFrac(big.NewInt(minor), scale)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import "testing"
+
+func TestCurrencyExponent(t *testing.T) {
+	for code, want := range map[string]int{"USD": 2, "jpy": 0, "KWD": 3, "CLF": 4, "BTC": 8, "XXX": DefaultCurrencyExponent} {
+		if got := CurrencyExponent(code); got != want {
+			t.Fatalf("%s: got %d, want %d", code, got, want)
+		}
+	}
+	if _, ok := LookupCurrency("ZZZ"); ok {
+		t.Fatal("unknown code must not be found")
+	}
+	for _, c := range Currencies() {
+		if c.Exponent > MaxCurrencyExponent {
+			t.Fatalf("%s exponent %d exceeds the storage scale", c.Code, c.Exponent)
+		}
+	}
+}
+
+func TestMinorDecimalRoundTrip(t *testing.T) {
+	cases := []struct {
+		minor    int64
+		currency string
+		dec      string
+	}{
+		{12345, "USD", "123.45"},
+		{5, "USD", "0.05"},
+		{-5, "RUB", "-0.05"},
+		{12345, "JPY", "12345"},
+		{1250, "KWD", "1.250"},
+		{1, "BTC", "0.00000001"},
+		{0, "EUR", "0.00"},
+	}
+	for _, c := range cases {
+		if got := MinorToDecimal(c.minor, c.currency); got != c.dec {
+			t.Fatalf("MinorToDecimal(%d, %s) = %q, want %q", c.minor, c.currency, got, c.dec)
+		}
+		got, err := DecimalToMinor(c.dec, c.currency)
+		if err != nil || got != c.minor {
+			t.Fatalf("DecimalToMinor(%q, %s) = %d (%v), want %d", c.dec, c.currency, got, err, c.minor)
+		}
+	}
+}
+
+func TestDecimalToMinor_RoundsToExponent(t *testing.T) {
+	cases := []struct {
+		dec      string
+		currency string
+		want     int64
+	}{
+		{"1.005", "USD", 101},
+		{"-1.005", "USD", -101},
+		{"12.5", "JPY", 13},
+		{"100.00000000", "JPY", 100},
+		{"1.2345", "KWD", 1235},
+		{"7", "USD", 700},
+	}
+	for _, c := range cases {
+		if got, err := DecimalToMinor(c.dec, c.currency); err != nil || got != c.want {
+			t.Fatalf("DecimalToMinor(%q, %s) = %d (%v), want %d", c.dec, c.currency, got, err, c.want)
+		}
+	}
+	if _, err := DecimalToMinor("abc", "USD"); err != ErrInvalidAmount {
+		t.Fatalf("expected ErrInvalidAmount, got %v", err)
+	}
+	m, err := MoneyFromDecimal("JPY", "1500")
+	if err != nil || m.MinorUnits != 1500 || m.Decimal() != "1500" {
+		t.Fatalf("money: %+v %v", m, err)
+	}
+}
+
+func TestCurrencyCatalogue(t *testing.T) {
+	usd, ok := LookupCurrency("usd")
+	if !ok || usd.Numeric != "840" || usd.Symbol != "$" {
+		t.Fatalf("unexpected USD entry: %+v", usd)
+	}
+	if got := usd.Name("ru-RU"); got != "Доллар США" {
+		t.Fatalf("ru name: %q", got)
+	}
+	if got := usd.Name("de"); got != "US Dollar" {
+		t.Fatalf("fallback name: %q", got)
+	}
+	if !IsCurrencyCode("EUR") || IsCurrencyCode("eur") || IsCurrencyCode("RUR") {
+		t.Fatal("IsCurrencyCode must accept canonical registry codes only")
+	}
+	seen := map[string]string{}
+	for _, c := range Currencies() {
+		if c.Numeric == "" {
+			continue
+		}
+		if other, dup := seen[c.Numeric]; dup {
+			t.Fatalf("numeric code %s shared by %s and %s", c.Numeric, other, c.Code)
+		}
+		seen[c.Numeric] = c.Code
+	}
+}
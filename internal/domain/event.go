@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// TenantEvent tells watchers of a tenant that an entity changed; they reload it if they care.
+// EntityType is AuditEntityTransaction or AuditEntityCategory.
+type TenantEvent struct {
+	TenantID   string      `json:"tenant_id"`
+	EntityType string      `json:"entity_type"`
+	EntityID   string      `json:"entity_id"`
+	Action     AuditAction `json:"action"`
+	ActorID    string      `json:"actor_id,omitempty"` // empty for changes made by the system
+	OccurredAt time.Time   `json:"occurred_at"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type LoginAttemptStatus string
+
+const (
+	LoginAttemptSuccess LoginAttemptStatus = "success"
+	LoginAttemptFailed  LoginAttemptStatus = "failed"
+	// LoginAttemptBlocked: rejected without checking the password (lockout or delay)
+	LoginAttemptBlocked LoginAttemptStatus = "blocked"
+	// LoginAttemptChallenged: the password was correct, the second factor was requested
+	LoginAttemptChallenged LoginAttemptStatus = "two_factor_required"
+)
+
+// Login methods recorded in the security log; external providers are logged by name.
+const (
+	LoginMethodPassword  = "password"
+	LoginMethodTwoFactor = "two_factor"
+)
+
+// LoginAttempt is an entry of the sign-in security log. UserID is empty when the email
+// doesn't belong to an account.
+type LoginAttempt struct {
+	ID        string
+	UserID    string
+	Email     string
+	Method    string
+	Status    LoginAttemptStatus
+	IPAddress string
+	UserAgent string
+	CreatedAt time.Time
+}
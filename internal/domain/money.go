@@ -1,6 +1,20 @@
 package domain
 
+// Money is an amount in minor units of its currency: the amount times 10^exponent, where the
+// exponent comes from the currency registry (2 for USD, 0 for JPY, 3 for KWD).
 type Money struct {
 	CurrencyCode string
-	MinorUnits   int64 // cents/kopecks (assumed 2 decimals for now)
+	MinorUnits   int64
+}
+
+// Decimal formats the amount in major units, e.g. "123.45".
+func (m Money) Decimal() string { return MinorToDecimal(m.MinorUnits, m.CurrencyCode) }
+
+// MoneyFromDecimal parses a major-unit decimal amount of a currency.
+func MoneyFromDecimal(currency, dec string) (Money, error) {
+	minor, err := DecimalToMinor(dec, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{CurrencyCode: currency, MinorUnits: minor}, nil
 }
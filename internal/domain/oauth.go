@@ -118,4 +118,6 @@ type OAuthConfig struct {
 	MaxAttemptsPerHour  int           // Максимум попыток в час
 	MaxAttemptsPer10Min int           // Максимум попыток за 10 минут
 	WebBaseURL          string        // Базовый URL веб-интерфейса
+	// RequireVerifiedEmail: не выдавать ссылки для неподтвержденных email
+	RequireVerifiedEmail bool
 }
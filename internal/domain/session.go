@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Session is a signed-in device: a chain of refresh tokens issued by rotation.
+type Session struct {
+	ID         string
+	UserID     string
+	TenantID   string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	Current    bool
+}
@@ -8,6 +8,24 @@ type Tenant struct {
 	Slug                string
 	DefaultCurrencyCode string
 	CreatedAt           time.Time
+	// DeletionScheduledAt is set when the tenant is scheduled for deletion (purged after that moment)
+	DeletionScheduledAt *time.Time
+	// CurrencyCodes pins the currencies the tenant uses; empty allows any known currency
+	CurrencyCodes []string
+}
+
+// AllowsCurrency reports whether new transactions in code are accepted by the tenant.
+// The default currency is always allowed.
+func (t Tenant) AllowsCurrency(code string) bool {
+	if len(t.CurrencyCodes) == 0 || code == t.DefaultCurrencyCode {
+		return true
+	}
+	for _, c := range t.CurrencyCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
 }
 
 type TenantRole string
@@ -27,3 +45,28 @@ type TenantMembership struct {
 	UserEmail string
 	UserName  string
 }
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusDeclined InvitationStatus = "declined"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+	InvitationStatusExpired  InvitationStatus = "expired"
+)
+
+// TenantInvitation is an invitation of an email address into a tenant.
+// The token itself is never stored, only its hash.
+type TenantInvitation struct {
+	ID            string
+	Tenant        Tenant
+	Email         string
+	Role          TenantRole
+	InvitedByID   string
+	InvitedByName string
+	Status        InvitationStatus
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	RespondedAt   *time.Time
+}
@@ -30,4 +30,5 @@ type Transaction struct {
 	Comment         string
 	CreatedAt       time.Time
 	IsExtraordinary bool
+	DeletedAt       *time.Time // set while the transaction is in the trash
 }
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// ViewPeriod is a date range relative to the moment a saved view is used.
+type ViewPeriod string
+
+const (
+	ViewPeriodNone       ViewPeriod = "" // no range or ViewFilter.From/To
+	ViewPeriodThisMonth  ViewPeriod = "this_month"
+	ViewPeriodLastMonth  ViewPeriod = "last_month"
+	ViewPeriodThisYear   ViewPeriod = "this_year"
+	ViewPeriodLastYear   ViewPeriod = "last_year"
+	ViewPeriodLast30Days ViewPeriod = "last_30_days"
+)
+
+// ViewFilter is the stored part of a transaction listing. Empty fields don't filter.
+type ViewFilter struct {
+	Period        ViewPeriod      `json:"period,omitempty"`
+	From          *time.Time      `json:"from,omitempty"` // absolute range, used when Period is empty
+	To            *time.Time      `json:"to,omitempty"`
+	CategoryIDs   []string        `json:"category_ids,omitempty"`
+	Type          TransactionType `json:"type,omitempty"`
+	CurrencyCode  string          `json:"currency_code,omitempty"`
+	MinMinorUnits *int64          `json:"min_minor_units,omitempty"`
+	MaxMinorUnits *int64          `json:"max_minor_units,omitempty"`
+	Search        string          `json:"search,omitempty"`
+	Query         string          `json:"query,omitempty"` // search query language
+	// Extraordinary: nil lists all transactions, true only extraordinary ones, false excludes them
+	Extraordinary *bool `json:"extraordinary,omitempty"`
+}
+
+// SavedView is a named filter and sort, personal or shared with the whole tenant.
+type SavedView struct {
+	ID       string
+	TenantID string
+	// UserID owns a personal view; empty for views shared with the tenant
+	UserID    string
+	Name      string
+	Filter    ViewFilter
+	Sort      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (v SavedView) Shared() bool { return v.UserID == "" }
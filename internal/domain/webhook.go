@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook event types a subscription can ask for
+const (
+	WebhookEventTransactionCreated  = "transaction.created"
+	WebhookEventTransactionUpdated  = "transaction.updated"
+	WebhookEventTransactionDeleted  = "transaction.deleted"
+	WebhookEventTransactionRestored = "transaction.restored"
+	WebhookEventAlertFired          = "alert.fired"
+)
+
+// WebhookEventTypes lists every event type a subscription may contain.
+var WebhookEventTypes = []string{
+	WebhookEventTransactionCreated,
+	WebhookEventTransactionUpdated,
+	WebhookEventTransactionDeleted,
+	WebhookEventTransactionRestored,
+	WebhookEventAlertFired,
+}
+
+// IsWebhookEventType reports whether t is a known webhook event type.
+func IsWebhookEventType(t string) bool {
+	for _, et := range WebhookEventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSubscription sends the tenant's events of EventTypes to URL, signed with Secret.
+type WebhookSubscription struct {
+	ID          string
+	TenantID    string
+	URL         string
+	Description string
+	EventTypes  []string
+	Secret      string // HMAC-SHA256 key shared with the receiver
+	Active      bool   // inactive subscriptions get no new deliveries
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // waiting for the first attempt or a retry
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered" // the receiver answered 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // retries exhausted
+)
+
+// WebhookDelivery is one event for one subscription: the outbox entry and its delivery log.
+type WebhookDelivery struct {
+	ID             string
+	TenantID       string
+	SubscriptionID string
+	EventID        string // the same for all subscriptions receiving the event
+	EventType      string
+	Payload        json.RawMessage // request body
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode int    // HTTP status of the last attempt, 0 if there was no response
+	LastError      string // empty after a successful attempt
+	DeliveredAt    *time.Time
+	RedeliveryOf   string // the delivery this one repeats, if it was redelivered manually
+	CreatedAt      time.Time
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// AlertConfig проверка правил оповещений и каналы доставки
+type AlertConfig struct {
+	EvalInterval time.Duration `env:"ALERT_EVAL_INTERVAL" envDefault:"15m"`
+	// TelegramBotToken: без токена канал telegram не настроен, оповещения пишутся в историю с ошибкой
+	TelegramBotToken string `env:"TELEGRAM_BOT_TOKEN"`
+	TelegramAPIURL   string `env:"TELEGRAM_API_URL" envDefault:"https://api.telegram.org"`
+}
+
+func loadAlertConfig() (AlertConfig, error) {
+	ac := AlertConfig{
+		TelegramBotToken: getenv("TELEGRAM_BOT_TOKEN", ""),
+		TelegramAPIURL:   getenv("TELEGRAM_API_URL", "https://api.telegram.org"),
+	}
+	v, err := time.ParseDuration(getenv("ALERT_EVAL_INTERVAL", "15m"))
+	if err != nil {
+		return AlertConfig{}, fmt.Errorf("parse ALERT_EVAL_INTERVAL: %w", err)
+	}
+	if v <= 0 {
+		return AlertConfig{}, fmt.Errorf("ALERT_EVAL_INTERVAL must be positive")
+	}
+	ac.EvalInterval = v
+	return ac, nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// BotConfig настройки Telegram бота (cmd/budgetbot); бот ходит в budgetd по gRPC и не использует БД
+type BotConfig struct {
+	AppEnv           string         `env:"APP_ENV"`
+	TelegramBotToken string         `env:"TELEGRAM_BOT_TOKEN"`
+	TelegramAPIURL   string         `env:"TELEGRAM_API_URL" envDefault:"https://api.telegram.org"`
+	BudgetGRPCAddr   string         `env:"BOT_BUDGET_GRPC_ADDR" envDefault:"localhost:8080"`
+	StateFile        string         `env:"BOT_STATE_FILE" envDefault:"budgetbot-state.json"`
+	Locale           string         `env:"BOT_LOCALE" envDefault:"ru"`
+	Location         *time.Location `env:"BOT_TIMEZONE" envDefault:"UTC"` // границы дня и месяца для /today и /month
+	RequestTimeout   time.Duration  `env:"BOT_REQUEST_TIMEOUT" envDefault:"10s"`
+}
+
+func LoadBotConfig() (BotConfig, error) {
+	bc := BotConfig{
+		AppEnv:           getenv("APP_ENV", "dev"),
+		TelegramBotToken: getenv("TELEGRAM_BOT_TOKEN", ""),
+		TelegramAPIURL:   getenv("TELEGRAM_API_URL", "https://api.telegram.org"),
+		BudgetGRPCAddr:   getenv("BOT_BUDGET_GRPC_ADDR", "localhost:8080"),
+		StateFile:        getenv("BOT_STATE_FILE", "budgetbot-state.json"),
+		Locale:           getenv("BOT_LOCALE", "ru"),
+	}
+	if bc.TelegramBotToken == "" {
+		return BotConfig{}, fmt.Errorf("TELEGRAM_BOT_TOKEN is required")
+	}
+	loc, err := time.LoadLocation(getenv("BOT_TIMEZONE", "UTC"))
+	if err != nil {
+		return BotConfig{}, fmt.Errorf("parse BOT_TIMEZONE: %w", err)
+	}
+	bc.Location = loc
+	if bc.RequestTimeout, err = time.ParseDuration(getenv("BOT_REQUEST_TIMEOUT", "10s")); err != nil {
+		return BotConfig{}, fmt.Errorf("parse BOT_REQUEST_TIMEOUT: %w", err)
+	}
+	if bc.RequestTimeout <= 0 {
+		return BotConfig{}, fmt.Errorf("BOT_REQUEST_TIMEOUT must be positive")
+	}
+	return bc, nil
+}
@@ -2,17 +2,23 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	AppEnv              string
-	GRPCAddr            string
+	AppEnv   string
+	GRPCAddr string
+	// TrustedProxies: адреса прокси, которым доверяем x-forwarded-for; без них IP клиента берется из соединения
+	TrustedProxies      []netip.Prefix
 	DatabaseURL         string
 	RedisURL            string
 	JWTSignKey          string
+	JWTKeys             JWTKeysConfig
 	GoogleClientID      string
+	OIDCProviders       []OIDCProviderConfig
 	AuthPasswordEnabled bool
 	JWTAccessTTL        time.Duration
 	JWTRefreshTTL       time.Duration
@@ -20,6 +26,21 @@ type Config struct {
 	OTelEndpoint        string
 	OTelInsecure        bool
 	OAuth               OAuthConfig
+	Mail                MailConfig
+	PasswordReset       PasswordResetConfig
+	LoginGuard          LoginGuardConfig
+	Webhooks            WebhookConfig
+	Alerts              AlertConfig
+	TenantInvitationTTL time.Duration
+	TenantDeletionGrace time.Duration
+	// TrashRetention: сколько удаленные транзакции и категории хранятся в корзине
+	TrashRetention time.Duration
+	// RequireVerifiedEmail: приглашения и OAuth-ссылки только для подтвержденных email
+	RequireVerifiedEmail bool
+	EmailVerificationTTL time.Duration
+	// 2FA: issuer в otpauth URI и время жизни challenge после пароля
+	TOTPIssuer            string
+	TwoFactorChallengeTTL time.Duration
 }
 
 func getenv(key, def string) string {
@@ -29,6 +50,31 @@ func getenv(key, def string) string {
 	return def
 }
 
+// parsePrefixes читает список сетей через запятую; одиночный адрес означает сеть из одного адреса
+func parsePrefixes(key string) ([]netip.Prefix, error) {
+	var out []netip.Prefix
+	for _, item := range strings.Split(getenv(key, ""), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			addr, err := netip.ParseAddr(item)
+			if err != nil {
+				return nil, fmt.Errorf("parse %s: %w", key, err)
+			}
+			out = append(out, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", key, err)
+		}
+		out = append(out, prefix.Masked())
+	}
+	return out, nil
+}
+
 func Load() (Config, error) {
 	var cfg Config
 
@@ -53,12 +99,25 @@ func Load() (Config, error) {
 		return Config{}, fmt.Errorf("REDIS_URL is required")
 	}
 
+	var err error
+	if cfg.JWTKeys, err = loadJWTKeysConfig(); err != nil {
+		return Config{}, err
+	}
+	// with RS256/EdDSA the shared key is optional: it only verifies tokens issued before the switch
 	cfg.JWTSignKey = getenv("JWT_SIGN_KEY", "")
-	if cfg.JWTSignKey == "" {
+	if cfg.JWTSignKey == "" && cfg.JWTKeys.Algorithm == "HS256" {
 		return Config{}, fmt.Errorf("JWT_SIGN_KEY is required")
 	}
 	cfg.GoogleClientID = getenv("GOOGLE_CLIENT_ID", "")
+	if cfg.OIDCProviders, err = loadOIDCProviders(); err != nil {
+		return Config{}, err
+	}
 	cfg.AuthPasswordEnabled = getenv("AUTH_PASSWORD_ENABLED", "true") == "true"
+	cfg.RequireVerifiedEmail = getenv("REQUIRE_VERIFIED_EMAIL", "false") == "true"
+
+	if cfg.TrustedProxies, err = parsePrefixes("TRUSTED_PROXIES"); err != nil {
+		return Config{}, err
+	}
 
 	cfg.MetricsAddr = getenv("METRICS_ADDR", "")
 	cfg.OTelEndpoint = getenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
@@ -74,7 +133,6 @@ func Load() (Config, error) {
 		return Config{}, fmt.Errorf("JWT_REFRESH_TTL is required")
 	}
 
-	var err error
 	if cfg.JWTAccessTTL, err = time.ParseDuration(access); err != nil {
 		return Config{}, fmt.Errorf("parse JWT_ACCESS_TTL: %w", err)
 	}
@@ -82,8 +140,41 @@ func Load() (Config, error) {
 		return Config{}, fmt.Errorf("parse JWT_REFRESH_TTL: %w", err)
 	}
 
+	if cfg.TenantInvitationTTL, err = time.ParseDuration(getenv("TENANT_INVITATION_TTL", "168h")); err != nil {
+		return Config{}, fmt.Errorf("parse TENANT_INVITATION_TTL: %w", err)
+	}
+	if cfg.TenantDeletionGrace, err = time.ParseDuration(getenv("TENANT_DELETION_GRACE", "168h")); err != nil {
+		return Config{}, fmt.Errorf("parse TENANT_DELETION_GRACE: %w", err)
+	}
+	if cfg.TrashRetention, err = time.ParseDuration(getenv("TRASH_RETENTION", "720h")); err != nil {
+		return Config{}, fmt.Errorf("parse TRASH_RETENTION: %w", err)
+	}
+	if cfg.EmailVerificationTTL, err = time.ParseDuration(getenv("EMAIL_VERIFICATION_TTL", "48h")); err != nil {
+		return Config{}, fmt.Errorf("parse EMAIL_VERIFICATION_TTL: %w", err)
+	}
+
+	cfg.TOTPIssuer = getenv("TOTP_ISSUER", "Budget")
+	if cfg.TwoFactorChallengeTTL, err = time.ParseDuration(getenv("TWO_FACTOR_CHALLENGE_TTL", "5m")); err != nil {
+		return Config{}, fmt.Errorf("parse TWO_FACTOR_CHALLENGE_TTL: %w", err)
+	}
+
 	// Загрузка OAuth конфигурации
 	cfg.OAuth = loadOAuthConfig()
+	if cfg.Mail, err = loadMailConfig(); err != nil {
+		return Config{}, err
+	}
+	if cfg.PasswordReset, err = loadPasswordResetConfig(); err != nil {
+		return Config{}, err
+	}
+	if cfg.LoginGuard, err = loadLoginGuardConfig(); err != nil {
+		return Config{}, err
+	}
+	if cfg.Webhooks, err = loadWebhookConfig(); err != nil {
+		return Config{}, err
+	}
+	if cfg.Alerts, err = loadAlertConfig(); err != nil {
+		return Config{}, err
+	}
 
 	return cfg, nil
 }
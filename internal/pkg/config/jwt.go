@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JWTKeysConfig асимметричная подпись access токенов (RS256/EdDSA).
+// С HS256 используется только JWT_SIGN_KEY.
+type JWTKeysConfig struct {
+	Algorithm      string `env:"JWT_SIGN_ALG" envDefault:"HS256"`
+	SigningKeyFile string `env:"JWT_SIGNING_KEY_FILE"`
+	SigningKeyPEM  string `env:"JWT_SIGNING_KEY_PEM"`
+	SigningKeyID   string `env:"JWT_SIGNING_KEY_ID"`
+	// VerificationKeyFiles – предыдущие ключи, которые еще принимаются (kid=путь,kid2=путь2)
+	VerificationKeyFiles map[string]string `env:"JWT_VERIFICATION_KEY_FILES"`
+	// VerificationKeyPEMs – те же ключи прямо в переменной (kid=PEM,kid2=PEM), при совпадении kid важнее файла
+	VerificationKeyPEMs map[string]string `env:"JWT_VERIFICATION_KEYS_PEM"`
+}
+
+func loadJWTKeysConfig() (JWTKeysConfig, error) {
+	keys := JWTKeysConfig{
+		Algorithm:      getenv("JWT_SIGN_ALG", "HS256"),
+		SigningKeyFile: getenv("JWT_SIGNING_KEY_FILE", ""),
+		SigningKeyPEM:  getenv("JWT_SIGNING_KEY_PEM", ""),
+		SigningKeyID:   getenv("JWT_SIGNING_KEY_ID", ""),
+	}
+	switch keys.Algorithm {
+	case "HS256":
+		return keys, nil
+	case "RS256", "EdDSA":
+	default:
+		return JWTKeysConfig{}, fmt.Errorf("unsupported JWT_SIGN_ALG %q", keys.Algorithm)
+	}
+	if keys.SigningKeyFile == "" && keys.SigningKeyPEM == "" {
+		return JWTKeysConfig{}, fmt.Errorf("JWT_SIGNING_KEY_FILE or JWT_SIGNING_KEY_PEM is required for %s", keys.Algorithm)
+	}
+	var err error
+	if keys.VerificationKeyFiles, err = parseKeyList("JWT_VERIFICATION_KEY_FILES", "path"); err != nil {
+		return JWTKeysConfig{}, err
+	}
+	if keys.VerificationKeyPEMs, err = parseKeyList("JWT_VERIFICATION_KEYS_PEM", "PEM"); err != nil {
+		return JWTKeysConfig{}, err
+	}
+	return keys, nil
+}
+
+// parseKeyList читает список kid=значение через запятую; в base64 PEM запятых нет, а '=' режется по первому
+func parseKeyList(name, what string) (map[string]string, error) {
+	raw := getenv(name, "")
+	if raw == "" {
+		return nil, nil
+	}
+	out := map[string]string{}
+	for _, item := range strings.Split(raw, ",") {
+		kid, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || kid == "" || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("parse %s: expected kid=%s, got %q", name, what, item)
+		}
+		out[kid] = value
+	}
+	return out, nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginGuardConfig защита входа по паролю от перебора (счётчики в Redis)
+type LoginGuardConfig struct {
+	FailureWindow time.Duration `env:"LOGIN_FAILURE_WINDOW" envDefault:"15m"`
+	FreeAttempts  int           `env:"LOGIN_FREE_ATTEMPTS" envDefault:"3"`
+	BaseDelay     time.Duration `env:"LOGIN_BASE_DELAY" envDefault:"1s"`
+	MaxDelay      time.Duration `env:"LOGIN_MAX_DELAY" envDefault:"30s"`
+	EmailLockAt   int           `env:"LOGIN_EMAIL_LOCK_AT" envDefault:"10"`
+	IPLockAt      int           `env:"LOGIN_IP_LOCK_AT" envDefault:"50"`
+	LockDuration  time.Duration `env:"LOGIN_LOCK_DURATION" envDefault:"15m"`
+}
+
+func loadLoginGuardConfig() (LoginGuardConfig, error) {
+	var lg LoginGuardConfig
+	durations := []struct {
+		key, def string
+		dst      *time.Duration
+	}{
+		{"LOGIN_FAILURE_WINDOW", "15m", &lg.FailureWindow},
+		{"LOGIN_BASE_DELAY", "1s", &lg.BaseDelay},
+		{"LOGIN_MAX_DELAY", "30s", &lg.MaxDelay},
+		{"LOGIN_LOCK_DURATION", "15m", &lg.LockDuration},
+	}
+	for _, d := range durations {
+		v, err := time.ParseDuration(getenv(d.key, d.def))
+		if err != nil {
+			return LoginGuardConfig{}, fmt.Errorf("parse %s: %w", d.key, err)
+		}
+		*d.dst = v
+	}
+	ints := []struct {
+		key, def string
+		dst      *int
+	}{
+		{"LOGIN_FREE_ATTEMPTS", "3", &lg.FreeAttempts},
+		{"LOGIN_EMAIL_LOCK_AT", "10", &lg.EmailLockAt},
+		{"LOGIN_IP_LOCK_AT", "50", &lg.IPLockAt},
+	}
+	for _, n := range ints {
+		if _, err := fmt.Sscanf(getenv(n.key, n.def), "%d", n.dst); err != nil {
+			return LoginGuardConfig{}, fmt.Errorf("parse %s: %w", n.key, err)
+		}
+	}
+	return lg, nil
+}
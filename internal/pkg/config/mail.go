@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// MailConfig конфигурация отправки писем
+type MailConfig struct {
+	// SMTP relay; если SMTPHost пуст – письма складываются в OutboxDir
+	SMTPHost     string `env:"MAIL_SMTP_HOST"`
+	SMTPPort     int    `env:"MAIL_SMTP_PORT" envDefault:"587"`
+	SMTPUsername string `env:"MAIL_SMTP_USERNAME"`
+	SMTPPassword string `env:"MAIL_SMTP_PASSWORD"`
+	// OutboxDir – каталог, куда складываются письма (.eml); пусто – письма не отправляются
+	OutboxDir string `env:"MAIL_OUTBOX_DIR"`
+	From      string `env:"MAIL_FROM" envDefault:"Budget <no-reply@localhost>"`
+}
+
+// PasswordResetConfig конфигурация сброса пароля
+type PasswordResetConfig struct {
+	TokenTTL           time.Duration `env:"PASSWORD_RESET_TTL" envDefault:"1h"`
+	MaxPerEmailPerHour int           `env:"PASSWORD_RESET_MAX_PER_EMAIL_PER_HOUR" envDefault:"3"`
+	MaxPerIPPerHour    int           `env:"PASSWORD_RESET_MAX_PER_IP_PER_HOUR" envDefault:"10"`
+}
+
+func loadMailConfig() (MailConfig, error) {
+	mail := MailConfig{
+		SMTPHost:     getenv("MAIL_SMTP_HOST", ""),
+		SMTPUsername: getenv("MAIL_SMTP_USERNAME", ""),
+		SMTPPassword: getenv("MAIL_SMTP_PASSWORD", ""),
+		OutboxDir:    getenv("MAIL_OUTBOX_DIR", ""),
+		From:         getenv("MAIL_FROM", "Budget <no-reply@localhost>"),
+	}
+	if _, err := fmt.Sscanf(getenv("MAIL_SMTP_PORT", "587"), "%d", &mail.SMTPPort); err != nil {
+		return MailConfig{}, fmt.Errorf("parse MAIL_SMTP_PORT: %w", err)
+	}
+	return mail, nil
+}
+
+func loadPasswordResetConfig() (PasswordResetConfig, error) {
+	var pr PasswordResetConfig
+	var err error
+	if pr.TokenTTL, err = time.ParseDuration(getenv("PASSWORD_RESET_TTL", "1h")); err != nil {
+		return PasswordResetConfig{}, fmt.Errorf("parse PASSWORD_RESET_TTL: %w", err)
+	}
+	if _, err := fmt.Sscanf(getenv("PASSWORD_RESET_MAX_PER_EMAIL_PER_HOUR", "3"), "%d", &pr.MaxPerEmailPerHour); err != nil {
+		return PasswordResetConfig{}, fmt.Errorf("parse PASSWORD_RESET_MAX_PER_EMAIL_PER_HOUR: %w", err)
+	}
+	if _, err := fmt.Sscanf(getenv("PASSWORD_RESET_MAX_PER_IP_PER_HOUR", "10"), "%d", &pr.MaxPerIPPerHour); err != nil {
+		return PasswordResetConfig{}, fmt.Errorf("parse PASSWORD_RESET_MAX_PER_IP_PER_HOUR: %w", err)
+	}
+	return pr, nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// OIDCProviderConfig провайдер OpenID Connect (Keycloak, Authentik, Authelia, Yandex...).
+// Для провайдера NAME из OIDC_PROVIDERS читаются OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID,
+// OIDC_<NAME>_JWKS_URL и OIDC_<NAME>_TRUST_EMAIL.
+type OIDCProviderConfig struct {
+	Name      string
+	IssuerURL string
+	ClientID  string
+	// JWKSURL – если пусто, берется из /.well-known/openid-configuration
+	JWKSURL string
+	// TrustEmail – провайдер проверяет email: первый вход привязывается к существующему
+	// аккаунту с тем же email. Иначе аккаунт нужно привязать вручную (LinkIdentity)
+	TrustEmail bool
+}
+
+var oidcProviderName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func loadOIDCProviders() ([]OIDCProviderConfig, error) {
+	raw := getenv("OIDC_PROVIDERS", "")
+	if raw == "" {
+		return nil, nil
+	}
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !oidcProviderName.MatchString(name) {
+			return nil, fmt.Errorf("invalid OIDC provider name %q", name)
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		p := OIDCProviderConfig{
+			Name:       name,
+			IssuerURL:  getenv(prefix+"ISSUER", ""),
+			ClientID:   getenv(prefix+"CLIENT_ID", ""),
+			JWKSURL:    getenv(prefix+"JWKS_URL", ""),
+			TrustEmail: getenv(prefix+"TRUST_EMAIL", "false") == "true",
+		}
+		if p.IssuerURL == "" || p.ClientID == "" {
+			return nil, fmt.Errorf("%sISSUER and %sCLIENT_ID are required", prefix, prefix)
+		}
+		providers = append(providers, p)
+	}
+	return providers, nil
+}
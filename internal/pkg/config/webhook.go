@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+// WebhookConfig отправка исходящих вебхуков из outbox
+type WebhookConfig struct {
+	DispatchInterval time.Duration `env:"WEBHOOK_DISPATCH_INTERVAL" envDefault:"5s"`
+	BatchSize        int           `env:"WEBHOOK_BATCH_SIZE" envDefault:"50"`
+	MaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"10"`
+	RetryBaseDelay   time.Duration `env:"WEBHOOK_RETRY_BASE_DELAY" envDefault:"30s"`
+	RetryMaxDelay    time.Duration `env:"WEBHOOK_RETRY_MAX_DELAY" envDefault:"6h"`
+	Timeout          time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	// Сети, куда разрешено доставлять вебхуки помимо публичных адресов (например, внутренний приёмник)
+	AllowedNetworks []netip.Prefix `env:"WEBHOOK_ALLOWED_NETWORKS"`
+}
+
+func loadWebhookConfig() (WebhookConfig, error) {
+	var wh WebhookConfig
+	durations := []struct {
+		key, def string
+		dst      *time.Duration
+	}{
+		{"WEBHOOK_DISPATCH_INTERVAL", "5s", &wh.DispatchInterval},
+		{"WEBHOOK_RETRY_BASE_DELAY", "30s", &wh.RetryBaseDelay},
+		{"WEBHOOK_RETRY_MAX_DELAY", "6h", &wh.RetryMaxDelay},
+		{"WEBHOOK_TIMEOUT", "10s", &wh.Timeout},
+	}
+	for _, d := range durations {
+		v, err := time.ParseDuration(getenv(d.key, d.def))
+		if err != nil {
+			return WebhookConfig{}, fmt.Errorf("parse %s: %w", d.key, err)
+		}
+		*d.dst = v
+	}
+	ints := []struct {
+		key, def string
+		dst      *int
+	}{
+		{"WEBHOOK_BATCH_SIZE", "50", &wh.BatchSize},
+		{"WEBHOOK_MAX_ATTEMPTS", "10", &wh.MaxAttempts},
+	}
+	for _, n := range ints {
+		if _, err := fmt.Sscanf(getenv(n.key, n.def), "%d", n.dst); err != nil {
+			return WebhookConfig{}, fmt.Errorf("parse %s: %w", n.key, err)
+		}
+	}
+	networks, err := parsePrefixes("WEBHOOK_ALLOWED_NETWORKS")
+	if err != nil {
+		return WebhookConfig{}, err
+	}
+	wh.AllowedNetworks = networks
+	if wh.DispatchInterval <= 0 {
+		return WebhookConfig{}, fmt.Errorf("WEBHOOK_DISPATCH_INTERVAL must be positive")
+	}
+	return wh, nil
+}
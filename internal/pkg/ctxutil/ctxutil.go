@@ -1,14 +1,26 @@
 package ctxutil
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ctxKey string
 
 const (
 	keyUserID   ctxKey = "user_id"
 	keyTenantID ctxKey = "tenant_id"
+	keyClient   ctxKey = "client_info"
+	keyToken    ctxKey = "access_token"
 )
 
+type clientInfo struct{ ip, userAgent string }
+
+type accessToken struct {
+	id        string
+	expiresAt time.Time
+}
+
 func WithUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, keyUserID, userID)
 }
@@ -26,3 +38,23 @@ func TenantIDFromContext(ctx context.Context) (string, bool) {
 	v, ok := ctx.Value(keyTenantID).(string)
 	return v, ok && v != ""
 }
+
+// WithClientInfo stores the caller IP and User-Agent (used to describe sessions).
+func WithClientInfo(ctx context.Context, ip, userAgent string) context.Context {
+	return context.WithValue(ctx, keyClient, clientInfo{ip: ip, userAgent: userAgent})
+}
+
+func ClientInfoFromContext(ctx context.Context) (ip, userAgent string) {
+	v, _ := ctx.Value(keyClient).(clientInfo)
+	return v.ip, v.userAgent
+}
+
+// WithAccessToken stores the jti and expiry of the access token the request was authenticated with.
+func WithAccessToken(ctx context.Context, jti string, expiresAt time.Time) context.Context {
+	return context.WithValue(ctx, keyToken, accessToken{id: jti, expiresAt: expiresAt})
+}
+
+func AccessTokenFromContext(ctx context.Context) (jti string, expiresAt time.Time, ok bool) {
+	v, ok := ctx.Value(keyToken).(accessToken)
+	return v.id, v.expiresAt, ok && v.id != ""
+}
@@ -3,6 +3,7 @@ package ctxutil
 import (
 	"context"
 	"testing"
+	"time"
 )
 
 func TestUserTenantContext(t *testing.T) {
@@ -23,3 +24,25 @@ func TestUserTenantContext(t *testing.T) {
 		t.Fatalf("want t1, got %q, ok=%v", tid, ok)
 	}
 }
+
+func TestClientInfoContext(t *testing.T) {
+	ip, ua := ClientInfoFromContext(context.Background())
+	if ip != "" || ua != "" {
+		t.Fatalf("expected empty client info, got %q %q", ip, ua)
+	}
+	ip, ua = ClientInfoFromContext(WithClientInfo(context.Background(), "10.0.0.1", "curl/8"))
+	if ip != "10.0.0.1" || ua != "curl/8" {
+		t.Fatalf("unexpected client info %q %q", ip, ua)
+	}
+}
+
+func TestAccessTokenContext(t *testing.T) {
+	if _, _, ok := AccessTokenFromContext(context.Background()); ok {
+		t.Fatal("expected no access token")
+	}
+	exp := time.Now().Add(time.Minute)
+	jti, gotExp, ok := AccessTokenFromContext(WithAccessToken(context.Background(), "j1", exp))
+	if !ok || jti != "j1" || !gotExp.Equal(exp) {
+		t.Fatalf("unexpected token %q %v %v", jti, gotExp, ok)
+	}
+}
@@ -0,0 +1,61 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B, SHA1 key "12345678901234567890" (last 6 digits of the 8-digit values)
+func TestCode_RFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	cases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for ts, want := range cases {
+		got, err := Code(secret, Step(time.Unix(ts, 0)))
+		if err != nil || got != want {
+			t.Fatalf("t=%d: got %q, %v; want %q", ts, got, err, want)
+		}
+	}
+}
+
+func TestValidate_Skew(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	prev, _ := Code(secret, Step(now)-1)
+	step, ok := Validate(secret, prev, now)
+	if !ok || step != Step(now)-1 {
+		t.Fatalf("previous step must be accepted: %v %d", ok, step)
+	}
+	old, _ := Code(secret, Step(now)-3)
+	if _, ok := Validate(secret, old, now); ok {
+		t.Fatal("code from 90s ago must be rejected")
+	}
+	if _, ok := Validate(secret, "12345", now); ok {
+		t.Fatal("short code must be rejected")
+	}
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("Budget", "a b@example.com", "ABC"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || !strings.HasPrefix(u.Path, "/Budget:a b@example.com") {
+		t.Fatalf("unexpected uri: %s", u)
+	}
+	if q := u.Query(); q.Get("secret") != "ABC" || q.Get("issuer") != "Budget" || q.Get("digits") != "6" {
+		t.Fatalf("unexpected query: %v", q)
+	}
+}
@@ -0,0 +1,238 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	txusecase "github.com/positron48/budget/internal/usecase/transaction"
+)
+
+const (
+	// largeExpenseLookback is how far back (by created_at) new large expenses are looked for
+	// when this process has not evaluated the rule yet, e.g. after a restart
+	largeExpenseLookback = 7 * 24 * time.Hour
+	// largeExpenseOverlap re-reads the end of the previous window: a transaction created right
+	// before an evaluation may commit after it. Alerts that already fired are not repeated.
+	largeExpenseOverlap  = 5 * time.Minute
+	largeExpensePageSize = 500
+)
+
+// Transactions is the transaction data rules are evaluated against (see transaction.Service).
+// Amounts of Totals and BaseAmount are in the tenant's default currency.
+type Transactions interface {
+	Totals(ctx context.Context, tenantID string, filter txusecase.ListFilter) (income, expense domain.Money, err error)
+	ListPage(ctx context.Context, tenantID string, filter txusecase.ListFilter, pageToken string) (txusecase.Page, error)
+	LastCreatedAt(ctx context.Context, tenantID string) (time.Time, error)
+}
+
+// HistoryRepo keeps fired alerts.
+type HistoryRepo interface {
+	// Record stores the alert unless its rule already fired for the period; created tells which
+	Record(ctx context.Context, a domain.FiredAlert) (stored domain.FiredAlert, created bool, err error)
+	SetNotifyError(ctx context.Context, id, msg string) error
+}
+
+// Channel delivers a fired alert to the people behind the rule.
+type Channel interface {
+	Notify(ctx context.Context, rule domain.AlertRule, alert domain.FiredAlert) error
+}
+
+// Evaluator checks the active rules of all tenants and notifies about the ones that fire.
+// RunOnce must not be called concurrently.
+type Evaluator struct {
+	rules    RuleRepo
+	history  HistoryRepo
+	txs      Transactions
+	channels map[domain.AlertChannel]Channel
+	now      func() time.Time
+	// evaluated is when each rule was last evaluated without errors
+	evaluated map[string]time.Time
+}
+
+func NewEvaluator(rules RuleRepo, history HistoryRepo, txs Transactions) *Evaluator {
+	return &Evaluator{rules: rules, history: history, txs: txs, channels: map[domain.AlertChannel]Channel{}, now: time.Now,
+		evaluated: map[string]time.Time{}}
+}
+
+// SetChannel registers how alerts are sent to rules that list the channel. A rule listing an
+// unregistered channel fires anyway; the failure is kept in the history.
+func (e *Evaluator) SetChannel(name domain.AlertChannel, ch Channel) { e.channels[name] = ch }
+
+// RunOnce evaluates every active rule and returns the number of alerts fired. A failing rule
+// doesn't stop the others; their errors are returned together.
+func (e *Evaluator) RunOnce(ctx context.Context) (int, error) {
+	rules, err := e.rules.ListActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := e.now().UTC()
+	fired := 0
+	var errs []error
+	for _, rule := range rules {
+		candidates, err := e.evaluate(ctx, rule, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+			continue
+		}
+		recorded := true
+		for _, a := range candidates {
+			a.TenantID, a.RuleID, a.Kind, a.FiredAt = rule.TenantID, rule.ID, rule.Kind, now
+			stored, created, err := e.history.Record(ctx, a)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+				recorded = false
+				continue
+			}
+			if !created {
+				continue
+			}
+			fired++
+			if msg := e.notify(ctx, rule, stored); msg != "" {
+				if err := e.history.SetNotifyError(ctx, stored.ID, msg); err != nil {
+					errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+				}
+			}
+		}
+		if recorded {
+			e.evaluated[rule.ID] = now
+		}
+	}
+	return fired, errors.Join(errs...)
+}
+
+// notify sends the alert through every channel of the rule and describes the failures
+func (e *Evaluator) notify(ctx context.Context, rule domain.AlertRule, a domain.FiredAlert) string {
+	var failures []string
+	for _, name := range rule.Channels {
+		ch, ok := e.channels[name]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s: channel is not configured", name))
+			continue
+		}
+		if err := ch.Notify(ctx, rule, a); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	return strings.Join(failures, "; ")
+}
+
+// evaluate returns the alerts the rule fires at now, already fired ones included. Months are
+// counted in the timezone of the rule.
+func (e *Evaluator) evaluate(ctx context.Context, rule domain.AlertRule, now time.Time) ([]domain.FiredAlert, error) {
+	loc, err := time.LoadLocation(rule.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	switch rule.Kind {
+	case domain.AlertCategoryMonthlyLimit:
+		from := monthStart(now.In(loc))
+		to := from.AddDate(0, 1, 0)
+		_, expense, err := e.txs.Totals(ctx, rule.TenantID, txusecase.ListFilter{From: &from, To: &to, CategoryIDs: []string{rule.CategoryID}})
+		if err != nil {
+			return nil, err
+		}
+		if expense.MinorUnits <= rule.ThresholdMinor {
+			return nil, nil
+		}
+		limit := domain.Money{CurrencyCode: expense.CurrencyCode, MinorUnits: rule.ThresholdMinor}
+		return []domain.FiredAlert{{
+			PeriodKey: from.Format("2006-01"),
+			Message:   fmt.Sprintf("%s: %s spent in %s, over the limit of %s", rule.Name, formatMoney(expense), from.Format("January 2006"), formatMoney(limit)),
+			Value:     expense,
+		}}, nil
+
+	case domain.AlertLargeExpense:
+		// expenses recorded since the last evaluation, whatever date they are entered for;
+		// expenses recorded before the rule existed are not news
+		since, ok := e.evaluated[rule.ID]
+		if ok {
+			since = since.Add(-largeExpenseOverlap)
+		} else {
+			since = now.Add(-largeExpenseLookback)
+		}
+		if since.Before(rule.CreatedAt) {
+			since = rule.CreatedAt
+		}
+		expense := domain.TransactionTypeExpense
+		filter := txusecase.ListFilter{CreatedFrom: &since, Type: &expense, PageSize: largeExpensePageSize, Sort: "created_at asc", SkipCount: true}
+		var out []domain.FiredAlert
+		for token := ""; ; {
+			page, err := e.txs.ListPage(ctx, rule.TenantID, filter, token)
+			if err != nil {
+				return nil, err
+			}
+			for _, tx := range page.Items {
+				if tx.BaseAmount.MinorUnits <= rule.ThresholdMinor {
+					continue
+				}
+				msg := fmt.Sprintf("%s: expense of %s on %s", rule.Name, formatMoney(tx.Amount), tx.OccurredAt.In(loc).Format("2006-01-02"))
+				if tx.Comment != "" {
+					msg += fmt.Sprintf(" (%s)", tx.Comment)
+				}
+				out = append(out, domain.FiredAlert{PeriodKey: "tx:" + tx.ID, Message: msg, Value: tx.BaseAmount})
+			}
+			if token = page.NextPageToken; token == "" {
+				return out, nil
+			}
+		}
+
+	case domain.AlertIncomeBelowLastMonth:
+		// compare the last finished month with the one before, once it is over
+		cur := monthStart(now.In(loc))
+		last, before := cur.AddDate(0, -1, 0), cur.AddDate(0, -2, 0)
+		if !cur.After(rule.CreatedAt) {
+			return nil, nil
+		}
+		lastIncome, _, err := e.txs.Totals(ctx, rule.TenantID, txusecase.ListFilter{From: &last, To: &cur})
+		if err != nil {
+			return nil, err
+		}
+		beforeIncome, _, err := e.txs.Totals(ctx, rule.TenantID, txusecase.ListFilter{From: &before, To: &last})
+		if err != nil {
+			return nil, err
+		}
+		if lastIncome.MinorUnits >= beforeIncome.MinorUnits {
+			return nil, nil
+		}
+		return []domain.FiredAlert{{
+			PeriodKey: last.Format("2006-01"),
+			Message: fmt.Sprintf("%s: income in %s was %s, below %s in %s", rule.Name,
+				last.Format("January 2006"), formatMoney(lastIncome), formatMoney(beforeIncome), before.Format("January 2006")),
+			Value: lastIncome,
+		}}, nil
+
+	case domain.AlertNoTransactions:
+		days := rule.Days
+		if days <= 0 {
+			days = domain.DefaultAlertQuietDays
+		}
+		// by created_at: a backdated entry still counts as activity, a future-dated one
+		// doesn't silence the rule
+		latest, err := e.txs.LastCreatedAt(ctx, rule.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		if latest.IsZero() || now.Sub(latest) < time.Duration(days)*24*time.Hour {
+			return nil, nil
+		}
+		// keyed by the last transaction: the alert repeats only after a new quiet period
+		return []domain.FiredAlert{{
+			PeriodKey: "since:" + latest.UTC().Format(time.RFC3339),
+			Message:   fmt.Sprintf("%s: no transactions recorded since %s", rule.Name, latest.In(loc).Format("2006-01-02")),
+		}}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidRule, rule.Kind)
+}
+
+// monthStart returns the start of the month of t in the location of t
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func formatMoney(m domain.Money) string {
+	return m.Decimal() + " " + m.CurrencyCode
+}
@@ -0,0 +1,301 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	txusecase "github.com/positron48/budget/internal/usecase/transaction"
+)
+
+// fakeTxs answers like transaction.Service over a fixed set of RUB transactions
+type fakeTxs struct{ txs []domain.Transaction }
+
+func rub(minor int64) domain.Money { return domain.Money{CurrencyCode: "RUB", MinorUnits: minor} }
+
+func (f *fakeTxs) match(tenantID string, tx domain.Transaction, filter txusecase.ListFilter) bool {
+	if tx.TenantID != tenantID {
+		return false
+	}
+	if filter.From != nil && tx.OccurredAt.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && !tx.OccurredAt.Before(*filter.To) {
+		return false
+	}
+	if filter.CreatedFrom != nil && tx.CreatedAt.Before(*filter.CreatedFrom) {
+		return false
+	}
+	if filter.Type != nil && tx.Type != *filter.Type {
+		return false
+	}
+	if len(filter.CategoryIDs) > 0 && tx.CategoryID != filter.CategoryIDs[0] {
+		return false
+	}
+	return true
+}
+
+func (f *fakeTxs) Totals(_ context.Context, tenantID string, filter txusecase.ListFilter) (domain.Money, domain.Money, error) {
+	income, expense := rub(0), rub(0)
+	for _, tx := range f.txs {
+		if !f.match(tenantID, tx, filter) {
+			continue
+		}
+		if tx.Type == domain.TransactionTypeIncome {
+			income.MinorUnits += tx.BaseAmount.MinorUnits
+		} else {
+			expense.MinorUnits += tx.BaseAmount.MinorUnits
+		}
+	}
+	return income, expense, nil
+}
+
+// ListPage returns matches in the given order; the page token is the offset of the page
+func (f *fakeTxs) ListPage(_ context.Context, tenantID string, filter txusecase.ListFilter, pageToken string) (txusecase.Page, error) {
+	var matches []domain.Transaction
+	for _, tx := range f.txs {
+		if f.match(tenantID, tx, filter) {
+			matches = append(matches, tx)
+		}
+	}
+	offset, _ := strconv.Atoi(pageToken)
+	end := min(offset+filter.PageSize, len(matches))
+	page := txusecase.Page{Items: matches[offset:end], Total: -1}
+	if end < len(matches) {
+		page.NextPageToken = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func (f *fakeTxs) LastCreatedAt(_ context.Context, tenantID string) (last time.Time, err error) {
+	for _, tx := range f.txs {
+		if tx.TenantID == tenantID && tx.CreatedAt.After(last) {
+			last = tx.CreatedAt
+		}
+	}
+	return last, nil
+}
+
+type recordingChannel struct {
+	sent []string
+	err  error
+}
+
+func (c *recordingChannel) Notify(_ context.Context, _ domain.AlertRule, a domain.FiredAlert) error {
+	c.sent = append(c.sent, a.Message)
+	return c.err
+}
+
+func tx(id string, typ domain.TransactionType, category string, minor int64, at time.Time) domain.Transaction {
+	return domain.Transaction{ID: id, TenantID: "t1", Type: typ, CategoryID: category, Amount: rub(minor), BaseAmount: rub(minor), OccurredAt: at, CreatedAt: at}
+}
+
+var (
+	created = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now     = time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+)
+
+func newTestEvaluator(repo *memRules, txs []domain.Transaction) (*Evaluator, *recordingChannel) {
+	e := NewEvaluator(repo, repo, &fakeTxs{txs: txs})
+	e.now = func() time.Time { return now }
+	ch := &recordingChannel{}
+	e.SetChannel(domain.AlertChannelEmail, ch)
+	return e, ch
+}
+
+func addRule(repo *memRules, r domain.AlertRule) domain.AlertRule {
+	r.TenantID, r.Active, r.CreatedAt = "t1", true, created
+	if r.Channels == nil {
+		r.Channels = []domain.AlertChannel{domain.AlertChannelEmail}
+	}
+	r, _ = repo.Create(context.Background(), r)
+	return r
+}
+
+func TestEvaluator_CategoryMonthlyLimit(t *testing.T) {
+	repo := newMemRules()
+	addRule(repo, domain.AlertRule{Name: "Food", Kind: domain.AlertCategoryMonthlyLimit, CategoryID: "food", ThresholdMinor: 10000})
+	e, ch := newTestEvaluator(repo, []domain.Transaction{
+		tx("1", domain.TransactionTypeExpense, "food", 20000, time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)), // previous month
+		tx("2", domain.TransactionTypeExpense, "food", 6000, time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)),
+		tx("3", domain.TransactionTypeExpense, "fun", 9000, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)),
+		tx("4", domain.TransactionTypeExpense, "food", 5000, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)),
+	})
+
+	fired, err := e.RunOnce(context.Background())
+	if err != nil || fired != 1 {
+		t.Fatalf("expected one alert, got %d %v", fired, err)
+	}
+	a := repo.fired[0]
+	if a.PeriodKey != "2025-03" || a.Value.MinorUnits != 11000 || a.Kind != domain.AlertCategoryMonthlyLimit || !a.FiredAt.Equal(now) {
+		t.Fatalf("unexpected alert: %#v", a)
+	}
+	if len(ch.sent) != 1 || !strings.Contains(ch.sent[0], "110.00 RUB") || !strings.Contains(ch.sent[0], "100.00 RUB") {
+		t.Fatalf("unexpected notifications: %q", ch.sent)
+	}
+
+	// the same month doesn't fire again
+	if fired, err := e.RunOnce(context.Background()); err != nil || fired != 0 || len(ch.sent) != 1 {
+		t.Fatalf("expected no repeat, got %d %v %q", fired, err, ch.sent)
+	}
+}
+
+func TestEvaluator_LargeExpense(t *testing.T) {
+	repo := newMemRules()
+	addRule(repo, domain.AlertRule{Name: "Big", Kind: domain.AlertLargeExpense, ThresholdMinor: 50000})
+	big := tx("big", domain.TransactionTypeExpense, "fun", 60000, now.Add(-time.Hour))
+	big.Comment = "TV"
+	old := tx("old", domain.TransactionTypeExpense, "fun", 90000, now.Add(-time.Hour))
+	old.CreatedAt = created.Add(-time.Hour) // recorded before the rule existed
+	backdated := tx("backdated", domain.TransactionTypeExpense, "fun", 70000, now.AddDate(0, -2, 0))
+	backdated.CreatedAt = now.Add(-time.Minute) // entered today for a date long ago
+	txs := []domain.Transaction{
+		old,
+		tx("small", domain.TransactionTypeExpense, "fun", 50000, now.Add(-time.Hour)),
+		tx("income", domain.TransactionTypeIncome, "salary", 900000, now.Add(-time.Hour)),
+		tx("stale", domain.TransactionTypeExpense, "fun", 90000, now.AddDate(0, 0, -30)),
+	}
+	// more small expenses than fit on a page come first
+	for i := 0; i < largeExpensePageSize; i++ {
+		txs = append(txs, tx(fmt.Sprintf("s%d", i), domain.TransactionTypeExpense, "fun", 100, now.Add(-2*time.Hour)))
+	}
+	e, ch := newTestEvaluator(repo, append(txs, big, backdated))
+
+	if fired, err := e.RunOnce(context.Background()); err != nil || fired != 2 {
+		t.Fatalf("expected two alerts, got %d %v", fired, err)
+	}
+	if repo.fired[0].PeriodKey != "tx:big" || !strings.Contains(ch.sent[0], "600.00 RUB") || !strings.Contains(ch.sent[0], "(TV)") {
+		t.Fatalf("unexpected alert %#v / %q", repo.fired[0], ch.sent)
+	}
+	if repo.fired[1].PeriodKey != "tx:backdated" {
+		t.Fatalf("backdated expense not alerted: %#v", repo.fired[1])
+	}
+	if fired, _ := e.RunOnce(context.Background()); fired != 0 {
+		t.Fatalf("expected no repeat, got %d", fired)
+	}
+
+	// the next run looks at expenses recorded since the previous one
+	later := tx("later", domain.TransactionTypeExpense, "fun", 80000, now.AddDate(0, 0, -20))
+	later.CreatedAt = now.Add(time.Hour)
+	e.txs.(*fakeTxs).txs = append(e.txs.(*fakeTxs).txs, later)
+	e.now = func() time.Time { return now.Add(2 * time.Hour) }
+	if fired, _ := e.RunOnce(context.Background()); fired != 1 || repo.fired[2].PeriodKey != "tx:later" {
+		t.Fatalf("expected an alert for the new expense, got %d", fired)
+	}
+}
+
+func TestEvaluator_MonthsInRuleTimezone(t *testing.T) {
+	repo := newMemRules()
+	addRule(repo, domain.AlertRule{Name: "Food", Kind: domain.AlertCategoryMonthlyLimit, CategoryID: "food", ThresholdMinor: 10000, Timezone: "Asia/Tokyo"})
+	// 1 March 05:00 in Tokyo, still February in UTC
+	e, ch := newTestEvaluator(repo, []domain.Transaction{
+		tx("1", domain.TransactionTypeExpense, "food", 20000, time.Date(2025, 2, 28, 20, 0, 0, 0, time.UTC)),
+	})
+	if fired, err := e.RunOnce(context.Background()); err != nil || fired != 1 {
+		t.Fatalf("expected one alert, got %d %v", fired, err)
+	}
+	if a := repo.fired[0]; a.PeriodKey != "2025-03" || !strings.Contains(ch.sent[0], "March 2025") {
+		t.Fatalf("unexpected alert: %#v", a)
+	}
+
+	// in UTC the expense belongs to February
+	repo = newMemRules()
+	addRule(repo, domain.AlertRule{Name: "Food", Kind: domain.AlertCategoryMonthlyLimit, CategoryID: "food", ThresholdMinor: 10000})
+	e, _ = newTestEvaluator(repo, e.txs.(*fakeTxs).txs)
+	if fired, _ := e.RunOnce(context.Background()); fired != 0 {
+		t.Fatalf("expected no alert in UTC, got %d", fired)
+	}
+}
+
+func TestEvaluator_IncomeBelowLastMonth(t *testing.T) {
+	repo := newMemRules()
+	addRule(repo, domain.AlertRule{Name: "Income", Kind: domain.AlertIncomeBelowLastMonth})
+	e, _ := newTestEvaluator(repo, []domain.Transaction{
+		tx("jan", domain.TransactionTypeIncome, "salary", 100000, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)),
+		tx("feb", domain.TransactionTypeIncome, "salary", 80000, time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC)),
+		tx("mar", domain.TransactionTypeIncome, "salary", 1000, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)), // month not over yet
+	})
+	if fired, err := e.RunOnce(context.Background()); err != nil || fired != 1 {
+		t.Fatalf("expected one alert, got %d %v", fired, err)
+	}
+	if a := repo.fired[0]; a.PeriodKey != "2025-02" || a.Value.MinorUnits != 80000 {
+		t.Fatalf("unexpected alert: %#v", a)
+	}
+
+	// a rule created this month waits for the month to end
+	repo = newMemRules()
+	r := addRule(repo, domain.AlertRule{Name: "Income", Kind: domain.AlertIncomeBelowLastMonth})
+	r.CreatedAt = time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	repo.rules[r.ID] = r
+	e, _ = newTestEvaluator(repo, e.txs.(*fakeTxs).txs)
+	if fired, _ := e.RunOnce(context.Background()); fired != 0 {
+		t.Fatalf("new rule fired for a past month")
+	}
+}
+
+func TestEvaluator_NoTransactions(t *testing.T) {
+	repo := newMemRules()
+	addRule(repo, domain.AlertRule{Name: "Quiet", Kind: domain.AlertNoTransactions, Days: 7})
+	last := now.AddDate(0, 0, -8)
+	txs := &fakeTxs{txs: []domain.Transaction{tx("1", domain.TransactionTypeExpense, "fun", 100, last)}}
+	e := NewEvaluator(repo, repo, txs)
+	e.now = func() time.Time { return now }
+	e.SetChannel(domain.AlertChannelEmail, &recordingChannel{})
+
+	if fired, err := e.RunOnce(context.Background()); err != nil || fired != 1 {
+		t.Fatalf("expected one alert, got %d %v", fired, err)
+	}
+	if fired, _ := e.RunOnce(context.Background()); fired != 0 {
+		t.Fatalf("expected no repeat during the same quiet period")
+	}
+
+	// a newly recorded transaction starts over, even when it is backdated; the next quiet period fires again
+	backdated := tx("2", domain.TransactionTypeExpense, "fun", 100, now.AddDate(0, 0, -30))
+	backdated.CreatedAt = now.AddDate(0, 0, -1)
+	txs.txs = append(txs.txs, backdated)
+	if fired, _ := e.RunOnce(context.Background()); fired != 0 {
+		t.Fatalf("fired despite a recent transaction")
+	}
+	e.now = func() time.Time { return now.AddDate(0, 0, 7) }
+	if fired, _ := e.RunOnce(context.Background()); fired != 1 {
+		t.Fatalf("expected a new alert after another quiet week")
+	}
+
+	// tenants without transactions are left alone
+	empty := newMemRules()
+	addRule(empty, domain.AlertRule{Name: "Quiet", Kind: domain.AlertNoTransactions, Days: 7})
+	e, _ = newTestEvaluator(empty, nil)
+	if fired, _ := e.RunOnce(context.Background()); fired != 0 {
+		t.Fatalf("fired for a tenant without transactions")
+	}
+}
+
+func TestEvaluator_RecordsNotifyErrors(t *testing.T) {
+	repo := newMemRules()
+	addRule(repo, domain.AlertRule{Name: "Big", Kind: domain.AlertLargeExpense, ThresholdMinor: 100,
+		Channels: []domain.AlertChannel{domain.AlertChannelEmail, domain.AlertChannelTelegram, domain.AlertChannelWebhook}})
+	e, email := newTestEvaluator(repo, []domain.Transaction{tx("1", domain.TransactionTypeExpense, "fun", 500, now.Add(-time.Hour))})
+	email.err = errors.New("smtp down")
+	webhook := &recordingChannel{}
+	e.SetChannel(domain.AlertChannelWebhook, webhook)
+
+	if fired, err := e.RunOnce(context.Background()); err != nil || fired != 1 {
+		t.Fatalf("expected one alert, got %d %v", fired, err)
+	}
+	if len(webhook.sent) != 1 {
+		t.Fatalf("a failing channel stopped the others")
+	}
+	got := repo.fired[0].NotifyError
+	if !strings.Contains(got, "email: smtp down") || !strings.Contains(got, "telegram: channel is not configured") || strings.Contains(got, "webhook") {
+		t.Fatalf("unexpected notify error %q", got)
+	}
+	// failed notifications are not retried by firing again
+	if fired, _ := e.RunOnce(context.Background()); fired != 0 || len(webhook.sent) != 1 {
+		t.Fatalf("alert fired twice")
+	}
+}
@@ -0,0 +1,197 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+var (
+	ErrRuleNotFound     = errors.New("alert rule not found")
+	ErrInvalidRule      = errors.New("invalid alert rule")
+	ErrPermissionDenied = errors.New("only owners and admins can manage alert rules")
+)
+
+const (
+	maxNameLength = 100
+	maxQuietDays  = 365
+)
+
+// RuleRepo stores alert rules. Get, Update and Delete return ErrRuleNotFound for unknown ids.
+type RuleRepo interface {
+	Create(ctx context.Context, r domain.AlertRule) (domain.AlertRule, error)
+	Get(ctx context.Context, tenantID, id string) (domain.AlertRule, error)
+	List(ctx context.Context, tenantID string) ([]domain.AlertRule, error)
+	// ListActive returns the active rules of all tenants
+	ListActive(ctx context.Context) ([]domain.AlertRule, error)
+	Update(ctx context.Context, r domain.AlertRule) (domain.AlertRule, error)
+	Delete(ctx context.Context, tenantID, id string) error
+	// ListFired returns fired alerts of the tenant, newest first; ruleID may be empty
+	ListFired(ctx context.Context, tenantID, ruleID string, page, pageSize int) ([]domain.FiredAlert, int64, error)
+}
+
+type RoleRepo interface {
+	GetUserRole(ctx context.Context, tenantID, userID string) (domain.TenantRole, error)
+}
+
+type CategoryRepo interface {
+	Get(ctx context.Context, id string) (domain.Category, error)
+}
+
+// Service manages alert rules. Every member sees the rules and their history; owners and admins change them.
+type Service struct {
+	repo  RuleRepo
+	roles RoleRepo
+	cats  CategoryRepo
+}
+
+func NewService(repo RuleRepo, roles RoleRepo, cats CategoryRepo) *Service {
+	return &Service{repo: repo, roles: roles, cats: cats}
+}
+
+func (s *Service) Create(ctx context.Context, tenantID, userID string, r domain.AlertRule) (domain.AlertRule, error) {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return domain.AlertRule{}, err
+	}
+	r.TenantID, r.CreatedBy, r.Active = tenantID, userID, true
+	r, err := s.normalize(ctx, r)
+	if err != nil {
+		return domain.AlertRule{}, err
+	}
+	return s.repo.Create(ctx, r)
+}
+
+func (s *Service) List(ctx context.Context, tenantID, userID string) ([]domain.AlertRule, error) {
+	if err := s.checkMember(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, tenantID)
+}
+
+// Update replaces everything but the kind of a rule.
+func (s *Service) Update(ctx context.Context, tenantID, userID string, r domain.AlertRule) (domain.AlertRule, error) {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return domain.AlertRule{}, err
+	}
+	current, err := s.repo.Get(ctx, tenantID, r.ID)
+	if err != nil {
+		return domain.AlertRule{}, err
+	}
+	current.Name, current.CategoryID, current.ThresholdMinor, current.Days = r.Name, r.CategoryID, r.ThresholdMinor, r.Days
+	current.Channels, current.TelegramChatID, current.Active = r.Channels, r.TelegramChatID, r.Active
+	if current, err = s.normalize(ctx, current); err != nil {
+		return domain.AlertRule{}, err
+	}
+	return s.repo.Update(ctx, current)
+}
+
+// Delete removes the rule together with its history.
+func (s *Service) Delete(ctx context.Context, tenantID, userID, id string) error {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, tenantID, id)
+}
+
+func (s *Service) ListFired(ctx context.Context, tenantID, userID, ruleID string, page, pageSize int) ([]domain.FiredAlert, int64, error) {
+	if err := s.checkMember(ctx, tenantID, userID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListFired(ctx, tenantID, ruleID, page, pageSize)
+}
+
+func (s *Service) checkManager(ctx context.Context, tenantID, userID string) error {
+	role, err := s.roles.GetUserRole(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if role != domain.TenantRoleOwner && role != domain.TenantRoleAdmin {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// checkMember is a second line behind the tenant guard
+func (s *Service) checkMember(ctx context.Context, tenantID, userID string) error {
+	role, err := s.roles.GetUserRole(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// normalize validates the rule and clears the fields its kind doesn't use
+func (s *Service) normalize(ctx context.Context, r domain.AlertRule) (domain.AlertRule, error) {
+	r.Name = strings.TrimSpace(r.Name)
+	r.TelegramChatID = strings.TrimSpace(r.TelegramChatID)
+	if r.Name == "" || utf8.RuneCountInString(r.Name) > maxNameLength {
+		return r, fmt.Errorf("%w: name must be 1..%d characters", ErrInvalidRule, maxNameLength)
+	}
+	if r.Timezone = strings.TrimSpace(r.Timezone); r.Timezone == "" {
+		r.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return r, fmt.Errorf("%w: unknown timezone %q", ErrInvalidRule, r.Timezone)
+	}
+	switch r.Kind {
+	case domain.AlertCategoryMonthlyLimit:
+		if r.CategoryID == "" {
+			return r, fmt.Errorf("%w: category is required", ErrInvalidRule)
+		}
+		cat, err := s.cats.Get(ctx, r.CategoryID)
+		if err != nil || cat.TenantID != r.TenantID {
+			return r, fmt.Errorf("%w: unknown category", ErrInvalidRule)
+		}
+		if cat.Kind != domain.CategoryKindExpense {
+			return r, fmt.Errorf("%w: category must be an expense category", ErrInvalidRule)
+		}
+		r.Days = 0
+	case domain.AlertLargeExpense:
+		r.CategoryID, r.Days = "", 0
+	case domain.AlertIncomeBelowLastMonth:
+		r.CategoryID, r.ThresholdMinor, r.Days = "", 0, 0
+	case domain.AlertNoTransactions:
+		if r.Days == 0 {
+			r.Days = domain.DefaultAlertQuietDays
+		}
+		if r.Days < 1 || r.Days > maxQuietDays {
+			return r, fmt.Errorf("%w: days must be 1..%d", ErrInvalidRule, maxQuietDays)
+		}
+		r.CategoryID, r.ThresholdMinor = "", 0
+	default:
+		return r, fmt.Errorf("%w: unknown kind %q", ErrInvalidRule, r.Kind)
+	}
+	if (r.Kind == domain.AlertCategoryMonthlyLimit || r.Kind == domain.AlertLargeExpense) && r.ThresholdMinor <= 0 {
+		return r, fmt.Errorf("%w: threshold must be positive", ErrInvalidRule)
+	}
+	seen := map[domain.AlertChannel]bool{}
+	channels := make([]domain.AlertChannel, 0, len(r.Channels))
+	for _, ch := range r.Channels {
+		switch ch {
+		case domain.AlertChannelEmail, domain.AlertChannelWebhook:
+		case domain.AlertChannelTelegram:
+			if r.TelegramChatID == "" {
+				return r, fmt.Errorf("%w: telegram chat id is required for the telegram channel", ErrInvalidRule)
+			}
+		default:
+			return r, fmt.Errorf("%w: unknown channel %q", ErrInvalidRule, ch)
+		}
+		if !seen[ch] {
+			seen[ch] = true
+			channels = append(channels, ch)
+		}
+	}
+	if len(channels) == 0 {
+		return r, fmt.Errorf("%w: at least one channel is required", ErrInvalidRule)
+	}
+	r.Channels = channels
+	return r, nil
+}
@@ -0,0 +1,223 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+type memRules struct {
+	rules map[string]domain.AlertRule
+	fired []domain.FiredAlert
+	seq   int
+}
+
+func newMemRules() *memRules { return &memRules{rules: map[string]domain.AlertRule{}} }
+
+func (m *memRules) Create(_ context.Context, r domain.AlertRule) (domain.AlertRule, error) {
+	m.seq++
+	r.ID = fmt.Sprintf("r%d", m.seq)
+	m.rules[r.ID] = r
+	return r, nil
+}
+
+func (m *memRules) Get(_ context.Context, tenantID, id string) (domain.AlertRule, error) {
+	r, ok := m.rules[id]
+	if !ok || r.TenantID != tenantID {
+		return domain.AlertRule{}, ErrRuleNotFound
+	}
+	return r, nil
+}
+
+func (m *memRules) List(_ context.Context, tenantID string) ([]domain.AlertRule, error) {
+	var out []domain.AlertRule
+	for _, r := range m.rules {
+		if r.TenantID == tenantID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *memRules) ListActive(context.Context) ([]domain.AlertRule, error) {
+	var out []domain.AlertRule
+	for i := 1; i <= m.seq; i++ {
+		if r, ok := m.rules[fmt.Sprintf("r%d", i)]; ok && r.Active {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *memRules) Update(_ context.Context, r domain.AlertRule) (domain.AlertRule, error) {
+	m.rules[r.ID] = r
+	return r, nil
+}
+
+func (m *memRules) Delete(_ context.Context, tenantID, id string) error {
+	if _, err := m.Get(context.Background(), tenantID, id); err != nil {
+		return err
+	}
+	delete(m.rules, id)
+	return nil
+}
+
+func (m *memRules) ListFired(_ context.Context, tenantID, ruleID string, _, _ int) ([]domain.FiredAlert, int64, error) {
+	var out []domain.FiredAlert
+	for _, a := range m.fired {
+		if a.TenantID == tenantID && (ruleID == "" || a.RuleID == ruleID) {
+			out = append(out, a)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+// Record and SetNotifyError make memRules the history repo of the evaluator as well
+func (m *memRules) Record(_ context.Context, a domain.FiredAlert) (domain.FiredAlert, bool, error) {
+	for _, f := range m.fired {
+		if f.RuleID == a.RuleID && f.PeriodKey == a.PeriodKey {
+			return domain.FiredAlert{}, false, nil
+		}
+	}
+	a.ID = fmt.Sprintf("a%d", len(m.fired)+1)
+	m.fired = append(m.fired, a)
+	return a, true, nil
+}
+
+func (m *memRules) SetNotifyError(_ context.Context, id, msg string) error {
+	for i := range m.fired {
+		if m.fired[i].ID == id {
+			m.fired[i].NotifyError = msg
+		}
+	}
+	return nil
+}
+
+type roles map[string]domain.TenantRole
+
+func (r roles) GetUserRole(_ context.Context, _, userID string) (domain.TenantRole, error) {
+	return r[userID], nil
+}
+
+type cats map[string]domain.Category
+
+func (c cats) Get(_ context.Context, id string) (domain.Category, error) {
+	cat, ok := c[id]
+	if !ok {
+		return domain.Category{}, errors.New("not found")
+	}
+	return cat, nil
+}
+
+var testRoles = roles{"owner": domain.TenantRoleOwner, "admin": domain.TenantRoleAdmin, "member": domain.TenantRoleMember}
+
+var testCats = cats{
+	"food":    {ID: "food", TenantID: "t1", Kind: domain.CategoryKindExpense},
+	"salary":  {ID: "salary", TenantID: "t1", Kind: domain.CategoryKindIncome},
+	"foreign": {ID: "foreign", TenantID: "t2", Kind: domain.CategoryKindExpense},
+}
+
+func TestService_Create(t *testing.T) {
+	svc := NewService(newMemRules(), testRoles, testCats)
+	ctx := context.Background()
+
+	r, err := svc.Create(ctx, "t1", "admin", domain.AlertRule{
+		Name:           " Food budget ",
+		Kind:           domain.AlertCategoryMonthlyLimit,
+		CategoryID:     "food",
+		ThresholdMinor: 30000,
+		Days:           3,
+		Channels:       []domain.AlertChannel{domain.AlertChannelEmail, domain.AlertChannelEmail},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if r.Name != "Food budget" || !r.Active || r.CreatedBy != "admin" || r.Days != 0 || len(r.Channels) != 1 || r.Timezone != "UTC" {
+		t.Fatalf("unexpected rule: %#v", r)
+	}
+
+	quiet, err := svc.Create(ctx, "t1", "owner", domain.AlertRule{Name: "Quiet", Kind: domain.AlertNoTransactions, ThresholdMinor: 5, Channels: []domain.AlertChannel{domain.AlertChannelWebhook}})
+	if err != nil {
+		t.Fatalf("create quiet: %v", err)
+	}
+	if quiet.Days != domain.DefaultAlertQuietDays || quiet.ThresholdMinor != 0 {
+		t.Fatalf("quiet rule not normalized: %#v", quiet)
+	}
+
+	if _, err := svc.Create(ctx, "t1", "member", domain.AlertRule{Name: "x", Kind: domain.AlertLargeExpense, ThresholdMinor: 1, Channels: []domain.AlertChannel{domain.AlertChannelEmail}}); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("member create: expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestService_CreateInvalid(t *testing.T) {
+	svc := NewService(newMemRules(), testRoles, testCats)
+	email := []domain.AlertChannel{domain.AlertChannelEmail}
+	cases := map[string]domain.AlertRule{
+		"no name":            {Kind: domain.AlertLargeExpense, ThresholdMinor: 1, Channels: email},
+		"unknown kind":       {Name: "x", Kind: "budget_exceeded", Channels: email},
+		"no category":        {Name: "x", Kind: domain.AlertCategoryMonthlyLimit, ThresholdMinor: 1, Channels: email},
+		"income category":    {Name: "x", Kind: domain.AlertCategoryMonthlyLimit, CategoryID: "salary", ThresholdMinor: 1, Channels: email},
+		"foreign category":   {Name: "x", Kind: domain.AlertCategoryMonthlyLimit, CategoryID: "foreign", ThresholdMinor: 1, Channels: email},
+		"zero threshold":     {Name: "x", Kind: domain.AlertLargeExpense, Channels: email},
+		"too many days":      {Name: "x", Kind: domain.AlertNoTransactions, Days: 400, Channels: email},
+		"no channels":        {Name: "x", Kind: domain.AlertLargeExpense, ThresholdMinor: 1},
+		"unknown channel":    {Name: "x", Kind: domain.AlertLargeExpense, ThresholdMinor: 1, Channels: []domain.AlertChannel{"sms"}},
+		"telegram, no chat":  {Name: "x", Kind: domain.AlertLargeExpense, ThresholdMinor: 1, Channels: []domain.AlertChannel{domain.AlertChannelTelegram}},
+		"telegram, blank id": {Name: "x", Kind: domain.AlertLargeExpense, ThresholdMinor: 1, Channels: []domain.AlertChannel{domain.AlertChannelTelegram}, TelegramChatID: "  "},
+		"unknown timezone":   {Name: "x", Kind: domain.AlertLargeExpense, ThresholdMinor: 1, Channels: email, Timezone: "Mars/Olympus"},
+	}
+	for name, r := range cases {
+		if _, err := svc.Create(context.Background(), "t1", "owner", r); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("%s: expected ErrInvalidRule, got %v", name, err)
+		}
+	}
+}
+
+func TestService_UpdateKeepsKind(t *testing.T) {
+	repo := newMemRules()
+	svc := NewService(repo, testRoles, testCats)
+	ctx := context.Background()
+	r, err := svc.Create(ctx, "t1", "owner", domain.AlertRule{Name: "Big", Kind: domain.AlertLargeExpense, ThresholdMinor: 100, Channels: []domain.AlertChannel{domain.AlertChannelEmail}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	upd, err := svc.Update(ctx, "t1", "owner", domain.AlertRule{ID: r.ID, Name: "Bigger", Kind: domain.AlertNoTransactions, ThresholdMinor: 500,
+		Channels: []domain.AlertChannel{domain.AlertChannelTelegram}, TelegramChatID: "42"})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if upd.Kind != domain.AlertLargeExpense || upd.Name != "Bigger" || upd.ThresholdMinor != 500 || upd.Active || upd.CreatedBy != "owner" {
+		t.Fatalf("unexpected update: %#v", upd)
+	}
+
+	if _, err := svc.Update(ctx, "t2", "owner", domain.AlertRule{ID: r.ID, Name: "x"}); !errors.Is(err, ErrRuleNotFound) {
+		t.Fatalf("other tenant: expected ErrRuleNotFound, got %v", err)
+	}
+	if err := svc.Delete(ctx, "t1", "member", r.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("member delete: expected ErrPermissionDenied, got %v", err)
+	}
+	if err := svc.Delete(ctx, "t1", "owner", r.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(repo.rules) != 0 {
+		t.Fatalf("rule not deleted")
+	}
+}
+
+func TestService_ListRequiresMembership(t *testing.T) {
+	svc := NewService(newMemRules(), testRoles, testCats)
+	ctx := context.Background()
+	if _, err := svc.List(ctx, "t1", "member"); err != nil {
+		t.Fatalf("member list: %v", err)
+	}
+	if _, err := svc.List(ctx, "t1", "stranger"); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("stranger list: expected ErrPermissionDenied, got %v", err)
+	}
+	if _, _, err := svc.ListFired(ctx, "t1", "stranger", "", 1, 50); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("stranger history: expected ErrPermissionDenied, got %v", err)
+	}
+}
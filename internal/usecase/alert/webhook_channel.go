@@ -0,0 +1,44 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/webhook"
+)
+
+// AlertData is the data of alert.fired webhook events.
+type AlertData struct {
+	ID        string             `json:"id"`
+	RuleID    string             `json:"rule_id"`
+	RuleName  string             `json:"rule_name"`
+	Kind      string             `json:"kind"`
+	PeriodKey string             `json:"period_key"`
+	Message   string             `json:"message"`
+	Value     *webhook.MoneyData `json:"value,omitempty"`
+	FiredAt   time.Time          `json:"fired_at"`
+}
+
+// WebhookChannel queues alert.fired events for the tenant's webhook subscriptions.
+type WebhookChannel struct{ outbox *webhook.Outbox }
+
+func NewWebhookChannel(outbox *webhook.Outbox) *WebhookChannel {
+	return &WebhookChannel{outbox: outbox}
+}
+
+func (c *WebhookChannel) Notify(ctx context.Context, rule domain.AlertRule, a domain.FiredAlert) error {
+	data := AlertData{
+		ID:        a.ID,
+		RuleID:    rule.ID,
+		RuleName:  rule.Name,
+		Kind:      string(a.Kind),
+		PeriodKey: a.PeriodKey,
+		Message:   a.Message,
+		FiredAt:   a.FiredAt.UTC(),
+	}
+	if a.Value.CurrencyCode != "" {
+		data.Value = &webhook.MoneyData{CurrencyCode: a.Value.CurrencyCode, MinorUnits: a.Value.MinorUnits, Decimal: a.Value.Decimal()}
+	}
+	return c.outbox.Publish(ctx, rule.TenantID, domain.WebhookEventAlertFired, data)
+}
@@ -0,0 +1,42 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/webhook"
+)
+
+type captureOutbox struct {
+	tenantID, eventType string
+	payload             []byte
+}
+
+func (c *captureOutbox) Enqueue(_ context.Context, tenantID, _, eventType string, payload []byte) (int, error) {
+	c.tenantID, c.eventType, c.payload = tenantID, eventType, payload
+	return 1, nil
+}
+
+func TestWebhookChannel_PublishesAlertFired(t *testing.T) {
+	repo := &captureOutbox{}
+	ch := NewWebhookChannel(webhook.NewOutbox(repo))
+	rule := domain.AlertRule{ID: "r1", TenantID: "t1", Name: "Food"}
+	a := domain.FiredAlert{ID: "a1", Kind: domain.AlertCategoryMonthlyLimit, PeriodKey: "2025-03", Message: "over", Value: rub(12345), FiredAt: now}
+	if err := ch.Notify(context.Background(), rule, a); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if repo.tenantID != "t1" || repo.eventType != domain.WebhookEventAlertFired {
+		t.Fatalf("unexpected event %q for %q", repo.eventType, repo.tenantID)
+	}
+	var ev struct {
+		Data AlertData `json:"data"`
+	}
+	if err := json.Unmarshal(repo.payload, &ev); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if ev.Data.RuleName != "Food" || ev.Data.PeriodKey != "2025-03" || ev.Data.Value == nil || ev.Data.Value.Decimal != "123.45" {
+		t.Fatalf("unexpected data: %#v", ev.Data)
+	}
+}
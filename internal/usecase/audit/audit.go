@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+// Repo stores audit events. Record must write with the transaction of ctx (see TxRunner).
+type Repo interface {
+	Record(ctx context.Context, ev domain.AuditEvent) error
+	List(ctx context.Context, tenantID string, filter Filter) ([]domain.AuditEvent, int64, error)
+}
+
+// TxRunner runs fn in a DB transaction; repositories called with the ctx passed to fn join it.
+type TxRunner interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Filter struct {
+	EntityType string
+	EntityID   string
+	ActorID    string
+	Action     domain.AuditAction
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	PageSize   int
+}
+
+// Log is used by usecases to record changes together with the changes themselves.
+// A nil *Log is valid: changes run without a transaction and nothing is recorded.
+type Log struct {
+	repo Repo
+	tx   TxRunner
+}
+
+func NewLog(repo Repo, tx TxRunner) *Log { return &Log{repo: repo, tx: tx} }
+
+// Run runs fn in a DB transaction, so that a change and its audit event commit together.
+func (l *Log) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if l == nil {
+		return fn(ctx)
+	}
+	return l.tx.WithinTx(ctx, fn)
+}
+
+// Record appends an event on behalf of the user of ctx. before and after are snapshots of
+// the entity (nil if absent) and are stored as JSON.
+func (l *Log) Record(ctx context.Context, tenantID, entityType, entityID string, action domain.AuditAction, before, after interface{}) error {
+	if l == nil {
+		return nil
+	}
+	ev := domain.AuditEvent{TenantID: tenantID, EntityType: entityType, EntityID: entityID, Action: action, CreatedAt: time.Now()}
+	ev.ActorID, _ = ctxutil.UserIDFromContext(ctx)
+	var err error
+	if ev.Before, err = snapshot(before); err != nil {
+		return err
+	}
+	if ev.After, err = snapshot(after); err != nil {
+		return err
+	}
+	return l.repo.Record(ctx, ev)
+}
+
+func (l *Log) List(ctx context.Context, tenantID string, filter Filter) ([]domain.AuditEvent, int64, error) {
+	return l.repo.List(ctx, tenantID, filter)
+}
+
+func snapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("audit snapshot: %w", err)
+	}
+	return b, nil
+}
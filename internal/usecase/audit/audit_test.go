@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+type memRepo struct{ events []domain.AuditEvent }
+
+func (m *memRepo) Record(ctx context.Context, ev domain.AuditEvent) error {
+	m.events = append(m.events, ev)
+	return nil
+}
+
+func (m *memRepo) List(ctx context.Context, tenantID string, f Filter) ([]domain.AuditEvent, int64, error) {
+	return m.events, int64(len(m.events)), nil
+}
+
+// rollbackRunner drops events recorded by a failed fn, like a rolled back transaction.
+type rollbackRunner struct{ repo *memRepo }
+
+func (r rollbackRunner) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	n := len(r.repo.events)
+	if err := fn(ctx); err != nil {
+		r.repo.events = r.repo.events[:n]
+		return err
+	}
+	return nil
+}
+
+func TestLog_RecordSnapshotsAndActor(t *testing.T) {
+	repo := &memRepo{}
+	l := NewLog(repo, rollbackRunner{repo})
+	ctx := ctxutil.WithUserID(context.Background(), "u1")
+	err := l.Run(ctx, func(ctx context.Context) error {
+		return l.Record(ctx, "t1", domain.AuditEntityCategory, "c1", domain.AuditActionUpdated,
+			map[string]string{"code": "food"}, map[string]string{"code": "groceries"})
+	})
+	if err != nil || len(repo.events) != 1 {
+		t.Fatalf("record: %v %v", err, repo.events)
+	}
+	ev := repo.events[0]
+	if ev.ActorID != "u1" || ev.TenantID != "t1" || string(ev.Before) != `{"code":"food"}` || string(ev.After) != `{"code":"groceries"}` {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+
+	// created: no before snapshot
+	_ = l.Record(context.Background(), "t1", domain.AuditEntityCategory, "c2", domain.AuditActionCreated, nil, map[string]int{"x": 1})
+	if ev := repo.events[1]; ev.Before != nil || ev.ActorID != "" {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+}
+
+func TestLog_FailedChangeIsNotRecorded(t *testing.T) {
+	repo := &memRepo{}
+	l := NewLog(repo, rollbackRunner{repo})
+	boom := errors.New("boom")
+	err := l.Run(context.Background(), func(ctx context.Context) error {
+		_ = l.Record(ctx, "t1", domain.AuditEntityTransaction, "x1", domain.AuditActionDeleted, struct{}{}, nil)
+		return boom
+	})
+	if !errors.Is(err, boom) || len(repo.events) != 0 {
+		t.Fatalf("expected rollback: %v %v", err, repo.events)
+	}
+}
+
+func TestLog_NilIsNoop(t *testing.T) {
+	var l *Log
+	ran := false
+	err := l.Run(context.Background(), func(ctx context.Context) error {
+		ran = true
+		return l.Record(ctx, "t1", domain.AuditEntityTenant, "t1", domain.AuditActionUpdated, nil, nil)
+	})
+	if err != nil || !ran {
+		t.Fatalf("nil log: %v %v", err, ran)
+	}
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEmailVerificationDisabled = errors.New("email verification is not configured")
+	ErrInvalidVerificationToken  = errors.New("invalid or expired verification token")
+	ErrEmailAlreadyVerified      = errors.New("email is already verified")
+)
+
+const (
+	// DefaultEmailVerificationTTL is how long a verification link stays valid.
+	DefaultEmailVerificationTTL = 48 * time.Hour
+	// maxVerificationEmailsPerHour limits ResendVerification per user
+	maxVerificationEmailsPerHour = 3
+	rateActionVerifyResend       = "verify_email_resend"
+)
+
+type EmailVerificationRepo interface {
+	RateLimitRepo
+	// CreateEmailVerification stores the hash of token for the user's current email
+	CreateEmailVerification(ctx context.Context, userID, email, token string, expiresAt time.Time) error
+	// VerifyEmail consumes a valid token and marks the user's email verified (if the email
+	// didn't change since the token was issued). Returns ErrInvalidVerificationToken otherwise.
+	VerifyEmail(ctx context.Context, token string) (User, error)
+	MarkEmailVerified(ctx context.Context, userID string) error
+	GetUser(ctx context.Context, userID string) (User, error)
+}
+
+type EmailVerificationMailer interface {
+	SendEmailVerification(ctx context.Context, u User, token string, expiresAt time.Time) error
+}
+
+type emailVerification struct {
+	repo   EmailVerificationRepo
+	mailer EmailVerificationMailer
+	ttl    time.Duration
+}
+
+// SetEmailVerification enables verification emails for password registrations. Pending
+// invitations are attached once the email is verified, never on registration.
+func (s *Service) SetEmailVerification(repo EmailVerificationRepo, mailer EmailVerificationMailer, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultEmailVerificationTTL
+	}
+	s.verify = &emailVerification{repo: repo, mailer: mailer, ttl: ttl}
+}
+
+// sendVerification is best effort on registration: the user can request another email later.
+func (s *Service) sendVerification(ctx context.Context, u User) error {
+	if s.verify == nil || s.verify.mailer == nil {
+		return ErrEmailVerificationDisabled
+	}
+	token, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.verify.ttl)
+	if err := s.verify.repo.CreateEmailVerification(ctx, u.ID, u.Email, token, expiresAt); err != nil {
+		return err
+	}
+	if err := s.verify.mailer.SendEmailVerification(ctx, u, token, expiresAt); err != nil {
+		return fmt.Errorf("send verification: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail confirms the address using the emailed token.
+func (s *Service) VerifyEmail(ctx context.Context, token string) (User, error) {
+	if s.verify == nil {
+		return User{}, ErrEmailVerificationDisabled
+	}
+	u, err := s.verify.repo.VerifyEmail(ctx, token)
+	if err != nil {
+		return User{}, err
+	}
+	// invitations to the address wait for its verification, see Register
+	s.attachInvitations(ctx, u)
+	return u, nil
+}
+
+// ResendVerification sends a new verification email to the authenticated user.
+func (s *Service) ResendVerification(ctx context.Context, userID string) error {
+	if s.verify == nil || s.verify.mailer == nil {
+		return ErrEmailVerificationDisabled
+	}
+	u, err := s.verify.repo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+	if err := checkRateLimit(ctx, s.verify.repo, userID, rateActionVerifyResend, maxVerificationEmailsPerHour); err != nil {
+		return err
+	}
+	incrementRateLimit(ctx, s.verify.repo, userID, rateActionVerifyResend)
+	return s.sendVerification(ctx, u)
+}
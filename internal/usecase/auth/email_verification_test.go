@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type verifyRepoMem struct {
+	users    map[string]User
+	tokens   map[string]string // token -> user id
+	counters map[string]int
+}
+
+func newVerifyRepoMem() *verifyRepoMem {
+	return &verifyRepoMem{users: map[string]User{}, tokens: map[string]string{}, counters: map[string]int{}}
+}
+
+func (r *verifyRepoMem) CreateEmailVerification(ctx context.Context, userID, email, token string, expiresAt time.Time) error {
+	u := r.users[userID]
+	u.ID, u.Email = userID, email
+	r.users[userID] = u
+	r.tokens[token] = userID
+	return nil
+}
+
+func (r *verifyRepoMem) VerifyEmail(ctx context.Context, token string) (User, error) {
+	userID, ok := r.tokens[token]
+	if !ok {
+		return User{}, ErrInvalidVerificationToken
+	}
+	delete(r.tokens, token)
+	u := r.users[userID]
+	u.EmailVerified = true
+	r.users[userID] = u
+	return u, nil
+}
+
+func (r *verifyRepoMem) MarkEmailVerified(ctx context.Context, userID string) error {
+	u := r.users[userID]
+	u.EmailVerified = true
+	r.users[userID] = u
+	return nil
+}
+
+func (r *verifyRepoMem) GetUser(ctx context.Context, userID string) (User, error) {
+	u, ok := r.users[userID]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return u, nil
+}
+
+func (r *verifyRepoMem) CheckRateLimit(ctx context.Context, subject, action string, windowStart time.Time) (int, error) {
+	return r.counters[subject+"|"+action], nil
+}
+
+func (r *verifyRepoMem) IncrementRateLimit(ctx context.Context, subject, action string, windowStart time.Time) error {
+	r.counters[subject+"|"+action]++
+	return nil
+}
+
+type verifyMailerMem struct{ last string }
+
+func (m *verifyMailerMem) SendEmailVerification(ctx context.Context, u User, token string, expiresAt time.Time) error {
+	m.last = token
+	return nil
+}
+
+func TestEmailVerification_RegisterAndVerify(t *testing.T) {
+	svc := NewService(&userRepoMem{}, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	ctx := context.Background()
+	if _, err := svc.VerifyEmail(ctx, "x"); !errors.Is(err, ErrEmailVerificationDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+	repo := newVerifyRepoMem()
+	mailer := &verifyMailerMem{}
+	svc.SetEmailVerification(repo, mailer, 0)
+
+	u, _, _, err := svc.Register(ctx, "e@x", "Passw0rd!", "User", "en", "")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if mailer.last == "" {
+		t.Fatal("verification email not sent")
+	}
+	if _, err := svc.VerifyEmail(ctx, "wrong"); !errors.Is(err, ErrInvalidVerificationToken) {
+		t.Fatalf("expected invalid token, got %v", err)
+	}
+	verified, err := svc.VerifyEmail(ctx, mailer.last)
+	if err != nil || !verified.EmailVerified || verified.ID != u.ID {
+		t.Fatalf("verify: %v %#v", err, verified)
+	}
+	if _, err := svc.VerifyEmail(ctx, mailer.last); !errors.Is(err, ErrInvalidVerificationToken) {
+		t.Fatalf("token must be single use, got %v", err)
+	}
+	if err := svc.ResendVerification(ctx, u.ID); !errors.Is(err, ErrEmailAlreadyVerified) {
+		t.Fatalf("expected already verified, got %v", err)
+	}
+}
+
+func TestEmailVerification_ResendRateLimited(t *testing.T) {
+	svc := NewService(&userRepoMem{}, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	repo := newVerifyRepoMem()
+	repo.users["u1"] = User{ID: "u1", Email: "e@x"}
+	svc.SetEmailVerification(repo, &verifyMailerMem{}, time.Hour)
+	ctx := context.Background()
+	for i := 0; i < maxVerificationEmailsPerHour; i++ {
+		if err := svc.ResendVerification(ctx, "u1"); err != nil {
+			t.Fatalf("resend %d: %v", i, err)
+		}
+	}
+	if err := svc.ResendVerification(ctx, "u1"); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("expected rate limit, got %v", err)
+	}
+}
+
+func TestEmailVerification_DefersInvitations(t *testing.T) {
+	svc := NewService(&userRepoMem{}, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	var gotUser, gotEmail string
+	attached := 0
+	svc.SetInvitationAttacher(InvitationAttacherFunc(func(ctx context.Context, userID, email string) ([]TenantMembership, error) {
+		attached++
+		gotUser, gotEmail = userID, email
+		return nil, errors.New("attach failed")
+	}))
+	mailer := &verifyMailerMem{}
+	svc.SetEmailVerification(newVerifyRepoMem(), mailer, time.Hour)
+	ctx := context.Background()
+	u, _, _, err := svc.Register(ctx, "e@x", "Passw0rd!", "User", "en", "")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if attached != 0 {
+		t.Fatal("invitations must wait for verification")
+	}
+	// attach errors must not fail the verification
+	if _, err := svc.VerifyEmail(ctx, mailer.last); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if attached != 1 || gotUser != u.ID || gotEmail != "e@x" {
+		t.Fatalf("expected invitations attached after verification, got %d (%q %q)", attached, gotUser, gotEmail)
+	}
+}
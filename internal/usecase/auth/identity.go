@@ -0,0 +1,291 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/positron48/budget/internal/domain"
+)
+
+// GoogleProvider is the provider name of Google sign-in.
+const GoogleProvider = "google"
+
+var (
+	ErrUnknownIdentityProvider  = errors.New("unknown identity provider")
+	ErrInvalidIDToken           = errors.New("invalid id token")
+	ErrExternalEmailNotVerified = errors.New("email of the external account is not verified")
+	// ErrIdentityNotLinked: an account with this email exists, but the provider is not trusted
+	// to prove ownership of the email or the account's email is unverified; sign in with a
+	// password and link the identity first.
+	ErrIdentityNotLinked     = errors.New("external identity is not linked to the account")
+	ErrIdentityAlreadyLinked = errors.New("external identity is already linked to another account")
+	ErrIdentityNotFound      = errors.New("external identity not found")
+)
+
+// IdentityClaims are the verified claims of an OpenID Connect ID token.
+type IdentityClaims struct {
+	Subject       string
+	Email         string
+	Name          string
+	EmailVerified bool
+	Locale        string
+}
+
+// GoogleClaims is kept for callers written before generic OIDC providers.
+type GoogleClaims = IdentityClaims
+
+type IDTokenVerifier interface {
+	VerifyIDToken(ctx context.Context, idToken string) (IdentityClaims, error)
+}
+
+// Identity is an external account (provider, subject) linked to a user.
+type Identity struct {
+	Provider    string
+	Subject     string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt *time.Time
+}
+
+// IdentityRepo maps (provider, subject) to users, so that a changed email at the provider
+// doesn't move the login to another account.
+type IdentityRepo interface {
+	// GetUserByIdentity returns ErrUserNotFound if the identity is not linked; records the login
+	GetUserByIdentity(ctx context.Context, provider, subject string) (User, []TenantMembership, error)
+	// LinkIdentity returns ErrIdentityAlreadyLinked if the identity belongs to another user
+	// or the user already has another identity of the provider
+	LinkIdentity(ctx context.Context, userID, provider, subject, email string) error
+	UnlinkIdentity(ctx context.Context, userID, provider string) (bool, error)
+	ListIdentities(ctx context.Context, userID string) ([]Identity, error)
+}
+
+type identityProvider struct {
+	verifier IDTokenVerifier
+	// trustEmail: the provider verifies emails (e.g. Google), so a first login with a
+	// verified email is linked to the existing account with that email
+	trustEmail bool
+}
+
+// SetIdentityProvider registers an OpenID Connect provider under name.
+func (s *Service) SetIdentityProvider(name string, verifier IDTokenVerifier, trustEmail bool) {
+	if s.providers == nil {
+		s.providers = map[string]identityProvider{}
+	}
+	s.providers[name] = identityProvider{verifier: verifier, trustEmail: trustEmail}
+}
+
+func (s *Service) SetIdentityRepo(repo IdentityRepo) { s.identities = repo }
+
+// IdentityProviders returns names of the configured providers (for login buttons).
+func (s *Service) IdentityProviders() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Service) verifyIdentity(ctx context.Context, provider, idToken string) (identityProvider, IdentityClaims, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return identityProvider{}, IdentityClaims{}, ErrUnknownIdentityProvider
+	}
+	claims, err := p.verifier.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return identityProvider{}, IdentityClaims{}, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	return p, claims, nil
+}
+
+// ExternalAuth signs in with an ID token of an OpenID Connect provider. A linked identity
+// signs in its user; otherwise a new account is created, or an existing account with the same
+// email is linked if the provider is trusted to verify emails. Like Login, it honours the login
+// guard lockouts and returns *TwoFactorRequiredError instead of tokens if the user has 2FA enabled.
+func (s *Service) ExternalAuth(ctx context.Context, provider, idToken, locale, tenantName string) (User, []TenantMembership, *Tenant, TokenPair, error) {
+	p, claims, err := s.verifyIdentity(ctx, provider, idToken)
+	if err != nil {
+		return User{}, nil, nil, TokenPair{}, err
+	}
+	if s.identities != nil {
+		user, memberships, err := s.identities.GetUserByIdentity(ctx, provider, claims.Subject)
+		if err == nil {
+			if err := s.checkExternalLogin(ctx, user.Email, provider); err != nil {
+				return User{}, nil, nil, TokenPair{}, err
+			}
+			tp, err := s.finishExternalLogin(ctx, user, memberships, provider)
+			if err != nil {
+				return user, memberships, nil, TokenPair{}, err
+			}
+			return user, memberships, nil, tp, nil
+		}
+		if !errors.Is(err, ErrUserNotFound) {
+			return User{}, nil, nil, TokenPair{}, err
+		}
+	}
+	if !claims.EmailVerified || claims.Email == "" {
+		return User{}, nil, nil, TokenPair{}, ErrExternalEmailNotVerified
+	}
+	if err := s.checkExternalLogin(ctx, claims.Email, provider); err != nil {
+		return User{}, nil, nil, TokenPair{}, err
+	}
+
+	user, memberships, err := s.users.GetByEmail(ctx, claims.Email)
+	var createdTenant *Tenant
+	if err != nil {
+		if !errors.Is(err, ErrUserNotFound) {
+			return User{}, nil, nil, TokenPair{}, err
+		}
+		name := claims.Name
+		if name == "" {
+			name = claims.Email
+		}
+		effectiveLocale := locale
+		if effectiveLocale == "" {
+			effectiveLocale = claims.Locale
+		}
+		if effectiveLocale == "" {
+			effectiveLocale = "ru"
+		}
+
+		// Password-based auth is deprecated for web flow, but DB schema still requires hash.
+		fallbackPassword := uuid.NewString()
+		hash, hashErr := s.hasher.Hash(fallbackPassword)
+		if hashErr != nil {
+			return User{}, nil, nil, TokenPair{}, hashErr
+		}
+
+		createdUser, tenant, createErr := s.users.CreateWithDefaultTenant(ctx, claims.Email, hash, name, effectiveLocale, tenantName)
+		if createErr != nil {
+			return User{}, nil, nil, TokenPair{}, createErr
+		}
+		user = createdUser
+		user.EmailVerified = true
+		s.markEmailVerified(ctx, user.ID)
+		createdTenant = &tenant
+		memberships = []TenantMembership{{
+			TenantID:  tenant.ID,
+			Role:      "owner",
+			IsDefault: true,
+		}}
+		memberships = append(memberships, s.attachInvitations(ctx, user)...)
+	} else {
+		// an unverified account may have been registered by someone else with the owner's
+		// address: linking it would hand the provider's sign-in to whoever knows its password
+		if !p.trustEmail || !user.EmailVerified {
+			return User{}, nil, nil, TokenPair{}, ErrIdentityNotLinked
+		}
+	}
+	if s.identities != nil {
+		if err := s.identities.LinkIdentity(ctx, user.ID, provider, claims.Subject, claims.Email); err != nil {
+			return User{}, nil, nil, TokenPair{}, err
+		}
+	}
+
+	tp, err := s.finishExternalLogin(ctx, user, memberships, provider)
+	if err != nil {
+		return user, memberships, createdTenant, TokenPair{}, err
+	}
+	return user, memberships, createdTenant, tp, nil
+}
+
+// checkExternalLogin applies the login guard lockouts of the email and the client IP.
+func (s *Service) checkExternalLogin(ctx context.Context, email, provider string) error {
+	attempt := newLoginAttempt(ctx, email, provider)
+	if err := s.guard.check(ctx, email, attempt.IPAddress, time.Now()); err != nil {
+		var blocked *LoginBlockedError
+		if errors.As(err, &blocked) {
+			s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptBlocked)
+		}
+		return err
+	}
+	return nil
+}
+
+// finishExternalLogin issues tokens, or starts the second step if the user has 2FA enabled:
+// the provider proves the first factor only.
+func (s *Service) finishExternalLogin(ctx context.Context, u User, memberships []TenantMembership, provider string) (TokenPair, error) {
+	attempt := newLoginAttempt(ctx, u.Email, provider)
+	attempt.UserID = u.ID
+	challenge, err := s.loginChallenge(ctx, u.ID, defaultTenantID(memberships))
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if challenge != nil {
+		s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptChallenged)
+		return TokenPair{}, challenge
+	}
+	tp, err := s.issueForMemberships(ctx, u.ID, memberships)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptSuccess)
+	return tp, nil
+}
+
+// defaultTenantID returns the default tenant of the user (or the first one).
+func defaultTenantID(memberships []TenantMembership) string {
+	for _, m := range memberships {
+		if m.IsDefault {
+			return m.TenantID
+		}
+	}
+	if len(memberships) > 0 {
+		return memberships[0].TenantID
+	}
+	return ""
+}
+
+// issueForMemberships issues tokens for the default tenant (or the first one).
+func (s *Service) issueForMemberships(ctx context.Context, userID string, memberships []TenantMembership) (TokenPair, error) {
+	tenantID := defaultTenantID(memberships)
+	tp, err := s.issuer.Issue(ctx, userID, tenantID, s.accessTTL, s.refreshTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if err := s.tokens.Store(ctx, userID, tenantID, tp.RefreshToken, tp.RefreshTokenExpiresAt); err != nil {
+		return TokenPair{}, err
+	}
+	return tp, nil
+}
+
+// LinkIdentity attaches an external account to the signed-in user, who proves control of it
+// with an ID token. Afterwards the user can sign in with the provider.
+func (s *Service) LinkIdentity(ctx context.Context, userID, provider, idToken string) (Identity, error) {
+	if s.identities == nil {
+		return Identity{}, ErrUnknownIdentityProvider
+	}
+	_, claims, err := s.verifyIdentity(ctx, provider, idToken)
+	if err != nil {
+		return Identity{}, err
+	}
+	if err := s.identities.LinkIdentity(ctx, userID, provider, claims.Subject, claims.Email); err != nil {
+		return Identity{}, err
+	}
+	return Identity{Provider: provider, Subject: claims.Subject, Email: claims.Email, CreatedAt: time.Now()}, nil
+}
+
+func (s *Service) UnlinkIdentity(ctx context.Context, userID, provider string) error {
+	if s.identities == nil {
+		return ErrIdentityNotFound
+	}
+	ok, err := s.identities.UnlinkIdentity(ctx, userID, provider)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrIdentityNotFound
+	}
+	return nil
+}
+
+func (s *Service) ListIdentities(ctx context.Context, userID string) ([]Identity, error) {
+	if s.identities == nil {
+		return nil, nil
+	}
+	return s.identities.ListIdentities(ctx, userID)
+}
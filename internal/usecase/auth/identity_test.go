@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/pkg/totp"
+)
+
+// verifierMap treats the ID token as a key into prepared claims.
+type verifierMap map[string]IdentityClaims
+
+func (v verifierMap) VerifyIDToken(ctx context.Context, idToken string) (IdentityClaims, error) {
+	c, ok := v[idToken]
+	if !ok {
+		return IdentityClaims{}, errors.New("bad signature")
+	}
+	return c, nil
+}
+
+type identityMem struct {
+	users *userRepoNotFound
+	links map[string]string // provider|subject -> email
+}
+
+func (m *identityMem) GetUserByIdentity(ctx context.Context, provider, subject string) (User, []TenantMembership, error) {
+	email, ok := m.links[provider+"|"+subject]
+	if !ok {
+		return User{}, nil, ErrUserNotFound
+	}
+	return m.users.GetByEmail(ctx, email)
+}
+
+func (m *identityMem) LinkIdentity(ctx context.Context, userID, provider, subject, email string) error {
+	key := provider + "|" + subject
+	if owner, ok := m.links[key]; ok {
+		u, _, _ := m.users.GetByEmail(ctx, owner)
+		if u.ID != userID {
+			return ErrIdentityAlreadyLinked
+		}
+		return nil
+	}
+	for _, row := range m.users.byEmail {
+		if row.U.ID == userID {
+			m.links[key] = row.U.Email
+		}
+	}
+	return nil
+}
+
+func (m *identityMem) UnlinkIdentity(ctx context.Context, userID, provider string) (bool, error) {
+	for key, email := range m.links {
+		u, _, _ := m.users.GetByEmail(ctx, email)
+		if u.ID == userID && len(key) > len(provider) && key[:len(provider)+1] == provider+"|" {
+			delete(m.links, key)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *identityMem) ListIdentities(ctx context.Context, userID string) ([]Identity, error) {
+	return nil, nil
+}
+
+func newIdentityService(t *testing.T) (*Service, *identityMem) {
+	t.Helper()
+	ur := &userRepoNotFound{}
+	ur.byEmail = map[string]struct {
+		U  User
+		Ms []TenantMembership
+	}{
+		"bob@x":   {U: User{ID: "u-bob", Email: "bob@x", EmailVerified: true}, Ms: []TenantMembership{{TenantID: "t-bob", Role: "owner", IsDefault: true}}},
+		"carol@x": {U: User{ID: "u-carol", Email: "carol@x"}, Ms: []TenantMembership{{TenantID: "t-carol", Role: "owner", IsDefault: true}}},
+	}
+	svc := NewService(ur, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	ids := &identityMem{users: ur, links: map[string]string{}}
+	svc.SetIdentityRepo(ids)
+	svc.SetIdentityProvider(GoogleProvider, verifierMap{
+		"g-bob":   {Subject: "g1", Email: "bob@x", EmailVerified: true},
+		"g-carol": {Subject: "g2", Email: "carol@x", EmailVerified: true},
+	}, true)
+	svc.SetIdentityProvider("keycloak", verifierMap{
+		"kc-bob":        {Subject: "kc1", Email: "bob@x", EmailVerified: true},
+		"kc-new":        {Subject: "kc2", Email: "new@x", EmailVerified: true, Name: "New"},
+		"kc-unverified": {Subject: "kc3", Email: "eve@x"},
+	}, false)
+	return svc, ids
+}
+
+func TestExternalAuth_TrustedProviderLinksExistingAccount(t *testing.T) {
+	svc, ids := newIdentityService(t)
+	ctx := context.Background()
+	u, ms, created, tp, err := svc.ExternalAuth(ctx, GoogleProvider, "g-bob", "", "")
+	if err != nil || u.ID != "u-bob" || created != nil || len(ms) != 1 || tp.AccessToken == "" {
+		t.Fatalf("external auth: %v %#v %v %#v", err, u, created, ms)
+	}
+	if ids.links["google|g1"] != "bob@x" {
+		t.Fatalf("identity not linked: %v", ids.links)
+	}
+	if _, _, _, _, err := svc.ExternalAuth(ctx, GoogleProvider, "forged", "", ""); !errors.Is(err, ErrInvalidIDToken) {
+		t.Fatalf("expected invalid id token, got %v", err)
+	}
+	if _, _, _, _, err := svc.ExternalAuth(ctx, "github", "g-bob", "", ""); !errors.Is(err, ErrUnknownIdentityProvider) {
+		t.Fatalf("expected unknown provider, got %v", err)
+	}
+}
+
+func TestExternalAuth_TrustedProviderDoesNotLinkUnverifiedAccount(t *testing.T) {
+	svc, ids := newIdentityService(t)
+	ctx := context.Background()
+	attached := false
+	svc.SetInvitationAttacher(InvitationAttacherFunc(func(ctx context.Context, userID, email string) ([]TenantMembership, error) {
+		attached = true
+		return nil, nil
+	}))
+	// carol@x was registered with a password but never verified: whoever registered it may
+	// not own the address, so the provider's sign-in must not be handed to that account
+	if _, _, _, _, err := svc.ExternalAuth(ctx, GoogleProvider, "g-carol", "", ""); !errors.Is(err, ErrIdentityNotLinked) {
+		t.Fatalf("expected not linked, got %v", err)
+	}
+	if _, ok := ids.links["google|g2"]; ok || attached {
+		t.Fatalf("unverified account was linked: links=%v attached=%v", ids.links, attached)
+	}
+	if u, _, _ := ids.users.GetByEmail(ctx, "carol@x"); u.EmailVerified {
+		t.Fatalf("unverified account was marked verified")
+	}
+}
+
+func TestExternalAuth_UntrustedProviderRequiresLinking(t *testing.T) {
+	svc, _ := newIdentityService(t)
+	ctx := context.Background()
+	if _, _, _, _, err := svc.ExternalAuth(ctx, "keycloak", "kc-bob", "", ""); !errors.Is(err, ErrIdentityNotLinked) {
+		t.Fatalf("expected not linked, got %v", err)
+	}
+	if _, _, _, _, err := svc.ExternalAuth(ctx, "keycloak", "kc-unverified", "", ""); !errors.Is(err, ErrExternalEmailNotVerified) {
+		t.Fatalf("expected unverified email, got %v", err)
+	}
+	// the signed-in user links the identity explicitly, then the provider signs them in
+	if _, err := svc.LinkIdentity(ctx, "u-bob", "keycloak", "kc-bob"); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	u, _, _, _, err := svc.ExternalAuth(ctx, "keycloak", "kc-bob", "", "")
+	if err != nil || u.ID != "u-bob" {
+		t.Fatalf("login after link: %v %#v", err, u)
+	}
+	if _, err := svc.LinkIdentity(ctx, "u-other", "keycloak", "kc-bob"); !errors.Is(err, ErrIdentityAlreadyLinked) {
+		t.Fatalf("expected already linked, got %v", err)
+	}
+	if err := svc.UnlinkIdentity(ctx, "u-bob", "keycloak"); err != nil {
+		t.Fatalf("unlink: %v", err)
+	}
+	if err := svc.UnlinkIdentity(ctx, "u-bob", "keycloak"); !errors.Is(err, ErrIdentityNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestExternalAuth_CreatesNewAccount(t *testing.T) {
+	svc, ids := newIdentityService(t)
+	u, ms, created, _, err := svc.ExternalAuth(context.Background(), "keycloak", "kc-new", "en", "Home")
+	if err != nil || created == nil || u.Email != "new@x" || !u.EmailVerified || len(ms) != 1 || !ms[0].IsDefault {
+		t.Fatalf("create: %v %#v %#v", err, u, ms)
+	}
+	if ids.links["keycloak|kc2"] != "new@x" {
+		t.Fatalf("identity not linked: %v", ids.links)
+	}
+	if got := svc.IdentityProviders(); len(got) != 2 || got[0] != GoogleProvider || got[1] != "keycloak" {
+		t.Fatalf("providers: %v", got)
+	}
+}
+
+func TestGoogleAuth_Disabled(t *testing.T) {
+	svc := NewService(&userRepoNotFound{}, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	if _, _, _, _, err := svc.GoogleAuth(context.Background(), "tok", "", ""); !errors.Is(err, ErrGoogleAuthDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+}
+
+func TestExternalAuth_HonoursTwoFactorAndLockouts(t *testing.T) {
+	svc, ids := newIdentityService(t)
+	repo := newTwoFactorMem()
+	svc.SetTwoFactor(repo, 0)
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.totp["u-bob"] = TOTPState{Secret: secret, Enabled: true}
+	ctx := context.Background()
+
+	// the first sign-in matches the account by email and links the identity, the second one
+	// goes through the link: both stop at the second factor
+	for i := 0; i < 2; i++ {
+		_, _, _, tp, err := svc.ExternalAuth(ctx, GoogleProvider, "g-bob", "", "")
+		var challenge *TwoFactorRequiredError
+		if !errors.As(err, &challenge) || tp.AccessToken != "" {
+			t.Fatalf("sign-in %d: expected two-factor challenge, got %v %+v", i, err, tp)
+		}
+		delete(repo.lastStep, "u-bob")
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+		if _, tp, err := svc.CompleteTwoFactorLogin(ctx, challenge.ChallengeToken, code); err != nil || tp.AccessToken == "" {
+			t.Fatalf("sign-in %d: complete: %v", i, err)
+		}
+	}
+	if len(ids.links) != 1 {
+		t.Fatalf("expected the identity linked, got %v", ids.links)
+	}
+
+	th := newThrottleMem()
+	svc.SetLoginGuard(th, DefaultLoginGuardConfig())
+	th.locks["email:bob@x"] = time.Now().Add(time.Minute)
+	if _, _, _, _, err := svc.ExternalAuth(ctx, GoogleProvider, "g-bob", "", ""); !errors.Is(err, ErrTooManyLoginAttempts) {
+		t.Fatalf("expected lockout, got %v", err)
+	}
+}
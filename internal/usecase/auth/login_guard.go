@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+
+// LoginBlockedError is returned by Login while the email or the IP is locked out, or while
+// the progressive delay after recent failures has not passed. It matches ErrTooManyLoginAttempts.
+type LoginBlockedError struct {
+	RetryAfter time.Duration
+	Locked     bool // temporary lockout rather than a short delay
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%v, retry in %s", ErrTooManyLoginAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginBlockedError) Is(target error) bool { return target == ErrTooManyLoginAttempts }
+
+// LoginThrottle keeps failed-attempt counters and lockouts by key (email or IP).
+type LoginThrottle interface {
+	// Failures returns failures within the current window and the time of the last one
+	Failures(ctx context.Context, key string) (int, time.Time, error)
+	// RecordFailure increments the counter; the counter expires window after the last failure
+	RecordFailure(ctx context.Context, key string, window time.Duration) (int, error)
+	ResetFailures(ctx context.Context, key string) error
+	Lock(ctx context.Context, key string, until time.Time) error
+	// LockedUntil returns zero time if the key is not locked
+	LockedUntil(ctx context.Context, key string) (time.Time, error)
+}
+
+// LoginAttemptLog records sign-in attempts for the user's security log.
+type LoginAttemptLog interface {
+	RecordLoginAttempt(ctx context.Context, attempt domain.LoginAttempt) error
+}
+
+type LoginGuardConfig struct {
+	Window       time.Duration // failures are forgotten after this much time without new ones
+	FreeAttempts int           // failures per email before the delay kicks in
+	BaseDelay    time.Duration // delay after FreeAttempts failures, doubled with each next one
+	MaxDelay     time.Duration
+	EmailLockAt  int // failures per email that lock the email out
+	IPLockAt     int // failures from one IP (any emails) that lock the IP out
+	LockDuration time.Duration
+}
+
+func DefaultLoginGuardConfig() LoginGuardConfig {
+	return LoginGuardConfig{
+		Window:       15 * time.Minute,
+		FreeAttempts: 3,
+		BaseDelay:    time.Second,
+		MaxDelay:     30 * time.Second,
+		EmailLockAt:  10,
+		IPLockAt:     50,
+		LockDuration: 15 * time.Minute,
+	}
+}
+
+type loginGuard struct {
+	throttle LoginThrottle
+	cfg      LoginGuardConfig
+}
+
+// SetLoginGuard enables brute-force protection of password login.
+func (s *Service) SetLoginGuard(throttle LoginThrottle, cfg LoginGuardConfig) {
+	s.guard = &loginGuard{throttle: throttle, cfg: cfg}
+}
+
+func (s *Service) SetLoginAttemptLog(log LoginAttemptLog) { s.loginLog = log }
+
+func emailKey(email string) string { return "email:" + normalizeEmail(email) }
+func ipKey(ip string) string       { return "ip:" + ip }
+
+func normalizeEmail(email string) string { return strings.ToLower(strings.TrimSpace(email)) }
+
+// delay is the wait required after n failures.
+func (g *loginGuard) delay(n int) time.Duration {
+	if n < g.cfg.FreeAttempts || g.cfg.BaseDelay <= 0 {
+		return 0
+	}
+	d := g.cfg.BaseDelay
+	for i := g.cfg.FreeAttempts; i < n && d < g.cfg.MaxDelay; i++ {
+		d *= 2
+	}
+	if g.cfg.MaxDelay > 0 && d > g.cfg.MaxDelay {
+		d = g.cfg.MaxDelay
+	}
+	return d
+}
+
+// check returns *LoginBlockedError if the attempt must be rejected without checking the password.
+func (g *loginGuard) check(ctx context.Context, email, ip string, now time.Time) error {
+	if g == nil {
+		return nil
+	}
+	keys := []string{emailKey(email)}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+	for _, key := range keys {
+		until, err := g.throttle.LockedUntil(ctx, key)
+		if err != nil {
+			return err
+		}
+		if until.After(now) {
+			return &LoginBlockedError{RetryAfter: until.Sub(now), Locked: true}
+		}
+	}
+	// the delay applies per email only: users behind one NAT shouldn't slow each other down
+	n, last, err := g.throttle.Failures(ctx, keys[0])
+	if err != nil {
+		return err
+	}
+	if d := g.delay(n); d > 0 && now.Before(last.Add(d)) {
+		return &LoginBlockedError{RetryAfter: last.Add(d).Sub(now)}
+	}
+	return nil
+}
+
+func (g *loginGuard) failure(ctx context.Context, email, ip string, now time.Time) error {
+	if g == nil {
+		return nil
+	}
+	if err := g.count(ctx, emailKey(email), g.cfg.EmailLockAt, now); err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	return g.count(ctx, ipKey(ip), g.cfg.IPLockAt, now)
+}
+
+func (g *loginGuard) count(ctx context.Context, key string, lockAt int, now time.Time) error {
+	n, err := g.throttle.RecordFailure(ctx, key, g.cfg.Window)
+	if err != nil {
+		return err
+	}
+	if lockAt <= 0 || n < lockAt {
+		return nil
+	}
+	if err := g.throttle.Lock(ctx, key, now.Add(g.cfg.LockDuration)); err != nil {
+		return err
+	}
+	// counting starts over once the lockout ends
+	return g.throttle.ResetFailures(ctx, key)
+}
+
+// success clears the email counter; the IP counter is kept, so that an attacker can't reset
+// it by signing in to their own account in between guesses.
+func (g *loginGuard) success(ctx context.Context, email string) error {
+	if g == nil {
+		return nil
+	}
+	return g.throttle.ResetFailures(ctx, emailKey(email))
+}
+
+// newLoginAttempt describes an attempt of the current request.
+func newLoginAttempt(ctx context.Context, email, method string) domain.LoginAttempt {
+	ip, ua := ctxutil.ClientInfoFromContext(ctx)
+	return domain.LoginAttempt{Email: normalizeEmail(email), Method: method, IPAddress: ip, UserAgent: ua}
+}
+
+// recordLoginAttempt is best effort: a failed log write must not fail the sign-in.
+func (s *Service) recordLoginAttempt(ctx context.Context, attempt domain.LoginAttempt, status domain.LoginAttemptStatus) {
+	if s.loginLog == nil {
+		return
+	}
+	attempt.Status = status
+	attempt.CreatedAt = time.Now()
+	_ = s.loginLog.RecordLoginAttempt(ctx, attempt)
+}
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+type throttleMem struct {
+	counts map[string]int
+	last   map[string]time.Time
+	locks  map[string]time.Time
+}
+
+func newThrottleMem() *throttleMem {
+	return &throttleMem{counts: map[string]int{}, last: map[string]time.Time{}, locks: map[string]time.Time{}}
+}
+
+func (m *throttleMem) Failures(ctx context.Context, key string) (int, time.Time, error) {
+	return m.counts[key], m.last[key], nil
+}
+
+func (m *throttleMem) RecordFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	m.counts[key]++
+	m.last[key] = time.Now()
+	return m.counts[key], nil
+}
+
+func (m *throttleMem) ResetFailures(ctx context.Context, key string) error {
+	delete(m.counts, key)
+	delete(m.last, key)
+	return nil
+}
+
+func (m *throttleMem) Lock(ctx context.Context, key string, until time.Time) error {
+	m.locks[key] = until
+	return nil
+}
+
+func (m *throttleMem) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	return m.locks[key], nil
+}
+
+type loginLogMem struct{ entries []domain.LoginAttempt }
+
+func (m *loginLogMem) RecordLoginAttempt(ctx context.Context, a domain.LoginAttempt) error {
+	m.entries = append(m.entries, a)
+	return nil
+}
+
+func newGuardedService(cfg LoginGuardConfig) (*Service, *throttleMem, *loginLogMem) {
+	ur := &userRepoNotFound{}
+	ur.byEmail = map[string]struct {
+		U  User
+		Ms []TenantMembership
+	}{
+		"e@x": {U: User{ID: "u1", Email: "e@x", PasswordHash: "hash:pass"}, Ms: []TenantMembership{{TenantID: "t1", Role: "owner", IsDefault: true}}},
+		"f@x": {U: User{ID: "u2", Email: "f@x", PasswordHash: "hash:pass"}, Ms: []TenantMembership{{TenantID: "t2", Role: "owner", IsDefault: true}}},
+	}
+	svc := NewService(ur, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	th, log := newThrottleMem(), &loginLogMem{}
+	svc.SetLoginGuard(th, cfg)
+	svc.SetLoginAttemptLog(log)
+	return svc, th, log
+}
+
+func TestLoginGuard_LocksEmailAfterFailures(t *testing.T) {
+	cfg := DefaultLoginGuardConfig()
+	cfg.BaseDelay = 0
+	cfg.EmailLockAt = 3
+	svc, th, log := newGuardedService(cfg)
+	ctx := ctxutil.WithClientInfo(context.Background(), "10.0.0.1", "curl")
+
+	for i := 0; i < 3; i++ {
+		if _, _, _, err := svc.Login(ctx, "e@x", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: expected invalid credentials, got %v", i, err)
+		}
+	}
+	// the counter is kept per normalized email
+	_, _, _, err := svc.Login(ctx, " E@x", "pass")
+	var blocked *LoginBlockedError
+	if !errors.As(err, &blocked) || !blocked.Locked || !errors.Is(err, ErrTooManyLoginAttempts) {
+		t.Fatalf("expected lockout even with the right password, got %v", err)
+	}
+	if blocked.RetryAfter <= 0 || blocked.RetryAfter > cfg.LockDuration {
+		t.Fatalf("unexpected retry after %v", blocked.RetryAfter)
+	}
+	// another account from the same IP is not affected
+	if _, _, _, err := svc.Login(ctx, "f@x", "pass"); err != nil {
+		t.Fatalf("other email: %v", err)
+	}
+	// lockout over
+	th.locks["email:e@x"] = time.Now().Add(-time.Second)
+	if _, _, _, err := svc.Login(ctx, "e@x", "pass"); err != nil {
+		t.Fatalf("after lockout: %v", err)
+	}
+
+	statuses := map[domain.LoginAttemptStatus]int{}
+	for _, a := range log.entries {
+		statuses[a.Status]++
+		if a.IPAddress != "10.0.0.1" || a.UserAgent != "curl" || a.Method != domain.LoginMethodPassword {
+			t.Fatalf("unexpected log entry: %+v", a)
+		}
+	}
+	if statuses[domain.LoginAttemptFailed] != 3 || statuses[domain.LoginAttemptBlocked] != 1 || statuses[domain.LoginAttemptSuccess] != 2 {
+		t.Fatalf("unexpected log: %v", statuses)
+	}
+	if log.entries[0].UserID != "u1" || log.entries[0].Email != "e@x" {
+		t.Fatalf("failed attempt must reference the account: %+v", log.entries[0])
+	}
+}
+
+func TestLoginGuard_ProgressiveDelay(t *testing.T) {
+	cfg := DefaultLoginGuardConfig()
+	cfg.FreeAttempts = 2
+	cfg.BaseDelay = time.Hour
+	cfg.MaxDelay = 4 * time.Hour
+	svc, th, _ := newGuardedService(cfg)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, _, err := svc.Login(ctx, "e@x", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+	}
+	_, _, _, err := svc.Login(ctx, "e@x", "pass")
+	var blocked *LoginBlockedError
+	if !errors.As(err, &blocked) || blocked.Locked {
+		t.Fatalf("expected delay, got %v", err)
+	}
+	// the delay has passed
+	th.last["email:e@x"] = time.Now().Add(-2 * time.Hour)
+	if _, _, _, err := svc.Login(ctx, "e@x", "pass"); err != nil {
+		t.Fatalf("after delay: %v", err)
+	}
+	if th.counts["email:e@x"] != 0 {
+		t.Fatal("success must reset the email counter")
+	}
+
+	g := &loginGuard{cfg: cfg}
+	for n, want := range map[int]time.Duration{0: 0, 1: 0, 2: time.Hour, 3: 2 * time.Hour, 4: 4 * time.Hour, 10: 4 * time.Hour} {
+		if got := g.delay(n); got != want {
+			t.Fatalf("delay(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestLoginGuard_LocksIPAcrossEmails(t *testing.T) {
+	cfg := DefaultLoginGuardConfig()
+	cfg.BaseDelay = 0
+	cfg.IPLockAt = 3
+	svc, th, log := newGuardedService(cfg)
+	ctx := ctxutil.WithClientInfo(context.Background(), "10.0.0.9", "")
+	for _, email := range []string{"a@x", "b@x", "c@x"} {
+		if _, _, _, err := svc.Login(ctx, email, "guess"); !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("%s: %v", email, err)
+		}
+	}
+	if _, _, _, err := svc.Login(ctx, "e@x", "pass"); !errors.Is(err, ErrTooManyLoginAttempts) {
+		t.Fatalf("expected IP lockout, got %v", err)
+	}
+	if _, _, _, err := svc.Login(context.Background(), "e@x", "pass"); err != nil {
+		t.Fatalf("other IP: %v", err)
+	}
+	if _, ok := th.locks["ip:10.0.0.9"]; !ok {
+		t.Fatal("IP not locked")
+	}
+	if log.entries[0].UserID != "" {
+		t.Fatalf("unknown email must not reference an account: %+v", log.entries[0])
+	}
+}
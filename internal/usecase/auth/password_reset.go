@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPasswordResetDisabled = errors.New("password reset is not configured")
+	ErrInvalidResetToken     = errors.New("invalid or expired reset token")
+	ErrRateLimitExceeded     = errors.New("rate limit exceeded")
+)
+
+// Rate limit actions, counted per hour
+const (
+	rateActionResetEmail = "password_reset_email"
+	rateActionResetIP    = "password_reset_ip"
+	rateActionConsumeIP  = "reset_password_ip"
+)
+
+// RateLimitRepo keeps hourly attempt counters per subject (email, IP or user id) and action.
+type RateLimitRepo interface {
+	CheckRateLimit(ctx context.Context, subject, action string, windowStart time.Time) (int, error)
+	IncrementRateLimit(ctx context.Context, subject, action string, windowStart time.Time) error
+}
+
+type PasswordResetRepo interface {
+	RateLimitRepo
+	// CreatePasswordReset stores the hash of token for the user
+	CreatePasswordReset(ctx context.Context, userID, token, ip string, expiresAt time.Time) error
+	// ResetPassword consumes a valid unused token, sets the new password hash and revokes
+	// all refresh tokens of the user in one transaction. Returns ErrInvalidResetToken
+	// for unknown, used or expired tokens.
+	ResetPassword(ctx context.Context, token, newPasswordHash string) (userID string, err error)
+}
+
+type PasswordResetMailer interface {
+	SendPasswordReset(ctx context.Context, u User, token string, expiresAt time.Time) error
+}
+
+// PasswordResetConfig holds token lifetime and hourly limits.
+type PasswordResetConfig struct {
+	TokenTTL           time.Duration
+	MaxPerEmailPerHour int
+	MaxPerIPPerHour    int
+}
+
+type passwordReset struct {
+	repo   PasswordResetRepo
+	mailer PasswordResetMailer
+	cfg    PasswordResetConfig
+}
+
+func (s *Service) SetPasswordReset(repo PasswordResetRepo, mailer PasswordResetMailer, cfg PasswordResetConfig) {
+	if cfg.TokenTTL <= 0 {
+		cfg.TokenTTL = time.Hour
+	}
+	if cfg.MaxPerEmailPerHour <= 0 {
+		cfg.MaxPerEmailPerHour = 3
+	}
+	if cfg.MaxPerIPPerHour <= 0 {
+		cfg.MaxPerIPPerHour = 10
+	}
+	s.reset = &passwordReset{repo: repo, mailer: mailer, cfg: cfg}
+}
+
+// RequestPasswordReset emails a reset link. Unknown emails are not reported to the caller
+// so the RPC can't be used to probe registered addresses.
+func (s *Service) RequestPasswordReset(ctx context.Context, email, ip string) error {
+	if s.reset == nil || s.reset.mailer == nil {
+		return ErrPasswordResetDisabled
+	}
+	email = strings.TrimSpace(email)
+	subject := strings.ToLower(email)
+	if err := checkRateLimit(ctx, s.reset.repo, subject, rateActionResetEmail, s.reset.cfg.MaxPerEmailPerHour); err != nil {
+		return err
+	}
+	if ip != "" {
+		if err := checkRateLimit(ctx, s.reset.repo, ip, rateActionResetIP, s.reset.cfg.MaxPerIPPerHour); err != nil {
+			return err
+		}
+		incrementRateLimit(ctx, s.reset.repo, ip, rateActionResetIP)
+	}
+	incrementRateLimit(ctx, s.reset.repo, subject, rateActionResetEmail)
+
+	u, _, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	token, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.reset.cfg.TokenTTL)
+	if err := s.reset.repo.CreatePasswordReset(ctx, u.ID, token, ip, expiresAt); err != nil {
+		return err
+	}
+	if err := s.reset.mailer.SendPasswordReset(ctx, u, token, expiresAt); err != nil {
+		return fmt.Errorf("send password reset: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token; all sessions of the user are revoked.
+func (s *Service) ResetPassword(ctx context.Context, token, newPassword, ip string) error {
+	if s.reset == nil {
+		return ErrPasswordResetDisabled
+	}
+	if ip != "" {
+		if err := checkRateLimit(ctx, s.reset.repo, ip, rateActionConsumeIP, s.reset.cfg.MaxPerIPPerHour); err != nil {
+			return err
+		}
+		incrementRateLimit(ctx, s.reset.repo, ip, rateActionConsumeIP)
+	}
+	hash, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	userID, err := s.reset.repo.ResetPassword(ctx, token, hash)
+	if err != nil {
+		return err
+	}
+	// refresh tokens are revoked by the repo; access tokens are denied until they expire
+	return s.revokeUserAccessTokens(ctx, userID)
+}
+
+func checkRateLimit(ctx context.Context, repo RateLimitRepo, subject, action string, limit int) error {
+	windowStart := time.Now().Truncate(time.Hour)
+	count, err := repo.CheckRateLimit(ctx, subject, action, windowStart)
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if count >= limit {
+		return ErrRateLimitExceeded
+	}
+	return nil
+}
+
+func incrementRateLimit(ctx context.Context, repo RateLimitRepo, subject, action string) {
+	windowStart := time.Now().Truncate(time.Hour)
+	_ = repo.IncrementRateLimit(ctx, subject, action, windowStart)
+}
+
+func newSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
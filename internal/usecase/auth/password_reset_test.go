@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type resetRepoMem struct {
+	tokens   map[string]string // token -> user id
+	used     map[string]bool
+	expires  map[string]time.Time
+	hashes   map[string]string // user id -> password hash
+	counters map[string]int
+	revoked  []string
+}
+
+func newResetRepoMem() *resetRepoMem {
+	return &resetRepoMem{tokens: map[string]string{}, used: map[string]bool{}, expires: map[string]time.Time{}, hashes: map[string]string{}, counters: map[string]int{}}
+}
+
+func (r *resetRepoMem) CreatePasswordReset(ctx context.Context, userID, token, ip string, expiresAt time.Time) error {
+	r.tokens[token] = userID
+	r.expires[token] = expiresAt
+	return nil
+}
+
+func (r *resetRepoMem) ResetPassword(ctx context.Context, token, newPasswordHash string) (string, error) {
+	userID, ok := r.tokens[token]
+	if !ok || r.used[token] || time.Now().After(r.expires[token]) {
+		return "", ErrInvalidResetToken
+	}
+	r.used[token] = true
+	r.hashes[userID] = newPasswordHash
+	r.revoked = append(r.revoked, userID)
+	return userID, nil
+}
+
+func (r *resetRepoMem) CheckRateLimit(ctx context.Context, subject, action string, windowStart time.Time) (int, error) {
+	return r.counters[subject+"|"+action], nil
+}
+
+func (r *resetRepoMem) IncrementRateLimit(ctx context.Context, subject, action string, windowStart time.Time) error {
+	r.counters[subject+"|"+action]++
+	return nil
+}
+
+type resetMailerMem struct{ tokens map[string]string }
+
+func (m *resetMailerMem) SendPasswordReset(ctx context.Context, u User, token string, expiresAt time.Time) error {
+	if m.tokens == nil {
+		m.tokens = map[string]string{}
+	}
+	m.tokens[u.Email] = token
+	return nil
+}
+
+type userRepoNotFound struct{ userRepoMem }
+
+func (r *userRepoNotFound) GetByEmail(ctx context.Context, email string) (User, []TenantMembership, error) {
+	if u, ms, err := r.userRepoMem.GetByEmail(ctx, email); err == nil {
+		return u, ms, nil
+	}
+	return User{}, nil, ErrUserNotFound
+}
+
+func TestPasswordReset_Flow(t *testing.T) {
+	ur := &userRepoNotFound{}
+	svc := NewService(ur, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	ctx := context.Background()
+	if err := svc.RequestPasswordReset(ctx, "e@x", "1.1.1.1"); !errors.Is(err, ErrPasswordResetDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+	if _, _, _, err := svc.Register(ctx, "e@x", "old", "User", "en", ""); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	repo := newResetRepoMem()
+	mailer := &resetMailerMem{}
+	svc.SetPasswordReset(repo, mailer, PasswordResetConfig{TokenTTL: time.Hour})
+
+	// unknown email is silently accepted
+	if err := svc.RequestPasswordReset(ctx, "nobody@x", "1.1.1.1"); err != nil {
+		t.Fatalf("unknown email: %v", err)
+	}
+	if len(mailer.tokens) != 0 {
+		t.Fatalf("no email expected for unknown address: %#v", mailer.tokens)
+	}
+	if err := svc.RequestPasswordReset(ctx, " e@x ", "1.1.1.1"); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	token := mailer.tokens["e@x"]
+	if token == "" {
+		t.Fatal("reset email not sent")
+	}
+	if err := svc.ResetPassword(ctx, "bogus", "new", "1.1.1.1"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("expected invalid token, got %v", err)
+	}
+	if err := svc.ResetPassword(ctx, token, "new", "1.1.1.1"); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if repo.hashes["u1"] != "hash:new" || len(repo.revoked) != 1 {
+		t.Fatalf("password not updated or sessions not revoked: %#v %#v", repo.hashes, repo.revoked)
+	}
+	if err := svc.ResetPassword(ctx, token, "again", "1.1.1.1"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("token must be single use, got %v", err)
+	}
+}
+
+func TestPasswordReset_RateLimits(t *testing.T) {
+	svc := NewService(&userRepoNotFound{}, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	repo := newResetRepoMem()
+	svc.SetPasswordReset(repo, &resetMailerMem{}, PasswordResetConfig{MaxPerEmailPerHour: 2, MaxPerIPPerHour: 3})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := svc.RequestPasswordReset(ctx, "a@x", "10.0.0.1"); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	if err := svc.RequestPasswordReset(ctx, "A@x", "10.0.0.2"); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("per-email limit not applied: %v", err)
+	}
+	if err := svc.RequestPasswordReset(ctx, "b@x", "10.0.0.1"); err != nil {
+		t.Fatalf("third request from ip: %v", err)
+	}
+	if err := svc.RequestPasswordReset(ctx, "c@x", "10.0.0.1"); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("per-ip limit not applied: %v", err)
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/positron48/budget/internal/domain"
 )
 
 type PasswordHasher interface {
@@ -52,34 +52,58 @@ type UserRepo interface {
 	GetByEmail(ctx context.Context, email string) (User, []TenantMembership, error)
 }
 
-type GoogleClaims struct {
-	Email         string
-	Name          string
-	EmailVerified bool
-	Locale        string
-}
-
-type GoogleTokenVerifier interface {
-	VerifyIDToken(ctx context.Context, idToken string) (GoogleClaims, error)
-}
+// GoogleTokenVerifier is kept for callers written before generic OIDC providers.
+type GoogleTokenVerifier = IDTokenVerifier
 
 type RefreshTokenRepo interface {
 	Store(ctx context.Context, userID, tenantID, token string, expiresAt time.Time) error
+	// Rotate replaces an active token with a new one of the same family;
+	// returns ErrRefreshTokenReused if the old token is no longer active.
 	Rotate(ctx context.Context, oldToken, newToken string, newExpiresAt time.Time) error
+	Revoke(ctx context.Context, token string) error
+	// RevokeFamily revokes all tokens issued by rotation from the same login
+	RevokeFamily(ctx context.Context, token string) error
 	GetByToken(ctx context.Context, token string) (struct {
 		UserID    string
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}, error)
 }
 
+// MembershipChecker reports whether a user belongs to a tenant.
+type MembershipChecker interface {
+	HasMembership(ctx context.Context, userID, tenantID string) (bool, error)
+}
+
+// InvitationAttacher accepts pending tenant invitations addressed to a newly created user.
+type InvitationAttacher interface {
+	AttachPendingInvitations(ctx context.Context, userID, email string) ([]TenantMembership, error)
+}
+
+// InvitationAttacherFunc adapts a function to InvitationAttacher.
+type InvitationAttacherFunc func(ctx context.Context, userID, email string) ([]TenantMembership, error)
+
+func (f InvitationAttacherFunc) AttachPendingInvitations(ctx context.Context, userID, email string) ([]TenantMembership, error) {
+	return f(ctx, userID, email)
+}
+
 type Service struct {
 	users      UserRepo
 	tokens     RefreshTokenRepo
 	hasher     PasswordHasher
 	issuer     TokenIssuer
-	google     GoogleTokenVerifier
+	providers  map[string]identityProvider
+	identities IdentityRepo
+	invites    InvitationAttacher
+	members    MembershipChecker
+	reset      *passwordReset
+	verify     *emailVerification
+	revoker    AccessTokenRevoker
+	twoFactor  *twoFactor
+	guard      *loginGuard
+	loginLog   LoginAttemptLog
 	accessTTL  time.Duration
 	refreshTTL time.Duration
 }
@@ -90,8 +114,11 @@ func NewService(users UserRepo, tokens RefreshTokenRepo, hasher PasswordHasher,
 
 var ErrInvalidCredentials = errors.New("invalid credentials")
 var ErrGoogleAuthDisabled = errors.New("google auth is disabled")
-var ErrGoogleEmailNotVerified = errors.New("google email is not verified")
+var ErrGoogleEmailNotVerified = ErrExternalEmailNotVerified
 var ErrUserNotFound = errors.New("user not found")
+var ErrTenantSwitchDisabled = errors.New("tenant switching is not configured")
+var ErrNotTenantMember = errors.New("user is not a member of the tenant")
+var ErrRefreshTokenReused = errors.New("refresh token reuse detected")
 
 func (s *Service) Register(ctx context.Context, email, password, name, locale, tenantName string) (User, Tenant, TokenPair, error) {
 	hash, err := s.hasher.Hash(password)
@@ -102,6 +129,11 @@ func (s *Service) Register(ctx context.Context, email, password, name, locale, t
 	if err != nil {
 		return User{}, Tenant{}, TokenPair{}, err
 	}
+	if s.verify != nil {
+		// best effort, see ResendVerification
+		_ = s.sendVerification(ctx, u)
+	}
+	// pending invitations are attached by VerifyEmail: the address is not proven yet
 	tp, err := s.issuer.Issue(ctx, u.ID, t.ID, s.accessTTL, s.refreshTTL)
 	if err != nil {
 		return User{}, Tenant{}, TokenPair{}, err
@@ -113,13 +145,37 @@ func (s *Service) Register(ctx context.Context, email, password, name, locale, t
 }
 
 func (s *Service) Login(ctx context.Context, email, password string) (User, []TenantMembership, TokenPair, error) {
+	attempt := newLoginAttempt(ctx, email, domain.LoginMethodPassword)
+	now := time.Now()
+	if err := s.guard.check(ctx, email, attempt.IPAddress, now); err != nil {
+		var blocked *LoginBlockedError
+		if errors.As(err, &blocked) {
+			s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptBlocked)
+		}
+		return User{}, nil, TokenPair{}, err
+	}
 	u, memberships, err := s.users.GetByEmail(ctx, email)
 	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			// unknown emails are counted too, otherwise they could be probed freely
+			if gerr := s.guard.failure(ctx, email, attempt.IPAddress, now); gerr != nil {
+				return User{}, nil, TokenPair{}, gerr
+			}
+			s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptFailed)
+		}
 		return User{}, nil, TokenPair{}, err
 	}
+	attempt.UserID = u.ID
 	if !s.hasher.Verify(u.PasswordHash, password) {
+		if err := s.guard.failure(ctx, email, attempt.IPAddress, now); err != nil {
+			return User{}, nil, TokenPair{}, err
+		}
+		s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptFailed)
 		return User{}, nil, TokenPair{}, ErrInvalidCredentials
 	}
+	if err := s.guard.success(ctx, email); err != nil {
+		return User{}, nil, TokenPair{}, err
+	}
 	// выбрать активный tenant: либо default в memberships, либо первый
 	tenantID := ""
 	for _, m := range memberships {
@@ -146,6 +202,16 @@ func (s *Service) Login(ctx context.Context, email, password string) (User, []Te
 		fmt.Printf("DEBUG: Selected tenantID: %s\n", tenantID)
 	}
 
+	// with 2FA enabled tokens are issued by CompleteTwoFactorLogin
+	challenge, err := s.loginChallenge(ctx, u.ID, tenantID)
+	if err != nil {
+		return User{}, nil, TokenPair{}, err
+	}
+	if challenge != nil {
+		s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptChallenged)
+		return u, memberships, TokenPair{}, challenge
+	}
+
 	tp, err := s.issuer.Issue(ctx, u.ID, tenantID, s.accessTTL, s.refreshTTL)
 	if err != nil {
 		return User{}, nil, TokenPair{}, err
@@ -153,84 +219,49 @@ func (s *Service) Login(ctx context.Context, email, password string) (User, []Te
 	if err := s.tokens.Store(ctx, u.ID, tenantID, tp.RefreshToken, tp.RefreshTokenExpiresAt); err != nil {
 		return User{}, nil, TokenPair{}, err
 	}
+	s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptSuccess)
 	return u, memberships, tp, nil
 }
 
+// SetGoogleVerifier registers Google as the "google" identity provider; Google verifies
+// emails, so existing accounts are linked by email.
 func (s *Service) SetGoogleVerifier(verifier GoogleTokenVerifier) {
-	s.google = verifier
+	s.SetIdentityProvider(GoogleProvider, verifier, true)
 }
 
-func (s *Service) GoogleAuth(ctx context.Context, idToken, locale, tenantName string) (User, []TenantMembership, *Tenant, TokenPair, error) {
-	if s.google == nil {
-		return User{}, nil, nil, TokenPair{}, ErrGoogleAuthDisabled
-	}
-
-	claims, err := s.google.VerifyIDToken(ctx, idToken)
-	if err != nil {
-		return User{}, nil, nil, TokenPair{}, err
-	}
-	if !claims.EmailVerified {
-		return User{}, nil, nil, TokenPair{}, ErrGoogleEmailNotVerified
-	}
-
-	user, memberships, err := s.users.GetByEmail(ctx, claims.Email)
-	var createdTenant *Tenant
-	if err != nil {
-		if !errors.Is(err, ErrUserNotFound) {
-			return User{}, nil, nil, TokenPair{}, err
-		}
-		name := claims.Name
-		if name == "" {
-			name = claims.Email
-		}
-		effectiveLocale := locale
-		if effectiveLocale == "" {
-			effectiveLocale = claims.Locale
-		}
-		if effectiveLocale == "" {
-			effectiveLocale = "ru"
-		}
+func (s *Service) SetMembershipChecker(checker MembershipChecker) {
+	s.members = checker
+}
 
-		// Password-based auth is deprecated for web flow, but DB schema still requires hash.
-		fallbackPassword := uuid.NewString()
-		hash, hashErr := s.hasher.Hash(fallbackPassword)
-		if hashErr != nil {
-			return User{}, nil, nil, TokenPair{}, hashErr
-		}
+func (s *Service) SetInvitationAttacher(attacher InvitationAttacher) {
+	s.invites = attacher
+}
 
-		createdUser, tenant, createErr := s.users.CreateWithDefaultTenant(ctx, claims.Email, hash, name, effectiveLocale, tenantName)
-		if createErr != nil {
-			return User{}, nil, nil, TokenPair{}, createErr
-		}
-		user = createdUser
-		createdTenant = &tenant
-		memberships = []TenantMembership{{
-			TenantID:  tenant.ID,
-			Role:      "owner",
-			IsDefault: true,
-		}}
+func (s *Service) markEmailVerified(ctx context.Context, userID string) {
+	if s.verify != nil {
+		_ = s.verify.repo.MarkEmailVerified(ctx, userID)
 	}
+}
 
-	tenantID := ""
-	for _, m := range memberships {
-		if m.IsDefault {
-			tenantID = m.TenantID
-			break
-		}
+// attachInvitations is best effort: the account is already created, and invitations
+// that could not be attached stay pending and can be accepted later. It must only be called
+// once the user has proven control of the email address.
+func (s *Service) attachInvitations(ctx context.Context, u User) []TenantMembership {
+	if s.invites == nil {
+		return nil
 	}
-	if tenantID == "" && len(memberships) > 0 {
-		tenantID = memberships[0].TenantID
-	}
-
-	tp, err := s.issuer.Issue(ctx, user.ID, tenantID, s.accessTTL, s.refreshTTL)
+	ms, err := s.invites.AttachPendingInvitations(ctx, u.ID, u.Email)
 	if err != nil {
-		return User{}, nil, nil, TokenPair{}, err
-	}
-	if err := s.tokens.Store(ctx, user.ID, tenantID, tp.RefreshToken, tp.RefreshTokenExpiresAt); err != nil {
-		return User{}, nil, nil, TokenPair{}, err
+		return nil
 	}
+	return ms
+}
 
-	return user, memberships, createdTenant, tp, nil
+func (s *Service) GoogleAuth(ctx context.Context, idToken, locale, tenantName string) (User, []TenantMembership, *Tenant, TokenPair, error) {
+	if _, ok := s.providers[GoogleProvider]; !ok {
+		return User{}, nil, nil, TokenPair{}, ErrGoogleAuthDisabled
+	}
+	return s.ExternalAuth(ctx, GoogleProvider, idToken, locale, tenantName)
 }
 
 // StoreRefreshToken сохраняет refresh token в базе данных
@@ -244,15 +275,84 @@ func (s *Service) Refresh(ctx context.Context, refreshToken string) (TokenPair,
 	if err != nil {
 		return TokenPair{}, ErrInvalidCredentials
 	}
-	if row.RevokedAt != nil || time.Now().After(row.ExpiresAt) {
+	if row.RotatedAt != nil {
+		// an already rotated token is presented again: it leaked, so end the whole session
+		return TokenPair{}, s.revokeFamily(ctx, refreshToken)
+	}
+	if row.RevokedAt != nil {
+		// logged out or the session was revoked
 		return TokenPair{}, ErrInvalidCredentials
 	}
+	if time.Now().After(row.ExpiresAt) {
+		return TokenPair{}, ErrInvalidCredentials
+	}
+	if s.members != nil && row.TenantID != "" {
+		// the user may have been removed from the tenant since login
+		ok, err := s.members.HasMembership(ctx, row.UserID, row.TenantID)
+		if err != nil {
+			return TokenPair{}, err
+		}
+		if !ok {
+			_ = s.tokens.RevokeFamily(ctx, refreshToken)
+			return TokenPair{}, ErrNotTenantMember
+		}
+	}
 	// issue new pair with tenant_id from refresh token
 	tp, err := s.issuer.Issue(ctx, row.UserID, row.TenantID, s.accessTTL, s.refreshTTL)
 	if err != nil {
 		return TokenPair{}, err
 	}
 	if err := s.tokens.Rotate(ctx, refreshToken, tp.RefreshToken, tp.RefreshTokenExpiresAt); err != nil {
+		if errors.Is(err, ErrRefreshTokenReused) {
+			// lost a race with another refresh of the same token
+			return TokenPair{}, s.revokeFamily(ctx, refreshToken)
+		}
+		return TokenPair{}, err
+	}
+	return tp, nil
+}
+
+func (s *Service) revokeFamily(ctx context.Context, refreshToken string) error {
+	if err := s.tokens.RevokeFamily(ctx, refreshToken); err != nil {
+		return fmt.Errorf("revoke token family: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
+
+// SwitchTenant issues a new token pair scoped to another tenant of the user.
+// If the current refresh token is passed, it is revoked so that a later refresh
+// does not bring the session back to the previous tenant.
+func (s *Service) SwitchTenant(ctx context.Context, userID, tenantID, refreshToken string) (TokenPair, error) {
+	if s.members == nil {
+		return TokenPair{}, ErrTenantSwitchDisabled
+	}
+	ok, err := s.members.HasMembership(ctx, userID, tenantID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if !ok {
+		return TokenPair{}, ErrNotTenantMember
+	}
+	if refreshToken != "" {
+		row, err := s.tokens.GetByToken(ctx, refreshToken)
+		if err != nil || row.UserID != userID || row.RevokedAt != nil {
+			return TokenPair{}, ErrInvalidCredentials
+		}
+	}
+	tp, err := s.issuer.Issue(ctx, userID, tenantID, s.accessTTL, s.refreshTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if err := s.tokens.Store(ctx, userID, tenantID, tp.RefreshToken, tp.RefreshTokenExpiresAt); err != nil {
+		return TokenPair{}, err
+	}
+	if refreshToken != "" {
+		if err := s.tokens.Revoke(ctx, refreshToken); err != nil {
+			return TokenPair{}, err
+		}
+	}
+	// the previous access token is still scoped to the old tenant
+	if err := s.revokeCurrentAccessToken(ctx); err != nil {
 		return TokenPair{}, err
 	}
 	return tp, nil
@@ -73,7 +73,9 @@ type tokensMem struct {
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}
+	families map[string]string // token -> first token of the family
 }
 
 func (t *tokensMem) Store(ctx context.Context, userID, tenantID, token string, expiresAt time.Time) error {
@@ -83,6 +85,7 @@ func (t *tokensMem) Store(ctx context.Context, userID, tenantID, token string, e
 			TenantID  string
 			ExpiresAt time.Time
 			RevokedAt *time.Time
+			RotatedAt *time.Time
 		}{}
 	}
 	t.rows[token] = struct {
@@ -90,7 +93,12 @@ func (t *tokensMem) Store(ctx context.Context, userID, tenantID, token string, e
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}{UserID: userID, TenantID: tenantID, ExpiresAt: expiresAt}
+	if t.families == nil {
+		t.families = map[string]string{}
+	}
+	t.families[token] = token
 	return nil
 }
 
@@ -99,23 +107,56 @@ func (t *tokensMem) Rotate(ctx context.Context, oldToken, newToken string, newEx
 		return errors.New("no rows")
 	}
 	row := t.rows[oldToken]
+	if row.RevokedAt != nil {
+		return ErrRefreshTokenReused
+	}
+	if t.families == nil {
+		t.families = map[string]string{}
+	}
+	t.families[newToken] = t.families[oldToken]
 	now := time.Now()
-	row.RevokedAt = &now
+	row.RevokedAt, row.RotatedAt = &now, &now
 	t.rows[oldToken] = row
 	t.rows[newToken] = struct {
 		UserID    string
 		TenantID  string
 		ExpiresAt time.Time
 		RevokedAt *time.Time
+		RotatedAt *time.Time
 	}{UserID: row.UserID, TenantID: row.TenantID, ExpiresAt: newExpiresAt}
 	return nil
 }
 
+func (t *tokensMem) Revoke(ctx context.Context, token string) error {
+	row, ok := t.rows[token]
+	if !ok {
+		return errors.New("not found")
+	}
+	now := time.Now()
+	row.RevokedAt = &now
+	t.rows[token] = row
+	return nil
+}
+
+func (t *tokensMem) RevokeFamily(ctx context.Context, token string) error {
+	family, ok := t.families[token]
+	if !ok {
+		return nil
+	}
+	for tok, f := range t.families {
+		if f == family {
+			_ = t.Revoke(ctx, tok)
+		}
+	}
+	return nil
+}
+
 func (t *tokensMem) GetByToken(ctx context.Context, token string) (struct {
 	UserID    string
 	TenantID  string
 	ExpiresAt time.Time
 	RevokedAt *time.Time
+	RotatedAt *time.Time
 }, error,
 ) {
 	if t.rows == nil {
@@ -124,6 +165,7 @@ func (t *tokensMem) GetByToken(ctx context.Context, token string) (struct {
 			TenantID  string
 			ExpiresAt time.Time
 			RevokedAt *time.Time
+			RotatedAt *time.Time
 		}{}, errors.New("not found")
 	}
 	row, ok := t.rows[token]
@@ -133,6 +175,7 @@ func (t *tokensMem) GetByToken(ctx context.Context, token string) (struct {
 			TenantID  string
 			ExpiresAt time.Time
 			RevokedAt *time.Time
+			RotatedAt *time.Time
 		}{}, errors.New("not found")
 	}
 	return row, nil
@@ -327,3 +370,98 @@ func TestService_Refresh_IssueAndRotateErrors(t *testing.T) {
 		t.Fatal("expected rotate error")
 	}
 }
+
+func TestService_Register_DoesNotAttachInvitations(t *testing.T) {
+	svc := NewService(&userRepoMem{}, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	attached := 0
+	svc.SetInvitationAttacher(InvitationAttacherFunc(func(ctx context.Context, userID, email string) ([]TenantMembership, error) {
+		attached++
+		return nil, nil
+	}))
+	// without verification nobody has proven control of the address
+	if _, _, _, err := svc.Register(context.Background(), "e@x", "Passw0rd!", "User", "ru", "Дом"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if attached != 0 {
+		t.Fatal("invitations must not be attached to an unverified address")
+	}
+}
+
+type membersStub map[string]bool
+
+func (m membersStub) HasMembership(ctx context.Context, userID, tenantID string) (bool, error) {
+	return m[userID+"|"+tenantID], nil
+}
+
+func TestService_SwitchTenant(t *testing.T) {
+	tr := &tokensMem{}
+	svc := NewService(&userRepoMem{}, tr, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	ctx := context.Background()
+	if _, err := svc.SwitchTenant(ctx, "u1", "t2", ""); !errors.Is(err, ErrTenantSwitchDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+	svc.SetMembershipChecker(membersStub{"u1|t2": true})
+	if _, err := svc.SwitchTenant(ctx, "u1", "t3", ""); !errors.Is(err, ErrNotTenantMember) {
+		t.Fatalf("expected not member, got %v", err)
+	}
+	_ = tr.Store(ctx, "u1", "t1", "old", time.Now().Add(time.Hour))
+	_ = tr.Store(ctx, "u9", "t1", "foreign", time.Now().Add(time.Hour))
+	if _, err := svc.SwitchTenant(ctx, "u1", "t2", "foreign"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("foreign refresh token must be rejected: %v", err)
+	}
+	tp, err := svc.SwitchTenant(ctx, "u1", "t2", "old")
+	if err != nil {
+		t.Fatalf("switch: %v", err)
+	}
+	if row := tr.rows[tp.RefreshToken]; row.TenantID != "t2" || row.UserID != "u1" {
+		t.Fatalf("new refresh token not bound to t2: %#v", row)
+	}
+	if tr.rows["old"].RevokedAt == nil {
+		t.Fatal("previous refresh token not revoked")
+	}
+	// refresh keeps the switched tenant
+	if _, err := svc.Refresh(ctx, tp.RefreshToken); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+}
+
+func TestService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	tr := &tokensMem{}
+	svc := NewService(&userRepoMem{}, tr, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	ctx := context.Background()
+	_, _, tp, err := svc.Register(ctx, "e@x", "pass", "U", "ru", "Дом")
+	if err != nil {
+		t.Fatalf("reg: %v", err)
+	}
+	rotated, err := svc.Refresh(ctx, tp.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	// the old token is presented again
+	if _, err := svc.Refresh(ctx, tp.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("expected reuse detection, got %v", err)
+	}
+	if tr.rows[rotated.RefreshToken].RevokedAt == nil {
+		t.Fatal("token issued by rotation must be revoked as well")
+	}
+	if _, err := svc.Refresh(ctx, rotated.RefreshToken); err == nil {
+		t.Fatal("expected the whole session to be signed out")
+	}
+}
+
+func TestService_Refresh_RevokedIsNotReuse(t *testing.T) {
+	tr := &tokensMem{}
+	svc := NewService(&userRepoMem{}, tr, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	ctx := context.Background()
+	_, _, tp, err := svc.Register(ctx, "e@x", "pass", "U", "ru", "Дом")
+	if err != nil {
+		t.Fatalf("reg: %v", err)
+	}
+	if err := tr.Revoke(ctx, tp.RefreshToken); err != nil {
+		t.Fatal(err)
+	}
+	// a logged out token is just invalid, not a leaked one
+	if _, err := svc.Refresh(ctx, tp.RefreshToken); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected invalid credentials, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+// AccessTokenRevoker invalidates access tokens before they expire.
+type AccessTokenRevoker interface {
+	// RevokeAccessToken denies a single token (by jti) until it expires
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeUserTokens denies all access tokens of the user issued before the given time (second precision)
+	RevokeUserTokens(ctx context.Context, userID string, before time.Time) error
+}
+
+func (s *Service) SetAccessTokenRevoker(r AccessTokenRevoker) { s.revoker = r }
+
+// revokeCurrentAccessToken denies the access token the request was authenticated with.
+func (s *Service) revokeCurrentAccessToken(ctx context.Context) error {
+	if s.revoker == nil {
+		return nil
+	}
+	jti, exp, ok := ctxutil.AccessTokenFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if err := s.revoker.RevokeAccessToken(ctx, jti, exp); err != nil {
+		return fmt.Errorf("revoke access token: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) revokeUserAccessTokens(ctx context.Context, userID string) error {
+	if s.revoker == nil {
+		return nil
+	}
+	if err := s.revoker.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+		return fmt.Errorf("revoke access tokens: %w", err)
+	}
+	return nil
+}
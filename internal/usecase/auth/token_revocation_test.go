@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+type revokerMem struct {
+	jtis  []string
+	users []string
+}
+
+func (r *revokerMem) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	r.jtis = append(r.jtis, jti)
+	return nil
+}
+
+func (r *revokerMem) RevokeUserTokens(ctx context.Context, userID string, before time.Time) error {
+	r.users = append(r.users, userID)
+	return nil
+}
+
+func TestService_SwitchTenant_RevokesCurrentAccessToken(t *testing.T) {
+	svc := NewService(&userRepoMem{}, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	svc.SetMembershipChecker(membersStub{"u1|t2": true})
+	rev := &revokerMem{}
+	svc.SetAccessTokenRevoker(rev)
+	ctx := ctxutil.WithAccessToken(context.Background(), "jti-1", time.Now().Add(time.Minute))
+	if _, err := svc.SwitchTenant(ctx, "u1", "t2", ""); err != nil {
+		t.Fatalf("switch: %v", err)
+	}
+	if len(rev.jtis) != 1 || rev.jtis[0] != "jti-1" {
+		t.Fatalf("expected current access token denied, got %v", rev.jtis)
+	}
+}
+
+func TestService_Refresh_RemovedMember(t *testing.T) {
+	tr := &tokensMem{}
+	svc := NewService(&userRepoMem{}, tr, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	svc.SetMembershipChecker(membersStub{"u1|t1": true})
+	ctx := context.Background()
+	_ = tr.Store(ctx, "u1", "t1", "member", time.Now().Add(time.Hour))
+	_ = tr.Store(ctx, "u1", "t2", "removed", time.Now().Add(time.Hour))
+	if _, err := svc.Refresh(ctx, "member"); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if _, err := svc.Refresh(ctx, "removed"); !errors.Is(err, ErrNotTenantMember) {
+		t.Fatalf("expected not member, got %v", err)
+	}
+	if tr.rows["removed"].RevokedAt == nil {
+		t.Fatal("refresh token of the removed tenant must be revoked")
+	}
+}
+
+func TestService_ResetPassword_RevokesAccessTokens(t *testing.T) {
+	ur := &userRepoNotFound{}
+	svc := NewService(ur, &tokensMem{}, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	ctx := context.Background()
+	if _, _, _, err := svc.Register(ctx, "e@x", "old", "User", "en", ""); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	mailer := &resetMailerMem{}
+	svc.SetPasswordReset(newResetRepoMem(), mailer, PasswordResetConfig{TokenTTL: time.Hour})
+	rev := &revokerMem{}
+	svc.SetAccessTokenRevoker(rev)
+	if err := svc.RequestPasswordReset(ctx, "e@x", ""); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if err := svc.ResetPassword(ctx, mailer.tokens["e@x"], "new", ""); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if len(rev.users) != 1 || rev.users[0] != "u1" {
+		t.Fatalf("expected access tokens of u1 revoked, got %v", rev.users)
+	}
+}
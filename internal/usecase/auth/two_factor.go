@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/totp"
+)
+
+var (
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+	ErrTwoFactorNotEnabled       = errors.New("two-factor authentication is not enabled")
+)
+
+const (
+	// DefaultTwoFactorChallengeTTL is how long a login challenge can be completed.
+	DefaultTwoFactorChallengeTTL = 5 * time.Minute
+	// RecoveryCodeCount codes are issued when 2FA is enabled
+	RecoveryCodeCount = 10
+
+	maxChallengeAttempts        = 5
+	maxTwoFactorFailuresPerHour = 10
+	rateActionTwoFactorFailure  = "two_factor_failure"
+	recoveryCodeAlphabet        = "0123456789abcdefghjkmnpqrstvwxyz" // Crockford base32: 256 % 32 == 0, no bias
+	recoveryCodeHalfLen         = 5
+)
+
+// TOTPState is the TOTP secret of a user; Enabled is false until enrollment is confirmed.
+type TOTPState struct {
+	Secret  string
+	Enabled bool
+}
+
+// SecondFactorRepo stores TOTP secrets and recovery codes (hashed).
+type SecondFactorRepo interface {
+	RateLimitRepo
+	// GetTOTP returns ErrTwoFactorNotEnabled if the user never started enrollment
+	GetTOTP(ctx context.Context, userID string) (TOTPState, error)
+	// MarkTOTPStepUsed records the last accepted step; false if step was already used
+	MarkTOTPStepUsed(ctx context.Context, userID string, step int64) (bool, error)
+	// ConsumeRecoveryCode marks an unused code as used; false if there is no such code
+	ConsumeRecoveryCode(ctx context.Context, userID, code string) (bool, error)
+}
+
+type LoginChallenge struct {
+	UserID   string
+	TenantID string
+	Attempts int
+}
+
+type TwoFactorRepo interface {
+	SecondFactorRepo
+	// CreateLoginChallenge stores the hash of token
+	CreateLoginChallenge(ctx context.Context, userID, tenantID, token string, expiresAt time.Time) error
+	// GetLoginChallenge returns ErrInvalidTwoFactorChallenge for unknown, used or expired tokens
+	GetLoginChallenge(ctx context.Context, token string) (LoginChallenge, error)
+	IncrementChallengeAttempts(ctx context.Context, token string) error
+	// ConsumeLoginChallenge marks the challenge used; ErrInvalidTwoFactorChallenge if it already was
+	ConsumeLoginChallenge(ctx context.Context, token string) error
+	ListMemberships(ctx context.Context, userID string) ([]TenantMembership, error)
+}
+
+// TwoFactorRequiredError is returned by Login when the password is correct but the user
+// has 2FA enabled: the challenge must be completed with CompleteTwoFactorLogin.
+type TwoFactorRequiredError struct {
+	ChallengeToken string
+	ExpiresAt      time.Time
+}
+
+func (e *TwoFactorRequiredError) Error() string { return "two-factor authentication required" }
+
+type twoFactor struct {
+	repo TwoFactorRepo
+	ttl  time.Duration
+}
+
+// SetTwoFactor enables the second login step for users with TOTP enabled.
+func (s *Service) SetTwoFactor(repo TwoFactorRepo, challengeTTL time.Duration) {
+	if challengeTTL <= 0 {
+		challengeTTL = DefaultTwoFactorChallengeTTL
+	}
+	s.twoFactor = &twoFactor{repo: repo, ttl: challengeTTL}
+}
+
+// loginChallenge returns a challenge if the user has 2FA enabled, nil otherwise.
+func (s *Service) loginChallenge(ctx context.Context, userID, tenantID string) (*TwoFactorRequiredError, error) {
+	if s.twoFactor == nil {
+		return nil, nil
+	}
+	state, err := s.twoFactor.repo.GetTOTP(ctx, userID)
+	if errors.Is(err, ErrTwoFactorNotEnabled) || (err == nil && !state.Enabled) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(s.twoFactor.ttl)
+	if err := s.twoFactor.repo.CreateLoginChallenge(ctx, userID, tenantID, token, expiresAt); err != nil {
+		return nil, err
+	}
+	return &TwoFactorRequiredError{ChallengeToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// CompleteTwoFactorLogin finishes a login started with Login using a TOTP or recovery code.
+// A challenge accepts a few wrong codes and is then invalidated.
+func (s *Service) CompleteTwoFactorLogin(ctx context.Context, challengeToken, code string) ([]TenantMembership, TokenPair, error) {
+	if s.twoFactor == nil {
+		return nil, TokenPair{}, ErrInvalidTwoFactorChallenge
+	}
+	repo := s.twoFactor.repo
+	ch, err := repo.GetLoginChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, TokenPair{}, err
+	}
+	if ch.Attempts >= maxChallengeAttempts {
+		_ = repo.ConsumeLoginChallenge(ctx, challengeToken)
+		return nil, TokenPair{}, ErrInvalidTwoFactorChallenge
+	}
+	attempt := newLoginAttempt(ctx, "", domain.LoginMethodTwoFactor)
+	attempt.UserID = ch.UserID
+	if err := VerifySecondFactor(ctx, repo, ch.UserID, code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			_ = repo.IncrementChallengeAttempts(ctx, challengeToken)
+			s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptFailed)
+		}
+		return nil, TokenPair{}, err
+	}
+	if err := repo.ConsumeLoginChallenge(ctx, challengeToken); err != nil {
+		return nil, TokenPair{}, err
+	}
+	memberships, err := repo.ListMemberships(ctx, ch.UserID)
+	if err != nil {
+		return nil, TokenPair{}, err
+	}
+	tp, err := s.issuer.Issue(ctx, ch.UserID, ch.TenantID, s.accessTTL, s.refreshTTL)
+	if err != nil {
+		return nil, TokenPair{}, err
+	}
+	if err := s.tokens.Store(ctx, ch.UserID, ch.TenantID, tp.RefreshToken, tp.RefreshTokenExpiresAt); err != nil {
+		return nil, TokenPair{}, err
+	}
+	s.recordLoginAttempt(ctx, attempt, domain.LoginAttemptSuccess)
+	return memberships, tp, nil
+}
+
+// VerifySecondFactor accepts a current TOTP code (each at most once) or an unused recovery code.
+// Failures are counted per user and limited per hour.
+func VerifySecondFactor(ctx context.Context, repo SecondFactorRepo, userID, code string) error {
+	if err := checkRateLimit(ctx, repo, userID, rateActionTwoFactorFailure, maxTwoFactorFailuresPerHour); err != nil {
+		return err
+	}
+	state, err := repo.GetTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !state.Enabled {
+		return ErrTwoFactorNotEnabled
+	}
+	ok, err := checkSecondFactor(ctx, repo, userID, state.Secret, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		incrementRateLimit(ctx, repo, userID, rateActionTwoFactorFailure)
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// VerifyTOTPEnrollment checks the first code for a not yet enabled secret and returns its
+// step; failures count towards the same hourly limit.
+func VerifyTOTPEnrollment(ctx context.Context, repo SecondFactorRepo, userID, secret, code string) (int64, error) {
+	if err := checkRateLimit(ctx, repo, userID, rateActionTwoFactorFailure, maxTwoFactorFailuresPerHour); err != nil {
+		return 0, err
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		incrementRateLimit(ctx, repo, userID, rateActionTwoFactorFailure)
+		return 0, ErrInvalidTwoFactorCode
+	}
+	return step, nil
+}
+
+func checkSecondFactor(ctx context.Context, repo SecondFactorRepo, userID, secret, code string) (bool, error) {
+	if totp.IsCode(code) {
+		step, ok := totp.Validate(secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		// a code seen once (e.g. over a shoulder) can't be replayed within its period
+		return repo.MarkTOTPStepUsed(ctx, userID, step)
+	}
+	code = NormalizeRecoveryCode(code)
+	if code == "" {
+		return false, nil
+	}
+	return repo.ConsumeRecoveryCode(ctx, userID, code)
+}
+
+// NewRecoveryCodes generates one-time codes like "7f3kq-m0zx9".
+func NewRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	buf := make([]byte, 2*recoveryCodeHalfLen)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("generate recovery code: %w", err)
+		}
+		var b strings.Builder
+		for j, c := range buf {
+			if j == recoveryCodeHalfLen {
+				b.WriteByte('-')
+			}
+			b.WriteByte(recoveryCodeAlphabet[int(c)%len(recoveryCodeAlphabet)])
+		}
+		codes = append(codes, b.String())
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode lowercases the code and drops separators, so that codes can be
+// typed with or without the dash. Repos hash the normalized form.
+func NormalizeRecoveryCode(code string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(code) {
+		if r == '-' || r == ' ' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
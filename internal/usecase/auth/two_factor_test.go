@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/pkg/totp"
+)
+
+type twoFactorMem struct {
+	totp       map[string]TOTPState
+	lastStep   map[string]int64
+	recovery   map[string]string // normalized code -> user id
+	challenges map[string]*LoginChallenge
+	used       map[string]bool
+	counters   map[string]int
+}
+
+func newTwoFactorMem() *twoFactorMem {
+	return &twoFactorMem{
+		totp: map[string]TOTPState{}, lastStep: map[string]int64{}, recovery: map[string]string{},
+		challenges: map[string]*LoginChallenge{}, used: map[string]bool{}, counters: map[string]int{},
+	}
+}
+
+func (m *twoFactorMem) CheckRateLimit(ctx context.Context, subject, action string, windowStart time.Time) (int, error) {
+	return m.counters[subject+"|"+action], nil
+}
+
+func (m *twoFactorMem) IncrementRateLimit(ctx context.Context, subject, action string, windowStart time.Time) error {
+	m.counters[subject+"|"+action]++
+	return nil
+}
+
+func (m *twoFactorMem) GetTOTP(ctx context.Context, userID string) (TOTPState, error) {
+	st, ok := m.totp[userID]
+	if !ok {
+		return TOTPState{}, ErrTwoFactorNotEnabled
+	}
+	return st, nil
+}
+
+func (m *twoFactorMem) MarkTOTPStepUsed(ctx context.Context, userID string, step int64) (bool, error) {
+	if m.lastStep[userID] >= step {
+		return false, nil
+	}
+	m.lastStep[userID] = step
+	return true, nil
+}
+
+func (m *twoFactorMem) ConsumeRecoveryCode(ctx context.Context, userID, code string) (bool, error) {
+	if m.recovery[code] != userID {
+		return false, nil
+	}
+	delete(m.recovery, code)
+	return true, nil
+}
+
+func (m *twoFactorMem) CreateLoginChallenge(ctx context.Context, userID, tenantID, token string, expiresAt time.Time) error {
+	m.challenges[token] = &LoginChallenge{UserID: userID, TenantID: tenantID}
+	return nil
+}
+
+func (m *twoFactorMem) GetLoginChallenge(ctx context.Context, token string) (LoginChallenge, error) {
+	ch, ok := m.challenges[token]
+	if !ok || m.used[token] {
+		return LoginChallenge{}, ErrInvalidTwoFactorChallenge
+	}
+	return *ch, nil
+}
+
+func (m *twoFactorMem) IncrementChallengeAttempts(ctx context.Context, token string) error {
+	m.challenges[token].Attempts++
+	return nil
+}
+
+func (m *twoFactorMem) ConsumeLoginChallenge(ctx context.Context, token string) error {
+	if m.used[token] {
+		return ErrInvalidTwoFactorChallenge
+	}
+	m.used[token] = true
+	return nil
+}
+
+func (m *twoFactorMem) ListMemberships(ctx context.Context, userID string) ([]TenantMembership, error) {
+	return []TenantMembership{{TenantID: "t1", Role: "owner", IsDefault: true}}, nil
+}
+
+func newTwoFactorService(t *testing.T) (*Service, *twoFactorMem, *tokensMem, string) {
+	t.Helper()
+	ur := &userRepoMem{}
+	_, _, _ = ur.CreateWithDefaultTenant(context.Background(), "e@x", "hash:pass", "N", "en", "T")
+	tokens := &tokensMem{}
+	svc := NewService(ur, tokens, hasherOK{}, issuerStub{}, time.Minute, time.Hour)
+	repo := newTwoFactorMem()
+	svc.SetTwoFactor(repo, 0)
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.totp["u1"] = TOTPState{Secret: secret, Enabled: true}
+	return svc, repo, tokens, secret
+}
+
+func loginChallengeToken(t *testing.T, svc *Service) string {
+	t.Helper()
+	_, _, tp, err := svc.Login(context.Background(), "e@x", "pass")
+	var challenge *TwoFactorRequiredError
+	if !errors.As(err, &challenge) {
+		t.Fatalf("expected two-factor challenge, got %v", err)
+	}
+	if tp.AccessToken != "" || challenge.ChallengeToken == "" || time.Until(challenge.ExpiresAt) <= 0 {
+		t.Fatalf("unexpected challenge: %+v %+v", tp, challenge)
+	}
+	return challenge.ChallengeToken
+}
+
+func TestLogin_TwoFactorChallenge(t *testing.T) {
+	svc, _, tokens, secret := newTwoFactorService(t)
+	ctx := context.Background()
+	ch := loginChallengeToken(t, svc)
+	if len(tokens.rows) != 0 {
+		t.Fatal("no refresh token must be stored before the second factor")
+	}
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	ms, tp, err := svc.CompleteTwoFactorLogin(ctx, ch, code)
+	if err != nil || tp.AccessToken == "" || len(ms) != 1 {
+		t.Fatalf("complete: %v %+v %v", err, tp, ms)
+	}
+	if _, ok := tokens.rows[tp.RefreshToken]; !ok {
+		t.Fatal("refresh token not stored")
+	}
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, ch, code); !errors.Is(err, ErrInvalidTwoFactorChallenge) {
+		t.Fatalf("challenge must be single use, got %v", err)
+	}
+	// the same code can't complete another login
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, loginChallengeToken(t, svc), code); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("replayed code must be rejected, got %v", err)
+	}
+}
+
+func TestLogin_WithoutTwoFactor(t *testing.T) {
+	svc, repo, _, _ := newTwoFactorService(t)
+	repo.totp["u1"] = TOTPState{Secret: "ABC", Enabled: false} // enrollment not confirmed
+	if _, _, tp, err := svc.Login(context.Background(), "e@x", "pass"); err != nil || tp.AccessToken == "" {
+		t.Fatalf("login without 2FA must issue tokens: %v", err)
+	}
+}
+
+func TestCompleteTwoFactorLogin_RecoveryCode(t *testing.T) {
+	svc, repo, _, _ := newTwoFactorService(t)
+	repo.recovery["abcdefghjk"] = "u1"
+	ctx := context.Background()
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, loginChallengeToken(t, svc), "ABCDE-FGHJK"); err != nil {
+		t.Fatalf("recovery code: %v", err)
+	}
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, loginChallengeToken(t, svc), "abcde-fghjk"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("recovery code must be single use, got %v", err)
+	}
+}
+
+func TestCompleteTwoFactorLogin_AttemptLimits(t *testing.T) {
+	svc, _, _, secret := newTwoFactorService(t)
+	ctx := context.Background()
+	ch := loginChallengeToken(t, svc)
+	for i := 0; i < maxChallengeAttempts; i++ {
+		if _, _, err := svc.CompleteTwoFactorLogin(ctx, ch, "000000"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+	}
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, ch, code); !errors.Is(err, ErrInvalidTwoFactorChallenge) {
+		t.Fatalf("challenge must be invalidated after too many attempts, got %v", err)
+	}
+	// a new challenge doesn't reset the hourly per-user limit
+	for i := maxChallengeAttempts; i < maxTwoFactorFailuresPerHour; i++ {
+		_, _, _ = svc.CompleteTwoFactorLogin(ctx, loginChallengeToken(t, svc), "000000")
+	}
+	if _, _, err := svc.CompleteTwoFactorLogin(ctx, loginChallengeToken(t, svc), code); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("expected rate limit, got %v", err)
+	}
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, err := NewRecoveryCodes()
+	if err != nil || len(codes) != RecoveryCodeCount {
+		t.Fatalf("codes: %v %v", err, codes)
+	}
+	seen := map[string]bool{}
+	for _, c := range codes {
+		if len(c) != 2*recoveryCodeHalfLen+1 || c[recoveryCodeHalfLen] != '-' || seen[c] {
+			t.Fatalf("bad code %q", c)
+		}
+		seen[c] = true
+		if totp.IsCode(NormalizeRecoveryCode(c)) {
+			t.Fatalf("recovery code %q must not look like a TOTP code", c)
+		}
+	}
+}
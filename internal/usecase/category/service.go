@@ -2,8 +2,17 @@ package category
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
+	"github.com/positron48/budget/internal/usecase/webhook"
+)
+
+var (
+	ErrCategoryInUse         = errors.New("category has transactions")
+	ErrInvalidReassignTarget = errors.New("invalid category to reassign transactions to")
 )
 
 type Repo interface {
@@ -12,21 +21,111 @@ type Repo interface {
 	Delete(ctx context.Context, id string) error
 	Get(ctx context.Context, id string) (domain.Category, error)
 	List(ctx context.Context, tenantID string, kind domain.CategoryKind, includeInactive bool) ([]domain.Category, error)
+	// CountTransactions counts live transactions of the category
+	CountTransactions(ctx context.Context, id string) (int64, error)
+	// ReassignTransactions moves every transaction of a category to another one and
+	// returns the moved transactions that are not in the trash
+	ReassignTransactions(ctx context.Context, fromID, toID string) ([]domain.Transaction, error)
+	// Trash: Delete only marks a category deleted, these work with the marked ones
+	ListDeleted(ctx context.Context, tenantID string) ([]domain.Category, error)
+	Restore(ctx context.Context, tenantID, id string) (domain.Category, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
 
-type Service struct{ repo Repo }
+type Service struct {
+	repo     Repo
+	audit    *audit.Log
+	webhooks *webhook.Outbox
+}
 
 func NewService(repo Repo) *Service { return &Service{repo: repo} }
 
+// SetAuditLog records every change of a category in the tenant audit log.
+func (s *Service) SetAuditLog(l *audit.Log) { s.audit = l }
+
+// SetWebhooks queues transaction.updated events for transactions moved by Delete.
+func (s *Service) SetWebhooks(o *webhook.Outbox) { s.webhooks = o }
+
 func (s *Service) Create(ctx context.Context, tenantID string, kind domain.CategoryKind, code string, parentID *string, isActive bool, translations []domain.CategoryTranslation) (domain.Category, error) {
-	return s.repo.Create(ctx, tenantID, kind, code, parentID, isActive, translations)
+	var created domain.Category
+	err := s.audit.Run(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.repo.Create(ctx, tenantID, kind, code, parentID, isActive, translations); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tenantID, domain.AuditEntityCategory, created.ID, domain.AuditActionCreated, nil, created)
+	})
+	if err != nil {
+		return domain.Category{}, err
+	}
+	return created, nil
 }
 
 func (s *Service) Update(ctx context.Context, id string, code string, parentID *string, isActive bool, translations []domain.CategoryTranslation) (domain.Category, error) {
-	return s.repo.Update(ctx, id, code, parentID, isActive, translations)
+	var updated domain.Category
+	err := s.audit.Run(ctx, func(ctx context.Context) error {
+		before, err := s.auditSnapshot(ctx, id)
+		if err != nil {
+			return err
+		}
+		if updated, err = s.repo.Update(ctx, id, code, parentID, isActive, translations); err != nil {
+			return err
+		}
+		if before == nil {
+			return nil
+		}
+		return s.audit.Record(ctx, before.TenantID, domain.AuditEntityCategory, id, domain.AuditActionUpdated, *before, updated)
+	})
+	if err != nil {
+		return domain.Category{}, err
+	}
+	return updated, nil
+}
+
+// Delete moves a category to the trash. A category with transactions is deleted only when
+// reassignTo names a category of the same tenant and kind: the transactions are moved there first.
+func (s *Service) Delete(ctx context.Context, id string, reassignTo string) error {
+	return s.audit.Run(ctx, func(ctx context.Context) error {
+		before, err := s.repo.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if reassignTo != "" {
+			target, err := s.repo.Get(ctx, reassignTo)
+			if err != nil || target.ID == id || target.TenantID != before.TenantID || target.Kind != before.Kind {
+				return ErrInvalidReassignTarget
+			}
+			moved, err := s.repo.ReassignTransactions(ctx, id, target.ID)
+			if err != nil {
+				return err
+			}
+			// every moved transaction changes like with an update, in the same DB transaction
+			for _, tx := range moved {
+				prev := tx
+				prev.CategoryID = id
+				if err := s.audit.Record(ctx, tx.TenantID, domain.AuditEntityTransaction, tx.ID, domain.AuditActionUpdated, prev, tx); err != nil {
+					return err
+				}
+				if err := s.webhooks.PublishTransaction(ctx, domain.WebhookEventTransactionUpdated, tx); err != nil {
+					return err
+				}
+			}
+		} else {
+			n, err := s.repo.CountTransactions(ctx, id)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				return ErrCategoryInUse
+			}
+		}
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, before.TenantID, domain.AuditEntityCategory, id, domain.AuditActionDeleted, before, nil)
+	})
 }
 
-func (s *Service) Delete(ctx context.Context, id string) error { return s.repo.Delete(ctx, id) }
 func (s *Service) Get(ctx context.Context, id string) (domain.Category, error) {
 	return s.repo.Get(ctx, id)
 }
@@ -34,3 +133,15 @@ func (s *Service) Get(ctx context.Context, id string) (domain.Category, error) {
 func (s *Service) List(ctx context.Context, tenantID string, kind domain.CategoryKind, includeInactive bool) ([]domain.Category, error) {
 	return s.repo.List(ctx, tenantID, kind, includeInactive)
 }
+
+// auditSnapshot loads the state before a change; without an audit log nothing is loaded.
+func (s *Service) auditSnapshot(ctx context.Context, id string) (*domain.Category, error) {
+	if s.audit == nil {
+		return nil, nil
+	}
+	c, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
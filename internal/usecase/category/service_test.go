@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/positron48/budget/internal/domain"
 )
@@ -36,6 +37,18 @@ func (s *stubRepo) List(ctx context.Context, tenantID string, kind domain.Catego
 	return s.list, nil
 }
 
+func (s *stubRepo) CountTransactions(ctx context.Context, id string) (int64, error) { return 0, nil }
+func (s *stubRepo) ReassignTransactions(ctx context.Context, fromID, toID string) ([]domain.Transaction, error) {
+	return nil, nil
+}
+func (s *stubRepo) ListDeleted(ctx context.Context, tenantID string) ([]domain.Category, error) {
+	return nil, nil
+}
+func (s *stubRepo) Restore(ctx context.Context, tenantID, id string) (domain.Category, error) {
+	return domain.Category{ID: id, TenantID: tenantID}, nil
+}
+func (s *stubRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) { return 0, nil }
+
 func TestService_CRUD(t *testing.T) {
 	r := &stubRepo{}
 	svc := NewService(r)
@@ -55,7 +68,7 @@ func TestService_CRUD(t *testing.T) {
 		t.Fatalf("update: %v %#v", err, up)
 	}
 
-	if err := svc.Delete(ctx, "c1"); err != nil || r.delID != "c1" {
+	if err := svc.Delete(ctx, "c1", ""); err != nil || r.delID != "c1" {
 		t.Fatalf("delete: %v", err)
 	}
 
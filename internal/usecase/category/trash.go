@@ -0,0 +1,35 @@
+package category
+
+import (
+	"context"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// ListDeleted returns the tenant's categories in the trash.
+func (s *Service) ListDeleted(ctx context.Context, tenantID string) ([]domain.Category, error) {
+	return s.repo.ListDeleted(ctx, tenantID)
+}
+
+// Restore takes a category out of the trash.
+func (s *Service) Restore(ctx context.Context, tenantID, id string) (domain.Category, error) {
+	var restored domain.Category
+	err := s.audit.Run(ctx, func(ctx context.Context) error {
+		var err error
+		if restored, err = s.repo.Restore(ctx, tenantID, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tenantID, domain.AuditEntityCategory, id, domain.AuditActionRestored, nil, restored)
+	})
+	if err != nil {
+		return domain.Category{}, err
+	}
+	return restored, nil
+}
+
+// PurgeDeleted permanently removes categories that were deleted before the cutoff and are no
+// longer referenced by transactions; purge transactions first.
+func (s *Service) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.PurgeDeleted(ctx, before)
+}
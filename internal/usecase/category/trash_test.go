@@ -0,0 +1,189 @@
+package category
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
+	"github.com/positron48/budget/internal/usecase/webhook"
+)
+
+// trashRepo keeps categories and per-category transaction counts in memory
+type trashRepo struct {
+	stubRepo
+	cats     map[string]domain.Category
+	txCount  map[string]int64
+	deleted  map[string]bool
+	reassign [2]string
+}
+
+func newTrashRepo() *trashRepo {
+	return &trashRepo{
+		cats: map[string]domain.Category{
+			"food":   {ID: "food", TenantID: "t1", Kind: domain.CategoryKindExpense, Code: "food"},
+			"cafe":   {ID: "cafe", TenantID: "t1", Kind: domain.CategoryKindExpense, Code: "cafe"},
+			"salary": {ID: "salary", TenantID: "t1", Kind: domain.CategoryKindIncome, Code: "salary"},
+			"other":  {ID: "other", TenantID: "t2", Kind: domain.CategoryKindExpense, Code: "other"},
+		},
+		txCount: map[string]int64{"food": 3},
+		deleted: map[string]bool{},
+	}
+}
+
+func (r *trashRepo) Get(ctx context.Context, id string) (domain.Category, error) {
+	c, ok := r.cats[id]
+	if !ok || r.deleted[id] {
+		return domain.Category{}, errors.New("not found")
+	}
+	return c, nil
+}
+
+func (r *trashRepo) Delete(ctx context.Context, id string) error {
+	r.deleted[id] = true
+	return nil
+}
+
+func (r *trashRepo) CountTransactions(ctx context.Context, id string) (int64, error) {
+	return r.txCount[id], nil
+}
+
+func (r *trashRepo) ReassignTransactions(ctx context.Context, fromID, toID string) ([]domain.Transaction, error) {
+	n := r.txCount[fromID]
+	r.txCount[toID] += n
+	r.txCount[fromID] = 0
+	r.reassign = [2]string{fromID, toID}
+	moved := make([]domain.Transaction, n)
+	for i := range moved {
+		moved[i] = domain.Transaction{ID: fmt.Sprintf("tx%d", i), TenantID: r.cats[toID].TenantID, CategoryID: toID}
+	}
+	return moved, nil
+}
+
+func (r *trashRepo) Restore(ctx context.Context, tenantID, id string) (domain.Category, error) {
+	c, ok := r.cats[id]
+	if !ok || !r.deleted[id] || c.TenantID != tenantID {
+		return domain.Category{}, errors.New("not found")
+	}
+	delete(r.deleted, id)
+	return c, nil
+}
+
+type auditRepoMem struct{ events []domain.AuditEvent }
+
+func (m *auditRepoMem) Record(ctx context.Context, ev domain.AuditEvent) error {
+	m.events = append(m.events, ev)
+	return nil
+}
+
+func (m *auditRepoMem) List(ctx context.Context, tenantID string, f audit.Filter) ([]domain.AuditEvent, int64, error) {
+	return m.events, int64(len(m.events)), nil
+}
+
+type txRunnerStub struct{}
+
+func (txRunnerStub) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestService_Delete_InUseRequiresReassign(t *testing.T) {
+	r := newTrashRepo()
+	svc := NewService(r)
+	ctx := context.Background()
+
+	if err := svc.Delete(ctx, "food", ""); !errors.Is(err, ErrCategoryInUse) {
+		t.Fatalf("expected ErrCategoryInUse, got %v", err)
+	}
+	if r.deleted["food"] {
+		t.Fatal("category with transactions must not be deleted")
+	}
+	if err := svc.Delete(ctx, "cafe", ""); err != nil || !r.deleted["cafe"] {
+		t.Fatalf("unused category: %v", err)
+	}
+}
+
+func TestService_Delete_Reassign(t *testing.T) {
+	r := newTrashRepo()
+	svc := NewService(r)
+	ctx := context.Background()
+
+	for _, target := range []string{"food", "salary", "other", "missing"} {
+		if err := svc.Delete(ctx, "food", target); !errors.Is(err, ErrInvalidReassignTarget) {
+			t.Fatalf("target %q: expected ErrInvalidReassignTarget, got %v", target, err)
+		}
+	}
+	if err := svc.Delete(ctx, "food", "cafe"); err != nil {
+		t.Fatalf("delete with reassign: %v", err)
+	}
+	if r.reassign != [2]string{"food", "cafe"} || r.txCount["cafe"] != 3 || !r.deleted["food"] {
+		t.Fatalf("transactions not moved: %+v %v", r.reassign, r.txCount)
+	}
+}
+
+type outboxRepoMem struct{ events []webhook.Event }
+
+func (m *outboxRepoMem) Enqueue(ctx context.Context, tenantID, eventID, eventType string, payload []byte) (int, error) {
+	var ev webhook.Event
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return 0, err
+	}
+	m.events = append(m.events, ev)
+	return 1, nil
+}
+
+func TestService_Delete_ReassignRecordsTransactionChanges(t *testing.T) {
+	r := newTrashRepo()
+	events, outbox := &auditRepoMem{}, &outboxRepoMem{}
+	svc := NewService(r)
+	svc.SetAuditLog(audit.NewLog(events, txRunnerStub{}))
+	svc.SetWebhooks(webhook.NewOutbox(outbox))
+
+	if err := svc.Delete(context.Background(), "food", "cafe"); err != nil {
+		t.Fatalf("delete with reassign: %v", err)
+	}
+	updated := 0
+	for _, ev := range events.events {
+		if ev.EntityType != domain.AuditEntityTransaction {
+			continue
+		}
+		var before, after domain.Transaction
+		_ = json.Unmarshal(ev.Before, &before)
+		_ = json.Unmarshal(ev.After, &after)
+		if ev.Action != domain.AuditActionUpdated || before.CategoryID != "food" || after.CategoryID != "cafe" {
+			t.Fatalf("unexpected audit event: %+v", ev)
+		}
+		updated++
+	}
+	if updated != 3 || len(outbox.events) != 3 || outbox.events[0].Type != domain.WebhookEventTransactionUpdated {
+		t.Fatalf("expected an update per moved transaction, got %d audit events, webhooks %+v", updated, outbox.events)
+	}
+}
+
+func TestService_Restore_RecordsAudit(t *testing.T) {
+	r := newTrashRepo()
+	events := &auditRepoMem{}
+	svc := NewService(r)
+	svc.SetAuditLog(audit.NewLog(events, txRunnerStub{}))
+	ctx := context.Background()
+
+	if err := svc.Delete(ctx, "cafe", ""); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := svc.Restore(ctx, "t2", "cafe"); err == nil {
+		t.Fatal("restore from another tenant must fail")
+	}
+	c, err := svc.Restore(ctx, "t1", "cafe")
+	if err != nil || c.ID != "cafe" || r.deleted["cafe"] {
+		t.Fatalf("restore: %v %#v", err, c)
+	}
+	if len(events.events) != 2 || events.events[0].Action != domain.AuditActionDeleted || events.events[1].Action != domain.AuditActionRestored {
+		t.Fatalf("unexpected audit events: %+v", events.events)
+	}
+	if _, err := svc.PurgeDeleted(ctx, time.Now()); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// subscriberBuffer is how many events a watcher may lag behind before it is dropped
+const subscriberBuffer = 64
+
+// Broker fans tenant events out to the watchers of the tenant in this process.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[string]map[*subscription]struct{} // by tenant id
+}
+
+type subscription struct {
+	ch     chan domain.TenantEvent
+	closed bool
+}
+
+func NewBroker() *Broker { return &Broker{subs: map[string]map[*subscription]struct{}{}} }
+
+// Subscribe returns the events of the tenant until cancel is called. The channel is closed by
+// cancel, or earlier if the subscriber falls behind by more than subscriberBuffer events:
+// it then has missed changes and should reload its data before subscribing again.
+func (b *Broker) Subscribe(tenantID string) (events <-chan domain.TenantEvent, cancel func()) {
+	s := &subscription{ch: make(chan domain.TenantEvent, subscriberBuffer)}
+	b.mu.Lock()
+	if b.subs[tenantID] == nil {
+		b.subs[tenantID] = map[*subscription]struct{}{}
+	}
+	b.subs[tenantID][s] = struct{}{}
+	b.mu.Unlock()
+	return s.ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.drop(tenantID, s)
+	}
+}
+
+// Publish delivers ev to the watchers of its tenant without blocking.
+func (b *Broker) Publish(ev domain.TenantEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs[ev.TenantID] {
+		select {
+		case s.ch <- ev:
+		default:
+			b.drop(ev.TenantID, s)
+		}
+	}
+}
+
+// DropAll closes every subscription, as if all watchers fell behind. It is used when events
+// may have been lost, e.g. after the listener reconnects, and on shutdown.
+func (b *Broker) DropAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for tenantID, subs := range b.subs {
+		for s := range subs {
+			b.drop(tenantID, s)
+		}
+	}
+}
+
+// Subscribers returns the number of watchers of the tenant.
+func (b *Broker) Subscribers(tenantID string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs[tenantID])
+}
+
+// drop must be called with mu held
+func (b *Broker) drop(tenantID string, s *subscription) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.ch)
+	delete(b.subs[tenantID], s)
+	if len(b.subs[tenantID]) == 0 {
+		delete(b.subs, tenantID)
+	}
+}
@@ -0,0 +1,80 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+func TestBroker_FanOutByTenant(t *testing.T) {
+	b := NewBroker()
+	a1, cancelA1 := b.Subscribe("t1")
+	a2, cancelA2 := b.Subscribe("t1")
+	other, cancelOther := b.Subscribe("t2")
+	defer cancelA2()
+	defer cancelOther()
+
+	b.Publish(domain.TenantEvent{TenantID: "t1", EntityID: "x1"})
+	for _, ch := range []<-chan domain.TenantEvent{a1, a2} {
+		if ev := <-ch; ev.EntityID != "x1" {
+			t.Fatalf("unexpected event %+v", ev)
+		}
+	}
+	select {
+	case ev := <-other:
+		t.Fatalf("event leaked to another tenant: %+v", ev)
+	default:
+	}
+
+	cancelA1()
+	cancelA1() // idempotent
+	if _, ok := <-a1; ok {
+		t.Fatalf("channel must be closed after cancel")
+	}
+	if n := b.Subscribers("t1"); n != 1 {
+		t.Fatalf("subscribers = %d", n)
+	}
+}
+
+func TestBroker_DropsSlowSubscriber(t *testing.T) {
+	b := NewBroker()
+	ch, cancel := b.Subscribe("t1")
+	defer cancel()
+	for i := 0; i <= subscriberBuffer; i++ {
+		b.Publish(domain.TenantEvent{TenantID: "t1"})
+	}
+	n := 0
+	for range ch {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Fatalf("got %d buffered events before close, want %d", n, subscriberBuffer)
+	}
+	if b.Subscribers("t1") != 0 {
+		t.Fatalf("slow subscriber must be removed")
+	}
+}
+
+func TestBroker_DropAll(t *testing.T) {
+	b := NewBroker()
+	a, cancelA := b.Subscribe("t1")
+	c, cancelC := b.Subscribe("t2")
+	defer cancelA()
+	defer cancelC()
+	b.DropAll()
+	for _, ch := range []<-chan domain.TenantEvent{a, c} {
+		if _, ok := <-ch; ok {
+			t.Fatal("every subscription must be closed")
+		}
+	}
+	if b.Subscribers("t1")+b.Subscribers("t2") != 0 {
+		t.Fatal("subscriptions must be removed")
+	}
+	// subscribing again works
+	ch, cancel := b.Subscribe("t1")
+	defer cancel()
+	b.Publish(domain.TenantEvent{TenantID: "t1", EntityID: "x1"})
+	if ev := <-ch; ev.EntityID != "x1" {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+}
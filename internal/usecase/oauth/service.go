@@ -64,6 +64,11 @@ type TokenIssuer interface {
 	Issue(ctx context.Context, userID, tenantID string, accessTTL, refreshTTL time.Duration) (useauth.TokenPair, error)
 }
 
+// TokenRevoker инвалидирует access токены пользователя, выпущенные до указанного времени
+type TokenRevoker interface {
+	RevokeUserTokens(ctx context.Context, userID string, before time.Time) error
+}
+
 // Ошибки
 var (
 	ErrAccountBlocked          = errors.New("account is blocked")
@@ -75,6 +80,7 @@ var (
 	ErrSessionExpired          = errors.New("session expired")
 	ErrInvalidEmail            = errors.New("invalid email format")
 	ErrUserNotFound            = errors.New("user not found")
+	ErrEmailNotVerified        = errors.New("email is not verified")
 )
 
 // Service OAuth2 сервис
@@ -86,6 +92,7 @@ type Service struct {
 	config      domain.OAuthConfig
 	accessTTL   time.Duration
 	refreshTTL  time.Duration
+	revoker     TokenRevoker
 }
 
 func NewService(repo OAuthRepo, cache OAuthCache, authService AuthService, issuer TokenIssuer, config domain.OAuthConfig, accessTTL, refreshTTL time.Duration) *Service {
@@ -100,6 +107,9 @@ func NewService(repo OAuthRepo, cache OAuthCache, authService AuthService, issue
 	}
 }
 
+// SetTokenRevoker включает инвалидацию выданных access токенов при отзыве сессии
+func (s *Service) SetTokenRevoker(r TokenRevoker) { s.revoker = r }
+
 // GenerateAuthLink генерирует ссылку для авторизации
 func (s *Service) GenerateAuthLink(ctx context.Context, email, telegramUserID, userAgent, ipAddress string) (string, string, time.Time, error) {
 	// Валидация email
@@ -124,11 +134,15 @@ func (s *Service) GenerateAuthLink(ctx context.Context, email, telegramUserID, u
 	}
 
 	// Проверка существования пользователя
-	_, _, err = s.repo.GetUserByEmail(ctx, email)
+	user, _, err := s.repo.GetUserByEmail(ctx, email)
 	if err != nil {
 		s.logAuthAction(ctx, email, telegramUserID, ipAddress, userAgent, domain.ActionGenerateLink, domain.LogStatusFailed, "user not found", nil, nil)
 		return "", "", time.Time{}, ErrUserNotFound
 	}
+	if s.config.RequireVerifiedEmail && !user.EmailVerified {
+		s.logAuthAction(ctx, email, telegramUserID, ipAddress, userAgent, domain.ActionGenerateLink, domain.LogStatusFailed, "email not verified", nil, nil)
+		return "", "", time.Time{}, ErrEmailNotVerified
+	}
 
 	// Генерация токена и кода
 	authToken := s.generateSecureToken()
@@ -393,6 +407,12 @@ func (s *Service) RevokeTelegramSession(ctx context.Context, sessionID, telegram
 	}
 
 	_ = s.revokeSession(ctx, sessionID)
+	// access токены сессии еще действуют до истечения – отзываем их
+	if s.revoker != nil {
+		if err := s.revoker.RevokeUserTokens(ctx, session.UserID, time.Now()); err != nil {
+			return fmt.Errorf("failed to revoke access tokens: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -2,6 +2,7 @@ package oauth
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ type mockOAuthRepo struct {
 	logs          []domain.AuthLogEntry
 	rateLimits    map[string]int
 	accountBlocks map[string]*domain.AccountBlock
+	unverified    bool
 }
 
 func newMockOAuthRepo() *mockOAuthRepo {
@@ -106,9 +108,10 @@ func (m *mockOAuthRepo) CreateAuthLog(ctx context.Context, log domain.AuthLogEnt
 func (m *mockOAuthRepo) GetUserByEmail(ctx context.Context, email string) (useauth.User, []useauth.TenantMembership, error) {
 	// Возвращаем тестового пользователя
 	user := useauth.User{
-		ID:    uuid.New().String(),
-		Email: email,
-		Name:  "Test User",
+		ID:            uuid.New().String(),
+		Email:         email,
+		Name:          "Test User",
+		EmailVerified: !m.unverified,
 	}
 
 	memberships := []useauth.TenantMembership{
@@ -527,3 +530,54 @@ func TestInvalidEmail(t *testing.T) {
 		t.Errorf("Expected invalid email error, got %v", err)
 	}
 }
+
+func TestGenerateAuthLink_RequireVerifiedEmail(t *testing.T) {
+	repo := newMockOAuthRepo()
+	repo.unverified = true
+	config := domain.OAuthConfig{
+		AuthTokenTTL:         5 * time.Minute,
+		MaxAttemptsPerHour:   10,
+		MaxAttemptsPer10Min:  3,
+		WebBaseURL:           "http://localhost:3000",
+		RequireVerifiedEmail: true,
+	}
+	service := NewService(repo, newMockOAuthCache(), &mockAuthService{}, &mockTokenIssuer{}, config, 15*time.Minute, 720*time.Hour)
+
+	_, _, _, err := service.GenerateAuthLink(context.Background(), "test@example.com", "123456", "TestBot/1.0", "127.0.0.1")
+	if !errors.Is(err, ErrEmailNotVerified) {
+		t.Fatalf("expected ErrEmailNotVerified, got %v", err)
+	}
+
+	repo.unverified = false
+	if _, _, _, err := service.GenerateAuthLink(context.Background(), "test@example.com", "123456", "TestBot/1.0", "127.0.0.1"); err != nil {
+		t.Fatalf("expected link for verified email, got %v", err)
+	}
+}
+
+type mockTokenRevoker struct{ users []string }
+
+func (m *mockTokenRevoker) RevokeUserTokens(ctx context.Context, userID string, before time.Time) error {
+	m.users = append(m.users, userID)
+	return nil
+}
+
+func TestRevokeTelegramSession_RevokesAccessTokens(t *testing.T) {
+	repo := newMockOAuthRepo()
+	repo.sessions["s1"] = domain.TelegramSession{SessionID: "s1", UserID: "u1", TelegramUserID: "tg1", IsActive: true}
+	service := NewService(repo, newMockOAuthCache(), &mockAuthService{}, &mockTokenIssuer{}, domain.OAuthConfig{}, 15*time.Minute, 720*time.Hour)
+	revoker := &mockTokenRevoker{}
+	service.SetTokenRevoker(revoker)
+
+	if err := service.RevokeTelegramSession(context.Background(), "s1", "other"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected session not found for another telegram user, got %v", err)
+	}
+	if err := service.RevokeTelegramSession(context.Background(), "s1", "tg1"); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if repo.sessions["s1"].IsActive {
+		t.Fatal("session must be inactive")
+	}
+	if len(revoker.users) != 1 || revoker.users[0] != "u1" {
+		t.Fatalf("expected access tokens of u1 revoked, got %v", revoker.users)
+	}
+}
@@ -72,7 +72,9 @@ type DateRange struct {
 	LatestDate   string // YYYY-MM-DD format
 }
 
-func (s *Service) GetMonthlySummary(ctx context.Context, tenantID string, year int, month int, locale string, targetCurrencyCode string, tzOffsetMinutes int, excludeExtraordinary bool) (MonthlySummary, error) {
+// GetMonthlySummary aggregates a calendar month by category. base narrows the transactions
+// (e.g. a saved view); its date range and paging are replaced by the report's.
+func (s *Service) GetMonthlySummary(ctx context.Context, tenantID string, year int, month int, locale string, targetCurrencyCode string, tzOffsetMinutes int, base txusecase.ListFilter) (MonthlySummary, error) {
 	// compute month range using client timezone offset to avoid crossing day boundaries
 	// tzOffsetMinutes comes from JS getTimezoneOffset(), e.g. Moscow is -180
 	fromLocal := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.FixedZone("client", -tzOffsetMinutes*60))
@@ -96,7 +98,8 @@ func (s *Service) GetMonthlySummary(ctx context.Context, tenantID string, year i
 	page := 1
 	pageSize := 500
 	for {
-		f := txusecase.ListFilter{From: &from, To: &to, ExcludeExtraordinary: excludeExtraordinary, Page: page, PageSize: pageSize}
+		f := base
+		f.From, f.To, f.Page, f.PageSize = &from, &to, page, pageSize
 		items, total, err := s.txsvc.List(ctx, tenantID, f)
 		if err != nil {
 			return MonthlySummary{}, err
@@ -128,7 +131,7 @@ func (s *Service) GetMonthlySummary(ctx context.Context, tenantID string, year i
 			if err != nil {
 				return MonthlySummary{}, err
 			}
-			minor, err = convertMinorByRate(baseMinor, rateDec)
+			minor, err = convertMinorByRate(baseMinor, baseCurrency, target, rateDec)
 			if err != nil {
 				return MonthlySummary{}, err
 			}
@@ -176,7 +179,8 @@ func (s *Service) GetMonthlySummary(ctx context.Context, tenantID string, year i
 	}, nil
 }
 
-func (s *Service) GetSummaryReport(ctx context.Context, tenantID string, fromDate, toDate, locale, targetCurrencyCode string, tzOffsetMinutes int, excludeExtraordinary bool) (SummaryReport, error) {
+// GetSummaryReport aggregates [fromDate, toDate] by category and month; base as in GetMonthlySummary.
+func (s *Service) GetSummaryReport(ctx context.Context, tenantID string, fromDate, toDate, locale, targetCurrencyCode string, tzOffsetMinutes int, base txusecase.ListFilter) (SummaryReport, error) {
 	// Parse date range
 	fromLocal, err := time.Parse("2006-01-02", fromDate)
 	if err != nil {
@@ -216,7 +220,8 @@ func (s *Service) GetSummaryReport(ctx context.Context, tenantID string, fromDat
 	page := 1
 	pageSize := 500
 	for {
-		f := txusecase.ListFilter{From: &from, To: &to, ExcludeExtraordinary: excludeExtraordinary, Page: page, PageSize: pageSize}
+		f := base
+		f.From, f.To, f.Page, f.PageSize = &from, &to, page, pageSize
 		items, total, err := s.txsvc.List(ctx, tenantID, f)
 		if err != nil {
 			return SummaryReport{}, err
@@ -249,7 +254,7 @@ func (s *Service) GetSummaryReport(ctx context.Context, tenantID string, fromDat
 			if err != nil {
 				return SummaryReport{}, err
 			}
-			minor, err = convertMinorByRate(baseMinor, rateDec)
+			minor, err = convertMinorByRate(baseMinor, baseCurrency, target, rateDec)
 			if err != nil {
 				return SummaryReport{}, err
 			}
@@ -395,27 +400,15 @@ func (s *Service) GetDateRange(ctx context.Context, tenantID string, locale stri
 	}, nil
 }
 
-// convertMinorByRate multiplies integer minor units (2 decimals) by decimal rate and rounds to nearest minor unit
-func convertMinorByRate(minor int64, rateDecimal string) (int64, error) {
-	// amount as rational (minor / 100)
-	num := big.NewInt(minor)
-	den := big.NewInt(100)
-	amount := new(big.Rat).SetFrac(num, den)
-	// rate as rational
+// convertMinorByRate converts minor units of one currency into minor units of another by a decimal
+// rate, honouring both currencies' exponents and rounding half away from zero
+func convertMinorByRate(minor int64, fromCurrency, toCurrency string, rateDecimal string) (int64, error) {
 	r := new(big.Rat)
 	if _, ok := r.SetString(rateDecimal); !ok {
 		return 0, ErrParseRate
 	}
-	prod := new(big.Rat).Mul(amount, r) // value in target currency
-	// to minor units: prod * 100, rounded
-	prodMul := new(big.Rat).Mul(prod, big.NewRat(100, 1))
-	p := new(big.Int).Set(prodMul.Num())
-	q := new(big.Int).Set(prodMul.Denom())
-	// round to nearest: (p + q/2) / q
-	halfQ := new(big.Int).Rsh(q, 1) // q/2 floor
-	p.Add(p, halfQ)
-	res := new(big.Int).Quo(p, q)
-	return res.Int64(), nil
+	amount := domain.MinorToRat(minor, fromCurrency)
+	return domain.RatToMinor(new(big.Rat).Mul(amount, r), toCurrency)
 }
 
 var ErrParseRate = domainError("invalid fx rate")
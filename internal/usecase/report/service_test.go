@@ -52,7 +52,7 @@ func TestReport_MonthlySummary(t *testing.T) {
 		{CategoryID: "books", Type: domain.TransactionTypeExpense, BaseAmount: domain.Money{CurrencyCode: "RUB", MinorUnits: 5000}, OccurredAt: time.Now()},
 	}
 	rep := Service{fx: fxRepoStub{}, tenants: tRepoStub{base: "RUB"}, cats: cRepoStub{}, txsvc: stubTxService{items: items}}
-	sum, err := rep.GetMonthlySummary(context.Background(), "t1", 2025, 2, "en", "", 0, false)
+	sum, err := rep.GetMonthlySummary(context.Background(), "t1", 2025, 2, "en", "", 0, txuse.ListFilter{})
 	if err != nil {
 		t.Fatalf("summary: %v", err)
 	}
@@ -65,7 +65,7 @@ func TestReport_TargetDiffersFromBase(t *testing.T) {
 	// one expense in EUR base, target USD with fx 2.0
 	items := []domain.Transaction{{CategoryID: "rent", Type: domain.TransactionTypeExpense, BaseAmount: domain.Money{CurrencyCode: "EUR", MinorUnits: 10000}, OccurredAt: time.Now()}}
 	rep := Service{fx: fxRepoStub{}, tenants: tRepoStub{base: "EUR"}, cats: cRepoStub{}, txsvc: stubTxService{items: items}}
-	sum, err := rep.GetMonthlySummary(context.Background(), "t1", 2025, 2, "en", "USD", 0, false)
+	sum, err := rep.GetMonthlySummary(context.Background(), "t1", 2025, 2, "en", "USD", 0, txuse.ListFilter{})
 	if err != nil {
 		t.Fatalf("summary: %v", err)
 	}
@@ -73,3 +73,29 @@ func TestReport_TargetDiffersFromBase(t *testing.T) {
 		t.Fatalf("conversion failed: %+v", sum)
 	}
 }
+
+func TestConvertMinorByRate_Exponents(t *testing.T) {
+	cases := []struct {
+		minor    int64
+		from, to string
+		rate     string
+		want     int64
+	}{
+		{10000, "EUR", "USD", "2", 20000},     // 100.00 EUR → 200.00 USD
+		{10000, "USD", "JPY", "150.5", 15050}, // 100.00 USD → 15050 JPY
+		{1500, "JPY", "USD", "0.0067", 1005},  // 1500 JPY → 10.05 USD
+		{1250, "KWD", "USD", "3.25", 406},     // 1.250 KWD → 4.0625 → 4.06 USD
+		{-1250, "KWD", "USD", "3.25", -406},   // rounding is symmetric for negatives
+		{1, "USD", "BTC", "0.00001", 10},      // 0.01 USD → 0.0000001 BTC
+		{333, "USD", "KWD", "0.30001", 999},   // 3.33 USD → 0.9990333 → 0.999 KWD
+	}
+	for _, c := range cases {
+		got, err := convertMinorByRate(c.minor, c.from, c.to, c.rate)
+		if err != nil || got != c.want {
+			t.Fatalf("%d %s→%s @%s: got %d (%v), want %d", c.minor, c.from, c.to, c.rate, got, err, c.want)
+		}
+	}
+	if _, err := convertMinorByRate(1, "USD", "EUR", "x"); err != ErrParseRate {
+		t.Fatalf("expected ErrParseRate, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
+)
+
+type auditRepoMem struct{ events []domain.AuditEvent }
+
+func (m *auditRepoMem) Record(ctx context.Context, ev domain.AuditEvent) error {
+	m.events = append(m.events, ev)
+	return nil
+}
+
+func (m *auditRepoMem) List(ctx context.Context, tenantID string, f audit.Filter) ([]domain.AuditEvent, int64, error) {
+	return m.events, int64(len(m.events)), nil
+}
+
+type txRunnerStub struct{}
+
+func (txRunnerStub) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// auditRoleRepo returns the configured role per user.
+type auditRoleRepo struct {
+	stubRepo
+	roles map[string]domain.TenantRole
+}
+
+func (r auditRoleRepo) GetUserRole(ctx context.Context, tenantID, userID string) (domain.TenantRole, error) {
+	return r.roles[userID], nil
+}
+
+func TestAudit_MembershipChangesAndAccess(t *testing.T) {
+	repo := auditRoleRepo{roles: map[string]domain.TenantRole{"owner": domain.TenantRoleOwner, "bob": domain.TenantRoleMember}}
+	svc := NewService(repo)
+	ctx := context.Background()
+	if _, _, err := svc.ListAuditEvents(ctx, "owner", "t1", audit.Filter{}); !errors.Is(err, ErrAuditDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+	events := &auditRepoMem{}
+	svc.SetAuditLog(audit.NewLog(events, txRunnerStub{}))
+
+	if _, err := svc.UpdateMemberRole(ctx, "owner", "t1", "bob", domain.TenantRoleAdmin); err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	if err := svc.RemoveMember(ctx, "owner", "t1", "bob"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if len(events.events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events.events)
+	}
+	upd := events.events[0]
+	if upd.EntityType != domain.AuditEntityMembership || upd.EntityID != "bob" || upd.Action != domain.AuditActionUpdated ||
+		string(upd.Before) != `{"user_id":"bob","role":"member"}` || string(upd.After) != `{"user_id":"bob","role":"admin"}` {
+		t.Fatalf("unexpected role change event: %+v (%s -> %s)", upd, upd.Before, upd.After)
+	}
+	if del := events.events[1]; del.Action != domain.AuditActionDeleted || del.After != nil {
+		t.Fatalf("unexpected removal event: %+v", del)
+	}
+
+	if _, _, err := svc.ListAuditEvents(ctx, "bob", "t1", audit.Filter{}); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("members must not read the audit log, got %v", err)
+	}
+	list, total, err := svc.ListAuditEvents(ctx, "owner", "t1", audit.Filter{})
+	if err != nil || total != 2 || len(list) != 2 {
+		t.Fatalf("list: %v %d", err, total)
+	}
+}
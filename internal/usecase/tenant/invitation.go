@@ -0,0 +1,264 @@
+package tenant
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+var (
+	ErrInvitationsDisabled     = errors.New("invitations are not configured")
+	ErrInvitationNotFound      = errors.New("invitation not found")
+	ErrInvitationExpired       = errors.New("invitation expired")
+	ErrInvitationNotPending    = errors.New("invitation is not pending")
+	ErrInvitationEmailMismatch = errors.New("invitation was sent to another email")
+	ErrEmailNotVerified        = errors.New("email is not verified")
+)
+
+// DefaultInvitationTTL is used when no TTL is configured.
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
+type InvitationRepo interface {
+	// CreateInvitation stores a pending invitation; previous pending invitations
+	// for the same tenant and email are superseded. Only the token hash is persisted.
+	CreateInvitation(ctx context.Context, inv domain.TenantInvitation, token string) (domain.TenantInvitation, error)
+	GetInvitation(ctx context.Context, id string) (domain.TenantInvitation, error)
+	GetInvitationByToken(ctx context.Context, token string) (domain.TenantInvitation, error)
+	ListInvitations(ctx context.Context, tenantID string) ([]domain.TenantInvitation, error)
+	ListPendingInvitationsForEmail(ctx context.Context, email string) ([]domain.TenantInvitation, error)
+	// AcceptInvitation marks the invitation accepted and grants the membership in one transaction.
+	AcceptInvitation(ctx context.Context, invitationID, userID string) (domain.TenantMembership, error)
+	// SetInvitationStatus changes the status of a pending invitation and returns
+	// ErrInvitationNotPending when it is no longer pending.
+	SetInvitationStatus(ctx context.Context, invitationID string, status domain.InvitationStatus) error
+	IsMemberByEmail(ctx context.Context, tenantID, email string) (bool, error)
+	GetUserEmail(ctx context.Context, userID string) (email string, verified bool, err error)
+}
+
+// InvitationSender delivers the invitation (with the plain token) to the invitee.
+type InvitationSender interface {
+	SendInvitation(ctx context.Context, inv domain.TenantInvitation, token string) error
+}
+
+// SetInvitations enables the invitation flow. sender may be nil, then invitations
+// are created but not delivered.
+func (s *Service) SetInvitations(repo InvitationRepo, sender InvitationSender, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultInvitationTTL
+	}
+	s.invites = repo
+	s.sender = sender
+	s.inviteTTL = ttl
+}
+
+// SetRequireVerifiedEmail makes accepting invitations require a verified email address.
+func (s *Service) SetRequireVerifiedEmail(required bool) { s.requireVerified = required }
+
+// Permissions: create invitation - owner or admin; only owner can invite an owner
+func (s *Service) CreateInvitation(ctx context.Context, actingUserID, tenantID, email string, role domain.TenantRole) (domain.TenantInvitation, error) {
+	if s.invites == nil {
+		return domain.TenantInvitation{}, ErrInvitationsDisabled
+	}
+	ar, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	if ar != domain.TenantRoleOwner && ar != domain.TenantRoleAdmin {
+		return domain.TenantInvitation{}, ErrPermissionDenied
+	}
+	if role == "" {
+		role = domain.TenantRoleMember
+	}
+	if role == domain.TenantRoleOwner && ar != domain.TenantRoleOwner {
+		return domain.TenantInvitation{}, ErrPermissionDenied
+	}
+	email = normalizeEmail(email)
+	member, err := s.invites.IsMemberByEmail(ctx, tenantID, email)
+	if err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	if member {
+		return domain.TenantInvitation{}, ErrAlreadyMember
+	}
+	token, err := newInvitationToken()
+	if err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	inv, err := s.invites.CreateInvitation(ctx, domain.TenantInvitation{
+		Tenant:      domain.Tenant{ID: tenantID},
+		Email:       email,
+		Role:        role,
+		InvitedByID: actingUserID,
+		Status:      domain.InvitationStatusPending,
+		ExpiresAt:   time.Now().Add(s.inviteTTL),
+	}, token)
+	if err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	if s.sender != nil {
+		if err := s.sender.SendInvitation(ctx, inv, token); err != nil {
+			return domain.TenantInvitation{}, fmt.Errorf("send invitation: %w", err)
+		}
+	}
+	return inv, nil
+}
+
+// Permissions: list tenant invitations - owner or admin
+func (s *Service) ListInvitations(ctx context.Context, actingUserID, tenantID string) ([]domain.TenantInvitation, error) {
+	if s.invites == nil {
+		return nil, ErrInvitationsDisabled
+	}
+	ar, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if ar != domain.TenantRoleOwner && ar != domain.TenantRoleAdmin {
+		return nil, ErrPermissionDenied
+	}
+	return s.invites.ListInvitations(ctx, tenantID)
+}
+
+// ListMyInvitations returns pending invitations addressed to the acting user's email.
+func (s *Service) ListMyInvitations(ctx context.Context, actingUserID string) ([]domain.TenantInvitation, error) {
+	if s.invites == nil {
+		return nil, ErrInvitationsDisabled
+	}
+	email, _, err := s.invites.GetUserEmail(ctx, actingUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.invites.ListPendingInvitationsForEmail(ctx, normalizeEmail(email))
+}
+
+// AcceptInvitation accepts an invitation identified either by the emailed token or by its id.
+// The invitation must be addressed to the acting user's email.
+func (s *Service) AcceptInvitation(ctx context.Context, actingUserID, token, invitationID string) (domain.TenantMembership, error) {
+	inv, err := s.respondable(ctx, actingUserID, token, invitationID)
+	if err != nil {
+		return domain.TenantMembership{}, err
+	}
+	var m domain.TenantMembership
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		if m, err = s.invites.AcceptInvitation(ctx, inv.ID, actingUserID); err != nil {
+			return err
+		}
+		return s.recordMember(ctx, inv.Tenant.ID, actingUserID, domain.AuditActionCreated, "", m.Role)
+	})
+	if err != nil {
+		return domain.TenantMembership{}, err
+	}
+	return m, nil
+}
+
+// DeclineInvitation declines an invitation addressed to the acting user.
+func (s *Service) DeclineInvitation(ctx context.Context, actingUserID, token, invitationID string) error {
+	inv, err := s.respondable(ctx, actingUserID, token, invitationID)
+	if err != nil {
+		return err
+	}
+	return s.invites.SetInvitationStatus(ctx, inv.ID, domain.InvitationStatusDeclined)
+}
+
+// Permissions: revoke invitation - owner or admin
+func (s *Service) RevokeInvitation(ctx context.Context, actingUserID, tenantID, invitationID string) error {
+	if s.invites == nil {
+		return ErrInvitationsDisabled
+	}
+	ar, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return err
+	}
+	if ar != domain.TenantRoleOwner && ar != domain.TenantRoleAdmin {
+		return ErrPermissionDenied
+	}
+	inv, err := s.invites.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv.Tenant.ID != tenantID {
+		return ErrInvitationNotFound
+	}
+	if inv.Status != domain.InvitationStatusPending {
+		return ErrInvitationNotPending
+	}
+	return s.invites.SetInvitationStatus(ctx, inv.ID, domain.InvitationStatusRevoked)
+}
+
+// AttachPendingInvitations accepts all pending, unexpired invitations for a freshly
+// registered user and returns the memberships granted by them.
+func (s *Service) AttachPendingInvitations(ctx context.Context, userID, email string) ([]domain.TenantMembership, error) {
+	if s.invites == nil {
+		return nil, nil
+	}
+	invs, err := s.invites.ListPendingInvitationsForEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+	var out []domain.TenantMembership
+	for _, inv := range invs {
+		if !time.Now().Before(inv.ExpiresAt) {
+			continue
+		}
+		m, err := s.invites.AcceptInvitation(ctx, inv.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (s *Service) respondable(ctx context.Context, actingUserID, token, invitationID string) (domain.TenantInvitation, error) {
+	if s.invites == nil {
+		return domain.TenantInvitation{}, ErrInvitationsDisabled
+	}
+	var inv domain.TenantInvitation
+	var err error
+	if token != "" {
+		inv, err = s.invites.GetInvitationByToken(ctx, token)
+	} else {
+		inv, err = s.invites.GetInvitation(ctx, invitationID)
+	}
+	if err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	// the repository already reports overdue pending invitations as expired;
+	// persist that status so they drop out of pending lists
+	if inv.Status == domain.InvitationStatusExpired ||
+		(inv.Status == domain.InvitationStatusPending && !time.Now().Before(inv.ExpiresAt)) {
+		if err := s.invites.SetInvitationStatus(ctx, inv.ID, domain.InvitationStatusExpired); err != nil && !errors.Is(err, ErrInvitationNotPending) {
+			return domain.TenantInvitation{}, err
+		}
+		return domain.TenantInvitation{}, ErrInvitationExpired
+	}
+	if inv.Status != domain.InvitationStatusPending {
+		return domain.TenantInvitation{}, ErrInvitationNotPending
+	}
+	email, verified, err := s.invites.GetUserEmail(ctx, actingUserID)
+	if err != nil {
+		return domain.TenantInvitation{}, err
+	}
+	if normalizeEmail(email) != inv.Email {
+		return domain.TenantInvitation{}, ErrInvitationEmailMismatch
+	}
+	if s.requireVerified && !verified {
+		return domain.TenantInvitation{}, ErrEmailNotVerified
+	}
+	return inv, nil
+}
+
+func normalizeEmail(email string) string { return strings.ToLower(strings.TrimSpace(email)) }
+
+func newInvitationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,254 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+type roleRepo struct {
+	stubRepo
+	role domain.TenantRole
+}
+
+func (r roleRepo) GetUserRole(ctx context.Context, tenantID, userID string) (domain.TenantRole, error) {
+	return r.role, nil
+}
+
+type invRepoMem struct {
+	invs       map[string]*domain.TenantInvitation
+	tokens     map[string]string // token -> id
+	emails     map[string]string // user id -> email
+	unverified map[string]bool
+	members    map[string]bool // tenant|email
+	seq        int
+}
+
+func newInvRepoMem() *invRepoMem {
+	return &invRepoMem{invs: map[string]*domain.TenantInvitation{}, tokens: map[string]string{}, emails: map[string]string{}, members: map[string]bool{}, unverified: map[string]bool{}}
+}
+
+func (r *invRepoMem) CreateInvitation(ctx context.Context, inv domain.TenantInvitation, token string) (domain.TenantInvitation, error) {
+	for _, p := range r.invs {
+		if p.Tenant.ID == inv.Tenant.ID && p.Email == inv.Email && p.Status == domain.InvitationStatusPending {
+			p.Status = domain.InvitationStatusRevoked
+		}
+	}
+	r.seq++
+	inv.ID = fmt.Sprintf("i%d", r.seq)
+	inv.CreatedAt = time.Now()
+	r.invs[inv.ID] = &inv
+	r.tokens[token] = inv.ID
+	return inv, nil
+}
+
+func (r *invRepoMem) GetInvitation(ctx context.Context, id string) (domain.TenantInvitation, error) {
+	inv, ok := r.invs[id]
+	if !ok {
+		return domain.TenantInvitation{}, ErrInvitationNotFound
+	}
+	return *inv, nil
+}
+
+func (r *invRepoMem) GetInvitationByToken(ctx context.Context, token string) (domain.TenantInvitation, error) {
+	return r.GetInvitation(ctx, r.tokens[token])
+}
+
+func (r *invRepoMem) ListInvitations(ctx context.Context, tenantID string) ([]domain.TenantInvitation, error) {
+	var out []domain.TenantInvitation
+	for _, inv := range r.invs {
+		if inv.Tenant.ID == tenantID {
+			out = append(out, *inv)
+		}
+	}
+	return out, nil
+}
+
+func (r *invRepoMem) ListPendingInvitationsForEmail(ctx context.Context, email string) ([]domain.TenantInvitation, error) {
+	var out []domain.TenantInvitation
+	for _, inv := range r.invs {
+		if inv.Email == email && inv.Status == domain.InvitationStatusPending {
+			out = append(out, *inv)
+		}
+	}
+	return out, nil
+}
+
+func (r *invRepoMem) AcceptInvitation(ctx context.Context, invitationID, userID string) (domain.TenantMembership, error) {
+	inv := r.invs[invitationID]
+	if inv.Status != domain.InvitationStatusPending {
+		return domain.TenantMembership{}, ErrInvitationNotPending
+	}
+	inv.Status = domain.InvitationStatusAccepted
+	r.members[inv.Tenant.ID+"|"+inv.Email] = true
+	return domain.TenantMembership{Tenant: inv.Tenant, Role: inv.Role}, nil
+}
+
+func (r *invRepoMem) SetInvitationStatus(ctx context.Context, invitationID string, status domain.InvitationStatus) error {
+	inv := r.invs[invitationID]
+	if inv.Status != domain.InvitationStatusPending {
+		return ErrInvitationNotPending
+	}
+	inv.Status = status
+	return nil
+}
+
+func (r *invRepoMem) IsMemberByEmail(ctx context.Context, tenantID, email string) (bool, error) {
+	return r.members[tenantID+"|"+email], nil
+}
+
+func (r *invRepoMem) GetUserEmail(ctx context.Context, userID string) (string, bool, error) {
+	return r.emails[userID], !r.unverified[userID], nil
+}
+
+type senderMem struct{ tokens []string }
+
+func (s *senderMem) SendInvitation(ctx context.Context, inv domain.TenantInvitation, token string) error {
+	s.tokens = append(s.tokens, token)
+	return nil
+}
+
+func TestInvitation_CreateAndAccept(t *testing.T) {
+	repo := newInvRepoMem()
+	repo.emails["u2"] = "bob@example.com"
+	sender := &senderMem{}
+	svc := NewService(stubRepo{})
+	svc.SetInvitations(repo, sender, time.Hour)
+	ctx := context.Background()
+
+	inv, err := svc.CreateInvitation(ctx, "u1", "t1", " Bob@Example.com ", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if inv.Email != "bob@example.com" || inv.Role != domain.TenantRoleMember || inv.Status != domain.InvitationStatusPending {
+		t.Fatalf("unexpected invitation: %#v", inv)
+	}
+	if len(sender.tokens) != 1 || sender.tokens[0] == "" {
+		t.Fatalf("token not delivered: %#v", sender.tokens)
+	}
+	m, err := svc.AcceptInvitation(ctx, "u2", sender.tokens[0], "")
+	if err != nil || m.Tenant.ID != "t1" || m.Role != domain.TenantRoleMember {
+		t.Fatalf("accept: %v %#v", err, m)
+	}
+	if _, err := svc.AcceptInvitation(ctx, "u2", sender.tokens[0], ""); !errors.Is(err, ErrInvitationNotPending) {
+		t.Fatalf("expected not pending on second accept, got %v", err)
+	}
+	if _, err := svc.CreateInvitation(ctx, "u1", "t1", "bob@example.com", ""); !errors.Is(err, ErrAlreadyMember) {
+		t.Fatalf("expected already member, got %v", err)
+	}
+}
+
+func TestInvitation_Permissions(t *testing.T) {
+	repo := newInvRepoMem()
+	ctx := context.Background()
+
+	member := NewService(roleRepo{role: domain.TenantRoleMember})
+	member.SetInvitations(repo, nil, 0)
+	if _, err := member.CreateInvitation(ctx, "u1", "t1", "a@example.com", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("member must not invite: %v", err)
+	}
+	admin := NewService(roleRepo{role: domain.TenantRoleAdmin})
+	admin.SetInvitations(repo, nil, 0)
+	if _, err := admin.CreateInvitation(ctx, "u1", "t1", "a@example.com", domain.TenantRoleOwner); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("admin must not invite owners: %v", err)
+	}
+	inv, err := admin.CreateInvitation(ctx, "u1", "t1", "a@example.com", domain.TenantRoleAdmin)
+	if err != nil {
+		t.Fatalf("admin invite: %v", err)
+	}
+	if !inv.ExpiresAt.After(time.Now().Add(DefaultInvitationTTL - time.Minute)) {
+		t.Fatalf("default ttl not applied: %v", inv.ExpiresAt)
+	}
+	if err := admin.RevokeInvitation(ctx, "u1", "t2", inv.ID); !errors.Is(err, ErrInvitationNotFound) {
+		t.Fatalf("revoke from another tenant: %v", err)
+	}
+	if err := admin.RevokeInvitation(ctx, "u1", "t1", inv.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := admin.RevokeInvitation(ctx, "u1", "t1", inv.ID); !errors.Is(err, ErrInvitationNotPending) {
+		t.Fatalf("double revoke: %v", err)
+	}
+}
+
+func TestInvitation_RespondChecks(t *testing.T) {
+	repo := newInvRepoMem()
+	repo.emails["u2"] = "bob@example.com"
+	repo.emails["u3"] = "eve@example.com"
+	sender := &senderMem{}
+	svc := NewService(stubRepo{})
+	svc.SetInvitations(repo, sender, time.Hour)
+	ctx := context.Background()
+
+	inv, err := svc.CreateInvitation(ctx, "u1", "t1", "bob@example.com", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := svc.AcceptInvitation(ctx, "u3", "", inv.ID); !errors.Is(err, ErrInvitationEmailMismatch) {
+		t.Fatalf("expected email mismatch, got %v", err)
+	}
+	mine, err := svc.ListMyInvitations(ctx, "u2")
+	if err != nil || len(mine) != 1 {
+		t.Fatalf("list mine: %v %#v", err, mine)
+	}
+	repo.invs[inv.ID].ExpiresAt = time.Now().Add(-time.Minute)
+	if err := svc.DeclineInvitation(ctx, "u2", "", inv.ID); !errors.Is(err, ErrInvitationExpired) {
+		t.Fatalf("expected expired, got %v", err)
+	}
+	if repo.invs[inv.ID].Status != domain.InvitationStatusExpired {
+		t.Fatalf("status not updated: %s", repo.invs[inv.ID].Status)
+	}
+	// accepting again still reports the expiry rather than "not pending"
+	if _, err := svc.AcceptInvitation(ctx, "u2", "", inv.ID); !errors.Is(err, ErrInvitationExpired) {
+		t.Fatalf("expected expired on retry, got %v", err)
+	}
+	if err := repo.SetInvitationStatus(ctx, inv.ID, domain.InvitationStatusDeclined); !errors.Is(err, ErrInvitationNotPending) {
+		t.Fatalf("expected not pending, got %v", err)
+	}
+}
+
+func TestInvitation_AttachPending(t *testing.T) {
+	repo := newInvRepoMem()
+	svc := NewService(stubRepo{})
+	svc.SetInvitations(repo, nil, time.Hour)
+	ctx := context.Background()
+
+	if _, err := svc.CreateInvitation(ctx, "u1", "t1", "new@example.com", domain.TenantRoleAdmin); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	ms, err := svc.AttachPendingInvitations(ctx, "u9", "New@example.com")
+	if err != nil || len(ms) != 1 || ms[0].Role != domain.TenantRoleAdmin {
+		t.Fatalf("attach: %v %#v", err, ms)
+	}
+	if _, err := NewService(stubRepo{}).AttachPendingInvitations(ctx, "u9", "new@example.com"); err != nil {
+		t.Fatalf("attach without invitations configured: %v", err)
+	}
+	if _, err := NewService(stubRepo{}).CreateInvitation(ctx, "u1", "t1", "x@example.com", ""); !errors.Is(err, ErrInvitationsDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+}
+
+func TestInvitation_RequireVerifiedEmail(t *testing.T) {
+	repo := newInvRepoMem()
+	repo.emails["u2"] = "bob@example.com"
+	repo.unverified["u2"] = true
+	svc := NewService(stubRepo{})
+	svc.SetInvitations(repo, nil, time.Hour)
+	svc.SetRequireVerifiedEmail(true)
+	ctx := context.Background()
+
+	inv, err := svc.CreateInvitation(ctx, "u1", "t1", "bob@example.com", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := svc.AcceptInvitation(ctx, "u2", "", inv.ID); !errors.Is(err, ErrEmailNotVerified) {
+		t.Fatalf("expected email not verified, got %v", err)
+	}
+	repo.unverified["u2"] = false
+	if _, err := svc.AcceptInvitation(ctx, "u2", "", inv.ID); err != nil {
+		t.Fatalf("accept after verification: %v", err)
+	}
+}
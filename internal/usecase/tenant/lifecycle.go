@@ -0,0 +1,185 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+var (
+	ErrInvalidDeletionToken = errors.New("invalid or expired deletion confirmation token")
+	ErrDeletionScheduled    = errors.New("tenant is already scheduled for deletion")
+	ErrDeletionNotScheduled = errors.New("tenant is not scheduled for deletion")
+)
+
+const (
+	// DefaultDeletionGracePeriod is how long a tenant scheduled for deletion can still be restored.
+	DefaultDeletionGracePeriod = 7 * 24 * time.Hour
+	// DeletionConfirmationTTL limits how long a deletion confirmation token stays valid.
+	DeletionConfirmationTTL = 15 * time.Minute
+)
+
+// DeletionRequest is the result of DeleteTenant: either a confirmation token to repeat
+// the call with, or the scheduled tenant once confirmed.
+type DeletionRequest struct {
+	ConfirmationToken     string
+	ConfirmationExpiresAt time.Time
+	Tenant                domain.Tenant
+}
+
+func (s *Service) SetDeletionGracePeriod(d time.Duration) {
+	if d > 0 {
+		s.deletionGrace = d
+	}
+}
+
+// Permissions: transfer ownership - owner only. The new owner must already be a member;
+// the acting owner becomes admin.
+func (s *Service) TransferOwnership(ctx context.Context, actingUserID, tenantID, newOwnerUserID string) (from, to domain.TenantMembership, err error) {
+	ar, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	if ar != domain.TenantRoleOwner || actingUserID == newOwnerUserID {
+		return domain.TenantMembership{}, domain.TenantMembership{}, ErrPermissionDenied
+	}
+	tr, err := s.repo.GetUserRole(ctx, tenantID, newOwnerUserID)
+	if err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	if tr == "" {
+		return domain.TenantMembership{}, domain.TenantMembership{}, ErrNotMember
+	}
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		if from, to, err = s.repo.TransferOwnership(ctx, tenantID, actingUserID, newOwnerUserID); err != nil {
+			return err
+		}
+		if err := s.recordMember(ctx, tenantID, actingUserID, domain.AuditActionUpdated, ar, from.Role); err != nil {
+			return err
+		}
+		return s.recordMember(ctx, tenantID, newOwnerUserID, domain.AuditActionUpdated, tr, to.Role)
+	})
+	if err != nil {
+		return domain.TenantMembership{}, domain.TenantMembership{}, err
+	}
+	return from, to, nil
+}
+
+// LeaveTenant removes the acting user from the tenant. The last owner has to transfer
+// ownership (or delete the tenant) first. If the left tenant was the default one,
+// another membership becomes default.
+func (s *Service) LeaveTenant(ctx context.Context, actingUserID, tenantID string) error {
+	ms, err := s.repo.ListForUser(ctx, actingUserID)
+	if err != nil {
+		return err
+	}
+	var current *domain.TenantMembership
+	var next string
+	for i := range ms {
+		if ms[i].Tenant.ID == tenantID {
+			current = &ms[i]
+		} else if next == "" {
+			next = ms[i].Tenant.ID
+		}
+	}
+	if current == nil {
+		return ErrNotMember
+	}
+	if current.Role == domain.TenantRoleOwner {
+		n, err := s.repo.CountOwners(ctx, tenantID)
+		if err != nil {
+			return err
+		}
+		if n <= 1 {
+			return ErrLastOwner
+		}
+	}
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		if err := s.repo.RemoveMember(ctx, tenantID, actingUserID); err != nil {
+			return err
+		}
+		return s.recordMember(ctx, tenantID, actingUserID, domain.AuditActionDeleted, current.Role, "")
+	})
+	if err != nil {
+		return err
+	}
+	if current.IsDefault && next != "" {
+		return s.repo.SetDefaultTenant(ctx, actingUserID, next)
+	}
+	return nil
+}
+
+// SetDefaultTenant marks the tenant Login selects for the acting user.
+func (s *Service) SetDefaultTenant(ctx context.Context, actingUserID, tenantID string) error {
+	role, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return ErrNotMember
+	}
+	return s.repo.SetDefaultTenant(ctx, actingUserID, tenantID)
+}
+
+// DeleteTenant is a two-step operation (owner only): called without a token it issues
+// a short-lived confirmation token; called with that token it schedules the tenant for
+// deletion after the grace period. Data is purged by PurgeScheduledTenants.
+func (s *Service) DeleteTenant(ctx context.Context, actingUserID, tenantID, confirmationToken string) (DeletionRequest, error) {
+	ar, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return DeletionRequest{}, err
+	}
+	if ar != domain.TenantRoleOwner {
+		return DeletionRequest{}, ErrPermissionDenied
+	}
+	t, err := s.repo.GetByID(ctx, tenantID)
+	if err != nil {
+		return DeletionRequest{}, err
+	}
+	if t.DeletionScheduledAt != nil {
+		return DeletionRequest{}, ErrDeletionScheduled
+	}
+	if confirmationToken == "" {
+		token, err := newInvitationToken()
+		if err != nil {
+			return DeletionRequest{}, err
+		}
+		exp := time.Now().Add(DeletionConfirmationTTL)
+		if err := s.repo.SetDeletionToken(ctx, tenantID, actingUserID, token, exp); err != nil {
+			return DeletionRequest{}, err
+		}
+		return DeletionRequest{ConfirmationToken: token, ConfirmationExpiresAt: exp, Tenant: t}, nil
+	}
+	t, err = s.repo.ScheduleDeletion(ctx, tenantID, confirmationToken, time.Now().Add(s.deletionGrace))
+	if err != nil {
+		return DeletionRequest{}, err
+	}
+	return DeletionRequest{Tenant: t}, nil
+}
+
+// Permissions: cancel scheduled deletion - owner only
+func (s *Service) CancelTenantDeletion(ctx context.Context, actingUserID, tenantID string) (domain.Tenant, error) {
+	ar, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	if ar != domain.TenantRoleOwner {
+		return domain.Tenant{}, ErrPermissionDenied
+	}
+	t, err := s.repo.GetByID(ctx, tenantID)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	if t.DeletionScheduledAt == nil {
+		return domain.Tenant{}, ErrDeletionNotScheduled
+	}
+	return s.repo.CancelDeletion(ctx, tenantID)
+}
+
+// PurgeScheduledTenants removes tenants whose deletion grace period has ended.
+// Called periodically from the server process.
+func (s *Service) PurgeScheduledTenants(ctx context.Context) (int64, error) {
+	return s.repo.PurgeScheduledTenants(ctx, time.Now())
+}
@@ -0,0 +1,191 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// lifecycleRepo keeps memberships of a single user set in memory.
+type lifecycleRepo struct {
+	stubRepo
+	roles       map[string]domain.TenantRole // "tenant|user" -> role
+	defaults    map[string]string            // user -> tenant
+	token       string
+	tokenExp    time.Time
+	scheduled   *time.Time
+	transferred bool
+}
+
+func newLifecycleRepo() *lifecycleRepo {
+	return &lifecycleRepo{roles: map[string]domain.TenantRole{}, defaults: map[string]string{}}
+}
+
+func (r *lifecycleRepo) GetUserRole(ctx context.Context, tenantID, userID string) (domain.TenantRole, error) {
+	return r.roles[tenantID+"|"+userID], nil
+}
+
+func (r *lifecycleRepo) ListForUser(ctx context.Context, userID string) ([]domain.TenantMembership, error) {
+	var out []domain.TenantMembership
+	for _, tid := range []string{"t1", "t2", "t3"} {
+		if role, ok := r.roles[tid+"|"+userID]; ok {
+			out = append(out, domain.TenantMembership{Tenant: domain.Tenant{ID: tid}, Role: role, IsDefault: r.defaults[userID] == tid})
+		}
+	}
+	return out, nil
+}
+
+func (r *lifecycleRepo) CountOwners(ctx context.Context, tenantID string) (int, error) {
+	n := 0
+	for k, role := range r.roles {
+		if role == domain.TenantRoleOwner && strings.HasPrefix(k, tenantID+"|") {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *lifecycleRepo) RemoveMember(ctx context.Context, tenantID, userID string) error {
+	delete(r.roles, tenantID+"|"+userID)
+	if r.defaults[userID] == tenantID {
+		delete(r.defaults, userID)
+	}
+	return nil
+}
+
+func (r *lifecycleRepo) SetDefaultTenant(ctx context.Context, userID, tenantID string) error {
+	r.defaults[userID] = tenantID
+	return nil
+}
+
+func (r *lifecycleRepo) TransferOwnership(ctx context.Context, tenantID, fromUserID, toUserID string) (domain.TenantMembership, domain.TenantMembership, error) {
+	r.transferred = true
+	r.roles[tenantID+"|"+toUserID] = domain.TenantRoleOwner
+	r.roles[tenantID+"|"+fromUserID] = domain.TenantRoleAdmin
+	return domain.TenantMembership{Role: domain.TenantRoleAdmin, UserID: fromUserID}, domain.TenantMembership{Role: domain.TenantRoleOwner, UserID: toUserID}, nil
+}
+
+func (r *lifecycleRepo) SetDeletionToken(ctx context.Context, tenantID, userID, token string, expiresAt time.Time) error {
+	r.token, r.tokenExp = token, expiresAt
+	return nil
+}
+
+func (r *lifecycleRepo) ScheduleDeletion(ctx context.Context, tenantID, token string, purgeAt time.Time) (domain.Tenant, error) {
+	if token == "" || token != r.token || !time.Now().Before(r.tokenExp) {
+		return domain.Tenant{}, ErrInvalidDeletionToken
+	}
+	r.token = ""
+	r.scheduled = &purgeAt
+	return domain.Tenant{ID: tenantID, DeletionScheduledAt: r.scheduled}, nil
+}
+
+func (r *lifecycleRepo) CancelDeletion(ctx context.Context, tenantID string) (domain.Tenant, error) {
+	r.scheduled = nil
+	return domain.Tenant{ID: tenantID}, nil
+}
+
+func (r *lifecycleRepo) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
+	return domain.Tenant{ID: id, DeletionScheduledAt: r.scheduled}, nil
+}
+
+func TestLifecycle_TransferOwnership(t *testing.T) {
+	repo := newLifecycleRepo()
+	repo.roles["t1|u1"] = domain.TenantRoleOwner
+	repo.roles["t1|u2"] = domain.TenantRoleMember
+	repo.roles["t1|u3"] = domain.TenantRoleAdmin
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	if _, _, err := svc.TransferOwnership(ctx, "u3", "t1", "u2"); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("admin must not transfer ownership: %v", err)
+	}
+	if _, _, err := svc.TransferOwnership(ctx, "u1", "t1", "u9"); !errors.Is(err, ErrNotMember) {
+		t.Fatalf("expected not member, got %v", err)
+	}
+	from, to, err := svc.TransferOwnership(ctx, "u1", "t1", "u2")
+	if err != nil || from.Role != domain.TenantRoleAdmin || to.Role != domain.TenantRoleOwner || !repo.transferred {
+		t.Fatalf("transfer: %v %#v %#v", err, from, to)
+	}
+}
+
+func TestLifecycle_LeaveTenant(t *testing.T) {
+	repo := newLifecycleRepo()
+	repo.roles["t1|u1"] = domain.TenantRoleOwner
+	repo.roles["t1|u2"] = domain.TenantRoleMember
+	repo.roles["t2|u2"] = domain.TenantRoleOwner
+	repo.defaults["u2"] = "t1"
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	if err := svc.LeaveTenant(ctx, "u1", "t1"); !errors.Is(err, ErrLastOwner) {
+		t.Fatalf("last owner must not leave: %v", err)
+	}
+	if err := svc.LeaveTenant(ctx, "u1", "t3"); !errors.Is(err, ErrNotMember) {
+		t.Fatalf("expected not member, got %v", err)
+	}
+	if err := svc.LeaveTenant(ctx, "u2", "t1"); err != nil {
+		t.Fatalf("leave: %v", err)
+	}
+	if _, ok := repo.roles["t1|u2"]; ok {
+		t.Fatal("membership not removed")
+	}
+	if repo.defaults["u2"] != "t2" {
+		t.Fatalf("default tenant not moved: %q", repo.defaults["u2"])
+	}
+	repo.roles["t1|u3"] = domain.TenantRoleOwner
+	if err := svc.LeaveTenant(ctx, "u1", "t1"); err != nil {
+		t.Fatalf("owner with a co-owner should leave: %v", err)
+	}
+}
+
+func TestLifecycle_SetDefaultTenant(t *testing.T) {
+	repo := newLifecycleRepo()
+	repo.roles["t2|u1"] = domain.TenantRoleMember
+	svc := NewService(repo)
+	if err := svc.SetDefaultTenant(context.Background(), "u1", "t1"); !errors.Is(err, ErrNotMember) {
+		t.Fatalf("expected not member, got %v", err)
+	}
+	if err := svc.SetDefaultTenant(context.Background(), "u1", "t2"); err != nil || repo.defaults["u1"] != "t2" {
+		t.Fatalf("set default: %v %#v", err, repo.defaults)
+	}
+}
+
+func TestLifecycle_DeleteTenant(t *testing.T) {
+	repo := newLifecycleRepo()
+	repo.roles["t1|u1"] = domain.TenantRoleOwner
+	repo.roles["t1|u2"] = domain.TenantRoleAdmin
+	svc := NewService(repo)
+	svc.SetDeletionGracePeriod(48 * time.Hour)
+	ctx := context.Background()
+
+	if _, err := svc.DeleteTenant(ctx, "u2", "t1", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("admin must not delete: %v", err)
+	}
+	req, err := svc.DeleteTenant(ctx, "u1", "t1", "")
+	if err != nil || req.ConfirmationToken == "" || req.Tenant.DeletionScheduledAt != nil {
+		t.Fatalf("request deletion: %v %#v", err, req)
+	}
+	if _, err := svc.DeleteTenant(ctx, "u1", "t1", "wrong"); !errors.Is(err, ErrInvalidDeletionToken) {
+		t.Fatalf("expected invalid token, got %v", err)
+	}
+	done, err := svc.DeleteTenant(ctx, "u1", "t1", req.ConfirmationToken)
+	if err != nil || done.Tenant.DeletionScheduledAt == nil {
+		t.Fatalf("confirm deletion: %v %#v", err, done)
+	}
+	if d := time.Until(*done.Tenant.DeletionScheduledAt); d < 47*time.Hour || d > 48*time.Hour {
+		t.Fatalf("grace period not applied: %v", d)
+	}
+	if _, err := svc.DeleteTenant(ctx, "u1", "t1", ""); !errors.Is(err, ErrDeletionScheduled) {
+		t.Fatalf("expected already scheduled, got %v", err)
+	}
+	if _, err := svc.CancelTenantDeletion(ctx, "u1", "t1"); err != nil || repo.scheduled != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if _, err := svc.CancelTenantDeletion(ctx, "u1", "t1"); !errors.Is(err, ErrDeletionNotScheduled) {
+		t.Fatalf("expected not scheduled, got %v", err)
+	}
+}
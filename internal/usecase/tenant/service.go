@@ -3,13 +3,19 @@ package tenant
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
 )
 
 var (
 	ErrPermissionDenied = errors.New("permission denied")
 	ErrAlreadyMember    = errors.New("already_member")
+	ErrNotMember        = errors.New("user is not a member of the tenant")
+	ErrLastOwner        = errors.New("the last owner cannot leave the tenant")
+	ErrAuditDisabled    = errors.New("audit log is not configured")
 )
 
 type Repo interface {
@@ -23,11 +29,51 @@ type Repo interface {
 	UpdateMemberRole(ctx context.Context, tenantID, userID string, role domain.TenantRole) (domain.TenantMembership, error)
 	RemoveMember(ctx context.Context, tenantID, userID string) error
 	GetUserRole(ctx context.Context, tenantID, userID string) (domain.TenantRole, error)
+
+	// Ownership, membership lifecycle and deletion
+	TransferOwnership(ctx context.Context, tenantID, fromUserID, toUserID string) (from, to domain.TenantMembership, err error)
+	CountOwners(ctx context.Context, tenantID string) (int, error)
+	SetDefaultTenant(ctx context.Context, userID, tenantID string) error
+	// SetDeletionToken stores the (hashed) confirmation token for a pending deletion request
+	SetDeletionToken(ctx context.Context, tenantID, userID, token string, expiresAt time.Time) error
+	// ScheduleDeletion consumes a valid confirmation token and schedules the purge
+	ScheduleDeletion(ctx context.Context, tenantID, token string, purgeAt time.Time) (domain.Tenant, error)
+	CancelDeletion(ctx context.Context, tenantID string) (domain.Tenant, error)
+	// PurgeScheduledTenants deletes (with cascade) tenants whose grace period ended before now
+	PurgeScheduledTenants(ctx context.Context, now time.Time) (int64, error)
+	GetByID(ctx context.Context, id string) (domain.Tenant, error)
+	// SetCurrencies replaces the list of currencies pinned by the tenant
+	SetCurrencies(ctx context.Context, tenantID string, codes []string) (domain.Tenant, error)
 }
 
-type Service struct{ repo Repo }
+type Service struct {
+	repo          Repo
+	deletionGrace time.Duration
+
+	// invitations (optional, see SetInvitations)
+	invites   InvitationRepo
+	sender    InvitationSender
+	inviteTTL time.Duration
+	// requireVerified: only verified addresses can accept invitations
+	requireVerified bool
 
-func NewService(repo Repo) *Service { return &Service{repo: repo} }
+	// revoker invalidates access tokens of removed members (optional)
+	revoker TokenRevoker
+
+	// audit records membership and tenant changes (optional)
+	audit *audit.Log
+}
+
+// TokenRevoker invalidates access tokens issued to a user before the given time.
+type TokenRevoker interface {
+	RevokeUserTokens(ctx context.Context, userID string, before time.Time) error
+}
+
+func NewService(repo Repo) *Service {
+	return &Service{repo: repo, deletionGrace: DefaultDeletionGracePeriod}
+}
+
+func (s *Service) SetTokenRevoker(r TokenRevoker) { s.revoker = r }
 
 func (s *Service) CreateTenant(ctx context.Context, name, slug, defaultCurrency, ownerUserID string) (domain.Tenant, error) {
 	return s.repo.Create(ctx, name, slug, defaultCurrency, ownerUserID)
@@ -46,7 +92,74 @@ func (s *Service) UpdateTenant(ctx context.Context, actingUserID, tenantID, name
 	if role != domain.TenantRoleOwner && role != domain.TenantRoleAdmin {
 		return domain.Tenant{}, ErrPermissionDenied
 	}
-	return s.repo.UpdateTenant(ctx, tenantID, name, slug, defaultCurrency)
+	var updated domain.Tenant
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		var before *domain.Tenant
+		if s.audit != nil {
+			t, err := s.repo.GetByID(ctx, tenantID)
+			if err != nil {
+				return err
+			}
+			before = &t
+		}
+		if updated, err = s.repo.UpdateTenant(ctx, tenantID, name, slug, defaultCurrency); err != nil {
+			return err
+		}
+		if before == nil {
+			return nil
+		}
+		return s.audit.Record(ctx, tenantID, domain.AuditEntityTenant, tenantID, domain.AuditActionUpdated, *before, updated)
+	})
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	return updated, nil
+}
+
+// SetCurrencies pins the currencies the tenant uses (an empty list allows all of them).
+// Codes must be known ISO 4217 codes; duplicates are dropped.
+// Permissions: only owner or admin can change the list
+func (s *Service) SetCurrencies(ctx context.Context, actingUserID, tenantID string, codes []string) (domain.Tenant, error) {
+	role, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	if role != domain.TenantRoleOwner && role != domain.TenantRoleAdmin {
+		return domain.Tenant{}, ErrPermissionDenied
+	}
+	pinned := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		if !domain.IsCurrencyCode(c) {
+			return domain.Tenant{}, fmt.Errorf("%w: %q", domain.ErrUnknownCurrency, c)
+		}
+		if !seen[c] {
+			seen[c] = true
+			pinned = append(pinned, c)
+		}
+	}
+	var updated domain.Tenant
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		var before *domain.Tenant
+		if s.audit != nil {
+			t, err := s.repo.GetByID(ctx, tenantID)
+			if err != nil {
+				return err
+			}
+			before = &t
+		}
+		if updated, err = s.repo.SetCurrencies(ctx, tenantID, pinned); err != nil {
+			return err
+		}
+		if before == nil {
+			return nil
+		}
+		return s.audit.Record(ctx, tenantID, domain.AuditEntityTenant, tenantID, domain.AuditActionUpdated, *before, updated)
+	})
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+	return updated, nil
 }
 
 // Permissions: list members - any member can view
@@ -73,7 +186,17 @@ func (s *Service) AddMember(ctx context.Context, actingUserID, tenantID, userEma
 	if role == domain.TenantRoleOwner && ar != domain.TenantRoleOwner {
 		return domain.TenantMembership{}, ErrPermissionDenied
 	}
-	return s.repo.AddMember(ctx, tenantID, userEmail, role)
+	var m domain.TenantMembership
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		if m, err = s.repo.AddMember(ctx, tenantID, userEmail, role); err != nil {
+			return err
+		}
+		return s.recordMember(ctx, tenantID, m.UserID, domain.AuditActionCreated, "", m.Role)
+	})
+	if err != nil {
+		return domain.TenantMembership{}, err
+	}
+	return m, nil
 }
 
 // Permissions: update member role - owner or admin, but only owner can grant owner
@@ -92,7 +215,21 @@ func (s *Service) UpdateMemberRole(ctx context.Context, actingUserID, tenantID,
 	if actingUserID == userID && role != domain.TenantRoleOwner {
 		return domain.TenantMembership{}, ErrPermissionDenied
 	}
-	return s.repo.UpdateMemberRole(ctx, tenantID, userID, role)
+	var m domain.TenantMembership
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetUserRole(ctx, tenantID, userID)
+		if err != nil {
+			return err
+		}
+		if m, err = s.repo.UpdateMemberRole(ctx, tenantID, userID, role); err != nil {
+			return err
+		}
+		return s.recordMember(ctx, tenantID, userID, domain.AuditActionUpdated, before, m.Role)
+	})
+	if err != nil {
+		return domain.TenantMembership{}, err
+	}
+	return m, nil
 }
 
 // Permissions: remove member - owner or admin; only owner can remove another owner
@@ -115,5 +252,55 @@ func (s *Service) RemoveMember(ctx context.Context, actingUserID, tenantID, user
 	if tr == domain.TenantRoleOwner && ar != domain.TenantRoleOwner {
 		return ErrPermissionDenied
 	}
-	return s.repo.RemoveMember(ctx, tenantID, userID)
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		if err := s.repo.RemoveMember(ctx, tenantID, userID); err != nil {
+			return err
+		}
+		return s.recordMember(ctx, tenantID, userID, domain.AuditActionDeleted, tr, "")
+	})
+	if err != nil {
+		return err
+	}
+	if s.revoker != nil {
+		// already issued tokens still carry the tenant; the user gets new ones on refresh
+		if err := s.revoker.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+			return fmt.Errorf("member removed, but revoking tokens failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) SetAuditLog(l *audit.Log) { s.audit = l }
+
+// memberSnapshot is the audited state of a membership.
+type memberSnapshot struct {
+	UserID string            `json:"user_id"`
+	Role   domain.TenantRole `json:"role"`
+}
+
+// recordMember records a membership change; an empty role means no membership.
+func (s *Service) recordMember(ctx context.Context, tenantID, userID string, action domain.AuditAction, before, after domain.TenantRole) error {
+	var b, a interface{}
+	if before != "" {
+		b = memberSnapshot{UserID: userID, Role: before}
+	}
+	if after != "" {
+		a = memberSnapshot{UserID: userID, Role: after}
+	}
+	return s.audit.Record(ctx, tenantID, domain.AuditEntityMembership, userID, action, b, a)
+}
+
+// ListAuditEvents returns the tenant audit log, newest first. Owners and admins only.
+func (s *Service) ListAuditEvents(ctx context.Context, actingUserID, tenantID string, filter audit.Filter) ([]domain.AuditEvent, int64, error) {
+	if s.audit == nil {
+		return nil, 0, ErrAuditDisabled
+	}
+	role, err := s.repo.GetUserRole(ctx, tenantID, actingUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if role != domain.TenantRoleOwner && role != domain.TenantRoleAdmin {
+		return nil, 0, ErrPermissionDenied
+	}
+	return s.audit.List(ctx, tenantID, filter)
 }
@@ -3,6 +3,7 @@ package tenant
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/positron48/budget/internal/domain"
 )
@@ -38,6 +39,31 @@ func (stubRepo) GetUserRole(ctx context.Context, tenantID, userID string) (domai
 	return domain.TenantRoleOwner, nil
 }
 
+func (stubRepo) TransferOwnership(ctx context.Context, tenantID, fromUserID, toUserID string) (domain.TenantMembership, domain.TenantMembership, error) {
+	return domain.TenantMembership{Tenant: domain.Tenant{ID: tenantID}, Role: domain.TenantRoleAdmin, UserID: fromUserID},
+		domain.TenantMembership{Tenant: domain.Tenant{ID: tenantID}, Role: domain.TenantRoleOwner, UserID: toUserID}, nil
+}
+func (stubRepo) CountOwners(ctx context.Context, tenantID string) (int, error)       { return 1, nil }
+func (stubRepo) SetDefaultTenant(ctx context.Context, userID, tenantID string) error { return nil }
+func (stubRepo) SetDeletionToken(ctx context.Context, tenantID, userID, token string, expiresAt time.Time) error {
+	return nil
+}
+func (stubRepo) ScheduleDeletion(ctx context.Context, tenantID, token string, purgeAt time.Time) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID, DeletionScheduledAt: &purgeAt}, nil
+}
+func (stubRepo) CancelDeletion(ctx context.Context, tenantID string) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID}, nil
+}
+func (stubRepo) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
+	return domain.Tenant{ID: id}, nil
+}
+func (stubRepo) SetCurrencies(ctx context.Context, tenantID string, codes []string) (domain.Tenant, error) {
+	return domain.Tenant{ID: tenantID, CurrencyCodes: codes}, nil
+}
+func (stubRepo) PurgeScheduledTenants(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
 func TestService_CreateAndList(t *testing.T) {
 	svc := NewService(stubRepo{})
 	ctx := context.Background()
@@ -50,3 +76,22 @@ func TestService_CreateAndList(t *testing.T) {
 		t.Fatalf("list: %v %#v", err, lst)
 	}
 }
+
+type revokerMem struct{ users []string }
+
+func (r *revokerMem) RevokeUserTokens(ctx context.Context, userID string, before time.Time) error {
+	r.users = append(r.users, userID)
+	return nil
+}
+
+func TestService_RemoveMember_RevokesTokens(t *testing.T) {
+	svc := NewService(stubRepo{})
+	rev := &revokerMem{}
+	svc.SetTokenRevoker(rev)
+	if err := svc.RemoveMember(context.Background(), "u1", "t1", "u2"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if len(rev.users) != 1 || rev.users[0] != "u2" {
+		t.Fatalf("expected tokens of u2 revoked, got %v", rev.users)
+	}
+}
@@ -0,0 +1,81 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
+)
+
+type auditRepoMem struct {
+	events []domain.AuditEvent
+	err    error
+}
+
+func (m *auditRepoMem) Record(ctx context.Context, ev domain.AuditEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, ev)
+	return nil
+}
+
+func (m *auditRepoMem) List(ctx context.Context, tenantID string, f audit.Filter) ([]domain.AuditEvent, int64, error) {
+	return m.events, int64(len(m.events)), nil
+}
+
+type txRunnerStub struct{ calls int }
+
+func (r *txRunnerStub) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.calls++
+	return fn(ctx)
+}
+
+type tenantTxRepo struct{ captureTxRepo }
+
+func (r *tenantTxRepo) Get(ctx context.Context, id string) (domain.Transaction, error) {
+	return domain.Transaction{ID: id, TenantID: "t1", Comment: "before"}, nil
+}
+
+func TestService_AuditLog(t *testing.T) {
+	repo := &tenantTxRepo{}
+	events, runner := &auditRepoMem{}, &txRunnerStub{}
+	svc := NewService(repo, stubFxRepo{rate: "1", provider: "p"}, stubTenantRepo{defCcy: "USD"}, stubCategoryRepo{})
+	svc.SetAuditLog(audit.NewLog(events, runner))
+	ctx := context.Background()
+
+	if _, err := svc.CreateForUser(ctx, "t1", "u1", domain.TransactionTypeExpense, "cat1", domain.Money{CurrencyCode: "USD", MinorUnits: 100}, time.Now(), "", false); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := svc.Update(ctx, domain.Transaction{ID: "tx1", TenantID: "t1", CategoryID: "cat1", Type: domain.TransactionTypeExpense, Amount: domain.Money{CurrencyCode: "USD", MinorUnits: 5}, Comment: "after"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := svc.Delete(ctx, "tx1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if runner.calls != 3 || len(events.events) != 3 {
+		t.Fatalf("expected 3 changes in transactions, got %d runs %d events", runner.calls, len(events.events))
+	}
+	want := []domain.AuditAction{domain.AuditActionCreated, domain.AuditActionUpdated, domain.AuditActionDeleted}
+	for i, ev := range events.events {
+		if ev.Action != want[i] || ev.EntityType != domain.AuditEntityTransaction || ev.TenantID != "t1" {
+			t.Fatalf("event %d: %+v", i, ev)
+		}
+	}
+	upd := events.events[1]
+	if upd.Before == nil || upd.After == nil || string(upd.Before) == string(upd.After) {
+		t.Fatalf("update must carry both snapshots: %+v", upd)
+	}
+	if del := events.events[2]; del.Before == nil || del.After != nil {
+		t.Fatalf("delete must carry only the before snapshot: %+v", del)
+	}
+
+	// a change whose audit event can't be written fails as a whole
+	events.err = errors.New("audit down")
+	if err := svc.Delete(ctx, "tx2"); err == nil {
+		t.Fatal("expected error when the audit event is not written")
+	}
+}
@@ -0,0 +1,226 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/positron48/budget/internal/domain"
+)
+
+// MaxBatchSize limits how many transactions one batch operation may touch.
+const MaxBatchSize = 1000
+
+var (
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrInvalidAmount       = errors.New("amount currency is required")
+	ErrInvalidBatch        = errors.New("batch needs either transaction ids or a filter")
+	ErrEmptyBatch          = errors.New("batch is empty")
+	ErrBatchTooLarge       = errors.New("batch is too large")
+)
+
+// TxRunner runs fn in a database transaction carried by ctx.
+type TxRunner interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// SetTxRunner makes batch operations atomic; without it the items are written one by one.
+func (s *Service) SetTxRunner(r TxRunner) { s.tx = r }
+
+// BatchSelector picks the transactions of a batch: explicit IDs or everything matching Filter.
+type BatchSelector struct {
+	IDs    []string
+	Filter *ListFilter
+}
+
+// BatchPatch holds the fields a batch update changes; nil fields are left as they are.
+type BatchPatch struct {
+	CategoryID      *string
+	Comment         *string
+	IsExtraordinary *bool
+}
+
+// BatchResult is the outcome for one item of a batch. Err is set when the item was rejected;
+// Transaction is set for successful creates and updates.
+type BatchResult struct {
+	ID          string
+	Transaction domain.Transaction
+	Err         error
+}
+
+// BatchUpdate applies the patch to every selected transaction with the same validation as Update.
+// Rejected items are reported in their result; any other error rolls the whole batch back.
+func (s *Service) BatchUpdate(ctx context.Context, tenantID string, sel BatchSelector, patch BatchPatch) ([]BatchResult, error) {
+	if patch.CategoryID != nil && !isUUID(*patch.CategoryID) {
+		return nil, ErrInvalidCategory
+	}
+	var results []BatchResult
+	err := s.withinTx(ctx, func(ctx context.Context) error {
+		ids, current, err := s.batchTargets(ctx, tenantID, sel)
+		if err != nil {
+			return err
+		}
+		results = make([]BatchResult, 0, len(ids))
+		for _, id := range ids {
+			tx, ok := current[id]
+			if !ok {
+				results = append(results, BatchResult{ID: id, Err: ErrTransactionNotFound})
+				continue
+			}
+			patch.apply(&tx)
+			updated, err := s.Update(ctx, tx)
+			if err != nil {
+				if !isBatchItemError(err) {
+					return err
+				}
+				results = append(results, BatchResult{ID: id, Err: err})
+				continue
+			}
+			results = append(results, BatchResult{ID: id, Transaction: updated})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchDelete moves every selected transaction to the trash.
+func (s *Service) BatchDelete(ctx context.Context, tenantID string, sel BatchSelector) ([]BatchResult, error) {
+	var results []BatchResult
+	err := s.withinTx(ctx, func(ctx context.Context) error {
+		ids, current, err := s.batchTargets(ctx, tenantID, sel)
+		if err != nil {
+			return err
+		}
+		results = make([]BatchResult, 0, len(ids))
+		for _, id := range ids {
+			if _, ok := current[id]; !ok {
+				results = append(results, BatchResult{ID: id, Err: ErrTransactionNotFound})
+				continue
+			}
+			if err := s.Delete(ctx, id); err != nil {
+				return err
+			}
+			results = append(results, BatchResult{ID: id})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchCreate creates the transactions with the same validation as CreateForUser.
+// Only Type, CategoryID, Amount, OccurredAt, Comment and IsExtraordinary of the items are used.
+func (s *Service) BatchCreate(ctx context.Context, tenantID, userID string, items []domain.Transaction) ([]BatchResult, error) {
+	if len(items) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if len(items) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	var results []BatchResult
+	err := s.withinTx(ctx, func(ctx context.Context) error {
+		results = make([]BatchResult, 0, len(items))
+		for _, it := range items {
+			var created domain.Transaction
+			var err error
+			switch {
+			case !isUUID(it.CategoryID):
+				err = ErrInvalidCategory
+			case it.Amount.CurrencyCode == "":
+				err = ErrInvalidAmount
+			default:
+				created, err = s.CreateForUser(ctx, tenantID, userID, it.Type, it.CategoryID, it.Amount, it.OccurredAt, it.Comment, it.IsExtraordinary)
+			}
+			if err != nil {
+				if !isBatchItemError(err) {
+					return err
+				}
+				results = append(results, BatchResult{Err: err})
+				continue
+			}
+			results = append(results, BatchResult{ID: created.ID, Transaction: created})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// batchTargets resolves the selector to ids (deduplicated, in order) and loads the tenant's
+// transactions among them. Malformed ids are kept and end up as not found.
+func (s *Service) batchTargets(ctx context.Context, tenantID string, sel BatchSelector) ([]string, map[string]domain.Transaction, error) {
+	var ids []string
+	switch {
+	case len(sel.IDs) > 0 && sel.Filter != nil, len(sel.IDs) == 0 && sel.Filter == nil:
+		return nil, nil, ErrInvalidBatch
+	case sel.Filter != nil:
+		var err error
+		if ids, err = s.txs.ListIDs(ctx, tenantID, *sel.Filter, MaxBatchSize+1); err != nil {
+			return nil, nil, err
+		}
+	default:
+		seen := make(map[string]bool, len(sel.IDs))
+		for _, id := range sel.IDs {
+			if id != "" && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) > MaxBatchSize {
+		return nil, nil, ErrBatchTooLarge
+	}
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if isUUID(id) {
+			valid = append(valid, id)
+		}
+	}
+	current, err := s.txs.GetMany(ctx, tenantID, valid)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ids, current, nil
+}
+
+func (s *Service) withinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.WithinTx(ctx, fn)
+}
+
+func (p BatchPatch) apply(tx *domain.Transaction) {
+	if p.CategoryID != nil {
+		tx.CategoryID = *p.CategoryID
+	}
+	if p.Comment != nil {
+		tx.Comment = *p.Comment
+	}
+	if p.IsExtraordinary != nil {
+		tx.IsExtraordinary = *p.IsExtraordinary
+	}
+}
+
+// isBatchItemError tells validation failures of a single item from failures of the whole batch.
+func isBatchItemError(err error) bool {
+	for _, target := range []error{ErrTransactionNotFound, ErrInvalidAmount, ErrInvalidCategory, ErrTypeMismatch, ErrFxRateNotFound, ErrCurrencyNotAllowed} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUUID guards queries against malformed ids, which would abort the surrounding DB transaction.
+func isUUID(s string) bool {
+	_, err := uuid.Parse(s)
+	return err == nil
+}
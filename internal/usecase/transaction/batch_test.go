@@ -0,0 +1,199 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+const (
+	batchCatFood   = "00000000-0000-0000-0000-0000000000f1"
+	batchCatCafe   = "00000000-0000-0000-0000-0000000000f2"
+	batchCatSalary = "00000000-0000-0000-0000-0000000000a1"
+	batchTx1       = "00000000-0000-0000-0000-000000000001"
+	batchTx2       = "00000000-0000-0000-0000-000000000002"
+	batchTxForeign = "00000000-0000-0000-0000-000000000009"
+)
+
+type batchCatRepo struct{}
+
+func (batchCatRepo) Get(ctx context.Context, id string) (domain.Category, error) {
+	switch id {
+	case batchCatFood, batchCatCafe:
+		return domain.Category{ID: id, TenantID: "t1", Kind: domain.CategoryKindExpense}, nil
+	case batchCatSalary:
+		return domain.Category{ID: id, TenantID: "t1", Kind: domain.CategoryKindIncome}, nil
+	}
+	return domain.Category{}, errors.New("not found")
+}
+
+// batchTxRepo keeps transactions in memory
+type batchTxRepo struct {
+	noopTxRepo
+	txs     map[string]domain.Transaction
+	deleted []string
+	filter  *ListFilter
+	failOn  string
+}
+
+func newBatchTxRepo() *batchTxRepo {
+	mk := func(id, tenant string) domain.Transaction {
+		return domain.Transaction{ID: id, TenantID: tenant, CategoryID: batchCatFood, Type: domain.TransactionTypeExpense,
+			Amount: domain.Money{CurrencyCode: "USD", MinorUnits: 100}, OccurredAt: time.Now()}
+	}
+	return &batchTxRepo{txs: map[string]domain.Transaction{
+		batchTx1:       mk(batchTx1, "t1"),
+		batchTx2:       mk(batchTx2, "t1"),
+		batchTxForeign: mk(batchTxForeign, "t2"),
+	}}
+}
+
+func (r *batchTxRepo) Create(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
+	tx.ID = "new-" + tx.Comment
+	r.txs[tx.ID] = tx
+	return tx, nil
+}
+
+func (r *batchTxRepo) Update(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
+	if tx.ID == r.failOn {
+		return domain.Transaction{}, errors.New("db down")
+	}
+	r.txs[tx.ID] = tx
+	return tx, nil
+}
+
+func (r *batchTxRepo) Delete(ctx context.Context, id string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *batchTxRepo) GetMany(ctx context.Context, tenantID string, ids []string) (map[string]domain.Transaction, error) {
+	out := map[string]domain.Transaction{}
+	for _, id := range ids {
+		if tx, ok := r.txs[id]; ok && tx.TenantID == tenantID {
+			out[id] = tx
+		}
+	}
+	return out, nil
+}
+
+func (r *batchTxRepo) ListIDs(ctx context.Context, tenantID string, filter ListFilter, limit int) ([]string, error) {
+	r.filter = &filter
+	return []string{batchTx2, batchTx1}, nil
+}
+
+func newBatchService(repo *batchTxRepo) (*Service, *txRunnerStub) {
+	runner := &txRunnerStub{}
+	svc := NewService(repo, stubFxRepo{rate: "1", provider: "p"}, stubTenantRepo{defCcy: "USD"}, batchCatRepo{})
+	svc.SetTxRunner(runner)
+	return svc, runner
+}
+
+func TestService_BatchUpdate_ByIDs(t *testing.T) {
+	repo := newBatchTxRepo()
+	svc, runner := newBatchService(repo)
+	cafe, comment := batchCatCafe, "moved"
+
+	results, err := svc.BatchUpdate(context.Background(), "t1",
+		BatchSelector{IDs: []string{batchTx1, "not-a-uuid", batchTxForeign, batchTx1, batchTx2}},
+		BatchPatch{CategoryID: &cafe, Comment: &comment})
+	if err != nil {
+		t.Fatalf("batch update: %v", err)
+	}
+	if runner.calls != 1 {
+		t.Fatalf("expected one DB transaction, got %d", runner.calls)
+	}
+	if len(results) != 4 {
+		t.Fatalf("duplicates must be collapsed: %+v", results)
+	}
+	for i, want := range []error{nil, ErrTransactionNotFound, ErrTransactionNotFound, nil} {
+		if !errors.Is(results[i].Err, want) {
+			t.Fatalf("result %d: want %v, got %v", i, want, results[i].Err)
+		}
+	}
+	if got := repo.txs[batchTx2]; got.CategoryID != batchCatCafe || got.Comment != "moved" {
+		t.Fatalf("patch not applied: %#v", got)
+	}
+	if repo.txs[batchTxForeign].Comment != "" {
+		t.Fatal("transaction of another tenant must not change")
+	}
+}
+
+func TestService_BatchUpdate_ItemValidation(t *testing.T) {
+	repo := newBatchTxRepo()
+	svc, _ := newBatchService(repo)
+	salary := batchCatSalary
+
+	results, err := svc.BatchUpdate(context.Background(), "t1", BatchSelector{Filter: &ListFilter{}}, BatchPatch{CategoryID: &salary})
+	if err != nil {
+		t.Fatalf("batch update: %v", err)
+	}
+	if repo.filter == nil || len(results) != 2 || results[0].ID != batchTx2 {
+		t.Fatalf("filter selection not used: %+v", results)
+	}
+	for _, r := range results {
+		if !errors.Is(r.Err, ErrTypeMismatch) {
+			t.Fatalf("expected type mismatch per item, got %v", r.Err)
+		}
+	}
+
+	bad := "nope"
+	if _, err := svc.BatchUpdate(context.Background(), "t1", BatchSelector{IDs: []string{batchTx1}}, BatchPatch{CategoryID: &bad}); !errors.Is(err, ErrInvalidCategory) {
+		t.Fatalf("expected ErrInvalidCategory, got %v", err)
+	}
+	if _, err := svc.BatchUpdate(context.Background(), "t1", BatchSelector{}, BatchPatch{}); !errors.Is(err, ErrInvalidBatch) {
+		t.Fatalf("expected ErrInvalidBatch, got %v", err)
+	}
+	if _, err := svc.BatchUpdate(context.Background(), "t1", BatchSelector{IDs: []string{batchTx1}, Filter: &ListFilter{}}, BatchPatch{}); !errors.Is(err, ErrInvalidBatch) {
+		t.Fatalf("ids and filter together: expected ErrInvalidBatch, got %v", err)
+	}
+}
+
+func TestService_BatchUpdate_FailureAbortsBatch(t *testing.T) {
+	repo := newBatchTxRepo()
+	repo.failOn = batchTx2
+	svc, _ := newBatchService(repo)
+	comment := "x"
+	if _, err := svc.BatchUpdate(context.Background(), "t1", BatchSelector{IDs: []string{batchTx1, batchTx2}}, BatchPatch{Comment: &comment}); err == nil {
+		t.Fatal("expected the repository error to fail the whole batch")
+	}
+}
+
+func TestService_BatchDeleteAndCreate(t *testing.T) {
+	repo := newBatchTxRepo()
+	svc, _ := newBatchService(repo)
+	ctx := context.Background()
+
+	results, err := svc.BatchDelete(ctx, "t1", BatchSelector{IDs: []string{batchTx1, batchTxForeign}})
+	if err != nil || len(results) != 2 || results[0].Err != nil || !errors.Is(results[1].Err, ErrTransactionNotFound) {
+		t.Fatalf("batch delete: %v %+v", err, results)
+	}
+	if len(repo.deleted) != 1 || repo.deleted[0] != batchTx1 {
+		t.Fatalf("unexpected deletions: %v", repo.deleted)
+	}
+
+	usd := domain.Money{CurrencyCode: "USD", MinorUnits: 500}
+	results, err = svc.BatchCreate(ctx, "t1", "u1", []domain.Transaction{
+		{Type: domain.TransactionTypeExpense, CategoryID: batchCatFood, Amount: usd, Comment: "ok"},
+		{Type: domain.TransactionTypeIncome, CategoryID: batchCatFood, Amount: usd},
+		{Type: domain.TransactionTypeExpense, CategoryID: "", Amount: usd},
+		{Type: domain.TransactionTypeExpense, CategoryID: batchCatFood},
+	})
+	if err != nil || len(results) != 4 {
+		t.Fatalf("batch create: %v %+v", err, results)
+	}
+	if results[0].Err != nil || results[0].Transaction.UserID != "u1" {
+		t.Fatalf("first item should be created: %+v", results[0])
+	}
+	for i, want := range []error{ErrTypeMismatch, ErrInvalidCategory, ErrInvalidAmount} {
+		if !errors.Is(results[i+1].Err, want) {
+			t.Fatalf("item %d: want %v, got %v", i+1, want, results[i+1].Err)
+		}
+	}
+	if _, err := svc.BatchCreate(ctx, "t1", "u1", nil); !errors.Is(err, ErrEmptyBatch) {
+		t.Fatalf("expected ErrEmptyBatch, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package transaction
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Cursor is a keyset position in a transaction listing: the sort column value and id of
+// the last row returned. Clients only see it as an opaque page token.
+type Cursor struct {
+	Sort string `json:"s"` // normalized sort the token was issued for, e.g. "occurred_at desc"
+	Key  string `json:"k"` // sort column value of the last row, as text
+	ID   string `json:"i"`
+	// Filter is a fingerprint of the filter: a token can't be replayed against another query
+	Filter string `json:"f"`
+}
+
+// Page is one page of a listing.
+type Page struct {
+	Items []domain.Transaction
+	// Total is the number of matching rows; -1 when the count was skipped
+	Total int64
+	// NextPageToken is empty on the last page
+	NextPageToken string
+}
+
+// ListPage returns one page of transactions. Without a page token it starts at
+// filter.Page (offset pagination, kept for compatibility); with one it continues right after
+// the row the token points to, so concurrent inserts don't shift the pages.
+func (s *Service) ListPage(ctx context.Context, tenantID string, filter ListFilter, pageToken string) (Page, error) {
+	sort := NormalizeSort(filter.Sort)
+	fp := filterFingerprint(filter)
+	if pageToken != "" {
+		c, err := DecodePageToken(pageToken)
+		if err != nil || c.Sort != sort || c.Filter != fp {
+			return Page{}, ErrInvalidPageToken
+		}
+		filter.After = &c
+	}
+	items, next, total, err := s.txs.ListPage(ctx, tenantID, filter)
+	if err != nil {
+		return Page{}, err
+	}
+	p := Page{Items: items, Total: total}
+	if next != nil {
+		next.Sort = sort
+		next.Filter = fp
+		p.NextPageToken = EncodePageToken(*next)
+	}
+	return p, nil
+}
+
+// NormalizeSort lower-cases a sort spec and collapses whitespace ("Amount_Numeric  DESC" → "amount_numeric desc").
+func NormalizeSort(sort string) string {
+	return strings.Join(strings.Fields(strings.ToLower(sort)), " ")
+}
+
+func EncodePageToken(c Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodePageToken(token string) (Cursor, error) {
+	var c Cursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return Cursor{}, ErrInvalidPageToken
+	}
+	return c, nil
+}
+
+// filterFingerprint hashes the fields that select rows; paging fields are left out.
+func filterFingerprint(f ListFilter) string {
+	h := fnv.New64a()
+	if f.From != nil {
+		fmt.Fprintf(h, "from=%d;", f.From.UnixNano())
+	}
+	if f.To != nil {
+		fmt.Fprintf(h, "to=%d;", f.To.UnixNano())
+	}
+	if f.CreatedFrom != nil {
+		fmt.Fprintf(h, "created=%d;", f.CreatedFrom.UnixNano())
+	}
+	fmt.Fprintf(h, "cats=%s;", strings.Join(f.CategoryIDs, ","))
+	if f.Type != nil {
+		fmt.Fprintf(h, "type=%s;", *f.Type)
+	}
+	if f.MinMinorUnits != nil {
+		fmt.Fprintf(h, "min=%d;", *f.MinMinorUnits)
+	}
+	if f.MaxMinorUnits != nil {
+		fmt.Fprintf(h, "max=%d;", *f.MaxMinorUnits)
+	}
+	if f.CurrencyCode != nil {
+		fmt.Fprintf(h, "ccy=%s;", *f.CurrencyCode)
+	}
+	if f.Search != nil {
+		fmt.Fprintf(h, "q=%s;", *f.Search)
+	}
+	fmt.Fprintf(h, "terms=%q;", f.SearchTerms)
+	fmt.Fprintf(h, "catnames=%q;", f.CategoryNames)
+	fmt.Fprintf(h, "noextra=%t;", f.ExcludeExtraordinary)
+	fmt.Fprintf(h, "onlyextra=%t", f.OnlyExtraordinary)
+	return fmt.Sprintf("%x", h.Sum64())
+}
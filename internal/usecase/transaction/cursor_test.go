@@ -0,0 +1,68 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+type pageTxRepo struct {
+	noopTxRepo
+	filter ListFilter
+	next   *Cursor
+}
+
+func (r *pageTxRepo) ListPage(ctx context.Context, tenantID string, filter ListFilter) ([]domain.Transaction, *Cursor, int64, error) {
+	r.filter = filter
+	return []domain.Transaction{{ID: "a"}, {ID: "b"}}, r.next, -1, nil
+}
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	c := Cursor{Sort: "occurred_at desc", Key: "2024-01-02 10:00:00+00", ID: "b", Filter: "f"}
+	got, err := DecodePageToken(EncodePageToken(c))
+	if err != nil || got != c {
+		t.Fatalf("round trip: %v %#v", err, got)
+	}
+	for _, bad := range []string{"!!!", "e30", "bm90IGpzb24"} { // invalid base64, "{}" (no id), "not json"
+		if _, err := DecodePageToken(bad); err != ErrInvalidPageToken {
+			t.Fatalf("%q: expected ErrInvalidPageToken, got %v", bad, err)
+		}
+	}
+}
+
+func TestService_ListPage_Cursor(t *testing.T) {
+	repo := &pageTxRepo{next: &Cursor{Key: "100", ID: "b"}}
+	svc := NewService(repo, stubFxRepo{}, stubTenantRepo{defCcy: "RUB"}, stubCategoryRepo{})
+	ctx := context.Background()
+	search := "coffee"
+	filter := ListFilter{Search: &search, Sort: "Amount_Numeric  DESC", PageSize: 2, SkipCount: true}
+
+	first, err := svc.ListPage(ctx, "t1", filter, "")
+	if err != nil || first.NextPageToken == "" || first.Total != -1 || repo.filter.After != nil {
+		t.Fatalf("first page: %v %#v", err, first)
+	}
+	c, _ := DecodePageToken(first.NextPageToken)
+	if c.Sort != "amount_numeric desc" || c.Key != "100" || c.ID != "b" {
+		t.Fatalf("unexpected cursor: %#v", c)
+	}
+
+	repo.next = nil
+	filter.PageSize = 10 // page size may change between pages
+	second, err := svc.ListPage(ctx, "t1", filter, first.NextPageToken)
+	if err != nil || second.NextPageToken != "" || repo.filter.After == nil || repo.filter.After.ID != "b" {
+		t.Fatalf("second page: %v %#v %#v", err, second, repo.filter.After)
+	}
+
+	other := "tea"
+	changed := filter
+	changed.Search = &other
+	if _, err := svc.ListPage(ctx, "t1", changed, first.NextPageToken); err != ErrInvalidPageToken {
+		t.Fatalf("token reused with another filter: %v", err)
+	}
+	resorted := filter
+	resorted.Sort = "occurred_at desc"
+	if _, err := svc.ListPage(ctx, "t1", resorted, first.NextPageToken); err != ErrInvalidPageToken {
+		t.Fatalf("token reused with another sort: %v", err)
+	}
+}
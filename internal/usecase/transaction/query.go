@@ -0,0 +1,152 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+var ErrInvalidQuery = errors.New("invalid search query")
+
+const queryDateLayout = "2006-01-02"
+
+// ApplyQuery narrows the filter with a search query such as
+//
+//	category:food amount>1000 before:2026-01-01 "coffee shop"
+//
+// Supported keys: category (code or localized name), type (income/expense), currency,
+// amount (with :, =, >, >=, <, <= and major units of the currency), before, after and on
+// (dates, YYYY-MM-DD, UTC). Everything else, including quoted phrases, is free text that
+// must match the comment or the category name. Keys in the query replace the same fields
+// of the filter.
+func ApplyQuery(f *ListFilter, q string) error {
+	type amountCond struct{ op, value string }
+	var amounts []amountCond
+	for _, tok := range splitQuery(q) {
+		key, op, value, ok := queryKey(tok)
+		if !ok {
+			if term := strings.ReplaceAll(tok, `"`, ""); strings.TrimSpace(term) != "" {
+				f.SearchTerms = append(f.SearchTerms, term)
+			}
+			continue
+		}
+		if op != ":" && key != "amount" {
+			return fmt.Errorf("%w: %s supports only %s:", ErrInvalidQuery, key, key)
+		}
+		if value == "" {
+			return fmt.Errorf("%w: empty value for %s", ErrInvalidQuery, key)
+		}
+		switch key {
+		case "category":
+			f.CategoryNames = append(f.CategoryNames, value)
+		case "type":
+			var tt domain.TransactionType
+			switch strings.ToLower(value) {
+			case "income", "доход":
+				tt = domain.TransactionTypeIncome
+			case "expense", "расход":
+				tt = domain.TransactionTypeExpense
+			default:
+				return fmt.Errorf("%w: unknown type %q", ErrInvalidQuery, value)
+			}
+			f.Type = &tt
+		case "currency":
+			code := strings.ToUpper(value)
+			if !domain.IsCurrencyCode(code) {
+				return fmt.Errorf("%w: unknown currency %q", ErrInvalidQuery, value)
+			}
+			f.CurrencyCode = &code
+		case "amount":
+			amounts = append(amounts, amountCond{op, value})
+		case "before", "after", "on":
+			d, err := time.Parse(queryDateLayout, value)
+			if err != nil {
+				return fmt.Errorf("%w: %s expects a date like 2026-01-31, got %q", ErrInvalidQuery, key, value)
+			}
+			next := d.AddDate(0, 0, 1)
+			switch key {
+			case "before":
+				f.To = &d
+			case "after":
+				f.From = &next
+			default:
+				f.From, f.To = &d, &next
+			}
+		}
+	}
+	// amounts are parsed last: they are in units of the currency, wherever it appears in the query
+	var currency string
+	if f.CurrencyCode != nil {
+		currency = *f.CurrencyCode
+	}
+	for _, a := range amounts {
+		v, err := domain.DecimalToMinor(a.value, currency)
+		if err != nil || v < 0 {
+			return fmt.Errorf("%w: bad amount %q", ErrInvalidQuery, a.value)
+		}
+		lo, hi := v, v
+		switch a.op {
+		case ">":
+			lo = v + 1
+		case "<":
+			hi = v - 1
+		}
+		if a.op != "<" && a.op != "<=" {
+			f.MinMinorUnits = &lo
+		}
+		if a.op != ">" && a.op != ">=" {
+			f.MaxMinorUnits = &hi
+		}
+	}
+	return nil
+}
+
+// splitQuery splits on whitespace outside double quotes; quotes are kept in the tokens.
+func splitQuery(q string) []string {
+	var out []string
+	var b strings.Builder
+	quoted := false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			b.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if b.Len() > 0 {
+				out = append(out, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		out = append(out, b.String())
+	}
+	return out
+}
+
+var queryKeys = []string{"category", "type", "currency", "amount", "before", "after", "on"}
+
+// queryKey recognizes "key<op>value" tokens; the value may be quoted (category:"eating out").
+func queryKey(tok string) (key, op, value string, ok bool) {
+	lower := strings.ToLower(tok)
+	for _, k := range queryKeys {
+		if !strings.HasPrefix(lower, k) {
+			continue
+		}
+		rest := tok[len(k):]
+		for _, o := range []string{">=", "<=", ":", "=", ">", "<"} {
+			if strings.HasPrefix(rest, o) {
+				if o == "=" {
+					o = ":"
+				}
+				return k, o, strings.Trim(rest[len(o):], `"`), true
+			}
+		}
+	}
+	return "", "", "", false
+}
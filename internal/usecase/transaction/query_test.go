@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+func TestApplyQuery(t *testing.T) {
+	var f ListFilter
+	q := `category:food category:"eating out" amount>1000 before:2026-01-01 "coffee shop" latte type:expense`
+	if err := ApplyQuery(&f, q); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if !reflect.DeepEqual(f.CategoryNames, []string{"food", "eating out"}) {
+		t.Fatalf("categories: %#v", f.CategoryNames)
+	}
+	if !reflect.DeepEqual(f.SearchTerms, []string{"coffee shop", "latte"}) {
+		t.Fatalf("terms: %#v", f.SearchTerms)
+	}
+	if f.MinMinorUnits == nil || *f.MinMinorUnits != 100001 || f.MaxMinorUnits != nil {
+		t.Fatalf("amount>1000 must be a strict lower bound: %v %v", f.MinMinorUnits, f.MaxMinorUnits)
+	}
+	if f.To == nil || !f.To.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || f.From != nil {
+		t.Fatalf("before: %v %v", f.From, f.To)
+	}
+	if f.Type == nil || *f.Type != domain.TransactionTypeExpense {
+		t.Fatalf("type: %v", f.Type)
+	}
+}
+
+func TestApplyQuery_AmountInQueryCurrency(t *testing.T) {
+	var f ListFilter
+	if err := ApplyQuery(&f, "amount<=500 currency:jpy on:2025-03-08"); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if f.CurrencyCode == nil || *f.CurrencyCode != "JPY" || f.MaxMinorUnits == nil || *f.MaxMinorUnits != 500 || f.MinMinorUnits != nil {
+		t.Fatalf("JPY has no minor units: %v %v", f.CurrencyCode, f.MaxMinorUnits)
+	}
+	if !f.From.Equal(time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)) || !f.To.Equal(time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("on: %v %v", f.From, f.To)
+	}
+	f = ListFilter{}
+	if err := ApplyQuery(&f, "amount:12.5 after:2025-12-31"); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if *f.MinMinorUnits != 1250 || *f.MaxMinorUnits != 1250 || !f.From.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("exact amount / after: %v %v %v", *f.MinMinorUnits, *f.MaxMinorUnits, f.From)
+	}
+}
+
+func TestApplyQuery_Errors(t *testing.T) {
+	for _, q := range []string{"amount>abc", "amount>-5", "before:yesterday", "type:transfer", "currency:XYZ", "category:", "before>2026-01-01"} {
+		var f ListFilter
+		if err := ApplyQuery(&f, q); !errors.Is(err, ErrInvalidQuery) {
+			t.Fatalf("%q: expected ErrInvalidQuery, got %v", q, err)
+		}
+	}
+	// words that merely start with a key stay free text
+	var f ListFilter
+	if err := ApplyQuery(&f, "online typeface 10:30"); err != nil || len(f.SearchTerms) != 3 {
+		t.Fatalf("plain words: %v %#v", err, f.SearchTerms)
+	}
+}
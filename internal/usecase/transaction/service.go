@@ -6,12 +6,17 @@ import (
 	"time"
 
 	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
+	"github.com/positron48/budget/internal/usecase/webhook"
 )
 
 var (
 	ErrFxRateNotFound  = errors.New("fx rate not found")
 	ErrInvalidCategory = errors.New("invalid category")
 	ErrTypeMismatch    = errors.New("transaction type does not match category kind")
+	ErrCategoryDeleted = errors.New("category of the transaction is deleted")
+	// ErrCurrencyNotAllowed: the tenant pinned its currencies and the code is not among them
+	ErrCurrencyNotAllowed = errors.New("currency is not enabled for the tenant")
 )
 
 type TxRepo interface {
@@ -20,8 +25,21 @@ type TxRepo interface {
 	Delete(ctx context.Context, id string) error
 	Get(ctx context.Context, id string) (domain.Transaction, error)
 	List(ctx context.Context, tenantID string, filter ListFilter) ([]domain.Transaction, int64, error)
+	// ListPage returns a page ordered by the sort column and id, starting after filter.After when set,
+	// and the cursor of the last row when more rows follow; total is -1 with filter.SkipCount
+	ListPage(ctx context.Context, tenantID string, filter ListFilter) (items []domain.Transaction, next *Cursor, total int64, err error)
 	Totals(ctx context.Context, tenantID string, filter ListFilter) (totalIncomeMinor int64, totalExpenseMinor int64, baseCurrency string, err error)
 	GetDateRange(ctx context.Context, tenantID string) (earliest, latest time.Time, err error)
+	// LastCreatedAt returns when the latest live transaction of the tenant was recorded, zero without any
+	LastCreatedAt(ctx context.Context, tenantID string) (time.Time, error)
+	// GetMany returns live transactions of the tenant by id; unknown ids are skipped
+	GetMany(ctx context.Context, tenantID string, ids []string) (map[string]domain.Transaction, error)
+	// ListIDs returns at most limit ids of live transactions matching the filter
+	ListIDs(ctx context.Context, tenantID string, filter ListFilter, limit int) ([]string, error)
+	// Trash: Delete only marks a transaction deleted, these work with the marked ones
+	ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error)
+	Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
 
 type FxRepo interface {
@@ -39,29 +57,46 @@ type CategoryRepo interface {
 type ListFilter struct {
 	From                 *time.Time
 	To                   *time.Time
+	CreatedFrom          *time.Time // recorded at or after, whatever the occurred_at
 	CategoryIDs          []string
 	Type                 *domain.TransactionType
 	MinMinorUnits        *int64
 	MaxMinorUnits        *int64
 	CurrencyCode         *string
 	Search               *string
+	SearchTerms          []string // all must match, each the comment or a localized category name
+	CategoryNames        []string // category codes or localized names, case-insensitive
 	ExcludeExtraordinary bool
+	OnlyExtraordinary    bool
 	Page                 int
 	PageSize             int
 	Sort                 string // e.g. "occurred_at desc", "amount_numeric asc", "comment asc"
+	// After continues a keyset listing right after this row (ListPage only; Page is ignored then)
+	After *Cursor
+	// SkipCount skips the COUNT(*) query of ListPage
+	SkipCount bool
 }
 
 type Service struct {
-	txs     TxRepo
-	fx      FxRepo
-	tenants TenantRepo
-	cats    CategoryRepo
+	txs      TxRepo
+	fx       FxRepo
+	tenants  TenantRepo
+	cats     CategoryRepo
+	audit    *audit.Log
+	webhooks *webhook.Outbox
+	tx       TxRunner
 }
 
 func NewService(txs TxRepo, fx FxRepo, tenants TenantRepo, cats CategoryRepo) *Service {
 	return &Service{txs: txs, fx: fx, tenants: tenants, cats: cats}
 }
 
+// SetAuditLog records every change of a transaction in the tenant audit log.
+func (s *Service) SetAuditLog(l *audit.Log) { s.audit = l }
+
+// SetWebhooks queues a webhook event for every change of a transaction.
+func (s *Service) SetWebhooks(o *webhook.Outbox) { s.webhooks = o }
+
 // ComputeBaseAmount converts original amount to tenant base currency on occurred date
 func (s *Service) ComputeBaseAmount(ctx context.Context, tenantID string, amount domain.Money, occurredAt time.Time) (base domain.Money, fx *domain.FxInfo, err error) {
 	tenant, err := s.tenants.GetByID(ctx, tenantID)
@@ -83,7 +118,21 @@ func (s *Service) ComputeBaseAmount(ctx context.Context, tenantID string, amount
 
 // CRUD and query operations delegate to repository layer
 func (s *Service) Create(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
-	return s.txs.Create(ctx, tx)
+	var created domain.Transaction
+	err := s.audit.Run(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.txs.Create(ctx, tx); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, created.TenantID, domain.AuditEntityTransaction, created.ID, domain.AuditActionCreated, nil, created); err != nil {
+			return err
+		}
+		return s.webhooks.PublishTransaction(ctx, domain.WebhookEventTransactionCreated, created)
+	})
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	return created, nil
 }
 
 func (s *Service) Update(ctx context.Context, tx domain.Transaction) (domain.Transaction, error) {
@@ -102,11 +151,57 @@ func (s *Service) Update(ctx context.Context, tx domain.Transaction) (domain.Tra
 	}
 	tx.BaseAmount = base
 	tx.Fx = fx
-	return s.txs.Update(ctx, tx)
+	var updated domain.Transaction
+	err = s.audit.Run(ctx, func(ctx context.Context) error {
+		before, err := s.auditSnapshot(ctx, tx.ID)
+		if err != nil {
+			return err
+		}
+		if updated, err = s.txs.Update(ctx, tx); err != nil {
+			return err
+		}
+		if before != nil {
+			if err := s.audit.Record(ctx, updated.TenantID, domain.AuditEntityTransaction, updated.ID, domain.AuditActionUpdated, *before, updated); err != nil {
+				return err
+			}
+		}
+		return s.webhooks.PublishTransaction(ctx, domain.WebhookEventTransactionUpdated, updated)
+	})
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	return updated, nil
 }
 
 func (s *Service) Delete(ctx context.Context, id string) error {
-	return s.txs.Delete(ctx, id)
+	return s.audit.Run(ctx, func(ctx context.Context) error {
+		before, err := s.auditSnapshot(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.txs.Delete(ctx, id); err != nil {
+			return err
+		}
+		if before == nil {
+			return nil
+		}
+		if err := s.audit.Record(ctx, before.TenantID, domain.AuditEntityTransaction, id, domain.AuditActionDeleted, *before, nil); err != nil {
+			return err
+		}
+		return s.webhooks.PublishTransaction(ctx, domain.WebhookEventTransactionDeleted, *before)
+	})
+}
+
+// auditSnapshot loads the state before a change; without an audit log and webhooks nothing is loaded.
+func (s *Service) auditSnapshot(ctx context.Context, id string) (*domain.Transaction, error) {
+	if s.audit == nil && s.webhooks == nil {
+		return nil, nil
+	}
+	tx, err := s.txs.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
 }
 
 func (s *Service) Get(ctx context.Context, id string) (domain.Transaction, error) {
@@ -144,6 +239,13 @@ func (s *Service) CreateForUser(ctx context.Context, tenantID, userID string, tx
 	if (cat.Kind == domain.CategoryKindIncome && txType != domain.TransactionTypeIncome) || (cat.Kind == domain.CategoryKindExpense && txType != domain.TransactionTypeExpense) {
 		return domain.Transaction{}, ErrTypeMismatch
 	}
+	tenant, err := s.tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	if !tenant.AllowsCurrency(amount.CurrencyCode) {
+		return domain.Transaction{}, ErrCurrencyNotAllowed
+	}
 	base, fx, err := s.ComputeBaseAmount(ctx, tenantID, amount, occurredAt)
 	if err != nil {
 		return domain.Transaction{}, err
@@ -160,10 +262,16 @@ func (s *Service) CreateForUser(ctx context.Context, tenantID, userID string, tx
 		Comment:         comment,
 		IsExtraordinary: isExtraordinary,
 	}
-	return s.txs.Create(ctx, tx)
+	return s.Create(ctx, tx)
 }
 
 // GetDateRange returns the earliest and latest transaction dates for a tenant
 func (s *Service) GetDateRange(ctx context.Context, tenantID string) (earliest, latest time.Time, err error) {
 	return s.txs.GetDateRange(ctx, tenantID)
 }
+
+// LastCreatedAt returns when the tenant last recorded a transaction (zero if it never did),
+// whatever date the transaction itself carries
+func (s *Service) LastCreatedAt(ctx context.Context, tenantID string) (time.Time, error) {
+	return s.txs.LastCreatedAt(ctx, tenantID)
+}
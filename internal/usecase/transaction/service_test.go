@@ -18,10 +18,13 @@ func (s stubFxRepo) GetRateAsOf(ctx context.Context, from, to string, asOf time.
 	return s.rate, s.provider, s.err
 }
 
-type stubTenantRepo struct{ defCcy string }
+type stubTenantRepo struct {
+	defCcy string
+	pinned []string
+}
 
 func (s stubTenantRepo) GetByID(ctx context.Context, id string) (domain.Tenant, error) {
-	return domain.Tenant{ID: id, DefaultCurrencyCode: s.defCcy}, nil
+	return domain.Tenant{ID: id, DefaultCurrencyCode: s.defCcy, CurrencyCodes: s.pinned}, nil
 }
 
 type stubCategoryRepo struct{}
@@ -57,6 +60,32 @@ func (noopTxRepo) GetDateRange(ctx context.Context, tenantID string) (earliest,
 	return time.Time{}, time.Time{}, nil
 }
 
+func (noopTxRepo) LastCreatedAt(ctx context.Context, tenantID string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (noopTxRepo) ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error) {
+	return nil, 0, nil
+}
+
+func (noopTxRepo) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	return domain.Transaction{ID: id, TenantID: tenantID}, nil
+}
+
+func (noopTxRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) { return 0, nil }
+
+func (noopTxRepo) GetMany(ctx context.Context, tenantID string, ids []string) (map[string]domain.Transaction, error) {
+	return map[string]domain.Transaction{}, nil
+}
+
+func (noopTxRepo) ListIDs(ctx context.Context, tenantID string, filter ListFilter, limit int) ([]string, error) {
+	return nil, nil
+}
+
+func (noopTxRepo) ListPage(ctx context.Context, tenantID string, filter ListFilter) ([]domain.Transaction, *Cursor, int64, error) {
+	return nil, nil, 0, nil
+}
+
 func TestService_CreateForUser_ValidationsAndCompute(t *testing.T) {
 	svc := NewService(noopTxRepo{}, stubFxRepo{rate: "1.0000", provider: "test"}, stubTenantRepo{defCcy: "RUB"}, stubCategoryRepo{})
 	tx, err := svc.CreateForUser(context.Background(), "t1", "u1", domain.TransactionTypeExpense, "cat1", domain.Money{CurrencyCode: "USD", MinorUnits: 123}, time.Now(), "", false)
@@ -97,6 +126,35 @@ func (c *captureTxRepo) GetDateRange(ctx context.Context, tenantID string) (earl
 	return time.Time{}, time.Time{}, nil
 }
 
+func (c *captureTxRepo) LastCreatedAt(ctx context.Context, tenantID string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (c *captureTxRepo) ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error) {
+	return nil, 0, nil
+}
+
+func (c *captureTxRepo) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	return domain.Transaction{ID: id, TenantID: tenantID}, nil
+}
+
+func (c *captureTxRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (c *captureTxRepo) GetMany(ctx context.Context, tenantID string, ids []string) (map[string]domain.Transaction, error) {
+	return map[string]domain.Transaction{}, nil
+}
+
+func (c *captureTxRepo) ListIDs(ctx context.Context, tenantID string, filter ListFilter, limit int) ([]string, error) {
+	return nil, nil
+}
+
+func (c *captureTxRepo) ListPage(ctx context.Context, tenantID string, filter ListFilter) ([]domain.Transaction, *Cursor, int64, error) {
+	c.lastListedTenant = tenantID
+	return nil, nil, 0, nil
+}
+
 func TestService_Update_RecomputesBaseAndFx(t *testing.T) {
 	cap := &captureTxRepo{}
 	svc := NewService(cap, stubFxRepo{rate: "2.0000", provider: "prov"}, stubTenantRepo{defCcy: "EUR"}, stubCategoryRepo{})
@@ -191,6 +249,16 @@ func TestService_CreateForUser_TypeMismatch(t *testing.T) {
 	}
 }
 
+func TestService_CreateForUser_PinnedCurrencies(t *testing.T) {
+	svc := NewService(noopTxRepo{}, stubFxRepo{rate: "1.0000", provider: "test"}, stubTenantRepo{defCcy: "RUB", pinned: []string{"USD"}}, stubCategoryRepo{})
+	for code, want := range map[string]error{"RUB": nil, "USD": nil, "EUR": ErrCurrencyNotAllowed} {
+		_, err := svc.CreateForUser(context.Background(), "t1", "u1", domain.TransactionTypeExpense, "cat1", domain.Money{CurrencyCode: code, MinorUnits: 100}, time.Now(), "", false)
+		if err != want {
+			t.Fatalf("%s: got %v, want %v", code, err, want)
+		}
+	}
+}
+
 func TestComputeBaseAmount_SameCurrency(t *testing.T) {
 	svc := NewService(noopTxRepo{}, stubFxRepo{}, stubTenantRepo{defCcy: "USD"}, stubCategoryRepo{})
 	amount := domain.Money{CurrencyCode: "USD", MinorUnits: 12345}
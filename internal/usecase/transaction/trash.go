@@ -0,0 +1,38 @@
+package transaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// ListDeleted returns a page of the tenant's transactions in the trash.
+func (s *Service) ListDeleted(ctx context.Context, tenantID string, page, pageSize int) ([]domain.Transaction, int64, error) {
+	return s.txs.ListDeleted(ctx, tenantID, page, pageSize)
+}
+
+// Restore takes a transaction out of the trash. A transaction whose category is deleted
+// can't be restored (ErrCategoryDeleted) until the category is restored.
+func (s *Service) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	var restored domain.Transaction
+	err := s.audit.Run(ctx, func(ctx context.Context) error {
+		var err error
+		if restored, err = s.txs.Restore(ctx, tenantID, id); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, tenantID, domain.AuditEntityTransaction, id, domain.AuditActionRestored, nil, restored); err != nil {
+			return err
+		}
+		return s.webhooks.PublishTransaction(ctx, domain.WebhookEventTransactionRestored, restored)
+	})
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	return restored, nil
+}
+
+// PurgeDeleted permanently removes transactions that were deleted before the cutoff.
+func (s *Service) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return s.txs.PurgeDeleted(ctx, before)
+}
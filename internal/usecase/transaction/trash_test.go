@@ -0,0 +1,46 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/audit"
+)
+
+type trashTxRepo struct {
+	captureTxRepo
+	err error
+}
+
+func (r *trashTxRepo) Restore(ctx context.Context, tenantID, id string) (domain.Transaction, error) {
+	if r.err != nil {
+		return domain.Transaction{}, r.err
+	}
+	return domain.Transaction{ID: id, TenantID: tenantID}, nil
+}
+
+func TestService_Restore(t *testing.T) {
+	repo := &trashTxRepo{}
+	events := &auditRepoMem{}
+	svc := NewService(repo, stubFxRepo{}, stubTenantRepo{defCcy: "USD"}, stubCategoryRepo{})
+	svc.SetAuditLog(audit.NewLog(events, &txRunnerStub{}))
+	ctx := context.Background()
+
+	tx, err := svc.Restore(ctx, "t1", "tx1")
+	if err != nil || tx.ID != "tx1" {
+		t.Fatalf("restore: %v %#v", err, tx)
+	}
+	if len(events.events) != 1 || events.events[0].Action != domain.AuditActionRestored || events.events[0].TenantID != "t1" {
+		t.Fatalf("unexpected audit events: %+v", events.events)
+	}
+
+	repo.err = ErrCategoryDeleted
+	if _, err := svc.Restore(ctx, "t1", "tx2"); !errors.Is(err, ErrCategoryDeleted) {
+		t.Fatalf("expected ErrCategoryDeleted, got %v", err)
+	}
+	if len(events.events) != 1 {
+		t.Fatal("failed restore must not be audited")
+	}
+}
@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/usecase/webhook"
+)
+
+type outboxRepoMem struct{ events []webhook.Event }
+
+func (m *outboxRepoMem) Enqueue(ctx context.Context, tenantID, eventID, eventType string, payload []byte) (int, error) {
+	var ev webhook.Event
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return 0, err
+	}
+	m.events = append(m.events, ev)
+	return 1, nil
+}
+
+func TestService_Webhooks(t *testing.T) {
+	outbox := &outboxRepoMem{}
+	svc := NewService(&tenantTxRepo{}, stubFxRepo{rate: "1", provider: "p"}, stubTenantRepo{defCcy: "USD"}, stubCategoryRepo{})
+	svc.SetWebhooks(webhook.NewOutbox(outbox))
+	ctx := context.Background()
+
+	if _, err := svc.CreateForUser(ctx, "t1", "u1", domain.TransactionTypeExpense, "cat1", domain.Money{CurrencyCode: "USD", MinorUnits: 35000}, time.Now(), "rent", false); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := svc.Update(ctx, domain.Transaction{ID: "tx1", TenantID: "t1", CategoryID: "cat1", Type: domain.TransactionTypeExpense, Amount: domain.Money{CurrencyCode: "USD", MinorUnits: 5}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	// without an audit log the state before the delete is still loaded for the payload
+	if err := svc.Delete(ctx, "tx1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	want := []string{domain.WebhookEventTransactionCreated, domain.WebhookEventTransactionUpdated, domain.WebhookEventTransactionDeleted}
+	if len(outbox.events) != len(want) {
+		t.Fatalf("queued %d events, want %d", len(outbox.events), len(want))
+	}
+	for i, ev := range outbox.events {
+		if ev.Type != want[i] || ev.TenantID != "t1" || ev.ID == "" {
+			t.Fatalf("event %d: %+v", i, ev)
+		}
+	}
+	var data webhook.TransactionData
+	if err := json.Unmarshal(outbox.events[0].Data, &data); err != nil {
+		t.Fatalf("data: %v", err)
+	}
+	if data.Amount.Decimal != "350.00" || data.Comment != "rent" || data.Type != "expense" {
+		t.Fatalf("unexpected data %+v", data)
+	}
+	if data := string(outbox.events[2].Data); data == "" || data == "null" {
+		t.Fatalf("delete must carry the deleted transaction")
+	}
+}
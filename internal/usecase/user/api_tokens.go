@@ -0,0 +1,128 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	authuse "github.com/positron48/budget/internal/usecase/auth"
+)
+
+var (
+	ErrAPITokensDisabled   = errors.New("api tokens are not configured")
+	ErrAPITokenNotFound    = errors.New("api token not found")
+	ErrInvalidAPIToken     = errors.New("invalid or revoked api token")
+	ErrInvalidAPITokenSpec = errors.New("api token needs a name, a scope and a future expiry")
+)
+
+const (
+	maxAPITokenNameLen = 100
+	apiTokenPrefixLen  = len(domain.APITokenPrefix) + 6
+	// last_used_at is updated at most this often to avoid a write per request
+	apiTokenTouchInterval = time.Minute
+)
+
+type APITokenRepo interface {
+	// CreateAPIToken stores the hash of token and returns the stored record
+	CreateAPIToken(ctx context.Context, t domain.APIToken, token string) (domain.APIToken, error)
+	ListAPITokens(ctx context.Context, userID string) ([]domain.APIToken, error)
+	RevokeAPIToken(ctx context.Context, userID, id string) (bool, error)
+	// GetAPITokenByToken returns ErrInvalidAPIToken for unknown, revoked or expired tokens
+	GetAPITokenByToken(ctx context.Context, token string) (domain.APIToken, error)
+	TouchAPIToken(ctx context.Context, id string, at time.Time) error
+}
+
+// MembershipChecker reports whether a user belongs to a tenant.
+type MembershipChecker interface {
+	HasMembership(ctx context.Context, userID, tenantID string) (bool, error)
+}
+
+func (s *Service) SetAPITokens(repo APITokenRepo, members MembershipChecker) {
+	s.apiTokens = repo
+	s.members = members
+}
+
+// CreateAPIToken issues a personal access token for a tenant of the user. The token is
+// returned only here; later it can be listed and revoked but not read again.
+func (s *Service) CreateAPIToken(ctx context.Context, userID, tenantID, name string, scope domain.APITokenScope, expiresAt *time.Time) (domain.APIToken, string, error) {
+	if s.apiTokens == nil {
+		return domain.APIToken{}, "", ErrAPITokensDisabled
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxAPITokenNameLen || (scope != domain.APITokenScopeRead && scope != domain.APITokenScopeReadWrite) {
+		return domain.APIToken{}, "", ErrInvalidAPITokenSpec
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return domain.APIToken{}, "", ErrInvalidAPITokenSpec
+	}
+	if s.members != nil {
+		ok, err := s.members.HasMembership(ctx, userID, tenantID)
+		if err != nil {
+			return domain.APIToken{}, "", err
+		}
+		if !ok {
+			return domain.APIToken{}, "", authuse.ErrNotTenantMember
+		}
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return domain.APIToken{}, "", err
+	}
+	token := domain.APITokenPrefix + hex.EncodeToString(b)
+	t, err := s.apiTokens.CreateAPIToken(ctx, domain.APIToken{
+		UserID:    userID,
+		TenantID:  tenantID,
+		Name:      name,
+		Scope:     scope,
+		Prefix:    token[:apiTokenPrefixLen],
+		ExpiresAt: expiresAt,
+	}, token)
+	if err != nil {
+		return domain.APIToken{}, "", err
+	}
+	return t, token, nil
+}
+
+func (s *Service) ListAPITokens(ctx context.Context, userID string) ([]domain.APIToken, error) {
+	if s.apiTokens == nil {
+		return nil, ErrAPITokensDisabled
+	}
+	return s.apiTokens.ListAPITokens(ctx, userID)
+}
+
+func (s *Service) RevokeAPIToken(ctx context.Context, userID, id string) error {
+	if s.apiTokens == nil {
+		return ErrAPITokensDisabled
+	}
+	ok, err := s.apiTokens.RevokeAPIToken(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// AuthenticateAPIToken resolves a token presented as a bearer credential and records its use.
+func (s *Service) AuthenticateAPIToken(ctx context.Context, token string) (domain.APIToken, error) {
+	if s.apiTokens == nil {
+		return domain.APIToken{}, ErrInvalidAPIToken
+	}
+	t, err := s.apiTokens.GetAPITokenByToken(ctx, token)
+	if err != nil {
+		return domain.APIToken{}, err
+	}
+	now := time.Now()
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= apiTokenTouchInterval {
+		// best effort: a failed update must not fail the request
+		if s.apiTokens.TouchAPIToken(ctx, t.ID, now) == nil {
+			t.LastUsedAt = &now
+		}
+	}
+	return t, nil
+}
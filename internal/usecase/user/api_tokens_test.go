@@ -0,0 +1,117 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	authuse "github.com/positron48/budget/internal/usecase/auth"
+)
+
+type apiTokensMem struct {
+	byToken map[string]domain.APIToken
+	touched int
+}
+
+func (m *apiTokensMem) CreateAPIToken(ctx context.Context, t domain.APIToken, token string) (domain.APIToken, error) {
+	t.ID = "id" + string(rune('0'+len(m.byToken)))
+	t.CreatedAt = time.Now()
+	m.byToken[token] = t
+	return t, nil
+}
+
+func (m *apiTokensMem) ListAPITokens(ctx context.Context, userID string) ([]domain.APIToken, error) {
+	var out []domain.APIToken
+	for _, t := range m.byToken {
+		if t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *apiTokensMem) RevokeAPIToken(ctx context.Context, userID, id string) (bool, error) {
+	for k, t := range m.byToken {
+		if t.ID == id && t.UserID == userID {
+			delete(m.byToken, k)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *apiTokensMem) GetAPITokenByToken(ctx context.Context, token string) (domain.APIToken, error) {
+	t, ok := m.byToken[token]
+	if !ok {
+		return domain.APIToken{}, ErrInvalidAPIToken
+	}
+	return t, nil
+}
+
+func (m *apiTokensMem) TouchAPIToken(ctx context.Context, id string, at time.Time) error {
+	m.touched++
+	for k, t := range m.byToken {
+		if t.ID == id {
+			t.LastUsedAt = &at
+			m.byToken[k] = t
+		}
+	}
+	return nil
+}
+
+type membersStub map[string]bool // user|tenant
+
+func (m membersStub) HasMembership(ctx context.Context, userID, tenantID string) (bool, error) {
+	return m[userID+"|"+tenantID], nil
+}
+
+func TestAPITokens_CreateAuthenticateRevoke(t *testing.T) {
+	svc := NewService(repoStub{}, hasherOK{})
+	ctx := context.Background()
+	if _, _, err := svc.CreateAPIToken(ctx, "u1", "t1", "x", domain.APITokenScopeRead, nil); !errors.Is(err, ErrAPITokensDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+	repo := &apiTokensMem{byToken: map[string]domain.APIToken{}}
+	svc.SetAPITokens(repo, membersStub{"u1|t1": true})
+
+	if _, _, err := svc.CreateAPIToken(ctx, "u1", "t2", "ha", domain.APITokenScopeRead, nil); !errors.Is(err, authuse.ErrNotTenantMember) {
+		t.Fatalf("expected membership error, got %v", err)
+	}
+	past := time.Now().Add(-time.Hour)
+	if _, _, err := svc.CreateAPIToken(ctx, "u1", "t1", "ha", domain.APITokenScopeRead, &past); !errors.Is(err, ErrInvalidAPITokenSpec) {
+		t.Fatalf("expected invalid spec for past expiry, got %v", err)
+	}
+	if _, _, err := svc.CreateAPIToken(ctx, "u1", "t1", " ", domain.APITokenScopeRead, nil); !errors.Is(err, ErrInvalidAPITokenSpec) {
+		t.Fatalf("expected invalid spec for empty name, got %v", err)
+	}
+
+	created, token, err := svc.CreateAPIToken(ctx, "u1", "t1", "Home Assistant", domain.APITokenScopeRead, nil)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !domain.IsAPIToken(token) || !strings.HasPrefix(token, created.Prefix) || len(created.Prefix) >= len(token) {
+		t.Fatalf("unexpected token %q / prefix %q", token, created.Prefix)
+	}
+
+	got, err := svc.AuthenticateAPIToken(ctx, token)
+	if err != nil || got.UserID != "u1" || got.TenantID != "t1" || got.LastUsedAt == nil {
+		t.Fatalf("authenticate: %v %+v", err, got)
+	}
+	// last_used_at is not rewritten on every request
+	if _, err := svc.AuthenticateAPIToken(ctx, token); err != nil || repo.touched != 1 {
+		t.Fatalf("expected a single touch, got %d (%v)", repo.touched, err)
+	}
+
+	if err := svc.RevokeAPIToken(ctx, "u2", created.ID); !errors.Is(err, ErrAPITokenNotFound) {
+		t.Fatalf("must not revoke another user's token, got %v", err)
+	}
+	if err := svc.RevokeAPIToken(ctx, "u1", created.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, err := svc.AuthenticateAPIToken(ctx, token); !errors.Is(err, ErrInvalidAPIToken) {
+		t.Fatalf("revoked token must be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+var ErrLoginHistoryDisabled = errors.New("login history is not configured")
+
+const (
+	defaultLoginHistoryLimit = 20
+	maxLoginHistoryLimit     = 100
+)
+
+type LoginAttemptRepo interface {
+	// ListLoginAttempts returns the newest attempts first
+	ListLoginAttempts(ctx context.Context, userID string, limit int) ([]domain.LoginAttempt, error)
+}
+
+func (s *Service) SetLoginHistory(repo LoginAttemptRepo) { s.loginHistory = repo }
+
+// ListLoginAttempts returns recent sign-ins and failed attempts to the user's account.
+func (s *Service) ListLoginAttempts(ctx context.Context, userID string, limit int) ([]domain.LoginAttempt, error) {
+	if s.loginHistory == nil {
+		return nil, ErrLoginHistoryDisabled
+	}
+	if limit <= 0 {
+		limit = defaultLoginHistoryLimit
+	}
+	if limit > maxLoginHistoryLimit {
+		limit = maxLoginHistoryLimit
+	}
+	return s.loginHistory.ListLoginAttempts(ctx, userID, limit)
+}
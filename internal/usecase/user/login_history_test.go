@@ -0,0 +1,32 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+type loginHistoryMem struct{ gotLimit int }
+
+func (m *loginHistoryMem) ListLoginAttempts(ctx context.Context, userID string, limit int) ([]domain.LoginAttempt, error) {
+	m.gotLimit = limit
+	return []domain.LoginAttempt{{ID: "a1", UserID: userID, Status: domain.LoginAttemptSuccess}}, nil
+}
+
+func TestListLoginAttempts(t *testing.T) {
+	svc := NewService(repoStub{}, hasherOK{})
+	ctx := context.Background()
+	if _, err := svc.ListLoginAttempts(ctx, "u1", 0); !errors.Is(err, ErrLoginHistoryDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+	repo := &loginHistoryMem{}
+	svc.SetLoginHistory(repo)
+	for limit, want := range map[int]int{0: 20, 5: 5, 1000: 100} {
+		list, err := svc.ListLoginAttempts(ctx, "u1", limit)
+		if err != nil || len(list) != 1 || repo.gotLimit != want {
+			t.Fatalf("limit %d: %v %v got %d", limit, err, list, repo.gotLimit)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/positron48/budget/internal/domain"
 	authuse "github.com/positron48/budget/internal/usecase/auth"
@@ -19,8 +20,17 @@ type PasswordHasher interface {
 }
 
 type Service struct {
-	users  Repo
-	hasher PasswordHasher
+	users    Repo
+	hasher   PasswordHasher
+	sessions SessionRepo
+	// optional TOTP enrollment
+	twoFactor  TwoFactorRepo
+	totpIssuer string
+	// optional personal access tokens
+	apiTokens APITokenRepo
+	members   MembershipChecker
+	// optional sign-in security log
+	loginHistory LoginAttemptRepo
 }
 
 func NewService(users Repo, hasher PasswordHasher) *Service {
@@ -35,7 +45,10 @@ func (s *Service) UpdateProfile(ctx context.Context, userID, name, locale string
 	return s.users.UpdateProfile(ctx, userID, name, locale)
 }
 
-func (s *Service) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string, getCurrentHash func(ctx context.Context, userID string) (string, error)) error {
+// ChangePassword sets a new password and signs out all other sessions; the session of
+// keepRefreshToken (if any) stays signed in. Access tokens of the other sessions stay valid
+// until they expire, like after RevokeSession.
+func (s *Service) ChangePassword(ctx context.Context, userID, currentPassword, newPassword, keepRefreshToken string, getCurrentHash func(ctx context.Context, userID string) (string, error)) error {
 	// Support verifying current password using repo or external provider
 	currentHash, err := getCurrentHash(ctx, userID)
 	if err != nil {
@@ -48,5 +61,15 @@ func (s *Service) ChangePassword(ctx context.Context, userID, currentPassword, n
 	if err != nil {
 		return err
 	}
-	return s.users.ChangePassword(ctx, userID, newHash)
+	if err := s.users.ChangePassword(ctx, userID, newHash); err != nil {
+		return err
+	}
+	if s.sessions == nil {
+		return nil
+	}
+	keep := s.currentSessionID(ctx, userID, keepRefreshToken)
+	if _, err := s.sessions.RevokeSessionsExcept(ctx, userID, keep); err != nil {
+		return fmt.Errorf("revoke sessions: %w", err)
+	}
+	return nil
 }
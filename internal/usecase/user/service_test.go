@@ -42,12 +42,12 @@ func TestService_GetMe_UpdateProfile(t *testing.T) {
 func TestService_ChangePassword_SuccessAndInvalid(t *testing.T) {
 	// success
 	svc := NewService(repoStub{}, hasherOK{})
-	if err := svc.ChangePassword(context.Background(), "u1", "old", "new", func(ctx context.Context, userID string) (string, error) { return "h", nil }); err != nil {
+	if err := svc.ChangePassword(context.Background(), "u1", "old", "new", "", func(ctx context.Context, userID string) (string, error) { return "h", nil }); err != nil {
 		t.Fatalf("change ok: %v", err)
 	}
 	// invalid current
 	svc2 := NewService(repoStub{}, hasherBad{})
-	if err := svc2.ChangePassword(context.Background(), "u1", "bad", "new", func(ctx context.Context, userID string) (string, error) { return "h", nil }); err == nil {
+	if err := svc2.ChangePassword(context.Background(), "u1", "bad", "new", "", func(ctx context.Context, userID string) (string, error) { return "h", nil }); err == nil {
 		t.Fatal("expected error on invalid current password")
 	}
 }
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrSessionsDisabled = errors.New("session management is not configured")
+)
+
+// SessionRepo manages refresh-token families of a user. A session ID is the family ID.
+type SessionRepo interface {
+	ListSessions(ctx context.Context, userID string) ([]domain.Session, error)
+	// SessionIDByToken returns ErrSessionNotFound if the token is unknown or belongs to another user
+	SessionIDByToken(ctx context.Context, userID, refreshToken string) (string, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) (bool, error)
+	RevokeSessionsExcept(ctx context.Context, userID, keepSessionID string) (int64, error)
+}
+
+func (s *Service) SetSessions(repo SessionRepo) { s.sessions = repo }
+
+// ListSessions returns active sessions of the user. If currentRefreshToken is given,
+// the session it belongs to is marked as current.
+func (s *Service) ListSessions(ctx context.Context, userID, currentRefreshToken string) ([]domain.Session, error) {
+	if s.sessions == nil {
+		return nil, ErrSessionsDisabled
+	}
+	list, err := s.sessions.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	current := s.currentSessionID(ctx, userID, currentRefreshToken)
+	for i := range list {
+		list[i].Current = current != "" && list[i].ID == current
+	}
+	return list, nil
+}
+
+// RevokeSession signs a session out: its refresh token stops working. Access tokens already issued
+// to it are not checked against sessions and stay valid until they expire (JWT_ACCESS_TTL).
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if s.sessions == nil {
+		return ErrSessionsDisabled
+	}
+	ok, err := s.sessions.RevokeSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeAllOtherSessions signs the user out everywhere except the session of currentRefreshToken.
+func (s *Service) RevokeAllOtherSessions(ctx context.Context, userID, currentRefreshToken string) (int64, error) {
+	if s.sessions == nil {
+		return 0, ErrSessionsDisabled
+	}
+	current, err := s.sessions.SessionIDByToken(ctx, userID, currentRefreshToken)
+	if err != nil {
+		return 0, err
+	}
+	return s.sessions.RevokeSessionsExcept(ctx, userID, current)
+}
+
+func (s *Service) currentSessionID(ctx context.Context, userID, refreshToken string) string {
+	if refreshToken == "" {
+		return ""
+	}
+	id, err := s.sessions.SessionIDByToken(ctx, userID, refreshToken)
+	if err != nil {
+		return ""
+	}
+	return id
+}
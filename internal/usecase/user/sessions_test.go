@@ -0,0 +1,133 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+type sessionsMem struct {
+	sessions map[string]domain.Session // id -> session
+	tokens   map[string]string         // refresh token -> session id
+}
+
+func newSessionsMem() *sessionsMem {
+	return &sessionsMem{
+		sessions: map[string]domain.Session{
+			"s1": {ID: "s1", UserID: "u1", UserAgent: "web"},
+			"s2": {ID: "s2", UserID: "u1", UserAgent: "phone"},
+			"s3": {ID: "s3", UserID: "u2"},
+		},
+		tokens: map[string]string{"rt1": "s1", "rt2": "s2", "rt3": "s3"},
+	}
+}
+
+func (m *sessionsMem) ListSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	var out []domain.Session
+	for _, id := range []string{"s1", "s2", "s3"} {
+		if s, ok := m.sessions[id]; ok && s.UserID == userID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (m *sessionsMem) SessionIDByToken(ctx context.Context, userID, refreshToken string) (string, error) {
+	id, ok := m.tokens[refreshToken]
+	if !ok || m.sessions[id].UserID != userID {
+		return "", ErrSessionNotFound
+	}
+	return id, nil
+}
+
+func (m *sessionsMem) RevokeSession(ctx context.Context, userID, sessionID string) (bool, error) {
+	s, ok := m.sessions[sessionID]
+	if !ok || s.UserID != userID {
+		return false, nil
+	}
+	delete(m.sessions, sessionID)
+	return true, nil
+}
+
+func (m *sessionsMem) RevokeSessionsExcept(ctx context.Context, userID, keepSessionID string) (int64, error) {
+	var n int64
+	for id, s := range m.sessions {
+		if s.UserID == userID && id != keepSessionID {
+			delete(m.sessions, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	svc := NewService(repoStub{}, hasherOK{})
+	ctx := context.Background()
+	if _, err := svc.ListSessions(ctx, "u1", ""); !errors.Is(err, ErrSessionsDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+	repo := newSessionsMem()
+	svc.SetSessions(repo)
+
+	list, err := svc.ListSessions(ctx, "u1", "rt2")
+	if err != nil || len(list) != 2 {
+		t.Fatalf("list: %v %#v", err, list)
+	}
+	if list[0].Current || !list[1].Current {
+		t.Fatalf("expected s2 to be current: %#v", list)
+	}
+	if err := svc.RevokeSession(ctx, "u1", "s3"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("must not revoke another user's session, got %v", err)
+	}
+	if err := svc.RevokeSession(ctx, "u1", "s1"); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, ok := repo.sessions["s1"]; ok {
+		t.Fatal("session s1 not revoked")
+	}
+}
+
+func TestSessions_RevokeAllOther(t *testing.T) {
+	svc := NewService(repoStub{}, hasherOK{})
+	repo := newSessionsMem()
+	svc.SetSessions(repo)
+	ctx := context.Background()
+	if _, err := svc.RevokeAllOtherSessions(ctx, "u1", "rt3"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected not found for foreign token, got %v", err)
+	}
+	n, err := svc.RevokeAllOtherSessions(ctx, "u1", "rt1")
+	if err != nil || n != 1 {
+		t.Fatalf("revoke others: %v %d", err, n)
+	}
+	if _, ok := repo.sessions["s1"]; !ok {
+		t.Fatal("current session must stay")
+	}
+	if _, ok := repo.sessions["s3"]; !ok {
+		t.Fatal("other users' sessions must stay")
+	}
+}
+
+func TestSessions_ChangePasswordRevokes(t *testing.T) {
+	svc := NewService(repoStub{}, hasherOK{})
+	repo := newSessionsMem()
+	svc.SetSessions(repo)
+	getHash := func(ctx context.Context, userID string) (string, error) { return "h", nil }
+	if err := svc.ChangePassword(context.Background(), "u1", "old", "new", "rt2", getHash); err != nil {
+		t.Fatalf("change: %v", err)
+	}
+	if _, ok := repo.sessions["s2"]; !ok {
+		t.Fatal("session of the caller must stay")
+	}
+	if _, ok := repo.sessions["s1"]; ok {
+		t.Fatal("other sessions must be revoked")
+	}
+	// without a refresh token every session is signed out
+	if err := svc.ChangePassword(context.Background(), "u1", "old", "new", "", getHash); err != nil {
+		t.Fatalf("change: %v", err)
+	}
+	if list, _ := repo.ListSessions(context.Background(), "u1"); len(list) != 0 {
+		t.Fatalf("expected no sessions, got %#v", list)
+	}
+}
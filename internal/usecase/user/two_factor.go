@@ -0,0 +1,92 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/positron48/budget/internal/pkg/totp"
+	authuse "github.com/positron48/budget/internal/usecase/auth"
+)
+
+var (
+	ErrTwoFactorDisabled       = errors.New("two-factor authentication is not configured")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+)
+
+// TwoFactorRepo stores TOTP enrollment; recovery codes are stored hashed.
+type TwoFactorRepo interface {
+	authuse.SecondFactorRepo
+	// SaveTOTPSecret starts (or restarts) enrollment with a new unconfirmed secret;
+	// returns ErrTwoFactorAlreadyEnabled if 2FA is enabled
+	SaveTOTPSecret(ctx context.Context, userID, secret string) error
+	// EnableTOTP confirms enrollment, records the used step and replaces recovery codes
+	EnableTOTP(ctx context.Context, userID string, step int64, recoveryCodes []string) error
+	// DisableTOTP removes the secret and recovery codes
+	DisableTOTP(ctx context.Context, userID string) error
+}
+
+const defaultTOTPIssuer = "Budget"
+
+func (s *Service) SetTwoFactor(repo TwoFactorRepo, issuer string) {
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+	s.twoFactor = repo
+	s.totpIssuer = issuer
+}
+
+// EnrollTOTP generates a secret to be added to an authenticator app. 2FA is enabled only
+// after ConfirmTOTP with a code from the app.
+func (s *Service) EnrollTOTP(ctx context.Context, userID string) (secret, uri string, err error) {
+	if s.twoFactor == nil {
+		return "", "", ErrTwoFactorDisabled
+	}
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if secret, err = totp.GenerateSecret(); err != nil {
+		return "", "", err
+	}
+	if err := s.twoFactor.SaveTOTPSecret(ctx, userID, secret); err != nil {
+		return "", "", err
+	}
+	return secret, totp.URI(s.totpIssuer, u.Email, secret), nil
+}
+
+// ConfirmTOTP enables 2FA and returns recovery codes; they are shown only once.
+func (s *Service) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	if s.twoFactor == nil {
+		return nil, ErrTwoFactorDisabled
+	}
+	state, err := s.twoFactor.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if state.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	step, err := authuse.VerifyTOTPEnrollment(ctx, s.twoFactor, userID, state.Secret, code)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := authuse.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactor.EnableTOTP(ctx, userID, step, codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns 2FA off; requires a current code or a recovery code.
+func (s *Service) DisableTOTP(ctx context.Context, userID, code string) error {
+	if s.twoFactor == nil {
+		return ErrTwoFactorDisabled
+	}
+	if err := authuse.VerifySecondFactor(ctx, s.twoFactor, userID, code); err != nil {
+		return err
+	}
+	return s.twoFactor.DisableTOTP(ctx, userID)
+}
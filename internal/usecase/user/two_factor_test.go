@@ -0,0 +1,110 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/pkg/totp"
+	authuse "github.com/positron48/budget/internal/usecase/auth"
+)
+
+type totpMem struct {
+	state    *authuse.TOTPState
+	lastStep int64
+	recovery map[string]bool // normalized code -> unused
+	counters map[string]int
+}
+
+func (m *totpMem) CheckRateLimit(ctx context.Context, subject, action string, windowStart time.Time) (int, error) {
+	return m.counters[subject+"|"+action], nil
+}
+
+func (m *totpMem) IncrementRateLimit(ctx context.Context, subject, action string, windowStart time.Time) error {
+	m.counters[subject+"|"+action]++
+	return nil
+}
+
+func (m *totpMem) GetTOTP(ctx context.Context, userID string) (authuse.TOTPState, error) {
+	if m.state == nil {
+		return authuse.TOTPState{}, authuse.ErrTwoFactorNotEnabled
+	}
+	return *m.state, nil
+}
+
+func (m *totpMem) MarkTOTPStepUsed(ctx context.Context, userID string, step int64) (bool, error) {
+	if m.lastStep >= step {
+		return false, nil
+	}
+	m.lastStep = step
+	return true, nil
+}
+
+func (m *totpMem) ConsumeRecoveryCode(ctx context.Context, userID, code string) (bool, error) {
+	if !m.recovery[code] {
+		return false, nil
+	}
+	m.recovery[code] = false
+	return true, nil
+}
+
+func (m *totpMem) SaveTOTPSecret(ctx context.Context, userID, secret string) error {
+	if m.state != nil && m.state.Enabled {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	m.state = &authuse.TOTPState{Secret: secret}
+	return nil
+}
+
+func (m *totpMem) EnableTOTP(ctx context.Context, userID string, step int64, recoveryCodes []string) error {
+	m.state.Enabled = true
+	m.lastStep = step
+	m.recovery = map[string]bool{}
+	for _, c := range recoveryCodes {
+		m.recovery[authuse.NormalizeRecoveryCode(c)] = true
+	}
+	return nil
+}
+
+func (m *totpMem) DisableTOTP(ctx context.Context, userID string) error {
+	m.state, m.recovery = nil, nil
+	return nil
+}
+
+func TestTwoFactor_EnrollConfirmDisable(t *testing.T) {
+	svc := NewService(repoStub{}, hasherOK{})
+	ctx := context.Background()
+	if _, _, err := svc.EnrollTOTP(ctx, "u1"); !errors.Is(err, ErrTwoFactorDisabled) {
+		t.Fatalf("expected disabled, got %v", err)
+	}
+	repo := &totpMem{counters: map[string]int{}}
+	svc.SetTwoFactor(repo, "")
+
+	secret, uri, err := svc.EnrollTOTP(ctx, "u1")
+	if err != nil || secret == "" || !strings.HasPrefix(uri, "otpauth://totp/Budget:e@x?") {
+		t.Fatalf("enroll: %v %q %q", err, secret, uri)
+	}
+	if _, err := svc.ConfirmTOTP(ctx, "u1", "000000"); !errors.Is(err, authuse.ErrInvalidTwoFactorCode) {
+		t.Fatalf("expected invalid code, got %v", err)
+	}
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	codes, err := svc.ConfirmTOTP(ctx, "u1", code)
+	if err != nil || len(codes) != authuse.RecoveryCodeCount || !repo.state.Enabled {
+		t.Fatalf("confirm: %v %v", err, codes)
+	}
+	if _, _, err := svc.EnrollTOTP(ctx, "u1"); !errors.Is(err, ErrTwoFactorAlreadyEnabled) {
+		t.Fatalf("re-enroll must be refused, got %v", err)
+	}
+	// the code used for confirmation can't be reused
+	if err := svc.DisableTOTP(ctx, "u1", code); !errors.Is(err, authuse.ErrInvalidTwoFactorCode) {
+		t.Fatalf("expected replay to be rejected, got %v", err)
+	}
+	if err := svc.DisableTOTP(ctx, "u1", strings.ToUpper(codes[0])); err != nil {
+		t.Fatalf("disable with recovery code: %v", err)
+	}
+	if repo.state != nil {
+		t.Fatal("2FA must be disabled")
+	}
+}
@@ -0,0 +1,213 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/positron48/budget/internal/domain"
+	txusecase "github.com/positron48/budget/internal/usecase/transaction"
+)
+
+var (
+	ErrViewNotFound     = errors.New("saved view not found")
+	ErrInvalidView      = errors.New("invalid saved view")
+	ErrPermissionDenied = errors.New("only owners and admins can manage shared views")
+)
+
+const maxNameLength = 100
+
+// Repo stores saved views. Get, Update and Delete return ErrViewNotFound for unknown ids.
+type Repo interface {
+	Create(ctx context.Context, v domain.SavedView) (domain.SavedView, error)
+	Get(ctx context.Context, tenantID, id string) (domain.SavedView, error)
+	List(ctx context.Context, tenantID, userID string) ([]domain.SavedView, error)
+	Update(ctx context.Context, v domain.SavedView) (domain.SavedView, error)
+	Delete(ctx context.Context, tenantID, id string) error
+}
+
+type RoleRepo interface {
+	GetUserRole(ctx context.Context, tenantID, userID string) (domain.TenantRole, error)
+}
+
+type Service struct {
+	repo  Repo
+	roles RoleRepo
+	now   func() time.Time
+}
+
+func NewService(repo Repo, roles RoleRepo) *Service {
+	return &Service{repo: repo, roles: roles, now: time.Now}
+}
+
+// Create saves a view owned by userID, or shared with the whole tenant (owners and admins only).
+func (s *Service) Create(ctx context.Context, tenantID, userID, name string, shared bool, filter domain.ViewFilter, sort string) (domain.SavedView, error) {
+	v := domain.SavedView{TenantID: tenantID, UserID: userID, Name: strings.TrimSpace(name), Filter: filter, Sort: txusecase.NormalizeSort(sort)}
+	if shared {
+		v.UserID = ""
+		if err := s.checkManager(ctx, tenantID, userID); err != nil {
+			return domain.SavedView{}, err
+		}
+	}
+	if err := validate(v); err != nil {
+		return domain.SavedView{}, err
+	}
+	return s.repo.Create(ctx, v)
+}
+
+// List returns the views visible to the user: their own and the shared ones.
+func (s *Service) List(ctx context.Context, tenantID, userID string) ([]domain.SavedView, error) {
+	return s.repo.List(ctx, tenantID, userID)
+}
+
+// Get returns a view visible to the user; other users' personal views are reported as not found.
+func (s *Service) Get(ctx context.Context, tenantID, userID, id string) (domain.SavedView, error) {
+	v, err := s.repo.Get(ctx, tenantID, id)
+	if err != nil {
+		return domain.SavedView{}, err
+	}
+	if !v.Shared() && v.UserID != userID {
+		return domain.SavedView{}, ErrViewNotFound
+	}
+	return v, nil
+}
+
+// Update replaces the name, filter and sort of a view. A view can't change between personal and shared.
+func (s *Service) Update(ctx context.Context, tenantID, userID, id, name string, filter domain.ViewFilter, sort string) (domain.SavedView, error) {
+	v, err := s.editable(ctx, tenantID, userID, id)
+	if err != nil {
+		return domain.SavedView{}, err
+	}
+	v.Name, v.Filter, v.Sort = strings.TrimSpace(name), filter, txusecase.NormalizeSort(sort)
+	if err := validate(v); err != nil {
+		return domain.SavedView{}, err
+	}
+	return s.repo.Update(ctx, v)
+}
+
+func (s *Service) Delete(ctx context.Context, tenantID, userID, id string) error {
+	if _, err := s.editable(ctx, tenantID, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, tenantID, id)
+}
+
+// Filter resolves a view into a transaction filter and sort. Relative periods are computed
+// at call time (UTC), so "this month" follows the calendar.
+func (s *Service) Filter(ctx context.Context, tenantID, userID, id string) (txusecase.ListFilter, string, error) {
+	v, err := s.Get(ctx, tenantID, userID, id)
+	if err != nil {
+		return txusecase.ListFilter{}, "", err
+	}
+	f, err := ListFilter(v.Filter, s.now())
+	if err != nil {
+		return txusecase.ListFilter{}, "", err
+	}
+	return f, v.Sort, nil
+}
+
+// editable returns a view the user may change: their own, or a shared one if they manage the tenant.
+func (s *Service) editable(ctx context.Context, tenantID, userID, id string) (domain.SavedView, error) {
+	v, err := s.Get(ctx, tenantID, userID, id)
+	if err != nil {
+		return domain.SavedView{}, err
+	}
+	if v.Shared() {
+		if err := s.checkManager(ctx, tenantID, userID); err != nil {
+			return domain.SavedView{}, err
+		}
+	}
+	return v, nil
+}
+
+func (s *Service) checkManager(ctx context.Context, tenantID, userID string) error {
+	role, err := s.roles.GetUserRole(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if role != domain.TenantRoleOwner && role != domain.TenantRoleAdmin {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func validate(v domain.SavedView) error {
+	if v.Name == "" || utf8.RuneCountInString(v.Name) > maxNameLength {
+		return fmt.Errorf("%w: name must be 1..%d characters", ErrInvalidView, maxNameLength)
+	}
+	switch v.Filter.Type {
+	case "", domain.TransactionTypeIncome, domain.TransactionTypeExpense:
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidView, v.Filter.Type)
+	}
+	if c := v.Filter.CurrencyCode; c != "" && !domain.IsCurrencyCode(c) {
+		return fmt.Errorf("%w: %q", domain.ErrUnknownCurrency, c)
+	}
+	if v.Filter.Period != domain.ViewPeriodNone && (v.Filter.From != nil || v.Filter.To != nil) {
+		return fmt.Errorf("%w: period and an explicit date range are exclusive", ErrInvalidView)
+	}
+	// the period and the query are checked the way they'll be resolved
+	if _, err := ListFilter(v.Filter, time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListFilter converts a stored filter into a transaction filter as of now.
+func ListFilter(vf domain.ViewFilter, now time.Time) (txusecase.ListFilter, error) {
+	var f txusecase.ListFilter
+	if vf.Period != domain.ViewPeriodNone {
+		from, to, err := periodRange(vf.Period, now.UTC())
+		if err != nil {
+			return txusecase.ListFilter{}, err
+		}
+		f.From, f.To = &from, &to
+	} else {
+		f.From, f.To = vf.From, vf.To
+	}
+	f.CategoryIDs = vf.CategoryIDs
+	if vf.Type != "" {
+		tt := vf.Type
+		f.Type = &tt
+	}
+	if vf.CurrencyCode != "" {
+		c := vf.CurrencyCode
+		f.CurrencyCode = &c
+	}
+	f.MinMinorUnits, f.MaxMinorUnits = vf.MinMinorUnits, vf.MaxMinorUnits
+	if vf.Search != "" {
+		q := vf.Search
+		f.Search = &q
+	}
+	if vf.Extraordinary != nil {
+		f.OnlyExtraordinary = *vf.Extraordinary
+		f.ExcludeExtraordinary = !*vf.Extraordinary
+	}
+	if err := txusecase.ApplyQuery(&f, vf.Query); err != nil {
+		return txusecase.ListFilter{}, fmt.Errorf("%w: %v", ErrInvalidView, err)
+	}
+	return f, nil
+}
+
+// periodRange returns the half-open range [from, to) of a relative period.
+func periodRange(p domain.ViewPeriod, now time.Time) (from, to time.Time, err error) {
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	year := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	switch p {
+	case domain.ViewPeriodThisMonth:
+		return month, month.AddDate(0, 1, 0), nil
+	case domain.ViewPeriodLastMonth:
+		return month.AddDate(0, -1, 0), month, nil
+	case domain.ViewPeriodThisYear:
+		return year, year.AddDate(1, 0, 0), nil
+	case domain.ViewPeriodLastYear:
+		return year.AddDate(-1, 0, 0), year, nil
+	case domain.ViewPeriodLast30Days:
+		day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -29), day.AddDate(0, 0, 1), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("%w: unknown period %q", ErrInvalidView, p)
+}
@@ -0,0 +1,177 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+type memRepo struct {
+	views map[string]domain.SavedView
+	seq   int
+}
+
+func newMemRepo() *memRepo { return &memRepo{views: map[string]domain.SavedView{}} }
+
+func (m *memRepo) Create(_ context.Context, v domain.SavedView) (domain.SavedView, error) {
+	m.seq++
+	v.ID = fmt.Sprintf("v%d", m.seq)
+	m.views[v.ID] = v
+	return v, nil
+}
+
+func (m *memRepo) Get(_ context.Context, tenantID, id string) (domain.SavedView, error) {
+	v, ok := m.views[id]
+	if !ok || v.TenantID != tenantID {
+		return domain.SavedView{}, ErrViewNotFound
+	}
+	return v, nil
+}
+
+func (m *memRepo) List(_ context.Context, tenantID, userID string) ([]domain.SavedView, error) {
+	var out []domain.SavedView
+	for _, v := range m.views {
+		if v.TenantID == tenantID && (v.Shared() || v.UserID == userID) {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+func (m *memRepo) Update(_ context.Context, v domain.SavedView) (domain.SavedView, error) {
+	m.views[v.ID] = v
+	return v, nil
+}
+
+func (m *memRepo) Delete(_ context.Context, _, id string) error {
+	delete(m.views, id)
+	return nil
+}
+
+type roles map[string]domain.TenantRole
+
+func (r roles) GetUserRole(_ context.Context, _, userID string) (domain.TenantRole, error) {
+	return r[userID], nil
+}
+
+func TestService_Visibility(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemRepo()
+	svc := NewService(repo, roles{"owner": domain.TenantRoleOwner, "member": domain.TenantRoleMember})
+
+	personal, err := svc.Create(ctx, "t1", "member", " Coffee ", false, domain.ViewFilter{Search: "coffee"}, "Amount_Numeric  DESC")
+	if err != nil {
+		t.Fatalf("create personal: %v", err)
+	}
+	if personal.Name != "Coffee" || personal.Sort != "amount_numeric desc" || personal.Shared() {
+		t.Fatalf("unexpected view: %+v", personal)
+	}
+	if _, err := svc.Create(ctx, "t1", "member", "Team", true, domain.ViewFilter{}, ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("member shared create: %v", err)
+	}
+	shared, err := svc.Create(ctx, "t1", "owner", "Team", true, domain.ViewFilter{}, "")
+	if err != nil || !shared.Shared() {
+		t.Fatalf("owner shared create: %+v %v", shared, err)
+	}
+
+	if _, err := svc.Get(ctx, "t1", "owner", personal.ID); !errors.Is(err, ErrViewNotFound) {
+		t.Fatalf("other user's personal view must be hidden: %v", err)
+	}
+	if list, _ := svc.List(ctx, "t1", "member"); len(list) != 2 {
+		t.Fatalf("member sees own and shared views, got %d", len(list))
+	}
+	if _, err := svc.Update(ctx, "t1", "member", shared.ID, "Mine", domain.ViewFilter{}, ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("member shared update: %v", err)
+	}
+	if err := svc.Delete(ctx, "t1", "owner", personal.ID); !errors.Is(err, ErrViewNotFound) {
+		t.Fatalf("owner can't delete a member's personal view: %v", err)
+	}
+	if err := svc.Delete(ctx, "t1", "member", personal.ID); err != nil {
+		t.Fatalf("delete own view: %v", err)
+	}
+}
+
+func TestService_Validation(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(newMemRepo(), roles{})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]domain.ViewFilter{
+		"period and range": {Period: domain.ViewPeriodThisMonth, From: &from},
+		"unknown period":   {Period: "next_week"},
+		"bad query":        {Query: "amount>lots"},
+		"bad type":         {Type: "transfer"},
+	}
+	for name, f := range cases {
+		if _, err := svc.Create(ctx, "t1", "u1", "v", false, f, ""); !errors.Is(err, ErrInvalidView) {
+			t.Errorf("%s: expected ErrInvalidView, got %v", name, err)
+		}
+	}
+	if _, err := svc.Create(ctx, "t1", "u1", "", false, domain.ViewFilter{}, ""); !errors.Is(err, ErrInvalidView) {
+		t.Errorf("empty name: %v", err)
+	}
+	if _, err := svc.Create(ctx, "t1", "u1", "v", false, domain.ViewFilter{CurrencyCode: "XXX"}, ""); !errors.Is(err, domain.ErrUnknownCurrency) {
+		t.Errorf("unknown currency: %v", err)
+	}
+}
+
+func TestService_Filter(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(newMemRepo(), roles{})
+	svc.now = func() time.Time { return time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC) }
+	extra := false
+	v, err := svc.Create(ctx, "t1", "u1", "Food last month", false, domain.ViewFilter{
+		Period:        domain.ViewPeriodLastMonth,
+		Type:          domain.TransactionTypeExpense,
+		Extraordinary: &extra,
+		Query:         "category:food",
+	}, "occurred_at asc")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	f, sort, err := svc.Filter(ctx, "t1", "u1", v.ID)
+	if err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+	if sort != "occurred_at asc" {
+		t.Fatalf("sort: %q", sort)
+	}
+	if !f.From.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) || !f.To.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("period: %v..%v", f.From, f.To)
+	}
+	if f.Type == nil || *f.Type != domain.TransactionTypeExpense || !f.ExcludeExtraordinary || f.OnlyExtraordinary {
+		t.Fatalf("filter: %+v", f)
+	}
+	if len(f.CategoryNames) != 1 || f.CategoryNames[0] != "food" {
+		t.Fatalf("query not applied: %+v", f.CategoryNames)
+	}
+	if _, _, err := svc.Filter(ctx, "t1", "u2", v.ID); !errors.Is(err, ErrViewNotFound) {
+		t.Fatalf("another user's view: %v", err)
+	}
+}
+
+func TestPeriodRange(t *testing.T) {
+	now := time.Date(2026, 1, 10, 23, 0, 0, 0, time.UTC)
+	cases := []struct {
+		p        domain.ViewPeriod
+		from, to string
+	}{
+		{domain.ViewPeriodThisMonth, "2026-01-01", "2026-02-01"},
+		{domain.ViewPeriodLastMonth, "2025-12-01", "2026-01-01"},
+		{domain.ViewPeriodThisYear, "2026-01-01", "2027-01-01"},
+		{domain.ViewPeriodLastYear, "2025-01-01", "2026-01-01"},
+		{domain.ViewPeriodLast30Days, "2025-12-12", "2026-01-11"},
+	}
+	for _, c := range cases {
+		from, to, err := periodRange(c.p, now)
+		if err != nil {
+			t.Fatalf("%s: %v", c.p, err)
+		}
+		if got := from.Format("2006-01-02") + ".." + to.Format("2006-01-02"); got != c.from+".."+c.to {
+			t.Errorf("%s: got %s", c.p, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned when a webhook would connect to a loopback, private or otherwise
+// non-public address that the operator has not allowed.
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// reserved are the special-purpose networks not covered by the netip predicates
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64 may map to internal IPv4 addresses
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// AddressPolicy decides which addresses webhook requests may connect to: public unicast
+// addresses and the networks the operator explicitly allows (WEBHOOK_ALLOWED_NETWORKS).
+type AddressPolicy struct {
+	Allowed []netip.Prefix
+}
+
+// Allows reports whether a connection to addr is permitted
+func (p AddressPolicy) Allows(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	for _, n := range p.Allowed {
+		if n.Contains(addr) {
+			return true
+		}
+	}
+	return isPublic(addr)
+}
+
+func isPublic(addr netip.Addr) bool {
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsMulticast() {
+		return false
+	}
+	for _, n := range reserved {
+		if n.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewClient returns the HTTP client deliveries are sent with. The address is checked after DNS
+// resolution, right before the connection is made, so a host re-resolving to an internal address
+// can't get past the check. Redirects are not followed: a 3xx answer is a failed delivery.
+// Proxies from the environment are ignored for the same reason.
+func NewClient(policy AddressPolicy, timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+			}
+			if !policy.Allows(ap.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, ap.Addr())
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        20,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// Request headers of a delivery
+const (
+	HeaderEvent     = "X-Budget-Event"
+	HeaderEventID   = "X-Budget-Event-Id"
+	HeaderDelivery  = "X-Budget-Delivery"
+	HeaderTimestamp = "X-Budget-Timestamp"
+	HeaderSignature = "X-Budget-Signature"
+)
+
+// maxErrorLength bounds the error text kept in the delivery log
+const maxErrorLength = 500
+
+// Due is a claimed delivery with the endpoint to send it to.
+type Due struct {
+	Delivery domain.WebhookDelivery
+	URL      string
+	Secret   string
+}
+
+// DispatchRepo hands out due deliveries and records the outcome of attempts.
+type DispatchRepo interface {
+	// ClaimDue returns up to limit pending deliveries due at now, counts the attempt and
+	// postpones them by lease, so that other dispatchers skip them and a crashed attempt
+	// is retried after the lease
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]Due, error)
+	MarkDelivered(ctx context.Context, id string, statusCode int, at time.Time) error
+	// MarkFailed records a failed attempt; the delivery is retried at next, or fails for good if next is nil
+	MarkFailed(ctx context.Context, id string, statusCode int, errMsg string, next *time.Time) error
+	// Release hands back claimed deliveries that were not sent: the attempt is not counted
+	// and they are due again at the given time
+	Release(ctx context.Context, ids []string, at time.Time) error
+}
+
+type DispatcherConfig struct {
+	BatchSize   int           // deliveries claimed per run
+	MaxAttempts int           // a delivery fails for good after this many attempts
+	BaseDelay   time.Duration // delay after the first failed attempt, doubled after each next one
+	MaxDelay    time.Duration
+	Timeout     time.Duration // per request
+}
+
+// Dispatcher sends queued deliveries, signed with the secret of their subscription.
+type Dispatcher struct {
+	repo   DispatchRepo
+	client *http.Client
+	cfg    DispatcherConfig
+	now    func() time.Time
+}
+
+// NewDispatcher sends deliveries with client; nil means NewClient with public addresses only.
+func NewDispatcher(repo DispatchRepo, client *http.Client, cfg DispatcherConfig) *Dispatcher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = 30 * time.Second
+	}
+	if cfg.MaxDelay < cfg.BaseDelay {
+		cfg.MaxDelay = cfg.BaseDelay
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if client == nil {
+		client = NewClient(AddressPolicy{}, cfg.Timeout)
+	}
+	return &Dispatcher{repo: repo, client: client, cfg: cfg, now: time.Now}
+}
+
+// RunOnce sends one batch of due deliveries and returns how many were delivered and how many
+// attempts failed. When ctx is cancelled (on shutdown) the deliveries not sent yet are released,
+// so that another instance picks them up right away instead of after the lease.
+func (d *Dispatcher) RunOnce(ctx context.Context) (delivered, failed int, err error) {
+	// requests of a batch are sent one after another, so the lease covers all of them
+	lease := time.Duration(d.cfg.BatchSize)*d.cfg.Timeout + time.Minute
+	due, err := d.repo.ClaimDue(ctx, d.now(), d.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, 0, err
+	}
+	// outcomes are stored even after ctx is cancelled: the deliveries are already claimed
+	store := context.WithoutCancel(ctx)
+	for i, item := range due {
+		code, sendErr := d.send(ctx, item)
+		if sendErr != nil && ctx.Err() != nil {
+			// the request was cut off by us, not refused by the receiver
+			ids := make([]string, 0, len(due)-i)
+			for _, rest := range due[i:] {
+				ids = append(ids, rest.Delivery.ID)
+			}
+			if err := d.repo.Release(store, ids, d.now()); err != nil {
+				return delivered, failed, err
+			}
+			return delivered, failed, ctx.Err()
+		}
+		if sendErr == nil {
+			if err := d.repo.MarkDelivered(store, item.Delivery.ID, code, d.now()); err != nil {
+				return delivered, failed, err
+			}
+			delivered++
+			continue
+		}
+		failed++
+		var next *time.Time
+		if item.Delivery.Attempts < d.cfg.MaxAttempts {
+			t := d.now().Add(d.Backoff(item.Delivery.Attempts))
+			next = &t
+		}
+		if err := d.repo.MarkFailed(store, item.Delivery.ID, code, truncate(sendErr.Error(), maxErrorLength), next); err != nil {
+			return delivered, failed, err
+		}
+	}
+	return delivered, failed, nil
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// Backoff returns the delay before the next attempt after the given number of attempts.
+func (d *Dispatcher) Backoff(attempts int) time.Duration {
+	delay := d.cfg.BaseDelay
+	for i := 1; i < attempts && delay < d.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.MaxDelay {
+		delay = d.cfg.MaxDelay
+	}
+	return delay
+}
+
+// send posts the payload and returns the response status; any answer but 2xx is an error
+func (d *Dispatcher) send(ctx context.Context, item Due) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	defer cancel()
+	ts := d.now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, item.URL, bytes.NewReader(item.Delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Budget-Webhooks/1")
+	req.Header.Set(HeaderEvent, item.Delivery.EventType)
+	req.Header.Set(HeaderEventID, item.Delivery.EventID)
+	req.Header.Set(HeaderDelivery, item.Delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(item.Secret, ts, item.Delivery.Payload))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the X-Budget-Signature value: "sha256=" and the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the subscription secret. Receivers recompute it from the
+// X-Budget-Timestamp header and the raw body, and should reject old timestamps.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,316 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// memOutbox is an in-memory DispatchRepo
+type memOutbox struct {
+	mu         sync.Mutex
+	url        string
+	secret     string
+	deliveries []*domain.WebhookDelivery
+}
+
+func (m *memOutbox) ClaimDue(_ context.Context, now time.Time, limit int, lease time.Duration) ([]Due, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Due
+	for _, d := range m.deliveries {
+		if len(out) == limit {
+			break
+		}
+		if d.Status != domain.WebhookDeliveryPending || d.NextAttemptAt.After(now) {
+			continue
+		}
+		d.Attempts++
+		d.LastAttemptAt = &now
+		d.NextAttemptAt = now.Add(lease)
+		out = append(out, Due{Delivery: *d, URL: m.url, Secret: m.secret})
+	}
+	return out, nil
+}
+
+func (m *memOutbox) find(id string) *domain.WebhookDelivery {
+	for _, d := range m.deliveries {
+		if d.ID == id {
+			return d
+		}
+	}
+	return nil
+}
+
+func (m *memOutbox) MarkDelivered(_ context.Context, id string, statusCode int, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := m.find(id)
+	d.Status, d.LastStatusCode, d.LastError, d.DeliveredAt = domain.WebhookDeliveryDelivered, statusCode, "", &at
+	return nil
+}
+
+func (m *memOutbox) MarkFailed(_ context.Context, id string, statusCode int, errMsg string, next *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := m.find(id)
+	d.LastStatusCode, d.LastError = statusCode, errMsg
+	if next == nil {
+		d.Status = domain.WebhookDeliveryFailed
+	} else {
+		d.NextAttemptAt = *next
+	}
+	return nil
+}
+
+func (m *memOutbox) Release(_ context.Context, ids []string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		d := m.find(id)
+		d.Attempts--
+		d.NextAttemptAt = at
+	}
+	return nil
+}
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestDispatcher(repo DispatchRepo, c *clock, maxAttempts int) *Dispatcher {
+	// test servers listen on loopback
+	client := NewClient(AddressPolicy{Allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}}, 2*time.Second)
+	d := NewDispatcher(repo, client, DispatcherConfig{MaxAttempts: maxAttempts, BaseDelay: time.Minute, MaxDelay: 4 * time.Minute, Timeout: 2 * time.Second})
+	d.now = c.now
+	return d
+}
+
+func TestDispatcher_DeliversSigned(t *testing.T) {
+	const secret = "whsec_0123456789abcdef"
+	payload := []byte(`{"id":"e1","type":"transaction.created"}`)
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	got := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- received{r.Header.Clone(), body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := &clock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	repo := &memOutbox{url: srv.URL, secret: secret, deliveries: []*domain.WebhookDelivery{
+		{ID: "d1", EventID: "e1", EventType: domain.WebhookEventTransactionCreated, Payload: payload, Status: domain.WebhookDeliveryPending, NextAttemptAt: c.t},
+	}}
+	delivered, failed, err := newTestDispatcher(repo, c, 3).RunOnce(context.Background())
+	if err != nil || delivered != 1 || failed != 0 {
+		t.Fatalf("run: delivered=%d failed=%d err=%v", delivered, failed, err)
+	}
+
+	req := <-got
+	if string(req.body) != string(payload) {
+		t.Fatalf("body = %s", req.body)
+	}
+	if req.header.Get(HeaderEvent) != domain.WebhookEventTransactionCreated || req.header.Get(HeaderDelivery) != "d1" || req.header.Get(HeaderEventID) != "e1" {
+		t.Fatalf("unexpected headers %v", req.header)
+	}
+	ts, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	if err != nil || ts != c.t.Unix() {
+		t.Fatalf("timestamp %q", req.header.Get(HeaderTimestamp))
+	}
+	// what a receiver does: recompute the signature from the raw body
+	sig := Sign(secret, ts, req.body)
+	if !hmac.Equal([]byte(req.header.Get(HeaderSignature)), []byte(sig)) {
+		t.Fatalf("signature %q, want %q", req.header.Get(HeaderSignature), sig)
+	}
+	if Sign("another-secret-value", ts, req.body) == sig || Sign(secret, ts+1, req.body) == sig {
+		t.Fatalf("signature must depend on the secret and the timestamp")
+	}
+	if d := repo.deliveries[0]; d.Status != domain.WebhookDeliveryDelivered || d.LastStatusCode != http.StatusNoContent || d.DeliveredAt == nil {
+		t.Fatalf("unexpected delivery %+v", d)
+	}
+}
+
+func TestDispatcher_RetriesWithBackoff(t *testing.T) {
+	var mu sync.Mutex
+	calls, okFrom := 0, 3
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls < okFrom {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := &clock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	repo := &memOutbox{url: srv.URL, secret: "whsec_0123456789abcdef", deliveries: []*domain.WebhookDelivery{
+		{ID: "d1", Payload: []byte(`{}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: c.t},
+	}}
+	disp := newTestDispatcher(repo, c, 5)
+	ctx := context.Background()
+	d := repo.deliveries[0]
+
+	if _, failed, _ := disp.RunOnce(ctx); failed != 1 {
+		t.Fatalf("first attempt must fail")
+	}
+	if d.Status != domain.WebhookDeliveryPending || d.LastStatusCode != http.StatusServiceUnavailable || d.LastError == "" {
+		t.Fatalf("after failure: %+v", d)
+	}
+	if !d.NextAttemptAt.Equal(c.t.Add(time.Minute)) {
+		t.Fatalf("retry at %v, want in 1m", d.NextAttemptAt)
+	}
+	// not due yet
+	if delivered, failed, _ := disp.RunOnce(ctx); delivered+failed != 0 {
+		t.Fatalf("delivery retried before its time")
+	}
+	c.advance(time.Minute)
+	if _, failed, _ := disp.RunOnce(ctx); failed != 1 {
+		t.Fatalf("second attempt must fail")
+	}
+	if !d.NextAttemptAt.Equal(c.t.Add(2 * time.Minute)) {
+		t.Fatalf("second retry at %v, want in 2m", d.NextAttemptAt)
+	}
+	c.advance(2 * time.Minute)
+	if delivered, _, _ := disp.RunOnce(ctx); delivered != 1 {
+		t.Fatalf("third attempt must succeed")
+	}
+	if d.Status != domain.WebhookDeliveryDelivered || d.Attempts != 3 || d.LastError != "" {
+		t.Fatalf("after success: %+v", d)
+	}
+}
+
+func TestDispatcher_GivesUp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := &clock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	repo := &memOutbox{url: srv.URL, secret: "whsec_0123456789abcdef", deliveries: []*domain.WebhookDelivery{
+		{ID: "d1", Payload: []byte(`{}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: c.t},
+	}}
+	disp := newTestDispatcher(repo, c, 2)
+	for i := 0; i < 2; i++ {
+		if _, _, err := disp.RunOnce(context.Background()); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		c.advance(time.Hour)
+	}
+	if d := repo.deliveries[0]; d.Status != domain.WebhookDeliveryFailed || d.Attempts != 2 || d.LastStatusCode != http.StatusInternalServerError {
+		t.Fatalf("delivery must fail for good: %+v", d)
+	}
+
+	// an unreachable receiver is a failed attempt without a status code
+	srv.Close()
+	repo.deliveries = append(repo.deliveries, &domain.WebhookDelivery{ID: "d2", Payload: []byte(`{}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: c.t})
+	if _, failed, _ := disp.RunOnce(context.Background()); failed != 1 {
+		t.Fatalf("unreachable receiver must fail")
+	}
+	if d := repo.deliveries[1]; d.LastStatusCode != 0 || d.LastError == "" || d.Status != domain.WebhookDeliveryPending {
+		t.Fatalf("unexpected delivery %+v", d)
+	}
+}
+
+func TestDispatcher_ReleasesOnShutdown(t *testing.T) {
+	started, unblock := make(chan struct{}, 1), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-unblock
+	}))
+	defer srv.Close()
+	defer close(unblock)
+
+	c := &clock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	repo := &memOutbox{url: srv.URL, secret: "whsec_0123456789abcdef", deliveries: []*domain.WebhookDelivery{
+		{ID: "d1", Payload: []byte(`{}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: c.t},
+		{ID: "d2", Payload: []byte(`{}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: c.t},
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, failed, err := newTestDispatcher(repo, c, 3).RunOnce(ctx)
+	if !errors.Is(err, context.Canceled) || failed != 0 {
+		t.Fatalf("run: failed=%d err=%v", failed, err)
+	}
+	// the cut-off delivery and the one not sent yet are due again, without a counted attempt
+	for _, d := range repo.deliveries {
+		if d.Status != domain.WebhookDeliveryPending || d.Attempts != 0 || !d.NextAttemptAt.Equal(c.t) || d.LastError != "" {
+			t.Fatalf("not released: %+v", d)
+		}
+	}
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := NewDispatcher(nil, nil, DispatcherConfig{BaseDelay: time.Minute, MaxDelay: 10 * time.Minute})
+	for attempts, want := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 3: 4 * time.Minute, 4: 8 * time.Minute, 5: 10 * time.Minute, 30: 10 * time.Minute} {
+		if got := d.Backoff(attempts); got != want {
+			t.Fatalf("Backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestClient_RefusesNonPublicAddressesAndRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient(AddressPolicy{}, time.Second).Post(srv.URL, "application/json", nil); !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("expected ErrForbiddenAddress for loopback, got %v", err)
+	}
+
+	allowed := NewClient(AddressPolicy{Allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")}}, time.Second)
+	resp, err := allowed.Post(srv.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("redirect must not be followed, got %d", resp.StatusCode)
+	}
+
+	public := AddressPolicy{}
+	for addr, want := range map[string]bool{
+		"8.8.8.8": true, "2606:4700::1111": true,
+		"10.0.0.1": false, "172.16.0.1": false, "192.168.0.1": false, "100.64.0.1": false,
+		"169.254.169.254": false, "0.0.0.0": false, "::1": false, "fe80::1": false, "fd00::1": false,
+		"::ffff:127.0.0.1": false,
+	} {
+		if got := public.Allows(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("%s: allowed=%v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestTruncate_KeepsRunesWhole(t *testing.T) {
+	msg := strings.Repeat("ж", maxErrorLength) // two bytes each
+	got := truncate(msg, maxErrorLength+1)
+	if len(got) != maxErrorLength || !utf8.ValidString(got) {
+		t.Fatalf("got %d bytes, valid=%v", len(got), utf8.ValidString(got))
+	}
+	if truncate("short", maxErrorLength) != "short" {
+		t.Fatalf("short messages must be kept")
+	}
+}
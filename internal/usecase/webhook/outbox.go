@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+// OutboxRepo queues a delivery of the event for every active subscription of the tenant to
+// its type and returns their number. It must write with the transaction of ctx, so that an
+// event is sent only if the change that caused it commits.
+type OutboxRepo interface {
+	Enqueue(ctx context.Context, tenantID, eventID, eventType string, payload []byte) (int, error)
+}
+
+// Event is the JSON body of every webhook request.
+type Event struct {
+	ID          string          `json:"id"` // the same for all subscriptions and redeliveries
+	Type        string          `json:"type"`
+	TenantID    string          `json:"tenant_id"`
+	ActorUserID string          `json:"actor_user_id,omitempty"` // empty for changes made by the system
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// MoneyData is an amount in a webhook payload; Decimal is the amount in major units, e.g. "123.45".
+type MoneyData struct {
+	CurrencyCode string `json:"currency_code"`
+	MinorUnits   int64  `json:"minor_units"`
+	Decimal      string `json:"decimal"`
+}
+
+// TransactionData is the data of transaction.* events.
+type TransactionData struct {
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`
+	CategoryID      string    `json:"category_id"`
+	UserID          string    `json:"user_id"`
+	Amount          MoneyData `json:"amount"`
+	BaseAmount      MoneyData `json:"base_amount"`
+	OccurredAt      time.Time `json:"occurred_at"`
+	Comment         string    `json:"comment"`
+	IsExtraordinary bool      `json:"is_extraordinary"`
+}
+
+// Outbox is used by usecases to queue webhook events together with the changes themselves.
+// A nil *Outbox is valid and queues nothing.
+type Outbox struct {
+	repo OutboxRepo
+	now  func() time.Time
+}
+
+func NewOutbox(repo OutboxRepo) *Outbox { return &Outbox{repo: repo, now: time.Now} }
+
+// Publish queues an event of the tenant on behalf of the user of ctx; data becomes Event.Data.
+func (o *Outbox) Publish(ctx context.Context, tenantID, eventType string, data interface{}) error {
+	if o == nil {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	ev := Event{ID: uuid.NewString(), Type: eventType, TenantID: tenantID, OccurredAt: o.now().UTC(), Data: raw}
+	ev.ActorUserID, _ = ctxutil.UserIDFromContext(ctx)
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = o.repo.Enqueue(ctx, tenantID, ev.ID, eventType, payload)
+	return err
+}
+
+// PublishTransaction queues a transaction.* event with the transaction as data.
+func (o *Outbox) PublishTransaction(ctx context.Context, eventType string, tx domain.Transaction) error {
+	if o == nil {
+		return nil
+	}
+	return o.Publish(ctx, tx.TenantID, eventType, TransactionData{
+		ID:              tx.ID,
+		Type:            string(tx.Type),
+		CategoryID:      tx.CategoryID,
+		UserID:          tx.UserID,
+		Amount:          moneyData(tx.Amount),
+		BaseAmount:      moneyData(tx.BaseAmount),
+		OccurredAt:      tx.OccurredAt.UTC(),
+		Comment:         tx.Comment,
+		IsExtraordinary: tx.IsExtraordinary,
+	})
+}
+
+func moneyData(m domain.Money) MoneyData {
+	return MoneyData{CurrencyCode: m.CurrencyCode, MinorUnits: m.MinorUnits, Decimal: m.Decimal()}
+}
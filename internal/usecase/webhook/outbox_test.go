@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/positron48/budget/internal/domain"
+	"github.com/positron48/budget/internal/pkg/ctxutil"
+)
+
+type enqueued struct {
+	tenantID, eventID, eventType string
+	payload                      []byte
+}
+
+type memOutboxRepo struct{ items []enqueued }
+
+func (m *memOutboxRepo) Enqueue(_ context.Context, tenantID, eventID, eventType string, payload []byte) (int, error) {
+	m.items = append(m.items, enqueued{tenantID, eventID, eventType, payload})
+	return 1, nil
+}
+
+func TestOutbox_PublishTransaction(t *testing.T) {
+	var nilOutbox *Outbox
+	if err := nilOutbox.PublishTransaction(context.Background(), domain.WebhookEventTransactionCreated, domain.Transaction{}); err != nil {
+		t.Fatalf("nil outbox: %v", err)
+	}
+
+	repo := &memOutboxRepo{}
+	o := NewOutbox(repo)
+	o.now = func() time.Time { return time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC) }
+	ctx := ctxutil.WithUserID(context.Background(), "u1")
+	tx := domain.Transaction{ID: "x1", TenantID: "t1", Type: domain.TransactionTypeExpense, Amount: domain.Money{CurrencyCode: "JPY", MinorUnits: 500}}
+	if err := o.PublishTransaction(ctx, domain.WebhookEventTransactionCreated, tx); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if len(repo.items) != 1 {
+		t.Fatalf("enqueued %d", len(repo.items))
+	}
+	it := repo.items[0]
+	var ev Event
+	if err := json.Unmarshal(it.payload, &ev); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if it.tenantID != "t1" || it.eventType != domain.WebhookEventTransactionCreated || ev.ID != it.eventID || ev.ActorUserID != "u1" || !ev.OccurredAt.Equal(o.now()) {
+		t.Fatalf("unexpected event %+v (%+v)", ev, it)
+	}
+	var data TransactionData
+	if err := json.Unmarshal(ev.Data, &data); err != nil || data.ID != "x1" || data.Amount.Decimal != "500" {
+		t.Fatalf("unexpected data %+v %v", data, err)
+	}
+}
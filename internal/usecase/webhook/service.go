@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrInvalidSubscription  = errors.New("invalid webhook")
+	ErrPermissionDenied     = errors.New("only owners and admins can manage webhooks")
+)
+
+const (
+	maxDescriptionLength = 200
+	minSecretLength      = 16
+)
+
+// Repo stores subscriptions and their deliveries. Lookups by id return ErrSubscriptionNotFound
+// or ErrDeliveryNotFound for ids of other tenants.
+type Repo interface {
+	CreateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, tenantID, id string) (domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, tenantID, id string) error
+	ListDeliveries(ctx context.Context, tenantID string, filter DeliveryFilter) ([]domain.WebhookDelivery, int64, error)
+	// Redeliver queues a copy of the delivery as a new pending delivery
+	Redeliver(ctx context.Context, tenantID, id string) (domain.WebhookDelivery, error)
+}
+
+type RoleRepo interface {
+	GetUserRole(ctx context.Context, tenantID, userID string) (domain.TenantRole, error)
+}
+
+// DeliveryFilter selects entries of the delivery log, newest first. Empty fields don't filter.
+type DeliveryFilter struct {
+	SubscriptionID string
+	Status         domain.WebhookDeliveryStatus
+	Page           int
+	PageSize       int
+}
+
+// Service manages the webhook subscriptions of a tenant; only owners and admins may use it.
+type Service struct {
+	repo   Repo
+	roles  RoleRepo
+	policy AddressPolicy
+}
+
+func NewService(repo Repo, roles RoleRepo) *Service {
+	return &Service{repo: repo, roles: roles}
+}
+
+// SetAddressPolicy sets the networks besides public addresses that subscription URLs may point to.
+// It should match the policy of the dispatcher client.
+func (s *Service) SetAddressPolicy(p AddressPolicy) { s.policy = p }
+
+// Create subscribes url to the event types. An empty secret is generated; the returned
+// subscription is the only place the caller gets to see it.
+func (s *Service) Create(ctx context.Context, tenantID, userID string, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	sub.TenantID = tenantID
+	sub.Active = true
+	if sub.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return domain.WebhookSubscription{}, err
+		}
+		sub.Secret = secret
+	}
+	sub, err := normalize(sub)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	if err := s.checkHost(sub.URL); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	return s.repo.CreateSubscription(ctx, sub)
+}
+
+func (s *Service) List(ctx context.Context, tenantID, userID string) ([]domain.WebhookSubscription, error) {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListSubscriptions(ctx, tenantID)
+}
+
+// Update replaces the url, description, event types and active flag; an empty secret keeps the current one.
+func (s *Service) Update(ctx context.Context, tenantID, userID string, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	current, err := s.repo.GetSubscription(ctx, tenantID, sub.ID)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	current.URL, current.Description, current.EventTypes, current.Active = sub.URL, sub.Description, sub.EventTypes, sub.Active
+	if sub.Secret != "" {
+		current.Secret = sub.Secret
+	}
+	if current, err = normalize(current); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	if err := s.checkHost(current.URL); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	return s.repo.UpdateSubscription(ctx, current)
+}
+
+// Delete removes the subscription together with its pending deliveries and log.
+func (s *Service) Delete(ctx context.Context, tenantID, userID, id string) error {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteSubscription(ctx, tenantID, id)
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, tenantID, userID string, filter DeliveryFilter) ([]domain.WebhookDelivery, int64, error) {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListDeliveries(ctx, tenantID, filter)
+}
+
+// Redeliver sends the event of a delivery again, whatever its status. The original stays in the log.
+func (s *Service) Redeliver(ctx context.Context, tenantID, userID, deliveryID string) (domain.WebhookDelivery, error) {
+	if err := s.checkManager(ctx, tenantID, userID); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return s.repo.Redeliver(ctx, tenantID, deliveryID)
+}
+
+func (s *Service) checkManager(ctx context.Context, tenantID, userID string) error {
+	role, err := s.roles.GetUserRole(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if role != domain.TenantRoleOwner && role != domain.TenantRoleAdmin {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// checkHost rejects URLs whose host is a literal non-public address or localhost early; names
+// resolving to such addresses are refused by the dispatcher client when it connects.
+func (s *Service) checkHost(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSubscription, err)
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		host = "127.0.0.1"
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !s.policy.Allows(addr) {
+		return fmt.Errorf("%w: url must not point to a non-public address", ErrInvalidSubscription)
+	}
+	return nil
+}
+
+// normalize trims and deduplicates the fields and validates the subscription
+func normalize(sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	sub.URL = strings.TrimSpace(sub.URL)
+	sub.Description = strings.TrimSpace(sub.Description)
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return sub, fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidSubscription)
+	}
+	if u.User != nil {
+		return sub, fmt.Errorf("%w: url must not contain credentials", ErrInvalidSubscription)
+	}
+	if utf8.RuneCountInString(sub.Description) > maxDescriptionLength {
+		return sub, fmt.Errorf("%w: description is longer than %d characters", ErrInvalidSubscription, maxDescriptionLength)
+	}
+	if len(sub.Secret) < minSecretLength {
+		return sub, fmt.Errorf("%w: secret must be at least %d characters", ErrInvalidSubscription, minSecretLength)
+	}
+	seen := map[string]bool{}
+	types := make([]string, 0, len(sub.EventTypes))
+	for _, t := range sub.EventTypes {
+		t = strings.TrimSpace(t)
+		if !domain.IsWebhookEventType(t) {
+			return sub, fmt.Errorf("%w: unknown event type %q", ErrInvalidSubscription, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		return sub, fmt.Errorf("%w: at least one event type is required", ErrInvalidSubscription)
+	}
+	sub.EventTypes = types
+	return sub, nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
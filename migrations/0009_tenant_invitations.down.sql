@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS uq_tenant_invitations_pending;
+DROP INDEX IF EXISTS idx_tenant_invitations_email;
+DROP INDEX IF EXISTS idx_tenant_invitations_tenant;
+
+DROP TABLE IF EXISTS tenant_invitations;
+
+DROP TYPE IF EXISTS tenant_invitation_status;
@@ -0,0 +1,23 @@
+-- Tenant invitations by email (accept/decline flow)
+DO $$ BEGIN
+    CREATE TYPE tenant_invitation_status AS ENUM ('pending', 'accepted', 'declined', 'revoked', 'expired');
+EXCEPTION WHEN duplicate_object THEN NULL; END $$;
+
+CREATE TABLE IF NOT EXISTS tenant_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,                -- invitee email (lower-cased)
+    role tenant_role NOT NULL DEFAULT 'member',
+    token_hash TEXT NOT NULL UNIQUE,    -- sha256 of the token sent by email
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    status tenant_invitation_status NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_invitations_tenant ON tenant_invitations(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_tenant_invitations_email ON tenant_invitations(email);
+-- only one pending invitation per tenant and email
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tenant_invitations_pending
+    ON tenant_invitations(tenant_id, email) WHERE status = 'pending';
@@ -0,0 +1,9 @@
+-- Tenant deletion: confirmation token (two-step request) and grace period before purge
+ALTER TABLE tenants
+  ADD COLUMN IF NOT EXISTS deletion_token_hash TEXT,
+  ADD COLUMN IF NOT EXISTS deletion_token_expires_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS deletion_requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_tenants_deletion_scheduled
+  ON tenants(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_auth_rate_limits_window;
+DROP TABLE IF EXISTS auth_rate_limits;
+
+DROP INDEX IF EXISTS idx_password_reset_tokens_user;
+DROP TABLE IF EXISTS password_reset_tokens;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_email_verification_tokens_user;
+DROP TABLE IF EXISTS email_verification_tokens;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_login_challenges_user;
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
@@ -0,0 +1,12 @@
+-- External (OpenID Connect) identities linked to users: logins are matched by
+-- (provider, subject), not by email
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email TEXT NOT NULL DEFAULT '',      -- email at the provider when linked, informational
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_login_at TIMESTAMPTZ,
+    PRIMARY KEY (provider, subject),
+    UNIQUE (user_id, provider)
+);
@@ -0,0 +1,15 @@
+-- Security log of sign-in attempts (password, second factor, external providers)
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,  -- NULL for unknown emails
+    email TEXT NOT NULL DEFAULT '',
+    method TEXT NOT NULL,                                 -- 'password', 'two_factor' or provider name
+    status TEXT NOT NULL CHECK (status IN ('success', 'failed', 'blocked', 'two_factor_required')),
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_user_created ON login_attempts(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_email_created ON login_attempts(email, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip_created ON login_attempts(ip_address, created_at DESC);
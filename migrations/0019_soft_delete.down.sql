@@ -0,0 +1,14 @@
+-- audit_events keeps the 'restored' action: recorded events can't be rewritten
+
+DELETE FROM transactions WHERE deleted_at IS NOT NULL;
+DELETE FROM categories c WHERE c.deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.category_id = c.id);
+UPDATE categories SET deleted_at = NULL WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS uq_categories_tenant_kind_code_live;
+ALTER TABLE categories ADD CONSTRAINT categories_tenant_id_kind_code_key UNIQUE (tenant_id, kind, code);
+
+DROP INDEX IF EXISTS idx_categories_tenant_deleted;
+DROP INDEX IF EXISTS idx_transactions_tenant_deleted;
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+-- Soft deletion: removed transactions and categories stay in the trash until the retention purge
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_tenant_deleted ON transactions(tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_categories_tenant_deleted ON categories(tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- A code is unique among live categories only, so a deleted code can be reused
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_tenant_id_kind_code_key;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_categories_tenant_kind_code_live ON categories(tenant_id, kind, code) WHERE deleted_at IS NULL;
+
+ALTER TABLE audit_events DROP CONSTRAINT IF EXISTS audit_events_action_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_action_check CHECK (action IN ('created', 'updated', 'deleted', 'restored'));
@@ -0,0 +1,25 @@
+-- Back to amounts divided by 100 whatever the currency (see the up migration)
+CREATE TEMP TABLE currency_exponents (code VARCHAR(3) PRIMARY KEY, exponent INT NOT NULL);
+INSERT INTO currency_exponents (code, exponent) VALUES
+    ('BIF', 0), ('CLP', 0), ('DJF', 0), ('GNF', 0), ('ISK', 0), ('JPY', 0), ('KMF', 0), ('KRW', 0), ('PYG', 0),
+    ('RWF', 0), ('UGX', 0), ('UYI', 0), ('VND', 0), ('VUV', 0), ('XAF', 0), ('XOF', 0), ('XPF', 0),
+    ('BHD', 3), ('IQD', 3), ('JOD', 3), ('KWD', 3), ('LYD', 3), ('OMR', 3), ('TND', 3),
+    ('CLF', 4), ('UYW', 4),
+    ('BTC', 8), ('ETH', 8);
+
+UPDATE transactions t SET amount_numeric = t.amount_numeric * power(10::numeric, e.exponent - 2)
+  FROM currency_exponents e WHERE e.code = t.currency_code;
+UPDATE transactions t SET base_amount_numeric = t.base_amount_numeric * power(10::numeric, e.exponent - 2)
+  FROM currency_exponents e WHERE e.code = t.base_currency_code AND t.fx_rate IS NULL;
+UPDATE transactions t SET base_amount_numeric = round(t.amount_numeric * t.fx_rate, 2)
+ WHERE t.fx_rate IS NOT NULL
+   AND (t.currency_code IN (SELECT code FROM currency_exponents) OR t.base_currency_code IN (SELECT code FROM currency_exponents));
+
+DROP TABLE currency_exponents;
+
+ALTER TABLE fx_rates ALTER COLUMN rate TYPE NUMERIC(18,8);
+
+ALTER TABLE transactions
+    ALTER COLUMN fx_rate TYPE NUMERIC(18,8),
+    ALTER COLUMN base_amount_numeric TYPE NUMERIC(18,2),
+    ALTER COLUMN amount_numeric TYPE NUMERIC(18,2);
@@ -0,0 +1,32 @@
+-- Amounts keep the precision of their currency (JPY 0, USD 2, KWD 3, BTC 8 decimals);
+-- rates need more digits for pairs like JPY/BTC
+ALTER TABLE transactions
+    ALTER COLUMN amount_numeric TYPE NUMERIC(28,8),
+    ALTER COLUMN base_amount_numeric TYPE NUMERIC(28,8),
+    ALTER COLUMN fx_rate TYPE NUMERIC(30,12);
+
+ALTER TABLE fx_rates ALTER COLUMN rate TYPE NUMERIC(30,12);
+
+-- Until now minor units were divided by 100 whatever the currency: 500 JPY was stored as 5.00 and
+-- 1.250 KWD as 12.50. Rescale amounts of currencies whose exponent is not 2 to major units.
+-- The list mirrors internal/domain/currencies.csv.
+CREATE TEMP TABLE currency_exponents (code VARCHAR(3) PRIMARY KEY, exponent INT NOT NULL);
+INSERT INTO currency_exponents (code, exponent) VALUES
+    ('BIF', 0), ('CLP', 0), ('DJF', 0), ('GNF', 0), ('ISK', 0), ('JPY', 0), ('KMF', 0), ('KRW', 0), ('PYG', 0),
+    ('RWF', 0), ('UGX', 0), ('UYI', 0), ('VND', 0), ('VUV', 0), ('XAF', 0), ('XOF', 0), ('XPF', 0),
+    ('BHD', 3), ('IQD', 3), ('JOD', 3), ('KWD', 3), ('LYD', 3), ('OMR', 3), ('TND', 3),
+    ('CLF', 4), ('UYW', 4),
+    ('BTC', 8), ('ETH', 8);
+
+UPDATE transactions t SET amount_numeric = t.amount_numeric * power(10::numeric, 2 - e.exponent)
+  FROM currency_exponents e WHERE e.code = t.currency_code;
+UPDATE transactions t SET base_amount_numeric = t.base_amount_numeric * power(10::numeric, 2 - e.exponent)
+  FROM currency_exponents e WHERE e.code = t.base_currency_code AND t.fx_rate IS NULL;
+-- converted amounts were computed from the misscaled amount: recompute them with the base exponent
+UPDATE transactions t
+   SET base_amount_numeric = round(t.amount_numeric * t.fx_rate,
+       COALESCE((SELECT exponent FROM currency_exponents WHERE code = t.base_currency_code), 2))
+ WHERE t.fx_rate IS NOT NULL
+   AND (t.currency_code IN (SELECT code FROM currency_exponents) OR t.base_currency_code IN (SELECT code FROM currency_exponents));
+
+DROP TABLE currency_exponents;
@@ -0,0 +1,2 @@
+-- Currencies a tenant actually uses; an empty list allows every ISO 4217 code
+ALTER TABLE tenants ADD COLUMN currency_codes TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+-- Keyset pagination walks (occurred_at, id) in either direction
+CREATE INDEX IF NOT EXISTS idx_transactions_keyset ON transactions(tenant_id, occurred_at, id) WHERE deleted_at IS NULL;
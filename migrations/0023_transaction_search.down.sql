@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_category_i18n_name_trgm;
+DROP INDEX IF EXISTS idx_category_i18n_search;
+ALTER TABLE category_i18n DROP COLUMN IF EXISTS search_tsv;
+
+DROP INDEX IF EXISTS idx_transactions_comment_trgm;
+DROP INDEX IF EXISTS idx_transactions_search;
+ALTER TABLE transactions DROP COLUMN IF EXISTS search_tsv;
+-- pg_trgm is left installed: other objects may depend on it
@@ -0,0 +1,17 @@
+-- Full-text search over comments and localized category names (Russian and English stemming)
+-- plus trigram indexes for substring matches of partial words
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS search_tsv tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('russian', coalesce(comment, '')) || to_tsvector('english', coalesce(comment, ''))
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_transactions_search ON transactions USING GIN (search_tsv);
+CREATE INDEX IF NOT EXISTS idx_transactions_comment_trgm ON transactions USING GIN (comment gin_trgm_ops);
+
+ALTER TABLE category_i18n ADD COLUMN IF NOT EXISTS search_tsv tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('russian', name) || to_tsvector('english', name)
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_category_i18n_search ON category_i18n USING GIN (search_tsv);
+CREATE INDEX IF NOT EXISTS idx_category_i18n_name_trgm ON category_i18n USING GIN (name gin_trgm_ops);
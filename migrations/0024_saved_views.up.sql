@@ -0,0 +1,14 @@
+-- Saved transaction filters: personal (user_id set) or shared with the tenant (user_id NULL)
+CREATE TABLE IF NOT EXISTS saved_views (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    sort TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_saved_views_personal_name ON saved_views(tenant_id, user_id, lower(name)) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_saved_views_shared_name ON saved_views(tenant_id, lower(name)) WHERE user_id IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS fired_alerts;
+DROP TABLE IF EXISTS alert_rules;
@@ -0,0 +1,35 @@
+-- Alert rules of a tenant and the history of alerts they fired
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('category_monthly_limit', 'large_expense', 'income_below_last_month', 'no_transactions')),
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    threshold_minor BIGINT NOT NULL DEFAULT 0,   -- minor units of the tenant's default currency
+    days INT NOT NULL DEFAULT 0,
+    channels TEXT[] NOT NULL,
+    telegram_chat_id TEXT NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_tenant ON alert_rules(tenant_id);
+
+-- One row per rule and period: the unique key keeps an alert from firing twice
+CREATE TABLE IF NOT EXISTS fired_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    rule_id UUID NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    period_key TEXT NOT NULL,
+    message TEXT NOT NULL,
+    value_minor BIGINT NOT NULL DEFAULT 0,
+    value_currency TEXT NOT NULL DEFAULT '',
+    notify_error TEXT NOT NULL DEFAULT '',
+    fired_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (rule_id, period_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fired_alerts_tenant ON fired_alerts(tenant_id, fired_at DESC);
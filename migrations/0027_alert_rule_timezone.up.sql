@@ -0,0 +1,2 @@
+-- Months of monthly alert rules are counted in the rule's timezone
+ALTER TABLE alert_rules ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/positron48/budget/internal/domain"
+)
+
+// The rescale in 0020 lists the currencies whose exponent is not 2; it must match the registry.
+func TestCurrencyScale_ExponentsMatchRegistry(t *testing.T) {
+	want := map[string]int{}
+	for _, c := range domain.Currencies() {
+		if c.Exponent != domain.DefaultCurrencyExponent {
+			want[c.Code] = c.Exponent
+		}
+	}
+	for _, name := range []string{"0020_currency_scale.up.sql", "0020_currency_scale.down.sql"} {
+		sql, err := FS.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]int{}
+		for _, m := range regexp.MustCompile(`\('([A-Z]{3})', (\d)\)`).FindAllStringSubmatch(string(sql), -1) {
+			got[m[1]], _ = strconv.Atoi(m[2])
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s lists %d currencies, registry has %d", name, len(got), len(want))
+		}
+		for code, exp := range want {
+			if got[code] != exp {
+				t.Errorf("%s: %s exponent %d, registry %d", name, code, got[code], exp)
+			}
+		}
+	}
+}
@@ -21,11 +21,13 @@ import {
 import type { IconName } from "@/components/Icon";
 import { TransactionType } from "@/proto/budget/v1/common_pb";
 import { formatCurrency, formatAmountWithSpaces } from "@/lib/utils";
+import { minorToMajor, useCurrencyExponents } from "@/lib/currency";
 import { chartPalettes } from "@/lib/theme/colors";
 import NewTransactionForm, { NewTxFormRef } from "./transactions/NewTransactionForm";
 
 function DashboardInner() {
   const { report, transaction } = useClients();
+  const currencyVersion = useCurrencyExponents();
   const t = useTranslations("home");
   const tt = useTranslations("transactions");
   const tc = useTranslations("common");
@@ -68,7 +70,7 @@ function DashboardInner() {
     () => monthly?.totalIncome?.currencyCode || monthly?.totalExpense?.currencyCode || "RUB",
     [monthly]
   );
-  // Totals are kept in minor units; formatCurrency divides by 100 for display.
+  // Totals are kept in minor units; formatCurrency scales them by the currency exponent for display.
   const sum = (arr: any[]) =>
     arr.reduce((s, it) => s + Math.abs(Number(it?.total?.minorUnits ?? 0)), 0);
   const expensesItems = useMemo(
@@ -94,10 +96,10 @@ function DashboardInner() {
         .slice(0, 8)
         .map((it, idx) => ({
           label: `${it?.categoryName ?? it?.categoryId}`,
-          value: Math.abs(Number(it?.total?.minorUnits ?? 0) / 100),
+          value: Math.abs(minorToMajor(it?.total?.minorUnits ?? 0, it?.total?.currencyCode)),
           color: warm[idx % warm.length],
         })),
-    [expensesItems, warm]
+    [expensesItems, warm, currencyVersion]
   );
 
   const recentItems = useMemo(() => (recent?.items ?? []) as any[], [recent]);
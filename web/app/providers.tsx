@@ -1,10 +1,11 @@
 "use client";
 
-import { createContext, useContext, useMemo, useState } from "react";
-import { QueryClient, QueryClientProvider } from "@tanstack/react-query";
+import { createContext, useContext, useEffect, useMemo, useState } from "react";
+import { QueryClient, QueryClientProvider, useQuery } from "@tanstack/react-query";
 import { createTransport } from "@/lib/api/transport";
 import { createClients } from "@/lib/api/clients";
 import { authStore } from "@/lib/auth/store";
+import { setCurrencyExponents } from "@/lib/currency";
 import { authInterceptor, tenantInterceptor, loggingInterceptor, refreshAuthInterceptor } from "@/lib/api/interceptors";
 
 const ClientsContext = createContext<any>(null);
@@ -30,7 +31,10 @@ export function ClientsProvider({ children }: { children: React.ReactNode }) {
   const clients = useMemo(() => createClients(transport) as any, [transport]);
   return (
     <QueryClientProvider client={queryClient}>
-      <ClientsContext.Provider value={clients}>{children}</ClientsContext.Provider>
+      <ClientsContext.Provider value={clients}>
+        <CurrencyExponentsLoader />
+        {children}
+      </ClientsContext.Provider>
     </QueryClientProvider>
   );
 }
@@ -42,3 +46,20 @@ export function useClients(): any {
 }
 
 
+
+// Загружает экспоненты валют для форматирования минорных единиц (см. lib/currency)
+function CurrencyExponentsLoader() {
+  const { currency } = useClients();
+  const { data } = useQuery({
+    queryKey: ["currency-exponents"],
+    queryFn: async () => (await currency.listCurrencies({ locale: "en" } as any)) as any,
+    enabled: Boolean(authStore.getAccess()),
+    staleTime: Infinity,
+    retry: false,
+    refetchOnWindowFocus: false,
+  });
+  useEffect(() => {
+    if (data?.currencies) setCurrencyExponents(data.currencies);
+  }, [data]);
+  return null;
+}
@@ -23,6 +23,7 @@ import {
   CombinedChart
 } from "@/components";
 import { formatAmountWithSpaces, formatDateLocal } from "@/lib/utils";
+import { minorToMajor, useCurrencyExponents } from "@/lib/currency";
 import { getCategoryColor } from "@/lib/categoryColors";
 import { useLocale } from "next-intl";
 import { chartPalettes } from "@/lib/theme/colors";
@@ -30,6 +31,7 @@ import { chartPalettes } from "@/lib/theme/colors";
 // Monthly Report Component
 function MonthlyReportInner() {
   const { report } = useClients();
+  const currencyVersion = useCurrencyExponents();
   const t = useTranslations("reports");
   const tc = useTranslations("common");
   const today = useMemo(() => new Date(), []);
@@ -80,9 +82,9 @@ function MonthlyReportInner() {
   const expenses = useMemo(() => items.filter((it) => it?.type === TransactionType.EXPENSE), [items]);
   const incomes = useMemo(() => items.filter((it) => it?.type === TransactionType.INCOME), [items]);
   const currencyCode = useMemo(() => data?.totalIncome?.currencyCode || data?.totalExpense?.currencyCode || "", [data]);
-  const sumAmount = (arr: any[]) => arr.reduce((s, it) => s + Math.abs(Number(it?.total?.minorUnits ?? 0) / 100), 0);
-  const totalExpenses = useMemo(() => sumAmount(expenses), [expenses]);
-  const totalIncomes = useMemo(() => sumAmount(incomes), [incomes]);
+  const sumAmount = (arr: any[]) => arr.reduce((s, it) => s + Math.abs(minorToMajor(it?.total?.minorUnits ?? 0, it?.total?.currencyCode)), 0);
+  const totalExpenses = useMemo(() => sumAmount(expenses), [expenses, currencyVersion]);
+  const totalIncomes = useMemo(() => sumAmount(incomes), [incomes, currencyVersion]);
   const expensesSorted = useMemo(() =>
     [...expenses].sort((a, b) => Math.abs(Number(b?.total?.minorUnits ?? 0)) - Math.abs(Number(a?.total?.minorUnits ?? 0))),
     [expenses]
@@ -173,7 +175,7 @@ function MonthlyReportInner() {
             <Card>
               <CardContent className="text-center pt-6">
                 <div className="text-2xl font-bold text-[hsl(var(--positive))]">
-                  {formatAmountWithSpaces(Number(data?.totalIncome?.minorUnits ?? 0) / 100)} {data?.totalIncome?.currencyCode ?? ""}
+                  {formatAmountWithSpaces(minorToMajor(data?.totalIncome?.minorUnits ?? 0, data?.totalIncome?.currencyCode))} {data?.totalIncome?.currencyCode ?? ""}
                 </div>
                 <div className="text-sm text-muted-foreground">{t("totalIncome")}</div>
               </CardContent>
@@ -181,7 +183,7 @@ function MonthlyReportInner() {
             <Card>
               <CardContent className="text-center pt-6">
                 <div className="text-2xl font-bold text-[hsl(var(--negative))]">
-                  {formatAmountWithSpaces(Number(data?.totalExpense?.minorUnits ?? 0) / 100)} {data?.totalExpense?.currencyCode ?? ""}
+                  {formatAmountWithSpaces(minorToMajor(data?.totalExpense?.minorUnits ?? 0, data?.totalExpense?.currencyCode))} {data?.totalExpense?.currencyCode ?? ""}
                 </div>
                 <div className="text-sm text-muted-foreground">{t("totalExpense")}</div>
               </CardContent>
@@ -195,7 +197,7 @@ function MonthlyReportInner() {
                       : "text-[hsl(var(--negative))]"
                   }`}
                 >
-                  {formatAmountWithSpaces((Number(data?.totalIncome?.minorUnits ?? 0) - Number(data?.totalExpense?.minorUnits ?? 0)) / 100)} {data?.totalIncome?.currencyCode ?? ""}
+                  {formatAmountWithSpaces(minorToMajor(Number(data?.totalIncome?.minorUnits ?? 0) - Number(data?.totalExpense?.minorUnits ?? 0), data?.totalIncome?.currencyCode))} {data?.totalIncome?.currencyCode ?? ""}
                 </div>
                 <div className="text-sm text-muted-foreground">{t("netIncome")}</div>
               </CardContent>
@@ -222,7 +224,7 @@ function MonthlyReportInner() {
                     <DonutChart
                         data={expensesSorted.map((it: any, idx: number) => ({
                           label: `${it?.categoryName ?? it?.categoryId}`,
-                          value: Math.abs(Number(it?.total?.minorUnits ?? 0) / 100),
+                          value: Math.abs(minorToMajor(it?.total?.minorUnits ?? 0, it?.total?.currencyCode)),
                           color: warmPalette[idx % warmPalette.length],
                       }))}
                         className="flex flex-col items-center"
@@ -242,7 +244,7 @@ function MonthlyReportInner() {
                     <DonutChart
                         data={incomesSorted.map((it: any, idx: number) => ({
                           label: `${it?.categoryName ?? it?.categoryId}`,
-                          value: Math.abs(Number(it?.total?.minorUnits ?? 0) / 100),
+                          value: Math.abs(minorToMajor(it?.total?.minorUnits ?? 0, it?.total?.currencyCode)),
                           color: coolPalette[idx % coolPalette.length],
                       }))}
                         className="flex flex-col items-center"
@@ -271,7 +273,7 @@ function MonthlyReportInner() {
                         </thead>
                         <tbody>
                           {expensesSorted.map((it: any, i: number) => {
-                            const val = Math.abs(Number(it?.total?.minorUnits ?? 0) / 100);
+                            const val = Math.abs(minorToMajor(it?.total?.minorUnits ?? 0, it?.total?.currencyCode));
                             const pct = totalExpenses ? Math.round((val / totalExpenses) * 100) : 0;
                             return (
                               <tr key={`exp-${i}`} className="border-b">
@@ -312,7 +314,7 @@ function MonthlyReportInner() {
                         </thead>
                         <tbody>
                           {incomesSorted.map((it: any, i: number) => {
-                            const val = Math.abs(Number(it?.total?.minorUnits ?? 0) / 100);
+                            const val = Math.abs(minorToMajor(it?.total?.minorUnits ?? 0, it?.total?.currencyCode));
                             const pct = totalIncomes ? Math.round((val / totalIncomes) * 100) : 0;
                             return (
                               <tr key={`inc-${i}`} className="border-b">
@@ -348,6 +350,7 @@ function MonthlyReportInner() {
 // Summary Report Component
 function SummaryReportInner() {
   const { report } = useClients();
+  const currencyVersion = useCurrencyExponents();
   const t = useTranslations("reports");
   const tc = useTranslations("common");
   
@@ -396,29 +399,29 @@ function SummaryReportInner() {
       name: cat.categoryName || cat.categoryId,
       color: getCategoryColor(cat.categoryName || cat.categoryId, 'expense'),
       values: (cat.monthlyTotals || []).map((money: any) => {
-        const value = Number(money?.minorUnits ?? 0) / 100;
+        const value = minorToMajor(money?.minorUnits ?? 0, money?.currencyCode);
         // Для расходов берем абсолютное значение, так как они отрицательные
         return Math.abs(value);
       }),
-      total: Math.abs(Number(cat?.total?.minorUnits ?? 0) / 100),
+      total: Math.abs(minorToMajor(cat?.total?.minorUnits ?? 0, cat?.total?.currencyCode)),
     }));
     
     return result;
-  }, [expenses]);
+  }, [expenses, currencyVersion]);
 
   const incomesData = useMemo(() => {
     return incomes.map((cat: any) => ({
       id: cat.categoryId,
       name: cat.categoryName || cat.categoryId,
       color: getCategoryColor(cat.categoryName || cat.categoryId, 'income'),
-      values: (cat.monthlyTotals || []).map((money: any) => Math.abs(Number(money?.minorUnits ?? 0) / 100)),
-      total: Math.abs(Number(cat?.total?.minorUnits ?? 0) / 100),
+      values: (cat.monthlyTotals || []).map((money: any) => Math.abs(minorToMajor(money?.minorUnits ?? 0, money?.currencyCode))),
+      total: Math.abs(minorToMajor(cat?.total?.minorUnits ?? 0, cat?.total?.currencyCode)),
     }));
-  }, [incomes]);
+  }, [incomes, currencyVersion]);
 
   const totalExpensesValue = useMemo(
-    () => Math.abs(Number(data?.totalExpense?.minorUnits ?? 0) / 100),
-    [data]
+    () => Math.abs(minorToMajor(data?.totalExpense?.minorUnits ?? 0, data?.totalExpense?.currencyCode)),
+    [data, currencyVersion]
   );
 
   const expensesDonutData = useMemo(
@@ -481,7 +484,7 @@ function SummaryReportInner() {
             <Card>
               <CardContent className="text-center pt-6">
                 <div className="text-2xl font-bold text-[hsl(var(--positive))]">
-                  {formatAmountWithSpaces(Number(data?.totalIncome?.minorUnits ?? 0) / 100)} {data?.totalIncome?.currencyCode ?? ""}
+                  {formatAmountWithSpaces(minorToMajor(data?.totalIncome?.minorUnits ?? 0, data?.totalIncome?.currencyCode))} {data?.totalIncome?.currencyCode ?? ""}
                 </div>
                 <div className="text-sm text-muted-foreground">{t("totalIncome")}</div>
               </CardContent>
@@ -489,7 +492,7 @@ function SummaryReportInner() {
             <Card>
               <CardContent className="text-center pt-6">
                 <div className="text-2xl font-bold text-[hsl(var(--negative))]">
-                  {formatAmountWithSpaces(Number(data?.totalExpense?.minorUnits ?? 0) / 100)} {data?.totalExpense?.currencyCode ?? ""}
+                  {formatAmountWithSpaces(minorToMajor(data?.totalExpense?.minorUnits ?? 0, data?.totalExpense?.currencyCode))} {data?.totalExpense?.currencyCode ?? ""}
                 </div>
                 <div className="text-sm text-muted-foreground">{t("totalExpense")}</div>
               </CardContent>
@@ -503,7 +506,7 @@ function SummaryReportInner() {
                       : "text-[hsl(var(--negative))]"
                   }`}
                 >
-                  {formatAmountWithSpaces((Number(data?.totalIncome?.minorUnits ?? 0) - Number(data?.totalExpense?.minorUnits ?? 0)) / 100)} {data?.totalIncome?.currencyCode ?? ""}
+                  {formatAmountWithSpaces(minorToMajor(Number(data?.totalIncome?.minorUnits ?? 0) - Number(data?.totalExpense?.minorUnits ?? 0), data?.totalIncome?.currencyCode))} {data?.totalIncome?.currencyCode ?? ""}
                 </div>
                 <div className="text-sm text-muted-foreground">{t("netIncome")}</div>
               </CardContent>
@@ -6,6 +6,7 @@ import { Button, Icon } from "@/components";
 import { useLocale, useTranslations } from "next-intl";
 import { useQuery, useQueryClient } from "@tanstack/react-query";
 import { CategoryKind, TransactionType } from "@/proto/budget/v1/common_pb";
+import { majorToMinor } from "@/lib/currency";
 import CategoryMapping from "./CategoryMapping";
 
 type ParsedCsv = { headers: string[]; rows: string[][] };
@@ -100,18 +101,18 @@ function guessMapping(headers: string[]): CsvMapping {
   };
 }
 
-function parseAmountToMinorUnits(raw: string): number | null {
+function parseAmountToMinorUnits(raw: string, currencyCode?: string): number | null {
   if (!raw) return null;
   // Extract numeric part at the start, allowing spaces, thousand seps, comma/dot decimals
   const trimmed = String(raw).trim();
-  const match = trimmed.match(/^([+\-]?\s*[0-9\s]+(?:[\.,][0-9]{1,2})?)/);
+  const match = trimmed.match(/^([+\-]?\s*[0-9\s]+(?:[\.,][0-9]{1,8})?)/);
   if (!match) return null;
   const numericPart = match[1];
   const noSpaces = numericPart.replace(/\s+/g, "");
   const normalized = noSpaces.replace(/,/g, ".");
   const val = Number(normalized);
   if (Number.isNaN(val)) return null;
-  return Math.round(val * 100);
+  return majorToMinor(val, currencyCode);
 }
 
 function parseDateToSeconds(raw: string): number | null {
@@ -440,11 +441,11 @@ export default function ImportWizard({ onClose, onCompleted }: Props) {
       };
       for (let i = 0; i < parsed.rows.length; i++) {
         const r = parsed.rows[i];
-        const amountMinorUnitsRaw = parseAmountToMinorUnits(idx.amount >= 0 ? r[idx.amount] : "");
+        const currencyCode = idx.currency >= 0 ? (r[idx.currency] || defaultCurrency) : defaultCurrency;
+        const amountMinorUnitsRaw = parseAmountToMinorUnits(idx.amount >= 0 ? r[idx.amount] : "", currencyCode);
         const seconds = parseDateToSeconds(idx.date >= 0 ? r[idx.date] : "");
         const tp = toTransactionType(idx.type >= 0 ? r[idx.type] : null, amountMinorUnitsRaw);
         if (amountMinorUnitsRaw == null || seconds == null || tp == null) continue;
-        const currencyCode = idx.currency >= 0 ? (r[idx.currency] || defaultCurrency) : defaultCurrency;
         const catNameRaw = idx.category >= 0 ? (r[idx.category] || "") : "";
         const catName = catNameRaw.trim();
         const norm = normalizeName(catName);
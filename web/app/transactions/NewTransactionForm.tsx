@@ -6,6 +6,7 @@ import { z } from "zod";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { useQuery, useQueryClient } from "@tanstack/react-query";
 import { useClients } from "@/app/providers";
+import { majorToMinor } from "@/lib/currency";
 import { TransactionType, CategoryKind } from "@/proto/budget/v1/common_pb";
 import { Icon, CategorySingleInput, Select } from "@/components";
 import { useTranslations } from "next-intl";
@@ -75,7 +76,7 @@ const NewTransactionForm = forwardRef<NewTxFormRef, Props>(function NewTransacti
   const submitInternal = useMemo(() => async (v: FormValues) => {
       const payload: any = {
         type: Number(v.type),
-        amount: { currencyCode: v.currencyCode, minorUnits: Math.round(v.amount * 100) },
+        amount: { currencyCode: v.currencyCode, minorUnits: majorToMinor(v.amount, v.currencyCode) },
         occurredAt: { seconds: Math.floor(new Date(v.occurredAt).getTime() / 1000) },
       };
       if (v.categoryId) payload.categoryId = v.categoryId;
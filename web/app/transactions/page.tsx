@@ -11,12 +11,15 @@ import ImportWizard from "./ImportWizard";
 import NewTransactionForm, { NewTxFormRef } from "./NewTransactionForm";
 import FiltersForm from "@/components/FiltersForm";
 import { formatCurrency } from "@/lib/utils";
+import { majorToMinor, minorToMajor, useCurrencyExponents } from "@/lib/currency";
 
 const SURFACE_CARD = "rounded-lg border border-border bg-card/80 backdrop-blur supports-[backdrop-filter]:bg-card/60";
 const PANEL_CARD = "rounded-lg border border-border bg-secondary/40";
 
 function TransactionsInner() {
   const { transaction, category } = useClients();
+  // amounts are re-rendered with the server exponents once they are loaded
+  useCurrencyExponents();
   const t = useTranslations("transactions");
   const tc = useTranslations("common");
   const locale = useLocale();
@@ -790,7 +793,7 @@ function TransactionTable({
                           updateMut.mutate({
                               id: tx.id as string,
                               comment: editComment,
-                              amountMinorUnits: editAmount ? Math.round(parseFloat(editAmount.replace(',', '.')) * 100) : undefined,
+                              amountMinorUnits: editAmount ? majorToMinor(parseFloat(editAmount.replace(',', '.')), tx?.amount?.currencyCode) : undefined,
                               categoryId: editCategoryId === "" ? undefined : editCategoryId,
                               occurredAt: editDate ? { seconds: Math.floor(new Date(editDate).getTime() / 1000) } : undefined,
                               isExtraordinary: editIsExtraordinary,
@@ -813,7 +816,7 @@ function TransactionTable({
                         onClick={() => {
                           setEditingId(tx?.id);
                           setEditComment(tx?.comment ?? "");
-                          setEditAmount(tx?.amount?.minorUnits ? (Number(tx.amount.minorUnits) / 100).toString() : "");
+                          setEditAmount(tx?.amount?.minorUnits ? minorToMajor(tx.amount.minorUnits, tx.amount.currencyCode).toString() : "");
                           setEditCategoryId(tx?.categoryId ?? "");
                           setEditIsExtraordinary(Boolean(tx?.isExtraordinary));
                           const toLocalInput = (d: Date) => {
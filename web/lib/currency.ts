@@ -0,0 +1,51 @@
+import { useSyncExternalStore } from "react";
+
+/**
+ * Минорные единицы валют: сумма хранится как целое число, умноженное на 10^exponent
+ * (USD 2, JPY 0, KWD 3, BTC 8). Экспоненты приходят из CurrencyService.ListCurrencies;
+ * пока список не загружен (и для кодов вне каталога) используется ISO 4217 из Intl.
+ */
+const exponents = new Map<string, number>();
+const listeners = new Set<() => void>();
+let version = 0;
+
+export function setCurrencyExponents(currencies: { code: string; exponent: number }[]) {
+  for (const c of currencies) {
+    if (c?.code) exponents.set(c.code.toUpperCase(), Number(c.exponent ?? 2));
+  }
+  version++;
+  listeners.forEach((l) => l());
+}
+
+export function currencyExponent(currencyCode: string = "RUB"): number {
+  const code = (currencyCode || "RUB").toUpperCase();
+  const known = exponents.get(code);
+  if (known !== undefined) return known;
+  try {
+    return new Intl.NumberFormat("en", { style: "currency", currency: code }).resolvedOptions().maximumFractionDigits ?? 2;
+  } catch {
+    return 2;
+  }
+}
+
+/** Переводит минорные единицы в сумму в валюте: 12345 USD → 123.45, 500 JPY → 500 */
+export function minorToMajor(minorUnits: number | bigint, currencyCode?: string): number {
+  return Number(minorUnits) / 10 ** currencyExponent(currencyCode);
+}
+
+/** Переводит сумму в валюте в минорные единицы с округлением до экспоненты валюты */
+export function majorToMinor(amount: number, currencyCode?: string): number {
+  return Math.round(amount * 10 ** currencyExponent(currencyCode));
+}
+
+/** Перерисовывает компонент, когда загружены экспоненты валют */
+export function useCurrencyExponents(): number {
+  return useSyncExternalStore(
+    (listener) => {
+      listeners.add(listener);
+      return () => listeners.delete(listener);
+    },
+    () => version,
+    () => version
+  );
+}
@@ -1,4 +1,5 @@
 import { TransactionType } from "@/proto/budget/v1/common_pb";
+import { currencyExponent, minorToMajor } from "@/lib/currency";
 
 export interface ExportTransaction {
   id: string;
@@ -45,9 +46,8 @@ export function exportTransactionsToCsv(transactions: ExportTransaction[], local
     const minorUnits = typeof tx.amount.minorUnits === 'bigint' 
       ? Number(tx.amount.minorUnits) 
       : tx.amount.minorUnits;
-    const amount = (minorUnits / 100).toFixed(2);
-    
     const currency = tx.amount.currencyCode || "RUB";
+    const amount = minorToMajor(minorUnits, currency).toFixed(currencyExponent(currency));
     
     const category = tx.categoryName || tx.categoryCode || "";
     
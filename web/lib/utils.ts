@@ -1,17 +1,20 @@
 import { type ClassValue, clsx } from "clsx";
 import { twMerge } from "tailwind-merge";
+import { currencyExponent, minorToMajor } from "@/lib/currency";
 
 export function cn(...inputs: ClassValue[]) {
   return twMerge(clsx(inputs));
 }
 
+// amount — в минорных единицах валюты (см. lib/currency)
 export function formatCurrency(amount: number, currencyCode: string = "RUB"): string {
+  const digits = currencyExponent(currencyCode);
   const formattedAmount = new Intl.NumberFormat('ru-RU', {
     style: 'currency',
     currency: currencyCode,
-    minimumFractionDigits: 2,
-    maximumFractionDigits: 2,
-  }).format(amount / 100);
+    minimumFractionDigits: digits,
+    maximumFractionDigits: digits,
+  }).format(minorToMajor(amount, currencyCode));
   
   return formattedAmount;
 }
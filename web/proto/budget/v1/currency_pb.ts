@@ -0,0 +1,128 @@
+// @generated by protoc-gen-es v2.8.0 with parameter "target=ts,import_extension=none"
+// @generated from file budget/v1/currency.proto (package budget.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file budget/v1/currency.proto.
+ */
+export const file_budget_v1_currency: GenFile = /*@__PURE__*/
+  fileDesc("ChhidWRnZXQvdjEvY3VycmVuY3kucHJvdG8SCWJ1ZGdldC52MSJuCghDdXJyZW5jeRIMCgRjb2RlGAEgASgJEhQKDG51bWVyaWNfY29kZRgCIAEoCRIQCghleHBvbmVudBgDIAEoBRIMCgRuYW1lGAQgASgJEg4KBnN5bWJvbBgFIAEoCRIOCgZwaW5uZWQYBiABKAgiPAoVTGlzdEN1cnJlbmNpZXNSZXF1ZXN0Eg4KBmxvY2FsZRgBIAEoCRITCgtwaW5uZWRfb25seRgCIAEoCCJBChZMaXN0Q3VycmVuY2llc1Jlc3BvbnNlEicKCmN1cnJlbmNpZXMYASADKAsyEy5idWRnZXQudjEuQ3VycmVuY3kyaAoPQ3VycmVuY3lTZXJ2aWNlElUKDkxpc3RDdXJyZW5jaWVzEiAuYnVkZ2V0LnYxLkxpc3RDdXJyZW5jaWVzUmVxdWVzdBohLmJ1ZGdldC52MS5MaXN0Q3VycmVuY2llc1Jlc3BvbnNlQjhaNmdpdGh1Yi5jb20vcG9zaXRyb240OC9idWRnZXQvZ2VuL2dvL2J1ZGdldC92MTtidWRnZXR2MWIGcHJvdG8z");
+
+/**
+ * ISO 4217 currency (or a crypto asset) from the built-in catalogue
+ *
+ * @generated from message budget.v1.Currency
+ */
+export type Currency = Message<"budget.v1.Currency"> & {
+  /**
+   * e.g. "USD"
+   *
+   * @generated from field: string code = 1;
+   */
+  code: string;
+
+  /**
+   * ISO 4217 numeric code, e.g. "840"; empty for crypto assets
+   *
+   * @generated from field: string numeric_code = 2;
+   */
+  numericCode: string;
+
+  /**
+   * digits in the minor unit: USD 2, JPY 0, KWD 3
+   *
+   * @generated from field: int32 exponent = 3;
+   */
+  exponent: number;
+
+  /**
+   * localized name
+   *
+   * @generated from field: string name = 4;
+   */
+  name: string;
+
+  /**
+   * e.g. "$", "₽"; may be empty
+   *
+   * @generated from field: string symbol = 5;
+   */
+  symbol: string;
+
+  /**
+   * pinned by the current tenant (see TenantService.SetTenantCurrencies)
+   *
+   * @generated from field: bool pinned = 6;
+   */
+  pinned: boolean;
+};
+
+/**
+ * Describes the message budget.v1.Currency.
+ * Use `create(CurrencySchema)` to create a new message.
+ */
+export const CurrencySchema: GenMessage<Currency> = /*@__PURE__*/
+  messageDesc(file_budget_v1_currency, 0);
+
+/**
+ * @generated from message budget.v1.ListCurrenciesRequest
+ */
+export type ListCurrenciesRequest = Message<"budget.v1.ListCurrenciesRequest"> & {
+  /**
+   * "ru", "en" (default)
+   *
+   * @generated from field: string locale = 1;
+   */
+  locale: string;
+
+  /**
+   * only currencies the current tenant accepts for new transactions
+   *
+   * @generated from field: bool pinned_only = 2;
+   */
+  pinnedOnly: boolean;
+};
+
+/**
+ * Describes the message budget.v1.ListCurrenciesRequest.
+ * Use `create(ListCurrenciesRequestSchema)` to create a new message.
+ */
+export const ListCurrenciesRequestSchema: GenMessage<ListCurrenciesRequest> = /*@__PURE__*/
+  messageDesc(file_budget_v1_currency, 1);
+
+/**
+ * @generated from message budget.v1.ListCurrenciesResponse
+ */
+export type ListCurrenciesResponse = Message<"budget.v1.ListCurrenciesResponse"> & {
+  /**
+   * @generated from field: repeated budget.v1.Currency currencies = 1;
+   */
+  currencies: Currency[];
+};
+
+/**
+ * Describes the message budget.v1.ListCurrenciesResponse.
+ * Use `create(ListCurrenciesResponseSchema)` to create a new message.
+ */
+export const ListCurrenciesResponseSchema: GenMessage<ListCurrenciesResponse> = /*@__PURE__*/
+  messageDesc(file_budget_v1_currency, 2);
+
+/**
+ * @generated from service budget.v1.CurrencyService
+ */
+export const CurrencyService: GenService<{
+  /**
+   * @generated from rpc budget.v1.CurrencyService.ListCurrencies
+   */
+  listCurrencies: {
+    methodKind: "unary";
+    input: typeof ListCurrenciesRequestSchema;
+    output: typeof ListCurrenciesResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_budget_v1_currency, 0);
+
@@ -1,5 +1,6 @@
 import { describe, it, expect } from "vitest";
 import { formatCurrency, formatDateLocal } from "@/lib/utils";
+import { majorToMinor, minorToMajor, setCurrencyExponents } from "@/lib/currency";
 
 describe('Utils', () => {
   describe('formatCurrency', () => {
@@ -22,6 +23,24 @@ describe('Utils', () => {
     it('handles very large numbers', () => {
       expect(formatCurrency(99999999900)).toMatch(/999\s*999\s*999,00\s*₽/); // 99999999900 копеек = 999999999 рублей
     });
+
+    it('uses the exponent of the currency', () => {
+      expect(formatCurrency(500, "JPY")).toMatch(/^500\s*[¥￥]$/); // иены без дробной части
+      expect(formatCurrency(1250, "KWD")).toMatch(/1,250/); // 1.250 динара
+      setCurrencyExponents([{ code: "BTC", exponent: 8 }]);
+      expect(formatCurrency(150000000, "BTC")).toMatch(/1,50000000/);
+    });
+  });
+
+  describe('minor units', () => {
+    it('converts with the currency exponent', () => {
+      expect(minorToMajor(12345, "USD")).toBe(123.45);
+      expect(minorToMajor(500, "JPY")).toBe(500);
+      expect(minorToMajor(BigInt(1250), "KWD")).toBe(1.25);
+      expect(majorToMinor(123.45, "USD")).toBe(12345);
+      expect(majorToMinor(500, "JPY")).toBe(500);
+      expect(majorToMinor(1.25, "KWD")).toBe(1250);
+    });
   });
 
   describe('formatDateLocal', () => {